@@ -3,11 +3,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path"
 	"path/filepath"
 	"strings"
 	"syscall"
@@ -106,6 +108,23 @@ func main() {
 	userWorkoutMovementRepo := repository.NewUserWorkoutMovementRepository(db)
 	userWorkoutWODRepo := repository.NewUserWorkoutWODRepository(db)
 	dataChangeLogRepo := repository.NewDataChangeLogRepository(db, cfg.Database.Driver)
+	coachAthleteRepo := repository.NewCoachAthleteRepository(db)
+	organizationRepo := repository.NewOrganizationRepository(db)
+	scheduledWorkoutRepo := repository.NewScheduledWorkoutRepository(db)
+	programRepo := repository.NewProgramRepository(db)
+	leaderboardRepo := repository.NewLeaderboardRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	fitnessStandardRepo := repository.NewFitnessStandardRepository(db)
+	bodyMetricRepo := repository.NewBodyMetricRepository(db)
+	goalRepo := repository.NewGoalRepository(db)
+	achievementRepo := repository.NewAchievementRepository(db)
+	wodStructureRepo := repository.NewWODStructureRepository(db)
+	nameAliasRepo := repository.NewNameAliasRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	enumerationRepo := repository.NewEnumerationRepository(db)
+	wodVersionRepo := repository.NewWODVersionRepository(db)
+	seedLibraryRepo := repository.NewSeedLibraryRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
 
 	// Initialize email service
 	var emailService *email.Service
@@ -170,9 +189,28 @@ func main() {
 		workoutWODRepo,
 	)
 
+	enumerationService := service.NewEnumerationService(enumerationRepo, dataChangeLogService)
+
 	wodService := service.NewWODService(wodRepo, dataChangeLogService)
+	wodService.SetEnumerationService(enumerationService)
+	wodStructureService := service.NewWODStructureService(wodStructureRepo, wodRepo, movementRepo)
+	wodService.SetStructureService(wodStructureService)
+	wodVersionService := service.NewWODVersionService(wodVersionRepo, wodRepo, dataChangeLogService)
+	wodVersionService.SetUserWorkoutService(userWorkoutService)
+	wodService.SetVersionService(wodVersionService)
+	userWorkoutService.SetWODVersionService(wodVersionService)
 
 	movementService := service.NewMovementService(movementRepo, dataChangeLogService)
+	movementService.SetEnumerationService(enumerationService)
+	aliasService := service.NewAliasService(nameAliasRepo, movementRepo, wodRepo, dataChangeLogService)
+	aliasService.SetUserWorkoutService(userWorkoutService)
+
+	searchService := service.NewSearchService(searchRepo)
+	wodService.SetSearchService(searchService)
+	movementService.SetSearchService(searchService)
+	workoutTemplateService.SetSearchService(searchService)
+	userWorkoutService.SetSearchService(searchService)
+	aliasService.SetSearchService(searchService)
 
 	workoutWODService := service.NewWorkoutWODService(
 		workoutWODRepo,
@@ -182,15 +220,142 @@ func main() {
 
 	userSettingsService := service.NewUserSettingsService(userSettingsRepo)
 
+	coachService := service.NewCoachService(
+		coachAthleteRepo,
+		userRepo,
+		userWorkoutRepo,
+		userWorkoutMovementRepo,
+		userWorkoutWODRepo,
+		auditLogService,
+	)
+
+	organizationService := service.NewOrganizationService(
+		organizationRepo,
+		userRepo,
+		wodRepo,
+		movementRepo,
+		wodService,
+		movementService,
+		auditLogService,
+	)
+
+	programmingService := service.NewProgrammingService(
+		scheduledWorkoutRepo,
+		workoutRepo,
+		wodRepo,
+		organizationService,
+		userWorkoutService,
+	)
+
+	programService := service.NewProgramService(
+		programRepo,
+		workoutRepo,
+		movementRepo,
+		userWorkoutRepo,
+		userWorkoutMovementRepo,
+	)
+
+	leaderboardService := service.NewLeaderboardService(leaderboardRepo, wodRepo)
+	leaderboardService.SetWODVersionService(wodVersionService)
+	fitnessProfileService := service.NewFitnessProfileService(fitnessStandardRepo, userRepo, movementRepo, wodRepo, analyticsRepo, userWorkoutWODRepo)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, movementRepo, wodRepo, userWorkoutRepo, userWorkoutWODRepo)
+	analyticsService.SetStructureService(wodStructureService)
+	yearInReviewService := service.NewYearInReviewService(analyticsRepo, userRepo, userWorkoutRepo, userWorkoutMovementRepo, userWorkoutWODRepo)
+	bodyMetricService := service.NewBodyMetricService(bodyMetricRepo)
+	goalService := service.NewGoalService(goalRepo, movementRepo, wodRepo, userWorkoutRepo, userWorkoutMovementRepo, userWorkoutWODRepo, auditLogService)
+	userWorkoutService.SetGoalService(goalService)
+	achievementService := service.NewAchievementService(achievementRepo, userWorkoutRepo)
+	userWorkoutService.SetAchievementService(achievementService)
+
 	exportService := service.NewExportService(wodRepo, movementRepo, userRepo, userWorkoutRepo)
+	exportService.SetEnumerationService(enumerationService)
 	importService := service.NewImportService(wodRepo, movementRepo, userRepo, userWorkoutRepo, userWorkoutMovementRepo, userWorkoutWODRepo)
+	importService.SetAliasService(aliasService)
+	importService.SetEnumerationService(enumerationService)
+	importService.SetWODVersionService(wodVersionService)
+	importService.SetStructureService(wodStructureService)
 	wodifyImportService := service.NewWodifyImportService(userRepo, movementRepo, wodRepo, userWorkoutRepo, userWorkoutMovementRepo, userWorkoutWODRepo)
+	wodifyImportService.SetAliasService(aliasService)
+	wodifyImportService.SetSearchService(searchService)
+	wodifyImportService.SetStructureService(wodStructureService)
+	importService.SetSearchService(searchService)
 
 	// Determine backups and uploads directories
 	workDir, _ := os.Getwd()
 	backupDir := filepath.Join(workDir, "backups")
 	uploadsPath := filepath.Join(workDir, "uploads")
 
+	// Attachments are stored under uploads/attachments and removed with their movement, WOD or workout
+	attachmentService := service.NewAttachmentService(attachmentRepo, movementRepo, wodRepo, userWorkoutRepo, uploadsPath)
+	movementService.SetAttachmentService(attachmentService)
+	wodService.SetAttachmentService(attachmentService)
+	userWorkoutService.SetAttachmentService(attachmentService)
+	attachmentService.SetCoachService(coachService)
+	attachmentService.SetFileURLSecret(cfg.JWT.SecretKey)
+
+	// Seed the default WOD source, type and regime and movement type values on first run
+	if count, err := enumerationService.SeedDefaults(); err != nil {
+		appLogger.Error("Failed to seed enumerations: %v", err)
+	} else if count > 0 {
+		appLogger.Info("Seeded %d enumeration values", count)
+	}
+
+	// Seed fitness profile reference standards on first run
+	if standardsFile, err := os.Open(filepath.Join(workDir, "seeds", "fitness_standards.csv")); err == nil {
+		if count, err := fitnessProfileService.SeedStandards(standardsFile); err != nil {
+			appLogger.Error("Failed to seed fitness standards: %v", err)
+		} else if count > 0 {
+			appLogger.Info("Seeded %d fitness standards", count)
+		}
+		standardsFile.Close()
+	}
+
+	// Fill in the taxonomy of standard movements that have none from the movement seed file
+	if movementsFile, err := os.Open(filepath.Join(workDir, "seeds", "movements.csv")); err == nil {
+		if count, err := movementService.SeedTaxonomy(movementsFile); err != nil {
+			appLogger.Error("Failed to seed movement taxonomy: %v", err)
+		} else if count > 0 {
+			appLogger.Info("Seeded taxonomy for %d movements", count)
+		}
+		movementsFile.Close()
+	}
+
+	// Seed default achievement rules on first run
+	if rulesFile, err := os.Open(filepath.Join(workDir, "seeds", "achievements.json")); err == nil {
+		if count, err := achievementService.SeedRules(rulesFile); err != nil {
+			appLogger.Error("Failed to seed achievement rules: %v", err)
+		} else if count > 0 {
+			appLogger.Info("Seeded %d achievement rules", count)
+		}
+		rulesFile.Close()
+	}
+
+	// Parse structures for WODs that have none yet (seeded, imported or pre-existing WODs)
+	if result, err := wodStructureService.Backfill(false); err != nil {
+		appLogger.Error("Failed to parse WOD structures: %v", err)
+	} else if result.Parsed > 0 {
+		appLogger.Info("Parsed %d WOD structures (%d flagged for review)", result.Parsed, result.NeedsReview)
+	}
+
+	// Build the search index on first run, or when it is out of step with the search backend
+	appLogger.Info("Search backend: %s", searchService.Backend())
+	if indexed, err := searchService.EnsureIndex(); err != nil {
+		appLogger.Error("Failed to build search index: %v", err)
+	} else if indexed > 0 {
+		appLogger.Info("Indexed %d search documents", indexed)
+	}
+
+	// Report seed bundle changes to the standard library; admins review and apply them
+	seedLibraryService := service.NewSeedLibraryService(seedLibraryRepo, movementRepo, wodRepo, movementService, wodService, dataChangeLogService, filepath.Join(workDir, "seeds"))
+	seedLibraryService.SetSearchService(searchService)
+	if diff, err := seedLibraryService.Diff(); err != nil {
+		if !errors.Is(err, service.ErrSeedBundleNotFound) {
+			appLogger.Error("Failed to compare the seed bundle with the standard library: %v", err)
+		}
+	} else if len(diff.Changes) > 0 {
+		appLogger.Info("Seed bundle %s has %d pending standard library changes (review with GET /api/admin/seed-library/diff)", diff.BundleVersion, len(diff.Changes))
+	}
+
 	backupService := service.NewBackupService(
 		db,
 		cfg.Database.Driver,
@@ -200,6 +365,7 @@ func main() {
 		userRepo,
 		auditLogRepo,
 	)
+	backupService.SetSearchService(searchService)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(userService, appLogger)
@@ -211,16 +377,34 @@ func main() {
 	workoutWODHandler := handler.NewWorkoutWODHandler(workoutWODService)
 	settingsHandler := handler.NewSettingsHandler(userSettingsService, appLogger)
 	prHandler := handler.NewPRHandler(db, appLogger)
-	performanceHandler := handler.NewPerformanceHandler(movementRepo, wodRepo, userWorkoutMovementRepo, userWorkoutWODRepo, appLogger)
+	performanceHandler := handler.NewPerformanceHandler(movementRepo, wodRepo, userWorkoutMovementRepo, userWorkoutWODRepo, bodyMetricService, searchService, appLogger)
 	adminHandler := handler.NewAdminHandler(db, userWorkoutWODRepo, wodRepo, movementRepo, workoutRepo, userRepo, wodService, movementService, workoutTemplateService, appLogger)
 	auditLogHandler := handler.NewAuditLogHandler(auditLogService, appLogger)
 	dataChangeLogHandler := handler.NewDataChangeLogHandler(dataChangeLogService, appLogger)
-	adminUserHandler := handler.NewAdminUserHandler(userService, appLogger)
+	adminUserHandler := handler.NewAdminUserHandler(userService, organizationService, appLogger)
 	sessionHandler := handler.NewSessionHandler(userService, appLogger)
 	exportHandler := handler.NewExportHandler(exportService)
 	importHandler := handler.NewImportHandler(importService)
 	wodifyImportHandler := handler.NewWodifyImportHandler(wodifyImportService)
 	backupHandler := handler.NewBackupHandler(backupService, auditLogRepo)
+	coachHandler := handler.NewCoachHandler(coachService, appLogger)
+	organizationHandler := handler.NewOrganizationHandler(organizationService, appLogger)
+	programmingHandler := handler.NewProgrammingHandler(programmingService, userWorkoutService, appLogger)
+	programHandler := handler.NewProgramHandler(programService, appLogger)
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardService, appLogger)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService, fitnessProfileService, coachService, appLogger)
+	yearInReviewHandler := handler.NewYearInReviewHandler(yearInReviewService, appLogger)
+	fitnessStandardHandler := handler.NewFitnessStandardHandler(fitnessProfileService, appLogger)
+	bodyMetricHandler := handler.NewBodyMetricHandler(bodyMetricService, appLogger)
+	goalHandler := handler.NewGoalHandler(goalService, appLogger)
+	achievementHandler := handler.NewAchievementHandler(achievementService, appLogger)
+	wodStructureHandler := handler.NewWODStructureHandler(wodStructureService, appLogger)
+	aliasHandler := handler.NewAliasHandler(aliasService, appLogger)
+	searchHandler := handler.NewSearchHandler(searchService, appLogger)
+	enumerationHandler := handler.NewEnumerationHandler(enumerationService, appLogger)
+	wodVersionHandler := handler.NewWODVersionHandler(wodVersionService, appLogger)
+	seedLibraryHandler := handler.NewSeedLibraryHandler(seedLibraryService, appLogger)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, appLogger)
 
 	// Set up router
 	r := chi.NewRouter()
@@ -267,7 +451,8 @@ func main() {
 	}
 
 	// Static file serving for uploads (avatars, etc.)
-	uploadsDir := http.Dir(uploadsPath)
+	// Logged workout attachments are private and only served through signed API URLs
+	uploadsDir := privateDirFS{FileSystem: http.Dir(uploadsPath), private: []string{"/" + service.WorkoutAttachmentUploadDir}}
 	FileServer(r, "/uploads", uploadsDir)
 
 	// API routes
@@ -293,13 +478,29 @@ func main() {
 		// Movement routes (public for browsing)
 		r.Get("/movements", movementHandler.ListAll)
 		r.Get("/movements/search", movementHandler.Search)
+		r.Get("/movements/taxonomy", movementHandler.GetTaxonomyOptions)
 		r.Get("/movements/{id}", movementHandler.GetByID)
+		r.Get("/movements/{id}/variants", movementHandler.ListVariants)
+		r.Get("/movements/{id}/wods", wodStructureHandler.ListWODsByMovement)
+		r.Get("/movements/{id}/attachments", attachmentHandler.ListMovementAttachments)
 
 		// WOD routes (public for browsing standard WODs)
 		r.Get("/wods", wodHandler.ListWODs)
 		r.Get("/wods/standard", wodHandler.ListStandardWODs)
+		r.Get("/wods/score-types", wodHandler.ListScoreTypes)
 		r.Get("/wods/search", wodHandler.SearchWODs)
 		r.Get("/wods/{id}", wodHandler.GetWOD)
+		r.Get("/wods/{id}/structure", wodStructureHandler.GetStructure)
+		r.Get("/wods/{id}/versions", wodVersionHandler.ListVersions)
+		r.Get("/wods/{id}/versions/{version}", wodVersionHandler.GetVersion)
+		r.Get("/wods/{id}/variants", wodVersionHandler.ListVariants)
+		r.Get("/wods/{id}/attachments", attachmentHandler.ListWODAttachments)
+
+		// Logged workout attachment files (public; the signed URL from listing the attachments grants access)
+		r.Get("/workouts/{id}/attachments/files/{name}", attachmentHandler.ServeWorkoutAttachmentFile)
+
+		// Enumeration routes (public - allowed WOD and movement field values)
+		r.Get("/enumerations", enumerationHandler.ListEnumerations)
 
 		// Template routes (public for browsing standard templates)
 		r.Get("/templates", workoutTemplateHandler.ListStandardTemplates)
@@ -310,9 +511,11 @@ func main() {
 			r.Use(middleware.Auth(cfg.JWT.SecretKey))
 
 			// Movement management (authenticated)
+			r.Get("/movements/match", aliasHandler.MatchMovement)
 			r.Post("/movements", movementHandler.Create)
 			r.Put("/movements/{id}", movementHandler.Update)
 			r.Delete("/movements/{id}", movementHandler.Delete)
+			r.Post("/movements/{id}/attachments", attachmentHandler.CreateMovementAttachment)
 
 			// User profile routes (authenticated)
 			r.Get("/users/profile", userHandler.GetProfile)
@@ -346,15 +549,22 @@ func main() {
 			r.Get("/workouts/{id}", userWorkoutHandler.GetLoggedWorkout)
 			r.Put("/workouts/{id}", userWorkoutHandler.UpdateLoggedWorkout)
 			r.Delete("/workouts/{id}", userWorkoutHandler.DeleteLoggedWorkout)
+			r.Get("/workouts/{id}/attachments", attachmentHandler.ListWorkoutAttachments)
+			r.Post("/workouts/{id}/attachments", attachmentHandler.CreateWorkoutAttachment)
 			r.Get("/workouts/stats/monthly", userWorkoutHandler.GetMonthlyStats)
 			r.Get("/workouts/personal-records", userWorkoutHandler.GetPersonalRecords)
 			r.Post("/workouts/retroactive-flag-prs", userWorkoutHandler.RetroactiveFlagPRs)
 
 			// WOD management (authenticated)
 			r.Get("/wods/my-wods", wodHandler.ListMyWODs)
+			r.Get("/wods/match", aliasHandler.MatchWOD)
 			r.Post("/wods", wodHandler.CreateWOD)
 			r.Put("/wods/{id}", wodHandler.UpdateWOD)
 			r.Delete("/wods/{id}", wodHandler.DeleteWOD)
+			r.Post("/wods/{id}/attachments", attachmentHandler.CreateWODAttachment)
+
+			// Attachment routes (movements, WODs and logged workouts)
+			r.Delete("/attachments/{id}", attachmentHandler.DeleteAttachment)
 
 			// Workout WOD linking (authenticated)
 			r.Post("/templates/{workout_id}/wods", workoutWODHandler.AddWODToWorkout)
@@ -370,13 +580,95 @@ func main() {
 
 			// Performance tracking routes (authenticated)
 			r.Get("/performance/search", performanceHandler.UnifiedSearch)
+
+			// Full-text search routes (authenticated - standard records and own records only)
+			r.Get("/search", searchHandler.Search)
 			r.Get("/performance/movements/{id}", performanceHandler.GetMovementPerformance)
 			r.Get("/performance/wods/{id}", performanceHandler.GetWODPerformance)
 
+			// Coach routes (authenticated, read-only access to athletes who accepted an invite)
+			r.Post("/coach/invites", coachHandler.InviteAthlete)
+			r.Get("/coach/athletes", coachHandler.ListAthletes)
+			r.Get("/coach/athletes/{athlete_id}/workouts", coachHandler.ListAthleteWorkouts)
+			r.Get("/coach/athletes/{athlete_id}/workouts/{id}", coachHandler.GetAthleteWorkout)
+			r.Get("/coach/athletes/{athlete_id}/workouts/{id}/attachments", attachmentHandler.ListAthleteWorkoutAttachments)
+			r.Get("/coach/athletes/{athlete_id}/prs", coachHandler.GetAthletePRs)
+			r.Get("/coach/athletes/{athlete_id}/performance/movements/{id}", coachHandler.GetAthleteMovementPerformance)
+			r.Get("/coach/athletes/{athlete_id}/performance/wods/{id}", coachHandler.GetAthleteWODPerformance)
+			r.Get("/coach/athletes/{athlete_id}/analytics/volume", analyticsHandler.GetAthleteVolume)
+			r.Get("/coach/athletes/{athlete_id}/analytics/movements", analyticsHandler.GetAthleteMovementBreakdown)
+			r.Get("/coach/athletes/{athlete_id}/analytics/movements/{id}/e1rm", analyticsHandler.GetAthleteE1RMProgression)
+			r.Get("/coach/athletes/{athlete_id}/analytics/wods/{id}/retests", analyticsHandler.GetAthleteBenchmarkRetests)
+			r.Get("/coach/athletes/{athlete_id}/analytics/consistency", analyticsHandler.GetAthleteConsistency)
+			r.Get("/coach/athletes/{athlete_id}/analytics/calendar", analyticsHandler.GetAthleteCalendar)
+			r.Get("/coach/athletes/{athlete_id}/analytics/fitness-profile", analyticsHandler.GetAthleteFitnessProfile)
+			r.Get("/coach/athletes/{athlete_id}/analytics/training-load", analyticsHandler.GetAthleteTrainingLoad)
+
+			// Athlete side of coaching (authenticated - own relationships only)
+			r.Get("/users/me/coaches", coachHandler.ListMyCoaches)
+			r.Post("/users/me/coaches/{id}/accept", coachHandler.AcceptInvite)
+			r.Post("/users/me/coaches/{id}/decline", coachHandler.DeclineInvite)
+			r.Delete("/users/me/coaches/{id}", coachHandler.RevokeCoach)
+
+			// Gym routes (authenticated - membership checked in the service)
+			r.Get("/gyms", organizationHandler.ListMyGyms)
+			r.Get("/gyms/{gym_id}", organizationHandler.GetGym)
+			r.Get("/gyms/{gym_id}/library", organizationHandler.GetGymLibrary)
+			r.Get("/gyms/{gym_id}/programming", programmingHandler.ListCalendar)
+			r.Get("/gyms/{gym_id}/programming/today", programmingHandler.GetToday)
+			r.Post("/gyms/{gym_id}/programming/{id}/log", programmingHandler.LogScheduledWorkout)
+
+			// Training program routes (authenticated)
+			r.Get("/programs", programHandler.ListPrograms)
+			r.Post("/programs", programHandler.CreateProgram)
+			r.Get("/programs/enrollments", programHandler.ListEnrollments)
+			r.Get("/programs/enrollments/{id}", programHandler.GetProgress)
+			r.Get("/programs/enrollments/{id}/next", programHandler.GetNextSession)
+			r.Post("/programs/enrollments/{id}/sessions/{session_id}/complete", programHandler.CompleteSession)
+			r.Delete("/programs/enrollments/{id}", programHandler.CancelEnrollment)
+			r.Get("/programs/{id}", programHandler.GetProgram)
+			r.Put("/programs/{id}", programHandler.UpdateProgram)
+			r.Delete("/programs/{id}", programHandler.DeleteProgram)
+			r.Post("/programs/{id}/enroll", programHandler.Enroll)
+
+			// Leaderboard routes (authenticated - only opted-in users are ranked)
+			r.Get("/leaderboards/wods/{wod_id}", leaderboardHandler.GetWODLeaderboard)
+
+			// Analytics routes (authenticated)
+			r.Get("/analytics/volume", analyticsHandler.GetVolume)
+			r.Get("/analytics/movements", analyticsHandler.GetMovementBreakdown)
+			r.Get("/analytics/movements/{id}/e1rm", analyticsHandler.GetE1RMProgression)
+			r.Get("/analytics/wods/{id}/retests", analyticsHandler.GetBenchmarkRetests)
+			r.Get("/analytics/consistency", analyticsHandler.GetConsistency)
+			r.Get("/analytics/calendar", analyticsHandler.GetCalendar)
+			r.Get("/analytics/fitness-profile", analyticsHandler.GetFitnessProfile)
+			r.Get("/analytics/training-load", analyticsHandler.GetTrainingLoad)
+			r.Get("/analytics/year-in-review", yearInReviewHandler.GetYearInReview)
+			r.Get("/fitness-standards", fitnessStandardHandler.ListStandards)
+
+			// Body metrics routes (authenticated - own log only)
+			r.Get("/body-metrics", bodyMetricHandler.ListMetrics)
+			r.Post("/body-metrics", bodyMetricHandler.CreateMetric)
+			r.Get("/body-metrics/{id}", bodyMetricHandler.GetMetric)
+			r.Put("/body-metrics/{id}", bodyMetricHandler.UpdateMetric)
+			r.Delete("/body-metrics/{id}", bodyMetricHandler.DeleteMetric)
+
+			// Goal routes (authenticated - own goals only)
+			r.Get("/goals", goalHandler.ListGoals)
+			r.Post("/goals", goalHandler.CreateGoal)
+			r.Get("/goals/{id}", goalHandler.GetGoal)
+			r.Put("/goals/{id}", goalHandler.UpdateGoal)
+			r.Delete("/goals/{id}", goalHandler.DeleteGoal)
+
+			// Achievement routes (authenticated - own badges only)
+			r.Get("/achievements", achievementHandler.ListAchievements)
+			r.Post("/achievements/backfill", achievementHandler.BackfillOwn)
+
 			// Export routes (authenticated)
 			r.Get("/export/wods", exportHandler.ExportWODs)
 			r.Get("/export/movements", exportHandler.ExportMovements)
 			r.Get("/export/user-workouts", exportHandler.ExportUserWorkouts)
+			r.Get("/export/body-metrics", bodyMetricHandler.ExportMetrics)
 
 			// Import routes (authenticated)
 			r.Post("/import/wods/preview", importHandler.PreviewWODImport)
@@ -387,53 +679,130 @@ func main() {
 			r.Post("/import/user-workouts/confirm", importHandler.ConfirmUserWorkoutImport)
 			r.Post("/import/wodify/preview", wodifyImportHandler.PreviewWodifyImport)
 			r.Post("/import/wodify/confirm", wodifyImportHandler.ConfirmWodifyImport)
+			r.Post("/import/body-metrics", bodyMetricHandler.ImportMetrics)
 
 			// Admin routes (authenticated + admin role check)
 			r.Route("/admin", func(r chi.Router) {
-				r.Use(middleware.AdminOnly)
-
-				// Backup routes (admin only)
-				r.Post("/backups", backupHandler.CreateBackup)
-				r.Get("/backups", backupHandler.ListBackups)
-				r.Post("/backups/upload", backupHandler.UploadBackup)
-				r.Get("/backups/{filename}", backupHandler.DownloadBackup)
-				r.Get("/backups/{filename}/metadata", backupHandler.GetBackupMetadata)
-				r.Delete("/backups/{filename}", backupHandler.DeleteBackup)
-				r.Post("/backups/{filename}/restore", backupHandler.RestoreBackup)
-
-				// Data cleanup routes
-				r.Get("/data-cleanup/wod-mismatches", adminHandler.DetectWODScoreTypeMismatches)
-				r.Delete("/data-cleanup/wod-mismatches", adminHandler.FixWODScoreTypeMismatches)
-				r.Put("/data-cleanup/wod-record/{id}", adminHandler.UpdateWODRecord)
-
-				// Audit log routes (admin only)
-				r.Get("/audit-logs", auditLogHandler.ListAuditLogs)
-				r.Get("/audit-logs/{id}", auditLogHandler.GetAuditLog)
-				r.Post("/audit-logs/cleanup", auditLogHandler.CleanupOldLogs)
-
-				// Data change log routes (admin only)
-				r.Get("/data-change-logs", dataChangeLogHandler.ListDataChangeLogs)
-				r.Get("/data-change-logs/{id}", dataChangeLogHandler.GetDataChangeLog)
-				r.Get("/data-change-logs/entity/{entity_type}/{entity_id}", dataChangeLogHandler.GetEntityHistory)
-				r.Post("/data-change-logs/cleanup", dataChangeLogHandler.CleanupOldLogs)
-
-				// User management routes (admin only)
-				r.Get("/users", adminUserHandler.ListUsers)
-				r.Post("/users/{id}/unlock", adminUserHandler.UnlockUser)
-				r.Get("/users/{id}", adminUserHandler.GetUserDetails)
-				r.Post("/users/{id}/disable", adminUserHandler.DisableUser)
-				r.Post("/users/{id}/enable", adminUserHandler.EnableUser)
-				r.Put("/users/{id}/role", adminUserHandler.ChangeUserRole)
-				r.Post("/users/{id}/toggle-email-verification", adminUserHandler.ToggleEmailVerification)
-				r.Delete("/users/{id}", adminUserHandler.DeleteUser)
-
-				// User-created content management routes (admin only)
-				r.Get("/user-created/wods", adminHandler.ListUserCreatedWODs)
-				r.Post("/user-created/wods/{id}/copy-to-standard", adminHandler.CopyWODToStandard)
-				r.Get("/user-created/movements", adminHandler.ListUserCreatedMovements)
-				r.Post("/user-created/movements/{id}/copy-to-standard", adminHandler.CopyMovementToStandard)
-				r.Get("/user-created/workouts", adminHandler.ListUserCreatedWorkouts)
-				r.Post("/user-created/workouts/{id}/copy-to-standard", adminHandler.CopyWorkoutToStandard)
+				// Gym-scopable admin routes: site admins, or gym admins for their own gym
+				// ({gym_id} in the path, or ?gym_id= to limit the user and user-created content lists)
+				r.Group(func(r chi.Router) {
+					r.Use(organizationHandler.GymAdminScope)
+
+					r.Get("/users", adminUserHandler.ListUsers)
+					r.Get("/users/{id}", adminUserHandler.GetUserDetails)
+					r.Get("/user-created/wods", adminHandler.ListUserCreatedWODs)
+					r.Get("/user-created/movements", adminHandler.ListUserCreatedMovements)
+					r.Get("/user-created/workouts", adminHandler.ListUserCreatedWorkouts)
+
+					r.Put("/gyms/{gym_id}", organizationHandler.UpdateGym)
+					r.Get("/gyms/{gym_id}/members", organizationHandler.ListGymMembers)
+					r.Post("/gyms/{gym_id}/members", organizationHandler.AddGymMember)
+					r.Put("/gyms/{gym_id}/members/{user_id}/role", organizationHandler.UpdateGymMemberRole)
+					r.Delete("/gyms/{gym_id}/members/{user_id}", organizationHandler.RemoveGymMember)
+					r.Post("/gyms/{gym_id}/library/wods", organizationHandler.CreateGymWOD)
+					r.Post("/gyms/{gym_id}/library/movements", organizationHandler.CreateGymMovement)
+					r.Post("/gyms/{gym_id}/library/items", organizationHandler.AddGymLibraryItem)
+					r.Delete("/gyms/{gym_id}/library/{entity_type}/{entity_id}", organizationHandler.RemoveGymLibraryItem)
+					r.Post("/gyms/{gym_id}/programming", programmingHandler.ScheduleWorkout)
+					r.Put("/gyms/{gym_id}/programming/{id}", programmingHandler.UpdateScheduledWorkout)
+					r.Delete("/gyms/{gym_id}/programming/{id}", programmingHandler.DeleteScheduledWorkout)
+				})
+
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.AdminOnly)
+
+					// Backup routes (admin only)
+					r.Post("/backups", backupHandler.CreateBackup)
+					r.Get("/backups", backupHandler.ListBackups)
+					r.Post("/backups/upload", backupHandler.UploadBackup)
+					r.Get("/backups/{filename}", backupHandler.DownloadBackup)
+					r.Get("/backups/{filename}/metadata", backupHandler.GetBackupMetadata)
+					r.Delete("/backups/{filename}", backupHandler.DeleteBackup)
+					r.Post("/backups/{filename}/restore", backupHandler.RestoreBackup)
+
+					// Data cleanup routes
+					r.Get("/data-cleanup/wod-mismatches", adminHandler.DetectWODScoreTypeMismatches)
+					r.Delete("/data-cleanup/wod-mismatches", adminHandler.FixWODScoreTypeMismatches)
+					r.Put("/data-cleanup/wod-record/{id}", adminHandler.UpdateWODRecord)
+
+					// Audit log routes (admin only)
+					r.Get("/audit-logs", auditLogHandler.ListAuditLogs)
+					r.Get("/audit-logs/{id}", auditLogHandler.GetAuditLog)
+					r.Post("/audit-logs/cleanup", auditLogHandler.CleanupOldLogs)
+
+					// Data change log routes (admin only)
+					r.Get("/data-change-logs", dataChangeLogHandler.ListDataChangeLogs)
+					r.Get("/data-change-logs/{id}", dataChangeLogHandler.GetDataChangeLog)
+					r.Get("/data-change-logs/entity/{entity_type}/{entity_id}", dataChangeLogHandler.GetEntityHistory)
+					r.Post("/data-change-logs/cleanup", dataChangeLogHandler.CleanupOldLogs)
+
+					// Fitness standards routes (admin only)
+					r.Post("/fitness-standards/import", fitnessStandardHandler.ImportStandards)
+
+					// WOD structure review routes (admin only)
+					r.Get("/wods/structures/review", wodStructureHandler.ListForReview)
+					r.Post("/wods/structures/backfill", wodStructureHandler.Backfill)
+					r.Post("/wods/{id}/structure/parse", wodStructureHandler.Reparse)
+					r.Put("/wods/{id}/structure", wodStructureHandler.UpdateStructure)
+
+					// Search index routes (admin only)
+					r.Post("/search/rebuild", searchHandler.RebuildIndex)
+
+					// Enumeration routes (admin only)
+					r.Get("/enumerations", enumerationHandler.ListAllEnumerations)
+					r.Post("/enumerations", enumerationHandler.CreateEnumeration)
+					r.Get("/enumerations/export", exportHandler.ExportEnumerations)
+					r.Post("/enumerations/import", enumerationHandler.ImportEnumerations)
+					r.Put("/enumerations/{id}", enumerationHandler.UpdateEnumeration)
+					r.Delete("/enumerations/{id}", enumerationHandler.DeleteEnumeration)
+
+					// Movement taxonomy routes (admin only)
+					r.Put("/movements/{id}/taxonomy", movementHandler.UpdateTaxonomy)
+
+					// Alias and merge routes (admin only)
+					r.Get("/movements/{id}/aliases", aliasHandler.ListMovementAliases)
+					r.Post("/movements/{id}/aliases", aliasHandler.AddMovementAlias)
+					r.Get("/wods/{id}/aliases", aliasHandler.ListWODAliases)
+					r.Post("/wods/{id}/aliases", aliasHandler.AddWODAlias)
+					r.Delete("/aliases/{id}", aliasHandler.DeleteAlias)
+					r.Post("/movements/merge", aliasHandler.MergeMovements)
+					r.Post("/wods/merge", aliasHandler.MergeWODs)
+					r.Post("/merge/recompute-prs", aliasHandler.RecomputePRs)
+
+					// WOD version and variant routes (admin only)
+					r.Put("/wods/{id}/versions/{version}", wodVersionHandler.SetVersionComparability)
+					r.Post("/wods/{id}/variants", wodVersionHandler.SaveVariant)
+					r.Delete("/wods/{id}/variant", wodVersionHandler.RemoveVariant)
+
+					// Seed library sync routes (admin only)
+					r.Get("/seed-library/diff", seedLibraryHandler.GetDiff)
+					r.Post("/seed-library/apply", seedLibraryHandler.Apply)
+
+					// Achievement rule routes (admin only)
+					r.Get("/achievements/rules", achievementHandler.ListRules)
+					r.Post("/achievements/rules", achievementHandler.CreateRule)
+					r.Put("/achievements/rules/{id}", achievementHandler.UpdateRule)
+					r.Delete("/achievements/rules/{id}", achievementHandler.DeleteRule)
+					r.Post("/achievements/backfill", achievementHandler.Backfill)
+
+					// User management routes (admin only)
+					r.Post("/users/{id}/unlock", adminUserHandler.UnlockUser)
+					r.Post("/users/{id}/disable", adminUserHandler.DisableUser)
+					r.Post("/users/{id}/enable", adminUserHandler.EnableUser)
+					r.Put("/users/{id}/role", adminUserHandler.ChangeUserRole)
+					r.Post("/users/{id}/toggle-email-verification", adminUserHandler.ToggleEmailVerification)
+					r.Delete("/users/{id}", adminUserHandler.DeleteUser)
+
+					// User-created content management routes (admin only)
+					r.Post("/user-created/wods/{id}/copy-to-standard", adminHandler.CopyWODToStandard)
+					r.Post("/user-created/movements/{id}/copy-to-standard", adminHandler.CopyMovementToStandard)
+					r.Post("/user-created/workouts/{id}/copy-to-standard", adminHandler.CopyWorkoutToStandard)
+
+					// Gym management routes (admin only)
+					r.Get("/gyms", organizationHandler.ListGyms)
+					r.Post("/gyms", organizationHandler.CreateGym)
+					r.Delete("/gyms/{gym_id}", organizationHandler.DeleteGym)
+				})
 			})
 		})
 	})
@@ -494,6 +863,25 @@ func main() {
 	appLogger.Info("Server exited")
 }
 
+// privateDirFS hides directories of a file system that must not be served publicly
+type privateDirFS struct {
+	http.FileSystem
+	private []string // Cleaned, slash-separated paths from the root
+}
+
+// Open refuses any name inside a private directory; names are compared case-insensitively so
+// case-insensitive file systems cannot be used to reach them
+func (fs privateDirFS) Open(name string) (http.File, error) {
+	cleaned := strings.ToLower(path.Clean("/" + name))
+	for _, dir := range fs.private {
+		dir = strings.ToLower(dir)
+		if cleaned == dir || strings.HasPrefix(cleaned, dir+"/") {
+			return nil, os.ErrNotExist
+		}
+	}
+	return fs.FileSystem.Open(name)
+}
+
 // FileServer conveniently sets up a http.FileServer handler to serve
 // static files from a http.FileSystem.
 func FileServer(r chi.Router, path string, root http.FileSystem) {
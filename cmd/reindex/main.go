@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/johnzastrow/actalog/configs"
+	"github.com/johnzastrow/actalog/internal/repository"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/joho/godotenv"
+)
+
+// Rebuilds the full-text search index from the movements, WODs, templates and logged workouts.
+// Build with -tags sqlite_fts5 to get FTS5 ranking on SQLite.
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Load configuration
+	cfg, err := configs.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Build DSN
+	dsn := repository.BuildDSN(
+		cfg.Database.Driver,
+		cfg.Database.Host,
+		cfg.Database.Port,
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Database,
+		cfg.Database.SSLMode,
+		cfg.Database.Schema,
+	)
+
+	// Initialize database (runs pending migrations)
+	db, err := repository.InitDatabase(cfg.Database.Driver, dsn, cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	searchService := service.NewSearchService(repository.NewSearchRepository(db))
+	fmt.Printf("Rebuilding search index (%s backend)...\n", searchService.Backend())
+
+	indexed, err := searchService.Rebuild()
+	if err != nil {
+		log.Fatalf("Failed to rebuild search index: %v", err)
+	}
+
+	fmt.Printf("✓ Indexed %d documents\n", indexed)
+}
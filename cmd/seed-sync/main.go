@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/johnzastrow/actalog/configs"
+	"github.com/johnzastrow/actalog/internal/repository"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/joho/godotenv"
+)
+
+// Compares the standard movement and WOD library with the seed bundle and applies selected changes.
+// Without -apply or -all it only prints the diff. Changes to records edited locally are skipped
+// unless -confirm-overwrite is given; every applied change is recorded in the data change log
+// under the admin named by -admin-email.
+func main() {
+	dir := flag.String("dir", "seeds", "directory holding bundle.json and the seed CSVs")
+	apply := flag.String("apply", "", "comma-separated change keys to apply (e.g. wod:fran,movement:backsquat)")
+	all := flag.Bool("all", false, "apply every change")
+	confirm := flag.Bool("confirm-overwrite", false, "also apply changes to records with local edits")
+	adminEmail := flag.String("admin-email", "", "admin the changes are logged under (required to apply)")
+	flag.Parse()
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Load configuration
+	cfg, err := configs.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Build DSN
+	dsn := repository.BuildDSN(
+		cfg.Database.Driver,
+		cfg.Database.Host,
+		cfg.Database.Port,
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Database,
+		cfg.Database.SSLMode,
+		cfg.Database.Schema,
+	)
+
+	// Initialize database (runs pending migrations)
+	db, err := repository.InitDatabase(cfg.Database.Driver, dsn, cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	movementRepo := repository.NewMovementRepository(db)
+	wodRepo := repository.NewWODRepository(db)
+	userRepo := repository.NewSQLiteUserRepository(db)
+	dataChangeLogService := service.NewDataChangeLogService(repository.NewDataChangeLogRepository(db, cfg.Database.Driver))
+
+	// Wire the same hooks as the server so applied edits refresh structures, versions, PRs and search
+	userWorkoutService := service.NewUserWorkoutService(
+		repository.NewUserWorkoutRepository(db),
+		repository.NewWorkoutRepository(db),
+		repository.NewWorkoutMovementRepository(db),
+		repository.NewUserWorkoutMovementRepository(db),
+		repository.NewUserWorkoutWODRepository(db),
+		wodRepo,
+	)
+	enumerationService := service.NewEnumerationService(repository.NewEnumerationRepository(db), dataChangeLogService)
+	if _, err := enumerationService.SeedDefaults(); err != nil {
+		log.Fatalf("Failed to seed enumerations: %v", err)
+	}
+	searchService := service.NewSearchService(repository.NewSearchRepository(db))
+
+	wodService := service.NewWODService(wodRepo, dataChangeLogService)
+	wodService.SetEnumerationService(enumerationService)
+	wodService.SetStructureService(service.NewWODStructureService(repository.NewWODStructureRepository(db), wodRepo, movementRepo))
+	wodService.SetSearchService(searchService)
+	wodVersionService := service.NewWODVersionService(repository.NewWODVersionRepository(db), wodRepo, dataChangeLogService)
+	wodVersionService.SetUserWorkoutService(userWorkoutService)
+	userWorkoutService.SetWODVersionService(wodVersionService)
+	wodService.SetVersionService(wodVersionService)
+
+	movementService := service.NewMovementService(movementRepo, dataChangeLogService)
+	movementService.SetEnumerationService(enumerationService)
+	movementService.SetSearchService(searchService)
+
+	seedLibraryService := service.NewSeedLibraryService(repository.NewSeedLibraryRepository(db), movementRepo, wodRepo,
+		movementService, wodService, dataChangeLogService, *dir)
+	seedLibraryService.SetSearchService(searchService)
+
+	diff, err := seedLibraryService.Diff()
+	if err != nil {
+		log.Fatalf("Failed to compare the seed bundle: %v", err)
+	}
+
+	fmt.Printf("Seed bundle %s", diff.BundleVersion)
+	if diff.AppliedVersion != "" {
+		fmt.Printf(" (last applied: %s)", diff.AppliedVersion)
+	}
+	fmt.Printf(": %d changes, %d unchanged\n", len(diff.Changes), diff.Unchanged)
+	for _, change := range diff.Changes {
+		line := fmt.Sprintf("  %-7s %s", change.Action, change.Key)
+		if len(change.Fields) > 0 {
+			line += " [" + strings.Join(change.Fields, ", ") + "]"
+		}
+		if change.LocalEdits {
+			line += " (local edits)"
+		}
+		fmt.Println(line)
+	}
+
+	if *apply == "" && !*all {
+		return
+	}
+	if *adminEmail == "" {
+		log.Fatalf("-admin-email is required to apply changes")
+	}
+	admin, err := userRepo.GetByEmail(*adminEmail)
+	if err != nil || admin == nil || admin.Role != "admin" {
+		log.Fatalf("No admin user with email %s", *adminEmail)
+	}
+
+	req := &service.SeedApplyRequest{BundleVersion: diff.BundleVersion, ConfirmOverwrite: *confirm}
+	if !*all {
+		for _, key := range strings.Split(*apply, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				req.Keys = append(req.Keys, key)
+			}
+		}
+	}
+
+	result, err := seedLibraryService.Apply(req, admin.ID, admin.Email)
+	if err != nil {
+		log.Fatalf("Failed to apply the seed bundle: %v", err)
+	}
+
+	for _, skipped := range result.Skipped {
+		fmt.Printf("  skipped %s: %s\n", skipped.Key, skipped.Reason)
+	}
+	fmt.Printf("✓ Applied %d changes, skipped %d, now tracking %d unchanged records\n",
+		len(result.Applied), len(result.Skipped), result.Adopted)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+// Achievement rule types; each rule's Params configure the type
+const (
+	AchievementRuleWorkoutCount = "workout_count"  // Nth logged workout: count
+	AchievementRuleWODCount     = "wod_count"      // Nth WOD result matching wod_type and/or wod_name: count
+	AchievementRulePRCount      = "pr_count"       // count PRs within one period (week, month, year; empty = all time)
+	AchievementRuleWODEveryYear = "wod_every_year" // wod_name logged in every calendar year from since_year to the current year
+)
+
+// AchievementRuleParams are the data-driven settings of a rule (stored as JSON)
+type AchievementRuleParams struct {
+	Count     int    `json:"count,omitempty"`
+	WODType   string `json:"wod_type,omitempty"` // Matches WOD.Type (Hero, Girl, Benchmark...), case-insensitive
+	WODName   string `json:"wod_name,omitempty"` // Matches WOD.Name, case-insensitive
+	Period    string `json:"period,omitempty"`   // pr_count: week, month or year
+	SinceYear int    `json:"since_year,omitempty"`
+}
+
+// AchievementRule defines a badge and the condition that awards it (achievement_rules table)
+type AchievementRule struct {
+	ID          int64                 `json:"id" db:"id"`
+	Code        string                `json:"code" db:"code"` // Stable unique key (e.g., first_hero_wod)
+	Name        string                `json:"name" db:"name"`
+	Description string                `json:"description" db:"description"`
+	Icon        *string               `json:"icon,omitempty" db:"icon"`
+	RuleType    string                `json:"rule_type" db:"rule_type"`
+	Params      AchievementRuleParams `json:"params" db:"params"`
+	IsActive    bool                  `json:"is_active" db:"is_active"`
+	CreatedBy   *int64                `json:"created_by,omitempty" db:"created_by"` // NULL for seeded rules
+	CreatedAt   time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// UserAchievement is a badge awarded to a user (user_achievements table); one per user per rule
+type UserAchievement struct {
+	ID            int64     `json:"id" db:"id"`
+	UserID        int64     `json:"user_id" db:"user_id"`
+	RuleID        int64     `json:"rule_id" db:"rule_id"`
+	UserWorkoutID *int64    `json:"user_workout_id,omitempty" db:"user_workout_id"` // Logged workout that triggered the award
+	WorkoutDate   *string   `json:"workout_date,omitempty" db:"workout_date"`       // YYYY-MM-DD of the triggering workout
+	AwardedAt     time.Time `json:"awarded_at" db:"awarded_at"`
+
+	// Related data (loaded via joins)
+	Code        string  `json:"code,omitempty" db:"-"`
+	Name        string  `json:"name,omitempty" db:"-"`
+	Description string  `json:"description,omitempty" db:"-"`
+	Icon        *string `json:"icon,omitempty" db:"-"`
+}
+
+// AchievementEvent is one logged result an achievement rule can count
+type AchievementEvent struct {
+	UserWorkoutID int64
+	WorkoutDate   time.Time
+	WODName       string
+	WODType       string
+}
+
+// AchievementRepository defines the interface for achievement rule, award and evaluation data access
+type AchievementRepository interface {
+	CreateRule(rule *AchievementRule) error
+	GetRuleByID(id int64) (*AchievementRule, error)
+	GetRuleByCode(code string) (*AchievementRule, error)
+	// ListRules lists rules by ID; activeOnly skips disabled rules
+	ListRules(activeOnly bool) ([]*AchievementRule, error)
+	CountRules() (int, error)
+	UpdateRule(rule *AchievementRule) error
+	DeleteRule(id int64) error
+
+	// Award saves a badge; awarding a rule the user already holds is a no-op
+	Award(achievement *UserAchievement) error
+	// ListByUser lists a user's badges, most recent first
+	ListByUser(userID int64) ([]*UserAchievement, error)
+
+	// ListWODEvents lists a user's WOD results with the WOD name and type, oldest first
+	ListWODEvents(userID int64) ([]*AchievementEvent, error)
+	// ListPREvents lists a user's PR-flagged movement and WOD results, oldest first
+	ListPREvents(userID int64) ([]*AchievementEvent, error)
+	// ListUserIDsWithWorkouts lists every user who has logged a workout (for backfills)
+	ListUserIDsWithWorkouts() ([]int64, error)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// Analytics time buckets
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week" // Weeks start on Monday
+	BucketMonth = "month"
+)
+
+// WorkoutVolume aggregates logged workouts for one time bucket
+type WorkoutVolume struct {
+	Bucket        string `json:"bucket"` // Bucket start date (YYYY-MM-DD)
+	WorkoutCount  int    `json:"workout_count"`
+	TimeUnderWork int    `json:"time_under_work"` // Sum of UserWorkout.TotalTime in seconds
+}
+
+// MovementVolume aggregates logged movement sets for one time bucket and movement type
+// Tonnage is sets × reps × weight; a row without sets counts as one set
+type MovementVolume struct {
+	Bucket       string  `json:"bucket"`
+	MovementType string  `json:"movement_type"` // weightlifting, gymnastics, cardio, bodyweight
+	Sets         int     `json:"sets"`
+	Reps         int     `json:"reps"`
+	Tonnage      float64 `json:"tonnage"`
+}
+
+// WorkoutTypeCount counts workouts of one WorkoutType in a time bucket
+type WorkoutTypeCount struct {
+	Bucket      string `json:"bucket"`
+	WorkoutType string `json:"workout_type"` // strength, metcon, cardio, mixed, or "unspecified"
+	Count       int    `json:"count"`
+}
+
+// MovementFrequency is how often a movement was logged in a date range
+type MovementFrequency struct {
+	MovementID   int64   `json:"movement_id"`
+	MovementName string  `json:"movement_name"`
+	Sessions     int     `json:"sessions"` // Workouts that included the movement
+	Sets         int     `json:"sets"`
+	Reps         int     `json:"reps"`
+	Tonnage      float64 `json:"tonnage"`
+}
+
+// WODFrequency is how often a WOD was logged in a date range
+type WODFrequency struct {
+	WODID     int64  `json:"wod_id"`
+	WODName   string `json:"wod_name"`
+	WODType   string `json:"wod_type"`
+	ScoreType string `json:"score_type"`
+	Count     int    `json:"count"`
+}
+
+// LiftSet is one logged set of a movement with weight and reps, used for estimated 1RM trends
+type LiftSet struct {
+	UserWorkoutID int64     `json:"user_workout_id"`
+	WorkoutDate   time.Time `json:"workout_date"`
+	Weight        float64   `json:"weight"`
+	Reps          int       `json:"reps"`
+}
+
+// AnalyticsRepository defines SQL aggregations over a user's training log
+// Date bounds are inclusive; results are ordered by bucket
+type AnalyticsRepository interface {
+	// GetWorkoutVolume returns workout counts and time under work per bucket
+	GetWorkoutVolume(userID int64, bucket string, start, end time.Time) ([]*WorkoutVolume, error)
+
+	// GetMovementVolume returns sets, reps and tonnage per bucket and movement type
+	GetMovementVolume(userID int64, bucket string, start, end time.Time) ([]*MovementVolume, error)
+
+	// GetWorkoutTypeCounts returns workouts per WorkoutType per bucket
+	GetWorkoutTypeCounts(userID int64, bucket string, start, end time.Time) ([]*WorkoutTypeCount, error)
+
+	// GetMovementFrequency returns every movement logged in the range, most sessions first
+	GetMovementFrequency(userID int64, start, end time.Time) ([]*MovementFrequency, error)
+
+	// GetMovementFamilyFrequency is GetMovementFrequency with variants counted under their parent movement
+	GetMovementFamilyFrequency(userID int64, start, end time.Time) ([]*MovementFrequency, error)
+
+	// GetWODFrequency returns every WOD logged in the range, most results first
+	GetWODFrequency(userID int64, start, end time.Time) ([]*WODFrequency, error)
+
+	// GetLiftSets returns every weighted set of a movement logged on or before end, oldest first
+	GetLiftSets(userID, movementID int64, end time.Time) ([]*LiftSet, error)
+
+	// GetMaxReps returns the most reps logged for a movement in one set (nil when never logged)
+	GetMaxReps(userID, movementID int64) (*int, error)
+
+	// GetBestMovementTime returns the fastest time in seconds for a movement at a distance (nil when never logged)
+	GetBestMovementTime(userID, movementID int64, distance float64) (*int, error)
+}
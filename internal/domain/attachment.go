@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// Attachment entity types
+const (
+	AttachmentEntityMovement = "movement"
+	AttachmentEntityWOD      = "wod"
+	AttachmentEntityWorkout  = "workout" // A user's logged workout (user_workouts table)
+)
+
+// AttachmentEntityTypes lists every entity type attachments can belong to
+var AttachmentEntityTypes = []string{AttachmentEntityMovement, AttachmentEntityWOD, AttachmentEntityWorkout}
+
+// Attachment kinds
+const (
+	AttachmentKindImage = "image"
+	AttachmentKindVideo = "video"
+	AttachmentKindLink  = "link"
+)
+
+// Attachment is an image, video or link on a movement, WOD or logged workout (attachments table)
+// Images and videos are stored under the uploads directory; links point elsewhere.
+type Attachment struct {
+	ID            int64     `json:"id" db:"id"`
+	EntityType    string    `json:"entity_type" db:"entity_type"` // movement, wod, workout
+	EntityID      int64     `json:"entity_id" db:"entity_id"`
+	Kind          string    `json:"kind" db:"kind"` // image, video, link
+	Title         string    `json:"title" db:"title"`
+	URL           string    `json:"url" db:"url"` // /uploads/... for files, the external address for links
+	ThumbnailURL  *string   `json:"thumbnail_url,omitempty" db:"thumbnail_url"`
+	ContentType   *string   `json:"content_type,omitempty" db:"content_type"`
+	SizeBytes     int64     `json:"size_bytes" db:"size_bytes"`
+	FilePath      *string   `json:"-" db:"file_path"`      // Relative to the uploads directory
+	ThumbnailPath *string   `json:"-" db:"thumbnail_path"` // Relative to the uploads directory
+	CreatedBy     *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// AttachmentRepository defines the interface for attachment data access
+type AttachmentRepository interface {
+	// Create creates a new attachment
+	Create(attachment *Attachment) error
+
+	// GetByID retrieves an attachment by ID, or nil if it does not exist
+	GetByID(id int64) (*Attachment, error)
+
+	// ListByEntity lists an entity's attachments, oldest first
+	ListByEntity(entityType string, entityID int64) ([]*Attachment, error)
+
+	// Delete deletes an attachment
+	Delete(id int64) error
+
+	// DeleteByEntity deletes every attachment of an entity and returns them
+	DeleteByEntity(entityType string, entityID int64) ([]*Attachment, error)
+}
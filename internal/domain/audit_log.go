@@ -48,6 +48,24 @@ const (
 
 	// Rate Limiting Events
 	EventRateLimitExceeded = "rate_limit_exceeded"
+
+	// Coaching Events
+	EventCoachInviteSent     = "coach_invite_sent"
+	EventCoachInviteAccepted = "coach_invite_accepted"
+	EventCoachInviteDeclined = "coach_invite_declined"
+	EventCoachAccessRevoked  = "coach_access_revoked"
+	EventCoachViewedAthlete  = "coach_viewed_athlete_data" // Coach read an athlete's workouts, PRs or history
+
+	// Gym Events
+	EventGymCreated           = "gym_created"
+	EventGymUpdated           = "gym_updated"
+	EventGymDeleted           = "gym_deleted"
+	EventGymMemberAdded       = "gym_member_added"
+	EventGymMemberRemoved     = "gym_member_removed"
+	EventGymMemberRoleChanged = "gym_member_role_changed"
+
+	// Goal Events
+	EventGoalAchieved = "goal_achieved" // A logged workout reached a personal goal
 )
 
 // AuditLogRepository defines the interface for audit log data access
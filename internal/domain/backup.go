@@ -36,6 +36,18 @@ type BackupData struct {
 	AuditLogs               []map[string]interface{} `json:"audit_logs"`
 	UserSettings            []map[string]interface{} `json:"user_settings"`
 	DataChangeLogs          []map[string]interface{} `json:"data_change_logs"`
+	BodyMetrics             []map[string]interface{} `json:"body_metrics"`
+	Goals                   []map[string]interface{} `json:"goals"`
+	AchievementRules        []map[string]interface{} `json:"achievement_rules"`
+	UserAchievements        []map[string]interface{} `json:"user_achievements"`
+	WODStructures           []map[string]interface{} `json:"wod_structures"`
+	WODComponents           []map[string]interface{} `json:"wod_components"`
+	NameAliases             []map[string]interface{} `json:"name_aliases"`
+	Enumerations            []map[string]interface{} `json:"enumerations"`
+	WODVersions             []map[string]interface{} `json:"wod_versions"`
+	WODVariants             []map[string]interface{} `json:"wod_variants"`
+	SeedLibraryEntries      []map[string]interface{} `json:"seed_library_entries"`
+	Attachments             []map[string]interface{} `json:"attachments"`
 }
 
 // BackupService defines the interface for backup/restore operations
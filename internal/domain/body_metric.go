@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// BodyMetric is one dated body measurement entry (body_metrics table); one entry per user per day
+// Weights use the same unit the user logs lifts in, so relative strength ratios are unitless
+type BodyMetric struct {
+	ID             int64              `json:"id" db:"id"`
+	UserID         int64              `json:"user_id" db:"user_id"`
+	MeasuredOn     string             `json:"measured_on" db:"measured_on"` // YYYY-MM-DD
+	BodyWeight     *float64           `json:"body_weight,omitempty" db:"body_weight"`
+	BodyFatPercent *float64           `json:"body_fat_percent,omitempty" db:"body_fat_percent"`
+	Measurements   map[string]float64 `json:"measurements,omitempty" db:"measurements"` // Custom measurements (e.g. waist, chest), stored as JSON
+	Notes          *string            `json:"notes,omitempty" db:"notes"`
+	CreatedAt      time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// BodyMetricRepository defines the interface for body metric data access
+type BodyMetricRepository interface {
+	// Create creates a new body metric entry
+	Create(m *BodyMetric) error
+
+	// GetByID retrieves a body metric entry by ID
+	GetByID(id int64) (*BodyMetric, error)
+
+	// GetByUserAndDate retrieves a user's entry for a date (nil when none)
+	GetByUserAndDate(userID int64, measuredOn string) (*BodyMetric, error)
+
+	// ListByUser retrieves a user's entries between two dates inclusive, oldest first (empty bound = open)
+	ListByUser(userID int64, startDate, endDate string) ([]*BodyMetric, error)
+
+	// Update updates a body metric entry
+	Update(m *BodyMetric) error
+
+	// Delete deletes a body metric entry
+	Delete(id int64) error
+}
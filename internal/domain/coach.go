@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Coach-athlete relationship statuses
+const (
+	CoachAthleteStatusPending  = "pending"  // Coach has invited, athlete has not responded
+	CoachAthleteStatusActive   = "active"   // Athlete accepted, coach has read access
+	CoachAthleteStatusDeclined = "declined" // Athlete declined the invite
+	CoachAthleteStatusRevoked  = "revoked"  // Athlete revoked previously granted access
+)
+
+// CoachAthlete represents a coach's read-only access to an athlete's logged data (coach_athletes table)
+// The coach invites, the athlete accepts, and only the athlete can revoke
+type CoachAthlete struct {
+	ID          int64      `json:"id" db:"id"`
+	CoachID     int64      `json:"coach_id" db:"coach_id"`                   // User who views the data
+	AthleteID   int64      `json:"athlete_id" db:"athlete_id"`               // User whose data is viewed
+	Status      string     `json:"status" db:"status"`                       // pending, active, declined, revoked
+	RespondedAt *time.Time `json:"responded_at,omitempty" db:"responded_at"` // When the athlete accepted or declined
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Related data (loaded via joins)
+	CoachName    string `json:"coach_name,omitempty" db:"-"`
+	CoachEmail   string `json:"coach_email,omitempty" db:"-"`
+	AthleteName  string `json:"athlete_name,omitempty" db:"-"`
+	AthleteEmail string `json:"athlete_email,omitempty" db:"-"`
+}
+
+// CoachAthleteRepository defines the interface for coach-athlete relationship data access
+type CoachAthleteRepository interface {
+	// Create creates a new coach-athlete relationship (invite)
+	Create(rel *CoachAthlete) error
+
+	// GetByID retrieves a relationship by ID
+	GetByID(id int64) (*CoachAthlete, error)
+
+	// GetByCoachAndAthlete retrieves the relationship between a coach and an athlete
+	GetByCoachAndAthlete(coachID, athleteID int64) (*CoachAthlete, error)
+
+	// ListByCoach retrieves relationships where the user is the coach (empty status = all)
+	ListByCoach(coachID int64, status string) ([]*CoachAthlete, error)
+
+	// ListByAthlete retrieves relationships where the user is the athlete (empty status = all)
+	ListByAthlete(athleteID int64, status string) ([]*CoachAthlete, error)
+
+	// Update updates the status and timestamps of a relationship
+	Update(rel *CoachAthlete) error
+}
@@ -9,7 +9,7 @@ type DataChangeLog struct {
 	EntityType   string    `json:"entity_type" db:"entity_type"`     // wod, movement, workout, user_workout, etc.
 	EntityID     int64     `json:"entity_id" db:"entity_id"`         // ID of the record that was changed
 	EntityName   string    `json:"entity_name" db:"entity_name"`     // Human-readable name (e.g., WOD name, movement name)
-	Operation    string    `json:"operation" db:"operation"`         // update, delete
+	Operation    string    `json:"operation" db:"operation"`         // create, update, delete, merge
 	UserID       int64     `json:"user_id" db:"user_id"`             // User who made the change
 	UserEmail    string    `json:"user_email" db:"user_email"`       // Email for display (denormalized)
 	BeforeValues *string   `json:"before_values" db:"before_values"` // JSON of record before change
@@ -21,8 +21,10 @@ type DataChangeLog struct {
 
 // Operation types
 const (
+	OperationCreate = "create" // Record added by an admin action such as a seed library sync; no before values
 	OperationUpdate = "update"
 	OperationDelete = "delete"
+	OperationMerge  = "merge" // Record merged into another; after values describe the target
 )
 
 // Entity types
@@ -34,6 +36,9 @@ const (
 	EntityTypeUserWorkoutMovement = "user_workout_movement"
 	EntityTypeUserWorkoutWOD      = "user_workout_wod"
 	EntityTypeUser                = "user"
+	EntityTypeEnumeration         = "enumeration"
+	EntityTypeWODVersion          = "wod_version"
+	EntityTypeWODVariant          = "wod_variant"
 )
 
 // DataChangeLogRepository defines the interface for data change log access
@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Enumeration categories: the fields whose allowed values admins manage
+const (
+	EnumerationWODSource    = "wod_source"
+	EnumerationWODType      = "wod_type"
+	EnumerationWODRegime    = "wod_regime"
+	EnumerationMovementType = "movement_type"
+)
+
+// EnumerationCategories lists the managed categories
+var EnumerationCategories = []string{EnumerationWODSource, EnumerationWODType, EnumerationWODRegime, EnumerationMovementType}
+
+// DefaultEnumerations are the values seeded into each empty category, in display order.
+// They are also used for validation when no enumeration service is wired (e.g. in tests).
+var DefaultEnumerations = map[string][]string{
+	EnumerationWODSource:    {"CrossFit", "Other Coach", "Self-recorded"},
+	EnumerationWODType:      {"Benchmark", "Hero", "Girl", "Notables", "Games", "Endurance", "Self-created"},
+	EnumerationWODRegime:    {"EMOM", "AMRAP", "Fastest Time", "Slowest Round", "Get Stronger", "Skills"},
+	EnumerationMovementType: {string(MovementTypeWeightlifting), string(MovementTypeBodyweight), string(MovementTypeCardio), string(MovementTypeGymnastics)},
+}
+
+// Enumeration is one allowed value of a WOD or movement field (enumerations table)
+// Deprecated values are kept on existing records but cannot be chosen for new ones.
+type Enumeration struct {
+	ID           int64     `json:"id" db:"id"`
+	Category     string    `json:"category" db:"category"`
+	Value        string    `json:"value" db:"value"` // Stored on WODs and movements; unique per category ignoring case
+	Description  *string   `json:"description,omitempty" db:"description"`
+	DisplayOrder int       `json:"display_order" db:"display_order"`
+	IsDeprecated bool      `json:"is_deprecated" db:"is_deprecated"`
+	InUse        int       `json:"in_use" db:"-"` // WODs or movements currently using the value
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// EnumerationRepository defines the interface for enumeration data access
+type EnumerationRepository interface {
+	Create(enumeration *Enumeration) error
+	GetByID(id int64) (*Enumeration, error)
+	// List lists a category's values (every category when empty) by category, display order and value
+	List(category string, includeDeprecated bool) ([]*Enumeration, error)
+	// Update saves an enumeration; when the value changed, the WODs or movements using the old
+	// value are renamed in the same transaction
+	Update(enumeration *Enumeration, previousValue string) error
+	Delete(id int64) error
+	// CountUsage counts the WODs or movements using a value of a category
+	CountUsage(category, value string) (int, error)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// Fitness profile domains
+const (
+	FitnessDomainStrength       = "strength"
+	FitnessDomainGymnastics     = "gymnastics"
+	FitnessDomainMonostructural = "monostructural"
+	FitnessDomainMetcon         = "metcon"
+)
+
+// Fitness standard kinds (what Name refers to)
+const (
+	FitnessKindMovement = "movement"
+	FitnessKindWOD      = "wod"
+)
+
+// Fitness standard metrics
+const (
+	FitnessMetricE1RM    = "e1rm"     // Best estimated 1RM of a movement
+	FitnessMetricMaxReps = "max_reps" // Most reps of a movement in one set
+	FitnessMetricTime    = "time"     // Fastest time in seconds (movement at Distance, or a WOD); lower is better
+	FitnessMetricRounds  = "rounds"   // Most rounds on a Rounds+Reps WOD
+)
+
+// FitnessStandard is a reference standard for one lift, skill, piece or benchmark WOD.
+// The four thresholds are the values reached at the beginner, intermediate, advanced and elite levels.
+type FitnessStandard struct {
+	ID           int64     `json:"id" db:"id"`
+	Domain       string    `json:"domain" db:"domain"`               // strength, gymnastics, monostructural, metcon
+	Name         string    `json:"name" db:"name"`                   // Movement or WOD name
+	Kind         string    `json:"kind" db:"kind"`                   // movement, wod
+	Metric       string    `json:"metric" db:"metric"`               // e1rm, max_reps, time, rounds
+	Distance     *float64  `json:"distance,omitempty" db:"distance"` // For timed movements (e.g. 2000 for a 2k row)
+	Gender       string    `json:"gender,omitempty" db:"gender"`     // male, female, or empty for everyone
+	Beginner     float64   `json:"beginner" db:"beginner"`
+	Intermediate float64   `json:"intermediate" db:"intermediate"`
+	Advanced     float64   `json:"advanced" db:"advanced"`
+	Elite        float64   `json:"elite" db:"elite"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// LowerIsBetter reports whether smaller values rank higher (timed standards)
+func (s *FitnessStandard) LowerIsBetter() bool {
+	return s.Metric == FitnessMetricTime
+}
+
+// FitnessStandardRepository defines the interface for fitness standard data access
+type FitnessStandardRepository interface {
+	// List returns all standards ordered by domain and name
+	List() ([]*FitnessStandard, error)
+
+	// Count returns the number of stored standards
+	Count() (int, error)
+
+	// ReplaceAll swaps the whole set of standards in one transaction
+	ReplaceAll(standards []*FitnessStandard) error
+}
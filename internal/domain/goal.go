@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// Goal types
+const (
+	GoalTypeMovement     = "movement"      // A lift or movement result (e.g., Back Squat 315 lb)
+	GoalTypeWOD          = "wod"           // A WOD score (e.g., Fran under 4:00)
+	GoalTypeWorkoutCount = "workout_count" // Number of logged workouts (e.g., 100 workouts this year)
+)
+
+// Goal metrics
+const (
+	GoalMetricMaxWeight = "max_weight" // Heaviest load logged for the movement
+	GoalMetricE1RM      = "e1rm"       // Best estimated 1RM for the movement
+	GoalMetricMaxReps   = "max_reps"   // Most reps in a single set of the movement
+	GoalMetricScore     = "score"      // WOD score in its unit (seconds, total reps or weight)
+	GoalMetricWorkouts  = "workouts"   // Workouts logged between the start and target dates
+)
+
+// Goal statuses
+const (
+	GoalStatusActive    = "active"
+	GoalStatusAchieved  = "achieved"
+	GoalStatusAbandoned = "abandoned"
+)
+
+// Goal is a personal target tied to a movement, a WOD or a workout count (goals table)
+type Goal struct {
+	ID                int64      `json:"id" db:"id"`
+	UserID            int64      `json:"user_id" db:"user_id"`
+	Title             string     `json:"title" db:"title"`
+	GoalType          string     `json:"goal_type" db:"goal_type"` // movement, wod, workout_count
+	MovementID        *int64     `json:"movement_id,omitempty" db:"movement_id"`
+	WODID             *int64     `json:"wod_id,omitempty" db:"wod_id"`
+	Metric            string     `json:"metric" db:"metric"` // max_weight, e1rm, max_reps, score, workouts
+	TargetValue       float64    `json:"target_value" db:"target_value"`
+	RepsPerRound      *int       `json:"reps_per_round,omitempty" db:"reps_per_round"` // Rounds+Reps WODs: target is total reps
+	StartDate         string     `json:"start_date" db:"start_date"`                   // YYYY-MM-DD
+	TargetDate        *string    `json:"target_date,omitempty" db:"target_date"`       // YYYY-MM-DD
+	BaselineValue     *float64   `json:"baseline_value,omitempty" db:"baseline_value"` // Best result before the start date
+	Status            string     `json:"status" db:"status"`                           // active, achieved, abandoned
+	AchievedAt        *time.Time `json:"achieved_at,omitempty" db:"achieved_at"`
+	AchievedWorkoutID *int64     `json:"achieved_workout_id,omitempty" db:"achieved_workout_id"` // Logged workout that reached the target
+	Notes             *string    `json:"notes,omitempty" db:"notes"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// GoalRepository defines the interface for goal data access
+type GoalRepository interface {
+	Create(goal *Goal) error
+	GetByID(id int64) (*Goal, error)
+	// ListByUser lists a user's goals, newest first; an empty status lists all
+	ListByUser(userID int64, status string) ([]*Goal, error)
+	Update(goal *Goal) error
+	Delete(id int64) error
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Leaderboard divisions recorded on WOD scores
+const (
+	DivisionRx     = "rx"
+	DivisionScaled = "scaled"
+)
+
+// Genders used for leaderboard brackets
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+// LeaderboardFilter narrows a WOD leaderboard
+// Zero values mean "no filter"
+type LeaderboardFilter struct {
+	WODIDs       []int64    // The WOD and its comparable variants; only results on each WOD's current comparable run rank
+	ScoreType    string     // WOD score type, decides ranking direction
+	StartDate    *time.Time // Inclusive workout date bounds
+	EndDate      *time.Time
+	Gender       string
+	BirthdayFrom *time.Time // Age bracket expressed as a birthday range (inclusive)
+	BirthdayTo   *time.Time
+	Division     string
+	Limit        int
+	Offset       int
+}
+
+// LeaderboardEntry is one athlete's best score on a WOD
+type LeaderboardEntry struct {
+	Rank          int       `json:"rank"`
+	UserID        int64     `json:"user_id"`
+	WODID         int64     `json:"wod_id"` // The leaderboard's WOD or a comparable variant of it
+	Name          string    `json:"name"`
+	Gender        *string   `json:"gender,omitempty"`
+	Division      *string   `json:"division,omitempty"`
+	ScoreValue    *string   `json:"score_value,omitempty"`
+	TimeSeconds   *int      `json:"time_seconds,omitempty"`
+	Rounds        *int      `json:"rounds,omitempty"`
+	Reps          *int      `json:"reps,omitempty"`
+	Weight        *float64  `json:"weight,omitempty"`
+	WorkoutDate   time.Time `json:"workout_date"`
+	UserWorkoutID int64     `json:"user_workout_id"`
+	WODScoreDetails
+}
+
+// LeaderboardRepository defines the interface for leaderboard queries
+type LeaderboardRepository interface {
+	// GetWODLeaderboard ranks opted-in users by their best score on a WOD
+	// Returns one page of entries and the total number of ranked users
+	GetWODLeaderboard(filter LeaderboardFilter) ([]*LeaderboardEntry, int, error)
+}
@@ -14,6 +14,39 @@ const (
 	MovementTypeGymnastics    MovementType = "gymnastics"
 )
 
+// Movement patterns (primary pattern of a movement)
+const (
+	MovementPatternSquat          = "squat"
+	MovementPatternHinge          = "hinge"
+	MovementPatternPush           = "push"
+	MovementPatternPull           = "pull"
+	MovementPatternLunge          = "lunge"
+	MovementPatternCarry          = "carry"
+	MovementPatternCore           = "core"
+	MovementPatternPlyometric     = "plyometric"
+	MovementPatternMonostructural = "monostructural"
+)
+
+// MovementPatterns lists the accepted movement patterns
+var MovementPatterns = []string{
+	MovementPatternSquat, MovementPatternHinge, MovementPatternPush, MovementPatternPull, MovementPatternLunge,
+	MovementPatternCarry, MovementPatternCore, MovementPatternPlyometric, MovementPatternMonostructural,
+}
+
+// MovementEquipment lists the accepted equipment values
+var MovementEquipment = []string{
+	"barbell", "dumbbell", "kettlebell", "plate", "medicine_ball", "slam_ball", "sandbag", "weighted_vest",
+	"rower", "bike", "ski_erg", "jump_rope", "battle_rope",
+	"pull_up_bar", "rings", "parallettes", "rope", "box", "wall", "bench", "ghd", "abmat",
+	"sled", "yoke", "atlas_stone", "tire", "sledgehammer",
+}
+
+// MuscleGroups lists the accepted muscle group values
+var MuscleGroups = []string{
+	"quads", "hamstrings", "glutes", "calves", "hip_flexors", "core", "lower_back",
+	"upper_back", "lats", "traps", "chest", "shoulders", "biceps", "triceps", "forearms",
+}
+
 // Movement represents a specific exercise or movement (movements table)
 type Movement struct {
 	ID          int64        `json:"id" db:"id"`
@@ -24,6 +57,17 @@ type Movement struct {
 	CreatedBy   *int64       `json:"created_by,omitempty" db:"created_by"` // User ID if custom
 	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+
+	// Taxonomy
+	Equipment    []string `json:"equipment" db:"equipment"`           // Stored comma-separated; values from MovementEquipment
+	Pattern      string   `json:"pattern,omitempty" db:"pattern"`     // One of MovementPatterns
+	MuscleGroups []string `json:"muscle_groups" db:"muscle_groups"`   // Stored comma-separated; values from MuscleGroups
+	ParentID     *int64   `json:"parent_id,omitempty" db:"parent_id"` // Movement this is a variant of (e.g. Power Clean -> Clean); parents are never variants themselves
+}
+
+// HasTaxonomy reports whether any taxonomy field is set
+func (m *Movement) HasTaxonomy() bool {
+	return len(m.Equipment) > 0 || m.Pattern != "" || len(m.MuscleGroups) > 0 || m.ParentID != nil
 }
 
 // MovementWithCreator extends Movement with creator information (for admin views)
@@ -58,18 +102,28 @@ type WorkoutMovement struct {
 // MovementRepository defines the interface for movement data access
 type MovementRepository interface {
 	Create(movement *Movement) error
+	// CreateInLibrary creates a new custom movement and adds it to a gym library in one transaction
+	CreateInLibrary(movement *Movement, orgID, addedBy int64) error
 	GetByID(id int64) (*Movement, error)
 	GetByName(name string) (*Movement, error)
-	ListAll() ([]*Movement, error)
+	// ListAll lists movements matching the filters; nil lists every movement.
+	// Filters: "type", "equipment", "pattern", "muscle_group" (string), "parent_id" (int64; variants of a
+	// movement), "family_id" (int64; a movement and its variants), "is_standard" (bool)
+	ListAll(filters map[string]interface{}) ([]*Movement, error)
 	ListStandard() ([]*Movement, error)
 	ListByUser(userID int64) ([]*Movement, error)
 	ListAllUserCreated() ([]*Movement, error)
 	ListAllUserCreatedWithUserInfo() ([]*MovementWithCreator, error)
-	ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, movementType, creator string) ([]*MovementWithCreator, int64, error)
+	ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, movementType, creator string, orgID int64) ([]*MovementWithCreator, int64, error)
 	CountAllUserCreated() (int64, error)
 	Update(movement *Movement) error
+	// UpdateStandard updates an existing standard movement (for admin edits and the seed library)
+	UpdateStandard(movement *Movement) error
+	// UpdateTaxonomy sets the equipment, pattern, muscle groups and parent of any movement, standard or custom
+	UpdateTaxonomy(movement *Movement) error
 	Delete(id int64) error
-	Search(query string, limit int) ([]*Movement, error)
+	// Search finds movements whose name contains query, narrowed by the same filters as ListAll
+	Search(query string, filters map[string]interface{}, limit int) ([]*Movement, error)
 	CopyToStandard(id int64, newName string) (*Movement, error)
 }
 
@@ -156,4 +210,7 @@ type UserWorkoutMovementRepository interface {
 
 	// UpdatePRFlag updates the is_pr flag for a user workout movement
 	UpdatePRFlag(id int64, isPR bool) error
+
+	// GetByUserIDAndMovementID retrieves performance history for a movement, newest first
+	GetByUserIDAndMovementID(userID, movementID int64, limit int) ([]*UserWorkoutMovement, error)
 }
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Alias entity types
+const (
+	AliasEntityMovement = "movement"
+	AliasEntityWOD      = "wod"
+)
+
+// NameAlias is an alternative name that resolves to a movement or WOD (name_aliases table)
+// e.g. "BS" and "Back Squats" both resolving to the Back Squat movement
+type NameAlias struct {
+	ID              int64     `json:"id" db:"id"`
+	EntityType      string    `json:"entity_type" db:"entity_type"` // movement, wod
+	EntityID        int64     `json:"entity_id" db:"entity_id"`
+	Alias           string    `json:"alias" db:"alias"`
+	NormalizedAlias string    `json:"-" db:"normalized_alias"` // NormalizeName(Alias); unique per entity type
+	CreatedBy       *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// NameCandidate is an existing movement or WOD that an imported name may refer to
+type NameCandidate struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	IsStandard   bool    `json:"is_standard"`
+	MatchedAlias string  `json:"matched_alias,omitempty"` // Set when the candidate was found through one of its aliases
+	Score        float64 `json:"score"`                   // Similarity from 0 to 1; 1 is an exact name or alias match
+}
+
+// NameSuggestion lists likely matches for an imported name that did not resolve to an existing record
+type NameSuggestion struct {
+	EntityType string           `json:"entity_type"` // movement, wod
+	Name       string           `json:"name"`
+	Candidates []*NameCandidate `json:"candidates"`
+}
+
+// NameAliasRepository defines the interface for movement and WOD alias data access
+type NameAliasRepository interface {
+	Create(alias *NameAlias) error
+	GetByID(id int64) (*NameAlias, error)
+	// GetByNormalized finds the alias of an entity type with the given normalized name
+	GetByNormalized(entityType, normalized string) (*NameAlias, error)
+	ListByEntity(entityType string, entityID int64) ([]*NameAlias, error)
+	ListByType(entityType string) ([]*NameAlias, error)
+	Delete(id int64) error
+	// CountReferences counts, per table, the rows referencing any of the given movements or WODs
+	CountReferences(entityType string, ids []int64) (map[string]int64, error)
+	// ListReferencingUserIDs lists the users with logged performances of any of the given movements or WODs
+	ListReferencingUserIDs(entityType string, ids []int64) ([]int64, error)
+	// MergeEntities repoints every reference to the source movements or WODs onto the target, moves
+	// the sources' aliases, keeps the source names as aliases of the target and deletes the sources,
+	// all in one transaction. It returns the number of rows repointed per table.
+	MergeEntities(entityType string, sourceIDs []int64, targetID int64) (map[string]int64, error)
+}
+
+// NormalizeName reduces a movement or WOD name to a comparison key that ignores case, spacing and
+// punctuation, so "Back Squat", "back-squat" and "BackSquat" all compare equal
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
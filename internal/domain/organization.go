@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+// Organization member roles
+const (
+	OrganizationRoleMember = "member" // Regular athlete at the gym
+	OrganizationRoleAdmin  = "admin"  // Gym admin: manages members and the gym library
+)
+
+// Organization library entity types
+const (
+	LibraryEntityWOD      = "wod"
+	LibraryEntityMovement = "movement"
+)
+
+// Organization represents a gym/affiliate (organizations table)
+// Users belong to one or more gyms; each gym curates its own library on top of the global standard set
+type Organization struct {
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Slug        string    `json:"slug" db:"slug"` // URL-friendly unique identifier
+	Description string    `json:"description,omitempty" db:"description"`
+	CreatedBy   *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data (loaded for the current user's memberships)
+	Role string `json:"role,omitempty" db:"-"`
+}
+
+// OrganizationMember represents a user's membership in a gym (organization_members table)
+type OrganizationMember struct {
+	ID             int64     `json:"id" db:"id"`
+	OrganizationID int64     `json:"organization_id" db:"organization_id"`
+	UserID         int64     `json:"user_id" db:"user_id"`
+	Role           string    `json:"role" db:"role"` // member, admin
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data (loaded via joins)
+	UserName  string `json:"user_name,omitempty" db:"-"`
+	UserEmail string `json:"user_email,omitempty" db:"-"`
+}
+
+// OrganizationRepository defines the interface for gym data access
+type OrganizationRepository interface {
+	// Create creates a new organization
+	Create(org *Organization) error
+
+	// GetByID retrieves an organization by ID
+	GetByID(id int64) (*Organization, error)
+
+	// GetBySlug retrieves an organization by slug
+	GetBySlug(slug string) (*Organization, error)
+
+	// List retrieves all organizations
+	List() ([]*Organization, error)
+
+	// ListByUser retrieves the organizations a user belongs to, with the user's role
+	ListByUser(userID int64) ([]*Organization, error)
+
+	// Update updates an organization's name, slug and description
+	Update(org *Organization) error
+
+	// Delete deletes an organization (memberships and library links cascade)
+	Delete(id int64) error
+
+	// AddMember adds a user to an organization
+	AddMember(member *OrganizationMember) error
+
+	// GetMember retrieves a user's membership in an organization
+	GetMember(orgID, userID int64) (*OrganizationMember, error)
+
+	// ListMembers retrieves all members of an organization
+	ListMembers(orgID int64) ([]*OrganizationMember, error)
+
+	// UpdateMemberRole changes a member's role
+	UpdateMemberRole(orgID, userID int64, role string) error
+
+	// RemoveMember removes a user from an organization
+	RemoveMember(orgID, userID int64) error
+
+	// AddLibraryItem adds a WOD or movement to the gym library
+	AddLibraryItem(orgID int64, entityType string, entityID int64, addedBy int64) error
+
+	// RemoveLibraryItem removes a WOD or movement from the gym library
+	RemoveLibraryItem(orgID int64, entityType string, entityID int64) error
+
+	// ListLibraryWODs retrieves the WODs curated by the gym
+	ListLibraryWODs(orgID int64) ([]*WOD, error)
+
+	// ListLibraryMovements retrieves the movements curated by the gym
+	ListLibraryMovements(orgID int64) ([]*Movement, error)
+}
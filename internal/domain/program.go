@@ -0,0 +1,121 @@
+package domain
+
+import "time"
+
+// Progression rule types
+const (
+	ProgressionLinear     = "linear"      // Base weight + fixed increment per week (e.g., +5 lb/week)
+	ProgressionPercent1RM = "percent_1rm" // Percentage of the athlete's estimated 1RM per week
+)
+
+// Program enrollment statuses
+const (
+	EnrollmentStatusActive    = "active"
+	EnrollmentStatusCompleted = "completed"
+	EnrollmentStatusCancelled = "cancelled"
+)
+
+// Program represents a multi-week training program built from workout templates (programs table)
+// Standard programs have CreatedBy = NULL; users can create their own
+type Program struct {
+	ID          int64     `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	Weeks       int       `json:"weeks" db:"weeks"`
+	CreatedBy   *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data (loaded with the program)
+	Sessions     []*ProgramSession     `json:"sessions,omitempty" db:"-"`
+	Progressions []*ProgramProgression `json:"progressions,omitempty" db:"-"`
+}
+
+// ProgramSession is one day of a program referencing a workout template (program_sessions table)
+type ProgramSession struct {
+	ID        int64   `json:"id" db:"id"`
+	ProgramID int64   `json:"program_id" db:"program_id"`
+	Week      int     `json:"week" db:"week"` // 1-based
+	Day       int     `json:"day" db:"day"`   // 1-based day within the week (1-7)
+	WorkoutID int64   `json:"workout_id" db:"workout_id"`
+	Notes     *string `json:"notes,omitempty" db:"notes"`
+
+	// Related data (loaded via joins)
+	WorkoutName string `json:"workout_name,omitempty" db:"-"`
+}
+
+// ProgramProgression is a per-movement load progression rule (program_progressions table)
+type ProgramProgression struct {
+	ID          int64     `json:"id" db:"id"`
+	ProgramID   int64     `json:"program_id" db:"program_id"`
+	MovementID  int64     `json:"movement_id" db:"movement_id"`
+	RuleType    string    `json:"rule_type" db:"rule_type"`               // linear, percent_1rm
+	BaseWeight  *float64  `json:"base_weight,omitempty" db:"base_weight"` // Linear: week 1 load (NULL = template weight)
+	Increment   *float64  `json:"increment,omitempty" db:"increment"`     // Linear: load added each week
+	Percentages []float64 `json:"percentages,omitempty" db:"percentages"` // Percent of 1RM per week (0.70 = 70%), last value repeats
+	RoundTo     float64   `json:"round_to" db:"round_to"`                 // Round prescribed loads to this increment (e.g., 5)
+
+	// Related data (loaded via joins)
+	MovementName string `json:"movement_name,omitempty" db:"-"`
+}
+
+// ProgramEnrollment is a user's run through a program from a start date (program_enrollments table)
+type ProgramEnrollment struct {
+	ID          int64      `json:"id" db:"id"`
+	ProgramID   int64      `json:"program_id" db:"program_id"`
+	UserID      int64      `json:"user_id" db:"user_id"`
+	StartDate   string     `json:"start_date" db:"start_date"` // YYYY-MM-DD
+	Status      string     `json:"status" db:"status"`         // active, completed, cancelled
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Related data (loaded via joins)
+	ProgramName string `json:"program_name,omitempty" db:"-"`
+}
+
+// ProgramSessionCompletion records that an enrolled user completed a program session (program_session_completions table)
+type ProgramSessionCompletion struct {
+	ID            int64     `json:"id" db:"id"`
+	EnrollmentID  int64     `json:"enrollment_id" db:"enrollment_id"`
+	SessionID     int64     `json:"session_id" db:"session_id"`
+	UserWorkoutID *int64    `json:"user_workout_id,omitempty" db:"user_workout_id"` // The logged workout, if any
+	CompletedAt   time.Time `json:"completed_at" db:"completed_at"`
+}
+
+// ProgramRepository defines the interface for training program data access
+type ProgramRepository interface {
+	// Create creates a program with its sessions and progressions
+	Create(program *Program) error
+
+	// GetByID retrieves a program with its sessions and progressions
+	GetByID(id int64) (*Program, error)
+
+	// ListForUser retrieves standard programs and programs created by the user
+	ListForUser(userID int64) ([]*Program, error)
+
+	// Update updates a program and replaces its progressions
+	// Sessions are matched by week and day and updated in place so completions are kept
+	Update(program *Program) error
+
+	// Delete deletes a program and its sessions, progressions and enrollments
+	Delete(id int64) error
+
+	// CreateEnrollment enrolls a user in a program
+	CreateEnrollment(enrollment *ProgramEnrollment) error
+
+	// GetEnrollment retrieves an enrollment by ID
+	GetEnrollment(id int64) (*ProgramEnrollment, error)
+
+	// ListEnrollmentsByUser retrieves a user's enrollments (empty status = all)
+	ListEnrollmentsByUser(userID int64, status string) ([]*ProgramEnrollment, error)
+
+	// UpdateEnrollment updates an enrollment's status
+	UpdateEnrollment(enrollment *ProgramEnrollment) error
+
+	// CreateCompletion marks a session as completed for an enrollment
+	CreateCompletion(completion *ProgramSessionCompletion) error
+
+	// ListCompletions retrieves the completed sessions of an enrollment
+	ListCompletions(enrollmentID int64) ([]*ProgramSessionCompletion, error)
+}
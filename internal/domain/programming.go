@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ScheduledDateFormat is the calendar date format used for programming (YYYY-MM-DD)
+const ScheduledDateFormat = "2006-01-02"
+
+// ScheduledWorkout is a workout template or WOD a gym coach published for a date (scheduled_workouts table)
+// Track separates parallel programming streams on the same day (e.g., Performance, Fitness, Endurance)
+type ScheduledWorkout struct {
+	ID             int64     `json:"id" db:"id"`
+	OrganizationID int64     `json:"organization_id" db:"organization_id"`
+	ScheduledDate  string    `json:"scheduled_date" db:"scheduled_date"` // YYYY-MM-DD (gym's calendar day)
+	Track          string    `json:"track,omitempty" db:"track"`         // Empty for the default track
+	WorkoutID      *int64    `json:"workout_id,omitempty" db:"workout_id"`
+	WODID          *int64    `json:"wod_id,omitempty" db:"wod_id"`
+	Title          string    `json:"title,omitempty" db:"title"` // Optional display title (defaults to template/WOD name)
+	Notes          *string   `json:"notes,omitempty" db:"notes"` // Coach notes (scaling, stimulus, etc.)
+	CreatedBy      *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data (loaded via joins)
+	WorkoutName string   `json:"workout_name,omitempty" db:"-"`
+	WODName     string   `json:"wod_name,omitempty" db:"-"`
+	Workout     *Workout `json:"workout,omitempty" db:"-"` // Template with movements and WODs (today's view)
+	WOD         *WOD     `json:"wod,omitempty" db:"-"`
+}
+
+// ScheduledWorkoutRepository defines the interface for programming calendar data access
+type ScheduledWorkoutRepository interface {
+	// Create creates a new scheduled workout
+	Create(sw *ScheduledWorkout) error
+
+	// GetByID retrieves a scheduled workout by ID
+	GetByID(id int64) (*ScheduledWorkout, error)
+
+	// ListByOrganization retrieves a gym's scheduled workouts between two dates inclusive (empty track = all tracks)
+	ListByOrganization(orgID int64, startDate, endDate string, track string) ([]*ScheduledWorkout, error)
+
+	// Update updates a scheduled workout
+	Update(sw *ScheduledWorkout) error
+
+	// Delete deletes a scheduled workout
+	Delete(id int64) error
+}
@@ -0,0 +1,482 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WOD score types (stored in wods.score_type and user_workout_wods.score_type)
+const (
+	ScoreTypeTime       = "Time (HH:MM:SS)"
+	ScoreTypeRoundsReps = "Rounds+Reps"
+	ScoreTypeMaxWeight  = "Max Weight" // Load
+	ScoreTypeReps       = "Reps"
+	ScoreTypeCalories   = "Calories"
+	ScoreTypeDistance   = "Distance"
+	ScoreTypePoints     = "Points"
+)
+
+// Score fields are the user_workout_wods columns a score type stores its result in
+const (
+	ScoreFieldTimeSeconds = "time_seconds"
+	ScoreFieldRounds      = "rounds"
+	ScoreFieldReps        = "reps"
+	ScoreFieldWeight      = "weight"
+	ScoreFieldCalories    = "calories"
+	ScoreFieldDistance    = "distance"
+	ScoreFieldPoints      = "points"
+)
+
+// Score text patterns: rounds+reps ("12+7" or "12") and a capped time result ("CAP+12")
+var (
+	roundsRepsPattern = regexp.MustCompile(`^(\d+)\s*(?:\+\s*(\d+))?$`)
+	cappedTimePattern = regexp.MustCompile(`(?i)^cap\s*\+?\s*(\d+)$`)
+)
+
+// scoreFields lists every score field in the order they are reported
+var scoreFields = []string{
+	ScoreFieldTimeSeconds, ScoreFieldRounds, ScoreFieldReps, ScoreFieldWeight,
+	ScoreFieldCalories, ScoreFieldDistance, ScoreFieldPoints,
+}
+
+// WODScoreDetails holds the score fields added for extended score types (all optional)
+type WODScoreDetails struct {
+	Calories        *int     `json:"calories,omitempty" db:"calories"`
+	Distance        *float64 `json:"distance,omitempty" db:"distance"`                   // Meters
+	Points          *float64 `json:"points,omitempty" db:"points"`                       // Judged or competition points
+	TieBreakSeconds *int     `json:"tie_break_seconds,omitempty" db:"tie_break_seconds"` // Lower wins when scores tie
+	TimeCapped      bool     `json:"time_capped,omitempty" db:"time_capped"`             // Time cap hit; reps holds the reps completed at the cap
+}
+
+// ScoreRank is one field a score type ranks by
+type ScoreRank struct {
+	Field      string `json:"field"`
+	Descending bool   `json:"descending"` // Higher values rank first
+}
+
+// ScoreTypeDefinition describes how a score type is stored, ranked, formatted and parsed
+type ScoreTypeDefinition struct {
+	Name             string      `json:"name"`
+	Unit             string      `json:"unit,omitempty"`
+	Fields           []string    `json:"fields"`                    // Fields that must be filled
+	OptionalFields   []string    `json:"optional_fields,omitempty"` // Fields that may also be filled
+	Rank             []ScoreRank `json:"rank"`                      // Most significant first
+	CappedRank       []ScoreRank `json:"capped_rank,omitempty"`     // Ranking of time-capped results, which always follow finishers
+	LowerIsBetter    bool        `json:"lower_is_better"`
+	SupportsTimeCap  bool        `json:"supports_time_cap"`
+	SupportsTieBreak bool        `json:"supports_tie_break"`
+	Example          string      `json:"example"`
+
+	format func(w *UserWorkoutWOD) string
+	parse  func(s string, w *UserWorkoutWOD) error
+}
+
+var scoreTypeRegistry = []*ScoreTypeDefinition{
+	{
+		Name:             ScoreTypeTime,
+		Unit:             "seconds",
+		Fields:           []string{ScoreFieldTimeSeconds},
+		Rank:             []ScoreRank{{Field: ScoreFieldTimeSeconds}},
+		CappedRank:       []ScoreRank{{Field: ScoreFieldReps, Descending: true}},
+		LowerIsBetter:    true,
+		SupportsTimeCap:  true,
+		SupportsTieBreak: true,
+		Example:          "12:34 or CAP+15",
+		format: func(w *UserWorkoutWOD) string {
+			if w.TimeCapped {
+				return fmt.Sprintf("CAP+%d", intValue(w.Reps))
+			}
+			return FormatScoreDuration(intValue(w.TimeSeconds))
+		},
+		parse: parseTimeScore,
+	},
+	{
+		Name:             ScoreTypeRoundsReps,
+		Unit:             "rounds",
+		Fields:           []string{ScoreFieldRounds},
+		OptionalFields:   []string{ScoreFieldReps},
+		Rank:             []ScoreRank{{Field: ScoreFieldRounds, Descending: true}, {Field: ScoreFieldReps, Descending: true}},
+		SupportsTieBreak: true,
+		Example:          "12+7",
+		format: func(w *UserWorkoutWOD) string {
+			return fmt.Sprintf("%d+%d", intValue(w.Rounds), intValue(w.Reps))
+		},
+		parse: func(s string, w *UserWorkoutWOD) error {
+			m := roundsRepsPattern.FindStringSubmatch(s)
+			if m == nil {
+				return fmt.Errorf("expected rounds+reps such as 12+7")
+			}
+			rounds, _ := strconv.Atoi(m[1])
+			reps := 0
+			if m[2] != "" {
+				reps, _ = strconv.Atoi(m[2])
+			}
+			w.Rounds, w.Reps = &rounds, &reps
+			return nil
+		},
+	},
+	{
+		Name:    ScoreTypeMaxWeight,
+		Unit:    "lb",
+		Fields:  []string{ScoreFieldWeight},
+		Rank:    []ScoreRank{{Field: ScoreFieldWeight, Descending: true}},
+		Example: "225 lb",
+		format: func(w *UserWorkoutWOD) string {
+			return fmt.Sprintf("%s lb", formatScoreNumber(floatValue(w.Weight)))
+		},
+		parse: func(s string, w *UserWorkoutWOD) error {
+			v, err := parseScoreNumber(s, "lbs", "lb", "#")
+			if err != nil {
+				return err
+			}
+			w.Weight = &v
+			return nil
+		},
+	},
+	{
+		Name:             ScoreTypeReps,
+		Unit:             "reps",
+		Fields:           []string{ScoreFieldReps},
+		Rank:             []ScoreRank{{Field: ScoreFieldReps, Descending: true}},
+		SupportsTieBreak: true,
+		Example:          "150 reps",
+		format: func(w *UserWorkoutWOD) string {
+			return fmt.Sprintf("%d reps", intValue(w.Reps))
+		},
+		parse: func(s string, w *UserWorkoutWOD) error {
+			v, err := parseScoreInt(s, "reps", "rep")
+			if err != nil {
+				return err
+			}
+			w.Reps = &v
+			return nil
+		},
+	},
+	{
+		Name:             ScoreTypeCalories,
+		Unit:             "cal",
+		Fields:           []string{ScoreFieldCalories},
+		Rank:             []ScoreRank{{Field: ScoreFieldCalories, Descending: true}},
+		SupportsTieBreak: true,
+		Example:          "85 cal",
+		format: func(w *UserWorkoutWOD) string {
+			return fmt.Sprintf("%d cal", intValue(w.Calories))
+		},
+		parse: func(s string, w *UserWorkoutWOD) error {
+			v, err := parseScoreInt(s, "calories", "calorie", "cals", "cal")
+			if err != nil {
+				return err
+			}
+			w.Calories = &v
+			return nil
+		},
+	},
+	{
+		Name:             ScoreTypeDistance,
+		Unit:             "m",
+		Fields:           []string{ScoreFieldDistance},
+		Rank:             []ScoreRank{{Field: ScoreFieldDistance, Descending: true}},
+		SupportsTieBreak: true,
+		Example:          "5000 m or 5 km",
+		format: func(w *UserWorkoutWOD) string {
+			return fmt.Sprintf("%s m", formatScoreNumber(floatValue(w.Distance)))
+		},
+		parse: func(s string, w *UserWorkoutWOD) error {
+			lower := strings.ToLower(strings.TrimSpace(s))
+			multiplier := 1.0
+			switch {
+			case strings.HasSuffix(lower, "km"):
+				lower, multiplier = strings.TrimSuffix(lower, "km"), 1000
+			case strings.HasSuffix(lower, "mi"):
+				lower, multiplier = strings.TrimSuffix(lower, "mi"), 1609.344
+			}
+			v, err := parseScoreNumber(lower, "meters", "meter", "m")
+			if err != nil {
+				return err
+			}
+			v = math.Round(v*multiplier*100) / 100
+			w.Distance = &v
+			return nil
+		},
+	},
+	{
+		Name:             ScoreTypePoints,
+		Unit:             "pts",
+		Fields:           []string{ScoreFieldPoints},
+		Rank:             []ScoreRank{{Field: ScoreFieldPoints, Descending: true}},
+		SupportsTieBreak: true,
+		Example:          "42 pts",
+		format: func(w *UserWorkoutWOD) string {
+			return fmt.Sprintf("%s pts", formatScoreNumber(floatValue(w.Points)))
+		},
+		parse: func(s string, w *UserWorkoutWOD) error {
+			v, err := parseScoreNumber(s, "points", "point", "pts", "pt")
+			if err != nil {
+				return err
+			}
+			w.Points = &v
+			return nil
+		},
+	},
+}
+
+// ScoreTypes returns every registered score type
+func ScoreTypes() []*ScoreTypeDefinition {
+	return scoreTypeRegistry
+}
+
+// ScoreTypeNames returns the names of every registered score type
+func ScoreTypeNames() []string {
+	names := make([]string, len(scoreTypeRegistry))
+	for i, def := range scoreTypeRegistry {
+		names[i] = def.Name
+	}
+	return names
+}
+
+// LookupScoreType returns the definition for a score type name, or nil when it is not registered
+func LookupScoreType(name string) *ScoreTypeDefinition {
+	for _, def := range scoreTypeRegistry {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// Validate checks a result fills the fields this score type needs and none it does not use
+func (d *ScoreTypeDefinition) Validate(w *UserWorkoutWOD) error {
+	required := d.Fields
+	allowed := append(append([]string{}, d.Fields...), d.OptionalFields...)
+	if w.TimeCapped {
+		if !d.SupportsTimeCap {
+			return fmt.Errorf("time cap is not supported")
+		}
+		// A capped result is scored by reps at the cap; the time (usually the cap) is optional
+		required = []string{ScoreFieldReps}
+		allowed = append(allowed, ScoreFieldReps)
+	}
+
+	for _, field := range required {
+		if _, ok := w.ScoreFieldValue(field); !ok {
+			return fmt.Errorf("%s is missing", field)
+		}
+	}
+
+	var invalid []string
+	for _, field := range scoreFields {
+		if _, ok := w.ScoreFieldValue(field); ok && !containsString(allowed, field) {
+			invalid = append(invalid, field)
+		}
+	}
+	if w.TieBreakSeconds != nil && !d.SupportsTieBreak {
+		invalid = append(invalid, "tie_break_seconds")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("contains invalid fields (%s)", strings.Join(invalid, "/"))
+	}
+
+	if w.TieBreakSeconds != nil && *w.TieBreakSeconds < 0 {
+		return fmt.Errorf("tie_break_seconds must not be negative")
+	}
+	return nil
+}
+
+// IsScored reports whether a result holds enough data to be ranked
+func (d *ScoreTypeDefinition) IsScored(w *UserWorkoutWOD) bool {
+	if w.TimeCapped && d.SupportsTimeCap {
+		_, ok := w.ScoreFieldValue(ScoreFieldReps)
+		return ok
+	}
+	for _, field := range d.Fields {
+		if _, ok := w.ScoreFieldValue(field); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare ranks two results: positive when a is better than b, negative when worse, 0 when tied.
+// Unscored results rank last. Tie-breaks are left to leaderboards; a PR has to beat the score itself.
+func (d *ScoreTypeDefinition) Compare(a, b *UserWorkoutWOD) int {
+	aScored, bScored := a != nil && d.IsScored(a), b != nil && d.IsScored(b)
+	switch {
+	case !aScored && !bScored:
+		return 0
+	case !bScored:
+		return 1
+	case !aScored:
+		return -1
+	}
+
+	if d.SupportsTimeCap && a.TimeCapped != b.TimeCapped {
+		// Finishing under the cap beats any capped result
+		if b.TimeCapped {
+			return 1
+		}
+		return -1
+	}
+
+	rank := d.Rank
+	if d.SupportsTimeCap && a.TimeCapped {
+		rank = d.CappedRank
+	}
+	for _, r := range rank {
+		av, _ := a.ScoreFieldValue(r.Field)
+		bv, _ := b.ScoreFieldValue(r.Field)
+		if av == bv {
+			continue
+		}
+		if (av > bv) == r.Descending {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// Format renders a result as a display score (e.g. "12:34", "CAP+15", "12+7", "85 cal")
+// Returns an empty string when the result is not scored
+func (d *ScoreTypeDefinition) Format(w *UserWorkoutWOD) string {
+	if !d.IsScored(w) {
+		return ""
+	}
+	return d.format(w)
+}
+
+// Parse reads a display score into the result's score fields
+func (d *ScoreTypeDefinition) Parse(s string, w *UserWorkoutWOD) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return fmt.Errorf("score is empty")
+	}
+	if err := d.parse(s, w); err != nil {
+		return fmt.Errorf("invalid %s score %q: %w", d.Name, s, err)
+	}
+	return nil
+}
+
+// ScoreFieldValue returns a score field as a number and whether it is set
+func (w *UserWorkoutWOD) ScoreFieldValue(field string) (float64, bool) {
+	switch field {
+	case ScoreFieldTimeSeconds:
+		return intField(w.TimeSeconds)
+	case ScoreFieldRounds:
+		return intField(w.Rounds)
+	case ScoreFieldReps:
+		return intField(w.Reps)
+	case ScoreFieldWeight:
+		return floatField(w.Weight)
+	case ScoreFieldCalories:
+		return intField(w.Calories)
+	case ScoreFieldDistance:
+		return floatField(w.Distance)
+	case ScoreFieldPoints:
+		return floatField(w.Points)
+	}
+	return 0, false
+}
+
+// FormatScoreDuration formats seconds as M:SS, or H:MM:SS from an hour up
+func FormatScoreDuration(seconds int) string {
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// parseTimeScore reads "MM:SS", "HH:MM:SS", plain seconds, or "CAP+reps" for a capped result
+func parseTimeScore(s string, w *UserWorkoutWOD) error {
+	if m := cappedTimePattern.FindStringSubmatch(s); m != nil {
+		reps, _ := strconv.Atoi(m[1])
+		w.TimeCapped = true
+		w.Reps = &reps
+		return nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return fmt.Errorf("expected MM:SS or HH:MM:SS")
+	}
+	total := 0
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || (i > 0 && v > 59) {
+			return fmt.Errorf("expected MM:SS or HH:MM:SS")
+		}
+		total = total*60 + v
+	}
+	w.TimeSeconds = &total
+	return nil
+}
+
+// parseScoreNumber reads a non-negative number with an optional trailing unit
+func parseScoreNumber(s string, units ...string) (float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, unit := range units {
+		if strings.HasSuffix(s, unit) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit))
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, fmt.Errorf("expected a number")
+	}
+	return v, nil
+}
+
+// parseScoreInt reads a non-negative whole number with an optional trailing unit
+func parseScoreInt(s string, units ...string) (int, error) {
+	v, err := parseScoreNumber(s, units...)
+	if err != nil || v != math.Trunc(v) {
+		return 0, fmt.Errorf("expected a whole number")
+	}
+	return int(v), nil
+}
+
+// formatScoreNumber drops trailing zeros ("225", "5000.5")
+func formatScoreNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func intField(v *int) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return float64(*v), true
+}
+
+func floatField(v *float64) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func floatValue(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Search entity types
+const (
+	SearchEntityMovement = "movement"
+	SearchEntityWOD      = "wod"
+	SearchEntityTemplate = "template" // Workout templates (workouts table)
+	SearchEntityWorkout  = "workout"  // A user's logged workouts and their notes (user_workouts table)
+)
+
+// SearchEntityTypes lists the searchable entity types
+var SearchEntityTypes = []string{SearchEntityMovement, SearchEntityWOD, SearchEntityTemplate, SearchEntityWorkout}
+
+// Search backends, one per database driver
+const (
+	SearchBackendFTS5     = "fts5"     // SQLite FTS5 with bm25 ranking
+	SearchBackendLike     = "like"     // SQLite built without FTS5; LIKE matching ranked in Go
+	SearchBackendTSVector = "tsvector" // PostgreSQL tsvector with ts_rank
+	SearchBackendFulltext = "fulltext" // MySQL FULLTEXT in boolean mode
+)
+
+// maxSearchTerms bounds the terms taken from one query
+const maxSearchTerms = 8
+
+// SearchDocument is the indexed text of one movement, WOD, template or logged workout (search_documents table)
+type SearchDocument struct {
+	ID         int64     `json:"id" db:"id"`
+	EntityType string    `json:"entity_type" db:"entity_type"`
+	EntityID   int64     `json:"entity_id" db:"entity_id"`
+	OwnerID    *int64    `json:"owner_id,omitempty" db:"owner_id"` // NULL for standard records visible to everyone
+	Title      string    `json:"title" db:"title"`
+	Body       string    `json:"body" db:"body"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SearchResult is one ranked match
+type SearchResult struct {
+	EntityType string  `json:"type"`
+	EntityID   int64   `json:"id"`
+	Title      string  `json:"title"`
+	Snippet    string  `json:"snippet,omitempty"` // Part of the body around the first matched term
+	Score      float64 `json:"score"`             // Backend relevance; higher is better, only comparable within one search
+}
+
+// SearchRepository maintains the full-text index and runs ranked searches over it
+type SearchRepository interface {
+	// Search returns documents matching every term, visible to the user and of the given types
+	// (all types when empty), best match first
+	Search(userID int64, terms []string, entityTypes []string, limit int) ([]*SearchResult, error)
+
+	// Refresh re-reads one record from its source table and updates its document;
+	// the document is removed when the record no longer exists
+	Refresh(entityType string, entityID int64) error
+
+	// Rebuild replaces every document from the source tables and returns the number indexed
+	Rebuild() (int, error)
+
+	// NeedsRebuild reports whether the index is empty or out of step with the documents
+	NeedsRebuild() (bool, error)
+
+	// Backend names the full-text implementation in use
+	Backend() string
+}
+
+// SearchTerms splits a query into lowercase words, dropping punctuation and operators
+// so user input is never interpreted as backend query syntax
+func SearchTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !containsString(terms, field) {
+			terms = append(terms, field)
+		}
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
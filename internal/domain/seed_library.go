@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Seed library entity types
+const (
+	SeedEntityMovement = "movement"
+	SeedEntityWOD      = "wod"
+)
+
+// SeedLibraryEntry records what the seed bundle last applied to a standard movement or WOD (seed_library_entries table)
+// A later sync compares the record with the applied hash to tell bundle updates from admin edits made since.
+type SeedLibraryEntry struct {
+	ID            int64     `json:"id" db:"id"`
+	EntityType    string    `json:"entity_type" db:"entity_type"` // movement, wod
+	SeedKey       string    `json:"seed_key" db:"seed_key"`       // Normalized name in the bundle (see NormalizeName)
+	EntityID      int64     `json:"entity_id" db:"entity_id"`
+	AppliedHash   string    `json:"applied_hash" db:"applied_hash"` // Hash of the bundle definition last applied
+	BundleVersion string    `json:"bundle_version" db:"bundle_version"`
+	AppliedAt     time.Time `json:"applied_at" db:"applied_at"`
+}
+
+// SeedLibraryRepository defines the interface for seed library tracking data access
+type SeedLibraryRepository interface {
+	// ListEntries lists every tracked entry
+	ListEntries() ([]*SeedLibraryEntry, error)
+
+	// SaveEntry creates or replaces the entry of an entity type and seed key
+	SaveEntry(entry *SeedLibraryEntry) error
+
+	// DeleteEntry stops tracking an entity type and seed key
+	DeleteEntry(entityType, seedKey string) error
+}
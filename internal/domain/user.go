@@ -13,7 +13,8 @@ type User struct {
 	Name                       string     `json:"name" db:"name"`
 	ProfileImage               *string    `json:"profile_image,omitempty" db:"profile_image"`
 	Birthday                   *time.Time `json:"birthday,omitempty" db:"birthday"`
-	Role                       string     `json:"role" db:"role"` // user, admin
+	Gender                     *string    `json:"gender,omitempty" db:"gender"` // male, female (used for leaderboard brackets)
+	Role                       string     `json:"role" db:"role"`               // user, admin
 	EmailVerified              bool       `json:"email_verified" db:"email_verified"`
 	EmailVerifiedAt            *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
 	VerificationToken          *string    `json:"-" db:"verification_token"` // Never serialize verification token
@@ -58,6 +59,8 @@ type UserRepository interface {
 	Delete(id int64) error
 	List(limit, offset int) ([]*User, error)
 	Count() (int64, error)
+	ListByOrganization(orgID int64, limit, offset int) ([]*User, error) // Members of a gym
+	CountByOrganization(orgID int64) (int64, error)
 
 	// Account security methods
 	IncrementFailedAttempts(userID int64) error
@@ -13,8 +13,19 @@ type UserWorkout struct {
 	WorkoutType *string   `json:"workout_type,omitempty" db:"workout_type"` // strength, metcon, cardio, mixed
 	TotalTime   *int      `json:"total_time,omitempty" db:"total_time"`     // Total workout duration in seconds
 	Notes       *string   `json:"notes,omitempty" db:"notes"`               // User's notes for this specific workout instance
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	SessionWellness
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SessionWellness is the effort and readiness a user reports for one logged session (all optional)
+// Session RPE × duration in minutes is the session load used for workload monitoring
+type SessionWellness struct {
+	SessionRPE   *int     `json:"session_rpe,omitempty" db:"session_rpe"`     // 1-10 (CR-10 scale)
+	SleepHours   *float64 `json:"sleep_hours,omitempty" db:"sleep_hours"`     // Sleep the night before
+	SleepQuality *int     `json:"sleep_quality,omitempty" db:"sleep_quality"` // 1 (poor) - 5 (excellent)
+	Soreness     *int     `json:"soreness,omitempty" db:"soreness"`           // 1 (none) - 5 (severe)
+	Readiness    *int     `json:"readiness,omitempty" db:"readiness"`         // 1 (drained) - 10 (ready to go)
 }
 
 // UserWorkoutWithDetails includes the workout template details and performance data
@@ -11,7 +11,7 @@ type WOD struct {
 	Source      string    `json:"source,omitempty" db:"source"`           // CrossFit, Other Coach, Self-recorded
 	Type        string    `json:"type,omitempty" db:"type"`               // Benchmark, Hero, Girl, Notables, Games, Endurance, Self-created
 	Regime      string    `json:"regime,omitempty" db:"regime"`           // EMOM, AMRAP, Fastest Time, Slowest Round, Get Stronger, Skills
-	ScoreType   string    `json:"score_type,omitempty" db:"score_type"`   // Time (HH:MM:SS), Rounds+Reps, Max Weight
+	ScoreType   string    `json:"score_type,omitempty" db:"score_type"`   // A registered score type (see ScoreTypes)
 	Description string    `json:"description,omitempty" db:"description"` // Full WOD description/instructions
 	URL         *string   `json:"url,omitempty" db:"url"`                 // Optional video or reference URL
 	Notes       *string   `json:"notes,omitempty" db:"notes"`             // Additional notes
@@ -32,25 +32,29 @@ type WODWithCreator struct {
 // This stores the actual performance data when a user logs a WOD
 type UserWorkoutWOD struct {
 	ID            int64     `json:"id" db:"id"`
-	UserWorkoutID int64     `json:"user_workout_id" db:"user_workout_id"`     // References user_workouts (logged workout instance)
-	WODID         int64     `json:"wod_id" db:"wod_id"`                       // References wods table
-	ScoreType     *string   `json:"score_type,omitempty" db:"score_type"`     // Time (HH:MM:SS), Rounds+Reps, Max Weight
-	ScoreValue    *string   `json:"score_value,omitempty" db:"score_value"`   // Formatted score (e.g., "12:34", "10+15", "225.5")
-	TimeSeconds   *int      `json:"time_seconds,omitempty" db:"time_seconds"` // For Time-based WODs
-	Rounds        *int      `json:"rounds,omitempty" db:"rounds"`             // For AMRAP WODs
-	Reps          *int      `json:"reps,omitempty" db:"reps"`                 // Remaining reps in AMRAP
-	Weight        *float64  `json:"weight,omitempty" db:"weight"`             // For Max Weight WODs
+	UserWorkoutID int64     `json:"user_workout_id" db:"user_workout_id"`         // References user_workouts (logged workout instance)
+	WODID         int64     `json:"wod_id" db:"wod_id"`                           // References wods table
+	WODVersionID  *int64    `json:"wod_version_id,omitempty" db:"wod_version_id"` // Version performed; NULL before the WOD's first edit
+	ScoreType     *string   `json:"score_type,omitempty" db:"score_type"`         // A registered score type (see ScoreTypes)
+	ScoreValue    *string   `json:"score_value,omitempty" db:"score_value"`       // Formatted score (e.g., "12:34", "10+15", "225.5")
+	TimeSeconds   *int      `json:"time_seconds,omitempty" db:"time_seconds"`     // For Time-based WODs
+	Rounds        *int      `json:"rounds,omitempty" db:"rounds"`                 // For AMRAP WODs
+	Reps          *int      `json:"reps,omitempty" db:"reps"`                     // Remaining reps in AMRAP
+	Weight        *float64  `json:"weight,omitempty" db:"weight"`                 // For Max Weight WODs
+	Division      *string   `json:"division,omitempty" db:"division"`             // rx, scaled (leaderboard division)
 	Notes         string    `json:"notes,omitempty" db:"notes"`
 	IsPR          bool      `json:"is_pr" db:"is_pr"`             // Personal record flag
 	OrderIndex    int       `json:"order_index" db:"order_index"` // Order in the workout
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	WODScoreDetails
 
 	// Related data (loaded via joins)
 	WOD          *WOD      `json:"wod,omitempty" db:"-"`
 	WODName      string    `json:"wod_name,omitempty" db:"-"`       // Flattened for convenience
 	WODType      string    `json:"wod_type,omitempty" db:"-"`       // Flattened for convenience (Benchmark, Hero, Girl, etc.)
 	WODScoreType string    `json:"wod_score_type,omitempty" db:"-"` // WOD's defined score_type from wods table
+	WODVersion   *int      `json:"wod_version,omitempty" db:"-"`    // Version number performed (see WODVersion)
 	WorkoutDate  time.Time `json:"workout_date" db:"-"`             // From user_workouts.workout_date
 }
 
@@ -59,6 +63,9 @@ type WODRepository interface {
 	// Create creates a new custom WOD
 	Create(wod *WOD) error
 
+	// CreateInLibrary creates a new custom WOD and adds it to a gym library in one transaction
+	CreateInLibrary(wod *WOD, orgID, addedBy int64) error
+
 	// GetByID retrieves a WOD by ID
 	GetByID(id int64) (*WOD, error)
 
@@ -80,8 +87,9 @@ type WODRepository interface {
 	// ListAllUserCreatedWithUserInfo retrieves all user-created WODs with creator info (for admin)
 	ListAllUserCreatedWithUserInfo(limit, offset int) ([]*WODWithCreator, error)
 
-	// ListAllUserCreatedWithUserInfoFiltered retrieves all user-created WODs with filters (for admin)
-	ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string) ([]*WODWithCreator, int64, error)
+	// ListAllUserCreatedWithUserInfoFiltered retrieves all user-created WODs with filters (for admin);
+	// a positive orgID limits it to WODs created by members of that gym
+	ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string, orgID int64) ([]*WODWithCreator, int64, error)
 
 	// CountAllUserCreated counts all user-created WODs
 	CountAllUserCreated() (int64, error)
@@ -125,15 +133,25 @@ type UserWorkoutWODRepository interface {
 	// DeleteByUserWorkoutID deletes all WODs for a logged workout
 	DeleteByUserWorkoutID(userWorkoutID int64) error
 
-	// GetBestTimeForWOD retrieves the fastest time for a specific WOD for a user
+	// GetBestTimeForWOD retrieves the fastest finishing time for a specific WOD for a user, ignoring time-capped results
 	GetBestTimeForWOD(userID, wodID int64) (*int, error)
 
 	// GetBestRoundsRepsForWOD retrieves the best rounds+reps for a specific WOD for a user
 	GetBestRoundsRepsForWOD(userID, wodID int64) (rounds *int, reps *int, err error)
 
+	// GetBestForWOD retrieves a user's best result on any of the given comparable WODs, ranked by the
+	// given score type and counting only results on each WOD's current comparable run (nil when none)
+	GetBestForWOD(userID int64, wodIDs []int64, scoreType string) (*UserWorkoutWOD, error)
+
 	// GetPRWODs retrieves recent PR-flagged WODs for a user
 	GetPRWODs(userID int64, limit int) ([]*UserWorkoutWOD, error)
 
 	// UpdatePRFlag updates the is_pr flag for a user workout WOD
 	UpdatePRFlag(id int64, isPR bool) error
+
+	// GetByUserIDAndWODID retrieves performance history for a WOD, newest first
+	GetByUserIDAndWODID(userID, wodID int64, limit int) ([]*UserWorkoutWOD, error)
+
+	// GetHistoryForWOD retrieves every performance of a WOD, oldest first, optionally limited to a date range
+	GetHistoryForWOD(userID, wodID int64, start, end *time.Time) ([]*UserWorkoutWOD, error)
 }
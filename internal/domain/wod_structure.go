@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WOD structure sources
+const (
+	WODStructureSourceParsed = "parsed" // Produced by the description parser
+	WODStructureSourceManual = "manual" // Entered or corrected by an admin
+)
+
+// WODStructure is the structured form of a WOD's description (wod_structures table)
+// It is derived from the free-form Markdown description and can be corrected by an admin
+type WODStructure struct {
+	WODID          int64     `json:"wod_id" db:"wod_id"`
+	Rounds         *int      `json:"rounds,omitempty" db:"rounds"`                     // e.g., 5 for "5 rounds for time"
+	RepScheme      []int     `json:"rep_scheme,omitempty" db:"rep_scheme"`             // e.g., [21 15 9]; stored as "21-15-9"
+	TimeCapSeconds *int      `json:"time_cap_seconds,omitempty" db:"time_cap_seconds"` // Time cap, or the AMRAP/EMOM duration
+	Notes          string    `json:"notes,omitempty" db:"notes"`                       // Rest periods, vests and other instructions
+	Confidence     float64   `json:"confidence" db:"confidence"`                       // 0-1: how much of the description the parser understood
+	NeedsReview    bool      `json:"needs_review" db:"needs_review"`                   // Low-confidence parse awaiting an admin
+	Warnings       []string  `json:"warnings,omitempty" db:"warnings"`                 // Parser findings for the reviewer; stored as JSON
+	Source         string    `json:"source" db:"source"`                               // parsed or manual
+	ReviewedBy     *int64    `json:"reviewed_by,omitempty" db:"reviewed_by"`           // Admin who last saved a manual structure
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data
+	Components []*WODComponent `json:"components" db:"-"`
+	WODName    string          `json:"wod_name,omitempty" db:"-"` // Loaded for review lists
+}
+
+// WODComponent is one movement line of a WOD (wod_components table)
+type WODComponent struct {
+	ID              int64    `json:"id" db:"id"`
+	WODID           int64    `json:"wod_id" db:"wod_id"`
+	OrderIndex      int      `json:"order_index" db:"order_index"`
+	MovementID      *int64   `json:"movement_id,omitempty" db:"movement_id"`           // NULL when the movement could not be matched
+	MovementName    string   `json:"movement_name" db:"movement_name"`                 // As written in the description (e.g., "Thrusters")
+	Reps            *int     `json:"reps,omitempty" db:"reps"`                         // Per round; NULL when the WOD's rep scheme applies
+	Calories        *int     `json:"calories,omitempty" db:"calories"`                 // e.g., "30 cal Row"
+	Distance        *float64 `json:"distance,omitempty" db:"distance"`                 // In meters
+	DurationSeconds *int     `json:"duration_seconds,omitempty" db:"duration_seconds"` // Work interval (e.g., "1 min Burpees")
+	WeightMale      *float64 `json:"weight_male,omitempty" db:"weight_male"`           // Rx load for men
+	WeightFemale    *float64 `json:"weight_female,omitempty" db:"weight_female"`       // Rx load for women
+	WeightUnit      string   `json:"weight_unit,omitempty" db:"weight_unit"`           // lb or kg
+	Notes           string   `json:"notes,omitempty" db:"notes"`                       // Qualifiers such as box height or "alternating"
+}
+
+// WODMovementVolume is the prescribed work for one movement over a complete WOD
+type WODMovementVolume struct {
+	MovementID   *int64   `json:"movement_id,omitempty"`
+	MovementName string   `json:"movement_name"`
+	Reps         int      `json:"reps,omitempty"`
+	Calories     int      `json:"calories,omitempty"`
+	Distance     float64  `json:"distance,omitempty"` // In meters
+	WeightMale   *float64 `json:"weight_male,omitempty"`
+	WeightFemale *float64 `json:"weight_female,omitempty"`
+	WeightUnit   string   `json:"weight_unit,omitempty"`
+}
+
+// MovementVolume totals the prescribed reps, calories and distance per movement
+// Components are multiplied by the round count; a rep scheme supplies the reps of components without their own
+// AMRAPs and EMOMs without a round count are totaled for a single round
+func (s *WODStructure) MovementVolume() []*WODMovementVolume {
+	rounds := 1
+	if s.Rounds != nil && *s.Rounds > 0 {
+		rounds = *s.Rounds
+	}
+	schemeReps := 0
+	for _, reps := range s.RepScheme {
+		schemeReps += reps
+	}
+
+	var volumes []*WODMovementVolume
+	byKey := make(map[string]*WODMovementVolume)
+	for _, c := range s.Components {
+		key := strings.ToLower(strings.TrimSpace(c.MovementName))
+		if c.MovementID != nil {
+			key = "#" + strconv.FormatInt(*c.MovementID, 10)
+		}
+		v, ok := byKey[key]
+		if !ok {
+			v = &WODMovementVolume{MovementID: c.MovementID, MovementName: c.MovementName}
+			byKey[key] = v
+			volumes = append(volumes, v)
+		}
+
+		switch {
+		case c.Reps != nil:
+			v.Reps += *c.Reps * rounds
+		case c.Calories == nil && c.Distance == nil && c.DurationSeconds == nil:
+			v.Reps += schemeReps * rounds
+		}
+		if c.Calories != nil {
+			v.Calories += *c.Calories * rounds
+		}
+		if c.Distance != nil {
+			v.Distance += *c.Distance * float64(rounds)
+		}
+		if v.WeightMale == nil && v.WeightFemale == nil {
+			v.WeightMale, v.WeightFemale, v.WeightUnit = c.WeightMale, c.WeightFemale, c.WeightUnit
+		}
+	}
+	return volumes
+}
+
+// RepsPerRound counts the reps in one round, so Rounds+Reps scores can be compared as total reps
+// Calories count as reps; nil when a component has no rep count (distance, duration or no reps at all)
+func (s *WODStructure) RepsPerRound() *int {
+	schemeReps := 0
+	for _, reps := range s.RepScheme {
+		schemeReps += reps
+	}
+
+	total := 0
+	for _, c := range s.Components {
+		switch {
+		case c.Reps != nil:
+			total += *c.Reps
+		case c.Calories != nil:
+			total += *c.Calories
+		case c.Distance == nil && c.DurationSeconds == nil && schemeReps > 0:
+			total += schemeReps
+		default:
+			return nil
+		}
+	}
+	if total <= 0 {
+		return nil
+	}
+	return &total
+}
+
+// FormatRepScheme renders a rep scheme as stored (e.g., "21-15-9"); empty for no scheme
+func FormatRepScheme(scheme []int) string {
+	parts := make([]string, len(scheme))
+	for i, reps := range scheme {
+		parts[i] = strconv.Itoa(reps)
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseRepScheme reads a stored rep scheme such as "21-15-9"; nil when empty or malformed
+func ParseRepScheme(s string) []int {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var scheme []int
+	for _, part := range strings.Split(s, "-") {
+		reps, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || reps <= 0 {
+			return nil
+		}
+		scheme = append(scheme, reps)
+	}
+	return scheme
+}
+
+// WODStructureRepository defines the interface for structured WOD definition data access
+type WODStructureRepository interface {
+	// GetByWODID retrieves a WOD's structure with its components; nil if the WOD has none
+	GetByWODID(wodID int64) (*WODStructure, error)
+	// Save creates or replaces a WOD's structure and its components
+	Save(structure *WODStructure) error
+	// DeleteByWODID removes a WOD's structure and components
+	DeleteByWODID(wodID int64) error
+	// ListForReview lists structures flagged for review, lowest confidence first, with the total count
+	ListForReview(limit, offset int) ([]*WODStructure, int, error)
+	// ListUnstructuredWODIDs lists the WODs that have no structure yet
+	ListUnstructuredWODIDs() ([]int64, error)
+	// ListParsedWODIDs lists the WODs whose structure came from the parser (not manually reviewed)
+	ListParsedWODIDs() ([]int64, error)
+}
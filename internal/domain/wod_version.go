@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// WOD variant kinds: official alternative versions of a parent WOD
+const (
+	WODVariantScaled  = "scaled"
+	WODVariantMasters = "masters"
+	WODVariantTeam    = "team"
+)
+
+// WODVariantKinds lists the accepted variant kinds
+var WODVariantKinds = []string{WODVariantScaled, WODVariantMasters, WODVariantTeam}
+
+// WODVersion is a snapshot of a WOD's definition (wod_versions table)
+// Versions are recorded from a WOD's first edit on: version 1 is the definition before that edit.
+// Results logged before then have no version and were performed on version 1.
+// Versions that share a baseline form a comparable run; leaderboards and PRs only rank results
+// on the WOD's current run.
+type WODVersion struct {
+	ID                     int64     `json:"id" db:"id"`
+	WODID                  int64     `json:"wod_id" db:"wod_id"`
+	Version                int       `json:"version" db:"version"`
+	Name                   string    `json:"name" db:"name"`
+	Source                 string    `json:"source,omitempty" db:"source"`
+	Type                   string    `json:"type,omitempty" db:"type"`
+	Regime                 string    `json:"regime,omitempty" db:"regime"`
+	ScoreType              string    `json:"score_type,omitempty" db:"score_type"`
+	Description            string    `json:"description,omitempty" db:"description"`
+	URL                    *string   `json:"url,omitempty" db:"url"`
+	Notes                  *string   `json:"notes,omitempty" db:"notes"`
+	ComparableWithPrevious bool      `json:"comparable_with_previous" db:"comparable_with_previous"` // Scores on this and the previous version rank together
+	BaselineVersion        int       `json:"baseline_version" db:"baseline_version"`                 // First version of the comparable run
+	CreatedBy              *int64    `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt              time.Time `json:"created_at" db:"created_at"`
+}
+
+// WODVariant links a variant WOD to its parent (wod_variants table)
+// Families are one level deep: a variant has no variants of its own.
+// Results on a comparable variant rank with the parent's on leaderboards and PRs.
+type WODVariant struct {
+	WODID       int64     `json:"wod_id" db:"wod_id"`
+	ParentWODID int64     `json:"parent_wod_id" db:"parent_wod_id"`
+	Kind        string    `json:"kind" db:"kind"` // scaled, masters, team
+	Comparable  bool      `json:"comparable" db:"comparable"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+
+	// Related data (loaded via joins)
+	WODName string `json:"wod_name,omitempty" db:"-"`
+}
+
+// WODVersionRepository defines the interface for WOD version and variant data access
+type WODVersionRepository interface {
+	// SaveEdit updates a WOD and saves the versions recorded for the edit in one transaction; when version 1
+	// is among them, the WOD's results without a version are assigned to it
+	SaveEdit(wod *WOD, versions []*WODVersion) error
+
+	// ListVersions lists a WOD's versions, oldest first
+	ListVersions(wodID int64) ([]*WODVersion, error)
+
+	// UpdateComparability saves the comparable flag and baseline of existing versions
+	UpdateComparability(versions []*WODVersion) error
+
+	// DeleteForWOD deletes a WOD's versions and variant links
+	DeleteForWOD(wodID int64) error
+
+	// GetVariant retrieves the link of a variant WOD to its parent (nil when the WOD is not a variant)
+	GetVariant(wodID int64) (*WODVariant, error)
+
+	// ListVariants lists a parent WOD's variants by kind and name
+	ListVariants(parentWODID int64) ([]*WODVariant, error)
+
+	// SaveVariant creates or replaces a variant link
+	SaveVariant(variant *WODVariant) error
+
+	// DeleteVariant removes a variant link; the variant WOD itself is kept
+	DeleteVariant(wodID int64) error
+
+	// ListUserIDsWithResults lists the users with logged results on any of the given WODs
+	ListUserIDsWithResults(wodIDs []int64) ([]int64, error)
+}
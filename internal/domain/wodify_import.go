@@ -40,6 +40,7 @@ type WodifyImportPreview struct {
 	WorkoutSummary       []WodifyWorkoutSummary `json:"workout_summary"`
 	NewMovements         []string               `json:"new_movements"`
 	NewWODs              []string               `json:"new_wods"`
+	Suggestions          []*NameSuggestion      `json:"suggestions,omitempty"` // Close matches for the new movements and WODs
 }
 
 // WodifyImportError represents an error in the import
@@ -60,8 +60,9 @@ type WorkoutRepository interface {
 	// ListAllUserCreatedWithUserInfo retrieves all user-created workouts with creator info (for admin)
 	ListAllUserCreatedWithUserInfo(limit, offset int) ([]*WorkoutWithCreator, error)
 
-	// ListAllUserCreatedWithUserInfoFiltered retrieves all user-created workouts with filters (for admin)
-	ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string) ([]*WorkoutWithCreator, int64, error)
+	// ListAllUserCreatedWithUserInfoFiltered retrieves all user-created workouts with filters (for admin);
+	// a positive orgID limits it to workouts created by members of that gym
+	ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string, orgID int64) ([]*WorkoutWithCreator, int64, error)
 
 	// CountAllUserCreated counts all user-created workout templates
 	CountAllUserCreated() (int64, error)
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// AchievementHandler handles badges and the rules that award them
+type AchievementHandler struct {
+	achievementService *service.AchievementService
+	logger             *logger.Logger
+}
+
+// NewAchievementHandler creates a new achievement handler
+func NewAchievementHandler(achievementService *service.AchievementService, l *logger.Logger) *AchievementHandler {
+	return &AchievementHandler{
+		achievementService: achievementService,
+		logger:             l,
+	}
+}
+
+// AchievementRuleRequest represents a rule to create or replace
+type AchievementRuleRequest struct {
+	Code        string                       `json:"code"`
+	Name        string                       `json:"name"`
+	Description string                       `json:"description"`
+	Icon        *string                      `json:"icon,omitempty"`
+	RuleType    string                       `json:"rule_type"` // workout_count, wod_count, pr_count, wod_every_year
+	Params      domain.AchievementRuleParams `json:"params"`
+	IsActive    *bool                        `json:"is_active,omitempty"` // Defaults to true
+}
+
+func (req AchievementRuleRequest) toDomain() *domain.AchievementRule {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	return &domain.AchievementRule{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+		RuleType:    req.RuleType,
+		Params:      req.Params,
+		IsActive:    isActive,
+	}
+}
+
+// ListAchievements handles GET /api/achievements (earned badges and those still available)
+func (h *AchievementHandler) ListAchievements(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	summary, err := h.achievementService.GetUserAchievements(userID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, summary)
+}
+
+// BackfillOwn handles POST /api/achievements/backfill (evaluate the caller's full history)
+func (h *AchievementHandler) BackfillOwn(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	awarded, err := h.achievementService.EvaluateUser(userID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if awarded == nil {
+		awarded = []*domain.UserAchievement{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"awarded": awarded,
+		"count":   len(awarded),
+	})
+}
+
+// ListRules handles GET /api/admin/achievements/rules (admin only)
+func (h *AchievementHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.achievementService.ListRules()
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// CreateRule handles POST /api/admin/achievements/rules (admin only)
+func (h *AchievementHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req AchievementRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	rule, err := h.achievementService.CreateRule(userID, req.toDomain())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=create_achievement_rule outcome=success user_id=%d rule_id=%d code=%s", userID, rule.ID, rule.Code)
+	}
+
+	respondJSON(w, http.StatusCreated, rule)
+}
+
+// UpdateRule handles PUT /api/admin/achievements/rules/{id} (admin only)
+func (h *AchievementHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	var req AchievementRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	rule, err := h.achievementService.UpdateRule(id, req.toDomain())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rule)
+}
+
+// DeleteRule handles DELETE /api/admin/achievements/rules/{id} (admin only)
+func (h *AchievementHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	if err := h.achievementService.DeleteRule(id); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		userID, _ := middleware.GetUserID(r.Context())
+		h.logger.Info("action=delete_achievement_rule outcome=success user_id=%d rule_id=%d", userID, id)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Achievement rule deleted"})
+}
+
+// Backfill handles POST /api/admin/achievements/backfill?user_id= (admin only)
+// Evaluates one user's history, or every user's when user_id is omitted
+func (h *AchievementHandler) Backfill(w http.ResponseWriter, r *http.Request) {
+	var userID *int64
+	if v := r.URL.Query().Get("user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid user ID")
+			return
+		}
+		userID = &id
+	}
+
+	result, err := h.achievementService.Backfill(userID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		adminID, _ := middleware.GetUserID(r.Context())
+		h.logger.Info("action=backfill_achievements outcome=success user_id=%d users=%d awarded=%d", adminID, result.UsersEvaluated, result.Awarded)
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func (h *AchievementHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrAchievementRuleNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrAchievementCodeTaken):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidAchievementCode), errors.Is(err, service.ErrAchievementNameRequired),
+		errors.Is(err, service.ErrInvalidAchievementType), errors.Is(err, service.ErrInvalidAchievementParams),
+		errors.Is(err, service.ErrInvalidAchievementPeriod):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=achievement_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "Achievement request failed")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/johnzastrow/actalog/internal/domain"
 	"github.com/johnzastrow/actalog/internal/service"
 	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
 )
 
 // AdminHandler handles admin-only operations
@@ -68,6 +69,24 @@ type WODMismatch struct {
 	Rounds           *int    `json:"rounds,omitempty"`
 	Reps             *int    `json:"reps,omitempty"`
 	Weight           *float64 `json:"weight,omitempty"`
+	domain.WODScoreDetails
+}
+
+// wodMismatchColumns are the user_workout_wods score columns checked against the registry
+const wodMismatchColumns = `uww.time_seconds, uww.rounds, uww.reps, uww.weight,
+		       uww.calories, uww.distance, uww.points, uww.tie_break_seconds, uww.time_capped`
+
+// scoreTypeMismatch checks a result against its WOD's registered score type
+// Returns an empty string when it matches, or the WOD has no registered score type
+func scoreTypeMismatch(scoreType string, result *domain.UserWorkoutWOD) string {
+	def := domain.LookupScoreType(scoreType)
+	if def == nil {
+		return ""
+	}
+	if err := def.Validate(result); err != nil {
+		return fmt.Sprintf("%s result %v", scoreType, err)
+	}
+	return ""
 }
 
 // DetectWODScoreTypeMismatches detects WOD records that don't match their score_type
@@ -75,8 +94,8 @@ func (h *AdminHandler) DetectWODScoreTypeMismatches(w http.ResponseWriter, r *ht
 	// Get all WOD performance records with WOD definitions
 	// Note: This query needs to be run across all users
 	query := `
-		SELECT uww.id, uww.wod_id, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
-		       w.name, w.score_type,
+		SELECT uww.id, uww.wod_id, ` + wodMismatchColumns + `,
+		       w.name, COALESCE(w.score_type, ''),
 		       u.email,
 		       uw.workout_date
 		FROM user_workout_wods uww
@@ -99,63 +118,40 @@ func (h *AdminHandler) DetectWODScoreTypeMismatches(w http.ResponseWriter, r *ht
 	for rows.Next() {
 		var (
 			id          int64
-			wodID       int64
-			timeSeconds *int
-			rounds      *int
-			reps        *int
-			weight      *float64
 			wodName     string
 			scoreType   string
 			userEmail   string
 			workoutDate string
+			result      domain.UserWorkoutWOD
 		)
+		details := &result.WODScoreDetails
 
-		err := rows.Scan(&id, &wodID, &timeSeconds, &rounds, &reps, &weight, &wodName, &scoreType, &userEmail, &workoutDate)
+		err := rows.Scan(&id, &result.WODID, &result.TimeSeconds, &result.Rounds, &result.Reps, &result.Weight,
+			&details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped,
+			&wodName, &scoreType, &userEmail, &workoutDate)
 		if err != nil {
 			h.logger.Error("Failed to scan WOD record: %v", err)
 			continue
 		}
 
-		// Check for mismatches based on score_type
-		var issue string
-
-		if scoreType == "Time (HH:MM:SS)" {
-			// Must have time_seconds, must NOT have rounds/reps/weight
-			if timeSeconds == nil {
-				issue = "Missing time_seconds for Time-based WOD"
-			} else if rounds != nil || reps != nil || weight != nil {
-				issue = "Has invalid fields (rounds/reps/weight) for Time-based WOD"
-			}
-		} else if scoreType == "Rounds+Reps" {
-			// Must have rounds, must NOT have time_seconds/weight
-			if rounds == nil {
-				issue = "Missing rounds for Rounds+Reps WOD"
-			} else if timeSeconds != nil || weight != nil {
-				issue = "Has invalid fields (time_seconds/weight) for Rounds+Reps WOD"
-			}
-		} else if scoreType == "Max Weight" {
-			// Must have weight, must NOT have time_seconds/rounds/reps
-			if weight == nil {
-				issue = "Missing weight for Max Weight WOD"
-			} else if timeSeconds != nil || rounds != nil || reps != nil {
-				issue = "Has invalid fields (time_seconds/rounds/reps) for Max Weight WOD"
-			}
-		}
+		// Check the result against the WOD's registered score type
+		issue := scoreTypeMismatch(scoreType, &result)
 
 		// If there's an issue, add to mismatches
 		if issue != "" {
 			mismatches = append(mismatches, WODMismatch{
 				ID:                id,
-				WODID:             wodID,
+				WODID:             result.WODID,
 				WODName:           wodName,
 				UserEmail:         userEmail,
 				WorkoutDate:       workoutDate,
 				ExpectedScoreType: scoreType,
 				Issue:             issue,
-				TimeSeconds:       timeSeconds,
-				Rounds:            rounds,
-				Reps:              reps,
-				Weight:            weight,
+				TimeSeconds:       result.TimeSeconds,
+				Rounds:            result.Rounds,
+				Reps:              result.Reps,
+				Weight:            result.Weight,
+				WODScoreDetails:   result.WODScoreDetails,
 			})
 		}
 	}
@@ -178,8 +174,8 @@ func (h *AdminHandler) DetectWODScoreTypeMismatches(w http.ResponseWriter, r *ht
 func (h *AdminHandler) FixWODScoreTypeMismatches(w http.ResponseWriter, r *http.Request) {
 	// First, get all mismatches
 	query := `
-		SELECT uww.id, uww.wod_id, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
-		       w.score_type
+		SELECT uww.id, ` + wodMismatchColumns + `,
+		       COALESCE(w.score_type, '')
 		FROM user_workout_wods uww
 		JOIN wods w ON uww.wod_id = w.id`
 
@@ -196,37 +192,21 @@ func (h *AdminHandler) FixWODScoreTypeMismatches(w http.ResponseWriter, r *http.
 
 	for rows.Next() {
 		var (
-			id          int64
-			wodID       int64
-			timeSeconds *int
-			rounds      *int
-			reps        *int
-			weight      *float64
-			scoreType   string
+			id        int64
+			scoreType string
+			result    domain.UserWorkoutWOD
 		)
+		details := &result.WODScoreDetails
 
-		err := rows.Scan(&id, &wodID, &timeSeconds, &rounds, &reps, &weight, &scoreType)
+		err := rows.Scan(&id, &result.TimeSeconds, &result.Rounds, &result.Reps, &result.Weight,
+			&details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped, &scoreType)
 		if err != nil {
 			h.logger.Error("Failed to scan WOD record: %v", err)
 			continue
 		}
 
-		// Check for mismatches based on score_type
-		isMismatch := false
-
-		if scoreType == "Time (HH:MM:SS)" {
-			if timeSeconds == nil || rounds != nil || reps != nil || weight != nil {
-				isMismatch = true
-			}
-		} else if scoreType == "Rounds+Reps" {
-			if rounds == nil || timeSeconds != nil || weight != nil {
-				isMismatch = true
-			}
-		} else if scoreType == "Max Weight" {
-			if weight == nil || timeSeconds != nil || rounds != nil || reps != nil {
-				isMismatch = true
-			}
-		}
+		// Check the result against the WOD's registered score type
+		isMismatch := scoreTypeMismatch(scoreType, &result) != ""
 
 		if isMismatch {
 			idsToDelete = append(idsToDelete, id)
@@ -266,7 +246,8 @@ type UpdateWODRecordRequest struct {
 	Rounds      *int     `json:"rounds"`
 	Reps        *int     `json:"reps"`
 	Weight      *float64 `json:"weight"`
-	Notes       string   `json:"notes"`
+	domain.WODScoreDetails
+	Notes string `json:"notes"`
 }
 
 // UpdateWODRecord updates an individual WOD record
@@ -310,67 +291,34 @@ func (h *AdminHandler) UpdateWODRecord(w http.ResponseWriter, r *http.Request) {
 
 	// Validate that the update matches the score_type
 	scoreType := wod.ScoreType
-	if scoreType == "Time (HH:MM:SS)" {
-		if req.TimeSeconds == nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": fmt.Sprintf("WOD '%s' has score_type '%s' but time_seconds is missing", wod.Name, scoreType),
-			})
-			return
-		}
-		if req.Rounds != nil || req.Reps != nil || req.Weight != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": fmt.Sprintf("WOD '%s' has score_type '%s' but contains invalid fields (rounds/reps/weight)", wod.Name, scoreType),
-			})
-			return
-		}
-	} else if scoreType == "Rounds+Reps" {
-		if req.Rounds == nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": fmt.Sprintf("WOD '%s' has score_type '%s' but rounds is missing", wod.Name, scoreType),
-			})
-			return
-		}
-		if req.TimeSeconds != nil || req.Weight != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": fmt.Sprintf("WOD '%s' has score_type '%s' but contains invalid fields (time_seconds/weight)", wod.Name, scoreType),
-			})
-			return
-		}
-	} else if scoreType == "Max Weight" {
-		if req.Weight == nil {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": fmt.Sprintf("WOD '%s' has score_type '%s' but weight is missing", wod.Name, scoreType),
-			})
-			return
-		}
-		if req.TimeSeconds != nil || req.Rounds != nil || req.Reps != nil {
+	updatedRecord := &domain.UserWorkoutWOD{
+		ID:              id,
+		UserWorkoutID:   existingRecord.UserWorkoutID,
+		WODID:           existingRecord.WODID,
+		ScoreType:       existingRecord.ScoreType,
+		TimeSeconds:     req.TimeSeconds,
+		Rounds:          req.Rounds,
+		Reps:            req.Reps,
+		Weight:          req.Weight,
+		WODScoreDetails: req.WODScoreDetails,
+		Division:        existingRecord.Division,
+		Notes:           req.Notes,
+		IsPR:            existingRecord.IsPR,
+		OrderIndex:      existingRecord.OrderIndex,
+	}
+	if def := domain.LookupScoreType(scoreType); def != nil {
+		if err := def.Validate(updatedRecord); err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(map[string]string{
-				"message": fmt.Sprintf("WOD '%s' has score_type '%s' but contains invalid fields (time_seconds/rounds/reps)", wod.Name, scoreType),
+				"message": fmt.Sprintf("WOD '%s' has score_type '%s' but %v", wod.Name, scoreType, err),
 			})
 			return
 		}
+		scoreValue := def.Format(updatedRecord)
+		updatedRecord.ScoreValue = &scoreValue
 	}
 
 	// Update the record
-	updatedRecord := &domain.UserWorkoutWOD{
-		ID:            id,
-		UserWorkoutID: existingRecord.UserWorkoutID,
-		WODID:         existingRecord.WODID,
-		TimeSeconds:   req.TimeSeconds,
-		Rounds:        req.Rounds,
-		Reps:          req.Reps,
-		Weight:        req.Weight,
-		Notes:         req.Notes,
-		IsPR:          existingRecord.IsPR,
-		OrderIndex:    existingRecord.OrderIndex,
-	}
-
 	if err := h.userWorkoutWODRepo.Update(updatedRecord); err != nil {
 		h.logger.Error("Failed to update WOD record: id=%v error=%v", id, err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -415,7 +363,9 @@ func (h *AdminHandler) ListUserCreatedWODs(w http.ResponseWriter, r *http.Reques
 	scoreType := r.URL.Query().Get("score_type")
 	creator := r.URL.Query().Get("creator")
 
-	wods, count, err := h.wodService.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, scoreType, creator)
+	gymID, _ := middleware.GetGymScope(r.Context()) // Gym admins only see their members' items
+
+	wods, count, err := h.wodService.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, scoreType, creator, gymID)
 	if err != nil {
 		h.logger.Error("Failed to list user-created WODs: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -495,7 +445,9 @@ func (h *AdminHandler) ListUserCreatedMovements(w http.ResponseWriter, r *http.R
 	movementType := r.URL.Query().Get("type")
 	creator := r.URL.Query().Get("creator")
 
-	movements, count, err := h.movementService.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, movementType, creator)
+	gymID, _ := middleware.GetGymScope(r.Context()) // Gym admins only see their members' items
+
+	movements, count, err := h.movementService.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, movementType, creator, gymID)
 	if err != nil {
 		h.logger.Error("Failed to list user-created movements: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -574,7 +526,9 @@ func (h *AdminHandler) ListUserCreatedWorkouts(w http.ResponseWriter, r *http.Re
 	search := r.URL.Query().Get("search")
 	creator := r.URL.Query().Get("creator")
 
-	workouts, count, err := h.workoutTemplateService.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, creator)
+	gymID, _ := middleware.GetGymScope(r.Context()) // Gym admins only see their members' items
+
+	workouts, count, err := h.workoutTemplateService.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, creator, gymID)
 	if err != nil {
 		h.logger.Error("Failed to list user-created workouts: %v", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
 	"github.com/johnzastrow/actalog/internal/service"
 	"github.com/johnzastrow/actalog/pkg/logger"
 	"github.com/johnzastrow/actalog/pkg/middleware"
@@ -14,13 +15,15 @@ import (
 // AdminUserHandler handles admin user management operations
 type AdminUserHandler struct {
 	userService *service.UserService
+	orgService  *service.OrganizationService
 	logger      *logger.Logger
 }
 
 // NewAdminUserHandler creates a new admin user handler
-func NewAdminUserHandler(userService *service.UserService, logger *logger.Logger) *AdminUserHandler {
+func NewAdminUserHandler(userService *service.UserService, orgService *service.OrganizationService, logger *logger.Logger) *AdminUserHandler {
 	return &AdminUserHandler{
 		userService: userService,
+		orgService:  orgService,
 		logger:      logger,
 	}
 }
@@ -42,8 +45,15 @@ func (h *AdminUserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Get users
-	users, total, err := h.userService.ListUsers(limit, offset)
+	// Get users (only the gym's members for a gym-scoped request)
+	var users []*domain.User
+	var total int64
+	var err error
+	if gymID, scoped := middleware.GetGymScope(r.Context()); scoped {
+		users, total, err = h.userService.ListOrganizationUsers(gymID, limit, offset)
+	} else {
+		users, total, err = h.userService.ListUsers(limit, offset)
+	}
 	if err != nil {
 		h.logger.Error("Failed to list users: %v", err)
 		http.Error(w, "Failed to retrieve users", http.StatusInternalServerError)
@@ -264,6 +274,20 @@ func (h *AdminUserHandler) GetUserDetails(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Gym admins only see their own members
+	if gymID, scoped := middleware.GetGymScope(r.Context()); scoped {
+		isMember, err := h.orgService.IsMember(gymID, targetUserID)
+		if err != nil {
+			h.logger.Error("Failed to check gym membership: gym_id=%d target_user_id=%d error=%v", gymID, targetUserID, err)
+			http.Error(w, "Failed to retrieve user", http.StatusInternalServerError)
+			return
+		}
+		if !isMember {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+	}
+
 	// Get user with admin details
 	user, err := h.userService.GetUserByIDWithAdminDetails(targetUserID)
 	if err != nil {
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// AliasHandler handles movement and WOD aliases, name matching and merging of duplicates
+type AliasHandler struct {
+	aliasService *service.AliasService
+	logger       *logger.Logger
+}
+
+// NewAliasHandler creates a new alias handler
+func NewAliasHandler(aliasService *service.AliasService, l *logger.Logger) *AliasHandler {
+	return &AliasHandler{
+		aliasService: aliasService,
+		logger:       l,
+	}
+}
+
+// AliasRequest represents a request to add an alias
+type AliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+// MergeRequest represents a request to merge duplicates into a target
+// source_id is accepted as a shorthand for a single-element source_ids
+type MergeRequest struct {
+	SourceIDs []int64 `json:"source_ids"`
+	SourceID  int64   `json:"source_id,omitempty"`
+	TargetID  int64   `json:"target_id"`
+	DryRun    bool    `json:"dry_run"`
+}
+
+// RecomputePRsRequest lists the users whose PRs to recompute
+type RecomputePRsRequest struct {
+	UserIDs []int64 `json:"user_ids"`
+}
+
+// MatchMovement handles GET /api/movements/match?name=...&limit=5
+func (h *AliasHandler) MatchMovement(w http.ResponseWriter, r *http.Request) {
+	h.match(w, r, domain.AliasEntityMovement)
+}
+
+// MatchWOD handles GET /api/wods/match?name=...&limit=5
+func (h *AliasHandler) MatchWOD(w http.ResponseWriter, r *http.Request) {
+	h.match(w, r, domain.AliasEntityWOD)
+}
+
+// ListMovementAliases handles GET /api/admin/movements/{id}/aliases (admin only)
+func (h *AliasHandler) ListMovementAliases(w http.ResponseWriter, r *http.Request) {
+	h.listAliases(w, r, domain.AliasEntityMovement)
+}
+
+// ListWODAliases handles GET /api/admin/wods/{id}/aliases (admin only)
+func (h *AliasHandler) ListWODAliases(w http.ResponseWriter, r *http.Request) {
+	h.listAliases(w, r, domain.AliasEntityWOD)
+}
+
+// AddMovementAlias handles POST /api/admin/movements/{id}/aliases (admin only)
+func (h *AliasHandler) AddMovementAlias(w http.ResponseWriter, r *http.Request) {
+	h.addAlias(w, r, domain.AliasEntityMovement)
+}
+
+// AddWODAlias handles POST /api/admin/wods/{id}/aliases (admin only)
+func (h *AliasHandler) AddWODAlias(w http.ResponseWriter, r *http.Request) {
+	h.addAlias(w, r, domain.AliasEntityWOD)
+}
+
+// DeleteAlias handles DELETE /api/admin/aliases/{id} (admin only)
+func (h *AliasHandler) DeleteAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid alias ID")
+		return
+	}
+
+	if err := h.aliasService.DeleteAlias(id); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Alias deleted successfully"})
+}
+
+// MergeMovements handles POST /api/admin/movements/merge (admin only)
+func (h *AliasHandler) MergeMovements(w http.ResponseWriter, r *http.Request) {
+	h.merge(w, r, domain.AliasEntityMovement)
+}
+
+// MergeWODs handles POST /api/admin/wods/merge (admin only)
+func (h *AliasHandler) MergeWODs(w http.ResponseWriter, r *http.Request) {
+	h.merge(w, r, domain.AliasEntityWOD)
+}
+
+func (h *AliasHandler) match(w http.ResponseWriter, r *http.Request, entityType string) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		respondError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	limit := 5
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 20 {
+		limit = v
+	}
+
+	result, err := h.aliasService.Match(userID, entityType, name, limit)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func (h *AliasHandler) listAliases(w http.ResponseWriter, r *http.Request, entityType string) {
+	entityID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	aliases, err := h.aliasService.ListAliases(entityType, entityID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"aliases": aliases,
+		"count":   len(aliases),
+	})
+}
+
+func (h *AliasHandler) addAlias(w http.ResponseWriter, r *http.Request, entityType string) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	entityID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	var req AliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	alias, err := h.aliasService.AddAlias(userID, entityType, entityID, req.Alias)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, alias)
+}
+
+func (h *AliasHandler) merge(w http.ResponseWriter, r *http.Request, entityType string) {
+	adminID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	adminEmail, _ := middleware.GetUserEmail(r.Context())
+
+	var req MergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	sourceIDs := req.SourceIDs
+	if req.SourceID != 0 {
+		sourceIDs = append(sourceIDs, req.SourceID)
+	}
+	if len(sourceIDs) == 0 || req.TargetID == 0 {
+		respondError(w, http.StatusBadRequest, "source_ids and target_id are required")
+		return
+	}
+
+	result, err := h.aliasService.Merge(adminID, adminEmail, entityType, sourceIDs, req.TargetID, req.DryRun)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil && !req.DryRun {
+		h.logger.Info("action=merge_%s outcome=success user_id=%d source_ids=%v target_id=%d affected_users=%d", entityType, adminID, sourceIDs, req.TargetID, len(result.AffectedUsers))
+		if len(result.PRRecomputeFailedUsers) > 0 || len(result.ChangeLogFailedSources) > 0 {
+			h.logger.Warn("action=merge_%s outcome=partial user_id=%d pr_recompute_failed_users=%v change_log_failed_sources=%v",
+				entityType, adminID, result.PRRecomputeFailedUsers, result.ChangeLogFailedSources)
+		}
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// RecomputePRs handles POST /api/admin/merge/recompute-prs (admin only)
+// Retries the PR recomputation a merge reported as failed
+func (h *AliasHandler) RecomputePRs(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req RecomputePRsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.UserIDs) == 0 {
+		respondError(w, http.StatusBadRequest, "user_ids is required")
+		return
+	}
+
+	result := h.aliasService.RecomputePRs(req.UserIDs)
+
+	if h.logger != nil {
+		h.logger.Info("action=recompute_prs outcome=success user_id=%d user_ids=%v prs_flagged=%d failed_users=%v",
+			adminID, req.UserIDs, result.PRsFlagged, result.FailedUsers)
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func (h *AliasHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrMovementNotFound), errors.Is(err, service.ErrWODNotFound),
+		errors.Is(err, service.ErrAliasNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrAliasConflict):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidAlias), errors.Is(err, service.ErrInvalidMerge),
+		errors.Is(err, service.ErrInvalidAliasEntity):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=alias_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "Alias request failed")
+	}
+}
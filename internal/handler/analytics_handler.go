@@ -0,0 +1,563 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// AnalyticsHandler handles training analytics for athletes and their coaches
+type AnalyticsHandler struct {
+	analyticsService      *service.AnalyticsService
+	fitnessProfileService *service.FitnessProfileService
+	coachService          *service.CoachService
+	logger                *logger.Logger
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(
+	analyticsService *service.AnalyticsService,
+	fitnessProfileService *service.FitnessProfileService,
+	coachService *service.CoachService,
+	l *logger.Logger,
+) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService:      analyticsService,
+		fitnessProfileService: fitnessProfileService,
+		coachService:          coachService,
+		logger:                l,
+	}
+}
+
+// GetVolume handles GET /api/analytics/volume
+// Query: bucket (day|week|month, default week), start, end (YYYY-MM-DD; default a window ending today)
+func (h *AnalyticsHandler) GetVolume(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondVolume(w, r, userID, nil)
+}
+
+// GetAthleteVolume handles GET /api/coach/athletes/{athlete_id}/analytics/volume (audited coach read)
+func (h *AnalyticsHandler) GetAthleteVolume(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondVolume(w, r, athleteID, &coachID)
+}
+
+// respondVolume builds the volume report; when coachID is set the read is authorized and audited first
+func (h *AnalyticsHandler) respondVolume(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	bucket, start, end, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "volume_analytics", map[string]interface{}{
+		"bucket": bucket,
+	}) {
+		return
+	}
+
+	report, err := h.analyticsService.GetVolumeReport(userID, bucket, start, end)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_volume_analytics outcome=failure user_id=%d bucket=%s error=%v", userID, bucket, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// GetE1RMProgression handles GET /api/analytics/movements/{id}/e1rm
+// Query: bucket (week|month, default week), start, end, formula (default Hybrid), baseline (YYYY-MM-DD)
+func (h *AnalyticsHandler) GetE1RMProgression(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondE1RMProgression(w, r, userID, nil)
+}
+
+// GetAthleteE1RMProgression handles GET /api/coach/athletes/{athlete_id}/analytics/movements/{id}/e1rm (audited coach read)
+func (h *AnalyticsHandler) GetAthleteE1RMProgression(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondE1RMProgression(w, r, athleteID, &coachID)
+}
+
+// respondE1RMProgression builds the estimated 1RM series; when coachID is set the read is authorized and audited first
+func (h *AnalyticsHandler) respondE1RMProgression(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	movementID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid movement ID")
+		return
+	}
+
+	bucket, start, end, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	var baseline *time.Time
+	if v := r.URL.Query().Get("baseline"); v != "" {
+		parsed, err := time.Parse(domain.ScheduledDateFormat, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid baseline date format. Use YYYY-MM-DD")
+			return
+		}
+		baseline = &parsed
+	}
+	formula := r.URL.Query().Get("formula")
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "e1rm_progression", map[string]interface{}{
+		"movement_id": movementID,
+	}) {
+		return
+	}
+
+	progression, err := h.analyticsService.GetE1RMProgression(userID, movementID, bucket, formula, start, end, baseline)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_e1rm_progression outcome=failure user_id=%d movement_id=%d error=%v", userID, movementID, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, progression)
+}
+
+// GetBenchmarkRetests handles GET /api/analytics/wods/{id}/retests
+// Query: reps_per_round (optional; overrides the reps per round counted from the WOD's structure)
+func (h *AnalyticsHandler) GetBenchmarkRetests(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondBenchmarkRetests(w, r, userID, nil)
+}
+
+// GetAthleteBenchmarkRetests handles GET /api/coach/athletes/{athlete_id}/analytics/wods/{id}/retests (audited coach read)
+func (h *AnalyticsHandler) GetAthleteBenchmarkRetests(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondBenchmarkRetests(w, r, athleteID, &coachID)
+}
+
+// respondBenchmarkRetests builds the retest report; when coachID is set the read is authorized and audited first
+func (h *AnalyticsHandler) respondBenchmarkRetests(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	var repsPerRound *int
+	if v := r.URL.Query().Get("reps_per_round"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid reps_per_round")
+			return
+		}
+		repsPerRound = &parsed
+	}
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "benchmark_retests", map[string]interface{}{
+		"wod_id": wodID,
+	}) {
+		return
+	}
+
+	report, err := h.analyticsService.GetBenchmarkRetests(userID, wodID, repsPerRound)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_benchmark_retests outcome=failure user_id=%d wod_id=%d error=%v", userID, wodID, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// GetConsistency handles GET /api/analytics/consistency
+// Query: rest_days (0-6, default 1) - consecutive days off a streak tolerates
+func (h *AnalyticsHandler) GetConsistency(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondConsistency(w, r, userID, nil)
+}
+
+// GetAthleteConsistency handles GET /api/coach/athletes/{athlete_id}/analytics/consistency (audited coach read)
+func (h *AnalyticsHandler) GetAthleteConsistency(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondConsistency(w, r, athleteID, &coachID)
+}
+
+// respondConsistency builds the consistency report; when coachID is set the read is authorized and audited first
+func (h *AnalyticsHandler) respondConsistency(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	restDays := 1
+	if v := r.URL.Query().Get("rest_days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid rest_days")
+			return
+		}
+		restDays = parsed
+	}
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "consistency", map[string]interface{}{
+		"rest_days": restDays,
+	}) {
+		return
+	}
+
+	report, err := h.analyticsService.GetConsistency(userID, restDays)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_consistency outcome=failure user_id=%d error=%v", userID, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// GetCalendar handles GET /api/analytics/calendar
+// Query: year (default current year)
+func (h *AnalyticsHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondCalendar(w, r, userID, nil)
+}
+
+// GetAthleteCalendar handles GET /api/coach/athletes/{athlete_id}/analytics/calendar (audited coach read)
+func (h *AnalyticsHandler) GetAthleteCalendar(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondCalendar(w, r, athleteID, &coachID)
+}
+
+// respondCalendar builds the heatmap calendar; when coachID is set the read is authorized and audited first
+func (h *AnalyticsHandler) respondCalendar(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	year := time.Now().Year()
+	if v := r.URL.Query().Get("year"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid year")
+			return
+		}
+		year = parsed
+	}
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "training_calendar", map[string]interface{}{
+		"year": year,
+	}) {
+		return
+	}
+
+	calendar, err := h.analyticsService.GetTrainingCalendar(userID, year)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_training_calendar outcome=failure user_id=%d year=%d error=%v", userID, year, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, calendar)
+}
+
+// GetTrainingLoad handles GET /api/analytics/training-load
+// Query: start, end (YYYY-MM-DD; default the 28 days ending today), acwr_low, acwr_high (safe band, default 0.8-1.3)
+func (h *AnalyticsHandler) GetTrainingLoad(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondTrainingLoad(w, r, userID, nil)
+}
+
+// GetAthleteTrainingLoad handles GET /api/coach/athletes/{athlete_id}/analytics/training-load (audited coach read)
+func (h *AnalyticsHandler) GetAthleteTrainingLoad(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondTrainingLoad(w, r, athleteID, &coachID)
+}
+
+// respondTrainingLoad builds the ACWR load report; when coachID is set the read is authorized and audited first
+func (h *AnalyticsHandler) respondTrainingLoad(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	query := r.URL.Query()
+
+	end := time.Now()
+	if v := query.Get("end"); v != "" {
+		parsed, err := time.Parse(domain.ScheduledDateFormat, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid end date format. Use YYYY-MM-DD")
+			return
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -27)
+	if v := query.Get("start"); v != "" {
+		parsed, err := time.Parse(domain.ScheduledDateFormat, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid start date format. Use YYYY-MM-DD")
+			return
+		}
+		start = parsed
+	}
+
+	acwrLow, acwrHigh := service.DefaultACWRLow, service.DefaultACWRHigh
+	for param, target := range map[string]*float64{"acwr_low": &acwrLow, "acwr_high": &acwrHigh} {
+		if v := query.Get(param); v != "" {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid "+param)
+				return
+			}
+			*target = parsed
+		}
+	}
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "training_load", map[string]interface{}{
+		"acwr_low":  acwrLow,
+		"acwr_high": acwrHigh,
+	}) {
+		return
+	}
+
+	report, err := h.analyticsService.GetTrainingLoad(userID, start, end, acwrLow, acwrHigh)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_training_load outcome=failure user_id=%d error=%v", userID, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// GetMovementBreakdown handles GET /api/analytics/movements
+// Query: start, end (YYYY-MM-DD; default the 12 weeks ending today), rollup (true counts variants under their parent)
+func (h *AnalyticsHandler) GetMovementBreakdown(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondMovementBreakdown(w, r, userID, nil)
+}
+
+// GetAthleteMovementBreakdown handles GET /api/coach/athletes/{athlete_id}/analytics/movements (audited coach read)
+func (h *AnalyticsHandler) GetAthleteMovementBreakdown(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondMovementBreakdown(w, r, athleteID, &coachID)
+}
+
+func (h *AnalyticsHandler) respondMovementBreakdown(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	_, start, end, ok := parseAnalyticsRange(w, r)
+	if !ok {
+		return
+	}
+
+	rollup := false
+	if v := r.URL.Query().Get("rollup"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid rollup")
+			return
+		}
+		rollup = parsed
+	}
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "movement_analytics", map[string]interface{}{
+		"rollup": rollup,
+	}) {
+		return
+	}
+
+	report, err := h.analyticsService.GetMovementBreakdown(userID, start, end, rollup)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_movement_breakdown outcome=failure user_id=%d rollup=%t error=%v", userID, rollup, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// GetFitnessProfile handles GET /api/analytics/fitness-profile
+// Query: gender (male|female; defaults to the profile gender)
+func (h *AnalyticsHandler) GetFitnessProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.respondFitnessProfile(w, r, userID, nil)
+}
+
+// GetAthleteFitnessProfile handles GET /api/coach/athletes/{athlete_id}/analytics/fitness-profile (audited coach read)
+func (h *AnalyticsHandler) GetAthleteFitnessProfile(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	h.respondFitnessProfile(w, r, athleteID, &coachID)
+}
+
+// respondFitnessProfile builds the fitness profile; when coachID is set the read is authorized and audited first
+func (h *AnalyticsHandler) respondFitnessProfile(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64) {
+	gender := r.URL.Query().Get("gender")
+
+	if !h.authorizeCoachView(w, r, userID, coachID, "fitness_profile", map[string]interface{}{
+		"gender": gender,
+	}) {
+		return
+	}
+
+	profile, err := h.fitnessProfileService.GetProfile(userID, gender)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=get_fitness_profile outcome=failure user_id=%d error=%v", userID, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, profile)
+}
+
+// parseAthleteRoute reads the coach from the context and the athlete from the URL
+func parseAthleteRoute(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	coachID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+
+	athleteID, err := strconv.ParseInt(chi.URLParam(r, "athlete_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid athlete ID")
+		return 0, 0, false
+	}
+
+	return coachID, athleteID, true
+}
+
+// authorizeCoachView checks and audits a coach read of an athlete's analytics; own reads (nil coachID) pass
+func (h *AnalyticsHandler) authorizeCoachView(w http.ResponseWriter, r *http.Request, userID int64, coachID *int64, resource string, details map[string]interface{}) bool {
+	if coachID == nil {
+		return true
+	}
+	if err := h.coachService.AuthorizeView(*coachID, userID, resource, r.RemoteAddr, r.UserAgent(), details); err != nil {
+		h.respondServiceError(w, err)
+		return false
+	}
+	return true
+}
+
+// parseAnalyticsRange reads bucket, start and end query parameters with defaults
+func parseAnalyticsRange(w http.ResponseWriter, r *http.Request) (string, time.Time, time.Time, bool) {
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		bucket = domain.BucketWeek
+	}
+
+	end := time.Now()
+	if v := r.URL.Query().Get("end"); v != "" {
+		parsed, err := time.Parse(domain.ScheduledDateFormat, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid end date format. Use YYYY-MM-DD")
+			return "", time.Time{}, time.Time{}, false
+		}
+		end = parsed
+	}
+
+	start := service.DefaultAnalyticsStart(bucket, end)
+	if v := r.URL.Query().Get("start"); v != "" {
+		parsed, err := time.Parse(domain.ScheduledDateFormat, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid start date format. Use YYYY-MM-DD")
+			return "", time.Time{}, time.Time{}, false
+		}
+		start = parsed
+	}
+
+	return bucket, start, end, true
+}
+
+func (h *AnalyticsHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrCoachAccessDenied:
+		respondError(w, http.StatusForbidden, err.Error())
+	case service.ErrMovementNotFound, service.ErrWODNotFound, service.ErrUserNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case service.ErrInvalidBucket, service.ErrAnalyticsRangeTooLarge, service.ErrInvalidScheduleRange,
+		service.ErrInvalidE1RMBucket, service.ErrInvalidBaselineDate, service.ErrInvalidRepsPerRound,
+		service.ErrInvalidRestDays, service.ErrInvalidCalendarYear, service.ErrInvalidGender, service.ErrProfileGenderRequired,
+		service.ErrInvalidACWRBand:
+		respondError(w, http.StatusBadRequest, err.Error())
+	case service.ErrInvalidE1RMFormula:
+		respondError(w, http.StatusBadRequest, err.Error()+"; use one of: "+strings.Join(service.E1RMFormulas(), ", "))
+	default:
+		respondError(w, http.StatusInternalServerError, "Failed to compute analytics")
+	}
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// AttachmentHandler handles images, videos and links on movements, WODs and logged workouts
+type AttachmentHandler struct {
+	attachmentService *service.AttachmentService
+	logger            *logger.Logger
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(attachmentService *service.AttachmentService, l *logger.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		logger:            l,
+	}
+}
+
+// AttachmentLinkRequest represents a link attachment
+type AttachmentLinkRequest struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// ListMovementAttachments handles GET /api/movements/{id}/attachments
+func (h *AttachmentHandler) ListMovementAttachments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, domain.AttachmentEntityMovement)
+}
+
+// ListWODAttachments handles GET /api/wods/{id}/attachments
+func (h *AttachmentHandler) ListWODAttachments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, domain.AttachmentEntityWOD)
+}
+
+// ListWorkoutAttachments handles GET /api/workouts/{id}/attachments (owner only)
+func (h *AttachmentHandler) ListWorkoutAttachments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, domain.AttachmentEntityWorkout)
+}
+
+// ListAthleteWorkoutAttachments handles GET /api/coach/athletes/{athlete_id}/workouts/{id}/attachments
+// Requires an active coaching relationship; the read is audited
+func (h *AttachmentHandler) ListAthleteWorkoutAttachments(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := parseAthleteRoute(w, r)
+	if !ok {
+		return
+	}
+
+	workoutID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	attachments, err := h.attachmentService.ListForCoach(coachID, athleteID, workoutID, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"attachments": attachments,
+		"count":       len(attachments),
+	})
+}
+
+// ServeWorkoutAttachmentFile handles GET /api/workouts/{id}/attachments/files/{name}?expires=&signature=
+// Takes no Authorization header so <img> and <video> tags can load and stream the file; the signed
+// URLs in attachment listings grant access until they expire
+func (h *AttachmentHandler) ServeWorkoutAttachmentFile(w http.ResponseWriter, r *http.Request) {
+	workoutID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	query := r.URL.Query()
+	filePath, err := h.attachmentService.WorkoutFile(workoutID, chi.URLParam(r, "name"), query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeFile(w, r, filePath)
+}
+
+// CreateMovementAttachment handles POST /api/movements/{id}/attachments
+// Admins attach to any movement, other users only to their own custom movements
+func (h *AttachmentHandler) CreateMovementAttachment(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, domain.AttachmentEntityMovement)
+}
+
+// CreateWODAttachment handles POST /api/wods/{id}/attachments
+// Admins attach to any WOD, other users only to their own custom WODs
+func (h *AttachmentHandler) CreateWODAttachment(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, domain.AttachmentEntityWOD)
+}
+
+// CreateWorkoutAttachment handles POST /api/workouts/{id}/attachments (owner only)
+func (h *AttachmentHandler) CreateWorkoutAttachment(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, domain.AttachmentEntityWorkout)
+}
+
+// DeleteAttachment handles DELETE /api/attachments/{id}
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	role, _ := middleware.GetUserRole(r.Context())
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attachment ID")
+		return
+	}
+
+	if err := h.attachmentService.Delete(id, userID, role == "admin"); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=delete_attachment outcome=success user_id=%d attachment_id=%d", userID, id)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Attachment deleted"})
+}
+
+func (h *AttachmentHandler) list(w http.ResponseWriter, r *http.Request, entityType string) {
+	entityID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	userID, _ := middleware.GetUserID(r.Context())
+
+	attachments, err := h.attachmentService.List(entityType, entityID, userID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"attachments": attachments,
+		"count":       len(attachments),
+	})
+}
+
+// create stores a multipart upload (a "file" field and optional "title") or a JSON link
+func (h *AttachmentHandler) create(w http.ResponseWriter, r *http.Request, entityType string) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	role, _ := middleware.GetUserRole(r.Context())
+
+	entityID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	var attachment *domain.Attachment
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		// Per-type limits are enforced by the service; this caps the request as a whole
+		r.Body = http.MaxBytesReader(w, r.Body, service.MaxAttachmentVideoBytes+(1<<20))
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			respondError(w, http.StatusBadRequest, "File too large (max 100MB) or invalid upload")
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "No file provided")
+			return
+		}
+		defer file.Close()
+
+		attachment, err = h.attachmentService.Upload(entityType, entityID, userID, role == "admin", r.FormValue("title"), header.Filename, file)
+		if err != nil {
+			h.respondServiceError(w, err)
+			return
+		}
+	} else {
+		var req AttachmentLinkRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		attachment, err = h.attachmentService.AddLink(entityType, entityID, userID, role == "admin", req.Title, req.URL)
+		if err != nil {
+			h.respondServiceError(w, err)
+			return
+		}
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=create_attachment outcome=success user_id=%d entity_type=%s entity_id=%d attachment_id=%d kind=%s size_bytes=%d",
+			userID, entityType, entityID, attachment.ID, attachment.Kind, attachment.SizeBytes)
+	}
+
+	respondJSON(w, http.StatusCreated, attachment)
+}
+
+func (h *AttachmentHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, service.ErrAttachmentEntityNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrAttachmentUnauthorized),
+		errors.Is(err, service.ErrAttachmentURLExpired),
+		errors.Is(err, service.ErrCoachAccessDenied):
+		respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		respondError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, service.ErrUnsupportedAttachment):
+		respondError(w, http.StatusUnsupportedMediaType, err.Error()+" (allowed: JPEG, PNG, GIF, WebP images and MP4, WebM, QuickTime videos)")
+	case errors.Is(err, service.ErrInvalidAttachmentEntity),
+		errors.Is(err, service.ErrInvalidAttachmentLink),
+		errors.Is(err, service.ErrInvalidAttachmentTitle):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=attachment_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "Attachment request failed")
+	}
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// BodyMetricHandler handles the dated body metrics log
+type BodyMetricHandler struct {
+	bodyMetricService *service.BodyMetricService
+	logger            *logger.Logger
+}
+
+// NewBodyMetricHandler creates a new body metric handler
+func NewBodyMetricHandler(bodyMetricService *service.BodyMetricService, l *logger.Logger) *BodyMetricHandler {
+	return &BodyMetricHandler{
+		bodyMetricService: bodyMetricService,
+		logger:            l,
+	}
+}
+
+// BodyMetricRequest represents a body metric entry
+type BodyMetricRequest struct {
+	MeasuredOn     string             `json:"measured_on"` // YYYY-MM-DD
+	BodyWeight     *float64           `json:"body_weight,omitempty"`
+	BodyFatPercent *float64           `json:"body_fat_percent,omitempty"`
+	Measurements   map[string]float64 `json:"measurements,omitempty"`
+	Notes          *string            `json:"notes,omitempty"`
+}
+
+func (req BodyMetricRequest) toDomain() *domain.BodyMetric {
+	return &domain.BodyMetric{
+		MeasuredOn:     req.MeasuredOn,
+		BodyWeight:     req.BodyWeight,
+		BodyFatPercent: req.BodyFatPercent,
+		Measurements:   req.Measurements,
+		Notes:          req.Notes,
+	}
+}
+
+// ListMetrics handles GET /api/body-metrics?start_date=&end_date=
+func (h *BodyMetricHandler) ListMetrics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	metrics, err := h.bodyMetricService.ListMetrics(userID, r.URL.Query().Get("start_date"), r.URL.Query().Get("end_date"))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if metrics == nil {
+		metrics = []*domain.BodyMetric{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"body_metrics": metrics,
+		"count":        len(metrics),
+	})
+}
+
+// GetMetric handles GET /api/body-metrics/{id}
+func (h *BodyMetricHandler) GetMetric(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+
+	metric, err := h.bodyMetricService.GetMetric(id, userID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, metric)
+}
+
+// CreateMetric handles POST /api/body-metrics
+func (h *BodyMetricHandler) CreateMetric(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req BodyMetricRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	metric := req.toDomain()
+	if err := h.bodyMetricService.CreateMetric(userID, metric); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=create_body_metric outcome=success user_id=%d body_metric_id=%d measured_on=%s", userID, metric.ID, metric.MeasuredOn)
+	}
+
+	respondJSON(w, http.StatusCreated, metric)
+}
+
+// UpdateMetric handles PUT /api/body-metrics/{id}
+func (h *BodyMetricHandler) UpdateMetric(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+
+	var req BodyMetricRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	metric, err := h.bodyMetricService.UpdateMetric(id, userID, req.toDomain())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, metric)
+}
+
+// DeleteMetric handles DELETE /api/body-metrics/{id}
+func (h *BodyMetricHandler) DeleteMetric(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.bodyMetricService.DeleteMetric(id, userID); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=delete_body_metric outcome=success user_id=%d body_metric_id=%d", userID, id)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Body metric deleted"})
+}
+
+// ExportMetrics handles GET /api/export/body-metrics (CSV)
+func (h *BodyMetricHandler) ExportMetrics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	data, err := h.bodyMetricService.ExportMetricsToCSV(userID)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=export_body_metrics outcome=failure user_id=%d error=%v", userID, err)
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to export body metrics")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=body_metrics_export.csv")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// ImportMetrics handles POST /api/import/body-metrics?update_existing=true
+// multipart/form-data with a "file" in the export CSV format
+func (h *BodyMetricHandler) ImportMetrics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		respondError(w, http.StatusBadRequest, "Failed to parse multipart form")
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	updateExisting := parseBoolParam(r.URL.Query().Get("update_existing"), false)
+	result, err := h.bodyMetricService.ImportMetricsCSV(userID, file, updateExisting)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=import_body_metrics outcome=failure user_id=%d error=%v", userID, err)
+		}
+		if errors.Is(err, service.ErrInvalidBodyMetricCSV) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to import body metrics")
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=import_body_metrics outcome=success user_id=%d created=%d updated=%d skipped=%d", userID, result.CreatedCount, result.UpdatedCount, result.SkippedCount)
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func (h *BodyMetricHandler) userAndID(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid body metric ID")
+		return 0, 0, false
+	}
+
+	return userID, id, true
+}
+
+func (h *BodyMetricHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrBodyMetricUnauthorized):
+		respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrBodyMetricNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrBodyMetricDateExists):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrBodyMetricEmpty), errors.Is(err, service.ErrBodyMetricInvalidValue),
+		errors.Is(err, service.ErrInvalidScheduleDate), errors.Is(err, service.ErrInvalidScheduleRange):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Body metric request failed: "+err.Error())
+	}
+}
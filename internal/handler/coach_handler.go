@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// CoachHandler handles coach-athlete relationships and coach read access to athlete data
+type CoachHandler struct {
+	coachService *service.CoachService
+	logger       *logger.Logger
+}
+
+// NewCoachHandler creates a new coach handler
+func NewCoachHandler(coachService *service.CoachService, l *logger.Logger) *CoachHandler {
+	return &CoachHandler{
+		coachService: coachService,
+		logger:       l,
+	}
+}
+
+// InviteAthleteRequest represents a coach's invite to an athlete
+type InviteAthleteRequest struct {
+	AthleteEmail string `json:"athlete_email"`
+}
+
+// InviteAthlete creates a pending invite to the athlete with the given email
+func (h *CoachHandler) InviteAthlete(w http.ResponseWriter, r *http.Request) {
+	coachID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req InviteAthleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.AthleteEmail == "" {
+		respondError(w, http.StatusBadRequest, "Athlete email is required")
+		return
+	}
+
+	rel, err := h.coachService.InviteAthlete(coachID, req.AthleteEmail, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Warn("action=coach_invite outcome=failure coach_id=%d error=%v", coachID, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=coach_invite outcome=success coach_id=%d athlete_id=%d", coachID, rel.AthleteID)
+	}
+
+	respondJSON(w, http.StatusCreated, rel)
+}
+
+// ListAthletes lists the coach's athletes (optionally filtered by ?status=)
+func (h *CoachHandler) ListAthletes(w http.ResponseWriter, r *http.Request) {
+	coachID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	rels, err := h.coachService.ListAthletes(coachID, r.URL.Query().Get("status"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list athletes")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"athletes": rels,
+		"count":    len(rels),
+	})
+}
+
+// ListAthleteWorkouts lists an athlete's logged workouts (read-only)
+func (h *CoachHandler) ListAthleteWorkouts(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := h.coachAndAthlete(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 20
+	offset := 0
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	if o, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && o >= 0 {
+		offset = o
+	}
+
+	workouts, err := h.coachService.ListAthleteWorkouts(coachID, athleteID, limit, offset, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.logFailure("coach_list_athlete_workouts", coachID, athleteID, err)
+		h.respondServiceError(w, err)
+		return
+	}
+	if workouts == nil {
+		workouts = []*domain.UserWorkoutWithDetails{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"workouts": workouts,
+		"count":    len(workouts),
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
+
+// GetAthleteWorkout retrieves one of an athlete's logged workouts (read-only)
+func (h *CoachHandler) GetAthleteWorkout(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := h.coachAndAthlete(w, r)
+	if !ok {
+		return
+	}
+
+	workoutID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid workout ID")
+		return
+	}
+
+	workout, err := h.coachService.GetAthleteWorkout(coachID, athleteID, workoutID, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.logFailure("coach_get_athlete_workout", coachID, athleteID, err)
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, workout)
+}
+
+// GetAthletePRs retrieves an athlete's PR summary (read-only)
+func (h *CoachHandler) GetAthletePRs(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := h.coachAndAthlete(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 50
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	summary, err := h.coachService.GetAthletePRs(coachID, athleteID, limit, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.logFailure("coach_get_athlete_prs", coachID, athleteID, err)
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, summary)
+}
+
+// GetAthleteMovementPerformance retrieves an athlete's history for a movement (read-only)
+func (h *CoachHandler) GetAthleteMovementPerformance(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := h.coachAndAthlete(w, r)
+	if !ok {
+		return
+	}
+
+	movementID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid movement ID")
+		return
+	}
+
+	history, err := h.coachService.GetAthleteMovementHistory(coachID, athleteID, movementID, 1000, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.logFailure("coach_get_athlete_movement_performance", coachID, athleteID, err)
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"performances": history,
+		"count":        len(history),
+	})
+}
+
+// GetAthleteWODPerformance retrieves an athlete's history for a WOD (read-only)
+func (h *CoachHandler) GetAthleteWODPerformance(w http.ResponseWriter, r *http.Request) {
+	coachID, athleteID, ok := h.coachAndAthlete(w, r)
+	if !ok {
+		return
+	}
+
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	history, err := h.coachService.GetAthleteWODHistory(coachID, athleteID, wodID, 1000, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.logFailure("coach_get_athlete_wod_performance", coachID, athleteID, err)
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"performances": history,
+		"count":        len(history),
+	})
+}
+
+// ListMyCoaches lists the authenticated athlete's coaches and pending invites
+func (h *CoachHandler) ListMyCoaches(w http.ResponseWriter, r *http.Request) {
+	athleteID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	rels, err := h.coachService.ListCoaches(athleteID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list coaches")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"coaches": rels,
+		"count":   len(rels),
+	})
+}
+
+// AcceptInvite accepts a pending coach invite
+func (h *CoachHandler) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+	athleteID, relID, ok := h.athleteAndRelationship(w, r)
+	if !ok {
+		return
+	}
+
+	rel, err := h.coachService.AcceptInvite(athleteID, relID, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rel)
+}
+
+// DeclineInvite declines a pending coach invite
+func (h *CoachHandler) DeclineInvite(w http.ResponseWriter, r *http.Request) {
+	athleteID, relID, ok := h.athleteAndRelationship(w, r)
+	if !ok {
+		return
+	}
+
+	rel, err := h.coachService.DeclineInvite(athleteID, relID, r.RemoteAddr, r.UserAgent())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rel)
+}
+
+// RevokeCoach revokes a coach's access to the authenticated athlete's data
+func (h *CoachHandler) RevokeCoach(w http.ResponseWriter, r *http.Request) {
+	athleteID, relID, ok := h.athleteAndRelationship(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.coachService.RevokeCoach(athleteID, relID, r.RemoteAddr, r.UserAgent()); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=revoke_coach outcome=success athlete_id=%d relationship_id=%d", athleteID, relID)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Coach access revoked"})
+}
+
+func (h *CoachHandler) coachAndAthlete(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	coachID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+
+	athleteID, err := strconv.ParseInt(chi.URLParam(r, "athlete_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid athlete ID")
+		return 0, 0, false
+	}
+
+	return coachID, athleteID, true
+}
+
+func (h *CoachHandler) athleteAndRelationship(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	athleteID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+
+	relID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid relationship ID")
+		return 0, 0, false
+	}
+
+	return athleteID, relID, true
+}
+
+func (h *CoachHandler) logFailure(action string, coachID, athleteID int64, err error) {
+	if h.logger != nil {
+		h.logger.Warn("action=%s outcome=failure coach_id=%d athlete_id=%d error=%v", action, coachID, athleteID, err)
+	}
+}
+
+func (h *CoachHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrCoachAccessDenied:
+		respondError(w, http.StatusForbidden, err.Error())
+	case service.ErrCoachAthleteNotFound, service.ErrCoachRelationshipNotFound, service.ErrUserWorkoutNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case service.ErrCoachRelationshipExists, service.ErrCoachInvalidTransition:
+		respondError(w, http.StatusConflict, err.Error())
+	case service.ErrCoachInviteSelf:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Coach request failed")
+	}
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// EnumerationHandler handles the admin-managed values of WOD source, type and regime and movement type
+type EnumerationHandler struct {
+	enumerationService *service.EnumerationService
+	logger             *logger.Logger
+}
+
+// NewEnumerationHandler creates a new enumeration handler
+func NewEnumerationHandler(enumerationService *service.EnumerationService, l *logger.Logger) *EnumerationHandler {
+	return &EnumerationHandler{
+		enumerationService: enumerationService,
+		logger:             l,
+	}
+}
+
+// EnumerationRequest represents a request to add a value to a category
+type EnumerationRequest struct {
+	Category     string  `json:"category"`
+	Value        string  `json:"value"`
+	Description  *string `json:"description,omitempty"`
+	DisplayOrder int     `json:"display_order,omitempty"` // Defaults to the end of the list
+	IsDeprecated bool    `json:"is_deprecated"`
+}
+
+// ListEnumerations handles GET /api/enumerations?category=
+// Lists the active values in display order, for pickers and CSV templates
+func (h *EnumerationHandler) ListEnumerations(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, false)
+}
+
+// ListAllEnumerations handles GET /api/admin/enumerations?category= (admin only)
+// Includes deprecated values and how many WODs or movements use each value
+func (h *EnumerationHandler) ListAllEnumerations(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, true)
+}
+
+func (h *EnumerationHandler) list(w http.ResponseWriter, r *http.Request, includeDeprecated bool) {
+	enumerations, err := h.enumerationService.List(r.URL.Query().Get("category"), includeDeprecated)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"enumerations": enumerations,
+		"categories":   domain.EnumerationCategories,
+	})
+}
+
+// CreateEnumeration handles POST /api/admin/enumerations (admin only)
+func (h *EnumerationHandler) CreateEnumeration(w http.ResponseWriter, r *http.Request) {
+	var req EnumerationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	enumeration := &domain.Enumeration{
+		Category:     req.Category,
+		Value:        req.Value,
+		Description:  req.Description,
+		DisplayOrder: req.DisplayOrder,
+		IsDeprecated: req.IsDeprecated,
+	}
+	if err := h.enumerationService.Create(enumeration); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		adminID, _ := middleware.GetUserID(r.Context())
+		h.logger.Info("action=create_enumeration outcome=success user_id=%d category=%s value=%s", adminID, enumeration.Category, enumeration.Value)
+	}
+
+	respondJSON(w, http.StatusCreated, enumeration)
+}
+
+// UpdateEnumeration handles PUT /api/admin/enumerations/{id} (admin only)
+// Any of value, description, display_order and is_deprecated; renaming updates the WODs or movements using the value
+func (h *EnumerationHandler) UpdateEnumeration(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid enumeration ID")
+		return
+	}
+
+	var req service.EnumerationUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(r.Context())
+	adminEmail, _ := middleware.GetUserEmail(r.Context())
+
+	enumeration, err := h.enumerationService.Update(id, &req, adminID, adminEmail)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=update_enumeration outcome=success user_id=%d enumeration_id=%d", adminID, id)
+	}
+
+	respondJSON(w, http.StatusOK, enumeration)
+}
+
+// DeleteEnumeration handles DELETE /api/admin/enumerations/{id} (admin only; values in use must be deprecated instead)
+func (h *EnumerationHandler) DeleteEnumeration(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid enumeration ID")
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(r.Context())
+	adminEmail, _ := middleware.GetUserEmail(r.Context())
+
+	if err := h.enumerationService.Delete(id, adminID, adminEmail); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=delete_enumeration outcome=success user_id=%d enumeration_id=%d", adminID, id)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Enumeration value deleted successfully"})
+}
+
+// ImportEnumerations handles POST /api/admin/enumerations/import (admin only)
+// The body is a JSON export from GET /api/admin/enumerations/export?format=json; values that already exist are skipped
+func (h *EnumerationHandler) ImportEnumerations(w http.ResponseWriter, r *http.Request) {
+	adminID, _ := middleware.GetUserID(r.Context())
+
+	count, err := h.enumerationService.Import(http.MaxBytesReader(w, r.Body, maxUploadSize))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=import_enumerations outcome=success user_id=%d created=%d", adminID, count)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"imported": count,
+	})
+}
+
+func (h *EnumerationHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrEnumerationNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrEnumerationDuplicate), errors.Is(err, service.ErrEnumerationInUse),
+		errors.Is(err, service.ErrEnumerationLastValue):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidEnumeration), errors.Is(err, service.ErrInvalidEnumerationCategory):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=enumeration_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "Enumeration request failed")
+	}
+}
@@ -134,6 +134,41 @@ func (h *ExportHandler) ExportMovements(w http.ResponseWriter, r *http.Request)
 	w.Write(data)
 }
 
+// ExportEnumerations exports the admin-managed WOD and movement field values to CSV or JSON format
+// GET /api/admin/enumerations/export?format=json (admin only; the JSON file can be imported on another install)
+func (h *ExportHandler) ExportEnumerations(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+
+	var data []byte
+	var err error
+	var contentType, filename string
+
+	if format == "json" {
+		data, err = h.exportService.ExportEnumerationsToJSON()
+		contentType = "application/json"
+		filename = "enumerations_export.json"
+	} else {
+		data, err = h.exportService.ExportEnumerationsToCSV()
+		contentType = "text/csv"
+		filename = "enumerations_export.csv"
+	}
+
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to export enumerations: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 // Helper function to parse boolean query parameters
 func parseBoolParam(value string, defaultValue bool) bool {
 	if value == "" {
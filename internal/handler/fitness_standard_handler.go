@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// FitnessStandardHandler handles the reference standards behind fitness profiles
+type FitnessStandardHandler struct {
+	fitnessProfileService *service.FitnessProfileService
+	logger                *logger.Logger
+}
+
+// NewFitnessStandardHandler creates a new fitness standard handler
+func NewFitnessStandardHandler(fitnessProfileService *service.FitnessProfileService, l *logger.Logger) *FitnessStandardHandler {
+	return &FitnessStandardHandler{
+		fitnessProfileService: fitnessProfileService,
+		logger:                l,
+	}
+}
+
+// ListStandards handles GET /api/fitness-standards
+func (h *FitnessStandardHandler) ListStandards(w http.ResponseWriter, r *http.Request) {
+	standards, err := h.fitnessProfileService.ListStandards()
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=list_fitness_standards outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to list fitness standards")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"standards": standards,
+		"count":     len(standards),
+	})
+}
+
+// ImportStandards handles POST /api/admin/fitness-standards/import (admin only)
+// multipart/form-data with a "file" in the seeds/fitness_standards.csv format; replaces all standards
+func (h *FitnessStandardHandler) ImportStandards(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		respondError(w, http.StatusBadRequest, "Failed to parse multipart form")
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "No file uploaded")
+		return
+	}
+	defer file.Close()
+
+	count, err := h.fitnessProfileService.ImportStandards(file)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=import_fitness_standards outcome=failure user_id=%d error=%v", userID, err)
+		}
+		if errors.Is(err, service.ErrInvalidFitnessStandards) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to import fitness standards")
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=import_fitness_standards outcome=success user_id=%d count=%d", userID, count)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"imported": count,
+	})
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// GoalHandler handles personal goals
+type GoalHandler struct {
+	goalService *service.GoalService
+	logger      *logger.Logger
+}
+
+// NewGoalHandler creates a new goal handler
+func NewGoalHandler(goalService *service.GoalService, l *logger.Logger) *GoalHandler {
+	return &GoalHandler{
+		goalService: goalService,
+		logger:      l,
+	}
+}
+
+// GoalRequest represents a goal to create or the fields to change
+type GoalRequest struct {
+	Title        string  `json:"title"`
+	GoalType     string  `json:"goal_type"` // movement, wod, workout_count (create only)
+	MovementID   *int64  `json:"movement_id,omitempty"`
+	WODID        *int64  `json:"wod_id,omitempty"`
+	Metric       string  `json:"metric,omitempty"`         // max_weight, e1rm, max_reps, score, workouts (create only)
+	TargetValue  float64 `json:"target_value"`             // Weight, reps, seconds or workout count
+	RepsPerRound *int    `json:"reps_per_round,omitempty"` // Rounds+Reps WODs
+	StartDate    string  `json:"start_date,omitempty"`     // YYYY-MM-DD, defaults to today (create only)
+	TargetDate   *string `json:"target_date,omitempty"`    // YYYY-MM-DD; "" clears it on update
+	Notes        *string `json:"notes,omitempty"`
+	Status       string  `json:"status,omitempty"` // active or abandoned (update only)
+}
+
+func (req GoalRequest) toDomain() *domain.Goal {
+	return &domain.Goal{
+		Title:        req.Title,
+		GoalType:     req.GoalType,
+		MovementID:   req.MovementID,
+		WODID:        req.WODID,
+		Metric:       req.Metric,
+		TargetValue:  req.TargetValue,
+		RepsPerRound: req.RepsPerRound,
+		StartDate:    req.StartDate,
+		TargetDate:   req.TargetDate,
+		Notes:        req.Notes,
+		Status:       req.Status,
+	}
+}
+
+// ListGoals handles GET /api/goals?status=active|achieved|abandoned
+func (h *GoalHandler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	goals, err := h.goalService.ListGoals(userID, r.URL.Query().Get("status"))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"goals": goals,
+		"count": len(goals),
+	})
+}
+
+// GetGoal handles GET /api/goals/{id}
+func (h *GoalHandler) GetGoal(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+
+	goal, err := h.goalService.GetGoal(id, userID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, goal)
+}
+
+// CreateGoal handles POST /api/goals
+func (h *GoalHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req GoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Status = ""
+
+	goal, err := h.goalService.CreateGoal(userID, req.toDomain())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=create_goal outcome=success user_id=%d goal_id=%d goal_type=%s", userID, goal.ID, goal.GoalType)
+	}
+
+	respondJSON(w, http.StatusCreated, goal)
+}
+
+// UpdateGoal handles PUT /api/goals/{id}
+func (h *GoalHandler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+
+	var req GoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	goal, err := h.goalService.UpdateGoal(id, userID, req.toDomain())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, goal)
+}
+
+// DeleteGoal handles DELETE /api/goals/{id}
+func (h *GoalHandler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.goalService.DeleteGoal(id, userID); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=delete_goal outcome=success user_id=%d goal_id=%d", userID, id)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Goal deleted"})
+}
+
+func (h *GoalHandler) userAndID(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid goal ID")
+		return 0, 0, false
+	}
+
+	return userID, id, true
+}
+
+func (h *GoalHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrGoalUnauthorized):
+		respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrGoalNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrGoalAlreadyMet), errors.Is(err, service.ErrGoalAlreadyAchieved):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrGoalTitleRequired), errors.Is(err, service.ErrInvalidGoalType),
+		errors.Is(err, service.ErrInvalidGoalMetric), errors.Is(err, service.ErrInvalidGoalTarget),
+		errors.Is(err, service.ErrGoalRepsPerRoundRequired), errors.Is(err, service.ErrInvalidGoalStatus),
+		errors.Is(err, service.ErrMovementNotFound), errors.Is(err, service.ErrWODNotFound),
+		errors.Is(err, service.ErrInvalidScheduleDate), errors.Is(err, service.ErrInvalidScheduleRange):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=goal_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "Goal request failed")
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+)
+
+// LeaderboardHandler handles community WOD leaderboards
+type LeaderboardHandler struct {
+	leaderboardService *service.LeaderboardService
+	logger             *logger.Logger
+}
+
+// NewLeaderboardHandler creates a new leaderboard handler
+func NewLeaderboardHandler(leaderboardService *service.LeaderboardService, l *logger.Logger) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		leaderboardService: leaderboardService,
+		logger:             l,
+	}
+}
+
+// GetWODLeaderboard handles GET /api/leaderboards/wods/{wod_id}
+// Query: start_date, end_date (YYYY-MM-DD), gender, age_min, age_max, division, limit, offset
+func (h *LeaderboardHandler) GetWODLeaderboard(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "wod_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	q := r.URL.Query()
+	query := service.LeaderboardQuery{
+		StartDate: q.Get("start_date"),
+		EndDate:   q.Get("end_date"),
+		Gender:    q.Get("gender"),
+		Division:  q.Get("division"),
+	}
+
+	for param, target := range map[string]**int{"age_min": &query.AgeMin, "age_max": &query.AgeMax} {
+		if v := q.Get(param); v != "" {
+			age, err := strconv.Atoi(v)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid "+param)
+				return
+			}
+			*target = &age
+		}
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			query.Limit = parsedLimit
+		}
+	}
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
+			query.Offset = parsedOffset
+		}
+	}
+
+	leaderboard, err := h.leaderboardService.GetWODLeaderboard(wodID, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrWODNotFound):
+			respondError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrLeaderboardNotAvailable), errors.Is(err, service.ErrInvalidLeaderboardQuery),
+			errors.Is(err, service.ErrInvalidScheduleDate), errors.Is(err, service.ErrInvalidScheduleRange):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			if h.logger != nil {
+				h.logger.Error("action=get_leaderboard outcome=failure wod_id=%d error=%v", wodID, err)
+			}
+			respondError(w, http.StatusInternalServerError, "Failed to retrieve leaderboard")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, leaderboard)
+}
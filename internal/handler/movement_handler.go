@@ -2,8 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/johnzastrow/actalog/internal/domain"
@@ -28,9 +30,43 @@ func NewMovementHandler(movementRepo domain.MovementRepository, movementService
 	}
 }
 
+// MovementTaxonomyRequest represents taxonomy fields in movement requests
+// On update, omitted fields keep their current value; a parent_id of 0 clears the parent
+type MovementTaxonomyRequest struct {
+	Equipment    *[]string `json:"equipment"`
+	Pattern      *string   `json:"pattern"`
+	MuscleGroups *[]string `json:"muscle_groups"`
+	ParentID     *int64    `json:"parent_id"`
+}
+
+// apply copies the fields present in the request onto movement
+func (t MovementTaxonomyRequest) apply(movement *domain.Movement) {
+	if t.Equipment != nil {
+		movement.Equipment = *t.Equipment
+	}
+	if t.Pattern != nil {
+		movement.Pattern = *t.Pattern
+	}
+	if t.MuscleGroups != nil {
+		movement.MuscleGroups = *t.MuscleGroups
+	}
+	if t.ParentID != nil {
+		movement.ParentID = t.ParentID
+		if *t.ParentID == 0 {
+			movement.ParentID = nil
+		}
+	}
+}
+
 // ListAll returns all movements (both standard and custom)
+// Query: type, equipment, pattern, muscle_group, parent_id (variants of a movement), family_id (a movement and its variants)
 func (h *MovementHandler) ListAll(w http.ResponseWriter, r *http.Request) {
-	movements, err := h.movementRepo.ListAll()
+	filters, ok := parseMovementFilters(w, r)
+	if !ok {
+		return
+	}
+
+	movements, err := h.movementRepo.ListAll(filters)
 	if err != nil {
 		if h.logger != nil {
 			h.logger.Error("action=list_all_movements outcome=failure error=%v", err)
@@ -61,6 +97,7 @@ func (h *MovementHandler) ListStandard(w http.ResponseWriter, r *http.Request) {
 }
 
 // Search searches for movements by name
+// Query: q, limit, and the same filters as ListAll
 func (h *MovementHandler) Search(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	if query == "" {
@@ -80,7 +117,12 @@ func (h *MovementHandler) Search(w http.ResponseWriter, r *http.Request) {
 		h.logger.Info("action=search_movements query=%s limit=%d", query, limit)
 	}
 
-	movements, err := h.movementRepo.Search(query, limit)
+	filters, ok := parseMovementFilters(w, r)
+	if !ok {
+		return
+	}
+
+	movements, err := h.movementRepo.Search(query, filters, limit)
 	if err != nil {
 		if h.logger != nil {
 			h.logger.Error("action=search_movements outcome=failure query=%s error=%v", query, err)
@@ -120,12 +162,102 @@ func (h *MovementHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, movement)
 }
 
+// ListVariants handles GET /api/movements/{id}/variants
+func (h *MovementHandler) ListVariants(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid movement ID")
+		return
+	}
+
+	variants, err := h.movementService.ListVariants(id)
+	if err != nil {
+		if err == service.ErrMovementNotFound {
+			respondError(w, http.StatusNotFound, "Movement not found")
+			return
+		}
+		if h.logger != nil {
+			h.logger.Error("action=list_movement_variants outcome=failure id=%d error=%v", id, err)
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to retrieve movement variants")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"variants": variants,
+	})
+}
+
+// GetTaxonomyOptions handles GET /api/movements/taxonomy: the accepted equipment, pattern and muscle group values
+func (h *MovementHandler) GetTaxonomyOptions(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"equipment":     domain.MovementEquipment,
+		"patterns":      domain.MovementPatterns,
+		"muscle_groups": domain.MuscleGroups,
+	})
+}
+
+// UpdateTaxonomy handles PUT /api/admin/movements/{id}/taxonomy (admin only)
+// Works for standard movements too; omitted fields keep their current value
+func (h *MovementHandler) UpdateTaxonomy(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	userEmail, _ := middleware.GetUserEmail(r.Context())
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid movement ID")
+		return
+	}
+
+	var req MovementTaxonomyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	existing, err := h.movementService.GetByID(id)
+	if err != nil {
+		if err == service.ErrMovementNotFound {
+			respondError(w, http.StatusNotFound, "Movement not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to retrieve movement")
+		return
+	}
+	taxonomy := *existing
+	req.apply(&taxonomy)
+
+	movement, err := h.movementService.UpdateTaxonomy(id, &taxonomy, userID, userEmail)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTaxonomy) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if h.logger != nil {
+			h.logger.Error("action=update_movement_taxonomy outcome=failure id=%d error=%v", id, err)
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to update movement taxonomy")
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=update_movement_taxonomy outcome=success id=%d user_id=%d", id, userID)
+	}
+
+	respondJSON(w, http.StatusOK, movement)
+}
+
 // Create creates a new custom movement
 func (h *MovementHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Name        string `json:"name"`
 		Description string `json:"description"`
 		Type        string `json:"type"`
+		MovementTaxonomyRequest
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -146,12 +278,17 @@ func (h *MovementHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Type:        domain.MovementType(req.Type),
 		IsStandard:  false,
 	}
+	req.apply(movement)
 
 	if h.logger != nil {
 		h.logger.Info("action=create_movement_attempt name=%s type=%s", req.Name, req.Type)
 	}
 
-	if err := h.movementRepo.Create(movement); err != nil {
+	if err := h.movementService.Create(movement); err != nil {
+		if errors.Is(err, service.ErrInvalidTaxonomy) || errors.Is(err, service.ErrInvalidEnumeration) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		if h.logger != nil {
 			h.logger.Error("action=create_movement outcome=failure name=%s error=%v", req.Name, err)
 		}
@@ -191,6 +328,7 @@ func (h *MovementHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Name        string `json:"name"`
 		Description string `json:"description"`
 		Type        string `json:"type"`
+		MovementTaxonomyRequest
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -210,6 +348,12 @@ func (h *MovementHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Type:        domain.MovementType(req.Type),
 	}
 
+	// Keep the current taxonomy for fields the request omits
+	if existing, err := h.movementService.GetByID(id); err == nil {
+		movement.Equipment, movement.Pattern, movement.MuscleGroups, movement.ParentID = existing.Equipment, existing.Pattern, existing.MuscleGroups, existing.ParentID
+	}
+	req.apply(movement)
+
 	if h.logger != nil {
 		h.logger.Info("action=update_movement_attempt id=%d name=%s", id, req.Name)
 	}
@@ -226,6 +370,8 @@ func (h *MovementHandler) Update(w http.ResponseWriter, r *http.Request) {
 		}
 		if err == service.ErrMovementUnauthorized {
 			respondError(w, http.StatusForbidden, "Cannot modify standard movement")
+		} else if errors.Is(err, service.ErrInvalidTaxonomy) || errors.Is(err, service.ErrInvalidEnumeration) {
+			respondError(w, http.StatusBadRequest, err.Error())
 		} else {
 			respondError(w, http.StatusInternalServerError, "Failed to update movement: "+err.Error())
 		}
@@ -286,3 +432,25 @@ func (h *MovementHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		"message": "Movement deleted successfully",
 	})
 }
+
+// parseMovementFilters reads the movement list and search filters from the query string
+func parseMovementFilters(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	query := r.URL.Query()
+	filters := map[string]interface{}{}
+	for _, name := range []string{"type", "equipment", "pattern", "muscle_group"} {
+		if v := query.Get(name); v != "" {
+			filters[name] = strings.ToLower(v)
+		}
+	}
+	for _, name := range []string{"parent_id", "family_id"} {
+		if v := query.Get(name); v != "" {
+			id, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid "+name)
+				return nil, false
+			}
+			filters[name] = id
+		}
+	}
+	return filters, true
+}
@@ -0,0 +1,492 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// OrganizationHandler handles gym (organization) endpoints
+// Gym admin endpoints live under /api/admin/gyms/{gym_id} behind GymAdminScope; creating and deleting gyms is site admin only
+type OrganizationHandler struct {
+	orgService *service.OrganizationService
+	logger     *logger.Logger
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(orgService *service.OrganizationService, l *logger.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+		logger:     l,
+	}
+}
+
+// OrganizationRequest represents a gym create/update request
+type OrganizationRequest struct {
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug,omitempty"`
+	Description *string `json:"description,omitempty"` // Update: omitted leaves the description unchanged
+	AdminEmail  string  `json:"admin_email,omitempty"` // Create only: first gym admin
+}
+
+// GymMemberRequest represents a request to add a gym member
+type GymMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role,omitempty"` // member (default) or admin
+}
+
+// GymMemberRoleRequest represents a request to change a gym member's role
+type GymMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// GymLibraryItemRequest represents a request to add an existing WOD or movement to a gym library
+type GymLibraryItemRequest struct {
+	EntityType string `json:"entity_type"` // wod or movement
+	EntityID   int64  `json:"entity_id"`
+}
+
+// ListMyGyms lists the gyms the authenticated user belongs to
+func (h *OrganizationHandler) ListMyGyms(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	orgs, err := h.orgService.ListMyOrganizations(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list gyms")
+		return
+	}
+	if orgs == nil {
+		orgs = []*domain.Organization{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"gyms":  orgs,
+		"count": len(orgs),
+	})
+}
+
+// GetGym retrieves a gym the user belongs to
+func (h *OrganizationHandler) GetGym(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgService.GetOrganization(actor, orgID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, org)
+}
+
+// GetGymLibrary retrieves the gym's WOD and movement library layered on the global standard set
+func (h *OrganizationHandler) GetGymLibrary(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	library, err := h.orgService.GetLibrary(actor, orgID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, library)
+}
+
+// UpdateGym updates a gym's details (gym admin)
+func (h *OrganizationHandler) UpdateGym(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	var req OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	org, err := h.orgService.UpdateOrganization(actor, orgID, req.Name, req.Slug, req.Description)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, org)
+}
+
+// ListGymMembers lists a gym's members (gym admin)
+func (h *OrganizationHandler) ListGymMembers(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.orgService.ListMembers(actor, orgID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if members == nil {
+		members = []*domain.OrganizationMember{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"members": members,
+		"count":   len(members),
+	})
+}
+
+// AddGymMember adds a user to a gym by email (gym admin)
+func (h *OrganizationHandler) AddGymMember(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	var req GymMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Email == "" {
+		respondError(w, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	member, err := h.orgService.AddMember(actor, orgID, req.Email, req.Role)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=add_gym_member outcome=success gym_id=%d user_id=%d actor_id=%d", orgID, member.UserID, actor.UserID)
+	}
+
+	respondJSON(w, http.StatusCreated, member)
+}
+
+// UpdateGymMemberRole changes a gym member's role (gym admin)
+func (h *OrganizationHandler) UpdateGymMemberRole(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req GymMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.orgService.UpdateMemberRole(actor, orgID, userID, req.Role); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Member role updated"})
+}
+
+// RemoveGymMember removes a user from a gym (gym admin)
+func (h *OrganizationHandler) RemoveGymMember(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseInt(chi.URLParam(r, "user_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.orgService.RemoveMember(actor, orgID, userID); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=remove_gym_member outcome=success gym_id=%d user_id=%d actor_id=%d", orgID, userID, actor.UserID)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Member removed"})
+}
+
+// CreateGymWOD creates a WOD in the gym library (gym admin)
+func (h *OrganizationHandler) CreateGymWOD(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	var req CreateWODRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	wod := &domain.WOD{
+		Name:        req.Name,
+		Source:      req.Source,
+		Type:        req.Type,
+		Regime:      req.Regime,
+		ScoreType:   req.ScoreType,
+		Description: req.Description,
+		URL:         req.URL,
+		Notes:       req.Notes,
+	}
+
+	if err := h.orgService.CreateGymWOD(actor, orgID, wod); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, wod)
+}
+
+// CreateGymMovement creates a movement in the gym library (gym admin)
+func (h *OrganizationHandler) CreateGymMovement(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	var movement domain.Movement
+	if err := json.NewDecoder(r.Body).Decode(&movement); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.orgService.CreateGymMovement(actor, orgID, &movement); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, movement)
+}
+
+// AddGymLibraryItem adds an existing WOD or movement to the gym library (gym admin)
+func (h *OrganizationHandler) AddGymLibraryItem(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	var req GymLibraryItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.orgService.AddLibraryItem(actor, orgID, req.EntityType, req.EntityID); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]string{"message": "Added to gym library"})
+}
+
+// RemoveGymLibraryItem removes a WOD or movement from the gym library (gym admin)
+func (h *OrganizationHandler) RemoveGymLibraryItem(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	entityID, err := strconv.ParseInt(chi.URLParam(r, "entity_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid entity ID")
+		return
+	}
+
+	if err := h.orgService.RemoveLibraryItem(actor, orgID, chi.URLParam(r, "entity_type"), entityID); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Removed from gym library"})
+}
+
+// ListGyms lists all gyms (site admin)
+func (h *OrganizationHandler) ListGyms(w http.ResponseWriter, r *http.Request) {
+	orgs, err := h.orgService.ListOrganizations()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list gyms")
+		return
+	}
+	if orgs == nil {
+		orgs = []*domain.Organization{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"gyms":  orgs,
+		"count": len(orgs),
+	})
+}
+
+// CreateGym creates a gym (site admin)
+func (h *OrganizationHandler) CreateGym(w http.ResponseWriter, r *http.Request) {
+	actor, ok := h.actor(w, r)
+	if !ok {
+		return
+	}
+
+	var req OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	description := ""
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	org, err := h.orgService.CreateOrganization(actor, req.Name, req.Slug, description, req.AdminEmail)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=create_gym outcome=success gym_id=%d slug=%s actor_id=%d", org.ID, org.Slug, actor.UserID)
+	}
+
+	respondJSON(w, http.StatusCreated, org)
+}
+
+// DeleteGym deletes a gym (site admin)
+func (h *OrganizationHandler) DeleteGym(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.orgService.DeleteOrganization(actor, orgID); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=delete_gym outcome=success gym_id=%d actor_id=%d", orgID, actor.UserID)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Gym deleted"})
+}
+
+// GymAdminScope guards admin routes that gym admins can use for their own gym. The gym comes from the
+// {gym_id} URL parameter or the gym_id query parameter; site admins may omit it to act site-wide.
+// Handlers read the scope with middleware.GetGymScope.
+func (h *OrganizationHandler) GymAdminScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor, ok := h.actor(w, r)
+		if !ok {
+			return
+		}
+
+		gymParam := chi.URLParam(r, "gym_id")
+		if gymParam == "" {
+			gymParam = r.URL.Query().Get("gym_id")
+		}
+		if gymParam == "" {
+			if !actor.IsSiteAdmin {
+				respondError(w, http.StatusForbidden, "Forbidden: admin access required")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		orgID, err := strconv.ParseInt(gymParam, 10, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid gym ID")
+			return
+		}
+		if err := h.orgService.AuthorizeAdmin(actor, orgID); err != nil {
+			h.respondServiceError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(middleware.WithGymScope(r.Context(), orgID)))
+	})
+}
+
+func (h *OrganizationHandler) actor(w http.ResponseWriter, r *http.Request) (service.GymActor, bool) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return service.GymActor{}, false
+	}
+
+	role, _ := middleware.GetUserRole(r.Context())
+	return service.GymActor{
+		UserID:      userID,
+		IsSiteAdmin: role == "admin",
+		IPAddress:   r.RemoteAddr,
+		UserAgent:   r.UserAgent(),
+	}, true
+}
+
+func (h *OrganizationHandler) actorAndGym(w http.ResponseWriter, r *http.Request) (service.GymActor, int64, bool) {
+	actor, ok := h.actor(w, r)
+	if !ok {
+		return actor, 0, false
+	}
+
+	orgID, err := strconv.ParseInt(chi.URLParam(r, "gym_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid gym ID")
+		return actor, 0, false
+	}
+
+	return actor, orgID, true
+}
+
+func (h *OrganizationHandler) respondServiceError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrInvalidEnumeration) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch err {
+	case service.ErrGymAccessDenied, service.ErrGymAdminRequired:
+		respondError(w, http.StatusForbidden, err.Error())
+	case service.ErrGymNotFound, service.ErrGymMemberNotFound, service.ErrUserNotFound,
+		service.ErrWODNotFound, service.ErrMovementNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case service.ErrGymSlugTaken, service.ErrGymMemberExists, service.ErrGymLastAdmin,
+		service.ErrGymLibraryItemExists, service.ErrWODDuplicateName:
+		respondError(w, http.StatusConflict, err.Error())
+	case service.ErrGymNameRequired, service.ErrGymSlugInvalid, service.ErrGymInvalidRole, service.ErrGymInvalidEntityType,
+		service.ErrWODNameRequired, service.ErrWODSourceRequired, service.ErrWODTypeRequired,
+		service.ErrMovementNameRequired, service.ErrMovementTypeRequired:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=gym_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "Gym request failed")
+	}
+}
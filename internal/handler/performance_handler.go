@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/johnzastrow/actalog/internal/domain"
 	"github.com/johnzastrow/actalog/internal/repository"
+	"github.com/johnzastrow/actalog/internal/service"
 	"github.com/johnzastrow/actalog/pkg/logger"
 	"github.com/johnzastrow/actalog/pkg/middleware"
 	"github.com/johnzastrow/actalog/pkg/prmath"
@@ -18,6 +20,8 @@ type PerformanceHandler struct {
 	wodRepo                 *repository.WODRepository
 	userWorkoutMovementRepo *repository.UserWorkoutMovementRepository
 	userWorkoutWODRepo      *repository.UserWorkoutWODRepository
+	bodyMetricService       *service.BodyMetricService
+	searchService           *service.SearchService
 	logger                  *logger.Logger
 }
 
@@ -26,6 +30,12 @@ type MovementPerformanceWithRM struct {
 	*domain.UserWorkoutMovement
 	Calculated1RM *float64 `json:"calculated_1rm,omitempty"`
 	Formula       *string  `json:"formula,omitempty"`
+
+	// Relative strength against the body weight logged nearest the workout date
+	BodyWeight       *float64 `json:"body_weight,omitempty"`
+	BodyWeightDate   *string  `json:"body_weight_date,omitempty"`
+	RelativeStrength *float64 `json:"relative_strength,omitempty"` // Weight ÷ body weight
+	Relative1RM      *float64 `json:"relative_1rm,omitempty"`      // Calculated 1RM ÷ body weight
 }
 
 // NewPerformanceHandler creates a new performance handler
@@ -33,7 +43,9 @@ func NewPerformanceHandler(
 	movementRepo *repository.MovementRepository,
 	wodRepo *repository.WODRepository,
 	userWorkoutMovementRepo *repository.UserWorkoutMovementRepository,
-	userWorkoutWODRepo      *repository.UserWorkoutWODRepository,
+	userWorkoutWODRepo *repository.UserWorkoutWODRepository,
+	bodyMetricService *service.BodyMetricService,
+	searchService *service.SearchService,
 	logger *logger.Logger,
 ) *PerformanceHandler {
 	return &PerformanceHandler{
@@ -41,11 +53,13 @@ func NewPerformanceHandler(
 		wodRepo:                 wodRepo,
 		userWorkoutMovementRepo: userWorkoutMovementRepo,
 		userWorkoutWODRepo:      userWorkoutWODRepo,
+		bodyMetricService:       bodyMetricService,
+		searchService:           searchService,
 		logger:                  logger,
 	}
 }
 
-// UnifiedSearch searches both movements and WODs
+// UnifiedSearch searches both movements and WODs, ranked by full-text relevance
 func (h *PerformanceHandler) UnifiedSearch(w http.ResponseWriter, r *http.Request) {
 	// Extract user ID from context (for authorization)
 	userID, ok := middleware.GetUserID(r.Context())
@@ -72,56 +86,72 @@ func (h *PerformanceHandler) UnifiedSearch(w http.ResponseWriter, r *http.Reques
 		h.logger.Info("action=unified_search user_id=%d query=%s limit=%d", userID, query, limit)
 	}
 
-	// Search movements
-	movements, err := h.movementRepo.Search(query, limit)
-	if err != nil {
-		if h.logger != nil {
-			h.logger.Error("action=unified_search outcome=failure user_id=%d error=%v", userID, err)
-		}
-		respondError(w, http.StatusInternalServerError, "Failed to search movements")
+	response, err := h.searchService.Search(userID, query, []string{domain.SearchEntityMovement, domain.SearchEntityWOD}, limit)
+	if errors.Is(err, service.ErrSearchQueryRequired) {
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
-	// Search WODs
-	wods, err := h.wodRepo.Search(query, limit)
 	if err != nil {
 		if h.logger != nil {
 			h.logger.Error("action=unified_search outcome=failure user_id=%d error=%v", userID, err)
 		}
-		respondError(w, http.StatusInternalServerError, "Failed to search WODs")
+		respondError(w, http.StatusInternalServerError, "Failed to search movements and WODs")
 		return
 	}
 
 	// Format results
 	type SearchResult struct {
-		Type string      `json:"type"` // "movement" or "wod"
-		ID   int64       `json:"id"`
-		Name string      `json:"name"`
-		Data interface{} `json:"data"` // Full movement or WOD object
+		Type    string      `json:"type"` // "movement" or "wod"
+		ID      int64       `json:"id"`
+		Name    string      `json:"name"`
+		Snippet string      `json:"snippet,omitempty"`
+		Score   float64     `json:"score"`
+		Data    interface{} `json:"data"` // Full movement or WOD object
 	}
 
 	results := []SearchResult{}
+	movements, wods := 0, 0
+
+	for _, match := range response.Results {
+		// Entries the index has not caught up with (e.g. a record deleted by another process) are skipped
+		var data interface{}
+		var err error
+		if match.EntityType == domain.SearchEntityMovement {
+			var movement *domain.Movement
+			if movement, err = h.movementRepo.GetByID(match.EntityID); movement != nil {
+				data = movement
+				movements++
+			}
+		} else {
+			var wod *domain.WOD
+			if wod, err = h.wodRepo.GetByID(match.EntityID); wod != nil {
+				data = wod
+				wods++
+			}
+		}
+		if err != nil {
+			if h.logger != nil {
+				h.logger.Error("action=unified_search outcome=failure user_id=%d %s_id=%d error=%v", userID, match.EntityType, match.EntityID, err)
+			}
+			respondError(w, http.StatusInternalServerError, "Failed to load search results")
+			return
+		}
+		if data == nil {
+			continue
+		}
 
-	for _, m := range movements {
-		results = append(results, SearchResult{
-			Type: "movement",
-			ID:   m.ID,
-			Name: m.Name,
-			Data: m,
-		})
-	}
-
-	for _, w := range wods {
 		results = append(results, SearchResult{
-			Type: "wod",
-			ID:   w.ID,
-			Name: w.Name,
-			Data: w,
+			Type:    match.EntityType,
+			ID:      match.EntityID,
+			Name:    match.Title,
+			Snippet: match.Snippet,
+			Score:   match.Score,
+			Data:    data,
 		})
 	}
 
 	if h.logger != nil {
-		h.logger.Info("action=unified_search outcome=success user_id=%d movements=%d wods=%d", userID, len(movements), len(wods))
+		h.logger.Info("action=unified_search outcome=success user_id=%d movements=%d wods=%d", userID, movements, wods)
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
@@ -131,6 +161,7 @@ func (h *PerformanceHandler) UnifiedSearch(w http.ResponseWriter, r *http.Reques
 }
 
 // GetMovementPerformance retrieves all performance history for a specific movement with calculated 1RM
+// and, when the user logs body weight, relative strength against the nearest measurement
 func (h *PerformanceHandler) GetMovementPerformance(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r.Context())
 	if !ok {
@@ -159,10 +190,20 @@ func (h *PerformanceHandler) GetMovementPerformance(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// Body weights for relative strength (optional - performances are still returned without them)
+	var timeline *service.BodyWeightTimeline
+	if h.bodyMetricService != nil {
+		timeline, err = h.bodyMetricService.GetBodyWeightTimeline(userID)
+		if err != nil && h.logger != nil {
+			h.logger.Warn("action=get_movement_performance user_id=%d body_weight_error=%v", userID, err)
+		}
+	}
+
 	// Calculate 1RM for each performance and find the best
 	performancesWithRM := make([]MovementPerformanceWithRM, 0, len(performances))
 	var best1RM *float64
 	var bestFormula *string
+	var bestRelative1RM *float64
 
 	for _, perf := range performances {
 		perfWithRM := MovementPerformanceWithRM{
@@ -183,6 +224,25 @@ func (h *PerformanceHandler) GetMovementPerformance(w http.ResponseWriter, r *ht
 			}
 		}
 
+		if perf.Weight != nil && *perf.Weight > 0 {
+			if bm := timeline.Nearest(perf.WorkoutDate); bm != nil {
+				bodyWeight := *bm.BodyWeight
+				measuredOn := bm.MeasuredOn
+				relative := service.RelativeStrength(*perf.Weight, bodyWeight)
+				perfWithRM.BodyWeight = &bodyWeight
+				perfWithRM.BodyWeightDate = &measuredOn
+				perfWithRM.RelativeStrength = &relative
+
+				if perfWithRM.Calculated1RM != nil {
+					relative1RM := service.RelativeStrength(*perfWithRM.Calculated1RM, bodyWeight)
+					perfWithRM.Relative1RM = &relative1RM
+					if bestRelative1RM == nil || relative1RM > *bestRelative1RM {
+						bestRelative1RM = &relative1RM
+					}
+				}
+			}
+		}
+
 		performancesWithRM = append(performancesWithRM, perfWithRM)
 	}
 
@@ -191,10 +251,11 @@ func (h *PerformanceHandler) GetMovementPerformance(w http.ResponseWriter, r *ht
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
-		"performances": performancesWithRM,
-		"count":        len(performancesWithRM),
-		"best_1rm":     best1RM,
-		"best_formula": bestFormula,
+		"performances":      performancesWithRM,
+		"count":             len(performancesWithRM),
+		"best_1rm":          best1RM,
+		"best_formula":      bestFormula,
+		"best_relative_1rm": bestRelative1RM,
 	})
 }
 
@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// ProgramHandler handles multi-week training programs and enrollments
+type ProgramHandler struct {
+	programService *service.ProgramService
+	logger         *logger.Logger
+}
+
+// NewProgramHandler creates a new program handler
+func NewProgramHandler(programService *service.ProgramService, l *logger.Logger) *ProgramHandler {
+	return &ProgramHandler{
+		programService: programService,
+		logger:         l,
+	}
+}
+
+// ProgramRequest represents a program definition
+type ProgramRequest struct {
+	Name         string                   `json:"name"`
+	Description  *string                  `json:"description,omitempty"`
+	Weeks        int                      `json:"weeks"`
+	Sessions     []ProgramSessionRequest  `json:"sessions"`
+	Progressions []ProgressionRuleRequest `json:"progressions,omitempty"`
+}
+
+// ProgramSessionRequest represents one day of a program
+type ProgramSessionRequest struct {
+	Week      int     `json:"week"`
+	Day       int     `json:"day"`
+	WorkoutID int64   `json:"workout_id"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+// ProgressionRuleRequest represents a per-movement progression rule
+type ProgressionRuleRequest struct {
+	MovementID  int64     `json:"movement_id"`
+	RuleType    string    `json:"rule_type"` // linear, percent_1rm
+	BaseWeight  *float64  `json:"base_weight,omitempty"`
+	Increment   *float64  `json:"increment,omitempty"`
+	Percentages []float64 `json:"percentages,omitempty"`
+	RoundTo     float64   `json:"round_to,omitempty"`
+}
+
+// EnrollRequest represents enrolling in a program
+type EnrollRequest struct {
+	StartDate string `json:"start_date,omitempty"` // YYYY-MM-DD, defaults to today
+}
+
+// CompleteSessionRequest represents completing a program session
+type CompleteSessionRequest struct {
+	UserWorkoutID *int64 `json:"user_workout_id,omitempty"`
+}
+
+// ListPrograms lists standard programs and the user's own
+func (h *ProgramHandler) ListPrograms(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	programs, err := h.programService.ListPrograms(userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list programs")
+		return
+	}
+	if programs == nil {
+		programs = []*domain.Program{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"programs": programs,
+		"count":    len(programs),
+	})
+}
+
+// GetProgram returns a program with sessions and progression rules
+func (h *ProgramHandler) GetProgram(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid program ID")
+	if !ok {
+		return
+	}
+
+	program, err := h.programService.GetProgram(id, userID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, program)
+}
+
+// CreateProgram creates a program owned by the user
+func (h *ProgramHandler) CreateProgram(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req ProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	program := req.toDomain()
+	if err := h.programService.CreateProgram(userID, program); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=create_program outcome=success user_id=%d program_id=%d weeks=%d sessions=%d", userID, program.ID, program.Weeks, len(program.Sessions))
+	}
+
+	created, err := h.programService.GetProgram(program.ID, userID)
+	if err != nil {
+		respondJSON(w, http.StatusCreated, program)
+		return
+	}
+	respondJSON(w, http.StatusCreated, created)
+}
+
+// UpdateProgram replaces a program's definition (owner only)
+func (h *ProgramHandler) UpdateProgram(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid program ID")
+	if !ok {
+		return
+	}
+
+	var req ProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	program, err := h.programService.UpdateProgram(id, userID, req.toDomain())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, program)
+}
+
+// DeleteProgram deletes a program (owner only)
+func (h *ProgramHandler) DeleteProgram(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid program ID")
+	if !ok {
+		return
+	}
+
+	if err := h.programService.DeleteProgram(id, userID); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=delete_program outcome=success user_id=%d program_id=%d", userID, id)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Program deleted"})
+}
+
+// Enroll enrolls the user in a program
+func (h *ProgramHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid program ID")
+	if !ok {
+		return
+	}
+
+	var req EnrollRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	if req.StartDate == "" {
+		req.StartDate = time.Now().Format(domain.ScheduledDateFormat)
+	}
+
+	enrollment, err := h.programService.Enroll(userID, id, req.StartDate)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=program_enroll outcome=success user_id=%d program_id=%d enrollment_id=%d start_date=%s", userID, id, enrollment.ID, enrollment.StartDate)
+	}
+
+	respondJSON(w, http.StatusCreated, enrollment)
+}
+
+// ListEnrollments lists the user's enrollments (?status=active|completed|cancelled)
+func (h *ProgramHandler) ListEnrollments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	enrollments, err := h.programService.ListEnrollments(userID, r.URL.Query().Get("status"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list enrollments")
+		return
+	}
+	if enrollments == nil {
+		enrollments = []*domain.ProgramEnrollment{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"enrollments": enrollments,
+		"count":       len(enrollments),
+	})
+}
+
+// GetProgress returns all sessions of an enrollment with due dates and completion
+func (h *ProgramHandler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid enrollment ID")
+	if !ok {
+		return
+	}
+
+	progress, err := h.programService.GetProgress(userID, id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, progress)
+}
+
+// GetNextSession returns the next session with concrete weights
+func (h *ProgramHandler) GetNextSession(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid enrollment ID")
+	if !ok {
+		return
+	}
+
+	next, err := h.programService.GetNextSession(userID, id)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if next == nil {
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"message":      "All sessions completed",
+			"next_session": nil,
+		})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, next)
+}
+
+// CompleteSession marks a program session as done
+func (h *ProgramHandler) CompleteSession(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid enrollment ID")
+	if !ok {
+		return
+	}
+	sessionID, err := strconv.ParseInt(chi.URLParam(r, "session_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	var req CompleteSessionRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	progress, err := h.programService.CompleteSession(userID, id, sessionID, req.UserWorkoutID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=program_session_complete outcome=success user_id=%d enrollment_id=%d session_id=%d completed=%d/%d",
+			userID, id, sessionID, progress.CompletedSessions, progress.TotalSessions)
+	}
+
+	respondJSON(w, http.StatusOK, progress)
+}
+
+// CancelEnrollment cancels an active enrollment
+func (h *ProgramHandler) CancelEnrollment(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.userAndID(w, r, "id", "Invalid enrollment ID")
+	if !ok {
+		return
+	}
+
+	if err := h.programService.CancelEnrollment(userID, id); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Enrollment cancelled"})
+}
+
+func (req ProgramRequest) toDomain() *domain.Program {
+	program := &domain.Program{
+		Name:        req.Name,
+		Description: req.Description,
+		Weeks:       req.Weeks,
+	}
+	for _, s := range req.Sessions {
+		program.Sessions = append(program.Sessions, &domain.ProgramSession{
+			Week:      s.Week,
+			Day:       s.Day,
+			WorkoutID: s.WorkoutID,
+			Notes:     s.Notes,
+		})
+	}
+	for _, p := range req.Progressions {
+		program.Progressions = append(program.Progressions, &domain.ProgramProgression{
+			MovementID:  p.MovementID,
+			RuleType:    p.RuleType,
+			BaseWeight:  p.BaseWeight,
+			Increment:   p.Increment,
+			Percentages: p.Percentages,
+			RoundTo:     p.RoundTo,
+		})
+	}
+	return program
+}
+
+func (h *ProgramHandler) userAndID(w http.ResponseWriter, r *http.Request, param, invalidMessage string) (int64, int64, bool) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, param), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, invalidMessage)
+		return 0, 0, false
+	}
+
+	return userID, id, true
+}
+
+func (h *ProgramHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrProgramUnauthorized), errors.Is(err, service.ErrUnauthorizedWorkoutAccess):
+		respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrProgramNotFound), errors.Is(err, service.ErrEnrollmentNotFound),
+		errors.Is(err, service.ErrWorkoutNotFound), errors.Is(err, service.ErrMovementNotFound),
+		errors.Is(err, service.ErrUserWorkoutNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrSessionAlreadyCompleted), errors.Is(err, service.ErrEnrollmentNotActive):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrProgramNameRequired), errors.Is(err, service.ErrProgramInvalidWeeks),
+		errors.Is(err, service.ErrProgramNoSessions), errors.Is(err, service.ErrProgramInvalidSession),
+		errors.Is(err, service.ErrProgramInvalidRule), errors.Is(err, service.ErrSessionNotInProgram),
+		errors.Is(err, service.ErrInvalidScheduleDate):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Program request failed: "+err.Error())
+	}
+}
@@ -0,0 +1,317 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// ProgrammingHandler handles a gym's programming calendar
+type ProgrammingHandler struct {
+	programmingService *service.ProgrammingService
+	userWorkoutService *service.UserWorkoutService
+	logger             *logger.Logger
+}
+
+// NewProgrammingHandler creates a new programming handler
+func NewProgrammingHandler(programmingService *service.ProgrammingService, userWorkoutService *service.UserWorkoutService, l *logger.Logger) *ProgrammingHandler {
+	return &ProgrammingHandler{
+		programmingService: programmingService,
+		userWorkoutService: userWorkoutService,
+		logger:             l,
+	}
+}
+
+// ScheduleWorkoutRequest represents a coach publishing a template and/or WOD for a date
+type ScheduleWorkoutRequest struct {
+	ScheduledDate string  `json:"scheduled_date"` // YYYY-MM-DD
+	Track         string  `json:"track,omitempty"`
+	WorkoutID     *int64  `json:"workout_id,omitempty"`
+	WODID         *int64  `json:"wod_id,omitempty"`
+	Title         string  `json:"title,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+}
+
+// LogScheduledWorkoutRequest represents an athlete logging a scheduled workout
+type LogScheduledWorkoutRequest struct {
+	WorkoutDate string  `json:"workout_date,omitempty"` // YYYY-MM-DD, defaults to the scheduled date
+	WorkoutType *string `json:"workout_type,omitempty"`
+	TotalTime   *int    `json:"total_time,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+	// Session RPE, sleep, soreness and readiness (optional)
+	domain.SessionWellness
+	Movements []MovementPerformance `json:"movements,omitempty"`
+	WODs      []WODPerformance      `json:"wods,omitempty"` // wod_id may be omitted for the scheduled WOD
+}
+
+// ListCalendar lists a gym's scheduled workouts (?start=YYYY-MM-DD&end=YYYY-MM-DD&track=)
+// Defaults to the current week starting today
+func (h *ProgrammingHandler) ListCalendar(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	today := time.Now()
+	start := r.URL.Query().Get("start")
+	if start == "" {
+		start = today.Format(domain.ScheduledDateFormat)
+	}
+	end := r.URL.Query().Get("end")
+	if end == "" {
+		end = today.AddDate(0, 0, 6).Format(domain.ScheduledDateFormat)
+	}
+
+	scheduled, err := h.programmingService.ListCalendar(actor, orgID, start, end, r.URL.Query().Get("track"))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if scheduled == nil {
+		scheduled = []*domain.ScheduledWorkout{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"scheduled_workouts": scheduled,
+		"count":              len(scheduled),
+		"start":              start,
+		"end":                end,
+	})
+}
+
+// GetToday returns today's programming with full template details (?date=YYYY-MM-DD&track= to override)
+func (h *ProgrammingHandler) GetToday(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = time.Now().Format(domain.ScheduledDateFormat)
+	}
+
+	scheduled, err := h.programmingService.GetDay(actor, orgID, date, r.URL.Query().Get("track"))
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if scheduled == nil {
+		scheduled = []*domain.ScheduledWorkout{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"date":               date,
+		"scheduled_workouts": scheduled,
+		"count":              len(scheduled),
+	})
+}
+
+// LogScheduledWorkout logs a scheduled workout for the authenticated athlete
+func (h *ProgrammingHandler) LogScheduledWorkout(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid scheduled workout ID")
+		return
+	}
+
+	var req LogScheduledWorkoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var date *time.Time
+	if req.WorkoutDate != "" {
+		d, err := time.Parse("2006-01-02", req.WorkoutDate)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid workout date format. Use YYYY-MM-DD")
+			return
+		}
+		date = &d
+	}
+
+	movements := make([]*domain.UserWorkoutMovement, len(req.Movements))
+	for i, m := range req.Movements {
+		movements[i] = &domain.UserWorkoutMovement{
+			MovementID: m.MovementID,
+			Sets:       m.Sets,
+			Reps:       m.Reps,
+			Weight:     m.Weight,
+			Time:       m.Time,
+			Distance:   m.Distance,
+			Notes:      m.Notes,
+			OrderIndex: m.OrderIndex,
+		}
+	}
+
+	wods := make([]*domain.UserWorkoutWOD, len(req.WODs))
+	for i, wp := range req.WODs {
+		wods[i] = &domain.UserWorkoutWOD{
+			WODID:       wp.WODID,
+			ScoreType:   wp.ScoreType,
+			ScoreValue:  wp.ScoreValue,
+			TimeSeconds: wp.TimeSeconds,
+			Rounds:      wp.Rounds,
+			Reps:        wp.Reps,
+			Weight:      wp.Weight,
+			Division:    wp.Division,
+			Notes:       wp.Notes,
+			OrderIndex:  wp.OrderIndex,
+		}
+	}
+
+	userWorkout, err := h.programmingService.LogScheduled(actor, orgID, id, date, req.Notes, req.TotalTime, req.WorkoutType, &req.SessionWellness, movements, wods)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=log_scheduled_workout outcome=failure user_id=%d gym_id=%d scheduled_id=%d error=%v", actor.UserID, orgID, id, err)
+		}
+		h.respondServiceError(w, err)
+		return
+	}
+
+	logged, err := h.userWorkoutService.GetLoggedWorkout(userWorkout.ID, actor.UserID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to retrieve logged workout")
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=log_scheduled_workout outcome=success user_id=%d gym_id=%d scheduled_id=%d user_workout_id=%d", actor.UserID, orgID, id, userWorkout.ID)
+	}
+
+	respondJSON(w, http.StatusCreated, logged)
+}
+
+// ScheduleWorkout publishes a template and/or WOD on the gym calendar (gym admin)
+func (h *ProgrammingHandler) ScheduleWorkout(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	var req ScheduleWorkoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	sw := req.toDomain()
+	if err := h.programmingService.Schedule(actor, orgID, sw); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=schedule_workout outcome=success gym_id=%d scheduled_id=%d date=%s track=%s actor_id=%d", orgID, sw.ID, sw.ScheduledDate, sw.Track, actor.UserID)
+	}
+
+	respondJSON(w, http.StatusCreated, sw)
+}
+
+// UpdateScheduledWorkout updates a scheduled workout (gym admin)
+func (h *ProgrammingHandler) UpdateScheduledWorkout(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid scheduled workout ID")
+		return
+	}
+
+	var req ScheduleWorkoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	sw, err := h.programmingService.UpdateScheduled(actor, orgID, id, req.toDomain())
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, sw)
+}
+
+// DeleteScheduledWorkout removes a scheduled workout (gym admin)
+func (h *ProgrammingHandler) DeleteScheduledWorkout(w http.ResponseWriter, r *http.Request) {
+	actor, orgID, ok := h.actorAndGym(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid scheduled workout ID")
+		return
+	}
+
+	if err := h.programmingService.DeleteScheduled(actor, orgID, id); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Scheduled workout deleted"})
+}
+
+func (req ScheduleWorkoutRequest) toDomain() *domain.ScheduledWorkout {
+	return &domain.ScheduledWorkout{
+		ScheduledDate: req.ScheduledDate,
+		Track:         req.Track,
+		WorkoutID:     req.WorkoutID,
+		WODID:         req.WODID,
+		Title:         req.Title,
+		Notes:         req.Notes,
+	}
+}
+
+func (h *ProgrammingHandler) actorAndGym(w http.ResponseWriter, r *http.Request) (service.GymActor, int64, bool) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return service.GymActor{}, 0, false
+	}
+
+	orgID, err := strconv.ParseInt(chi.URLParam(r, "gym_id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid gym ID")
+		return service.GymActor{}, 0, false
+	}
+
+	role, _ := middleware.GetUserRole(r.Context())
+	return service.GymActor{
+		UserID:      userID,
+		IsSiteAdmin: role == "admin",
+		IPAddress:   r.RemoteAddr,
+		UserAgent:   r.UserAgent(),
+	}, orgID, true
+}
+
+func (h *ProgrammingHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrGymAccessDenied, service.ErrGymAdminRequired, service.ErrUnauthorizedWorkoutAccess:
+		respondError(w, http.StatusForbidden, err.Error())
+	case service.ErrGymNotFound, service.ErrScheduledWorkoutNotFound, service.ErrWorkoutNotFound, service.ErrWODNotFound:
+		respondError(w, http.StatusNotFound, err.Error())
+	case service.ErrScheduledWorkoutEmpty, service.ErrInvalidScheduleDate, service.ErrInvalidScheduleRange, service.ErrInvalidSessionWellness:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Programming request failed: "+err.Error())
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// SearchHandler handles full-text search across movements, WODs, templates and workout notes
+type SearchHandler struct {
+	searchService *service.SearchService
+	logger        *logger.Logger
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService *service.SearchService, l *logger.Logger) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		logger:        l,
+	}
+}
+
+// Search handles GET /api/search?q=&types=&limit=
+// types is a comma-separated subset of movement, wod, template and workout; results are ranked by relevance
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	var types []string
+	for _, entityType := range strings.Split(q.Get("types"), ",") {
+		if entityType = strings.TrimSpace(entityType); entityType != "" {
+			types = append(types, entityType)
+		}
+	}
+	limit := 0
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	response, err := h.searchService.Search(userID, q.Get("q"), types, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSearchQueryRequired), errors.Is(err, service.ErrInvalidSearchType):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			if h.logger != nil {
+				h.logger.Error("action=search outcome=failure user_id=%d error=%v", userID, err)
+			}
+			respondError(w, http.StatusInternalServerError, "Failed to search")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// RebuildIndex handles POST /api/admin/search/rebuild (admin only)
+// Re-indexes every movement, WOD, template and logged workout
+func (h *SearchHandler) RebuildIndex(w http.ResponseWriter, r *http.Request) {
+	adminID, _ := middleware.GetUserID(r.Context())
+
+	indexed, err := h.searchService.Rebuild()
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=rebuild_search_index outcome=failure user_id=%d error=%v", adminID, err)
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to rebuild search index")
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=rebuild_search_index outcome=success user_id=%d indexed=%d backend=%s", adminID, indexed, h.searchService.Backend())
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"indexed": indexed,
+		"backend": h.searchService.Backend(),
+	})
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// SeedLibraryHandler handles syncing the standard movements and WODs with the seed bundle
+type SeedLibraryHandler struct {
+	seedLibraryService *service.SeedLibraryService
+	logger             *logger.Logger
+}
+
+// NewSeedLibraryHandler creates a new seed library handler
+func NewSeedLibraryHandler(seedLibraryService *service.SeedLibraryService, l *logger.Logger) *SeedLibraryHandler {
+	return &SeedLibraryHandler{
+		seedLibraryService: seedLibraryService,
+		logger:             l,
+	}
+}
+
+// GetDiff handles GET /api/admin/seed-library/diff (admin only)
+// Lists the movements and WODs the bundle would create, update or retire; changes to records with
+// local edits are flagged and need confirmation to apply
+func (h *SeedLibraryHandler) GetDiff(w http.ResponseWriter, r *http.Request) {
+	diff, err := h.seedLibraryService.Diff()
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, diff)
+}
+
+// Apply handles POST /api/admin/seed-library/apply (admin only)
+// The body names the reviewed bundle_version, the keys to apply (all when empty) and confirm_overwrite
+func (h *SeedLibraryHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	var req service.SeedApplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(r.Context())
+	adminEmail, _ := middleware.GetUserEmail(r.Context())
+
+	result, err := h.seedLibraryService.Apply(&req, adminID, adminEmail)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=apply_seed_library outcome=success user_id=%d bundle_version=%s applied=%d skipped=%d adopted=%d",
+			adminID, result.BundleVersion, len(result.Applied), len(result.Skipped), result.Adopted)
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+func (h *SeedLibraryHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrSeedBundleNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrSeedBundleChanged):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=seed_library_request outcome=failure error=%v", err)
+		}
+		if errors.Is(err, service.ErrInvalidSeedBundle) {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Seed library request failed")
+	}
+}
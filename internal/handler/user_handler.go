@@ -33,7 +33,11 @@ func NewUserHandler(userService *service.UserService, l *logger.Logger) *UserHan
 type UpdateProfileRequest struct {
 	Name     string `json:"name,omitempty"`
 	Email    string `json:"email,omitempty"`
-	Birthday string `json:"birthday,omitempty"` // Format: "YYYY-MM-DD" or empty
+	Birthday string `json:"birthday,omitempty"` // Format: "YYYY-MM-DD"; empty keeps the current birthday
+	Gender   string `json:"gender,omitempty"`   // male or female; empty keeps the current gender
+	// ClearBirthday and ClearGender remove the stored value (cannot be combined with a new value)
+	ClearBirthday bool `json:"clear_birthday,omitempty"`
+	ClearGender   bool `json:"clear_gender,omitempty"`
 }
 
 // ProfileResponse represents a profile response
@@ -56,6 +60,11 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if (req.ClearBirthday && req.Birthday != "") || (req.ClearGender && req.Gender != "") {
+		respondError(w, http.StatusBadRequest, "Cannot set and clear the same field")
+		return
+	}
+
 	// Parse birthday if provided
 	var birthday *time.Time
 	if req.Birthday != "" {
@@ -67,14 +76,21 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		birthday = &parsedBirthday
 	}
 
+	var gender *string
+	if req.Gender != "" {
+		gender = &req.Gender
+	}
+
 	if h.logger != nil {
 		h.logger.Info("action=update_profile_attempt user_id=%d name=%s email=%s", userID, req.Name, req.Email)
 	}
 
 	// Update profile
-	user, err := h.userService.UpdateProfile(userID, req.Name, req.Email, birthday)
+	user, err := h.userService.UpdateProfile(userID, req.Name, req.Email, birthday, gender, req.ClearBirthday, req.ClearGender)
 	if err != nil {
 		switch err {
+		case service.ErrInvalidGender:
+			respondError(w, http.StatusBadRequest, err.Error())
 		case service.ErrEmailAlreadyExists:
 			if h.logger != nil {
 				h.logger.Warn("action=update_profile outcome=failure user_id=%d reason=email_exists email=%s", userID, req.Email)
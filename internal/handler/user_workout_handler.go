@@ -35,6 +35,8 @@ type LogWorkoutRequest struct {
 	WorkoutType *string `json:"workout_type,omitempty"`
 	TotalTime   *int    `json:"total_time,omitempty"`
 	Notes       *string `json:"notes,omitempty"`
+	// Session RPE, sleep, soreness and readiness (optional)
+	domain.SessionWellness
 	// Performance data
 	Movements []MovementPerformance `json:"movements,omitempty"`
 	WODs      []WODPerformance      `json:"wods,omitempty"`
@@ -61,6 +63,7 @@ type WODPerformance struct {
 	Rounds      *int     `json:"rounds,omitempty"`       // For AMRAP
 	Reps        *int     `json:"reps,omitempty"`         // Remaining reps in AMRAP
 	Weight      *float64 `json:"weight,omitempty"`       // For max weight WODs
+	Division    *string  `json:"division,omitempty"`     // rx, scaled
 	Notes       string   `json:"notes,omitempty"`
 	OrderIndex  int      `json:"order_index"`
 }
@@ -73,6 +76,7 @@ type UpdateLoggedWorkoutRequest struct {
 	Notes       *string                `json:"notes,omitempty"`
 	Movements   []MovementPerformance  `json:"movements,omitempty"`
 	WODs        []WODPerformance       `json:"wods,omitempty"`
+	domain.SessionWellness
 }
 
 // UserWorkoutResponse represents a logged workout instance
@@ -92,6 +96,7 @@ type UserWorkoutResponse struct {
 	PerformanceMovements []*domain.UserWorkoutMovement   `json:"performance_movements,omitempty"` // Actual performance
 	PerformanceWODs      []*domain.UserWorkoutWOD        `json:"performance_wods,omitempty"`      // Actual performance
 	WorkoutNotes         *string                         `json:"workout_notes,omitempty"`
+	domain.SessionWellness
 }
 
 // LogWorkout logs a workout instance (user performs a workout template)
@@ -171,6 +176,7 @@ func (h *UserWorkoutHandler) LogWorkout(w http.ResponseWriter, r *http.Request)
 				Rounds:      w.Rounds,
 				Reps:        w.Reps,
 				Weight:      w.Weight,
+				Division:    w.Division,
 				Notes:       w.Notes,
 				OrderIndex:  w.OrderIndex,
 			}
@@ -179,18 +185,22 @@ func (h *UserWorkoutHandler) LogWorkout(w http.ResponseWriter, r *http.Request)
 		// Log workout with performance data
 		userWorkout, err = h.userWorkoutService.LogWorkoutWithPerformance(
 			userID, req.WorkoutID, req.WorkoutName, workoutDate,
-			req.Notes, req.TotalTime, req.WorkoutType,
+			req.Notes, req.TotalTime, req.WorkoutType, &req.SessionWellness,
 			movements, wods,
 		)
 	} else {
 		// Log workout without performance data
-		userWorkout, err = h.userWorkoutService.LogWorkout(userID, req.WorkoutID, req.WorkoutName, workoutDate, req.Notes, req.TotalTime, req.WorkoutType)
+		userWorkout, err = h.userWorkoutService.LogWorkout(userID, req.WorkoutID, req.WorkoutName, workoutDate, req.Notes, req.TotalTime, req.WorkoutType, &req.SessionWellness)
 	}
 
 	if err != nil {
 		if h.logger != nil {
 			h.logger.Error("action=log_workout outcome=failure user_id=%d error=%v", userID, err)
 		}
+		if err == service.ErrInvalidSessionWellness {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to log workout: "+err.Error())
 		return
 	}
@@ -214,6 +224,7 @@ func (h *UserWorkoutHandler) LogWorkout(w http.ResponseWriter, r *http.Request)
 		WorkoutType:          logged.WorkoutType,
 		TotalTime:            logged.TotalTime,
 		Notes:                logged.Notes,
+		SessionWellness:      logged.SessionWellness,
 		CreatedAt:            logged.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:            logged.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 		Movements:            logged.Movements,
@@ -268,6 +279,7 @@ func (h *UserWorkoutHandler) GetLoggedWorkout(w http.ResponseWriter, r *http.Req
 		WorkoutType:          logged.WorkoutType,
 		TotalTime:            logged.TotalTime,
 		Notes:                logged.Notes,
+		SessionWellness:      logged.SessionWellness,
 		CreatedAt:            logged.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:            logged.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 		Movements:            logged.Movements,
@@ -375,6 +387,7 @@ func (h *UserWorkoutHandler) ListLoggedWorkouts(w http.ResponseWriter, r *http.R
 			WorkoutType:          logged.WorkoutType,
 			TotalTime:            logged.TotalTime,
 			Notes:                logged.Notes,
+			SessionWellness:      logged.SessionWellness,
 			CreatedAt:            logged.CreatedAt.Format("2006-01-02T15:04:05Z"),
 			UpdatedAt:            logged.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 			Movements:            logged.Movements,
@@ -420,7 +433,7 @@ func (h *UserWorkoutHandler) UpdateLoggedWorkout(w http.ResponseWriter, r *http.
 		h.logger.Info("action=update_workout_attempt user_id=%d workout_id=%d", userID, id)
 	}
 
-	if err := h.userWorkoutService.UpdateLoggedWorkout(id, userID, req.WorkoutName, req.Notes, req.TotalTime, req.WorkoutType); err != nil {
+	if err := h.userWorkoutService.UpdateLoggedWorkout(id, userID, req.WorkoutName, req.Notes, req.TotalTime, req.WorkoutType, &req.SessionWellness); err != nil {
 		switch err {
 		case service.ErrUserWorkoutNotFound:
 			if h.logger != nil {
@@ -432,6 +445,8 @@ func (h *UserWorkoutHandler) UpdateLoggedWorkout(w http.ResponseWriter, r *http.
 				h.logger.Warn("action=update_workout outcome=failure user_id=%d workout_id=%d reason=unauthorized", userID, id)
 			}
 			respondError(w, http.StatusForbidden, "You don't have permission to update this workout")
+		case service.ErrInvalidSessionWellness:
+			respondError(w, http.StatusBadRequest, err.Error())
 		default:
 			if h.logger != nil {
 				h.logger.Error("action=update_workout outcome=failure user_id=%d workout_id=%d error=%v", userID, id, err)
@@ -480,6 +495,7 @@ func (h *UserWorkoutHandler) UpdateLoggedWorkout(w http.ResponseWriter, r *http.
 				Rounds:      w.Rounds,
 				Reps:        w.Reps,
 				Weight:      w.Weight,
+				Division:    w.Division,
 				Notes:       w.Notes,
 				OrderIndex:  w.OrderIndex,
 			}
@@ -510,6 +526,7 @@ func (h *UserWorkoutHandler) UpdateLoggedWorkout(w http.ResponseWriter, r *http.
 		WorkoutType:          logged.WorkoutType,
 		TotalTime:            logged.TotalTime,
 		Notes:                logged.Notes,
+		SessionWellness:      logged.SessionWellness,
 		CreatedAt:            logged.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:            logged.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 		Movements:            logged.Movements,
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -98,6 +99,10 @@ func (h *WODHandler) CreateWOD(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.wodService.Create(wod, userID); err != nil {
+		if errors.Is(err, service.ErrInvalidEnumeration) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to create WOD: "+err.Error())
 		return
 	}
@@ -277,6 +282,13 @@ func (h *WODHandler) ListStandardWODs(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ListScoreTypes returns the registered WOD score types with their fields, ranking and example scores
+func (h *WODHandler) ListScoreTypes(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"score_types": domain.ScoreTypes(),
+	})
+}
+
 // ListMyWODs returns only the authenticated user's custom WODs
 func (h *WODHandler) ListMyWODs(w http.ResponseWriter, r *http.Request) {
 	// Extract user ID from JWT token in context
@@ -424,6 +436,8 @@ func (h *WODHandler) UpdateWOD(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if err == service.ErrUnauthorized || err == service.ErrWODUnauthorized || err == service.ErrWODOwnership {
 			respondError(w, http.StatusForbidden, "You don't have permission to update this WOD")
+		} else if errors.Is(err, service.ErrInvalidEnumeration) {
+			respondError(w, http.StatusBadRequest, err.Error())
 		} else {
 			respondError(w, http.StatusInternalServerError, "Failed to update WOD: "+err.Error())
 		}
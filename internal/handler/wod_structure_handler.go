@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// WODStructureHandler handles structured WOD definitions parsed from descriptions
+type WODStructureHandler struct {
+	structureService *service.WODStructureService
+	logger           *logger.Logger
+}
+
+// NewWODStructureHandler creates a new WOD structure handler
+func NewWODStructureHandler(structureService *service.WODStructureService, l *logger.Logger) *WODStructureHandler {
+	return &WODStructureHandler{
+		structureService: structureService,
+		logger:           l,
+	}
+}
+
+// WODStructureRequest represents an admin's corrected structure for a WOD
+type WODStructureRequest struct {
+	Rounds         *int                   `json:"rounds,omitempty"`
+	RepScheme      []int                  `json:"rep_scheme,omitempty"`
+	TimeCapSeconds *int                   `json:"time_cap_seconds,omitempty"`
+	Notes          string                 `json:"notes,omitempty"`
+	Components     []*domain.WODComponent `json:"components"`
+}
+
+// WODStructureResponse is a WOD's structure with the prescribed volume per movement
+type WODStructureResponse struct {
+	*domain.WODStructure
+	Volume []*domain.WODMovementVolume `json:"volume"`
+}
+
+func newWODStructureResponse(structure *domain.WODStructure) WODStructureResponse {
+	volume := structure.MovementVolume()
+	if volume == nil {
+		volume = []*domain.WODMovementVolume{}
+	}
+	return WODStructureResponse{WODStructure: structure, Volume: volume}
+}
+
+// GetStructure handles GET /api/wods/{id}/structure
+func (h *WODStructureHandler) GetStructure(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	structure, err := h.structureService.GetStructure(wodID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newWODStructureResponse(structure))
+}
+
+// ListWODsByMovement handles GET /api/movements/{id}/wods (WODs containing the movement)
+func (h *WODStructureHandler) ListWODsByMovement(w http.ResponseWriter, r *http.Request) {
+	movementID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid movement ID")
+		return
+	}
+	limit, offset := parseStructureListPage(r)
+
+	var userID *int64
+	if id, ok := middleware.GetUserID(r.Context()); ok {
+		userID = &id
+	}
+
+	wods, err := h.structureService.ListWODsByMovement(movementID, userID, limit, offset)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"wods":  wods,
+		"count": len(wods),
+	})
+}
+
+// ListForReview handles GET /api/admin/wods/structures/review (admin only)
+func (h *WODStructureHandler) ListForReview(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parseStructureListPage(r)
+
+	structures, total, err := h.structureService.ListForReview(limit, offset)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+	if structures == nil {
+		structures = []*domain.WODStructure{}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"structures": structures,
+		"total":      total,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// Backfill handles POST /api/admin/wods/structures/backfill?all=true (admin only)
+// Parses WODs without a structure; all=true also refreshes structures that were never reviewed
+func (h *WODStructureHandler) Backfill(w http.ResponseWriter, r *http.Request) {
+	all := r.URL.Query().Get("all") == "true"
+
+	result, err := h.structureService.Backfill(all)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		adminID, _ := middleware.GetUserID(r.Context())
+		h.logger.Info("action=backfill_wod_structures outcome=success user_id=%d parsed=%d needs_review=%d", adminID, result.Parsed, result.NeedsReview)
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// Reparse handles POST /api/admin/wods/{id}/structure/parse (admin only; discards a manual review)
+func (h *WODStructureHandler) Reparse(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	structure, err := h.structureService.Reparse(wodID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, newWODStructureResponse(structure))
+}
+
+// UpdateStructure handles PUT /api/admin/wods/{id}/structure (admin only)
+// Saves the corrected structure as reviewed, clearing its review flag
+func (h *WODStructureHandler) UpdateStructure(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	var req WODStructureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	structure, err := h.structureService.SaveReviewed(adminID, wodID, &domain.WODStructure{
+		Rounds:         req.Rounds,
+		RepScheme:      req.RepScheme,
+		TimeCapSeconds: req.TimeCapSeconds,
+		Notes:          req.Notes,
+		Components:     req.Components,
+	})
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=review_wod_structure outcome=success user_id=%d wod_id=%d components=%d", adminID, wodID, len(structure.Components))
+	}
+
+	respondJSON(w, http.StatusOK, newWODStructureResponse(structure))
+}
+
+func (h *WODStructureHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrWODNotFound), errors.Is(err, service.ErrMovementNotFound),
+		errors.Is(err, service.ErrWODStructureNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidWODStructure):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=wod_structure_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "WOD structure request failed")
+	}
+}
+
+// parseStructureListPage reads limit (default 50, max 200) and offset query parameters
+func parseStructureListPage(r *http.Request) (int, int) {
+	limit, offset := 50, 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v >= 0 {
+		offset = v
+	}
+	return limit, offset
+}
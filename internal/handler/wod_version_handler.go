@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// WODVersionHandler handles WOD version history and scaled, masters and team variants
+type WODVersionHandler struct {
+	versionService *service.WODVersionService
+	logger         *logger.Logger
+}
+
+// NewWODVersionHandler creates a new WOD version handler
+func NewWODVersionHandler(versionService *service.WODVersionService, l *logger.Logger) *WODVersionHandler {
+	return &WODVersionHandler{
+		versionService: versionService,
+		logger:         l,
+	}
+}
+
+// WODVersionComparabilityRequest marks whether a version's scores rank with the previous version's
+type WODVersionComparabilityRequest struct {
+	ComparableWithPrevious bool `json:"comparable_with_previous"`
+}
+
+// ListVersions handles GET /api/wods/{id}/versions
+// Versions are recorded from the WOD's first definition edit on; an unedited WOD has none
+func (h *WODVersionHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	versions, current, err := h.versionService.ListVersions(wodID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"wod_id":          wodID,
+		"current_version": current,
+		"versions":        versions,
+	})
+}
+
+// GetVersion handles GET /api/wods/{id}/versions/{version}
+func (h *WODVersionHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+	number, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid version number")
+		return
+	}
+
+	version, err := h.versionService.GetVersion(wodID, number)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, version)
+}
+
+// ListVariants handles GET /api/wods/{id}/variants
+// Returns the WOD's family: its parent and the parent's scaled, masters and team variants
+func (h *WODVersionHandler) ListVariants(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	family, err := h.versionService.GetFamily(wodID)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, family)
+}
+
+// SetVersionComparability handles PUT /api/admin/wods/{id}/versions/{version} (admin only)
+// Changing comparability moves which results rank on leaderboards and recomputes PRs
+func (h *WODVersionHandler) SetVersionComparability(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+	number, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid version number")
+		return
+	}
+
+	var req WODVersionComparabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(r.Context())
+	adminEmail, _ := middleware.GetUserEmail(r.Context())
+
+	version, err := h.versionService.SetComparable(wodID, number, req.ComparableWithPrevious, adminID, adminEmail)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=set_wod_version_comparability outcome=success user_id=%d wod_id=%d version=%d comparable=%t",
+			adminID, wodID, number, req.ComparableWithPrevious)
+	}
+
+	respondJSON(w, http.StatusOK, version)
+}
+
+// SaveVariant handles POST /api/admin/wods/{id}/variants (admin only)
+// Links an existing WOD as a scaled, masters or team variant of the WOD in the path
+func (h *WODVersionHandler) SaveVariant(w http.ResponseWriter, r *http.Request) {
+	parentID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	var req service.WODVariantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(r.Context())
+	adminEmail, _ := middleware.GetUserEmail(r.Context())
+
+	variant, err := h.versionService.SaveVariant(parentID, &req, adminID, adminEmail)
+	if err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=save_wod_variant outcome=success user_id=%d parent_wod_id=%d wod_id=%d kind=%s",
+			adminID, parentID, req.VariantWODID, variant.Kind)
+	}
+
+	respondJSON(w, http.StatusOK, variant)
+}
+
+// RemoveVariant handles DELETE /api/admin/wods/{id}/variant (admin only)
+// Detaches the WOD from its parent; the WOD and its results are kept
+func (h *WODVersionHandler) RemoveVariant(w http.ResponseWriter, r *http.Request) {
+	wodID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid WOD ID")
+		return
+	}
+
+	adminID, _ := middleware.GetUserID(r.Context())
+	adminEmail, _ := middleware.GetUserEmail(r.Context())
+
+	if err := h.versionService.RemoveVariant(wodID, adminID, adminEmail); err != nil {
+		h.respondServiceError(w, err)
+		return
+	}
+
+	if h.logger != nil {
+		h.logger.Info("action=remove_wod_variant outcome=success user_id=%d wod_id=%d", adminID, wodID)
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "WOD variant link removed successfully"})
+}
+
+func (h *WODVersionHandler) respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrWODNotFound), errors.Is(err, service.ErrWODVersionNotFound),
+		errors.Is(err, service.ErrWODVariantNotFound):
+		respondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidWODVersion), errors.Is(err, service.ErrInvalidWODVariant):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		if h.logger != nil {
+			h.logger.Error("action=wod_version_request outcome=failure error=%v", err)
+		}
+		respondError(w, http.StatusInternalServerError, "WOD version request failed")
+	}
+}
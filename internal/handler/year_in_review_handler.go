@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/service"
+	"github.com/johnzastrow/actalog/pkg/logger"
+	"github.com/johnzastrow/actalog/pkg/middleware"
+)
+
+// YearInReviewHandler handles annual training summaries
+type YearInReviewHandler struct {
+	yearInReviewService *service.YearInReviewService
+	logger              *logger.Logger
+}
+
+// NewYearInReviewHandler creates a new year in review handler
+func NewYearInReviewHandler(yearInReviewService *service.YearInReviewService, l *logger.Logger) *YearInReviewHandler {
+	return &YearInReviewHandler{
+		yearInReviewService: yearInReviewService,
+		logger:              l,
+	}
+}
+
+// GetYearInReview handles GET /api/analytics/year-in-review
+// Query: year (default current year), rest_days (0-6, default 1), format (json or html, default json)
+func (h *YearInReviewHandler) GetYearInReview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	year := time.Now().Year()
+	if v := query.Get("year"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid year")
+			return
+		}
+		year = parsed
+	}
+
+	restDays := 1
+	if v := query.Get("rest_days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid rest_days")
+			return
+		}
+		restDays = parsed
+	}
+
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "html" {
+		respondError(w, http.StatusBadRequest, "format must be json or html")
+		return
+	}
+
+	review, err := h.yearInReviewService.GetYearInReview(userID, year, restDays)
+	if err != nil {
+		switch err {
+		case service.ErrInvalidCalendarYear, service.ErrInvalidRestDays:
+			respondError(w, http.StatusBadRequest, err.Error())
+		case service.ErrUserNotFound:
+			respondError(w, http.StatusNotFound, err.Error())
+		default:
+			if h.logger != nil {
+				h.logger.Error("action=get_year_in_review outcome=failure user_id=%d year=%d error=%v", userID, year, err)
+			}
+			respondError(w, http.StatusInternalServerError, "Failed to build year in review")
+		}
+		return
+	}
+
+	if format != "html" {
+		respondJSON(w, http.StatusOK, review)
+		return
+	}
+
+	var page bytes.Buffer
+	if err := service.RenderYearInReviewHTML(&page, review); err != nil {
+		if h.logger != nil {
+			h.logger.Error("action=render_year_in_review outcome=failure user_id=%d year=%d error=%v", userID, year, err)
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to render year in review")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=actalog-year-in-review-%d.html", year))
+	w.Header().Set("Content-Length", strconv.Itoa(page.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(page.Bytes())
+}
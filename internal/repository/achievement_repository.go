@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// AchievementRepository implements domain.AchievementRepository
+type AchievementRepository struct {
+	db *sql.DB
+}
+
+// NewAchievementRepository creates a new achievement repository
+func NewAchievementRepository(db *sql.DB) *AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+const achievementRuleSelect = `
+	SELECT id, code, name, description, icon, rule_type, params, is_active, created_by, created_at, updated_at
+	FROM achievement_rules`
+
+// CreateRule creates a new achievement rule
+func (r *AchievementRepository) CreateRule(rule *domain.AchievementRule) error {
+	params, err := json.Marshal(rule.Params)
+	if err != nil {
+		return fmt.Errorf("failed to encode rule params: %w", err)
+	}
+
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	id, err := insertReturningID(r.db, `INSERT INTO achievement_rules (code, name, description, icon, rule_type, params, is_active, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.Code, rule.Name, rule.Description, rule.Icon, rule.RuleType, string(params), rule.IsActive, rule.CreatedBy, rule.CreatedAt, rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create achievement rule: %w", err)
+	}
+
+	rule.ID = id
+	return nil
+}
+
+// GetRuleByID retrieves a rule by ID
+func (r *AchievementRepository) GetRuleByID(id int64) (*domain.AchievementRule, error) {
+	rule, err := scanAchievementRule(r.db.QueryRow(rebindQuery(achievementRuleSelect+` WHERE id = ?`), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get achievement rule: %w", err)
+	}
+	return rule, nil
+}
+
+// GetRuleByCode retrieves a rule by its unique code
+func (r *AchievementRepository) GetRuleByCode(code string) (*domain.AchievementRule, error) {
+	rule, err := scanAchievementRule(r.db.QueryRow(rebindQuery(achievementRuleSelect+` WHERE code = ?`), code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get achievement rule: %w", err)
+	}
+	return rule, nil
+}
+
+// ListRules retrieves all rules (or only active ones) by ID
+func (r *AchievementRepository) ListRules(activeOnly bool) ([]*domain.AchievementRule, error) {
+	query := achievementRuleSelect
+	var args []interface{}
+	if activeOnly {
+		query += ` WHERE is_active = ?`
+		args = append(args, true)
+	}
+	query += ` ORDER BY id`
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list achievement rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*domain.AchievementRule
+	for rows.Next() {
+		rule, err := scanAchievementRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan achievement rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// CountRules returns the number of stored rules
+func (r *AchievementRepository) CountRules() (int, error) {
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM achievement_rules`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count achievement rules: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateRule updates a rule's definition
+func (r *AchievementRepository) UpdateRule(rule *domain.AchievementRule) error {
+	params, err := json.Marshal(rule.Params)
+	if err != nil {
+		return fmt.Errorf("failed to encode rule params: %w", err)
+	}
+
+	rule.UpdatedAt = time.Now()
+
+	query := rebindQuery(`UPDATE achievement_rules
+		SET code = ?, name = ?, description = ?, icon = ?, rule_type = ?, params = ?, is_active = ?, updated_at = ?
+		WHERE id = ?`)
+
+	result, err := r.db.Exec(query, rule.Code, rule.Name, rule.Description, rule.Icon, rule.RuleType, string(params), rule.IsActive, rule.UpdatedAt, rule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update achievement rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("achievement rule not found")
+	}
+
+	return nil
+}
+
+// DeleteRule deletes a rule and, by cascade, the badges awarded for it
+func (r *AchievementRepository) DeleteRule(id int64) error {
+	result, err := r.db.Exec(rebindQuery(`DELETE FROM achievement_rules WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete achievement rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("achievement rule not found")
+	}
+
+	return nil
+}
+
+// Award saves a badge unless the user already holds it
+func (r *AchievementRepository) Award(a *domain.UserAchievement) error {
+	var existing int
+	err := r.db.QueryRow(rebindQuery(`SELECT COUNT(*) FROM user_achievements WHERE user_id = ? AND rule_id = ?`), a.UserID, a.RuleID).Scan(&existing)
+	if err != nil {
+		return fmt.Errorf("failed to check existing achievement: %w", err)
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	if a.AwardedAt.IsZero() {
+		a.AwardedAt = time.Now()
+	}
+
+	id, err := insertReturningID(r.db, `INSERT INTO user_achievements (user_id, rule_id, user_workout_id, workout_date, awarded_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		a.UserID, a.RuleID, a.UserWorkoutID, a.WorkoutDate, a.AwardedAt)
+	if err != nil {
+		return fmt.Errorf("failed to award achievement: %w", err)
+	}
+
+	a.ID = id
+	return nil
+}
+
+// ListByUser retrieves a user's badges with their rule details, most recent first
+func (r *AchievementRepository) ListByUser(userID int64) ([]*domain.UserAchievement, error) {
+	query := rebindQuery(`
+		SELECT ua.id, ua.user_id, ua.rule_id, ua.user_workout_id, ua.workout_date, ua.awarded_at,
+			ar.code, ar.name, ar.description, ar.icon
+		FROM user_achievements ua
+		JOIN achievement_rules ar ON ar.id = ua.rule_id
+		WHERE ua.user_id = ?
+		ORDER BY ua.awarded_at DESC, ua.id DESC`)
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list achievements: %w", err)
+	}
+	defer rows.Close()
+
+	var achievements []*domain.UserAchievement
+	for rows.Next() {
+		a := &domain.UserAchievement{}
+		var userWorkoutID sql.NullInt64
+		var workoutDate, icon sql.NullString
+		if err := rows.Scan(&a.ID, &a.UserID, &a.RuleID, &userWorkoutID, &workoutDate, &a.AwardedAt,
+			&a.Code, &a.Name, &a.Description, &icon); err != nil {
+			return nil, fmt.Errorf("failed to scan achievement: %w", err)
+		}
+		if userWorkoutID.Valid {
+			a.UserWorkoutID = &userWorkoutID.Int64
+		}
+		if workoutDate.Valid {
+			a.WorkoutDate = &workoutDate.String
+		}
+		if icon.Valid {
+			a.Icon = &icon.String
+		}
+		achievements = append(achievements, a)
+	}
+
+	return achievements, rows.Err()
+}
+
+// ListWODEvents retrieves a user's WOD results with WOD name and type, oldest first
+func (r *AchievementRepository) ListWODEvents(userID int64) ([]*domain.AchievementEvent, error) {
+	return r.listEvents(`
+		SELECT uw.id, uw.workout_date, w.name, COALESCE(w.type, '')
+		FROM user_workout_wods uww
+		JOIN user_workouts uw ON uw.id = uww.user_workout_id
+		JOIN wods w ON w.id = uww.wod_id
+		WHERE uw.user_id = ?
+		ORDER BY uw.workout_date, uw.id, uww.id`, userID)
+}
+
+// ListPREvents retrieves a user's PR-flagged movement and WOD results, oldest first
+func (r *AchievementRepository) ListPREvents(userID int64) ([]*domain.AchievementEvent, error) {
+	return r.listEvents(`
+		SELECT id, workout_date, '', '' FROM (
+			SELECT uw.id, uw.workout_date
+			FROM user_workout_movements uwm
+			JOIN user_workouts uw ON uw.id = uwm.user_workout_id
+			WHERE uw.user_id = ? AND uwm.is_pr = ?
+			UNION ALL
+			SELECT uw.id, uw.workout_date
+			FROM user_workout_wods uww
+			JOIN user_workouts uw ON uw.id = uww.user_workout_id
+			WHERE uw.user_id = ? AND uww.is_pr = ?
+		) prs
+		ORDER BY workout_date, id`, userID, true, userID, true)
+}
+
+func (r *AchievementRepository) listEvents(query string, args ...interface{}) ([]*domain.AchievementEvent, error) {
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list achievement events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.AchievementEvent
+	for rows.Next() {
+		e := &domain.AchievementEvent{}
+		if err := rows.Scan(&e.UserWorkoutID, &e.WorkoutDate, &e.WODName, &e.WODType); err != nil {
+			return nil, fmt.Errorf("failed to scan achievement event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// ListUserIDsWithWorkouts lists every user who has logged at least one workout
+func (r *AchievementRepository) ListUserIDsWithWorkouts() ([]int64, error) {
+	rows, err := r.db.Query(`SELECT DISTINCT user_id FROM user_workouts ORDER BY user_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users with workouts: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func scanAchievementRule(row rowScanner) (*domain.AchievementRule, error) {
+	rule := &domain.AchievementRule{}
+	var icon, params sql.NullString
+	var createdBy sql.NullInt64
+
+	err := row.Scan(&rule.ID, &rule.Code, &rule.Name, &rule.Description, &icon, &rule.RuleType, &params, &rule.IsActive,
+		&createdBy, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if icon.Valid {
+		rule.Icon = &icon.String
+	}
+	if createdBy.Valid {
+		rule.CreatedBy = &createdBy.Int64
+	}
+	if params.Valid && params.String != "" {
+		if err := json.Unmarshal([]byte(params.String), &rule.Params); err != nil {
+			return nil, fmt.Errorf("failed to decode rule params: %w", err)
+		}
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// AnalyticsRepository implements domain.AnalyticsRepository with SQL aggregation
+type AnalyticsRepository struct {
+	db *sql.DB
+}
+
+// NewAnalyticsRepository creates a new analytics repository
+func NewAnalyticsRepository(db *sql.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// bucketExpr returns a driver-specific SQL expression that maps a date column to its bucket start (YYYY-MM-DD)
+func bucketExpr(bucket, column string) (string, error) {
+	switch currentDriver {
+	case "postgres":
+		switch bucket {
+		case domain.BucketDay:
+			return "to_char(date_trunc('day', " + column + "), 'YYYY-MM-DD')", nil
+		case domain.BucketWeek:
+			return "to_char(date_trunc('week', " + column + "), 'YYYY-MM-DD')", nil
+		case domain.BucketMonth:
+			return "to_char(date_trunc('month', " + column + "), 'YYYY-MM-DD')", nil
+		}
+	case "mysql":
+		switch bucket {
+		case domain.BucketDay:
+			return "DATE_FORMAT(" + column + ", '%Y-%m-%d')", nil
+		case domain.BucketWeek:
+			return "DATE_FORMAT(DATE_SUB(" + column + ", INTERVAL WEEKDAY(" + column + ") DAY), '%Y-%m-%d')", nil
+		case domain.BucketMonth:
+			return "DATE_FORMAT(" + column + ", '%Y-%m-01')", nil
+		}
+	default: // sqlite3
+		switch bucket {
+		case domain.BucketDay:
+			return "date(" + column + ")", nil
+		case domain.BucketWeek:
+			// Move to the coming Sunday (or stay on Sunday), then back to that week's Monday
+			return "date(" + column + ", 'weekday 0', '-6 days')", nil
+		case domain.BucketMonth:
+			return "strftime('%Y-%m-01', " + column + ")", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported bucket: %s", bucket)
+}
+
+// GetWorkoutVolume returns workout counts and time under work per bucket
+func (r *AnalyticsRepository) GetWorkoutVolume(userID int64, bucket string, start, end time.Time) ([]*domain.WorkoutVolume, error) {
+	expr, err := bucketExpr(bucket, "workout_date")
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + expr + ` AS bucket, COUNT(*), COALESCE(SUM(total_time), 0)
+		FROM user_workouts
+		WHERE user_id = ? AND workout_date >= ? AND workout_date <= ?
+		GROUP BY bucket
+		ORDER BY bucket`
+
+	rows, err := r.db.Query(rebindQuery(query), userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate workout volume: %w", err)
+	}
+	defer rows.Close()
+
+	var volumes []*domain.WorkoutVolume
+	for rows.Next() {
+		v := &domain.WorkoutVolume{}
+		if err := rows.Scan(&v.Bucket, &v.WorkoutCount, &v.TimeUnderWork); err != nil {
+			return nil, fmt.Errorf("failed to scan workout volume: %w", err)
+		}
+		volumes = append(volumes, v)
+	}
+
+	return volumes, rows.Err()
+}
+
+// GetMovementVolume returns sets, reps and tonnage per bucket and movement type
+func (r *AnalyticsRepository) GetMovementVolume(userID int64, bucket string, start, end time.Time) ([]*domain.MovementVolume, error) {
+	expr, err := bucketExpr(bucket, "uw.workout_date")
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + expr + ` AS bucket, m.type,
+		       COALESCE(SUM(COALESCE(uwm.sets, 1)), 0),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1) * COALESCE(uwm.reps, 0)), 0),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1) * COALESCE(uwm.reps, 0) * COALESCE(uwm.weight, 0)), 0)
+		FROM user_workout_movements uwm
+		JOIN user_workouts uw ON uwm.user_workout_id = uw.id
+		JOIN movements m ON uwm.movement_id = m.id
+		WHERE uw.user_id = ? AND uw.workout_date >= ? AND uw.workout_date <= ?
+		GROUP BY bucket, m.type
+		ORDER BY bucket, m.type`
+
+	rows, err := r.db.Query(rebindQuery(query), userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate movement volume: %w", err)
+	}
+	defer rows.Close()
+
+	var volumes []*domain.MovementVolume
+	for rows.Next() {
+		v := &domain.MovementVolume{}
+		if err := rows.Scan(&v.Bucket, &v.MovementType, &v.Sets, &v.Reps, &v.Tonnage); err != nil {
+			return nil, fmt.Errorf("failed to scan movement volume: %w", err)
+		}
+		volumes = append(volumes, v)
+	}
+
+	return volumes, rows.Err()
+}
+
+// GetWorkoutTypeCounts returns workouts per WorkoutType per bucket
+func (r *AnalyticsRepository) GetWorkoutTypeCounts(userID int64, bucket string, start, end time.Time) ([]*domain.WorkoutTypeCount, error) {
+	expr, err := bucketExpr(bucket, "workout_date")
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + expr + ` AS bucket, COALESCE(NULLIF(workout_type, ''), 'unspecified') AS wtype, COUNT(*)
+		FROM user_workouts
+		WHERE user_id = ? AND workout_date >= ? AND workout_date <= ?
+		GROUP BY bucket, wtype
+		ORDER BY bucket, wtype`
+
+	rows, err := r.db.Query(rebindQuery(query), userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count workout types: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*domain.WorkoutTypeCount
+	for rows.Next() {
+		c := &domain.WorkoutTypeCount{}
+		if err := rows.Scan(&c.Bucket, &c.WorkoutType, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan workout type count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// GetMovementFrequency returns every movement logged in the range, most sessions first
+func (r *AnalyticsRepository) GetMovementFrequency(userID int64, start, end time.Time) ([]*domain.MovementFrequency, error) {
+	query := `SELECT m.id, m.name,
+		       COUNT(DISTINCT uwm.user_workout_id),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1)), 0),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1) * COALESCE(uwm.reps, 0)), 0),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1) * COALESCE(uwm.reps, 0) * COALESCE(uwm.weight, 0)), 0)
+		FROM user_workout_movements uwm
+		JOIN user_workouts uw ON uwm.user_workout_id = uw.id
+		JOIN movements m ON uwm.movement_id = m.id
+		WHERE uw.user_id = ? AND uw.workout_date >= ? AND uw.workout_date <= ?
+		GROUP BY m.id, m.name
+		ORDER BY 3 DESC, 6 DESC, m.name`
+
+	rows, err := r.db.Query(rebindQuery(query), userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count movements: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []*domain.MovementFrequency
+	for rows.Next() {
+		m := &domain.MovementFrequency{}
+		if err := rows.Scan(&m.MovementID, &m.MovementName, &m.Sessions, &m.Sets, &m.Reps, &m.Tonnage); err != nil {
+			return nil, fmt.Errorf("failed to scan movement frequency: %w", err)
+		}
+		movements = append(movements, m)
+	}
+
+	return movements, rows.Err()
+}
+
+// GetMovementFamilyFrequency is GetMovementFrequency with variants counted under their parent movement
+func (r *AnalyticsRepository) GetMovementFamilyFrequency(userID int64, start, end time.Time) ([]*domain.MovementFrequency, error) {
+	query := `SELECT f.id, f.name,
+		       COUNT(DISTINCT uwm.user_workout_id),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1)), 0),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1) * COALESCE(uwm.reps, 0)), 0),
+		       COALESCE(SUM(COALESCE(uwm.sets, 1) * COALESCE(uwm.reps, 0) * COALESCE(uwm.weight, 0)), 0)
+		FROM user_workout_movements uwm
+		JOIN user_workouts uw ON uwm.user_workout_id = uw.id
+		JOIN movements m ON uwm.movement_id = m.id
+		JOIN movements f ON f.id = COALESCE(m.parent_id, m.id)
+		WHERE uw.user_id = ? AND uw.workout_date >= ? AND uw.workout_date <= ?
+		GROUP BY f.id, f.name
+		ORDER BY 3 DESC, 6 DESC, f.name`
+
+	rows, err := r.db.Query(rebindQuery(query), userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count movement families: %w", err)
+	}
+	defer rows.Close()
+
+	var families []*domain.MovementFrequency
+	for rows.Next() {
+		m := &domain.MovementFrequency{}
+		if err := rows.Scan(&m.MovementID, &m.MovementName, &m.Sessions, &m.Sets, &m.Reps, &m.Tonnage); err != nil {
+			return nil, fmt.Errorf("failed to scan movement family frequency: %w", err)
+		}
+		families = append(families, m)
+	}
+
+	return families, rows.Err()
+}
+
+// GetWODFrequency returns every WOD logged in the range, most results first
+func (r *AnalyticsRepository) GetWODFrequency(userID int64, start, end time.Time) ([]*domain.WODFrequency, error) {
+	query := `SELECT w.id, w.name, COALESCE(w.type, ''), COALESCE(w.score_type, ''), COUNT(*)
+		FROM user_workout_wods uww
+		JOIN user_workouts uw ON uww.user_workout_id = uw.id
+		JOIN wods w ON uww.wod_id = w.id
+		WHERE uw.user_id = ? AND uw.workout_date >= ? AND uw.workout_date <= ?
+		GROUP BY w.id, w.name, w.type, w.score_type
+		ORDER BY 5 DESC, w.name`
+
+	rows, err := r.db.Query(rebindQuery(query), userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count WODs: %w", err)
+	}
+	defer rows.Close()
+
+	var wods []*domain.WODFrequency
+	for rows.Next() {
+		w := &domain.WODFrequency{}
+		if err := rows.Scan(&w.WODID, &w.WODName, &w.WODType, &w.ScoreType, &w.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan WOD frequency: %w", err)
+		}
+		wods = append(wods, w)
+	}
+
+	return wods, rows.Err()
+}
+
+// GetLiftSets returns every weighted set of a movement logged on or before end, oldest first
+func (r *AnalyticsRepository) GetLiftSets(userID, movementID int64, end time.Time) ([]*domain.LiftSet, error) {
+	query := `SELECT uwm.user_workout_id, uw.workout_date, uwm.weight, uwm.reps
+		FROM user_workout_movements uwm
+		JOIN user_workouts uw ON uwm.user_workout_id = uw.id
+		WHERE uw.user_id = ? AND uwm.movement_id = ? AND uw.workout_date <= ?
+		  AND uwm.weight > 0 AND uwm.reps > 0
+		ORDER BY uw.workout_date, uwm.id`
+
+	rows, err := r.db.Query(rebindQuery(query), userID, movementID, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lift sets: %w", err)
+	}
+	defer rows.Close()
+
+	var sets []*domain.LiftSet
+	for rows.Next() {
+		s := &domain.LiftSet{}
+		if err := rows.Scan(&s.UserWorkoutID, &s.WorkoutDate, &s.Weight, &s.Reps); err != nil {
+			return nil, fmt.Errorf("failed to scan lift set: %w", err)
+		}
+		sets = append(sets, s)
+	}
+
+	return sets, rows.Err()
+}
+
+// GetMaxReps returns the most reps logged for a movement in one set (nil when never logged)
+func (r *AnalyticsRepository) GetMaxReps(userID, movementID int64) (*int, error) {
+	query := `SELECT MAX(uwm.reps)
+		FROM user_workout_movements uwm
+		JOIN user_workouts uw ON uwm.user_workout_id = uw.id
+		WHERE uw.user_id = ? AND uwm.movement_id = ?`
+
+	var maxReps sql.NullInt64
+	if err := r.db.QueryRow(rebindQuery(query), userID, movementID).Scan(&maxReps); err != nil {
+		return nil, fmt.Errorf("failed to get max reps: %w", err)
+	}
+	if !maxReps.Valid {
+		return nil, nil
+	}
+
+	reps := int(maxReps.Int64)
+	return &reps, nil
+}
+
+// GetBestMovementTime returns the fastest time in seconds for a movement at a distance (nil when never logged)
+func (r *AnalyticsRepository) GetBestMovementTime(userID, movementID int64, distance float64) (*int, error) {
+	query := `SELECT MIN(uwm.time)
+		FROM user_workout_movements uwm
+		JOIN user_workouts uw ON uwm.user_workout_id = uw.id
+		WHERE uw.user_id = ? AND uwm.movement_id = ? AND uwm.distance = ? AND uwm.time > 0`
+
+	var best sql.NullInt64
+	if err := r.db.QueryRow(rebindQuery(query), userID, movementID, distance).Scan(&best); err != nil {
+		return nil, fmt.Errorf("failed to get best movement time: %w", err)
+	}
+	if !best.Valid {
+		return nil, nil
+	}
+
+	seconds := int(best.Int64)
+	return &seconds, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// AttachmentRepository implements domain.AttachmentRepository
+type AttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+const attachmentSelect = `
+	SELECT id, entity_type, entity_id, kind, title, url, thumbnail_url, content_type, size_bytes,
+	       file_path, thumbnail_path, created_by, created_at
+	FROM attachments`
+
+// Create creates a new attachment
+func (r *AttachmentRepository) Create(a *domain.Attachment) error {
+	a.CreatedAt = time.Now()
+
+	id, err := insertReturningID(r.db, `INSERT INTO attachments (entity_type, entity_id, kind, title, url, thumbnail_url, content_type,
+		size_bytes, file_path, thumbnail_path, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		a.EntityType, a.EntityID, a.Kind, a.Title, a.URL, a.ThumbnailURL, a.ContentType,
+		a.SizeBytes, a.FilePath, a.ThumbnailPath, a.CreatedBy, a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	a.ID = id
+	return nil
+}
+
+// GetByID retrieves an attachment by ID, or nil if it does not exist
+func (r *AttachmentRepository) GetByID(id int64) (*domain.Attachment, error) {
+	a, err := scanAttachment(r.db.QueryRow(rebindQuery(attachmentSelect+` WHERE id = ?`), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return a, nil
+}
+
+// ListByEntity lists an entity's attachments, oldest first
+func (r *AttachmentRepository) ListByEntity(entityType string, entityID int64) ([]*domain.Attachment, error) {
+	rows, err := r.db.Query(rebindQuery(attachmentSelect+` WHERE entity_type = ? AND entity_id = ? ORDER BY created_at, id`), entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []*domain.Attachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, rows.Err()
+}
+
+// Delete deletes an attachment
+func (r *AttachmentRepository) Delete(id int64) error {
+	if _, err := r.db.Exec(rebindQuery(`DELETE FROM attachments WHERE id = ?`), id); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
+
+// DeleteByEntity deletes every attachment of an entity and returns them
+func (r *AttachmentRepository) DeleteByEntity(entityType string, entityID int64) ([]*domain.Attachment, error) {
+	attachments, err := r.ListByEntity(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.db.Exec(rebindQuery(`DELETE FROM attachments WHERE entity_type = ? AND entity_id = ?`), entityType, entityID); err != nil {
+		return nil, fmt.Errorf("failed to delete attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func scanAttachment(row rowScanner) (*domain.Attachment, error) {
+	a := &domain.Attachment{}
+	var thumbnailURL, contentType, filePath, thumbnailPath sql.NullString
+	var createdBy sql.NullInt64
+	if err := row.Scan(&a.ID, &a.EntityType, &a.EntityID, &a.Kind, &a.Title, &a.URL, &thumbnailURL, &contentType, &a.SizeBytes,
+		&filePath, &thumbnailPath, &createdBy, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	if thumbnailURL.Valid {
+		a.ThumbnailURL = &thumbnailURL.String
+	}
+	if contentType.Valid {
+		a.ContentType = &contentType.String
+	}
+	if filePath.Valid {
+		a.FilePath = &filePath.String
+	}
+	if thumbnailPath.Valid {
+		a.ThumbnailPath = &thumbnailPath.String
+	}
+	if createdBy.Valid {
+		a.CreatedBy = &createdBy.Int64
+	}
+	return a, nil
+}
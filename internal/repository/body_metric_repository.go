@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// BodyMetricRepository implements domain.BodyMetricRepository
+type BodyMetricRepository struct {
+	db *sql.DB
+}
+
+// NewBodyMetricRepository creates a new body metric repository
+func NewBodyMetricRepository(db *sql.DB) *BodyMetricRepository {
+	return &BodyMetricRepository{db: db}
+}
+
+const bodyMetricSelect = `
+	SELECT id, user_id, measured_on, body_weight, body_fat_percent, measurements, notes, created_at, updated_at
+	FROM body_metrics`
+
+// Create creates a new body metric entry
+func (r *BodyMetricRepository) Create(m *domain.BodyMetric) error {
+	measurements, err := encodeMeasurements(m.Measurements)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+
+	id, err := insertReturningID(r.db, `INSERT INTO body_metrics (user_id, measured_on, body_weight, body_fat_percent, measurements, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.UserID, m.MeasuredOn, m.BodyWeight, m.BodyFatPercent, measurements, m.Notes, m.CreatedAt, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create body metric: %w", err)
+	}
+
+	m.ID = id
+	return nil
+}
+
+// GetByID retrieves a body metric entry by ID
+func (r *BodyMetricRepository) GetByID(id int64) (*domain.BodyMetric, error) {
+	m, err := scanBodyMetric(r.db.QueryRow(rebindQuery(bodyMetricSelect+` WHERE id = ?`), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get body metric: %w", err)
+	}
+	return m, nil
+}
+
+// GetByUserAndDate retrieves a user's entry for a date (nil when none)
+func (r *BodyMetricRepository) GetByUserAndDate(userID int64, measuredOn string) (*domain.BodyMetric, error) {
+	m, err := scanBodyMetric(r.db.QueryRow(rebindQuery(bodyMetricSelect+` WHERE user_id = ? AND measured_on = ?`), userID, measuredOn))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get body metric: %w", err)
+	}
+	return m, nil
+}
+
+// ListByUser retrieves a user's entries between two dates inclusive, oldest first (empty bound = open)
+func (r *BodyMetricRepository) ListByUser(userID int64, startDate, endDate string) ([]*domain.BodyMetric, error) {
+	query := bodyMetricSelect + ` WHERE user_id = ?`
+	args := []interface{}{userID}
+	if startDate != "" {
+		query += ` AND measured_on >= ?`
+		args = append(args, startDate)
+	}
+	if endDate != "" {
+		query += ` AND measured_on <= ?`
+		args = append(args, endDate)
+	}
+	query += ` ORDER BY measured_on`
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list body metrics: %w", err)
+	}
+	defer rows.Close()
+
+	var metrics []*domain.BodyMetric
+	for rows.Next() {
+		m, err := scanBodyMetric(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan body metric: %w", err)
+		}
+		metrics = append(metrics, m)
+	}
+
+	return metrics, rows.Err()
+}
+
+// Update updates a body metric entry
+func (r *BodyMetricRepository) Update(m *domain.BodyMetric) error {
+	measurements, err := encodeMeasurements(m.Measurements)
+	if err != nil {
+		return err
+	}
+
+	m.UpdatedAt = time.Now()
+
+	query := rebindQuery(`UPDATE body_metrics
+		SET measured_on = ?, body_weight = ?, body_fat_percent = ?, measurements = ?, notes = ?, updated_at = ?
+		WHERE id = ?`)
+
+	result, err := r.db.Exec(query, m.MeasuredOn, m.BodyWeight, m.BodyFatPercent, measurements, m.Notes, m.UpdatedAt, m.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update body metric: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("body metric not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a body metric entry
+func (r *BodyMetricRepository) Delete(id int64) error {
+	result, err := r.db.Exec(rebindQuery(`DELETE FROM body_metrics WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete body metric: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("body metric not found")
+	}
+
+	return nil
+}
+
+// encodeMeasurements serializes custom measurements for the TEXT column (NULL when empty)
+func encodeMeasurements(measurements map[string]float64) (*string, error) {
+	if len(measurements) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(measurements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode measurements: %w", err)
+	}
+	s := string(data)
+	return &s, nil
+}
+
+func scanBodyMetric(row rowScanner) (*domain.BodyMetric, error) {
+	m := &domain.BodyMetric{}
+	var bodyWeight, bodyFat sql.NullFloat64
+	var measurements, notes sql.NullString
+
+	err := row.Scan(&m.ID, &m.UserID, &m.MeasuredOn, &bodyWeight, &bodyFat, &measurements, &notes, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if bodyWeight.Valid {
+		m.BodyWeight = &bodyWeight.Float64
+	}
+	if bodyFat.Valid {
+		m.BodyFatPercent = &bodyFat.Float64
+	}
+	if notes.Valid {
+		m.Notes = &notes.String
+	}
+	if measurements.Valid && measurements.String != "" {
+		if err := json.Unmarshal([]byte(measurements.String), &m.Measurements); err != nil {
+			return nil, fmt.Errorf("failed to decode measurements: %w", err)
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// CoachAthleteRepository implements domain.CoachAthleteRepository
+type CoachAthleteRepository struct {
+	db *sql.DB
+}
+
+// NewCoachAthleteRepository creates a new coach-athlete relationship repository
+func NewCoachAthleteRepository(db *sql.DB) *CoachAthleteRepository {
+	return &CoachAthleteRepository{db: db}
+}
+
+const coachAthleteSelect = `
+	SELECT ca.id, ca.coach_id, ca.athlete_id, ca.status, ca.responded_at, ca.revoked_at,
+	       ca.created_at, ca.updated_at,
+	       c.name, c.email, a.name, a.email
+	FROM coach_athletes ca
+	JOIN users c ON ca.coach_id = c.id
+	JOIN users a ON ca.athlete_id = a.id`
+
+// Create creates a new coach-athlete relationship (invite)
+func (r *CoachAthleteRepository) Create(rel *domain.CoachAthlete) error {
+	now := time.Now()
+	rel.CreatedAt = now
+	rel.UpdatedAt = now
+	if rel.Status == "" {
+		rel.Status = domain.CoachAthleteStatusPending
+	}
+
+	id, err := insertReturningID(r.db, `INSERT INTO coach_athletes (coach_id, athlete_id, status, responded_at, revoked_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		rel.CoachID, rel.AthleteID, rel.Status, rel.RespondedAt, rel.RevokedAt, rel.CreatedAt, rel.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create coach-athlete relationship: %w", err)
+	}
+
+	rel.ID = id
+	return nil
+}
+
+// GetByID retrieves a relationship by ID
+func (r *CoachAthleteRepository) GetByID(id int64) (*domain.CoachAthlete, error) {
+	query := rebindQuery(coachAthleteSelect + ` WHERE ca.id = ?`)
+
+	rel, err := scanCoachAthlete(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get coach-athlete relationship: %w", err)
+	}
+	return rel, nil
+}
+
+// GetByCoachAndAthlete retrieves the relationship between a coach and an athlete
+func (r *CoachAthleteRepository) GetByCoachAndAthlete(coachID, athleteID int64) (*domain.CoachAthlete, error) {
+	query := rebindQuery(coachAthleteSelect + ` WHERE ca.coach_id = ? AND ca.athlete_id = ?`)
+
+	rel, err := scanCoachAthlete(r.db.QueryRow(query, coachID, athleteID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get coach-athlete relationship: %w", err)
+	}
+	return rel, nil
+}
+
+// ListByCoach retrieves relationships where the user is the coach (empty status = all)
+func (r *CoachAthleteRepository) ListByCoach(coachID int64, status string) ([]*domain.CoachAthlete, error) {
+	query := coachAthleteSelect + ` WHERE ca.coach_id = ?`
+	args := []interface{}{coachID}
+	if status != "" {
+		query += ` AND ca.status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY a.name`
+
+	return r.list(query, args...)
+}
+
+// ListByAthlete retrieves relationships where the user is the athlete (empty status = all)
+func (r *CoachAthleteRepository) ListByAthlete(athleteID int64, status string) ([]*domain.CoachAthlete, error) {
+	query := coachAthleteSelect + ` WHERE ca.athlete_id = ?`
+	args := []interface{}{athleteID}
+	if status != "" {
+		query += ` AND ca.status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY ca.created_at DESC`
+
+	return r.list(query, args...)
+}
+
+// Update updates the status and timestamps of a relationship
+func (r *CoachAthleteRepository) Update(rel *domain.CoachAthlete) error {
+	rel.UpdatedAt = time.Now()
+
+	query := rebindQuery(`UPDATE coach_athletes
+		SET status = ?, responded_at = ?, revoked_at = ?, updated_at = ?
+		WHERE id = ?`)
+
+	result, err := r.db.Exec(query, rel.Status, rel.RespondedAt, rel.RevokedAt, rel.UpdatedAt, rel.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update coach-athlete relationship: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("coach-athlete relationship not found")
+	}
+
+	return nil
+}
+
+func (r *CoachAthleteRepository) list(query string, args ...interface{}) ([]*domain.CoachAthlete, error) {
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coach-athlete relationships: %w", err)
+	}
+	defer rows.Close()
+
+	var rels []*domain.CoachAthlete
+	for rows.Next() {
+		rel, err := scanCoachAthlete(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan coach-athlete relationship: %w", err)
+		}
+		rels = append(rels, rel)
+	}
+
+	return rels, rows.Err()
+}
+
+func scanCoachAthlete(row rowScanner) (*domain.CoachAthlete, error) {
+	rel := &domain.CoachAthlete{}
+	var respondedAt sql.NullTime
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&rel.ID, &rel.CoachID, &rel.AthleteID, &rel.Status, &respondedAt, &revokedAt,
+		&rel.CreatedAt, &rel.UpdatedAt,
+		&rel.CoachName, &rel.CoachEmail, &rel.AthleteName, &rel.AthleteEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if respondedAt.Valid {
+		rel.RespondedAt = &respondedAt.Time
+	}
+	if revokedAt.Valid {
+		rel.RevokedAt = &revokedAt.Time
+	}
+
+	return rel, nil
+}
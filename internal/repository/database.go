@@ -922,6 +922,20 @@ func getTimestampFunc() string {
 	}
 }
 
+// escapeLike escapes LIKE wildcards and the escape character so a value matches literally; use with likeEscapeClause
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// likeEscapeClause declares the backslash as the LIKE escape character
+// MySQL string literals treat a backslash as an escape itself, so it needs doubling there
+func likeEscapeClause() string {
+	if currentDriver == "mysql" {
+		return ` ESCAPE '\\'`
+	}
+	return ` ESCAPE '\'`
+}
+
 // rebindQuery converts ? placeholders to $N for PostgreSQL
 // This allows queries written with ? placeholders to work across all databases
 func rebindQuery(query string) string {
@@ -943,6 +957,50 @@ func rebindQuery(query string) string {
 	return string(result)
 }
 
+// rowScanner is satisfied by both *sql.Row and *sql.Rows so scan helpers can serve single and list queries
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// insertReturningID executes an INSERT written with ? placeholders and returns the new row ID
+// PostgreSQL does not support LastInsertId, so RETURNING id is appended for that driver
+func insertReturningID(db *sql.DB, query string, args ...interface{}) (int64, error) {
+	query = rebindQuery(query)
+
+	if currentDriver == "postgres" {
+		var id int64
+		if err := db.QueryRow(query+" RETURNING id", args...).Scan(&id); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// txInsertReturningID is insertReturningID within a transaction
+func txInsertReturningID(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	query = rebindQuery(query)
+
+	if currentDriver == "postgres" {
+		var id int64
+		if err := tx.QueryRow(query+" RETURNING id", args...).Scan(&id); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
 // seedStandardMovements seeds the database with standard CrossFit movements
 func seedStandardMovements(db *sql.DB) error {
 	// Determine target table before querying (migrations may rename it)
@@ -1193,7 +1251,7 @@ func seedStandardWODs(db *sql.DB) error {
 		}
 	}
 
-	return nil
+	return createMissingWODVersions(db)
 }
 
 // seedWorkoutTemplates seeds the database with sample workout templates
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// EnumerationRepository implements domain.EnumerationRepository
+type EnumerationRepository struct {
+	db *sql.DB
+}
+
+// NewEnumerationRepository creates a new enumeration repository
+func NewEnumerationRepository(db *sql.DB) *EnumerationRepository {
+	return &EnumerationRepository{db: db}
+}
+
+const enumerationColumns = `id, category, value, description, display_order, is_deprecated, created_at, updated_at`
+
+// enumerationUsage maps each category to the column holding its values
+var enumerationUsage = map[string]struct {
+	table  string
+	column string
+}{
+	domain.EnumerationWODSource:    {"wods", "source"},
+	domain.EnumerationWODType:      {"wods", "type"},
+	domain.EnumerationWODRegime:    {"wods", "regime"},
+	domain.EnumerationMovementType: {"movements", "type"},
+}
+
+// Create creates a new enumeration value
+func (r *EnumerationRepository) Create(enumeration *domain.Enumeration) error {
+	now := time.Now()
+	enumeration.CreatedAt = now
+	enumeration.UpdatedAt = now
+
+	id, err := insertReturningID(r.db, `INSERT INTO enumerations (category, value, description, display_order, is_deprecated, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		enumeration.Category, enumeration.Value, enumeration.Description, enumeration.DisplayOrder, enumeration.IsDeprecated,
+		enumeration.CreatedAt, enumeration.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create enumeration: %w", err)
+	}
+
+	enumeration.ID = id
+	return nil
+}
+
+// GetByID retrieves an enumeration value by ID
+func (r *EnumerationRepository) GetByID(id int64) (*domain.Enumeration, error) {
+	query := rebindQuery(`SELECT ` + enumerationColumns + ` FROM enumerations WHERE id = ?`)
+
+	enumeration, err := scanEnumeration(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get enumeration: %w", err)
+	}
+	return enumeration, nil
+}
+
+// List lists a category's values (every category when empty) by category, display order and value
+func (r *EnumerationRepository) List(category string, includeDeprecated bool) ([]*domain.Enumeration, error) {
+	query := `SELECT ` + enumerationColumns + ` FROM enumerations WHERE 1 = 1`
+	var args []interface{}
+	if category != "" {
+		query += ` AND category = ?`
+		args = append(args, category)
+	}
+	if !includeDeprecated {
+		query += ` AND is_deprecated = ?`
+		args = append(args, false)
+	}
+	query += ` ORDER BY category, display_order, value`
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enumerations: %w", err)
+	}
+	defer rows.Close()
+
+	enumerations := []*domain.Enumeration{}
+	for rows.Next() {
+		enumeration, err := scanEnumeration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan enumeration: %w", err)
+		}
+		enumerations = append(enumerations, enumeration)
+	}
+	return enumerations, rows.Err()
+}
+
+// Update saves an enumeration; when the value changed, the WODs or movements using the old value
+// are renamed in the same transaction
+func (r *EnumerationRepository) Update(enumeration *domain.Enumeration, previousValue string) error {
+	usage, ok := enumerationUsage[enumeration.Category]
+	if !ok {
+		return fmt.Errorf("unsupported enumeration category: %s", enumeration.Category)
+	}
+	enumeration.UpdatedAt = time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(rebindQuery(`UPDATE enumerations SET value = ?, description = ?, display_order = ?, is_deprecated = ?, updated_at = ?
+		WHERE id = ?`),
+		enumeration.Value, enumeration.Description, enumeration.DisplayOrder, enumeration.IsDeprecated, enumeration.UpdatedAt,
+		enumeration.ID); err != nil {
+		return fmt.Errorf("failed to update enumeration: %w", err)
+	}
+
+	if previousValue != enumeration.Value {
+		if _, err := tx.Exec(rebindQuery(`UPDATE `+usage.table+` SET `+usage.column+` = ? WHERE `+usage.column+` = ?`),
+			enumeration.Value, previousValue); err != nil {
+			return fmt.Errorf("failed to rename %s values: %w", usage.table, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete deletes an enumeration value
+func (r *EnumerationRepository) Delete(id int64) error {
+	if _, err := r.db.Exec(rebindQuery(`DELETE FROM enumerations WHERE id = ?`), id); err != nil {
+		return fmt.Errorf("failed to delete enumeration: %w", err)
+	}
+	return nil
+}
+
+// CountUsage counts the WODs or movements using a value of a category
+func (r *EnumerationRepository) CountUsage(category, value string) (int, error) {
+	usage, ok := enumerationUsage[category]
+	if !ok {
+		return 0, fmt.Errorf("unsupported enumeration category: %s", category)
+	}
+
+	var count int
+	if err := r.db.QueryRow(rebindQuery(`SELECT COUNT(*) FROM `+usage.table+` WHERE `+usage.column+` = ?`), value).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count %s using %s: %w", usage.table, value, err)
+	}
+	return count, nil
+}
+
+func scanEnumeration(row rowScanner) (*domain.Enumeration, error) {
+	enumeration := &domain.Enumeration{}
+	var description sql.NullString
+	if err := row.Scan(&enumeration.ID, &enumeration.Category, &enumeration.Value, &description, &enumeration.DisplayOrder,
+		&enumeration.IsDeprecated, &enumeration.CreatedAt, &enumeration.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if description.Valid {
+		enumeration.Description = &description.String
+	}
+	return enumeration, nil
+}
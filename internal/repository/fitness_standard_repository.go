@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// FitnessStandardRepository implements domain.FitnessStandardRepository
+type FitnessStandardRepository struct {
+	db *sql.DB
+}
+
+// NewFitnessStandardRepository creates a new fitness standard repository
+func NewFitnessStandardRepository(db *sql.DB) *FitnessStandardRepository {
+	return &FitnessStandardRepository{db: db}
+}
+
+// List returns all standards ordered by domain and name
+func (r *FitnessStandardRepository) List() ([]*domain.FitnessStandard, error) {
+	query := `SELECT id, domain, name, kind, metric, distance, gender,
+		       beginner, intermediate, advanced, elite, created_at, updated_at
+		FROM fitness_standards
+		ORDER BY domain, name, gender`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fitness standards: %w", err)
+	}
+	defer rows.Close()
+
+	var standards []*domain.FitnessStandard
+	for rows.Next() {
+		s := &domain.FitnessStandard{}
+		var distance sql.NullFloat64
+		if err := rows.Scan(&s.ID, &s.Domain, &s.Name, &s.Kind, &s.Metric, &distance, &s.Gender,
+			&s.Beginner, &s.Intermediate, &s.Advanced, &s.Elite, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan fitness standard: %w", err)
+		}
+		if distance.Valid {
+			s.Distance = &distance.Float64
+		}
+		standards = append(standards, s)
+	}
+
+	return standards, rows.Err()
+}
+
+// Count returns the number of stored standards
+func (r *FitnessStandardRepository) Count() (int, error) {
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM fitness_standards`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count fitness standards: %w", err)
+	}
+	return count, nil
+}
+
+// ReplaceAll swaps the whole set of standards in one transaction
+func (r *FitnessStandardRepository) ReplaceAll(standards []*domain.FitnessStandard) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM fitness_standards`); err != nil {
+		return fmt.Errorf("failed to clear fitness standards: %w", err)
+	}
+
+	now := time.Now()
+	query := rebindQuery(`INSERT INTO fitness_standards (domain, name, kind, metric, distance, gender,
+		beginner, intermediate, advanced, elite, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	for _, s := range standards {
+		s.CreatedAt = now
+		s.UpdatedAt = now
+		if _, err := tx.Exec(query, s.Domain, s.Name, s.Kind, s.Metric, s.Distance, s.Gender,
+			s.Beginner, s.Intermediate, s.Advanced, s.Elite, s.CreatedAt, s.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to insert fitness standard %s: %w", s.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
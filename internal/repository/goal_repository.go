@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// GoalRepository implements domain.GoalRepository
+type GoalRepository struct {
+	db *sql.DB
+}
+
+// NewGoalRepository creates a new goal repository
+func NewGoalRepository(db *sql.DB) *GoalRepository {
+	return &GoalRepository{db: db}
+}
+
+const goalSelect = `
+	SELECT id, user_id, title, goal_type, movement_id, wod_id, metric, target_value, reps_per_round,
+		start_date, target_date, baseline_value, status, achieved_at, achieved_workout_id, notes, created_at, updated_at
+	FROM goals`
+
+// Create creates a new goal
+func (r *GoalRepository) Create(g *domain.Goal) error {
+	now := time.Now()
+	g.CreatedAt = now
+	g.UpdatedAt = now
+
+	id, err := insertReturningID(r.db, `INSERT INTO goals (user_id, title, goal_type, movement_id, wod_id, metric, target_value, reps_per_round,
+			start_date, target_date, baseline_value, status, achieved_at, achieved_workout_id, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		g.UserID, g.Title, g.GoalType, g.MovementID, g.WODID, g.Metric, g.TargetValue, g.RepsPerRound,
+		g.StartDate, g.TargetDate, g.BaselineValue, g.Status, g.AchievedAt, g.AchievedWorkoutID, g.Notes, g.CreatedAt, g.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	g.ID = id
+	return nil
+}
+
+// GetByID retrieves a goal by ID
+func (r *GoalRepository) GetByID(id int64) (*domain.Goal, error) {
+	g, err := scanGoal(r.db.QueryRow(rebindQuery(goalSelect+` WHERE id = ?`), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+	return g, nil
+}
+
+// ListByUser retrieves a user's goals, newest first (empty status = all)
+func (r *GoalRepository) ListByUser(userID int64, status string) ([]*domain.Goal, error) {
+	query := goalSelect + ` WHERE user_id = ?`
+	args := []interface{}{userID}
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goals: %w", err)
+	}
+	defer rows.Close()
+
+	var goals []*domain.Goal
+	for rows.Next() {
+		g, err := scanGoal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+		goals = append(goals, g)
+	}
+
+	return goals, rows.Err()
+}
+
+// Update updates a goal
+func (r *GoalRepository) Update(g *domain.Goal) error {
+	g.UpdatedAt = time.Now()
+
+	query := rebindQuery(`UPDATE goals
+		SET title = ?, target_value = ?, reps_per_round = ?, target_date = ?, baseline_value = ?, status = ?,
+			achieved_at = ?, achieved_workout_id = ?, notes = ?, updated_at = ?
+		WHERE id = ?`)
+
+	result, err := r.db.Exec(query, g.Title, g.TargetValue, g.RepsPerRound, g.TargetDate, g.BaselineValue, g.Status,
+		g.AchievedAt, g.AchievedWorkoutID, g.Notes, g.UpdatedAt, g.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("goal not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a goal
+func (r *GoalRepository) Delete(id int64) error {
+	result, err := r.db.Exec(rebindQuery(`DELETE FROM goals WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("goal not found")
+	}
+
+	return nil
+}
+
+func scanGoal(row rowScanner) (*domain.Goal, error) {
+	g := &domain.Goal{}
+	var movementID, wodID, repsPerRound, achievedWorkoutID sql.NullInt64
+	var targetDate, notes sql.NullString
+	var baseline sql.NullFloat64
+	var achievedAt sql.NullTime
+
+	err := row.Scan(&g.ID, &g.UserID, &g.Title, &g.GoalType, &movementID, &wodID, &g.Metric, &g.TargetValue, &repsPerRound,
+		&g.StartDate, &targetDate, &baseline, &g.Status, &achievedAt, &achievedWorkoutID, &notes, &g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if movementID.Valid {
+		g.MovementID = &movementID.Int64
+	}
+	if wodID.Valid {
+		g.WODID = &wodID.Int64
+	}
+	if repsPerRound.Valid {
+		v := int(repsPerRound.Int64)
+		g.RepsPerRound = &v
+	}
+	if targetDate.Valid {
+		g.TargetDate = &targetDate.String
+	}
+	if baseline.Valid {
+		g.BaselineValue = &baseline.Float64
+	}
+	if achievedAt.Valid {
+		g.AchievedAt = &achievedAt.Time
+	}
+	if achievedWorkoutID.Valid {
+		g.AchievedWorkoutID = &achievedWorkoutID.Int64
+	}
+	if notes.Valid {
+		g.Notes = &notes.String
+	}
+
+	return g, nil
+}
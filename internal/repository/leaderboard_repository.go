@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// LeaderboardRepository implements domain.LeaderboardRepository
+type LeaderboardRepository struct {
+	db *sql.DB
+}
+
+// NewLeaderboardRepository creates a new leaderboard repository
+func NewLeaderboardRepository(db *sql.DB) *LeaderboardRepository {
+	return &LeaderboardRepository{db: db}
+}
+
+// GetWODLeaderboard ranks opted-in users by their best score on a WOD
+// Each user's best score is picked with ROW_NUMBER, then users are ranked with RANK so ties share a place
+func (r *LeaderboardRepository) GetWODLeaderboard(filter domain.LeaderboardFilter) ([]*domain.LeaderboardEntry, int, error) {
+	// WODs without a registered score type rank by time, as they always have
+	def := domain.LookupScoreType(filter.ScoreType)
+	if def == nil {
+		def = domain.LookupScoreType(domain.ScoreTypeTime)
+	}
+	order, scored := scoreRankOrder(def)
+
+	in, wodArgs := idList(filter.WODIDs)
+	conditions := []string{
+		"uww.wod_id IN " + in,
+		currentWODVersionCondition,
+		"us.leaderboard_opt_in = ?",
+		"u.account_disabled = ?",
+		scored,
+	}
+	args := append(wodArgs, true, false)
+
+	if filter.StartDate != nil {
+		conditions = append(conditions, "uw.workout_date >= ?")
+		args = append(args, *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		conditions = append(conditions, "uw.workout_date <= ?")
+		args = append(args, *filter.EndDate)
+	}
+	if filter.Gender != "" {
+		conditions = append(conditions, "u.gender = ?")
+		args = append(args, filter.Gender)
+	}
+	if filter.BirthdayFrom != nil {
+		conditions = append(conditions, "u.birthday >= ?")
+		args = append(args, *filter.BirthdayFrom)
+	}
+	if filter.BirthdayTo != nil {
+		conditions = append(conditions, "u.birthday <= ?")
+		args = append(args, *filter.BirthdayTo)
+	}
+	if filter.Division != "" {
+		conditions = append(conditions, "uww.division = ?")
+		args = append(args, filter.Division)
+	}
+
+	bestScores := fmt.Sprintf(`
+		WITH scores AS (
+			SELECT uww.id AS score_id, uw.user_id, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
+			       uww.calories, uww.distance, uww.points, uww.tie_break_seconds, uww.time_capped, uw.workout_date
+			FROM user_workout_wods uww
+			JOIN user_workouts uw ON uww.user_workout_id = uw.id
+			JOIN users u ON uw.user_id = u.id
+			JOIN user_settings us ON us.user_id = u.id
+			WHERE %s
+		),
+		best AS (
+			SELECT score_id, time_seconds, rounds, reps, weight, calories, distance, points, tie_break_seconds, time_capped,
+			       ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY %s, workout_date, score_id) AS user_rank
+			FROM scores
+		),
+		ranked AS (
+			SELECT score_id, RANK() OVER (ORDER BY %s) AS place
+			FROM best
+			WHERE user_rank = 1
+		)`, strings.Join(conditions, " AND "), order, order)
+
+	var total int
+	if err := r.db.QueryRow(rebindQuery(bestScores+` SELECT COUNT(*) FROM ranked`), args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count leaderboard entries: %w", err)
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	// Join back to the base tables so column types (e.g., workout_date) are preserved for scanning
+	query := bestScores + `
+		SELECT ranked.place, u.id, uww.wod_id, u.name, u.gender, uww.division, uww.score_value,
+		       uww.time_seconds, uww.rounds, uww.reps, uww.weight, ` + wodScoreDetailColumns + `, uw.workout_date, uw.id
+		FROM ranked
+		JOIN user_workout_wods uww ON uww.id = ranked.score_id
+		JOIN user_workouts uw ON uww.user_workout_id = uw.id
+		JOIN users u ON uw.user_id = u.id
+		ORDER BY ranked.place, uw.workout_date, uww.id
+		LIMIT ? OFFSET ?`
+	pageArgs := append(append([]interface{}{}, args...), filter.Limit, filter.Offset)
+
+	rows, err := r.db.Query(rebindQuery(query), pageArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.LeaderboardEntry
+	for rows.Next() {
+		e := &domain.LeaderboardEntry{}
+		details := &e.WODScoreDetails
+		var gender, division, scoreValue sql.NullString
+		var timeSeconds, rounds, reps sql.NullInt64
+		var weight sql.NullFloat64
+
+		if err := rows.Scan(&e.Rank, &e.UserID, &e.WODID, &e.Name, &gender, &division, &scoreValue,
+			&timeSeconds, &rounds, &reps, &weight, &details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped,
+			&e.WorkoutDate, &e.UserWorkoutID); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+
+		if gender.Valid {
+			e.Gender = &gender.String
+		}
+		if division.Valid {
+			e.Division = &division.String
+		}
+		if scoreValue.Valid {
+			e.ScoreValue = &scoreValue.String
+		}
+		if timeSeconds.Valid {
+			t := int(timeSeconds.Int64)
+			e.TimeSeconds = &t
+		}
+		if rounds.Valid {
+			v := int(rounds.Int64)
+			e.Rounds = &v
+		}
+		if reps.Valid {
+			v := int(reps.Int64)
+			e.Reps = &v
+		}
+		if weight.Valid {
+			e.Weight = &weight.Float64
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, total, rows.Err()
+}
@@ -446,9 +446,1562 @@ var migrations = []Migration{
 			return nil
 		},
 	},
+	{
+		Version:     "0.13.0",
+		Description: "Add coach_athletes table for coach read access to athlete workout logs",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "coach_athletes", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS coach_athletes (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					coach_id INTEGER NOT NULL,
+					athlete_id INTEGER NOT NULL,
+					status TEXT NOT NULL DEFAULT 'pending',
+					responded_at DATETIME,
+					revoked_at DATETIME,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					UNIQUE (coach_id, athlete_id),
+					FOREIGN KEY (coach_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (athlete_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_coach_athletes_coach_id ON coach_athletes(coach_id, status);
+				CREATE INDEX IF NOT EXISTS idx_coach_athletes_athlete_id ON coach_athletes(athlete_id, status);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS coach_athletes (
+					id BIGSERIAL PRIMARY KEY,
+					coach_id BIGINT NOT NULL,
+					athlete_id BIGINT NOT NULL,
+					status VARCHAR(20) NOT NULL DEFAULT 'pending',
+					responded_at TIMESTAMP,
+					revoked_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (coach_id, athlete_id),
+					FOREIGN KEY (coach_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (athlete_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_coach_athletes_coach_id ON coach_athletes(coach_id, status);
+				CREATE INDEX IF NOT EXISTS idx_coach_athletes_athlete_id ON coach_athletes(athlete_id, status);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS coach_athletes (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					coach_id BIGINT NOT NULL,
+					athlete_id BIGINT NOT NULL,
+					status VARCHAR(20) NOT NULL DEFAULT 'pending',
+					responded_at DATETIME,
+					revoked_at DATETIME,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_coach_athletes_pair (coach_id, athlete_id),
+					INDEX idx_coach_athletes_coach_id (coach_id, status),
+					INDEX idx_coach_athletes_athlete_id (athlete_id, status),
+					FOREIGN KEY (coach_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (athlete_id) REFERENCES users(id) ON DELETE CASCADE
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS coach_athletes")
+			return err
+		},
+	},
+	{
+		Version:     "0.13.1",
+		Description: "Add organizations, organization_members and organization_library tables for gym multi-tenancy",
+		Up: func(db *sql.DB, driver string) error {
+			if err := createTableIfNotExists(db, driver, "organizations", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS organizations (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					name TEXT NOT NULL,
+					slug TEXT UNIQUE NOT NULL,
+					description TEXT,
+					created_by INTEGER,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS organizations (
+					id BIGSERIAL PRIMARY KEY,
+					name VARCHAR(255) NOT NULL,
+					slug VARCHAR(100) UNIQUE NOT NULL,
+					description TEXT,
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS organizations (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					name VARCHAR(255) NOT NULL,
+					slug VARCHAR(100) NOT NULL,
+					description TEXT,
+					created_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_organizations_slug (slug),
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			if err := createTableIfNotExists(db, driver, "organization_members", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS organization_members (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					organization_id INTEGER NOT NULL,
+					user_id INTEGER NOT NULL,
+					role TEXT NOT NULL DEFAULT 'member',
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					UNIQUE (organization_id, user_id),
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS organization_members (
+					id BIGSERIAL PRIMARY KEY,
+					organization_id BIGINT NOT NULL,
+					user_id BIGINT NOT NULL,
+					role VARCHAR(20) NOT NULL DEFAULT 'member',
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (organization_id, user_id),
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS organization_members (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					organization_id BIGINT NOT NULL,
+					user_id BIGINT NOT NULL,
+					role VARCHAR(20) NOT NULL DEFAULT 'member',
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_organization_members_pair (organization_id, user_id),
+					INDEX idx_organization_members_user_id (user_id),
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			// Library links reference either wods or movements (entity_type), so no FK on entity_id
+			return createTableIfNotExists(db, driver, "organization_library", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS organization_library (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					organization_id INTEGER NOT NULL,
+					entity_type TEXT NOT NULL,
+					entity_id INTEGER NOT NULL,
+					added_by INTEGER,
+					created_at DATETIME NOT NULL,
+					UNIQUE (organization_id, entity_type, entity_id),
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (added_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_organization_library_entity ON organization_library(entity_type, entity_id);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS organization_library (
+					id BIGSERIAL PRIMARY KEY,
+					organization_id BIGINT NOT NULL,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					added_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (organization_id, entity_type, entity_id),
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (added_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_organization_library_entity ON organization_library(entity_type, entity_id);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS organization_library (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					organization_id BIGINT NOT NULL,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					added_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_organization_library_item (organization_id, entity_type, entity_id),
+					INDEX idx_organization_library_entity (entity_type, entity_id),
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (added_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			for _, table := range []string{"organization_library", "organization_members", "organizations"} {
+				if _, err := db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     "0.13.2",
+		Description: "Add scheduled_workouts table for gym programming calendars",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "scheduled_workouts", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS scheduled_workouts (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					organization_id INTEGER NOT NULL,
+					scheduled_date TEXT NOT NULL,
+					track TEXT NOT NULL DEFAULT '',
+					workout_id INTEGER,
+					wod_id INTEGER,
+					title TEXT,
+					notes TEXT,
+					created_by INTEGER,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE SET NULL,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE SET NULL,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_scheduled_workouts_org_date ON scheduled_workouts(organization_id, scheduled_date, track);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS scheduled_workouts (
+					id BIGSERIAL PRIMARY KEY,
+					organization_id BIGINT NOT NULL,
+					scheduled_date VARCHAR(10) NOT NULL,
+					track VARCHAR(100) NOT NULL DEFAULT '',
+					workout_id BIGINT,
+					wod_id BIGINT,
+					title VARCHAR(255),
+					notes TEXT,
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE SET NULL,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE SET NULL,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_scheduled_workouts_org_date ON scheduled_workouts(organization_id, scheduled_date, track);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS scheduled_workouts (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					organization_id BIGINT NOT NULL,
+					scheduled_date VARCHAR(10) NOT NULL,
+					track VARCHAR(100) NOT NULL DEFAULT '',
+					workout_id BIGINT,
+					wod_id BIGINT,
+					title VARCHAR(255),
+					notes TEXT,
+					created_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					INDEX idx_scheduled_workouts_org_date (organization_id, scheduled_date, track),
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE SET NULL,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE SET NULL,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS scheduled_workouts")
+			return err
+		},
+	},
+	{
+		Version:     "0.13.3",
+		Description: "Add training program tables (programs, sessions, progressions, enrollments, completions)",
+		Up: func(db *sql.DB, driver string) error {
+			if err := createTableIfNotExists(db, driver, "programs", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS programs (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					name TEXT NOT NULL,
+					description TEXT,
+					weeks INTEGER NOT NULL,
+					created_by INTEGER,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS programs (
+					id BIGSERIAL PRIMARY KEY,
+					name VARCHAR(255) NOT NULL,
+					description TEXT,
+					weeks INTEGER NOT NULL,
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS programs (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					name VARCHAR(255) NOT NULL,
+					description TEXT,
+					weeks INT NOT NULL,
+					created_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			if err := createTableIfNotExists(db, driver, "program_sessions", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS program_sessions (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					program_id INTEGER NOT NULL,
+					week INTEGER NOT NULL,
+					day INTEGER NOT NULL,
+					workout_id INTEGER NOT NULL,
+					notes TEXT,
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_program_sessions_program ON program_sessions(program_id, week, day);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS program_sessions (
+					id BIGSERIAL PRIMARY KEY,
+					program_id BIGINT NOT NULL,
+					week INTEGER NOT NULL,
+					day INTEGER NOT NULL,
+					workout_id BIGINT NOT NULL,
+					notes TEXT,
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_program_sessions_program ON program_sessions(program_id, week, day);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS program_sessions (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					program_id BIGINT NOT NULL,
+					week INT NOT NULL,
+					day INT NOT NULL,
+					workout_id BIGINT NOT NULL,
+					notes TEXT,
+					INDEX idx_program_sessions_program (program_id, week, day),
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			if err := createTableIfNotExists(db, driver, "program_progressions", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS program_progressions (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					program_id INTEGER NOT NULL,
+					movement_id INTEGER NOT NULL,
+					rule_type TEXT NOT NULL,
+					base_weight REAL,
+					increment REAL,
+					percentages TEXT,
+					round_to REAL NOT NULL DEFAULT 5,
+					UNIQUE (program_id, movement_id),
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE CASCADE
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS program_progressions (
+					id BIGSERIAL PRIMARY KEY,
+					program_id BIGINT NOT NULL,
+					movement_id BIGINT NOT NULL,
+					rule_type VARCHAR(20) NOT NULL,
+					base_weight DECIMAL(10,2),
+					increment DECIMAL(10,2),
+					percentages TEXT,
+					round_to DECIMAL(10,2) NOT NULL DEFAULT 5,
+					UNIQUE (program_id, movement_id),
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE CASCADE
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS program_progressions (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					program_id BIGINT NOT NULL,
+					movement_id BIGINT NOT NULL,
+					rule_type VARCHAR(20) NOT NULL,
+					base_weight DECIMAL(10,2),
+					increment DECIMAL(10,2),
+					percentages TEXT,
+					round_to DECIMAL(10,2) NOT NULL DEFAULT 5,
+					UNIQUE KEY uq_program_progressions_movement (program_id, movement_id),
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE CASCADE
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			if err := createTableIfNotExists(db, driver, "program_enrollments", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS program_enrollments (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					program_id INTEGER NOT NULL,
+					user_id INTEGER NOT NULL,
+					start_date TEXT NOT NULL,
+					status TEXT NOT NULL DEFAULT 'active',
+					completed_at DATETIME,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_program_enrollments_user ON program_enrollments(user_id, status);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS program_enrollments (
+					id BIGSERIAL PRIMARY KEY,
+					program_id BIGINT NOT NULL,
+					user_id BIGINT NOT NULL,
+					start_date VARCHAR(10) NOT NULL,
+					status VARCHAR(20) NOT NULL DEFAULT 'active',
+					completed_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+				CREATE INDEX IF NOT EXISTS idx_program_enrollments_user ON program_enrollments(user_id, status);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS program_enrollments (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					program_id BIGINT NOT NULL,
+					user_id BIGINT NOT NULL,
+					start_date VARCHAR(10) NOT NULL,
+					status VARCHAR(20) NOT NULL DEFAULT 'active',
+					completed_at DATETIME,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					INDEX idx_program_enrollments_user (user_id, status),
+					FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			if err := createTableIfNotExists(db, driver, "program_session_completions", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS program_session_completions (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					enrollment_id INTEGER NOT NULL,
+					session_id INTEGER NOT NULL,
+					user_workout_id INTEGER,
+					completed_at DATETIME NOT NULL,
+					UNIQUE (enrollment_id, session_id),
+					FOREIGN KEY (enrollment_id) REFERENCES program_enrollments(id) ON DELETE CASCADE,
+					FOREIGN KEY (session_id) REFERENCES program_sessions(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS program_session_completions (
+					id BIGSERIAL PRIMARY KEY,
+					enrollment_id BIGINT NOT NULL,
+					session_id BIGINT NOT NULL,
+					user_workout_id BIGINT,
+					completed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (enrollment_id, session_id),
+					FOREIGN KEY (enrollment_id) REFERENCES program_enrollments(id) ON DELETE CASCADE,
+					FOREIGN KEY (session_id) REFERENCES program_sessions(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS program_session_completions (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					enrollment_id BIGINT NOT NULL,
+					session_id BIGINT NOT NULL,
+					user_workout_id BIGINT,
+					completed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_program_session_completions (enrollment_id, session_id),
+					FOREIGN KEY (enrollment_id) REFERENCES program_enrollments(id) ON DELETE CASCADE,
+					FOREIGN KEY (session_id) REFERENCES program_sessions(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Down: func(db *sql.DB, driver string) error {
+			for _, table := range []string{"program_session_completions", "program_enrollments", "program_progressions", "program_sessions", "programs"} {
+				if _, err := db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     "0.13.4",
+		Description: "Add leaderboard columns (users.gender, user_settings.leaderboard_opt_in, user_workout_wods.division)",
+		Up: func(db *sql.DB, driver string) error {
+			if err := addColumnIfNotExists(db, driver, "users", "gender", map[string]string{
+				"sqlite3":  "TEXT",
+				"postgres": "VARCHAR(20)",
+				"mysql":    "VARCHAR(20)",
+			}); err != nil {
+				return err
+			}
+
+			if err := addColumnIfNotExists(db, driver, "user_settings", "leaderboard_opt_in", map[string]string{
+				"sqlite3":  "INTEGER NOT NULL DEFAULT 0",
+				"postgres": "BOOLEAN NOT NULL DEFAULT FALSE",
+				"mysql":    "BOOLEAN NOT NULL DEFAULT FALSE",
+			}); err != nil {
+				return err
+			}
+
+			return addColumnIfNotExists(db, driver, "user_workout_wods", "division", map[string]string{
+				"sqlite3":  "TEXT",
+				"postgres": "VARCHAR(20)",
+				"mysql":    "VARCHAR(20)",
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			for table, column := range map[string]string{"users": "gender", "user_settings": "leaderboard_opt_in", "user_workout_wods": "division"} {
+				if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, column)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     "0.13.5",
+		Description: "Add fitness_standards table for athlete fitness profiles",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "fitness_standards", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS fitness_standards (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					domain TEXT NOT NULL,
+					name TEXT NOT NULL,
+					kind TEXT NOT NULL,
+					metric TEXT NOT NULL,
+					distance REAL,
+					gender TEXT NOT NULL DEFAULT '',
+					beginner REAL NOT NULL,
+					intermediate REAL NOT NULL,
+					advanced REAL NOT NULL,
+					elite REAL NOT NULL,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS fitness_standards (
+					id BIGSERIAL PRIMARY KEY,
+					domain VARCHAR(50) NOT NULL,
+					name VARCHAR(255) NOT NULL,
+					kind VARCHAR(20) NOT NULL,
+					metric VARCHAR(20) NOT NULL,
+					distance DOUBLE PRECISION,
+					gender VARCHAR(20) NOT NULL DEFAULT '',
+					beginner DOUBLE PRECISION NOT NULL,
+					intermediate DOUBLE PRECISION NOT NULL,
+					advanced DOUBLE PRECISION NOT NULL,
+					elite DOUBLE PRECISION NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS fitness_standards (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					domain VARCHAR(50) NOT NULL,
+					name VARCHAR(255) NOT NULL,
+					kind VARCHAR(20) NOT NULL,
+					metric VARCHAR(20) NOT NULL,
+					distance DOUBLE,
+					gender VARCHAR(20) NOT NULL DEFAULT '',
+					beginner DOUBLE NOT NULL,
+					intermediate DOUBLE NOT NULL,
+					advanced DOUBLE NOT NULL,
+					elite DOUBLE NOT NULL,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS fitness_standards")
+			return err
+		},
+	},
+	{
+		Version:     "0.13.6",
+		Description: "Add body_metrics table for dated body weight, body fat and custom measurements",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "body_metrics", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS body_metrics (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					user_id INTEGER NOT NULL,
+					measured_on TEXT NOT NULL,
+					body_weight REAL,
+					body_fat_percent REAL,
+					measurements TEXT,
+					notes TEXT,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					UNIQUE (user_id, measured_on),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS body_metrics (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					measured_on VARCHAR(10) NOT NULL,
+					body_weight DOUBLE PRECISION,
+					body_fat_percent DOUBLE PRECISION,
+					measurements TEXT,
+					notes TEXT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (user_id, measured_on),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS body_metrics (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					measured_on VARCHAR(10) NOT NULL,
+					body_weight DOUBLE,
+					body_fat_percent DOUBLE,
+					measurements TEXT,
+					notes TEXT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_body_metrics_user_date (user_id, measured_on),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS body_metrics")
+			return err
+		},
+	},
+	{
+		Version:     "0.13.7",
+		Description: "Add session wellness columns to user_workouts (session RPE, sleep, soreness, readiness)",
+		Up: func(db *sql.DB, driver string) error {
+			for _, column := range []struct {
+				name       string
+				definition map[string]string
+			}{
+				{"session_rpe", map[string]string{"sqlite3": "INTEGER", "postgres": "INTEGER", "mysql": "INT"}},
+				{"sleep_hours", map[string]string{"sqlite3": "REAL", "postgres": "DOUBLE PRECISION", "mysql": "DOUBLE"}},
+				{"sleep_quality", map[string]string{"sqlite3": "INTEGER", "postgres": "INTEGER", "mysql": "INT"}},
+				{"soreness", map[string]string{"sqlite3": "INTEGER", "postgres": "INTEGER", "mysql": "INT"}},
+				{"readiness", map[string]string{"sqlite3": "INTEGER", "postgres": "INTEGER", "mysql": "INT"}},
+			} {
+				if err := addColumnIfNotExists(db, driver, "user_workouts", column.name, column.definition); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(db *sql.DB, driver string) error {
+			for _, column := range []string{"session_rpe", "sleep_hours", "sleep_quality", "soreness", "readiness"} {
+				if _, err := db.Exec(fmt.Sprintf("ALTER TABLE user_workouts DROP COLUMN %s", column)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     "0.13.8",
+		Description: "Add goals table for personal goals with progress tracking",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "goals", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS goals (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					user_id INTEGER NOT NULL,
+					title TEXT NOT NULL,
+					goal_type TEXT NOT NULL,
+					movement_id INTEGER,
+					wod_id INTEGER,
+					metric TEXT NOT NULL,
+					target_value REAL NOT NULL,
+					reps_per_round INTEGER,
+					start_date TEXT NOT NULL,
+					target_date TEXT,
+					baseline_value REAL,
+					status TEXT NOT NULL DEFAULT 'active',
+					achieved_at DATETIME,
+					achieved_workout_id INTEGER,
+					notes TEXT,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE CASCADE,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (achieved_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_goals_user_status ON goals(user_id, status);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS goals (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					title VARCHAR(255) NOT NULL,
+					goal_type VARCHAR(20) NOT NULL,
+					movement_id BIGINT,
+					wod_id BIGINT,
+					metric VARCHAR(20) NOT NULL,
+					target_value DOUBLE PRECISION NOT NULL,
+					reps_per_round INTEGER,
+					start_date VARCHAR(10) NOT NULL,
+					target_date VARCHAR(10),
+					baseline_value DOUBLE PRECISION,
+					status VARCHAR(20) NOT NULL DEFAULT 'active',
+					achieved_at TIMESTAMP,
+					achieved_workout_id BIGINT,
+					notes TEXT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE CASCADE,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (achieved_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_goals_user_status ON goals(user_id, status);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS goals (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					title VARCHAR(255) NOT NULL,
+					goal_type VARCHAR(20) NOT NULL,
+					movement_id BIGINT,
+					wod_id BIGINT,
+					metric VARCHAR(20) NOT NULL,
+					target_value DOUBLE NOT NULL,
+					reps_per_round INT,
+					start_date VARCHAR(10) NOT NULL,
+					target_date VARCHAR(10),
+					baseline_value DOUBLE,
+					status VARCHAR(20) NOT NULL DEFAULT 'active',
+					achieved_at DATETIME,
+					achieved_workout_id BIGINT,
+					notes TEXT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					INDEX idx_goals_user_status (user_id, status),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE CASCADE,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (achieved_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS goals")
+			return err
+		},
+	},
+	{
+		Version:     "0.13.9",
+		Description: "Add achievement_rules and user_achievements tables for badges",
+		Up: func(db *sql.DB, driver string) error {
+			if err := createTableIfNotExists(db, driver, "achievement_rules", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS achievement_rules (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					code TEXT NOT NULL UNIQUE,
+					name TEXT NOT NULL,
+					description TEXT NOT NULL DEFAULT '',
+					icon TEXT,
+					rule_type TEXT NOT NULL,
+					params TEXT NOT NULL DEFAULT '{}',
+					is_active INTEGER NOT NULL DEFAULT 1,
+					created_by INTEGER,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				)`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS achievement_rules (
+					id BIGSERIAL PRIMARY KEY,
+					code VARCHAR(100) NOT NULL UNIQUE,
+					name VARCHAR(255) NOT NULL,
+					description TEXT NOT NULL DEFAULT '',
+					icon VARCHAR(100),
+					rule_type VARCHAR(50) NOT NULL,
+					params TEXT NOT NULL DEFAULT '{}',
+					is_active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				)`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS achievement_rules (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					code VARCHAR(100) NOT NULL UNIQUE,
+					name VARCHAR(255) NOT NULL,
+					description TEXT NOT NULL,
+					icon VARCHAR(100),
+					rule_type VARCHAR(50) NOT NULL,
+					params TEXT NOT NULL,
+					is_active BOOLEAN NOT NULL DEFAULT TRUE,
+					created_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			return createTableIfNotExists(db, driver, "user_achievements", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS user_achievements (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					user_id INTEGER NOT NULL,
+					rule_id INTEGER NOT NULL,
+					user_workout_id INTEGER,
+					workout_date TEXT,
+					awarded_at DATETIME NOT NULL,
+					UNIQUE(user_id, rule_id),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (rule_id) REFERENCES achievement_rules(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				)`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS user_achievements (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					rule_id BIGINT NOT NULL,
+					user_workout_id BIGINT,
+					workout_date VARCHAR(10),
+					awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE(user_id, rule_id),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (rule_id) REFERENCES achievement_rules(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				)`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS user_achievements (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					rule_id BIGINT NOT NULL,
+					user_workout_id BIGINT,
+					workout_date VARCHAR(10),
+					awarded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_user_achievements_user_rule (user_id, rule_id),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (rule_id) REFERENCES achievement_rules(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			if _, err := db.Exec("DROP TABLE IF EXISTS user_achievements"); err != nil {
+				return err
+			}
+			_, err := db.Exec("DROP TABLE IF EXISTS achievement_rules")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.0",
+		Description: "Add extended score columns to user_workout_wods (calories, distance, points, tie-break, time cap)",
+		Up: func(db *sql.DB, driver string) error {
+			columns := []struct {
+				name       string
+				definition map[string]string
+			}{
+				{"calories", map[string]string{"sqlite3": "INTEGER", "postgres": "INTEGER", "mysql": "INT"}},
+				{"distance", map[string]string{"sqlite3": "REAL", "postgres": "DOUBLE PRECISION", "mysql": "DOUBLE"}},
+				{"points", map[string]string{"sqlite3": "REAL", "postgres": "DOUBLE PRECISION", "mysql": "DOUBLE"}},
+				{"tie_break_seconds", map[string]string{"sqlite3": "INTEGER", "postgres": "INTEGER", "mysql": "INT"}},
+				{"time_capped", map[string]string{
+					"sqlite3":  "INTEGER NOT NULL DEFAULT 0",
+					"postgres": "BOOLEAN NOT NULL DEFAULT FALSE",
+					"mysql":    "BOOLEAN NOT NULL DEFAULT FALSE",
+				}},
+			}
+			for _, c := range columns {
+				if err := addColumnIfNotExists(db, driver, "user_workout_wods", c.name, c.definition); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(db *sql.DB, driver string) error {
+			for _, column := range []string{"calories", "distance", "points", "tie_break_seconds", "time_capped"} {
+				if _, err := db.Exec(fmt.Sprintf("ALTER TABLE user_workout_wods DROP COLUMN %s", column)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     "0.14.1",
+		Description: "Add wod_structures and wod_components tables for structured WOD definitions",
+		Up: func(db *sql.DB, driver string) error {
+			if err := createTableIfNotExists(db, driver, "wod_structures", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS wod_structures (
+					wod_id INTEGER PRIMARY KEY,
+					rounds INTEGER,
+					rep_scheme TEXT,
+					time_cap_seconds INTEGER,
+					notes TEXT NOT NULL DEFAULT '',
+					confidence REAL NOT NULL DEFAULT 0,
+					needs_review INTEGER NOT NULL DEFAULT 0,
+					warnings TEXT NOT NULL DEFAULT '[]',
+					source TEXT NOT NULL DEFAULT 'parsed',
+					reviewed_by INTEGER,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL
+				)`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS wod_structures (
+					wod_id BIGINT PRIMARY KEY,
+					rounds INTEGER,
+					rep_scheme VARCHAR(100),
+					time_cap_seconds INTEGER,
+					notes TEXT NOT NULL DEFAULT '',
+					confidence DOUBLE PRECISION NOT NULL DEFAULT 0,
+					needs_review BOOLEAN NOT NULL DEFAULT FALSE,
+					warnings TEXT NOT NULL DEFAULT '[]',
+					source VARCHAR(20) NOT NULL DEFAULT 'parsed',
+					reviewed_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL
+				)`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS wod_structures (
+					wod_id BIGINT PRIMARY KEY,
+					rounds INT,
+					rep_scheme VARCHAR(100),
+					time_cap_seconds INT,
+					notes TEXT NOT NULL,
+					confidence DOUBLE NOT NULL DEFAULT 0,
+					needs_review BOOLEAN NOT NULL DEFAULT FALSE,
+					warnings TEXT NOT NULL,
+					source VARCHAR(20) NOT NULL DEFAULT 'parsed',
+					reviewed_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			return createTableIfNotExists(db, driver, "wod_components", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS wod_components (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					wod_id INTEGER NOT NULL,
+					order_index INTEGER NOT NULL DEFAULT 0,
+					movement_id INTEGER,
+					movement_name TEXT NOT NULL,
+					reps INTEGER,
+					calories INTEGER,
+					distance REAL,
+					duration_seconds INTEGER,
+					weight_male REAL,
+					weight_female REAL,
+					weight_unit TEXT NOT NULL DEFAULT '',
+					notes TEXT NOT NULL DEFAULT '',
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_wod_components_wod_id ON wod_components(wod_id);
+				CREATE INDEX IF NOT EXISTS idx_wod_components_movement_id ON wod_components(movement_id);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS wod_components (
+					id BIGSERIAL PRIMARY KEY,
+					wod_id BIGINT NOT NULL,
+					order_index INTEGER NOT NULL DEFAULT 0,
+					movement_id BIGINT,
+					movement_name VARCHAR(255) NOT NULL,
+					reps INTEGER,
+					calories INTEGER,
+					distance DOUBLE PRECISION,
+					duration_seconds INTEGER,
+					weight_male DOUBLE PRECISION,
+					weight_female DOUBLE PRECISION,
+					weight_unit VARCHAR(10) NOT NULL DEFAULT '',
+					notes TEXT NOT NULL DEFAULT '',
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_wod_components_wod_id ON wod_components(wod_id);
+				CREATE INDEX IF NOT EXISTS idx_wod_components_movement_id ON wod_components(movement_id);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS wod_components (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					wod_id BIGINT NOT NULL,
+					order_index INT NOT NULL DEFAULT 0,
+					movement_id BIGINT,
+					movement_name VARCHAR(255) NOT NULL,
+					reps INT,
+					calories INT,
+					distance DOUBLE,
+					duration_seconds INT,
+					weight_male DOUBLE,
+					weight_female DOUBLE,
+					weight_unit VARCHAR(10) NOT NULL DEFAULT '',
+					notes TEXT NOT NULL,
+					INDEX idx_wod_components_wod_id (wod_id),
+					INDEX idx_wod_components_movement_id (movement_id),
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			if _, err := db.Exec("DROP TABLE IF EXISTS wod_components"); err != nil {
+				return err
+			}
+			_, err := db.Exec("DROP TABLE IF EXISTS wod_structures")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.2",
+		Description: "Add name_aliases table for alternative movement and WOD names",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "name_aliases", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS name_aliases (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					entity_type TEXT NOT NULL,
+					entity_id INTEGER NOT NULL,
+					alias TEXT NOT NULL,
+					normalized_alias TEXT NOT NULL,
+					created_by INTEGER,
+					created_at DATETIME NOT NULL,
+					UNIQUE (entity_type, normalized_alias),
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_name_aliases_entity ON name_aliases(entity_type, entity_id);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS name_aliases (
+					id BIGSERIAL PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					alias VARCHAR(255) NOT NULL,
+					normalized_alias VARCHAR(255) NOT NULL,
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (entity_type, normalized_alias),
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_name_aliases_entity ON name_aliases(entity_type, entity_id);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS name_aliases (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					alias VARCHAR(255) NOT NULL,
+					normalized_alias VARCHAR(255) NOT NULL,
+					created_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_name_aliases_normalized (entity_type, normalized_alias),
+					INDEX idx_name_aliases_entity (entity_type, entity_id),
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS name_aliases")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.3",
+		Description: "Add movement taxonomy columns (equipment, pattern, muscle_groups, parent_id)",
+		Up: func(db *sql.DB, driver string) error {
+			for _, column := range []struct {
+				name       string
+				definition map[string]string
+			}{
+				{"equipment", map[string]string{"sqlite3": "TEXT", "postgres": "VARCHAR(255)", "mysql": "VARCHAR(255)"}},
+				{"pattern", map[string]string{"sqlite3": "TEXT", "postgres": "VARCHAR(50)", "mysql": "VARCHAR(50)"}},
+				{"muscle_groups", map[string]string{"sqlite3": "TEXT", "postgres": "VARCHAR(255)", "mysql": "VARCHAR(255)"}},
+				{"parent_id", map[string]string{"sqlite3": "INTEGER", "postgres": "BIGINT", "mysql": "BIGINT"}},
+			} {
+				if err := addColumnIfNotExists(db, driver, "movements", column.name, column.definition); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(db *sql.DB, driver string) error {
+			for _, column := range []string{"equipment", "pattern", "muscle_groups", "parent_id"} {
+				if _, err := db.Exec(fmt.Sprintf("ALTER TABLE movements DROP COLUMN %s", column)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     "0.14.4",
+		Description: "Add search_documents table with a full-text index (FTS5, tsvector or FULLTEXT by driver)",
+		Up: func(db *sql.DB, driver string) error {
+			if err := createTableIfNotExists(db, driver, "search_documents", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS search_documents (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					entity_type TEXT NOT NULL,
+					entity_id INTEGER NOT NULL,
+					owner_id INTEGER,
+					title TEXT NOT NULL,
+					body TEXT NOT NULL,
+					updated_at DATETIME NOT NULL,
+					UNIQUE (entity_type, entity_id)
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS search_documents (
+					id BIGSERIAL PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					owner_id BIGINT,
+					title TEXT NOT NULL,
+					body TEXT NOT NULL,
+					updated_at TIMESTAMP NOT NULL,
+					search_vector tsvector GENERATED ALWAYS AS (
+						setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', body), 'B')
+					) STORED,
+					UNIQUE (entity_type, entity_id)
+				);
+				CREATE INDEX IF NOT EXISTS idx_search_documents_vector ON search_documents USING GIN (search_vector);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS search_documents (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					owner_id BIGINT,
+					title VARCHAR(255) NOT NULL,
+					body TEXT NOT NULL,
+					updated_at DATETIME NOT NULL,
+					UNIQUE KEY uq_search_documents_entity (entity_type, entity_id),
+					FULLTEXT KEY ft_search_documents (title, body),
+					FULLTEXT KEY ft_search_documents_title (title)
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			// FTS5 is only available when the binary is built with the sqlite_fts5 tag;
+			// otherwise search falls back to LIKE matching
+			if driver == "sqlite3" && sqliteHasFTS5(db) {
+				return createSearchFTS(db)
+			}
+			return nil
+		},
+		Down: func(db *sql.DB, driver string) error {
+			if driver == "sqlite3" {
+				if _, err := db.Exec("DROP TABLE IF EXISTS search_fts"); err != nil {
+					return err
+				}
+			}
+			_, err := db.Exec("DROP TABLE IF EXISTS search_documents")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.5",
+		Description: "Add enumerations table for admin-managed WOD source, type, regime and movement type values",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "enumerations", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS enumerations (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					category TEXT NOT NULL,
+					value TEXT NOT NULL,
+					description TEXT,
+					display_order INTEGER NOT NULL DEFAULT 0,
+					is_deprecated BOOLEAN NOT NULL DEFAULT 0,
+					created_at DATETIME NOT NULL,
+					updated_at DATETIME NOT NULL,
+					UNIQUE (category, value)
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS enumerations (
+					id BIGSERIAL PRIMARY KEY,
+					category VARCHAR(50) NOT NULL,
+					value VARCHAR(100) NOT NULL,
+					description TEXT,
+					display_order INTEGER NOT NULL DEFAULT 0,
+					is_deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (category, value)
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS enumerations (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					category VARCHAR(50) NOT NULL,
+					value VARCHAR(100) NOT NULL,
+					description TEXT,
+					display_order INT NOT NULL DEFAULT 0,
+					is_deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_enumerations_value (category, value)
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS enumerations")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.6",
+		Description: "Add wod_versions and wod_variants tables and the version performed on user_workout_wods",
+		Up: func(db *sql.DB, driver string) error {
+			if err := createTableIfNotExists(db, driver, "wod_versions", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS wod_versions (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					wod_id INTEGER NOT NULL,
+					version INTEGER NOT NULL,
+					name TEXT NOT NULL,
+					source TEXT,
+					type TEXT,
+					regime TEXT,
+					score_type TEXT,
+					description TEXT,
+					url TEXT,
+					notes TEXT,
+					comparable_with_previous INTEGER NOT NULL DEFAULT 0,
+					baseline_version INTEGER NOT NULL,
+					created_by INTEGER,
+					created_at DATETIME NOT NULL,
+					UNIQUE (wod_id, version),
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				)`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS wod_versions (
+					id BIGSERIAL PRIMARY KEY,
+					wod_id BIGINT NOT NULL,
+					version INTEGER NOT NULL,
+					name VARCHAR(255) NOT NULL,
+					source VARCHAR(100),
+					type VARCHAR(100),
+					regime VARCHAR(100),
+					score_type VARCHAR(50),
+					description TEXT,
+					url TEXT,
+					notes TEXT,
+					comparable_with_previous BOOLEAN NOT NULL DEFAULT FALSE,
+					baseline_version INTEGER NOT NULL,
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (wod_id, version),
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				)`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS wod_versions (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					wod_id BIGINT NOT NULL,
+					version INT NOT NULL,
+					name VARCHAR(255) NOT NULL,
+					source VARCHAR(100),
+					type VARCHAR(100),
+					regime VARCHAR(100),
+					score_type VARCHAR(50),
+					description TEXT,
+					url TEXT,
+					notes TEXT,
+					comparable_with_previous BOOLEAN NOT NULL DEFAULT FALSE,
+					baseline_version INT NOT NULL,
+					created_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_wod_versions_version (wod_id, version),
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			if err := createTableIfNotExists(db, driver, "wod_variants", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS wod_variants (
+					wod_id INTEGER PRIMARY KEY,
+					parent_wod_id INTEGER NOT NULL,
+					kind TEXT NOT NULL,
+					comparable INTEGER NOT NULL DEFAULT 0,
+					created_at DATETIME NOT NULL,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (parent_wod_id) REFERENCES wods(id) ON DELETE CASCADE
+				)`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS wod_variants (
+					wod_id BIGINT PRIMARY KEY,
+					parent_wod_id BIGINT NOT NULL,
+					kind VARCHAR(20) NOT NULL,
+					comparable BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (parent_wod_id) REFERENCES wods(id) ON DELETE CASCADE
+				)`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS wod_variants (
+					wod_id BIGINT PRIMARY KEY,
+					parent_wod_id BIGINT NOT NULL,
+					kind VARCHAR(20) NOT NULL,
+					comparable BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+					FOREIGN KEY (parent_wod_id) REFERENCES wods(id) ON DELETE CASCADE
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			}); err != nil {
+				return err
+			}
+
+			// Results logged before a WOD's first edit keep a NULL version: the original definition
+			return addColumnIfNotExists(db, driver, "user_workout_wods", "wod_version_id", map[string]string{
+				"sqlite3":  "INTEGER REFERENCES wod_versions(id) ON DELETE SET NULL",
+				"postgres": "BIGINT REFERENCES wod_versions(id) ON DELETE SET NULL",
+				"mysql":    "BIGINT",
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			if _, err := db.Exec("ALTER TABLE user_workout_wods DROP COLUMN wod_version_id"); err != nil {
+				return err
+			}
+			if _, err := db.Exec("DROP TABLE IF EXISTS wod_variants"); err != nil {
+				return err
+			}
+			_, err := db.Exec("DROP TABLE IF EXISTS wod_versions")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.7",
+		Description: "Add seed_library_entries table tracking what the seed bundle applied to standard movements and WODs",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "seed_library_entries", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS seed_library_entries (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					entity_type TEXT NOT NULL,
+					seed_key TEXT NOT NULL,
+					entity_id INTEGER NOT NULL,
+					applied_hash TEXT NOT NULL,
+					bundle_version TEXT NOT NULL,
+					applied_at DATETIME NOT NULL,
+					UNIQUE (entity_type, seed_key)
+				);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS seed_library_entries (
+					id BIGSERIAL PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					seed_key VARCHAR(255) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					applied_hash VARCHAR(64) NOT NULL,
+					bundle_version VARCHAR(50) NOT NULL,
+					applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE (entity_type, seed_key)
+				);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS seed_library_entries (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					seed_key VARCHAR(255) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					applied_hash VARCHAR(64) NOT NULL,
+					bundle_version VARCHAR(50) NOT NULL,
+					applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE KEY uq_seed_library_entries_key (entity_type, seed_key)
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS seed_library_entries")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.8",
+		Description: "Add attachments table for images, videos and links on movements, WODs and logged workouts",
+		Up: func(db *sql.DB, driver string) error {
+			return createTableIfNotExists(db, driver, "attachments", map[string]string{
+				"sqlite3": `
+				CREATE TABLE IF NOT EXISTS attachments (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					entity_type TEXT NOT NULL,
+					entity_id INTEGER NOT NULL,
+					kind TEXT NOT NULL,
+					title TEXT NOT NULL,
+					url TEXT NOT NULL,
+					thumbnail_url TEXT,
+					content_type TEXT,
+					size_bytes INTEGER NOT NULL DEFAULT 0,
+					file_path TEXT,
+					thumbnail_path TEXT,
+					created_by INTEGER,
+					created_at DATETIME NOT NULL,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_attachments_entity ON attachments(entity_type, entity_id);`,
+				"postgres": `
+				CREATE TABLE IF NOT EXISTS attachments (
+					id BIGSERIAL PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					kind VARCHAR(20) NOT NULL,
+					title VARCHAR(255) NOT NULL,
+					url TEXT NOT NULL,
+					thumbnail_url TEXT,
+					content_type VARCHAR(100),
+					size_bytes BIGINT NOT NULL DEFAULT 0,
+					file_path TEXT,
+					thumbnail_path TEXT,
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+				CREATE INDEX IF NOT EXISTS idx_attachments_entity ON attachments(entity_type, entity_id);`,
+				"mysql": `
+				CREATE TABLE IF NOT EXISTS attachments (
+					id BIGINT AUTO_INCREMENT PRIMARY KEY,
+					entity_type VARCHAR(20) NOT NULL,
+					entity_id BIGINT NOT NULL,
+					kind VARCHAR(20) NOT NULL,
+					title VARCHAR(255) NOT NULL,
+					url TEXT NOT NULL,
+					thumbnail_url TEXT,
+					content_type VARCHAR(100),
+					size_bytes BIGINT NOT NULL DEFAULT 0,
+					file_path TEXT,
+					thumbnail_path TEXT,
+					created_by BIGINT,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					INDEX idx_attachments_entity (entity_type, entity_id),
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;`,
+			})
+		},
+		Down: func(db *sql.DB, driver string) error {
+			_, err := db.Exec("DROP TABLE IF EXISTS attachments")
+			return err
+		},
+	},
+	{
+		Version:     "0.14.9",
+		Description: "Backfill version 1 of every unversioned WOD and assign it the WOD's unversioned results",
+		Up: func(db *sql.DB, driver string) error {
+			// Version 1 is the WOD's current definition, in effect since its last update
+			if _, err := db.Exec(`INSERT INTO wod_versions (wod_id, version, name, source, type, regime, score_type, description, url, notes,
+				baseline_version, created_by, created_at)
+				SELECT w.id, 1, w.name, w.source, w.type, w.regime, w.score_type, w.description, w.url, w.notes,
+					1, w.created_by, COALESCE(w.updated_at, w.created_at, CURRENT_TIMESTAMP)
+				FROM wods w
+				WHERE NOT EXISTS (SELECT 1 FROM wod_versions v WHERE v.wod_id = w.id)`); err != nil {
+				return fmt.Errorf("failed to backfill wod versions: %w", err)
+			}
+
+			// Only WODs that were never edited have a single version; their results were all performed on it
+			if _, err := db.Exec(`UPDATE user_workout_wods
+				SET wod_version_id = (SELECT v.id FROM wod_versions v WHERE v.wod_id = user_workout_wods.wod_id AND v.version = 1)
+				WHERE wod_version_id IS NULL
+				AND wod_id IN (SELECT wod_id FROM wod_versions GROUP BY wod_id HAVING COUNT(*) = 1)`); err != nil {
+				return fmt.Errorf("failed to assign results to backfilled wod versions: %w", err)
+			}
+			return nil
+		},
+		Down: func(db *sql.DB, driver string) error {
+			// Backfilled versions cannot be told apart from versions recorded by edits; they are kept
+			return nil
+		},
+	},
 	// Future incremental migrations will be added here
 }
 
+// createTableIfNotExists creates a table from the driver-specific DDL if it does not already exist
+func createTableIfNotExists(db *sql.DB, driver, tableName string, ddl map[string]string) error {
+	exists, err := checkTableExists(db, driver, tableName)
+	if err != nil {
+		return fmt.Errorf("failed to check for %s table: %w", tableName, err)
+	}
+	if exists {
+		return nil
+	}
+
+	createSQL, ok := ddl[driver]
+	if !ok {
+		return fmt.Errorf("unsupported database driver: %s", driver)
+	}
+
+	if _, err := db.Exec(createSQL); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", tableName, err)
+	}
+	return nil
+}
+
+// checkColumnExists reports whether a table has the given column
+func checkColumnExists(db *sql.DB, driver, tableName, columnName string) (bool, error) {
+	var query string
+	switch driver {
+	case "sqlite3":
+		query = "SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?"
+	case "postgres":
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema='public' AND table_name=$1 AND column_name=$2"
+	case "mysql":
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema=DATABASE() AND table_name=? AND column_name=?"
+	default:
+		return false, fmt.Errorf("unsupported database driver: %s", driver)
+	}
+
+	var count int
+	if err := db.QueryRow(query, tableName, columnName).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// addColumnIfNotExists adds a column using the driver-specific type definition if it does not already exist
+func addColumnIfNotExists(db *sql.DB, driver, tableName, columnName string, definition map[string]string) error {
+	exists, err := checkColumnExists(db, driver, tableName, columnName)
+	if err != nil {
+		return fmt.Errorf("failed to check for %s.%s column: %w", tableName, columnName, err)
+	}
+	if exists {
+		return nil
+	}
+
+	columnType, ok := definition[driver]
+	if !ok {
+		return fmt.Errorf("unsupported database driver: %s", driver)
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", tableName, columnName, columnType)); err != nil {
+		return fmt.Errorf("failed to add %s.%s column: %w", tableName, columnName, err)
+	}
+	return nil
+}
+
 // RunMigrations runs all pending migrations
 func RunMigrations(db *sql.DB, driver string) error {
 	// Create migrations table if it doesn't exist
@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/johnzastrow/actalog/internal/domain"
@@ -19,15 +20,18 @@ func NewMovementRepository(db *sql.DB) *MovementRepository {
 	return &MovementRepository{db: db}
 }
 
+const movementColumns = `id, name, description, type, is_standard, created_by, created_at, updated_at, equipment, pattern, muscle_groups, parent_id`
+
 // Create creates a new movement
 func (r *MovementRepository) Create(movement *domain.Movement) error {
 	movement.CreatedAt = time.Now()
 	movement.UpdatedAt = time.Now()
 
-	query := `INSERT INTO movements (name, description, type, is_standard, created_by, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO movements (name, description, type, is_standard, created_by, created_at, updated_at, equipment, pattern, muscle_groups, parent_id)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	result, err := r.db.Exec(query, movement.Name, movement.Description, movement.Type, movement.IsStandard, movement.CreatedBy, movement.CreatedAt, movement.UpdatedAt)
+	result, err := r.db.Exec(query, movement.Name, movement.Description, movement.Type, movement.IsStandard, movement.CreatedBy, movement.CreatedAt, movement.UpdatedAt,
+		formatTaxonomyList(movement.Equipment), movement.Pattern, formatTaxonomyList(movement.MuscleGroups), movement.ParentID)
 	if err != nil {
 		return fmt.Errorf("failed to create movement: %w", err)
 	}
@@ -41,14 +45,40 @@ func (r *MovementRepository) Create(movement *domain.Movement) error {
 	return nil
 }
 
+// CreateInLibrary creates a new movement and adds it to a gym library in one transaction
+func (r *MovementRepository) CreateInLibrary(movement *domain.Movement, orgID, addedBy int64) error {
+	movement.CreatedAt = time.Now()
+	movement.UpdatedAt = time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, err := txInsertReturningID(tx, `INSERT INTO movements (name, description, type, is_standard, created_by, created_at, updated_at, equipment, pattern, muscle_groups, parent_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		movement.Name, movement.Description, movement.Type, movement.IsStandard, movement.CreatedBy, movement.CreatedAt, movement.UpdatedAt,
+		formatTaxonomyList(movement.Equipment), movement.Pattern, formatTaxonomyList(movement.MuscleGroups), movement.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to create movement: %w", err)
+	}
+	if err := addLibraryItem(tx, orgID, domain.LibraryEntityMovement, id, addedBy); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit movement: %w", err)
+	}
+	movement.ID = id
+	return nil
+}
+
 // GetByID retrieves a movement by ID
 func (r *MovementRepository) GetByID(id int64) (*domain.Movement, error) {
-	query := `SELECT id, name, description, type, is_standard, created_by, created_at, updated_at FROM movements WHERE id = ?`
+	query := `SELECT ` + movementColumns + ` FROM movements WHERE id = ?`
 
-	movement := &domain.Movement{}
-	var createdBy sql.NullInt64
-
-	err := r.db.QueryRow(query, id).Scan(&movement.ID, &movement.Name, &movement.Description, &movement.Type, &movement.IsStandard, &createdBy, &movement.CreatedAt, &movement.UpdatedAt)
+	movement, err := scanMovement(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -56,21 +86,14 @@ func (r *MovementRepository) GetByID(id int64) (*domain.Movement, error) {
 		return nil, fmt.Errorf("failed to get movement: %w", err)
 	}
 
-	if createdBy.Valid {
-		movement.CreatedBy = &createdBy.Int64
-	}
-
 	return movement, nil
 }
 
 // GetByName retrieves a movement by name
 func (r *MovementRepository) GetByName(name string) (*domain.Movement, error) {
-	query := `SELECT id, name, description, type, is_standard, created_by, created_at, updated_at FROM movements WHERE name = ?`
+	query := `SELECT ` + movementColumns + ` FROM movements WHERE name = ?`
 
-	movement := &domain.Movement{}
-	var createdBy sql.NullInt64
-
-	err := r.db.QueryRow(query, name).Scan(&movement.ID, &movement.Name, &movement.Description, &movement.Type, &movement.IsStandard, &createdBy, &movement.CreatedAt, &movement.UpdatedAt)
+	movement, err := scanMovement(r.db.QueryRow(query, name))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -78,16 +101,12 @@ func (r *MovementRepository) GetByName(name string) (*domain.Movement, error) {
 		return nil, fmt.Errorf("failed to get movement by name: %w", err)
 	}
 
-	if createdBy.Valid {
-		movement.CreatedBy = &createdBy.Int64
-	}
-
 	return movement, nil
 }
 
 // ListStandard retrieves all standard movements
 func (r *MovementRepository) ListStandard() ([]*domain.Movement, error) {
-	query := `SELECT id, name, description, type, is_standard, created_by, created_at, updated_at FROM movements WHERE is_standard = 1 ORDER BY name`
+	query := `SELECT ` + movementColumns + ` FROM movements WHERE is_standard = 1 ORDER BY name`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -98,11 +117,12 @@ func (r *MovementRepository) ListStandard() ([]*domain.Movement, error) {
 	return r.scanMovements(rows)
 }
 
-// ListAll retrieves all movements (both standard and custom)
-func (r *MovementRepository) ListAll() ([]*domain.Movement, error) {
-	query := `SELECT id, name, description, type, is_standard, created_by, created_at, updated_at FROM movements ORDER BY name`
+// ListAll retrieves all movements (both standard and custom) matching the filters
+func (r *MovementRepository) ListAll(filters map[string]interface{}) ([]*domain.Movement, error) {
+	where, args := movementFilterClause(filters)
+	query := `SELECT ` + movementColumns + ` FROM movements WHERE 1=1` + where + ` ORDER BY name`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list all movements: %w", err)
 	}
@@ -113,7 +133,7 @@ func (r *MovementRepository) ListAll() ([]*domain.Movement, error) {
 
 // ListByUser retrieves movements created by a user
 func (r *MovementRepository) ListByUser(userID int64) ([]*domain.Movement, error) {
-	query := `SELECT id, name, description, type, is_standard, created_by, created_at, updated_at FROM movements WHERE created_by = ? ORDER BY name`
+	query := `SELECT ` + movementColumns + ` FROM movements WHERE created_by = ? ORDER BY name`
 
 	rows, err := r.db.Query(query, userID)
 	if err != nil {
@@ -129,10 +149,11 @@ func (r *MovementRepository) Update(movement *domain.Movement) error {
 	movement.UpdatedAt = time.Now()
 
 	query := `UPDATE movements
-	          SET name = ?, description = ?, type = ?, updated_at = ?
+	          SET name = ?, description = ?, type = ?, updated_at = ?, equipment = ?, pattern = ?, muscle_groups = ?, parent_id = ?
 	          WHERE id = ? AND is_standard = 0`
 
-	result, err := r.db.Exec(query, movement.Name, movement.Description, movement.Type, movement.UpdatedAt, movement.ID)
+	result, err := r.db.Exec(query, movement.Name, movement.Description, movement.Type, movement.UpdatedAt,
+		formatTaxonomyList(movement.Equipment), movement.Pattern, formatTaxonomyList(movement.MuscleGroups), movement.ParentID, movement.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update movement: %w", err)
 	}
@@ -149,6 +170,54 @@ func (r *MovementRepository) Update(movement *domain.Movement) error {
 	return nil
 }
 
+// UpdateStandard updates an existing standard movement
+func (r *MovementRepository) UpdateStandard(movement *domain.Movement) error {
+	movement.UpdatedAt = time.Now()
+
+	query := `UPDATE movements
+	          SET name = ?, description = ?, type = ?, updated_at = ?, equipment = ?, pattern = ?, muscle_groups = ?, parent_id = ?
+	          WHERE id = ? AND is_standard = 1`
+
+	result, err := r.db.Exec(query, movement.Name, movement.Description, movement.Type, movement.UpdatedAt,
+		formatTaxonomyList(movement.Equipment), movement.Pattern, formatTaxonomyList(movement.MuscleGroups), movement.ParentID, movement.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update standard movement: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("standard movement not found")
+	}
+
+	return nil
+}
+
+// UpdateTaxonomy sets the equipment, pattern, muscle groups and parent of any movement, standard or custom
+func (r *MovementRepository) UpdateTaxonomy(movement *domain.Movement) error {
+	movement.UpdatedAt = time.Now()
+
+	query := `UPDATE movements SET equipment = ?, pattern = ?, muscle_groups = ?, parent_id = ?, updated_at = ? WHERE id = ?`
+
+	result, err := r.db.Exec(query, formatTaxonomyList(movement.Equipment), movement.Pattern, formatTaxonomyList(movement.MuscleGroups), movement.ParentID, movement.UpdatedAt, movement.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update movement taxonomy: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("movement not found")
+	}
+
+	return nil
+}
+
 // Delete deletes a movement (only for user-created movements)
 func (r *MovementRepository) Delete(id int64) error {
 	query := `DELETE FROM movements WHERE id = ? AND is_standard = 0`
@@ -172,8 +241,7 @@ func (r *MovementRepository) Delete(id int64) error {
 
 // ListAllUserCreated retrieves all user-created movements across all users (for admin view)
 func (r *MovementRepository) ListAllUserCreated() ([]*domain.Movement, error) {
-	query := `SELECT id, name, description, type, is_standard, created_by, created_at, updated_at
-	          FROM movements WHERE is_standard = 0 ORDER BY name`
+	query := `SELECT ` + movementColumns + ` FROM movements WHERE is_standard = 0 ORDER BY name`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -242,7 +310,8 @@ func (r *MovementRepository) CountAllUserCreated() (int64, error) {
 }
 
 // ListAllUserCreatedWithUserInfoFiltered retrieves all user-created movements with creator info and filters (for admin view)
-func (r *MovementRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, movementType, creator string) ([]*domain.MovementWithCreator, int64, error) {
+// A positive orgID limits the list to items created by members of that gym
+func (r *MovementRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, movementType, creator string, orgID int64) ([]*domain.MovementWithCreator, int64, error) {
 	baseQuery := `SELECT m.id, m.name, m.description, m.type, m.is_standard, m.created_by, m.created_at, m.updated_at,
 	                 COALESCE(u.email, '') as creator_email, COALESCE(u.name, '') as creator_name
 	          FROM movements m
@@ -275,6 +344,12 @@ func (r *MovementRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offse
 		args = append(args, creatorTerm)
 		countArgs = append(countArgs, creatorTerm)
 	}
+	if orgID > 0 {
+		baseQuery += " AND m.created_by IN (SELECT user_id FROM organization_members WHERE organization_id = ?)"
+		countQuery += " AND m.created_by IN (SELECT user_id FROM organization_members WHERE organization_id = ?)"
+		args = append(args, orgID)
+		countArgs = append(countArgs, orgID)
+	}
 
 	// Get count first
 	var count int64
@@ -331,14 +406,16 @@ func (r *MovementRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offse
 	return movements, count, rows.Err()
 }
 
-// Search searches for movements by name
-func (r *MovementRepository) Search(query string, limit int) ([]*domain.Movement, error) {
-	searchQuery := `SELECT id, name, description, type, is_standard, created_by, created_at, updated_at FROM movements
-	                WHERE name LIKE ?
+// Search searches for movements by name, narrowed by the same filters as ListAll
+func (r *MovementRepository) Search(query string, filters map[string]interface{}, limit int) ([]*domain.Movement, error) {
+	where, filterArgs := movementFilterClause(filters)
+	searchQuery := `SELECT ` + movementColumns + ` FROM movements
+	                WHERE name LIKE ?` + where + `
 	                ORDER BY is_standard DESC, name
 	                LIMIT ?`
 
-	rows, err := r.db.Query(searchQuery, "%"+query+"%", limit)
+	args := append([]interface{}{"%" + query + "%"}, filterArgs...)
+	rows, err := r.db.Query(searchQuery, append(args, limit)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search movements: %w", err)
 	}
@@ -361,17 +438,21 @@ func (r *MovementRepository) CopyToStandard(id int64, newName string) (*domain.M
 	// Create a new standard movement
 	now := time.Now()
 	standardMovement := &domain.Movement{
-		Name:        newName,
-		Description: source.Description,
-		Type:        source.Type,
-		IsStandard:  true,
-		CreatedBy:   nil, // Standard movements have no creator
-		CreatedAt:   now,
-		UpdatedAt:   now,
-	}
-
-	query := `INSERT INTO movements (name, description, type, is_standard, created_by, created_at, updated_at)
-	          VALUES (?, ?, ?, 1, NULL, ?, ?)`
+		Name:         newName,
+		Description:  source.Description,
+		Type:         source.Type,
+		IsStandard:   true,
+		CreatedBy:    nil, // Standard movements have no creator
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Equipment:    source.Equipment,
+		Pattern:      source.Pattern,
+		MuscleGroups: source.MuscleGroups,
+		ParentID:     source.ParentID,
+	}
+
+	query := `INSERT INTO movements (name, description, type, is_standard, created_by, created_at, updated_at, equipment, pattern, muscle_groups, parent_id)
+	          VALUES (?, ?, ?, 1, NULL, ?, ?, ?, ?, ?, ?)`
 
 	result, err := r.db.Exec(query,
 		standardMovement.Name,
@@ -379,6 +460,10 @@ func (r *MovementRepository) CopyToStandard(id int64, newName string) (*domain.M
 		standardMovement.Type,
 		standardMovement.CreatedAt,
 		standardMovement.UpdatedAt,
+		formatTaxonomyList(standardMovement.Equipment),
+		standardMovement.Pattern,
+		formatTaxonomyList(standardMovement.MuscleGroups),
+		standardMovement.ParentID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create standard movement: %w", err)
@@ -397,20 +482,97 @@ func (r *MovementRepository) CopyToStandard(id int64, newName string) (*domain.M
 func (r *MovementRepository) scanMovements(rows *sql.Rows) ([]*domain.Movement, error) {
 	var movements []*domain.Movement
 	for rows.Next() {
-		movement := &domain.Movement{}
-		var createdBy sql.NullInt64
-
-		err := rows.Scan(&movement.ID, &movement.Name, &movement.Description, &movement.Type, &movement.IsStandard, &createdBy, &movement.CreatedAt, &movement.UpdatedAt)
+		movement, err := scanMovement(rows)
 		if err != nil {
 			return nil, err
 		}
+		movements = append(movements, movement)
+	}
 
-		if createdBy.Valid {
-			movement.CreatedBy = &createdBy.Int64
+	return movements, rows.Err()
+}
+
+// scanMovement scans a row selected with movementColumns
+func scanMovement(row rowScanner) (*domain.Movement, error) {
+	movement := &domain.Movement{}
+	var createdBy, parentID sql.NullInt64
+	var equipment, pattern, muscleGroups sql.NullString
+
+	err := row.Scan(&movement.ID, &movement.Name, &movement.Description, &movement.Type, &movement.IsStandard, &createdBy, &movement.CreatedAt, &movement.UpdatedAt,
+		&equipment, &pattern, &muscleGroups, &parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdBy.Valid {
+		movement.CreatedBy = &createdBy.Int64
+	}
+	if parentID.Valid {
+		movement.ParentID = &parentID.Int64
+	}
+	movement.Equipment = parseTaxonomyList(equipment.String)
+	movement.Pattern = pattern.String
+	movement.MuscleGroups = parseTaxonomyList(muscleGroups.String)
+
+	return movement, nil
+}
+
+// movementFilterClause builds the AND conditions for ListAll and Search filters
+func movementFilterClause(filters map[string]interface{}) (string, []interface{}) {
+	var where string
+	var args []interface{}
+	if filters == nil {
+		return where, args
+	}
+
+	if movementType, ok := filters["type"].(string); ok && movementType != "" {
+		where += " AND type = ?"
+		args = append(args, movementType)
+	}
+	if pattern, ok := filters["pattern"].(string); ok && pattern != "" {
+		where += " AND pattern = ?"
+		args = append(args, pattern)
+	}
+	for _, list := range []struct{ filter, column string }{{"equipment", "equipment"}, {"muscle_group", "muscle_groups"}} {
+		if value, ok := filters[list.filter].(string); ok && value != "" {
+			column := list.column
+			// Lists are stored comma-separated: match the value as the whole list, or its first, last or a middle entry
+			escape := likeEscapeClause()
+			where += " AND (" + column + " = ? OR " + column + " LIKE ?" + escape + " OR " + column + " LIKE ?" + escape + " OR " + column + " LIKE ?" + escape + ")"
+			literal := escapeLike(value)
+			args = append(args, value, literal+",%", "%,"+literal, "%,"+literal+",%")
 		}
+	}
+	if parentID, ok := filters["parent_id"].(int64); ok {
+		where += " AND parent_id = ?"
+		args = append(args, parentID)
+	}
+	if familyID, ok := filters["family_id"].(int64); ok {
+		where += " AND (id = ? OR parent_id = ?)"
+		args = append(args, familyID, familyID)
+	}
+	if isStandard, ok := filters["is_standard"].(bool); ok {
+		where += " AND is_standard = ?"
+		args = append(args, isStandard)
+	}
 
-		movements = append(movements, movement)
+	return where, args
+}
+
+// formatTaxonomyList stores equipment or muscle groups as a comma-separated list
+func formatTaxonomyList(values []string) interface{} {
+	if len(values) == 0 {
+		return nil
 	}
+	return strings.Join(values, ",")
+}
 
-	return movements, rows.Err()
+func parseTaxonomyList(value string) []string {
+	values := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
 }
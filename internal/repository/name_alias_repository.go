@@ -0,0 +1,463 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// NameAliasRepository implements domain.NameAliasRepository
+type NameAliasRepository struct {
+	db *sql.DB
+}
+
+// NewNameAliasRepository creates a new name alias repository
+func NewNameAliasRepository(db *sql.DB) *NameAliasRepository {
+	return &NameAliasRepository{db: db}
+}
+
+const nameAliasColumns = `id, entity_type, entity_id, alias, normalized_alias, created_by, created_at`
+
+// mergeReference is a column holding a movement or WOD ID that a merge repoints
+type mergeReference struct {
+	table  string
+	column string
+}
+
+// mergeReferences lists, per entity type, every column that references a movement or WOD
+var mergeReferences = map[string][]mergeReference{
+	domain.AliasEntityMovement: {
+		{"user_workout_movements", "movement_id"},
+		{"workout_movements", "movement_id"},
+		{"wod_components", "movement_id"},
+		{"goals", "movement_id"},
+		{"program_progressions", "movement_id"},
+	},
+	domain.AliasEntityWOD: {
+		{"user_workout_wods", "wod_id"},
+		{"workout_wods", "wod_id"},
+		{"goals", "wod_id"},
+		{"scheduled_workouts", "wod_id"},
+	},
+}
+
+// mergeEntityTypedTables lists the tables referencing movements and WODs by entity_type and entity_id
+var mergeEntityTypedTables = []string{"name_aliases", "attachments", "organization_library"}
+
+// mergeEntityTables maps alias entity types to the table holding the entities
+var mergeEntityTables = map[string]string{
+	domain.AliasEntityMovement: "movements",
+	domain.AliasEntityWOD:      "wods",
+}
+
+// Create creates a new alias
+func (r *NameAliasRepository) Create(alias *domain.NameAlias) error {
+	alias.CreatedAt = time.Now()
+
+	id, err := insertReturningID(r.db, `INSERT INTO name_aliases (entity_type, entity_id, alias, normalized_alias, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		alias.EntityType, alias.EntityID, alias.Alias, alias.NormalizedAlias, alias.CreatedBy, alias.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create alias: %w", err)
+	}
+
+	alias.ID = id
+	return nil
+}
+
+// GetByID retrieves an alias by ID
+func (r *NameAliasRepository) GetByID(id int64) (*domain.NameAlias, error) {
+	query := rebindQuery(`SELECT ` + nameAliasColumns + ` FROM name_aliases WHERE id = ?`)
+
+	alias, err := scanNameAlias(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get alias: %w", err)
+	}
+	return alias, nil
+}
+
+// GetByNormalized finds the alias of an entity type with the given normalized name
+func (r *NameAliasRepository) GetByNormalized(entityType, normalized string) (*domain.NameAlias, error) {
+	query := rebindQuery(`SELECT ` + nameAliasColumns + ` FROM name_aliases WHERE entity_type = ? AND normalized_alias = ?`)
+
+	alias, err := scanNameAlias(r.db.QueryRow(query, entityType, normalized))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get alias: %w", err)
+	}
+	return alias, nil
+}
+
+// ListByEntity lists the aliases of a movement or WOD
+func (r *NameAliasRepository) ListByEntity(entityType string, entityID int64) ([]*domain.NameAlias, error) {
+	return r.list(`SELECT `+nameAliasColumns+` FROM name_aliases WHERE entity_type = ? AND entity_id = ? ORDER BY alias`, entityType, entityID)
+}
+
+// ListByType lists every alias of an entity type
+func (r *NameAliasRepository) ListByType(entityType string) ([]*domain.NameAlias, error) {
+	return r.list(`SELECT `+nameAliasColumns+` FROM name_aliases WHERE entity_type = ? ORDER BY entity_id, alias`, entityType)
+}
+
+// Delete deletes an alias
+func (r *NameAliasRepository) Delete(id int64) error {
+	if _, err := r.db.Exec(rebindQuery(`DELETE FROM name_aliases WHERE id = ?`), id); err != nil {
+		return fmt.Errorf("failed to delete alias: %w", err)
+	}
+	return nil
+}
+
+// CountReferences counts, per table, the rows referencing any of the given movements or WODs
+func (r *NameAliasRepository) CountReferences(entityType string, ids []int64) (map[string]int64, error) {
+	refs, ok := mergeReferences[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported alias entity type: %s", entityType)
+	}
+	in, args := idList(ids)
+
+	counts := make(map[string]int64)
+	for _, ref := range refs {
+		var n int64
+		if err := r.db.QueryRow(rebindQuery(`SELECT COUNT(*) FROM `+ref.table+` WHERE `+ref.column+` IN `+in), args...).Scan(&n); err != nil {
+			return nil, fmt.Errorf("failed to count %s references: %w", ref.table, err)
+		}
+		if n > 0 {
+			counts[ref.table] = n
+		}
+	}
+
+	if entityType == domain.AliasEntityMovement {
+		var variants int64
+		if err := r.db.QueryRow(rebindQuery(`SELECT COUNT(*) FROM movements WHERE parent_id IN `+in), args...).Scan(&variants); err != nil {
+			return nil, fmt.Errorf("failed to count movement variants: %w", err)
+		}
+		if variants > 0 {
+			counts["movements"] = variants
+		}
+	}
+
+	typedArgs := append([]interface{}{entityType}, args...)
+	for _, table := range mergeEntityTypedTables {
+		var n int64
+		if err := r.db.QueryRow(rebindQuery(`SELECT COUNT(*) FROM `+table+` WHERE entity_type = ? AND entity_id IN `+in), typedArgs...).Scan(&n); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", table, err)
+		}
+		if n > 0 {
+			counts[table] = n
+		}
+	}
+	return counts, nil
+}
+
+// ListReferencingUserIDs lists the users with logged performances of any of the given movements or WODs
+func (r *NameAliasRepository) ListReferencingUserIDs(entityType string, ids []int64) ([]int64, error) {
+	var performanceTable, column string
+	switch entityType {
+	case domain.AliasEntityMovement:
+		performanceTable, column = "user_workout_movements", "movement_id"
+	case domain.AliasEntityWOD:
+		performanceTable, column = "user_workout_wods", "wod_id"
+	default:
+		return nil, fmt.Errorf("unsupported alias entity type: %s", entityType)
+	}
+	in, args := idList(ids)
+
+	rows, err := r.db.Query(rebindQuery(`SELECT DISTINCT uw.user_id FROM user_workouts uw
+		JOIN `+performanceTable+` p ON p.user_workout_id = uw.id
+		WHERE p.`+column+` IN `+in+`
+		ORDER BY uw.user_id`), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referencing users: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+// MergeEntities repoints every reference to the source movements or WODs onto the target, moves the
+// sources' aliases, keeps the source names as aliases of the target and deletes the sources
+func (r *NameAliasRepository) MergeEntities(entityType string, sourceIDs []int64, targetID int64) (map[string]int64, error) {
+	entityTable, ok := mergeEntityTables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported alias entity type: %s", entityType)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	nameQuery := rebindQuery(`SELECT name FROM ` + entityTable + ` WHERE id = ?`)
+	var targetName string
+	if err := tx.QueryRow(nameQuery, targetID).Scan(&targetName); err != nil {
+		return nil, fmt.Errorf("failed to get merge target: %w", err)
+	}
+
+	counts := make(map[string]int64)
+	for _, sourceID := range sourceIDs {
+		var sourceName string
+		if err := tx.QueryRow(nameQuery, sourceID).Scan(&sourceName); err != nil {
+			return nil, fmt.Errorf("failed to get merge source %d: %w", sourceID, err)
+		}
+		if err := r.mergeEntity(tx, entityType, entityTable, sourceID, sourceName, targetID, targetName, counts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return counts, nil
+}
+
+// mergeEntity folds one source into the target within the merge transaction, adding to counts
+func (r *NameAliasRepository) mergeEntity(tx *sql.Tx, entityType, entityTable string, sourceID int64, sourceName string, targetID int64, targetName string, counts map[string]int64) error {
+	// A program keeps one progression per movement, so the source's progression is dropped
+	// wherever the program already has one for the target
+	if entityType == domain.AliasEntityMovement {
+		if err := r.dropConflictingProgressions(tx, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range mergeReferences[entityType] {
+		result, err := tx.Exec(rebindQuery(`UPDATE `+ref.table+` SET `+ref.column+` = ? WHERE `+ref.column+` = ?`), targetID, sourceID)
+		if err != nil {
+			return fmt.Errorf("failed to repoint %s: %w", ref.table, err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			counts[ref.table] += n
+		}
+	}
+
+	if entityType == domain.AliasEntityMovement {
+		if err := r.repointVariants(tx, sourceID, targetID, counts); err != nil {
+			return err
+		}
+	}
+
+	// The merged WOD's own structure describes the same workout as the target's
+	if entityType == domain.AliasEntityWOD {
+		if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_components WHERE wod_id = ?`), sourceID); err != nil {
+			return fmt.Errorf("failed to delete merged wod components: %w", err)
+		}
+		if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_structures WHERE wod_id = ?`), sourceID); err != nil {
+			return fmt.Errorf("failed to delete merged wod structure: %w", err)
+		}
+		if err := r.mergeWODVersions(tx, sourceID, targetID); err != nil {
+			return err
+		}
+	}
+
+	// A gym lists each record once, so the source's library entry is dropped wherever the gym
+	// already has the target
+	if err := r.dropConflictingLibraryItems(tx, entityType, sourceID, targetID); err != nil {
+		return err
+	}
+
+	for _, table := range mergeEntityTypedTables {
+		result, err := tx.Exec(rebindQuery(`UPDATE `+table+` SET entity_id = ? WHERE entity_type = ? AND entity_id = ?`), targetID, entityType, sourceID)
+		if err != nil {
+			return fmt.Errorf("failed to move %s: %w", table, err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			counts[table] += n
+		}
+	}
+
+	normalized := domain.NormalizeName(sourceName)
+	if normalized != "" && normalized != domain.NormalizeName(targetName) {
+		var existing int
+		if err := tx.QueryRow(rebindQuery(`SELECT COUNT(*) FROM name_aliases WHERE entity_type = ? AND normalized_alias = ?`),
+			entityType, normalized).Scan(&existing); err != nil {
+			return fmt.Errorf("failed to check alias: %w", err)
+		}
+		if existing == 0 {
+			if _, err := tx.Exec(rebindQuery(`INSERT INTO name_aliases (entity_type, entity_id, alias, normalized_alias, created_at)
+				VALUES (?, ?, ?, ?, ?)`), entityType, targetID, sourceName, normalized, time.Now()); err != nil {
+				return fmt.Errorf("failed to keep merged name as alias: %w", err)
+			}
+		}
+	}
+
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM `+entityTable+` WHERE id = ?`), sourceID); err != nil {
+		return fmt.Errorf("failed to delete merge source: %w", err)
+	}
+	return nil
+}
+
+// repointVariants moves the source's variants into the target's family. Families stay one level
+// deep, so when the target is itself a variant they join its parent, and a target that was a
+// variant of the source becomes a root.
+func (r *NameAliasRepository) repointVariants(tx *sql.Tx, sourceID, targetID int64, counts map[string]int64) error {
+	var targetParent sql.NullInt64
+	if err := tx.QueryRow(rebindQuery(`SELECT parent_id FROM movements WHERE id = ?`), targetID).Scan(&targetParent); err != nil {
+		return fmt.Errorf("failed to get merge target parent: %w", err)
+	}
+	if targetParent.Valid && targetParent.Int64 == sourceID {
+		if _, err := tx.Exec(rebindQuery(`UPDATE movements SET parent_id = NULL WHERE id = ?`), targetID); err != nil {
+			return fmt.Errorf("failed to detach merge target: %w", err)
+		}
+		targetParent.Valid = false
+	}
+
+	familyID := targetID
+	if targetParent.Valid {
+		familyID = targetParent.Int64
+	}
+	result, err := tx.Exec(rebindQuery(`UPDATE movements SET parent_id = ? WHERE parent_id = ? AND id <> ?`), familyID, sourceID, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to repoint movement variants: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n > 0 {
+		counts["movements"] += n
+	}
+	return nil
+}
+
+// mergeWODVersions moves results performed on the source's versions onto the target's latest version,
+// drops the source's versions and moves its variants into the target's family the way
+// repointVariants does for movements
+func (r *NameAliasRepository) mergeWODVersions(tx *sql.Tx, sourceID, targetID int64) error {
+	if _, err := tx.Exec(rebindQuery(`UPDATE user_workout_wods SET wod_version_id = `+latestWODVersionID+`
+		WHERE wod_id = ? AND (wod_version_id IS NULL OR wod_version_id IN (SELECT id FROM wod_versions WHERE wod_id = ?))`),
+		targetID, targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to repoint merged wod versions: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_versions WHERE wod_id = ?`), sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged wod versions: %w", err)
+	}
+
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_variants WHERE wod_id = ? AND parent_wod_id = ?`), targetID, sourceID); err != nil {
+		return fmt.Errorf("failed to detach merge target: %w", err)
+	}
+	familyID := targetID
+	var targetParent int64
+	err := tx.QueryRow(rebindQuery(`SELECT parent_wod_id FROM wod_variants WHERE wod_id = ?`), targetID).Scan(&targetParent)
+	if err == nil {
+		familyID = targetParent
+	} else if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get merge target parent: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`UPDATE wod_variants SET parent_wod_id = ? WHERE parent_wod_id = ? AND wod_id <> ?`),
+		familyID, sourceID, familyID); err != nil {
+		return fmt.Errorf("failed to repoint wod variants: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_variants WHERE wod_id = ? OR parent_wod_id = ?`), sourceID, sourceID); err != nil {
+		return fmt.Errorf("failed to delete merged wod variant links: %w", err)
+	}
+	return nil
+}
+
+func (r *NameAliasRepository) dropConflictingProgressions(tx *sql.Tx, sourceID, targetID int64) error {
+	rows, err := tx.Query(rebindQuery(`SELECT s.id FROM program_progressions s
+		JOIN program_progressions t ON t.program_id = s.program_id AND t.movement_id = ?
+		WHERE s.movement_id = ?`), targetID, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to find conflicting progressions: %w", err)
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan progression id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := tx.Exec(rebindQuery(`DELETE FROM program_progressions WHERE id = ?`), id); err != nil {
+			return fmt.Errorf("failed to drop conflicting progression: %w", err)
+		}
+	}
+	return nil
+}
+
+// dropConflictingLibraryItems deletes the source's gym library entries in gyms that already list the target
+func (r *NameAliasRepository) dropConflictingLibraryItems(tx *sql.Tx, entityType string, sourceID, targetID int64) error {
+	rows, err := tx.Query(rebindQuery(`SELECT s.id FROM organization_library s
+		JOIN organization_library t ON t.organization_id = s.organization_id AND t.entity_type = s.entity_type AND t.entity_id = ?
+		WHERE s.entity_type = ? AND s.entity_id = ?`), targetID, entityType, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to find conflicting library items: %w", err)
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan library item id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := tx.Exec(rebindQuery(`DELETE FROM organization_library WHERE id = ?`), id); err != nil {
+			return fmt.Errorf("failed to drop conflicting library item: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *NameAliasRepository) list(query string, args ...interface{}) ([]*domain.NameAlias, error) {
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []*domain.NameAlias{}
+	for rows.Next() {
+		alias, err := scanNameAlias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, rows.Err()
+}
+
+// idList builds an IN clause with one placeholder per ID
+func idList(ids []int64) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + ")", args
+}
+
+func scanNameAlias(row rowScanner) (*domain.NameAlias, error) {
+	alias := &domain.NameAlias{}
+	if err := row.Scan(&alias.ID, &alias.EntityType, &alias.EntityID, &alias.Alias, &alias.NormalizedAlias,
+		&alias.CreatedBy, &alias.CreatedAt); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// OrganizationRepository implements domain.OrganizationRepository
+type OrganizationRepository struct {
+	db *sql.DB
+}
+
+// NewOrganizationRepository creates a new organization (gym) repository
+func NewOrganizationRepository(db *sql.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+const organizationColumns = `o.id, o.name, o.slug, o.description, o.created_by, o.created_at, o.updated_at`
+
+// Create creates a new organization
+func (r *OrganizationRepository) Create(org *domain.Organization) error {
+	now := time.Now()
+	org.CreatedAt = now
+	org.UpdatedAt = now
+
+	id, err := insertReturningID(r.db, `INSERT INTO organizations (name, slug, description, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		org.Name, org.Slug, org.Description, org.CreatedBy, org.CreatedAt, org.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	org.ID = id
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *OrganizationRepository) GetByID(id int64) (*domain.Organization, error) {
+	query := rebindQuery(`SELECT ` + organizationColumns + ` FROM organizations o WHERE o.id = ?`)
+	return r.getOne(query, id)
+}
+
+// GetBySlug retrieves an organization by slug
+func (r *OrganizationRepository) GetBySlug(slug string) (*domain.Organization, error) {
+	query := rebindQuery(`SELECT ` + organizationColumns + ` FROM organizations o WHERE o.slug = ?`)
+	return r.getOne(query, slug)
+}
+
+// List retrieves all organizations
+func (r *OrganizationRepository) List() ([]*domain.Organization, error) {
+	rows, err := r.db.Query(`SELECT ` + organizationColumns + ` FROM organizations o ORDER BY o.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []*domain.Organization
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// ListByUser retrieves the organizations a user belongs to, with the user's role
+func (r *OrganizationRepository) ListByUser(userID int64) ([]*domain.Organization, error) {
+	query := rebindQuery(`SELECT ` + organizationColumns + `, m.role
+		FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = ?
+		ORDER BY o.name`)
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []*domain.Organization
+	for rows.Next() {
+		org := &domain.Organization{}
+		var description sql.NullString
+		var createdBy sql.NullInt64
+
+		if err := rows.Scan(&org.ID, &org.Name, &org.Slug, &description, &createdBy, &org.CreatedAt, &org.UpdatedAt, &org.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		org.Description = description.String
+		if createdBy.Valid {
+			org.CreatedBy = &createdBy.Int64
+		}
+		orgs = append(orgs, org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// Update updates an organization's name, slug and description
+func (r *OrganizationRepository) Update(org *domain.Organization) error {
+	org.UpdatedAt = time.Now()
+
+	query := rebindQuery(`UPDATE organizations SET name = ?, slug = ?, description = ?, updated_at = ? WHERE id = ?`)
+
+	result, err := r.db.Exec(query, org.Name, org.Slug, org.Description, org.UpdatedAt, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("organization not found")
+	}
+
+	return nil
+}
+
+// Delete deletes an organization (memberships and library links cascade)
+func (r *OrganizationRepository) Delete(id int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Delete children explicitly as SQLite only cascades with foreign_keys enabled
+	for _, table := range []string{"scheduled_workouts", "organization_library", "organization_members"} {
+		if _, err := tx.Exec(rebindQuery(`DELETE FROM `+table+` WHERE organization_id = ?`), id); err != nil {
+			return fmt.Errorf("failed to delete from %s: %w", table, err)
+		}
+	}
+
+	result, err := tx.Exec(rebindQuery(`DELETE FROM organizations WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("organization not found")
+	}
+
+	return tx.Commit()
+}
+
+// AddMember adds a user to an organization
+func (r *OrganizationRepository) AddMember(member *domain.OrganizationMember) error {
+	now := time.Now()
+	member.CreatedAt = now
+	member.UpdatedAt = now
+	if member.Role == "" {
+		member.Role = domain.OrganizationRoleMember
+	}
+
+	id, err := insertReturningID(r.db, `INSERT INTO organization_members (organization_id, user_id, role, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		member.OrganizationID, member.UserID, member.Role, member.CreatedAt, member.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+
+	member.ID = id
+	return nil
+}
+
+// GetMember retrieves a user's membership in an organization
+func (r *OrganizationRepository) GetMember(orgID, userID int64) (*domain.OrganizationMember, error) {
+	query := rebindQuery(`SELECT m.id, m.organization_id, m.user_id, m.role, m.created_at, m.updated_at, u.name, u.email
+		FROM organization_members m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.organization_id = ? AND m.user_id = ?`)
+
+	member, err := scanOrganizationMember(r.db.QueryRow(query, orgID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return member, nil
+}
+
+// ListMembers retrieves all members of an organization
+func (r *OrganizationRepository) ListMembers(orgID int64) ([]*domain.OrganizationMember, error) {
+	query := rebindQuery(`SELECT m.id, m.organization_id, m.user_id, m.role, m.created_at, m.updated_at, u.name, u.email
+		FROM organization_members m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.organization_id = ?
+		ORDER BY u.name`)
+
+	rows, err := r.db.Query(query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*domain.OrganizationMember
+	for rows.Next() {
+		member, err := scanOrganizationMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+// UpdateMemberRole changes a member's role
+func (r *OrganizationRepository) UpdateMemberRole(orgID, userID int64, role string) error {
+	query := rebindQuery(`UPDATE organization_members SET role = ?, updated_at = ? WHERE organization_id = ? AND user_id = ?`)
+
+	result, err := r.db.Exec(query, role, time.Now(), orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("organization member not found")
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from an organization
+func (r *OrganizationRepository) RemoveMember(orgID, userID int64) error {
+	query := rebindQuery(`DELETE FROM organization_members WHERE organization_id = ? AND user_id = ?`)
+
+	result, err := r.db.Exec(query, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("organization member not found")
+	}
+
+	return nil
+}
+
+// AddLibraryItem adds a WOD or movement to the gym library
+func (r *OrganizationRepository) AddLibraryItem(orgID int64, entityType string, entityID int64, addedBy int64) error {
+	_, err := insertReturningID(r.db, addLibraryItemQuery, orgID, entityType, entityID, addedBy, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to add library item: %w", err)
+	}
+	return nil
+}
+
+const addLibraryItemQuery = `INSERT INTO organization_library (organization_id, entity_type, entity_id, added_by, created_at)
+	VALUES (?, ?, ?, ?, ?)`
+
+// addLibraryItem adds a WOD or movement to a gym library within a transaction
+func addLibraryItem(tx *sql.Tx, orgID int64, entityType string, entityID int64, addedBy int64) error {
+	if _, err := txInsertReturningID(tx, addLibraryItemQuery, orgID, entityType, entityID, addedBy, time.Now()); err != nil {
+		return fmt.Errorf("failed to add library item: %w", err)
+	}
+	return nil
+}
+
+// RemoveLibraryItem removes a WOD or movement from the gym library
+func (r *OrganizationRepository) RemoveLibraryItem(orgID int64, entityType string, entityID int64) error {
+	query := rebindQuery(`DELETE FROM organization_library WHERE organization_id = ? AND entity_type = ? AND entity_id = ?`)
+
+	result, err := r.db.Exec(query, orgID, entityType, entityID)
+	if err != nil {
+		return fmt.Errorf("failed to remove library item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("library item not found")
+	}
+
+	return nil
+}
+
+// ListLibraryWODs retrieves the WODs curated by the gym
+func (r *OrganizationRepository) ListLibraryWODs(orgID int64) ([]*domain.WOD, error) {
+	query := rebindQuery(`SELECT w.id, w.name, w.source, w.type, w.regime, w.score_type, w.description, w.url, w.notes, w.is_standard, w.created_by, w.created_at, w.updated_at
+		FROM wods w
+		JOIN organization_library l ON l.entity_id = w.id AND l.entity_type = ?
+		WHERE l.organization_id = ?
+		ORDER BY w.name`)
+
+	rows, err := r.db.Query(query, domain.LibraryEntityWOD, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list library wods: %w", err)
+	}
+	defer rows.Close()
+
+	return (&WODRepository{db: r.db}).scanWODs(rows)
+}
+
+// ListLibraryMovements retrieves the movements curated by the gym
+func (r *OrganizationRepository) ListLibraryMovements(orgID int64) ([]*domain.Movement, error) {
+	query := rebindQuery(`SELECT ` + movementColumns + ` FROM movements
+		WHERE id IN (SELECT entity_id FROM organization_library WHERE entity_type = ? AND organization_id = ?)
+		ORDER BY name`)
+
+	rows, err := r.db.Query(query, domain.LibraryEntityMovement, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list library movements: %w", err)
+	}
+	defer rows.Close()
+
+	return (&MovementRepository{db: r.db}).scanMovements(rows)
+}
+
+func (r *OrganizationRepository) getOne(query string, arg interface{}) (*domain.Organization, error) {
+	org, err := scanOrganization(r.db.QueryRow(query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org, nil
+}
+
+func scanOrganization(row rowScanner) (*domain.Organization, error) {
+	org := &domain.Organization{}
+	var description sql.NullString
+	var createdBy sql.NullInt64
+
+	if err := row.Scan(&org.ID, &org.Name, &org.Slug, &description, &createdBy, &org.CreatedAt, &org.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	org.Description = description.String
+	if createdBy.Valid {
+		org.CreatedBy = &createdBy.Int64
+	}
+
+	return org, nil
+}
+
+func scanOrganizationMember(row rowScanner) (*domain.OrganizationMember, error) {
+	member := &domain.OrganizationMember{}
+	err := row.Scan(&member.ID, &member.OrganizationID, &member.UserID, &member.Role, &member.CreatedAt, &member.UpdatedAt,
+		&member.UserName, &member.UserEmail)
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
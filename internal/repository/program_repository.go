@@ -0,0 +1,515 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// ProgramRepository implements domain.ProgramRepository
+type ProgramRepository struct {
+	db *sql.DB
+}
+
+// NewProgramRepository creates a new training program repository
+func NewProgramRepository(db *sql.DB) *ProgramRepository {
+	return &ProgramRepository{db: db}
+}
+
+// Create creates a program with its sessions and progressions
+func (r *ProgramRepository) Create(program *domain.Program) error {
+	now := time.Now()
+	program.CreatedAt = now
+	program.UpdatedAt = now
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := rebindQuery(`INSERT INTO programs (name, description, weeks, created_by, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`)
+	args := []interface{}{program.Name, program.Description, program.Weeks, program.CreatedBy, program.CreatedAt, program.UpdatedAt}
+
+	if currentDriver == "postgres" {
+		err = tx.QueryRow(query+" RETURNING id", args...).Scan(&program.ID)
+	} else {
+		var result sql.Result
+		result, err = tx.Exec(query, args...)
+		if err == nil {
+			program.ID, err = result.LastInsertId()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create program: %w", err)
+	}
+
+	if err := r.insertChildren(tx, program); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a program with its sessions and progressions
+func (r *ProgramRepository) GetByID(id int64) (*domain.Program, error) {
+	query := rebindQuery(`SELECT id, name, description, weeks, created_by, created_at, updated_at FROM programs WHERE id = ?`)
+
+	program, err := scanProgram(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	if program.Sessions, err = r.getSessions(id); err != nil {
+		return nil, err
+	}
+	if program.Progressions, err = r.getProgressions(id); err != nil {
+		return nil, err
+	}
+
+	return program, nil
+}
+
+// ListForUser retrieves standard programs and programs created by the user
+func (r *ProgramRepository) ListForUser(userID int64) ([]*domain.Program, error) {
+	query := rebindQuery(`SELECT id, name, description, weeks, created_by, created_at, updated_at
+		FROM programs
+		WHERE created_by IS NULL OR created_by = ?
+		ORDER BY name`)
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+	defer rows.Close()
+
+	var programs []*domain.Program
+	for rows.Next() {
+		program, err := scanProgram(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan program: %w", err)
+		}
+		programs = append(programs, program)
+	}
+
+	return programs, rows.Err()
+}
+
+// Update updates a program, replacing its sessions and progressions
+// Completions of replaced sessions are removed along with them
+func (r *ProgramRepository) Update(program *domain.Program) error {
+	program.UpdatedAt = time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(rebindQuery(`UPDATE programs SET name = ?, description = ?, weeks = ?, updated_at = ? WHERE id = ?`),
+		program.Name, program.Description, program.Weeks, program.UpdatedAt, program.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update program: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("program not found")
+	}
+
+	if err := updateProgramSessions(tx, program); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM program_progressions WHERE program_id = ?`), program.ID); err != nil {
+		return fmt.Errorf("failed to delete program progressions: %w", err)
+	}
+	if err := r.insertChildren(tx, &domain.Program{ID: program.ID, Progressions: program.Progressions}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// updateProgramSessions matches the new sessions to the existing ones by week and day and updates them in place,
+// so enrollments keep their completions; unmatched sessions are inserted or deleted (with their completions)
+func updateProgramSessions(tx *sql.Tx, program *domain.Program) error {
+	rows, err := tx.Query(rebindQuery(`SELECT id, week, day FROM program_sessions WHERE program_id = ? ORDER BY week, day, id`), program.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get program sessions: %w", err)
+	}
+	existing := map[[2]int][]int64{}
+	for rows.Next() {
+		var id int64
+		var week, day int
+		if err := rows.Scan(&id, &week, &day); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan program session: %w", err)
+		}
+		existing[[2]int{week, day}] = append(existing[[2]int{week, day}], id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to get program sessions: %w", err)
+	}
+
+	updateQuery := rebindQuery(`UPDATE program_sessions SET workout_id = ?, notes = ? WHERE id = ?`)
+	insertQuery := rebindQuery(`INSERT INTO program_sessions (program_id, week, day, workout_id, notes) VALUES (?, ?, ?, ?, ?)`)
+	for _, s := range program.Sessions {
+		s.ProgramID = program.ID
+		key := [2]int{s.Week, s.Day}
+		if ids := existing[key]; len(ids) > 0 {
+			s.ID = ids[0]
+			existing[key] = ids[1:]
+			if _, err := tx.Exec(updateQuery, s.WorkoutID, s.Notes, s.ID); err != nil {
+				return fmt.Errorf("failed to update program session: %w", err)
+			}
+			continue
+		}
+		if _, err := tx.Exec(insertQuery, s.ProgramID, s.Week, s.Day, s.WorkoutID, s.Notes); err != nil {
+			return fmt.Errorf("failed to create program session: %w", err)
+		}
+	}
+
+	// Children are deleted explicitly as SQLite only cascades with foreign_keys enabled
+	for _, ids := range existing {
+		for _, id := range ids {
+			if _, err := tx.Exec(rebindQuery(`DELETE FROM program_session_completions WHERE session_id = ?`), id); err != nil {
+				return fmt.Errorf("failed to delete program session completions: %w", err)
+			}
+			if _, err := tx.Exec(rebindQuery(`DELETE FROM program_sessions WHERE id = ?`), id); err != nil {
+				return fmt.Errorf("failed to delete program session: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Delete deletes a program and its sessions, progressions and enrollments
+func (r *ProgramRepository) Delete(id int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := deleteProgramChildren(tx, id); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(rebindQuery(`DELETE FROM programs WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete program: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("program not found")
+	}
+
+	return tx.Commit()
+}
+
+// CreateEnrollment enrolls a user in a program
+func (r *ProgramRepository) CreateEnrollment(enrollment *domain.ProgramEnrollment) error {
+	now := time.Now()
+	enrollment.CreatedAt = now
+	enrollment.UpdatedAt = now
+	if enrollment.Status == "" {
+		enrollment.Status = domain.EnrollmentStatusActive
+	}
+
+	id, err := insertReturningID(r.db, `INSERT INTO program_enrollments (program_id, user_id, start_date, status, completed_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		enrollment.ProgramID, enrollment.UserID, enrollment.StartDate, enrollment.Status, enrollment.CompletedAt, enrollment.CreatedAt, enrollment.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create enrollment: %w", err)
+	}
+
+	enrollment.ID = id
+	return nil
+}
+
+// GetEnrollment retrieves an enrollment by ID
+func (r *ProgramRepository) GetEnrollment(id int64) (*domain.ProgramEnrollment, error) {
+	query := rebindQuery(`SELECT e.id, e.program_id, e.user_id, e.start_date, e.status, e.completed_at, e.created_at, e.updated_at, p.name
+		FROM program_enrollments e
+		JOIN programs p ON e.program_id = p.id
+		WHERE e.id = ?`)
+
+	enrollment, err := scanEnrollment(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get enrollment: %w", err)
+	}
+	return enrollment, nil
+}
+
+// ListEnrollmentsByUser retrieves a user's enrollments (empty status = all)
+func (r *ProgramRepository) ListEnrollmentsByUser(userID int64, status string) ([]*domain.ProgramEnrollment, error) {
+	query := `SELECT e.id, e.program_id, e.user_id, e.start_date, e.status, e.completed_at, e.created_at, e.updated_at, p.name
+		FROM program_enrollments e
+		JOIN programs p ON e.program_id = p.id
+		WHERE e.user_id = ?`
+	args := []interface{}{userID}
+	if status != "" {
+		query += ` AND e.status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY e.start_date DESC, e.id DESC`
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	var enrollments []*domain.ProgramEnrollment
+	for rows.Next() {
+		enrollment, err := scanEnrollment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan enrollment: %w", err)
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+
+	return enrollments, rows.Err()
+}
+
+// UpdateEnrollment updates an enrollment's status
+func (r *ProgramRepository) UpdateEnrollment(enrollment *domain.ProgramEnrollment) error {
+	enrollment.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(rebindQuery(`UPDATE program_enrollments SET status = ?, completed_at = ?, updated_at = ? WHERE id = ?`),
+		enrollment.Status, enrollment.CompletedAt, enrollment.UpdatedAt, enrollment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update enrollment: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("enrollment not found")
+	}
+
+	return nil
+}
+
+// CreateCompletion marks a session as completed for an enrollment
+func (r *ProgramRepository) CreateCompletion(completion *domain.ProgramSessionCompletion) error {
+	if completion.CompletedAt.IsZero() {
+		completion.CompletedAt = time.Now()
+	}
+
+	id, err := insertReturningID(r.db, `INSERT INTO program_session_completions (enrollment_id, session_id, user_workout_id, completed_at)
+		VALUES (?, ?, ?, ?)`,
+		completion.EnrollmentID, completion.SessionID, completion.UserWorkoutID, completion.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record session completion: %w", err)
+	}
+
+	completion.ID = id
+	return nil
+}
+
+// ListCompletions retrieves the completed sessions of an enrollment
+func (r *ProgramRepository) ListCompletions(enrollmentID int64) ([]*domain.ProgramSessionCompletion, error) {
+	query := rebindQuery(`SELECT id, enrollment_id, session_id, user_workout_id, completed_at
+		FROM program_session_completions
+		WHERE enrollment_id = ?
+		ORDER BY completed_at`)
+
+	rows, err := r.db.Query(query, enrollmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session completions: %w", err)
+	}
+	defer rows.Close()
+
+	var completions []*domain.ProgramSessionCompletion
+	for rows.Next() {
+		c := &domain.ProgramSessionCompletion{}
+		var userWorkoutID sql.NullInt64
+		if err := rows.Scan(&c.ID, &c.EnrollmentID, &c.SessionID, &userWorkoutID, &c.CompletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session completion: %w", err)
+		}
+		if userWorkoutID.Valid {
+			c.UserWorkoutID = &userWorkoutID.Int64
+		}
+		completions = append(completions, c)
+	}
+
+	return completions, rows.Err()
+}
+
+func (r *ProgramRepository) insertChildren(tx *sql.Tx, program *domain.Program) error {
+	sessionQuery := rebindQuery(`INSERT INTO program_sessions (program_id, week, day, workout_id, notes) VALUES (?, ?, ?, ?, ?)`)
+	for _, s := range program.Sessions {
+		s.ProgramID = program.ID
+		if _, err := tx.Exec(sessionQuery, s.ProgramID, s.Week, s.Day, s.WorkoutID, s.Notes); err != nil {
+			return fmt.Errorf("failed to create program session: %w", err)
+		}
+	}
+
+	progressionQuery := rebindQuery(`INSERT INTO program_progressions (program_id, movement_id, rule_type, base_weight, increment, percentages, round_to)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	for _, p := range program.Progressions {
+		p.ProgramID = program.ID
+		if _, err := tx.Exec(progressionQuery, p.ProgramID, p.MovementID, p.RuleType, p.BaseWeight, p.Increment,
+			formatPercentages(p.Percentages), p.RoundTo); err != nil {
+			return fmt.Errorf("failed to create program progression: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteProgramChildren removes sessions (with their completions), progressions and enrollments
+// Children are deleted explicitly as SQLite only cascades with foreign_keys enabled
+func deleteProgramChildren(tx *sql.Tx, programID int64) error {
+	statements := []string{
+		`DELETE FROM program_session_completions WHERE session_id IN (SELECT id FROM program_sessions WHERE program_id = ?)`,
+		`DELETE FROM program_sessions WHERE program_id = ?`,
+		`DELETE FROM program_progressions WHERE program_id = ?`,
+		`DELETE FROM program_enrollments WHERE program_id = ?`,
+	}
+
+	for _, stmt := range statements {
+		if _, err := tx.Exec(rebindQuery(stmt), programID); err != nil {
+			return fmt.Errorf("failed to delete program data: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *ProgramRepository) getSessions(programID int64) ([]*domain.ProgramSession, error) {
+	query := rebindQuery(`SELECT ps.id, ps.program_id, ps.week, ps.day, ps.workout_id, ps.notes, w.name
+		FROM program_sessions ps
+		JOIN workouts w ON ps.workout_id = w.id
+		WHERE ps.program_id = ?
+		ORDER BY ps.week, ps.day, ps.id`)
+
+	rows, err := r.db.Query(query, programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*domain.ProgramSession
+	for rows.Next() {
+		s := &domain.ProgramSession{}
+		var notes sql.NullString
+		if err := rows.Scan(&s.ID, &s.ProgramID, &s.Week, &s.Day, &s.WorkoutID, &notes, &s.WorkoutName); err != nil {
+			return nil, fmt.Errorf("failed to scan program session: %w", err)
+		}
+		if notes.Valid {
+			s.Notes = &notes.String
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (r *ProgramRepository) getProgressions(programID int64) ([]*domain.ProgramProgression, error) {
+	query := rebindQuery(`SELECT pp.id, pp.program_id, pp.movement_id, pp.rule_type, pp.base_weight, pp.increment, pp.percentages, pp.round_to, m.name
+		FROM program_progressions pp
+		JOIN movements m ON pp.movement_id = m.id
+		WHERE pp.program_id = ?
+		ORDER BY m.name`)
+
+	rows, err := r.db.Query(query, programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program progressions: %w", err)
+	}
+	defer rows.Close()
+
+	var progressions []*domain.ProgramProgression
+	for rows.Next() {
+		p := &domain.ProgramProgression{}
+		var baseWeight, increment sql.NullFloat64
+		var percentages sql.NullString
+		if err := rows.Scan(&p.ID, &p.ProgramID, &p.MovementID, &p.RuleType, &baseWeight, &increment, &percentages, &p.RoundTo, &p.MovementName); err != nil {
+			return nil, fmt.Errorf("failed to scan program progression: %w", err)
+		}
+		if baseWeight.Valid {
+			p.BaseWeight = &baseWeight.Float64
+		}
+		if increment.Valid {
+			p.Increment = &increment.Float64
+		}
+		p.Percentages = parsePercentages(percentages.String)
+		progressions = append(progressions, p)
+	}
+
+	return progressions, rows.Err()
+}
+
+func scanProgram(row rowScanner) (*domain.Program, error) {
+	program := &domain.Program{}
+	var description sql.NullString
+	var createdBy sql.NullInt64
+
+	if err := row.Scan(&program.ID, &program.Name, &description, &program.Weeks, &createdBy, &program.CreatedAt, &program.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if description.Valid {
+		program.Description = &description.String
+	}
+	if createdBy.Valid {
+		program.CreatedBy = &createdBy.Int64
+	}
+
+	return program, nil
+}
+
+func scanEnrollment(row rowScanner) (*domain.ProgramEnrollment, error) {
+	e := &domain.ProgramEnrollment{}
+	var completedAt sql.NullTime
+
+	if err := row.Scan(&e.ID, &e.ProgramID, &e.UserID, &e.StartDate, &e.Status, &completedAt, &e.CreatedAt, &e.UpdatedAt, &e.ProgramName); err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		e.CompletedAt = &completedAt.Time
+	}
+
+	return e, nil
+}
+
+// formatPercentages stores weekly percentages as a comma-separated list (e.g., "0.65,0.7,0.75")
+func formatPercentages(percentages []float64) interface{} {
+	if len(percentages) == 0 {
+		return nil
+	}
+	parts := make([]string, len(percentages))
+	for i, p := range percentages {
+		parts[i] = strconv.FormatFloat(p, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+func parsePercentages(value string) []float64 {
+	if value == "" {
+		return nil
+	}
+	var percentages []float64
+	for _, part := range strings.Split(value, ",") {
+		if p, err := strconv.ParseFloat(strings.TrimSpace(part), 64); err == nil {
+			percentages = append(percentages, p)
+		}
+	}
+	return percentages
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// ScheduledWorkoutRepository implements domain.ScheduledWorkoutRepository
+type ScheduledWorkoutRepository struct {
+	db *sql.DB
+}
+
+// NewScheduledWorkoutRepository creates a new programming calendar repository
+func NewScheduledWorkoutRepository(db *sql.DB) *ScheduledWorkoutRepository {
+	return &ScheduledWorkoutRepository{db: db}
+}
+
+const scheduledWorkoutSelect = `
+	SELECT sw.id, sw.organization_id, sw.scheduled_date, sw.track, sw.workout_id, sw.wod_id, sw.title, sw.notes,
+	       sw.created_by, sw.created_at, sw.updated_at,
+	       w.name, wd.name
+	FROM scheduled_workouts sw
+	LEFT JOIN workouts w ON sw.workout_id = w.id
+	LEFT JOIN wods wd ON sw.wod_id = wd.id`
+
+// Create creates a new scheduled workout
+func (r *ScheduledWorkoutRepository) Create(sw *domain.ScheduledWorkout) error {
+	now := time.Now()
+	sw.CreatedAt = now
+	sw.UpdatedAt = now
+
+	id, err := insertReturningID(r.db, `INSERT INTO scheduled_workouts (organization_id, scheduled_date, track, workout_id, wod_id, title, notes, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		sw.OrganizationID, sw.ScheduledDate, sw.Track, sw.WorkoutID, sw.WODID, sw.Title, sw.Notes, sw.CreatedBy, sw.CreatedAt, sw.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled workout: %w", err)
+	}
+
+	sw.ID = id
+	return nil
+}
+
+// GetByID retrieves a scheduled workout by ID
+func (r *ScheduledWorkoutRepository) GetByID(id int64) (*domain.ScheduledWorkout, error) {
+	query := rebindQuery(scheduledWorkoutSelect + ` WHERE sw.id = ?`)
+
+	sw, err := scanScheduledWorkout(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get scheduled workout: %w", err)
+	}
+	return sw, nil
+}
+
+// ListByOrganization retrieves a gym's scheduled workouts between two dates inclusive (empty track = all tracks)
+func (r *ScheduledWorkoutRepository) ListByOrganization(orgID int64, startDate, endDate string, track string) ([]*domain.ScheduledWorkout, error) {
+	query := scheduledWorkoutSelect + ` WHERE sw.organization_id = ? AND sw.scheduled_date >= ? AND sw.scheduled_date <= ?`
+	args := []interface{}{orgID, startDate, endDate}
+	if track != "" {
+		query += ` AND sw.track = ?`
+		args = append(args, track)
+	}
+	query += ` ORDER BY sw.scheduled_date, sw.track, sw.id`
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled workouts: %w", err)
+	}
+	defer rows.Close()
+
+	var scheduled []*domain.ScheduledWorkout
+	for rows.Next() {
+		sw, err := scanScheduledWorkout(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled workout: %w", err)
+		}
+		scheduled = append(scheduled, sw)
+	}
+
+	return scheduled, rows.Err()
+}
+
+// Update updates a scheduled workout
+func (r *ScheduledWorkoutRepository) Update(sw *domain.ScheduledWorkout) error {
+	sw.UpdatedAt = time.Now()
+
+	query := rebindQuery(`UPDATE scheduled_workouts
+		SET scheduled_date = ?, track = ?, workout_id = ?, wod_id = ?, title = ?, notes = ?, updated_at = ?
+		WHERE id = ?`)
+
+	result, err := r.db.Exec(query, sw.ScheduledDate, sw.Track, sw.WorkoutID, sw.WODID, sw.Title, sw.Notes, sw.UpdatedAt, sw.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled workout: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("scheduled workout not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a scheduled workout
+func (r *ScheduledWorkoutRepository) Delete(id int64) error {
+	result, err := r.db.Exec(rebindQuery(`DELETE FROM scheduled_workouts WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete scheduled workout: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("scheduled workout not found")
+	}
+
+	return nil
+}
+
+func scanScheduledWorkout(row rowScanner) (*domain.ScheduledWorkout, error) {
+	sw := &domain.ScheduledWorkout{}
+	var workoutID, wodID, createdBy sql.NullInt64
+	var title, notes, workoutName, wodName sql.NullString
+
+	err := row.Scan(&sw.ID, &sw.OrganizationID, &sw.ScheduledDate, &sw.Track, &workoutID, &wodID, &title, &notes,
+		&createdBy, &sw.CreatedAt, &sw.UpdatedAt,
+		&workoutName, &wodName)
+	if err != nil {
+		return nil, err
+	}
+
+	if workoutID.Valid {
+		sw.WorkoutID = &workoutID.Int64
+	}
+	if wodID.Valid {
+		sw.WODID = &wodID.Int64
+	}
+	if createdBy.Valid {
+		sw.CreatedBy = &createdBy.Int64
+	}
+	if notes.Valid {
+		sw.Notes = &notes.String
+	}
+	sw.Title = title.String
+	sw.WorkoutName = workoutName.String
+	sw.WODName = wodName.String
+
+	return sw, nil
+}
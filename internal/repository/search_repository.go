@@ -0,0 +1,473 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// maxLikeSearchCandidates bounds the rows ranked in Go by the LIKE fallback
+const maxLikeSearchCandidates = 500
+
+// snippetRadius is the number of characters kept on each side of the first matched term
+const snippetRadius = 60
+
+// SearchRepository implements domain.SearchRepository over the search_documents table,
+// using FTS5, tsvector or FULLTEXT depending on the database driver
+type SearchRepository struct {
+	db      *sql.DB
+	backend string
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{
+		db:      db,
+		backend: detectSearchBackend(db),
+	}
+}
+
+// detectSearchBackend picks the full-text implementation for the current driver.
+// mattn/go-sqlite3 only includes FTS5 when built with the sqlite_fts5 tag.
+func detectSearchBackend(db *sql.DB) string {
+	switch currentDriver {
+	case "postgres":
+		return domain.SearchBackendTSVector
+	case "mysql":
+		return domain.SearchBackendFulltext
+	}
+
+	if !sqliteHasFTS5(db) {
+		return domain.SearchBackendLike
+	}
+	return domain.SearchBackendFTS5
+}
+
+// sqliteHasFTS5 reports whether the linked SQLite library was compiled with FTS5
+func sqliteHasFTS5(db *sql.DB) bool {
+	var enabled int
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		return false
+	}
+	return enabled == 1
+}
+
+// createSearchFTS creates the SQLite FTS5 table mirroring search_documents (rowid = search_documents.id).
+// The table is maintained by the repository rather than triggers so a binary built without FTS5
+// can still write documents.
+func createSearchFTS(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS search_fts USING fts5(title, body, tokenize = 'porter unicode61')`); err != nil {
+		return fmt.Errorf("failed to create search_fts table: %w", err)
+	}
+	return nil
+}
+
+// searchSource reads the documents of one entity type from its source table
+type searchSource struct {
+	query    string // Selects the columns read by scan
+	idColumn string // Used to refresh a single record
+	scan     func(row rowScanner) (*domain.SearchDocument, error)
+}
+
+var searchSources = map[string]searchSource{
+	domain.SearchEntityMovement: {
+		query:    `SELECT id, name, COALESCE(description, ''), is_standard, created_by, updated_at FROM movements`,
+		idColumn: "id",
+		scan: func(row rowScanner) (*domain.SearchDocument, error) {
+			doc := &domain.SearchDocument{EntityType: domain.SearchEntityMovement}
+			var isStandard bool
+			var createdBy sql.NullInt64
+			if err := row.Scan(&doc.EntityID, &doc.Title, &doc.Body, &isStandard, &createdBy, &doc.UpdatedAt); err != nil {
+				return nil, err
+			}
+			if !isStandard && createdBy.Valid {
+				doc.OwnerID = &createdBy.Int64
+			}
+			return doc, nil
+		},
+	},
+	domain.SearchEntityWOD: {
+		query:    `SELECT id, name, COALESCE(description, ''), COALESCE(notes, ''), is_standard, created_by, updated_at FROM wods`,
+		idColumn: "id",
+		scan: func(row rowScanner) (*domain.SearchDocument, error) {
+			doc := &domain.SearchDocument{EntityType: domain.SearchEntityWOD}
+			var notes string
+			var isStandard bool
+			var createdBy sql.NullInt64
+			if err := row.Scan(&doc.EntityID, &doc.Title, &doc.Body, &notes, &isStandard, &createdBy, &doc.UpdatedAt); err != nil {
+				return nil, err
+			}
+			doc.Body = strings.TrimSpace(doc.Body + "\n" + notes)
+			if !isStandard && createdBy.Valid {
+				doc.OwnerID = &createdBy.Int64
+			}
+			return doc, nil
+		},
+	},
+	domain.SearchEntityTemplate: {
+		query:    `SELECT id, name, COALESCE(notes, ''), created_by, updated_at FROM workouts`,
+		idColumn: "id",
+		scan: func(row rowScanner) (*domain.SearchDocument, error) {
+			doc := &domain.SearchDocument{EntityType: domain.SearchEntityTemplate}
+			var ownerID sql.NullInt64
+			if err := row.Scan(&doc.EntityID, &doc.Title, &doc.Body, &ownerID, &doc.UpdatedAt); err != nil {
+				return nil, err
+			}
+			if ownerID.Valid {
+				doc.OwnerID = &ownerID.Int64
+			}
+			return doc, nil
+		},
+	},
+	domain.SearchEntityWorkout: {
+		query: `SELECT uw.id, COALESCE(uw.workout_name, w.name, ''), COALESCE(uw.notes, ''), uw.user_id, uw.workout_date, uw.updated_at
+			FROM user_workouts uw
+			LEFT JOIN workouts w ON uw.workout_id = w.id`,
+		idColumn: "uw.id",
+		scan: func(row rowScanner) (*domain.SearchDocument, error) {
+			doc := &domain.SearchDocument{EntityType: domain.SearchEntityWorkout}
+			var userID int64
+			var workoutDate time.Time
+			if err := row.Scan(&doc.EntityID, &doc.Title, &doc.Body, &userID, &workoutDate, &doc.UpdatedAt); err != nil {
+				return nil, err
+			}
+			doc.OwnerID = &userID
+			if doc.Title == "" {
+				doc.Title = "Workout on " + workoutDate.Format(domain.ScheduledDateFormat)
+			}
+			return doc, nil
+		},
+	},
+}
+
+// Backend names the full-text implementation in use
+func (r *SearchRepository) Backend() string {
+	return r.backend
+}
+
+// Search returns documents matching every term, visible to the user and of the given types, best match first
+func (r *SearchRepository) Search(userID int64, terms []string, entityTypes []string, limit int) ([]*domain.SearchResult, error) {
+	if len(terms) == 0 {
+		return []*domain.SearchResult{}, nil
+	}
+
+	filter := ` AND (d.owner_id IS NULL OR d.owner_id = ?)`
+	filterArgs := []interface{}{userID}
+	if len(entityTypes) > 0 {
+		filter += ` AND d.entity_type IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(entityTypes)), ", ") + `)`
+		for _, entityType := range entityTypes {
+			filterArgs = append(filterArgs, entityType)
+		}
+	}
+
+	var query string
+	var args []interface{}
+	switch r.backend {
+	case domain.SearchBackendFTS5:
+		match := make([]string, len(terms))
+		for i, term := range terms {
+			match[i] = `"` + term + `"*`
+		}
+		query = `SELECT d.entity_type, d.entity_id, d.title, d.body, -bm25(search_fts, 5.0, 1.0)
+			FROM search_fts
+			JOIN search_documents d ON d.id = search_fts.rowid
+			WHERE search_fts MATCH ?` + filter + `
+			ORDER BY bm25(search_fts, 5.0, 1.0), d.title
+			LIMIT ?`
+		args = append(append([]interface{}{strings.Join(match, " ")}, filterArgs...), limit)
+	case domain.SearchBackendTSVector:
+		match := make([]string, len(terms))
+		for i, term := range terms {
+			match[i] = term + ":*"
+		}
+		tsquery := strings.Join(match, " & ")
+		query = `SELECT d.entity_type, d.entity_id, d.title, d.body, ts_rank(d.search_vector, to_tsquery('english', ?)) AS score
+			FROM search_documents d
+			WHERE d.search_vector @@ to_tsquery('english', ?)` + filter + `
+			ORDER BY score DESC, d.title
+			LIMIT ?`
+		args = append(append([]interface{}{tsquery, tsquery}, filterArgs...), limit)
+	case domain.SearchBackendFulltext:
+		match := make([]string, len(terms))
+		for i, term := range terms {
+			match[i] = "+" + term + "*"
+		}
+		against := strings.Join(match, " ")
+		query = `SELECT d.entity_type, d.entity_id, d.title, d.body,
+			       MATCH(d.title) AGAINST (? IN BOOLEAN MODE) * 2 + MATCH(d.title, d.body) AGAINST (? IN BOOLEAN MODE) AS score
+			FROM search_documents d
+			WHERE MATCH(d.title, d.body) AGAINST (? IN BOOLEAN MODE)` + filter + `
+			ORDER BY score DESC, d.title
+			LIMIT ?`
+		args = append(append([]interface{}{against, against, against}, filterArgs...), limit)
+	default:
+		return r.searchLike(terms, filter, filterArgs, limit)
+	}
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*domain.SearchResult{}
+	for rows.Next() {
+		result := &domain.SearchResult{}
+		var body string
+		if err := rows.Scan(&result.EntityType, &result.EntityID, &result.Title, &body, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		result.Snippet = searchSnippet(body, terms)
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// searchLike matches every term with LIKE and ranks title matches above body matches
+func (r *SearchRepository) searchLike(terms []string, filter string, filterArgs []interface{}, limit int) ([]*domain.SearchResult, error) {
+	query := `SELECT d.entity_type, d.entity_id, d.title, d.body FROM search_documents d WHERE 1 = 1`
+	var args []interface{}
+	for _, term := range terms {
+		query += ` AND (LOWER(d.title) LIKE ? OR LOWER(d.body) LIKE ?)`
+		args = append(args, "%"+term+"%", "%"+term+"%")
+	}
+	query += filter + ` LIMIT ?`
+	args = append(append(args, filterArgs...), maxLikeSearchCandidates)
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*domain.SearchResult{}
+	for rows.Next() {
+		result := &domain.SearchResult{}
+		var body string
+		if err := rows.Scan(&result.EntityType, &result.EntityID, &result.Title, &body); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		title, lowerBody := strings.ToLower(result.Title), strings.ToLower(body)
+		for _, term := range terms {
+			switch {
+			case strings.HasPrefix(title, term) || strings.Contains(title, " "+term):
+				result.Score += 3
+			case strings.Contains(title, term):
+				result.Score += 2
+			}
+			result.Score += float64(strings.Count(lowerBody, term)) / 4
+		}
+		result.Snippet = searchSnippet(body, terms)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Title < results[j].Title
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// searchSnippet returns the part of the body around the first matched term, or its start
+func searchSnippet(body string, terms []string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if body == "" {
+		return ""
+	}
+
+	lower := strings.ToLower(body)
+	at := -1
+	for _, term := range terms {
+		if i := strings.Index(lower, term); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	if at < 0 {
+		at = 0
+	}
+
+	start := max(at-snippetRadius, 0)
+	end := min(at+snippetRadius, len(body))
+	for start > 0 && !utf8.RuneStart(body[start]) {
+		start--
+	}
+	for end < len(body) && !utf8.RuneStart(body[end]) {
+		end++
+	}
+
+	snippet := body[start:end]
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(body) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// Refresh re-reads one record from its source table and updates its document;
+// the document is removed when the record no longer exists
+func (r *SearchRepository) Refresh(entityType string, entityID int64) error {
+	source, ok := searchSources[entityType]
+	if !ok {
+		return fmt.Errorf("unsupported search entity type: %s", entityType)
+	}
+
+	doc, err := source.scan(r.db.QueryRow(rebindQuery(source.query+` WHERE `+source.idColumn+` = ?`), entityID))
+	if err == sql.ErrNoRows {
+		doc = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read %s %d for search: %w", entityType, entityID, err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if r.backend == domain.SearchBackendFTS5 {
+		if _, err := tx.Exec(`DELETE FROM search_fts WHERE rowid IN
+			(SELECT id FROM search_documents WHERE entity_type = ? AND entity_id = ?)`, entityType, entityID); err != nil {
+			return fmt.Errorf("failed to remove search index entry: %w", err)
+		}
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM search_documents WHERE entity_type = ? AND entity_id = ?`), entityType, entityID); err != nil {
+		return fmt.Errorf("failed to remove search document: %w", err)
+	}
+	if doc != nil {
+		if err := r.insertDocument(tx, doc); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Rebuild replaces every document from the source tables and returns the number indexed
+func (r *SearchRepository) Rebuild() (int, error) {
+	if r.backend == domain.SearchBackendFTS5 {
+		if err := createSearchFTS(r.db); err != nil {
+			return 0, err
+		}
+	}
+
+	// Read everything before writing so no cursor is open on the connection doing the writes
+	var docs []*domain.SearchDocument
+	for _, entityType := range domain.SearchEntityTypes {
+		source := searchSources[entityType]
+		rows, err := r.db.Query(source.query)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read %s records for search: %w", entityType, err)
+		}
+		for rows.Next() {
+			doc, err := source.scan(rows)
+			if err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("failed to scan %s record for search: %w", entityType, err)
+			}
+			docs = append(docs, doc)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if r.backend == domain.SearchBackendFTS5 {
+		if _, err := tx.Exec(`DELETE FROM search_fts`); err != nil {
+			return 0, fmt.Errorf("failed to clear search index: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM search_documents`); err != nil {
+		return 0, fmt.Errorf("failed to clear search documents: %w", err)
+	}
+
+	indexed := 0
+	for _, doc := range docs {
+		if err := r.insertDocument(tx, doc); err != nil {
+			return 0, err
+		}
+		if doc.Title != "" || doc.Body != "" {
+			indexed++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit search index: %w", err)
+	}
+	return indexed, nil
+}
+
+// insertDocument stores a document and, for FTS5, its index entry; empty documents are skipped
+func (r *SearchRepository) insertDocument(tx *sql.Tx, doc *domain.SearchDocument) error {
+	if doc.Title == "" && doc.Body == "" {
+		return nil
+	}
+
+	result, err := tx.Exec(rebindQuery(`INSERT INTO search_documents (entity_type, entity_id, owner_id, title, body, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`), doc.EntityType, doc.EntityID, doc.OwnerID, doc.Title, doc.Body, doc.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store search document: %w", err)
+	}
+
+	if r.backend == domain.SearchBackendFTS5 {
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get search document ID: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO search_fts (rowid, title, body) VALUES (?, ?, ?)`, id, doc.Title, doc.Body); err != nil {
+			return fmt.Errorf("failed to index search document: %w", err)
+		}
+	}
+	return nil
+}
+
+// NeedsRebuild reports whether the index is empty or out of step with the documents,
+// e.g. after the binary was first run without FTS5
+func (r *SearchRepository) NeedsRebuild() (bool, error) {
+	var documents int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM search_documents`).Scan(&documents); err != nil {
+		return false, fmt.Errorf("failed to count search documents: %w", err)
+	}
+	if documents == 0 {
+		return true, nil
+	}
+	if r.backend != domain.SearchBackendFTS5 {
+		return false, nil
+	}
+
+	exists, err := checkTableExists(r.db, currentDriver, "search_fts")
+	if err != nil {
+		return false, fmt.Errorf("failed to check for search_fts table: %w", err)
+	}
+	if !exists {
+		return true, nil
+	}
+	var indexed int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM search_fts`).Scan(&indexed); err != nil {
+		return false, fmt.Errorf("failed to count search index entries: %w", err)
+	}
+	return indexed != documents, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// SeedLibraryRepository implements domain.SeedLibraryRepository
+type SeedLibraryRepository struct {
+	db *sql.DB
+}
+
+// NewSeedLibraryRepository creates a new seed library repository
+func NewSeedLibraryRepository(db *sql.DB) *SeedLibraryRepository {
+	return &SeedLibraryRepository{db: db}
+}
+
+// ListEntries lists every tracked entry
+func (r *SeedLibraryRepository) ListEntries() ([]*domain.SeedLibraryEntry, error) {
+	rows, err := r.db.Query(`SELECT id, entity_type, seed_key, entity_id, applied_hash, bundle_version, applied_at
+		FROM seed_library_entries ORDER BY entity_type, seed_key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seed library entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.SeedLibraryEntry{}
+	for rows.Next() {
+		entry := &domain.SeedLibraryEntry{}
+		if err := rows.Scan(&entry.ID, &entry.EntityType, &entry.SeedKey, &entry.EntityID, &entry.AppliedHash,
+			&entry.BundleVersion, &entry.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan seed library entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// SaveEntry creates or replaces the entry of an entity type and seed key
+func (r *SeedLibraryRepository) SaveEntry(entry *domain.SeedLibraryEntry) error {
+	if entry.AppliedAt.IsZero() {
+		entry.AppliedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM seed_library_entries WHERE entity_type = ? AND seed_key = ?`),
+		entry.EntityType, entry.SeedKey); err != nil {
+		return fmt.Errorf("failed to replace seed library entry: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`INSERT INTO seed_library_entries (entity_type, seed_key, entity_id, applied_hash, bundle_version, applied_at)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		entry.EntityType, entry.SeedKey, entry.EntityID, entry.AppliedHash, entry.BundleVersion, entry.AppliedAt); err != nil {
+		return fmt.Errorf("failed to save seed library entry: %w", err)
+	}
+	return tx.Commit()
+}
+
+// DeleteEntry stops tracking an entity type and seed key
+func (r *SeedLibraryRepository) DeleteEntry(entityType, seedKey string) error {
+	if _, err := r.db.Exec(rebindQuery(`DELETE FROM seed_library_entries WHERE entity_type = ? AND seed_key = ?`),
+		entityType, seedKey); err != nil {
+		return fmt.Errorf("failed to delete seed library entry: %w", err)
+	}
+	return nil
+}
@@ -68,7 +68,8 @@ func (r *SQLiteUserRepository) GetByID(id int64) (*domain.User, error) {
 		SELECT id, email, password_hash, name, profile_image, role,
 		       created_at, updated_at, last_login_at, email_verified, email_verified_at,
 		       failed_login_attempts, locked_at, locked_until,
-		       account_disabled, disabled_at, disabled_by_user_id, disable_reason
+		       account_disabled, disabled_at, disabled_by_user_id, disable_reason,
+		       birthday, gender
 		FROM users
 		WHERE id = ?
 	`)
@@ -77,6 +78,8 @@ func (r *SQLiteUserRepository) GetByID(id int64) (*domain.User, error) {
 	var lastLoginAt, emailVerifiedAt, lockedAt, lockedUntil, disabledAt sql.NullTime
 	var disabledByUserID sql.NullInt64
 	var disableReason sql.NullString
+	var birthday sql.NullTime
+	var gender sql.NullString
 
 	err := r.db.QueryRow(query, id).Scan(
 		&user.ID,
@@ -97,6 +100,8 @@ func (r *SQLiteUserRepository) GetByID(id int64) (*domain.User, error) {
 		&disabledAt,
 		&disabledByUserID,
 		&disableReason,
+		&birthday,
+		&gender,
 	)
 
 	if err != nil {
@@ -128,6 +133,12 @@ func (r *SQLiteUserRepository) GetByID(id int64) (*domain.User, error) {
 	if disableReason.Valid {
 		user.DisableReason = &disableReason.String
 	}
+	if birthday.Valid {
+		user.Birthday = &birthday.Time
+	}
+	if gender.Valid {
+		user.Gender = &gender.String
+	}
 
 	return user, nil
 }
@@ -138,7 +149,8 @@ func (r *SQLiteUserRepository) GetByEmail(email string) (*domain.User, error) {
 		SELECT id, email, password_hash, name, profile_image, role,
 		       created_at, updated_at, last_login_at, email_verified, email_verified_at,
 		       failed_login_attempts, locked_at, locked_until,
-		       account_disabled, disabled_at, disabled_by_user_id, disable_reason
+		       account_disabled, disabled_at, disabled_by_user_id, disable_reason,
+		       birthday, gender
 		FROM users
 		WHERE email = ?
 	`)
@@ -147,6 +159,8 @@ func (r *SQLiteUserRepository) GetByEmail(email string) (*domain.User, error) {
 	var lastLoginAt, emailVerifiedAt, lockedAt, lockedUntil, disabledAt sql.NullTime
 	var disabledByUserID sql.NullInt64
 	var disableReason sql.NullString
+	var birthday sql.NullTime
+	var gender sql.NullString
 
 	err := r.db.QueryRow(query, email).Scan(
 		&user.ID,
@@ -167,6 +181,8 @@ func (r *SQLiteUserRepository) GetByEmail(email string) (*domain.User, error) {
 		&disabledAt,
 		&disabledByUserID,
 		&disableReason,
+		&birthday,
+		&gender,
 	)
 
 	if err != nil {
@@ -198,6 +214,12 @@ func (r *SQLiteUserRepository) GetByEmail(email string) (*domain.User, error) {
 	if disableReason.Valid {
 		user.DisableReason = &disableReason.String
 	}
+	if birthday.Valid {
+		user.Birthday = &birthday.Time
+	}
+	if gender.Valid {
+		user.Gender = &gender.String
+	}
 
 	return user, nil
 }
@@ -224,7 +246,7 @@ func (r *SQLiteUserRepository) Update(user *domain.User) error {
 		UPDATE users
 		SET email = ?, name = ?, profile_image = ?, role = ?,
 		    updated_at = ?, last_login_at = ?, password_hash = ?,
-		    email_verified = ?, email_verified_at = ?
+		    email_verified = ?, email_verified_at = ?, birthday = ?, gender = ?
 		WHERE id = ?
 	`)
 
@@ -243,6 +265,11 @@ func (r *SQLiteUserRepository) Update(user *domain.User) error {
 		profileImage = *user.ProfileImage
 	}
 
+	var birthday interface{}
+	if user.Birthday != nil {
+		birthday = *user.Birthday
+	}
+
 	user.UpdatedAt = time.Now()
 
 	_, err := r.db.Exec(
@@ -256,6 +283,8 @@ func (r *SQLiteUserRepository) Update(user *domain.User) error {
 		user.PasswordHash,
 		user.EmailVerified,
 		emailVerifiedAt,
+		birthday,
+		user.Gender,
 		user.ID,
 	)
 
@@ -288,7 +317,27 @@ func (r *SQLiteUserRepository) List(limit, offset int) ([]*domain.User, error) {
 		LIMIT ? OFFSET ?
 	`)
 
-	rows, err := r.db.Query(query, limit, offset)
+	return r.queryUsers(query, limit, offset)
+}
+
+// ListByOrganization retrieves the members of a gym with pagination
+func (r *SQLiteUserRepository) ListByOrganization(orgID int64, limit, offset int) ([]*domain.User, error) {
+	query := rebindQuery(`
+		SELECT id, email, password_hash, name, profile_image, role,
+		       created_at, updated_at, last_login_at, email_verified, email_verified_at,
+		       failed_login_attempts, locked_at, locked_until,
+		       account_disabled, disabled_at, disabled_by_user_id, disable_reason
+		FROM users
+		WHERE id IN (SELECT user_id FROM organization_members WHERE organization_id = ?)
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?
+	`)
+
+	return r.queryUsers(query, orgID, limit, offset)
+}
+
+func (r *SQLiteUserRepository) queryUsers(query string, args ...interface{}) ([]*domain.User, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -362,6 +411,14 @@ func (r *SQLiteUserRepository) Count() (int64, error) {
 	return count, err
 }
 
+// CountByOrganization returns the number of members of a gym
+func (r *SQLiteUserRepository) CountByOrganization(orgID int64) (int64, error) {
+	query := rebindQuery(`SELECT COUNT(*) FROM organization_members WHERE organization_id = ?`)
+	var count int64
+	err := r.db.QueryRow(query, orgID).Scan(&count)
+	return count, err
+}
+
 // Account Security Methods
 
 // IncrementFailedAttempts increments the failed login attempts counter
@@ -21,7 +21,7 @@ func NewSQLiteUserSettingsRepository(db *sql.DB) domain.UserSettingsRepository {
 func (r *SQLiteUserSettingsRepository) GetByUserID(userID int64) (*domain.UserSettings, error) {
 	query := `
 		SELECT id, user_id, notification_preferences, data_export_format, theme,
-		       weight_unit, distance_unit, created_at, updated_at
+		       weight_unit, distance_unit, leaderboard_opt_in, created_at, updated_at
 		FROM user_settings
 		WHERE user_id = ?
 	`
@@ -35,6 +35,7 @@ func (r *SQLiteUserSettingsRepository) GetByUserID(userID int64) (*domain.UserSe
 		&settings.Theme,
 		&settings.WeightUnit,
 		&settings.DistanceUnit,
+		&settings.LeaderboardOptIn,
 		&settings.CreatedAt,
 		&settings.UpdatedAt,
 	)
@@ -54,8 +55,8 @@ func (r *SQLiteUserSettingsRepository) Create(settings *domain.UserSettings) err
 	query := `
 		INSERT INTO user_settings (
 			user_id, notification_preferences, data_export_format, theme,
-			weight_unit, distance_unit, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			weight_unit, distance_unit, leaderboard_opt_in, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	now := time.Now()
@@ -70,6 +71,7 @@ func (r *SQLiteUserSettingsRepository) Create(settings *domain.UserSettings) err
 		settings.Theme,
 		settings.WeightUnit,
 		settings.DistanceUnit,
+		settings.LeaderboardOptIn,
 		settings.CreatedAt,
 		settings.UpdatedAt,
 	)
@@ -91,7 +93,7 @@ func (r *SQLiteUserSettingsRepository) Update(settings *domain.UserSettings) err
 	query := `
 		UPDATE user_settings
 		SET notification_preferences = ?, data_export_format = ?, theme = ?,
-		    weight_unit = ?, distance_unit = ?, updated_at = ?
+		    weight_unit = ?, distance_unit = ?, leaderboard_opt_in = ?, updated_at = ?
 		WHERE user_id = ?
 	`
 
@@ -104,6 +106,7 @@ func (r *SQLiteUserSettingsRepository) Update(settings *domain.UserSettings) err
 		settings.Theme,
 		settings.WeightUnit,
 		settings.DistanceUnit,
+		settings.LeaderboardOptIn,
 		settings.UpdatedAt,
 		settings.UserID,
 	)
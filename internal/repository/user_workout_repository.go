@@ -8,6 +8,9 @@ import (
 	"github.com/johnzastrow/actalog/internal/domain"
 )
 
+// sessionWellnessColumns are the user_workouts columns scanned into domain.SessionWellness (NULL when not reported)
+const sessionWellnessColumns = "session_rpe, sleep_hours, sleep_quality, soreness, readiness"
+
 type UserWorkoutRepository struct {
 	db *sql.DB
 }
@@ -21,10 +24,13 @@ func (r *UserWorkoutRepository) Create(userWorkout *domain.UserWorkout) error {
 	userWorkout.CreatedAt = time.Now()
 	userWorkout.UpdatedAt = time.Now()
 
-	query := `INSERT INTO user_workouts (user_id, workout_id, workout_name, workout_date, workout_type, total_time, notes, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO user_workouts (user_id, workout_id, workout_name, workout_date, workout_type, total_time, notes,
+	                                     session_rpe, sleep_hours, sleep_quality, soreness, readiness, created_at, updated_at)
+	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	result, err := r.db.Exec(query, userWorkout.UserID, userWorkout.WorkoutID, userWorkout.WorkoutName, userWorkout.WorkoutDate, userWorkout.WorkoutType, userWorkout.TotalTime, userWorkout.Notes, userWorkout.CreatedAt, userWorkout.UpdatedAt)
+	wellness := userWorkout.SessionWellness
+	result, err := r.db.Exec(query, userWorkout.UserID, userWorkout.WorkoutID, userWorkout.WorkoutName, userWorkout.WorkoutDate, userWorkout.WorkoutType, userWorkout.TotalTime, userWorkout.Notes,
+		wellness.SessionRPE, wellness.SleepHours, wellness.SleepQuality, wellness.Soreness, wellness.Readiness, userWorkout.CreatedAt, userWorkout.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create user workout: %w", err)
 	}
@@ -40,7 +46,7 @@ func (r *UserWorkoutRepository) Create(userWorkout *domain.UserWorkout) error {
 
 // GetByID retrieves a user workout by ID
 func (r *UserWorkoutRepository) GetByID(id int64) (*domain.UserWorkout, error) {
-	query := `SELECT id, user_id, workout_id, workout_name, workout_date, workout_type, total_time, notes, created_at, updated_at FROM user_workouts WHERE id = ?`
+	query := `SELECT id, user_id, workout_id, workout_name, workout_date, workout_type, total_time, notes, ` + sessionWellnessColumns + `, created_at, updated_at FROM user_workouts WHERE id = ?`
 
 	userWorkout := &domain.UserWorkout{}
 	var workoutID sql.NullInt64
@@ -49,7 +55,9 @@ func (r *UserWorkoutRepository) GetByID(id int64) (*domain.UserWorkout, error) {
 	var totalTime sql.NullInt64
 	var notes sql.NullString
 
-	err := r.db.QueryRow(query, id).Scan(&userWorkout.ID, &userWorkout.UserID, &workoutID, &workoutName, &userWorkout.WorkoutDate, &workoutType, &totalTime, &notes, &userWorkout.CreatedAt, &userWorkout.UpdatedAt)
+	wellness := &userWorkout.SessionWellness
+	err := r.db.QueryRow(query, id).Scan(&userWorkout.ID, &userWorkout.UserID, &workoutID, &workoutName, &userWorkout.WorkoutDate, &workoutType, &totalTime, &notes,
+		&wellness.SessionRPE, &wellness.SleepHours, &wellness.SleepQuality, &wellness.Soreness, &wellness.Readiness, &userWorkout.CreatedAt, &userWorkout.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -299,7 +307,7 @@ func (r *UserWorkoutRepository) GetByIDWithDetails(id int64, userID int64) (*dom
 	// Get actual performance WODs from user_workout_wods table
 	perfWODsQuery := `
 		SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.score_type, uww.score_value,
-		       uww.time_seconds, uww.rounds, uww.reps, uww.weight, uww.notes,
+		       uww.time_seconds, uww.rounds, uww.reps, uww.weight, ` + wodScoreDetailColumns + `, uww.notes,
 		       uww.order_index, uww.created_at, uww.updated_at,
 		       w.name as wod_name, w.type as wod_type, w.regime as wod_regime
 		FROM user_workout_wods uww
@@ -316,6 +324,7 @@ func (r *UserWorkoutRepository) GetByIDWithDetails(id int64, userID int64) (*dom
 	var performanceWODs []*domain.UserWorkoutWOD
 	for perfWODRows.Next() {
 		uww := &domain.UserWorkoutWOD{}
+		details := &uww.WODScoreDetails
 		var scoreType sql.NullString
 		var scoreValue sql.NullString
 		var timeSeconds sql.NullInt64
@@ -328,7 +337,7 @@ func (r *UserWorkoutRepository) GetByIDWithDetails(id int64, userID int64) (*dom
 		var wodRegime string
 
 		err := perfWODRows.Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &scoreType, &scoreValue,
-			&timeSeconds, &rounds, &reps, &weight, &notes,
+			&timeSeconds, &rounds, &reps, &weight, &details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped, &notes,
 			&uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt,
 			&wodName, &wodType, &wodRegime)
 		if err != nil {
@@ -390,7 +399,7 @@ func (r *UserWorkoutRepository) GetByIDWithDetails(id int64, userID int64) (*dom
 
 // ListByUser retrieves all workouts logged by a specific user
 func (r *UserWorkoutRepository) ListByUser(userID int64, limit, offset int) ([]*domain.UserWorkout, error) {
-	query := `SELECT id, user_id, workout_id, workout_date, workout_type, total_time, notes, created_at, updated_at FROM user_workouts WHERE user_id = ? ORDER BY workout_date DESC, created_at DESC LIMIT ? OFFSET ?`
+	query := `SELECT id, user_id, workout_id, workout_date, workout_type, total_time, notes, ` + sessionWellnessColumns + `, created_at, updated_at FROM user_workouts WHERE user_id = ? ORDER BY workout_date DESC, created_at DESC LIMIT ? OFFSET ?`
 
 	rows, err := r.db.Query(query, userID, limit, offset)
 	if err != nil {
@@ -424,7 +433,7 @@ func (r *UserWorkoutRepository) ListByUserWithDetails(userID int64, limit, offse
 
 // ListByUserAndDateRange retrieves workouts within a date range
 func (r *UserWorkoutRepository) ListByUserAndDateRange(userID int64, startDate, endDate time.Time) ([]*domain.UserWorkout, error) {
-	query := `SELECT id, user_id, workout_id, workout_date, workout_type, total_time, notes, created_at, updated_at FROM user_workouts WHERE user_id = ? AND workout_date >= ? AND workout_date <= ? ORDER BY workout_date DESC`
+	query := `SELECT id, user_id, workout_id, workout_date, workout_type, total_time, notes, ` + sessionWellnessColumns + `, created_at, updated_at FROM user_workouts WHERE user_id = ? AND workout_date >= ? AND workout_date <= ? ORDER BY workout_date DESC`
 
 	rows, err := r.db.Query(query, userID, startDate, endDate)
 	if err != nil {
@@ -441,10 +450,12 @@ func (r *UserWorkoutRepository) Update(userWorkout *domain.UserWorkout) error {
 
 	query := `UPDATE user_workouts
 	          SET workout_name = ?, workout_date = ?, workout_type = ?, total_time = ?,
-	              notes = ?, updated_at = ?
+	              notes = ?, session_rpe = ?, sleep_hours = ?, sleep_quality = ?, soreness = ?, readiness = ?, updated_at = ?
 	          WHERE id = ? AND user_id = ?`
 
-	result, err := r.db.Exec(query, userWorkout.WorkoutName, userWorkout.WorkoutDate, userWorkout.WorkoutType, userWorkout.TotalTime, userWorkout.Notes, userWorkout.UpdatedAt, userWorkout.ID, userWorkout.UserID)
+	wellness := userWorkout.SessionWellness
+	result, err := r.db.Exec(query, userWorkout.WorkoutName, userWorkout.WorkoutDate, userWorkout.WorkoutType, userWorkout.TotalTime, userWorkout.Notes,
+		wellness.SessionRPE, wellness.SleepHours, wellness.SleepQuality, wellness.Soreness, wellness.Readiness, userWorkout.UpdatedAt, userWorkout.ID, userWorkout.UserID)
 	if err != nil {
 		return fmt.Errorf("failed to update user workout: %w", err)
 	}
@@ -596,7 +607,9 @@ func (r *UserWorkoutRepository) scanUserWorkouts(rows *sql.Rows) ([]*domain.User
 		var totalTime sql.NullInt64
 		var notes sql.NullString
 
-		err := rows.Scan(&userWorkout.ID, &userWorkout.UserID, &userWorkout.WorkoutID, &userWorkout.WorkoutDate, &workoutType, &totalTime, &notes, &userWorkout.CreatedAt, &userWorkout.UpdatedAt)
+		wellness := &userWorkout.SessionWellness
+		err := rows.Scan(&userWorkout.ID, &userWorkout.UserID, &userWorkout.WorkoutID, &userWorkout.WorkoutDate, &workoutType, &totalTime, &notes,
+			&wellness.SessionRPE, &wellness.SleepHours, &wellness.SleepQuality, &wellness.Soreness, &wellness.Readiness, &userWorkout.CreatedAt, &userWorkout.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
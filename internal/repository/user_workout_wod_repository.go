@@ -3,11 +3,15 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/johnzastrow/actalog/internal/domain"
 )
 
+// wodScoreDetailColumns are the user_workout_wods columns scanned into domain.WODScoreDetails
+const wodScoreDetailColumns = "uww.calories, uww.distance, uww.points, uww.tie_break_seconds, uww.time_capped"
+
 // UserWorkoutWODRepository implements domain.UserWorkoutWODRepository
 type UserWorkoutWODRepository struct {
 	db *sql.DB
@@ -23,10 +27,14 @@ func (r *UserWorkoutWODRepository) Create(uww *domain.UserWorkoutWOD) error {
 	uww.CreatedAt = time.Now()
 	uww.UpdatedAt = time.Now()
 
-	query := `INSERT INTO user_workout_wods (user_workout_id, wod_id, score_type, score_value, time_seconds, rounds, reps, weight, notes, is_pr, order_index, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	// Results without a version are performed on the WOD's latest version
+	query := `INSERT INTO user_workout_wods (user_workout_id, wod_id, wod_version_id, score_type, score_value, time_seconds, rounds, reps, weight,
+	          calories, distance, points, tie_break_seconds, time_capped, division, notes, is_pr, order_index, created_at, updated_at)
+	          VALUES (?, ?, COALESCE(?, ` + latestWODVersionID + `), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	result, err := r.db.Exec(query, uww.UserWorkoutID, uww.WODID, uww.ScoreType, uww.ScoreValue, uww.TimeSeconds, uww.Rounds, uww.Reps, uww.Weight, uww.Notes, uww.IsPR, uww.OrderIndex, uww.CreatedAt, uww.UpdatedAt)
+	details := uww.WODScoreDetails
+	result, err := r.db.Exec(query, uww.UserWorkoutID, uww.WODID, uww.WODVersionID, uww.WODID, uww.ScoreType, uww.ScoreValue, uww.TimeSeconds, uww.Rounds, uww.Reps, uww.Weight,
+		details.Calories, details.Distance, details.Points, details.TieBreakSeconds, details.TimeCapped, uww.Division, uww.Notes, uww.IsPR, uww.OrderIndex, uww.CreatedAt, uww.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create user workout WOD: %w", err)
 	}
@@ -52,8 +60,10 @@ func (r *UserWorkoutWODRepository) CreateBatch(wods []*domain.UserWorkoutWOD) er
 	}
 	defer tx.Rollback()
 
-	query := `INSERT INTO user_workout_wods (user_workout_id, wod_id, score_type, score_value, time_seconds, rounds, reps, weight, notes, is_pr, order_index, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	// Results without a version are performed on the WOD's latest version
+	query := `INSERT INTO user_workout_wods (user_workout_id, wod_id, wod_version_id, score_type, score_value, time_seconds, rounds, reps, weight,
+	          calories, distance, points, tie_break_seconds, time_capped, division, notes, is_pr, order_index, created_at, updated_at)
+	          VALUES (?, ?, COALESCE(?, ` + latestWODVersionID + `), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	stmt, err := tx.Prepare(query)
 	if err != nil {
@@ -66,7 +76,9 @@ func (r *UserWorkoutWODRepository) CreateBatch(wods []*domain.UserWorkoutWOD) er
 		uww.CreatedAt = now
 		uww.UpdatedAt = now
 
-		result, err := stmt.Exec(uww.UserWorkoutID, uww.WODID, uww.ScoreType, uww.ScoreValue, uww.TimeSeconds, uww.Rounds, uww.Reps, uww.Weight, uww.Notes, uww.IsPR, uww.OrderIndex, uww.CreatedAt, uww.UpdatedAt)
+		details := uww.WODScoreDetails
+		result, err := stmt.Exec(uww.UserWorkoutID, uww.WODID, uww.WODVersionID, uww.WODID, uww.ScoreType, uww.ScoreValue, uww.TimeSeconds, uww.Rounds, uww.Reps, uww.Weight,
+			details.Calories, details.Distance, details.Points, details.TieBreakSeconds, details.TimeCapped, uww.Division, uww.Notes, uww.IsPR, uww.OrderIndex, uww.CreatedAt, uww.UpdatedAt)
 		if err != nil {
 			return fmt.Errorf("failed to insert user workout WOD: %w", err)
 		}
@@ -87,18 +99,23 @@ func (r *UserWorkoutWODRepository) CreateBatch(wods []*domain.UserWorkoutWOD) er
 
 // GetByID retrieves a user workout WOD by ID
 func (r *UserWorkoutWODRepository) GetByID(id int64) (*domain.UserWorkoutWOD, error) {
-	query := `SELECT id, user_workout_id, wod_id, score_type, score_value, time_seconds, rounds, reps, weight, notes, order_index, created_at, updated_at
-	          FROM user_workout_wods WHERE id = ?`
+	query := `SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.wod_version_id, uww.score_type, uww.score_value, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
+	          ` + wodScoreDetailColumns + `, uww.division, uww.notes, uww.order_index, uww.created_at, uww.updated_at
+	          FROM user_workout_wods uww WHERE uww.id = ?`
 
 	uww := &domain.UserWorkoutWOD{}
+	details := &uww.WODScoreDetails
 	var scoreType sql.NullString
 	var scoreValue sql.NullString
 	var timeSeconds sql.NullInt64
 	var rounds sql.NullInt64
 	var reps sql.NullInt64
 	var weight sql.NullFloat64
+	var division sql.NullString
+	var versionID sql.NullInt64
 
-	err := r.db.QueryRow(query, id).Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &scoreType, &scoreValue, &timeSeconds, &rounds, &reps, &weight, &uww.Notes, &uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt)
+	err := r.db.QueryRow(query, id).Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &versionID, &scoreType, &scoreValue, &timeSeconds, &rounds, &reps, &weight,
+		&details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped, &division, &uww.Notes, &uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -127,6 +144,12 @@ func (r *UserWorkoutWODRepository) GetByID(id int64) (*domain.UserWorkoutWOD, er
 	if weight.Valid {
 		uww.Weight = &weight.Float64
 	}
+	if division.Valid {
+		uww.Division = &division.String
+	}
+	if versionID.Valid {
+		uww.WODVersionID = &versionID.Int64
+	}
 
 	return uww, nil
 }
@@ -134,11 +157,13 @@ func (r *UserWorkoutWODRepository) GetByID(id int64) (*domain.UserWorkoutWOD, er
 // GetByUserWorkoutID retrieves all WODs for a specific logged workout
 func (r *UserWorkoutWODRepository) GetByUserWorkoutID(userWorkoutID int64) ([]*domain.UserWorkoutWOD, error) {
 	query := `
-		SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.score_type, uww.score_value, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
-		       uww.notes, uww.order_index, uww.created_at, uww.updated_at,
-		       w.id as wod_id, w.name, w.source, w.type, w.regime, w.score_type as wod_score_type, w.description, w.url, w.notes as wod_notes, w.is_standard, w.created_by, w.created_at, w.updated_at
+		SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.wod_version_id, v.version, uww.score_type, uww.score_value, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
+		       ` + wodScoreDetailColumns + `, uww.division, uww.notes, uww.is_pr, uww.order_index, uww.created_at, uww.updated_at,
+		       w.id as wod_id, COALESCE(v.name, w.name), w.source, w.type, COALESCE(v.regime, w.regime), COALESCE(v.score_type, w.score_type) as wod_score_type,
+		       COALESCE(v.description, w.description), w.url, w.notes as wod_notes, w.is_standard, w.created_by, w.created_at, w.updated_at
 		FROM user_workout_wods uww
 		JOIN wods w ON uww.wod_id = w.id
+		LEFT JOIN wod_versions v ON uww.wod_version_id = v.id
 		WHERE uww.user_workout_id = ?
 		ORDER BY uww.order_index`
 
@@ -153,18 +178,21 @@ func (r *UserWorkoutWODRepository) GetByUserWorkoutID(userWorkoutID int64) ([]*d
 		uww := &domain.UserWorkoutWOD{
 			WOD: &domain.WOD{},
 		}
+		details := &uww.WODScoreDetails
 		var scoreType sql.NullString
 		var scoreValue sql.NullString
 		var timeSeconds sql.NullInt64
 		var rounds sql.NullInt64
 		var reps sql.NullInt64
 		var weight sql.NullFloat64
+		var division sql.NullString
 		var wodURL sql.NullString
 		var wodNotes sql.NullString
 		var createdBy sql.NullInt64
+		var versionID, version sql.NullInt64
 
-		err := rows.Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &scoreType, &scoreValue, &timeSeconds, &rounds, &reps, &weight,
-			&uww.Notes, &uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt,
+		err := rows.Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &versionID, &version, &scoreType, &scoreValue, &timeSeconds, &rounds, &reps, &weight,
+			&details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped, &division, &uww.Notes, &uww.IsPR, &uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt,
 			&uww.WOD.ID, &uww.WOD.Name, &uww.WOD.Source, &uww.WOD.Type, &uww.WOD.Regime, &uww.WOD.ScoreType, &uww.WOD.Description, &wodURL, &wodNotes, &uww.WOD.IsStandard, &createdBy, &uww.WOD.CreatedAt, &uww.WOD.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user workout WOD: %w", err)
@@ -191,6 +219,9 @@ func (r *UserWorkoutWODRepository) GetByUserWorkoutID(userWorkoutID int64) ([]*d
 		if weight.Valid {
 			uww.Weight = &weight.Float64
 		}
+		if division.Valid {
+			uww.Division = &division.String
+		}
 		if wodURL.Valid {
 			uww.WOD.URL = &wodURL.String
 		}
@@ -201,6 +232,11 @@ func (r *UserWorkoutWODRepository) GetByUserWorkoutID(userWorkoutID int64) ([]*d
 			cb := createdBy.Int64
 			uww.WOD.CreatedBy = &cb
 		}
+		if versionID.Valid {
+			uww.WODVersionID = &versionID.Int64
+			v := int(version.Int64)
+			uww.WODVersion = &v
+		}
 
 		wods = append(wods, uww)
 	}
@@ -217,10 +253,15 @@ func (r *UserWorkoutWODRepository) Update(uww *domain.UserWorkoutWOD) error {
 	uww.UpdatedAt = time.Now()
 
 	query := `UPDATE user_workout_wods
-	          SET score_type = ?, score_value = ?, time_seconds = ?, rounds = ?, reps = ?, weight = ?, notes = ?, order_index = ?, updated_at = ?
+	          SET score_type = ?, score_value = ?, time_seconds = ?, rounds = ?, reps = ?, weight = ?,
+	              calories = ?, distance = ?, points = ?, tie_break_seconds = ?, time_capped = ?,
+	              division = ?, notes = ?, order_index = ?, updated_at = ?
 	          WHERE id = ?`
 
-	result, err := r.db.Exec(query, uww.ScoreType, uww.ScoreValue, uww.TimeSeconds, uww.Rounds, uww.Reps, uww.Weight, uww.Notes, uww.OrderIndex, uww.UpdatedAt, uww.ID)
+	details := uww.WODScoreDetails
+	result, err := r.db.Exec(query, uww.ScoreType, uww.ScoreValue, uww.TimeSeconds, uww.Rounds, uww.Reps, uww.Weight,
+		details.Calories, details.Distance, details.Points, details.TieBreakSeconds, details.TimeCapped,
+		uww.Division, uww.Notes, uww.OrderIndex, uww.UpdatedAt, uww.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update user workout WOD: %w", err)
 	}
@@ -270,29 +311,14 @@ func (r *UserWorkoutWODRepository) DeleteByUserWorkoutID(userWorkoutID int64) er
 	return nil
 }
 
-// GetBestTimeForWOD retrieves the fastest time for a specific WOD for a user
+// GetBestTimeForWOD retrieves the fastest finishing time for a specific WOD for a user
+// Results are ranked like PRs (see scoreRankOrder); time-capped results have no finishing time and are skipped
 func (r *UserWorkoutWODRepository) GetBestTimeForWOD(userID, wodID int64) (*int, error) {
-	query := `
-		SELECT MIN(uww.time_seconds)
-		FROM user_workout_wods uww
-		INNER JOIN user_workouts uw ON uww.user_workout_id = uw.id
-		WHERE uw.user_id = ? AND uww.wod_id = ? AND uww.time_seconds IS NOT NULL`
-
-	var bestTime sql.NullInt64
-	err := r.db.QueryRow(query, userID, wodID).Scan(&bestTime)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("failed to get best time: %w", err)
-	}
-
-	if !bestTime.Valid {
-		return nil, nil
+	best, err := r.GetBestForWOD(userID, []int64{wodID}, domain.ScoreTypeTime)
+	if err != nil || best == nil || best.TimeCapped {
+		return nil, err
 	}
-
-	t := int(bestTime.Int64)
-	return &t, nil
+	return best.TimeSeconds, nil
 }
 
 // GetBestRoundsRepsForWOD retrieves the best rounds+reps for a specific WOD for a user
@@ -328,16 +354,95 @@ func (r *UserWorkoutWODRepository) GetBestRoundsRepsForWOD(userID, wodID int64)
 	return rounds, reps, nil
 }
 
+// GetBestForWOD retrieves a user's best result on any of the given comparable WODs, ranked by the given
+// score type; results on superseded, non-comparable versions of a WOD are ignored
+func (r *UserWorkoutWODRepository) GetBestForWOD(userID int64, wodIDs []int64, scoreType string) (*domain.UserWorkoutWOD, error) {
+	def := domain.LookupScoreType(scoreType)
+	if def == nil {
+		return nil, fmt.Errorf("unknown score type: %s", scoreType)
+	}
+	if len(wodIDs) == 0 {
+		return nil, nil
+	}
+	order, scored := scoreRankOrder(def)
+	in, args := idList(wodIDs)
+
+	query := fmt.Sprintf(`
+		SELECT uww.id, uww.wod_id, uww.time_seconds, uww.rounds, uww.reps, uww.weight, %s
+		FROM user_workout_wods uww
+		INNER JOIN user_workouts uw ON uww.user_workout_id = uw.id
+		WHERE uw.user_id = ? AND uww.wod_id IN %s AND %s AND %s
+		ORDER BY %s
+		LIMIT 1`, wodScoreDetailColumns, in, currentWODVersionCondition, scored, order)
+
+	best := &domain.UserWorkoutWOD{}
+	details := &best.WODScoreDetails
+	err := r.db.QueryRow(query, append([]interface{}{userID}, args...)...).Scan(&best.ID, &best.WODID, &best.TimeSeconds, &best.Rounds, &best.Reps, &best.Weight,
+		&details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get best result: %w", err)
+	}
+
+	return best, nil
+}
+
+// scoreRankOrder returns the ranking order (best first) and the "has a score" condition for a score type.
+// Order terms use unqualified score columns; the condition uses the uww alias.
+// Time-capped results follow every finisher, and tie-break times settle equal scores.
+func scoreRankOrder(def *domain.ScoreTypeDefinition) (order string, scored string) {
+	direction := func(rank domain.ScoreRank) string {
+		if rank.Descending {
+			return "DESC"
+		}
+		return "ASC"
+	}
+	notNull := func(fields []string) string {
+		conditions := make([]string, len(fields))
+		for i, field := range fields {
+			conditions[i] = "uww." + field + " IS NOT NULL"
+		}
+		return strings.Join(conditions, " AND ")
+	}
+
+	var terms []string
+	scored = notNull(def.Fields)
+	if def.SupportsTimeCap {
+		cappedFields := make([]string, len(def.CappedRank))
+		terms = append(terms, "time_capped ASC")
+		for _, rank := range def.Rank {
+			terms = append(terms, fmt.Sprintf("CASE WHEN time_capped THEN NULL ELSE %s END %s", rank.Field, direction(rank)))
+		}
+		for i, rank := range def.CappedRank {
+			cappedFields[i] = rank.Field
+			terms = append(terms, fmt.Sprintf("CASE WHEN time_capped THEN %s END %s", rank.Field, direction(rank)))
+		}
+		scored = fmt.Sprintf("((NOT uww.time_capped AND %s) OR (uww.time_capped AND %s))", scored, notNull(cappedFields))
+	} else {
+		for _, rank := range def.Rank {
+			terms = append(terms, fmt.Sprintf("COALESCE(%s, 0) %s", rank.Field, direction(rank)))
+		}
+	}
+
+	if def.SupportsTieBreak {
+		terms = append(terms, "CASE WHEN tie_break_seconds IS NULL THEN 1 ELSE 0 END", "tie_break_seconds ASC")
+	}
+	return strings.Join(terms, ", "), scored
+}
+
 // GetPRWODs retrieves recent PR-flagged WODs for a user
 func (r *UserWorkoutWODRepository) GetPRWODs(userID int64, limit int) ([]*domain.UserWorkoutWOD, error) {
 	query := `
-		SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.score_type, uww.score_value, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
-		       uww.notes, uww.is_pr, uww.order_index, uww.created_at, uww.updated_at,
-		       w.name,
+		SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.wod_version_id, v.version, uww.score_type, uww.score_value, uww.time_seconds, uww.rounds, uww.reps, uww.weight,
+		       ` + wodScoreDetailColumns + `, uww.notes, uww.is_pr, uww.order_index, uww.created_at, uww.updated_at,
+		       COALESCE(v.name, w.name),
 		       uw.workout_date
 		FROM user_workout_wods uww
 		JOIN wods w ON uww.wod_id = w.id
 		JOIN user_workouts uw ON uww.user_workout_id = uw.id
+		LEFT JOIN wod_versions v ON uww.wod_version_id = v.id
 		WHERE uw.user_id = ? AND uww.is_pr = 1
 		ORDER BY uw.workout_date DESC, uww.created_at DESC
 		LIMIT ?`
@@ -351,6 +456,7 @@ func (r *UserWorkoutWODRepository) GetPRWODs(userID int64, limit int) ([]*domain
 	var wods []*domain.UserWorkoutWOD
 	for rows.Next() {
 		uww := &domain.UserWorkoutWOD{}
+		details := &uww.WODScoreDetails
 		var scoreType sql.NullString
 		var scoreValue sql.NullString
 		var timeSeconds sql.NullInt64
@@ -358,9 +464,10 @@ func (r *UserWorkoutWODRepository) GetPRWODs(userID int64, limit int) ([]*domain
 		var reps sql.NullInt64
 		var weight sql.NullFloat64
 		var workoutDate time.Time
+		var versionID, version sql.NullInt64
 
-		err := rows.Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &scoreType, &scoreValue, &timeSeconds, &rounds, &reps, &weight,
-			&uww.Notes, &uww.IsPR, &uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt,
+		err := rows.Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &versionID, &version, &scoreType, &scoreValue, &timeSeconds, &rounds, &reps, &weight,
+			&details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped, &uww.Notes, &uww.IsPR, &uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt,
 			&uww.WODName, &workoutDate)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan PR WOD: %w", err)
@@ -387,6 +494,11 @@ func (r *UserWorkoutWODRepository) GetPRWODs(userID int64, limit int) ([]*domain
 		if weight.Valid {
 			uww.Weight = &weight.Float64
 		}
+		if versionID.Valid {
+			uww.WODVersionID = &versionID.Int64
+			v := int(version.Int64)
+			uww.WODVersion = &v
+		}
 
 		// Assign workout date
 		uww.WorkoutDate = workoutDate
@@ -421,17 +533,21 @@ func (r *UserWorkoutWODRepository) UpdatePRFlag(id int64, isPR bool) error {
 	return nil
 }
 
-// GetByUserIDAndWODID retrieves all WOD performance records for a specific user and WOD
-func (r *UserWorkoutWODRepository) GetByUserIDAndWODID(userID, wodID int64, limit int) ([]*domain.UserWorkoutWOD, error) {
-	query := `
-		SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.score_type, uww.score_value,
-		       uww.time_seconds, uww.rounds, uww.reps, uww.weight, uww.notes, uww.is_pr,
+// wodPerformanceQuery selects a user's WOD performances with their workout dates; callers add WHERE and ORDER BY
+const wodPerformanceQuery = `
+		SELECT uww.id, uww.user_workout_id, uww.wod_id, uww.wod_version_id, v.version, uww.score_type, uww.score_value,
+		       uww.time_seconds, uww.rounds, uww.reps, uww.weight, ` + wodScoreDetailColumns + `, uww.division, uww.notes, uww.is_pr,
 		       uww.order_index, uww.created_at, uww.updated_at,
-		       w.name, w.type, w.score_type,
+		       COALESCE(v.name, w.name), w.type, COALESCE(v.score_type, w.score_type),
 		       uw.workout_date
 		FROM user_workout_wods uww
 		JOIN wods w ON uww.wod_id = w.id
 		JOIN user_workouts uw ON uww.user_workout_id = uw.id
+		LEFT JOIN wod_versions v ON uww.wod_version_id = v.id`
+
+// GetByUserIDAndWODID retrieves all WOD performance records for a specific user and WOD
+func (r *UserWorkoutWODRepository) GetByUserIDAndWODID(userID, wodID int64, limit int) ([]*domain.UserWorkoutWOD, error) {
+	query := wodPerformanceQuery + `
 		WHERE uw.user_id = ? AND uww.wod_id = ?
 		ORDER BY uw.workout_date DESC, uww.created_at DESC
 		LIMIT ?`
@@ -442,19 +558,52 @@ func (r *UserWorkoutWODRepository) GetByUserIDAndWODID(userID, wodID int64, limi
 	}
 	defer rows.Close()
 
+	return scanWODPerformances(rows)
+}
+
+// GetHistoryForWOD retrieves every performance of a WOD by a user, oldest first, optionally within a date range
+func (r *UserWorkoutWODRepository) GetHistoryForWOD(userID, wodID int64, start, end *time.Time) ([]*domain.UserWorkoutWOD, error) {
+	query := wodPerformanceQuery + `
+		WHERE uw.user_id = ? AND uww.wod_id = ?`
+	args := []interface{}{userID, wodID}
+	if start != nil {
+		query += ` AND uw.workout_date >= ?`
+		args = append(args, *start)
+	}
+	if end != nil {
+		query += ` AND uw.workout_date <= ?`
+		args = append(args, *end)
+	}
+	query += `
+		ORDER BY uw.workout_date ASC, uww.created_at ASC`
+
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query WOD history: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWODPerformances(rows)
+}
+
+// scanWODPerformances reads rows selected by wodPerformanceQuery
+func scanWODPerformances(rows *sql.Rows) ([]*domain.UserWorkoutWOD, error) {
 	var wods []*domain.UserWorkoutWOD
 	for rows.Next() {
 		uww := &domain.UserWorkoutWOD{}
+		details := &uww.WODScoreDetails
 		var scoreType sql.NullString
 		var scoreValue sql.NullString
 		var timeSeconds sql.NullInt64
 		var rounds sql.NullInt64
 		var reps sql.NullInt64
 		var weight sql.NullFloat64
+		var division sql.NullString
 		var workoutDate time.Time
+		var versionID, version sql.NullInt64
 
-		err := rows.Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &scoreType, &scoreValue,
-			&timeSeconds, &rounds, &reps, &weight, &uww.Notes, &uww.IsPR,
+		err := rows.Scan(&uww.ID, &uww.UserWorkoutID, &uww.WODID, &versionID, &version, &scoreType, &scoreValue,
+			&timeSeconds, &rounds, &reps, &weight, &details.Calories, &details.Distance, &details.Points, &details.TieBreakSeconds, &details.TimeCapped, &division, &uww.Notes, &uww.IsPR,
 			&uww.OrderIndex, &uww.CreatedAt, &uww.UpdatedAt,
 			&uww.WODName, &uww.WODType, &uww.WODScoreType, &workoutDate)
 		if err != nil {
@@ -482,6 +631,14 @@ func (r *UserWorkoutWODRepository) GetByUserIDAndWODID(userID, wodID int64, limi
 		if weight.Valid {
 			uww.Weight = &weight.Float64
 		}
+		if division.Valid {
+			uww.Division = &division.String
+		}
+		if versionID.Valid {
+			uww.WODVersionID = &versionID.Int64
+			v := int(version.Int64)
+			uww.WODVersion = &v
+		}
 
 		// Store workout date from user_workouts table
 		uww.WorkoutDate = workoutDate
@@ -19,41 +19,77 @@ func NewWODRepository(db *sql.DB) *WODRepository {
 	return &WODRepository{db: db}
 }
 
-// Create creates a new custom WOD
+// Create creates a new custom WOD with its version 1 in one transaction
 func (r *WODRepository) Create(wod *domain.WOD) error {
-	wod.CreatedAt = time.Now()
-	wod.UpdatedAt = time.Now()
+	return r.create(wod, nil)
+}
 
-	query := `INSERT INTO wods (name, source, type, regime, score_type, description, url, notes, is_standard, created_by, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+// CreateInLibrary creates a new WOD with its version 1 and adds it to a gym library in one transaction
+func (r *WODRepository) CreateInLibrary(wod *domain.WOD, orgID, addedBy int64) error {
+	return r.create(wod, func(tx *sql.Tx, id int64) error {
+		return addLibraryItem(tx, orgID, domain.LibraryEntityWOD, id, addedBy)
+	})
+}
 
-	result, err := r.db.Exec(query,
-		wod.Name,
-		wod.Source,
-		wod.Type,
-		wod.Regime,
-		wod.ScoreType,
-		wod.Description,
-		wod.URL,
-		wod.Notes,
-		wod.IsStandard,
-		wod.CreatedBy,
-		wod.CreatedAt,
-		wod.UpdatedAt,
-	)
+// create inserts a WOD and its version 1 and, when set, runs also in the same transaction
+func (r *WODRepository) create(wod *domain.WOD, also func(tx *sql.Tx, id int64) error) error {
+	wod.CreatedAt = time.Now()
+	wod.UpdatedAt = wod.CreatedAt
+
+	tx, err := r.db.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to create wod: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	id, err := result.LastInsertId()
+	id, err := createWOD(tx, wod)
 	if err != nil {
-		return fmt.Errorf("failed to get wod ID: %w", err)
+		return err
+	}
+	if also != nil {
+		if err := also(tx, id); err != nil {
+			return err
+		}
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wod: %w", err)
+	}
 	wod.ID = id
 	return nil
 }
 
+// createWOD inserts a WOD and records its definition as version 1 within a transaction
+func createWOD(tx *sql.Tx, wod *domain.WOD) (int64, error) {
+	id, err := txInsertReturningID(tx, `INSERT INTO wods (name, source, type, regime, score_type, description, url, notes, is_standard, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		wod.Name, wod.Source, wod.Type, wod.Regime, wod.ScoreType, wod.Description, wod.URL, wod.Notes,
+		wod.IsStandard, wod.CreatedBy, wod.CreatedAt, wod.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create wod: %w", err)
+	}
+
+	first := &domain.WODVersion{
+		WODID:           id,
+		Version:         1,
+		Name:            wod.Name,
+		Source:          wod.Source,
+		Type:            wod.Type,
+		Regime:          wod.Regime,
+		ScoreType:       wod.ScoreType,
+		Description:     wod.Description,
+		URL:             wod.URL,
+		Notes:           wod.Notes,
+		BaselineVersion: 1,
+		CreatedBy:       wod.CreatedBy,
+		CreatedAt:       wod.CreatedAt,
+	}
+	if err := createWODVersion(tx, first); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
 // GetByID retrieves a WOD by ID
 func (r *WODRepository) GetByID(id int64) (*domain.WOD, error) {
 	query := `SELECT id, name, source, type, regime, score_type, description, url, notes, is_standard, created_by, created_at, updated_at
@@ -175,6 +211,10 @@ func (r *WODRepository) List(filters map[string]interface{}, limit, offset int)
 			query += " AND created_by = ?"
 			args = append(args, createdBy)
 		}
+		if movementID, ok := filters["movement_id"].(int64); ok {
+			query += " AND id IN (SELECT wod_id FROM wod_components WHERE movement_id = ?)"
+			args = append(args, movementID)
+		}
 	}
 
 	query += " ORDER BY is_standard DESC, name"
@@ -474,7 +514,8 @@ func (r *WODRepository) CountAllUserCreated() (int64, error) {
 }
 
 // ListAllUserCreatedWithUserInfoFiltered retrieves all user-created WODs with creator info and filters (for admin view)
-func (r *WODRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string) ([]*domain.WODWithCreator, int64, error) {
+// A positive orgID limits the list to items created by members of that gym
+func (r *WODRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string, orgID int64) ([]*domain.WODWithCreator, int64, error) {
 	baseQuery := `SELECT w.id, w.name, w.source, w.type, w.regime, w.score_type, w.description, w.url, w.notes, w.is_standard, w.created_by, w.created_at, w.updated_at,
 	                 COALESCE(u.email, '') as creator_email, COALESCE(u.name, '') as creator_name
 	          FROM wods w
@@ -507,6 +548,12 @@ func (r *WODRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset int
 		args = append(args, creatorTerm)
 		countArgs = append(countArgs, creatorTerm)
 	}
+	if orgID > 0 {
+		baseQuery += " AND w.created_by IN (SELECT user_id FROM organization_members WHERE organization_id = ?)"
+		countQuery += " AND w.created_by IN (SELECT user_id FROM organization_members WHERE organization_id = ?)"
+		args = append(args, orgID)
+		countArgs = append(countArgs, orgID)
+	}
 
 	// Get count first
 	var count int64
@@ -653,28 +700,18 @@ func (r *WODRepository) CopyToStandard(id int64, newName string) (*domain.WOD, e
 		UpdatedAt:   now,
 	}
 
-	query := `INSERT INTO wods (name, source, type, regime, score_type, description, url, notes, is_standard, created_by, created_at, updated_at)
-	          VALUES (?, ?, ?, ?, ?, ?, ?, ?, 1, NULL, ?, ?)`
-
-	result, err := r.db.Exec(query,
-		standardWOD.Name,
-		standardWOD.Source,
-		standardWOD.Type,
-		standardWOD.Regime,
-		standardWOD.ScoreType,
-		standardWOD.Description,
-		standardWOD.URL,
-		standardWOD.Notes,
-		standardWOD.CreatedAt,
-		standardWOD.UpdatedAt,
-	)
+	tx, err := r.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create standard wod: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	newID, err := result.LastInsertId()
+	newID, err := createWOD(tx, standardWOD)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get new wod ID: %w", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit standard wod: %w", err)
 	}
 
 	standardWOD.ID = newID
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// WODStructureRepository implements domain.WODStructureRepository
+type WODStructureRepository struct {
+	db *sql.DB
+}
+
+// NewWODStructureRepository creates a new WOD structure repository
+func NewWODStructureRepository(db *sql.DB) *WODStructureRepository {
+	return &WODStructureRepository{db: db}
+}
+
+const wodStructureColumns = `ws.wod_id, ws.rounds, ws.rep_scheme, ws.time_cap_seconds, ws.notes, ws.confidence,
+	ws.needs_review, ws.warnings, ws.source, ws.reviewed_by, ws.created_at, ws.updated_at`
+
+// GetByWODID retrieves a WOD's structure with its components
+func (r *WODStructureRepository) GetByWODID(wodID int64) (*domain.WODStructure, error) {
+	query := rebindQuery(`SELECT ` + wodStructureColumns + `, w.name
+		FROM wod_structures ws
+		JOIN wods w ON w.id = ws.wod_id
+		WHERE ws.wod_id = ?`)
+
+	structure, err := scanWODStructure(r.db.QueryRow(query, wodID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get wod structure: %w", err)
+	}
+
+	if structure.Components, err = r.getComponents(wodID); err != nil {
+		return nil, err
+	}
+	return structure, nil
+}
+
+// Save creates or replaces a WOD's structure, replacing all of its components
+func (r *WODStructureRepository) Save(structure *domain.WODStructure) error {
+	warnings, err := json.Marshal(structure.Warnings)
+	if err != nil {
+		return fmt.Errorf("failed to encode structure warnings: %w", err)
+	}
+	if structure.Warnings == nil {
+		warnings = []byte("[]")
+	}
+
+	now := time.Now()
+	if structure.CreatedAt.IsZero() {
+		structure.CreatedAt = now
+	}
+	structure.UpdatedAt = now
+
+	var repScheme *string
+	if len(structure.RepScheme) > 0 {
+		scheme := domain.FormatRepScheme(structure.RepScheme)
+		repScheme = &scheme
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_components WHERE wod_id = ?`), structure.WODID); err != nil {
+		return fmt.Errorf("failed to clear wod components: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_structures WHERE wod_id = ?`), structure.WODID); err != nil {
+		return fmt.Errorf("failed to clear wod structure: %w", err)
+	}
+
+	_, err = tx.Exec(rebindQuery(`INSERT INTO wod_structures
+		(wod_id, rounds, rep_scheme, time_cap_seconds, notes, confidence, needs_review, warnings, source, reviewed_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		structure.WODID, structure.Rounds, repScheme, structure.TimeCapSeconds, structure.Notes, structure.Confidence,
+		structure.NeedsReview, string(warnings), structure.Source, structure.ReviewedBy, structure.CreatedAt, structure.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save wod structure: %w", err)
+	}
+
+	query := rebindQuery(`INSERT INTO wod_components
+		(wod_id, order_index, movement_id, movement_name, reps, calories, distance, duration_seconds, weight_male, weight_female, weight_unit, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	for i, c := range structure.Components {
+		c.WODID = structure.WODID
+		c.OrderIndex = i
+		args := []interface{}{c.WODID, c.OrderIndex, c.MovementID, c.MovementName, c.Reps, c.Calories, c.Distance,
+			c.DurationSeconds, c.WeightMale, c.WeightFemale, c.WeightUnit, c.Notes}
+
+		if currentDriver == "postgres" {
+			err = tx.QueryRow(query+" RETURNING id", args...).Scan(&c.ID)
+		} else {
+			var result sql.Result
+			result, err = tx.Exec(query, args...)
+			if err == nil {
+				c.ID, err = result.LastInsertId()
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save wod component: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// DeleteByWODID removes a WOD's structure and components
+func (r *WODStructureRepository) DeleteByWODID(wodID int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_components WHERE wod_id = ?`), wodID); err != nil {
+		return fmt.Errorf("failed to delete wod components: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_structures WHERE wod_id = ?`), wodID); err != nil {
+		return fmt.Errorf("failed to delete wod structure: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListForReview lists structures flagged for review, lowest confidence first
+func (r *WODStructureRepository) ListForReview(limit, offset int) ([]*domain.WODStructure, int, error) {
+	var total int
+	if err := r.db.QueryRow(rebindQuery(`SELECT COUNT(*) FROM wod_structures WHERE needs_review = ?`), true).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count wod structures for review: %w", err)
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	query := rebindQuery(`SELECT ` + wodStructureColumns + `, w.name
+		FROM wod_structures ws
+		JOIN wods w ON w.id = ws.wod_id
+		WHERE ws.needs_review = ?
+		ORDER BY ws.confidence, w.name
+		LIMIT ? OFFSET ?`)
+
+	rows, err := r.db.Query(query, true, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list wod structures for review: %w", err)
+	}
+	defer rows.Close()
+
+	var structures []*domain.WODStructure
+	for rows.Next() {
+		structure, err := scanWODStructure(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan wod structure: %w", err)
+		}
+		structures = append(structures, structure)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	for _, structure := range structures {
+		if structure.Components, err = r.getComponents(structure.WODID); err != nil {
+			return nil, 0, err
+		}
+	}
+	return structures, total, nil
+}
+
+// ListUnstructuredWODIDs lists the WODs that have no structure yet
+func (r *WODStructureRepository) ListUnstructuredWODIDs() ([]int64, error) {
+	return r.listIDs(`SELECT w.id FROM wods w
+		LEFT JOIN wod_structures ws ON ws.wod_id = w.id
+		WHERE ws.wod_id IS NULL
+		ORDER BY w.id`)
+}
+
+// ListParsedWODIDs lists the WODs whose structure came from the parser
+func (r *WODStructureRepository) ListParsedWODIDs() ([]int64, error) {
+	return r.listIDs(`SELECT wod_id FROM wod_structures WHERE source = ? ORDER BY wod_id`, domain.WODStructureSourceParsed)
+}
+
+func (r *WODStructureRepository) listIDs(query string, args ...interface{}) ([]int64, error) {
+	rows, err := r.db.Query(rebindQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wod ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan wod id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *WODStructureRepository) getComponents(wodID int64) ([]*domain.WODComponent, error) {
+	query := rebindQuery(`SELECT id, wod_id, order_index, movement_id, movement_name, reps, calories, distance,
+		duration_seconds, weight_male, weight_female, weight_unit, notes
+		FROM wod_components
+		WHERE wod_id = ?
+		ORDER BY order_index, id`)
+
+	rows, err := r.db.Query(query, wodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wod components: %w", err)
+	}
+	defer rows.Close()
+
+	components := []*domain.WODComponent{}
+	for rows.Next() {
+		c := &domain.WODComponent{}
+		if err := rows.Scan(&c.ID, &c.WODID, &c.OrderIndex, &c.MovementID, &c.MovementName, &c.Reps, &c.Calories, &c.Distance,
+			&c.DurationSeconds, &c.WeightMale, &c.WeightFemale, &c.WeightUnit, &c.Notes); err != nil {
+			return nil, fmt.Errorf("failed to scan wod component: %w", err)
+		}
+		components = append(components, c)
+	}
+	return components, rows.Err()
+}
+
+func scanWODStructure(row rowScanner) (*domain.WODStructure, error) {
+	s := &domain.WODStructure{}
+	var repScheme sql.NullString
+	var warnings string
+
+	if err := row.Scan(&s.WODID, &s.Rounds, &repScheme, &s.TimeCapSeconds, &s.Notes, &s.Confidence,
+		&s.NeedsReview, &warnings, &s.Source, &s.ReviewedBy, &s.CreatedAt, &s.UpdatedAt, &s.WODName); err != nil {
+		return nil, err
+	}
+
+	if repScheme.Valid {
+		s.RepScheme = domain.ParseRepScheme(repScheme.String)
+	}
+	if warnings != "" {
+		if err := json.Unmarshal([]byte(warnings), &s.Warnings); err != nil {
+			return nil, fmt.Errorf("failed to decode structure warnings: %w", err)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// currentWODVersionCondition keeps the user_workout_wods rows (aliased uww) performed on the current
+// comparable run of their WOD. Results of WODs that were never edited have no version and always count.
+const currentWODVersionCondition = `(uww.wod_version_id IS NULL OR uww.wod_version_id IN (
+	SELECT cv.id FROM wod_versions cv WHERE cv.wod_id = uww.wod_id AND cv.baseline_version = (
+		SELECT MAX(lv.baseline_version) FROM wod_versions lv WHERE lv.wod_id = uww.wod_id)))`
+
+// latestWODVersionID selects the ID of a WOD's latest version (NULL before its first edit); the WOD ID is the only argument
+const latestWODVersionID = `(SELECT MAX(id) FROM wod_versions WHERE wod_id = ?)`
+
+// WODVersionRepository implements domain.WODVersionRepository
+type WODVersionRepository struct {
+	db *sql.DB
+}
+
+// NewWODVersionRepository creates a new WOD version repository
+func NewWODVersionRepository(db *sql.DB) *WODVersionRepository {
+	return &WODVersionRepository{db: db}
+}
+
+const wodVersionColumns = `id, wod_id, version, name, source, type, regime, score_type, description, url, notes,
+	comparable_with_previous, baseline_version, created_by, created_at`
+
+// SaveEdit updates a WOD and saves the versions recorded for the edit in one transaction; when version 1
+// is among them, the WOD's results without a version are assigned to it
+func (r *WODVersionRepository) SaveEdit(wod *domain.WOD, versions []*domain.WODVersion) error {
+	wod.UpdatedAt = time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(rebindQuery(`UPDATE wods
+		SET name = ?, source = ?, type = ?, regime = ?, score_type = ?, description = ?, url = ?, notes = ?, updated_at = ?
+		WHERE id = ? AND is_standard = ?`),
+		wod.Name, wod.Source, wod.Type, wod.Regime, wod.ScoreType, wod.Description, wod.URL, wod.Notes, wod.UpdatedAt,
+		wod.ID, wod.IsStandard)
+	if err != nil {
+		return fmt.Errorf("failed to update wod: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("wod not found")
+	}
+
+	for _, version := range versions {
+		if err := createWODVersion(tx, version); err != nil {
+			return err
+		}
+		if version.Version != 1 {
+			continue
+		}
+		if _, err := tx.Exec(rebindQuery(`UPDATE user_workout_wods SET wod_version_id = ? WHERE wod_id = ? AND wod_version_id IS NULL`),
+			version.ID, wod.ID); err != nil {
+			return fmt.Errorf("failed to assign results to wod version: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// createWODVersion saves a new version snapshot within a transaction
+func createWODVersion(tx *sql.Tx, version *domain.WODVersion) error {
+	if version.CreatedAt.IsZero() {
+		version.CreatedAt = time.Now()
+	}
+
+	query := rebindQuery(`INSERT INTO wod_versions (wod_id, version, name, source, type, regime, score_type, description, url, notes,
+		comparable_with_previous, baseline_version, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	args := []interface{}{version.WODID, version.Version, version.Name, version.Source, version.Type, version.Regime, version.ScoreType,
+		version.Description, version.URL, version.Notes, version.ComparableWithPrevious, version.BaselineVersion, version.CreatedBy, version.CreatedAt}
+
+	var err error
+	if currentDriver == "postgres" {
+		err = tx.QueryRow(query+" RETURNING id", args...).Scan(&version.ID)
+	} else {
+		var result sql.Result
+		result, err = tx.Exec(query, args...)
+		if err == nil {
+			version.ID, err = result.LastInsertId()
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create wod version: %w", err)
+	}
+	return nil
+}
+
+// createMissingWODVersions records the current definition as version 1 of every WOD without versions
+// (WODs seeded with plain INSERTs)
+func createMissingWODVersions(db *sql.DB) error {
+	if _, err := db.Exec(`INSERT INTO wod_versions (wod_id, version, name, source, type, regime, score_type, description, url, notes,
+		baseline_version, created_by, created_at)
+		SELECT w.id, 1, w.name, w.source, w.type, w.regime, w.score_type, w.description, w.url, w.notes,
+			1, w.created_by, COALESCE(w.updated_at, w.created_at, CURRENT_TIMESTAMP)
+		FROM wods w
+		WHERE NOT EXISTS (SELECT 1 FROM wod_versions v WHERE v.wod_id = w.id)`); err != nil {
+		return fmt.Errorf("failed to create wod versions: %w", err)
+	}
+	return nil
+}
+
+// ListVersions lists a WOD's versions, oldest first
+func (r *WODVersionRepository) ListVersions(wodID int64) ([]*domain.WODVersion, error) {
+	rows, err := r.db.Query(rebindQuery(`SELECT `+wodVersionColumns+` FROM wod_versions WHERE wod_id = ? ORDER BY version`), wodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wod versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*domain.WODVersion{}
+	for rows.Next() {
+		version, err := scanWODVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan wod version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
+
+// UpdateComparability saves the comparable flag and baseline of existing versions
+func (r *WODVersionRepository) UpdateComparability(versions []*domain.WODVersion) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := rebindQuery(`UPDATE wod_versions SET comparable_with_previous = ?, baseline_version = ? WHERE id = ?`)
+	for _, version := range versions {
+		if _, err := tx.Exec(query, version.ComparableWithPrevious, version.BaselineVersion, version.ID); err != nil {
+			return fmt.Errorf("failed to update wod version %d: %w", version.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteForWOD deletes a WOD's versions and variant links
+func (r *WODVersionRepository) DeleteForWOD(wodID int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(rebindQuery(`UPDATE user_workout_wods SET wod_version_id = NULL WHERE wod_id = ?`), wodID); err != nil {
+		return fmt.Errorf("failed to clear result versions: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_versions WHERE wod_id = ?`), wodID); err != nil {
+		return fmt.Errorf("failed to delete wod versions: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_variants WHERE wod_id = ? OR parent_wod_id = ?`), wodID, wodID); err != nil {
+		return fmt.Errorf("failed to delete wod variants: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetVariant retrieves the link of a variant WOD to its parent (nil when the WOD is not a variant)
+func (r *WODVersionRepository) GetVariant(wodID int64) (*domain.WODVariant, error) {
+	variant := &domain.WODVariant{}
+	err := r.db.QueryRow(rebindQuery(`SELECT v.wod_id, v.parent_wod_id, v.kind, v.comparable, v.created_at, w.name
+		FROM wod_variants v JOIN wods w ON w.id = v.wod_id
+		WHERE v.wod_id = ?`), wodID).Scan(&variant.WODID, &variant.ParentWODID, &variant.Kind, &variant.Comparable,
+		&variant.CreatedAt, &variant.WODName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get wod variant: %w", err)
+	}
+	return variant, nil
+}
+
+// ListVariants lists a parent WOD's variants by kind and name
+func (r *WODVersionRepository) ListVariants(parentWODID int64) ([]*domain.WODVariant, error) {
+	rows, err := r.db.Query(rebindQuery(`SELECT v.wod_id, v.parent_wod_id, v.kind, v.comparable, v.created_at, w.name
+		FROM wod_variants v JOIN wods w ON w.id = v.wod_id
+		WHERE v.parent_wod_id = ?
+		ORDER BY v.kind, w.name`), parentWODID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wod variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := []*domain.WODVariant{}
+	for rows.Next() {
+		variant := &domain.WODVariant{}
+		if err := rows.Scan(&variant.WODID, &variant.ParentWODID, &variant.Kind, &variant.Comparable,
+			&variant.CreatedAt, &variant.WODName); err != nil {
+			return nil, fmt.Errorf("failed to scan wod variant: %w", err)
+		}
+		variants = append(variants, variant)
+	}
+	return variants, rows.Err()
+}
+
+// SaveVariant creates or replaces a variant link
+func (r *WODVersionRepository) SaveVariant(variant *domain.WODVariant) error {
+	if variant.CreatedAt.IsZero() {
+		variant.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(rebindQuery(`DELETE FROM wod_variants WHERE wod_id = ?`), variant.WODID); err != nil {
+		return fmt.Errorf("failed to replace wod variant: %w", err)
+	}
+	if _, err := tx.Exec(rebindQuery(`INSERT INTO wod_variants (wod_id, parent_wod_id, kind, comparable, created_at) VALUES (?, ?, ?, ?, ?)`),
+		variant.WODID, variant.ParentWODID, variant.Kind, variant.Comparable, variant.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save wod variant: %w", err)
+	}
+	return tx.Commit()
+}
+
+// DeleteVariant removes a variant link; the variant WOD itself is kept
+func (r *WODVersionRepository) DeleteVariant(wodID int64) error {
+	if _, err := r.db.Exec(rebindQuery(`DELETE FROM wod_variants WHERE wod_id = ?`), wodID); err != nil {
+		return fmt.Errorf("failed to delete wod variant: %w", err)
+	}
+	return nil
+}
+
+// ListUserIDsWithResults lists the users with logged results on any of the given WODs
+func (r *WODVersionRepository) ListUserIDsWithResults(wodIDs []int64) ([]int64, error) {
+	if len(wodIDs) == 0 {
+		return nil, nil
+	}
+	in, args := idList(wodIDs)
+
+	rows, err := r.db.Query(rebindQuery(`SELECT DISTINCT uw.user_id FROM user_workouts uw
+		JOIN user_workout_wods uww ON uww.user_workout_id = uw.id
+		WHERE uww.wod_id IN `+in+`
+		ORDER BY uw.user_id`), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users with wod results: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+func scanWODVersion(row rowScanner) (*domain.WODVersion, error) {
+	version := &domain.WODVersion{}
+	var source, wodType, regime, scoreType, description, url, notes sql.NullString
+	var createdBy sql.NullInt64
+	if err := row.Scan(&version.ID, &version.WODID, &version.Version, &version.Name, &source, &wodType, &regime, &scoreType,
+		&description, &url, &notes, &version.ComparableWithPrevious, &version.BaselineVersion, &createdBy, &version.CreatedAt); err != nil {
+		return nil, err
+	}
+	version.Source = source.String
+	version.Type = wodType.String
+	version.Regime = regime.String
+	version.ScoreType = scoreType.String
+	version.Description = description.String
+	if url.Valid {
+		version.URL = &url.String
+	}
+	if notes.Valid {
+		version.Notes = &notes.String
+	}
+	if createdBy.Valid {
+		version.CreatedBy = &createdBy.Int64
+	}
+	return version, nil
+}
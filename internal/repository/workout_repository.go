@@ -383,7 +383,8 @@ func (r *WorkoutRepository) CountAllUserCreated() (int64, error) {
 }
 
 // ListAllUserCreatedWithUserInfoFiltered retrieves all user-created workout templates with creator info and filters (for admin view)
-func (r *WorkoutRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string) ([]*domain.WorkoutWithCreator, int64, error) {
+// A positive orgID limits the list to items created by members of that gym
+func (r *WorkoutRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string, orgID int64) ([]*domain.WorkoutWithCreator, int64, error) {
 	baseQuery := `SELECT w.id, w.name, w.notes, w.created_by, w.created_at, w.updated_at,
 	                 COALESCE(u.email, '') as creator_email, COALESCE(u.name, '') as creator_name
 	          FROM workouts w
@@ -410,6 +411,12 @@ func (r *WorkoutRepository) ListAllUserCreatedWithUserInfoFiltered(limit, offset
 		args = append(args, creatorTerm)
 		countArgs = append(countArgs, creatorTerm)
 	}
+	if orgID > 0 {
+		baseQuery += " AND w.created_by IN (SELECT user_id FROM organization_members WHERE organization_id = ?)"
+		countQuery += " AND w.created_by IN (SELECT user_id FROM organization_members WHERE organization_id = ?)"
+		args = append(args, orgID)
+		countArgs = append(countArgs, orgID)
+	}
 
 	// Get count first
 	var count int64
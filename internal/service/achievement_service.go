@@ -0,0 +1,463 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrAchievementRuleNotFound  = errors.New("achievement rule not found")
+	ErrAchievementCodeTaken     = errors.New("an achievement rule with this code already exists")
+	ErrInvalidAchievementCode   = errors.New("code must be lowercase letters, digits and underscores")
+	ErrAchievementNameRequired  = errors.New("achievement name is required")
+	ErrInvalidAchievementType   = errors.New("rule type must be workout_count, wod_count, pr_count or wod_every_year")
+	ErrInvalidAchievementParams = errors.New("rule params are not valid for this rule type")
+	ErrInvalidAchievementPeriod = errors.New("period must be week, month or year")
+	ErrInvalidAchievementSeed   = errors.New("invalid achievement seed file")
+)
+
+var achievementCodePattern = regexp.MustCompile(`^[a-z0-9_]{1,100}$`)
+
+// AchievementSummary lists a user's earned badges and the active badges still available
+type AchievementSummary struct {
+	Earned    []*domain.UserAchievement `json:"earned"`
+	Available []*domain.AchievementRule `json:"available"`
+}
+
+// AchievementBackfillResult reports the outcome of evaluating stored history
+type AchievementBackfillResult struct {
+	UsersEvaluated int `json:"users_evaluated"`
+	Awarded        int `json:"awarded"`
+}
+
+// AchievementService manages achievement rules and awards badges from logged history
+type AchievementService struct {
+	achievementRepo domain.AchievementRepository
+	userWorkoutRepo domain.UserWorkoutRepository
+	now             func() time.Time
+}
+
+// NewAchievementService creates a new achievement service
+func NewAchievementService(achievementRepo domain.AchievementRepository, userWorkoutRepo domain.UserWorkoutRepository) *AchievementService {
+	return &AchievementService{
+		achievementRepo: achievementRepo,
+		userWorkoutRepo: userWorkoutRepo,
+		now:             time.Now,
+	}
+}
+
+// SeedRules loads the default rules from a JSON file when no rules exist yet; returns the number loaded
+func (s *AchievementService) SeedRules(jsonData io.Reader) (int, error) {
+	count, err := s.achievementRepo.CountRules()
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, nil
+	}
+
+	var rules []*domain.AchievementRule
+	if err := json.NewDecoder(jsonData).Decode(&rules); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidAchievementSeed, err)
+	}
+
+	for _, rule := range rules {
+		rule.IsActive = true
+		rule.CreatedBy = nil
+		if err := s.validateRule(rule); err != nil {
+			return 0, fmt.Errorf("%w: %s: %v", ErrInvalidAchievementSeed, rule.Code, err)
+		}
+		if err := s.achievementRepo.CreateRule(rule); err != nil {
+			return 0, err
+		}
+	}
+	return len(rules), nil
+}
+
+// ListRules returns every rule, including disabled ones (admin)
+func (s *AchievementService) ListRules() ([]*domain.AchievementRule, error) {
+	return s.achievementRepo.ListRules(false)
+}
+
+// CreateRule validates and stores a new rule (admin)
+func (s *AchievementService) CreateRule(adminID int64, rule *domain.AchievementRule) (*domain.AchievementRule, error) {
+	if err := s.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.achievementRepo.GetRuleByCode(rule.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAchievementCodeTaken
+	}
+
+	rule.CreatedBy = &adminID
+	if err := s.achievementRepo.CreateRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// UpdateRule replaces a rule's definition (admin); badges already awarded are kept
+func (s *AchievementService) UpdateRule(id int64, updates *domain.AchievementRule) (*domain.AchievementRule, error) {
+	rule, err := s.achievementRepo.GetRuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, ErrAchievementRuleNotFound
+	}
+
+	if err := s.validateRule(updates); err != nil {
+		return nil, err
+	}
+	if updates.Code != rule.Code {
+		existing, err := s.achievementRepo.GetRuleByCode(updates.Code)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrAchievementCodeTaken
+		}
+	}
+
+	rule.Code = updates.Code
+	rule.Name = updates.Name
+	rule.Description = updates.Description
+	rule.Icon = updates.Icon
+	rule.RuleType = updates.RuleType
+	rule.Params = updates.Params
+	rule.IsActive = updates.IsActive
+
+	if err := s.achievementRepo.UpdateRule(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteRule removes a rule and the badges awarded for it (admin)
+func (s *AchievementService) DeleteRule(id int64) error {
+	rule, err := s.achievementRepo.GetRuleByID(id)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return ErrAchievementRuleNotFound
+	}
+	return s.achievementRepo.DeleteRule(id)
+}
+
+// GetUserAchievements returns a user's badges and the active rules they have not earned yet
+func (s *AchievementService) GetUserAchievements(userID int64) (*AchievementSummary, error) {
+	earned, err := s.achievementRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.achievementRepo.ListRules(true)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[int64]bool, len(earned))
+	for _, a := range earned {
+		held[a.RuleID] = true
+	}
+
+	summary := &AchievementSummary{
+		Earned:    earned,
+		Available: []*domain.AchievementRule{},
+	}
+	if summary.Earned == nil {
+		summary.Earned = []*domain.UserAchievement{}
+	}
+	for _, rule := range rules {
+		if !held[rule.ID] {
+			summary.Available = append(summary.Available, rule)
+		}
+	}
+	return summary, nil
+}
+
+// EvaluateUser checks every active rule the user does not hold yet against their
+// full history and awards the ones now met; returns the new badges
+func (s *AchievementService) EvaluateUser(userID int64) ([]*domain.UserAchievement, error) {
+	rules, err := s.achievementRepo.ListRules(true)
+	if err != nil {
+		return nil, err
+	}
+	earned, err := s.achievementRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[int64]bool, len(earned))
+	for _, a := range earned {
+		held[a.RuleID] = true
+	}
+
+	history := &achievementHistory{service: s, userID: userID}
+	var awarded []*domain.UserAchievement
+	for _, rule := range rules {
+		if held[rule.ID] {
+			continue
+		}
+
+		trigger, err := history.trigger(rule, s.now().Year())
+		if err != nil {
+			return awarded, err
+		}
+		if trigger == nil {
+			continue
+		}
+
+		workoutID := trigger.UserWorkoutID
+		workoutDate := trigger.WorkoutDate.Format(domain.ScheduledDateFormat)
+		achievement := &domain.UserAchievement{
+			UserID:        userID,
+			RuleID:        rule.ID,
+			UserWorkoutID: &workoutID,
+			WorkoutDate:   &workoutDate,
+			AwardedAt:     s.now(),
+			Code:          rule.Code,
+			Name:          rule.Name,
+			Description:   rule.Description,
+			Icon:          rule.Icon,
+		}
+		if err := s.achievementRepo.Award(achievement); err != nil {
+			return awarded, err
+		}
+		awarded = append(awarded, achievement)
+	}
+
+	return awarded, nil
+}
+
+// Backfill evaluates one user's history, or every user's when userID is nil
+func (s *AchievementService) Backfill(userID *int64) (*AchievementBackfillResult, error) {
+	var userIDs []int64
+	if userID != nil {
+		userIDs = []int64{*userID}
+	} else {
+		ids, err := s.achievementRepo.ListUserIDsWithWorkouts()
+		if err != nil {
+			return nil, err
+		}
+		userIDs = ids
+	}
+
+	result := &AchievementBackfillResult{}
+	for _, id := range userIDs {
+		awarded, err := s.EvaluateUser(id)
+		result.Awarded += len(awarded)
+		if err != nil {
+			return result, fmt.Errorf("failed to evaluate achievements for user %d: %w", id, err)
+		}
+		result.UsersEvaluated++
+	}
+	return result, nil
+}
+
+func (s *AchievementService) validateRule(rule *domain.AchievementRule) error {
+	rule.Code = strings.TrimSpace(rule.Code)
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Description = strings.TrimSpace(rule.Description)
+	p := &rule.Params
+	p.WODType = strings.TrimSpace(p.WODType)
+	p.WODName = strings.TrimSpace(p.WODName)
+	p.Period = strings.ToLower(strings.TrimSpace(p.Period))
+
+	if !achievementCodePattern.MatchString(rule.Code) {
+		return ErrInvalidAchievementCode
+	}
+	if rule.Name == "" {
+		return ErrAchievementNameRequired
+	}
+
+	switch rule.RuleType {
+	case domain.AchievementRuleWorkoutCount:
+		if p.Count < 1 {
+			return ErrInvalidAchievementParams
+		}
+	case domain.AchievementRuleWODCount:
+		if p.Count < 1 || (p.WODType == "" && p.WODName == "") {
+			return ErrInvalidAchievementParams
+		}
+	case domain.AchievementRulePRCount:
+		if p.Count < 1 {
+			return ErrInvalidAchievementParams
+		}
+		if p.Period != "" && p.Period != "week" && p.Period != "month" && p.Period != "year" {
+			return ErrInvalidAchievementPeriod
+		}
+	case domain.AchievementRuleWODEveryYear:
+		if p.WODName == "" || p.SinceYear < 1900 || p.SinceYear > s.now().Year() {
+			return ErrInvalidAchievementParams
+		}
+	default:
+		return ErrInvalidAchievementType
+	}
+	return nil
+}
+
+// achievementHistory lazily loads the user's history once per evaluation
+type achievementHistory struct {
+	service  *AchievementService
+	userID   int64
+	workouts []*domain.AchievementEvent
+	wods     []*domain.AchievementEvent
+	prs      []*domain.AchievementEvent
+	loaded   map[string]bool
+}
+
+func (h *achievementHistory) load(kind string) error {
+	if h.loaded == nil {
+		h.loaded = make(map[string]bool)
+	}
+	if h.loaded[kind] {
+		return nil
+	}
+
+	var err error
+	switch kind {
+	case domain.AchievementRuleWorkoutCount:
+		h.workouts, err = h.service.listWorkoutEvents(h.userID)
+	case domain.AchievementRuleWODCount:
+		h.wods, err = h.service.achievementRepo.ListWODEvents(h.userID)
+	case domain.AchievementRulePRCount:
+		h.prs, err = h.service.achievementRepo.ListPREvents(h.userID)
+	}
+	if err != nil {
+		return err
+	}
+	h.loaded[kind] = true
+	return nil
+}
+
+// trigger returns the event that met the rule, or nil if the rule is not met yet
+func (h *achievementHistory) trigger(rule *domain.AchievementRule, currentYear int) (*domain.AchievementEvent, error) {
+	p := rule.Params
+	switch rule.RuleType {
+	case domain.AchievementRuleWorkoutCount:
+		if err := h.load(domain.AchievementRuleWorkoutCount); err != nil {
+			return nil, err
+		}
+		return nthAchievementEvent(h.workouts, p.Count), nil
+	case domain.AchievementRuleWODCount:
+		if err := h.load(domain.AchievementRuleWODCount); err != nil {
+			return nil, err
+		}
+		return nthAchievementEvent(filterWODEvents(h.wods, p.WODType, p.WODName), p.Count), nil
+	case domain.AchievementRulePRCount:
+		if err := h.load(domain.AchievementRulePRCount); err != nil {
+			return nil, err
+		}
+		return periodCountTrigger(h.prs, p.Period, p.Count), nil
+	case domain.AchievementRuleWODEveryYear:
+		if err := h.load(domain.AchievementRuleWODCount); err != nil {
+			return nil, err
+		}
+		return everyYearTrigger(filterWODEvents(h.wods, "", p.WODName), p.SinceYear, currentYear), nil
+	}
+	return nil, nil
+}
+
+// listWorkoutEvents returns all of a user's logged workouts as events, oldest first
+func (s *AchievementService) listWorkoutEvents(userID int64) ([]*domain.AchievementEvent, error) {
+	workouts, err := s.userWorkoutRepo.ListByUserAndDateRange(userID, time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.AchievementEvent, 0, len(workouts))
+	for _, uw := range workouts {
+		events = append(events, &domain.AchievementEvent{UserWorkoutID: uw.ID, WorkoutDate: uw.WorkoutDate})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].WorkoutDate.Equal(events[j].WorkoutDate) {
+			return events[i].WorkoutDate.Before(events[j].WorkoutDate)
+		}
+		return events[i].UserWorkoutID < events[j].UserWorkoutID
+	})
+	return events, nil
+}
+
+// nthAchievementEvent returns the nth (1-based) event, or nil if there are fewer
+func nthAchievementEvent(events []*domain.AchievementEvent, n int) *domain.AchievementEvent {
+	if n < 1 || len(events) < n {
+		return nil
+	}
+	return events[n-1]
+}
+
+// filterWODEvents keeps the WOD results matching a WOD type and/or name (case-insensitive)
+func filterWODEvents(events []*domain.AchievementEvent, wodType, wodName string) []*domain.AchievementEvent {
+	var filtered []*domain.AchievementEvent
+	for _, e := range events {
+		if wodType != "" && !strings.EqualFold(e.WODType, wodType) {
+			continue
+		}
+		if wodName != "" && !strings.EqualFold(e.WODName, wodName) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// periodCountTrigger returns the first event that brings its calendar week (ISO),
+// month or year to count events; an empty period counts all time
+func periodCountTrigger(events []*domain.AchievementEvent, period string, count int) *domain.AchievementEvent {
+	buckets := make(map[string]int)
+	for _, e := range events {
+		var key string
+		switch period {
+		case "week":
+			year, week := e.WorkoutDate.ISOWeek()
+			key = fmt.Sprintf("%d-W%02d", year, week)
+		case "month":
+			key = e.WorkoutDate.Format("2006-01")
+		case "year":
+			key = e.WorkoutDate.Format("2006")
+		}
+		buckets[key]++
+		if buckets[key] >= count {
+			return e
+		}
+	}
+	return nil
+}
+
+// everyYearTrigger returns the event completing a result in every calendar year from
+// sinceYear through currentYear (the first result of the latest year), or nil if a year is missing
+func everyYearTrigger(events []*domain.AchievementEvent, sinceYear, currentYear int) *domain.AchievementEvent {
+	if sinceYear > currentYear {
+		return nil
+	}
+
+	firstByYear := make(map[int]*domain.AchievementEvent)
+	for _, e := range events {
+		year := e.WorkoutDate.Year()
+		if _, ok := firstByYear[year]; !ok {
+			firstByYear[year] = e
+		}
+	}
+
+	var trigger *domain.AchievementEvent
+	for year := sinceYear; year <= currentYear; year++ {
+		e, ok := firstByYear[year]
+		if !ok {
+			return nil
+		}
+		trigger = e
+	}
+	return trigger
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+func achievementEvent(id int64, date string, wodName, wodType string) *domain.AchievementEvent {
+	d, _ := time.Parse(domain.ScheduledDateFormat, date)
+	return &domain.AchievementEvent{UserWorkoutID: id, WorkoutDate: d, WODName: wodName, WODType: wodType}
+}
+
+func TestAchievementTriggers(t *testing.T) {
+	wods := []*domain.AchievementEvent{
+		achievementEvent(1, "2023-05-29", "Murph", "Hero"),
+		achievementEvent(2, "2023-06-02", "Fran", "Girl"),
+		achievementEvent(3, "2024-05-27", "Murph", "Hero"),
+		achievementEvent(4, "2025-05-26", "DT", "Hero"),
+		achievementEvent(5, "2026-05-25", "murph", "Hero"),
+	}
+
+	if got := nthAchievementEvent(filterWODEvents(wods, "hero", ""), 3); got == nil || got.UserWorkoutID != 4 {
+		t.Errorf("expected the third Hero WOD to be workout 4, got %+v", got)
+	}
+	if got := nthAchievementEvent(filterWODEvents(wods, "Girl", ""), 2); got != nil {
+		t.Errorf("expected no second Girl WOD, got %+v", got)
+	}
+
+	murph := filterWODEvents(wods, "", "Murph")
+	if got := everyYearTrigger(murph, 2023, 2024); got == nil || got.UserWorkoutID != 3 {
+		t.Errorf("expected Murph 2023-2024 to be completed by workout 3, got %+v", got)
+	}
+	// 2025 has no Murph
+	if got := everyYearTrigger(murph, 2023, 2026); got != nil {
+		t.Errorf("expected a missing year to block the award, got %+v", got)
+	}
+
+	prs := []*domain.AchievementEvent{
+		achievementEvent(10, "2026-01-30", "", ""),
+		achievementEvent(11, "2026-01-31", "", ""),
+		achievementEvent(12, "2026-02-01", "", ""),
+		achievementEvent(13, "2026-02-03", "", ""),
+		achievementEvent(14, "2026-02-20", "", ""),
+	}
+	if got := periodCountTrigger(prs, "month", 3); got == nil || got.UserWorkoutID != 14 {
+		t.Errorf("expected the third February PR (workout 14), got %+v", got)
+	}
+	// Jan 30 - Feb 1 fall in the same ISO week
+	if got := periodCountTrigger(prs, "week", 3); got == nil || got.UserWorkoutID != 12 {
+		t.Errorf("expected the third PR of the week (workout 12), got %+v", got)
+	}
+	if got := periodCountTrigger(prs, "", 5); got == nil || got.UserWorkoutID != 14 {
+		t.Errorf("expected the fifth PR overall (workout 14), got %+v", got)
+	}
+}
+
+func TestAchievementService_ValidateRule(t *testing.T) {
+	service := NewAchievementService(nil, nil)
+	service.now = func() time.Time { return time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		rule domain.AchievementRule
+		want error
+	}{
+		{"valid wod count", domain.AchievementRule{Code: "first_hero_wod", Name: "Hero", RuleType: domain.AchievementRuleWODCount, Params: domain.AchievementRuleParams{Count: 1, WODType: "Hero"}}, nil},
+		{"bad code", domain.AchievementRule{Code: "First Hero", Name: "Hero", RuleType: domain.AchievementRuleWorkoutCount, Params: domain.AchievementRuleParams{Count: 1}}, ErrInvalidAchievementCode},
+		{"unknown type", domain.AchievementRule{Code: "x", Name: "X", RuleType: "streak"}, ErrInvalidAchievementType},
+		{"wod count without filter", domain.AchievementRule{Code: "x", Name: "X", RuleType: domain.AchievementRuleWODCount, Params: domain.AchievementRuleParams{Count: 1}}, ErrInvalidAchievementParams},
+		{"bad period", domain.AchievementRule{Code: "x", Name: "X", RuleType: domain.AchievementRulePRCount, Params: domain.AchievementRuleParams{Count: 10, Period: "fortnight"}}, ErrInvalidAchievementPeriod},
+		{"future since year", domain.AchievementRule{Code: "x", Name: "X", RuleType: domain.AchievementRuleWODEveryYear, Params: domain.AchievementRuleParams{WODName: "Murph", SinceYear: 2027}}, ErrInvalidAchievementParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			if err := service.validateRule(&rule); err != tt.want {
+				t.Errorf("validateRule() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrAliasNotFound      = errors.New("alias not found")
+	ErrInvalidAlias       = errors.New("invalid alias")
+	ErrAliasConflict      = errors.New("alias already names another record")
+	ErrInvalidMerge       = errors.New("invalid merge")
+	ErrInvalidAliasEntity = errors.New("entity type must be movement or wod")
+)
+
+// MergeEntity identifies a movement or WOD taking part in a merge
+type MergeEntity struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	IsStandard bool   `json:"is_standard"`
+}
+
+// MergeResult describes duplicates merged, or to be merged on a dry run, into a target movement or WOD
+type MergeResult struct {
+	EntityType    string           `json:"entity_type"`
+	DryRun        bool             `json:"dry_run"`
+	Target        *MergeEntity     `json:"target"`
+	Sources       []*MergeEntity   `json:"sources"`
+	Repointed     map[string]int64 `json:"repointed"`     // Rows moved to the target, per table
+	AliasesAdded  []string         `json:"aliases_added"` // Source names kept as aliases of the target
+	AffectedUsers []int64          `json:"affected_users"`
+	PRsFlagged    int              `json:"prs_flagged"` // Performances newly flagged as PRs after recomputing
+
+	// Follow-up steps that failed after the merge was committed; the merge itself stands
+	PRRecomputeFailedUsers []int64 `json:"pr_recompute_failed_users,omitempty"` // Retry with RecomputePRs
+	ChangeLogFailedSources []int64 `json:"change_log_failed_sources,omitempty"` // Sources whose merge was not recorded in the data change log
+}
+
+// PRRecomputeResult is the outcome of recomputing the PRs of several users
+type PRRecomputeResult struct {
+	PRsFlagged  int     `json:"prs_flagged"`
+	FailedUsers []int64 `json:"failed_users,omitempty"`
+}
+
+// mergeLogEntry is the before value recorded in the data change log for each merged source
+type mergeLogEntry struct {
+	Record     interface{}         `json:"record"`
+	Aliases    []*domain.NameAlias `json:"aliases"`
+	References map[string]int64    `json:"references"`
+}
+
+// NameMatchResult is the outcome of matching a name against existing movements or WODs
+type NameMatchResult struct {
+	Name        string                  `json:"name"`
+	Match       *domain.NameCandidate   `json:"match,omitempty"`
+	Suggestions []*domain.NameCandidate `json:"suggestions"`
+}
+
+// nameEntity is the part of a movement or WOD that alias handling needs
+type nameEntity struct {
+	ID         int64
+	Name       string
+	IsStandard bool
+	Record     interface{}
+}
+
+// AliasService manages alternative movement and WOD names, name matching for imports and
+// merging of duplicates
+type AliasService struct {
+	aliasRepo            domain.NameAliasRepository
+	movementRepo         domain.MovementRepository
+	wodRepo              domain.WODRepository
+	dataChangeLogService *DataChangeLogService
+	userWorkoutService   *UserWorkoutService
+	searchService        *SearchService
+}
+
+// NewAliasService creates a new alias service
+func NewAliasService(aliasRepo domain.NameAliasRepository, movementRepo domain.MovementRepository, wodRepo domain.WODRepository, dataChangeLogService *DataChangeLogService) *AliasService {
+	return &AliasService{
+		aliasRepo:            aliasRepo,
+		movementRepo:         movementRepo,
+		wodRepo:              wodRepo,
+		dataChangeLogService: dataChangeLogService,
+	}
+}
+
+// SetUserWorkoutService enables PR recomputation for users whose performances a merge repoints
+func (s *AliasService) SetUserWorkoutService(userWorkoutService *UserWorkoutService) {
+	s.userWorkoutService = userWorkoutService
+}
+
+// SetSearchService removes merged duplicates from the search index
+func (s *AliasService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
+// ListAliases lists the aliases of a movement or WOD
+func (s *AliasService) ListAliases(entityType string, entityID int64) ([]*domain.NameAlias, error) {
+	if _, err := s.getEntity(entityType, entityID); err != nil {
+		return nil, err
+	}
+	return s.aliasRepo.ListByEntity(entityType, entityID)
+}
+
+// AddAlias adds an alternative name for a movement or WOD
+// The alias must not normalize to the name or alias of any other movement or WOD of the same type
+func (s *AliasService) AddAlias(userID int64, entityType string, entityID int64, name string) (*domain.NameAlias, error) {
+	entity, err := s.getEntity(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	normalized := domain.NormalizeName(name)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: alias must contain letters or digits", ErrInvalidAlias)
+	}
+	if len(name) > 255 {
+		return nil, fmt.Errorf("%w: alias must be at most 255 characters", ErrInvalidAlias)
+	}
+	if normalized == domain.NormalizeName(entity.Name) {
+		return nil, fmt.Errorf("%w: alias matches the %s's own name", ErrInvalidAlias, entityType)
+	}
+
+	// Aliases must be unique across every record, including other users' custom ones
+	matcher, err := s.matcher(nil, entityType)
+	if err != nil {
+		return nil, err
+	}
+	if existing, ok := matcher.Resolve(name); ok {
+		if existing.ID == entityID {
+			return nil, fmt.Errorf("%w: %q is already an alias of this %s", ErrAliasConflict, name, entityType)
+		}
+		return nil, fmt.Errorf("%w: %q already refers to %s", ErrAliasConflict, name, existing.Name)
+	}
+
+	alias := &domain.NameAlias{
+		EntityType:      entityType,
+		EntityID:        entityID,
+		Alias:           name,
+		NormalizedAlias: normalized,
+		CreatedBy:       &userID,
+	}
+	if err := s.aliasRepo.Create(alias); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
+
+// DeleteAlias removes an alias
+func (s *AliasService) DeleteAlias(id int64) error {
+	alias, err := s.aliasRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if alias == nil {
+		return ErrAliasNotFound
+	}
+	return s.aliasRepo.Delete(id)
+}
+
+// Match resolves a name against the standard movements or WODs and the user's own, and suggests close matches
+func (s *AliasService) Match(userID int64, entityType, name string, limit int) (*NameMatchResult, error) {
+	matcher, err := s.matcher(&userID, entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NameMatchResult{Name: name, Suggestions: []*domain.NameCandidate{}}
+	if match, ok := matcher.Resolve(name); ok {
+		result.Match = match
+	}
+	for _, candidate := range matcher.Suggest(name, limit+1) {
+		if result.Match != nil && candidate.ID == result.Match.ID {
+			continue
+		}
+		if len(result.Suggestions) < limit {
+			result.Suggestions = append(result.Suggestions, candidate)
+		}
+	}
+	return result, nil
+}
+
+// MovementMatcher builds a matcher over the standard movements, the user's own and their aliases
+func (s *AliasService) MovementMatcher(userID int64) (*NameMatcher, error) {
+	return buildMovementMatcher(s.movementRepo, s.aliasRepo, &userID)
+}
+
+// WODMatcher builds a matcher over the standard WODs, the user's own and their aliases
+func (s *AliasService) WODMatcher(userID int64) (*NameMatcher, error) {
+	return buildWODMatcher(s.wodRepo, s.aliasRepo, &userID)
+}
+
+// Merge folds duplicate movements or WODs into the target (admin only)
+// Every logged performance, template, goal and schedule entry is repointed to the target in one
+// transaction, the source names are kept as aliases so future imports resolve to the target, the
+// sources are deleted and the PRs of affected users are recomputed. A dry run reports what would
+// change without modifying anything.
+func (s *AliasService) Merge(adminID int64, adminEmail, entityType string, sourceIDs []int64, targetID int64, dryRun bool) (*MergeResult, error) {
+	if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one source is required", ErrInvalidMerge)
+	}
+	target, err := s.getEntity(entityType, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{
+		EntityType:    entityType,
+		DryRun:        dryRun,
+		Target:        &MergeEntity{ID: target.ID, Name: target.Name, IsStandard: target.IsStandard},
+		AliasesAdded:  []string{},
+		AffectedUsers: []int64{},
+	}
+
+	sources := make([]*nameEntity, 0, len(sourceIDs))
+	seen := map[int64]bool{}
+	keptNames := map[string]bool{domain.NormalizeName(target.Name): true}
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return nil, fmt.Errorf("%w: cannot merge a %s into itself", ErrInvalidMerge, entityType)
+		}
+		if seen[sourceID] {
+			return nil, fmt.Errorf("%w: %s %d is listed more than once", ErrInvalidMerge, entityType, sourceID)
+		}
+		seen[sourceID] = true
+
+		source, err := s.getEntity(entityType, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		if source.IsStandard && !target.IsStandard {
+			return nil, fmt.Errorf("%w: a standard %s can only be merged into another standard %s", ErrInvalidMerge, entityType, entityType)
+		}
+		sources = append(sources, source)
+		result.Sources = append(result.Sources, &MergeEntity{ID: source.ID, Name: source.Name, IsStandard: source.IsStandard})
+
+		normalized := domain.NormalizeName(source.Name)
+		if normalized == "" || keptNames[normalized] {
+			continue
+		}
+		keptNames[normalized] = true
+		existing, err := s.aliasRepo.GetByNormalized(entityType, normalized)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			result.AliasesAdded = append(result.AliasesAdded, source.Name)
+		}
+	}
+
+	affectedUsers, err := s.aliasRepo.ListReferencingUserIDs(entityType, sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+	result.AffectedUsers = append(result.AffectedUsers, affectedUsers...)
+
+	// Snapshot each source before it is deleted, for the preview and the change log
+	logEntries := make([]*mergeLogEntry, len(sources))
+	result.Repointed = map[string]int64{}
+	for i, source := range sources {
+		aliases, err := s.aliasRepo.ListByEntity(entityType, source.ID)
+		if err != nil {
+			return nil, err
+		}
+		references, err := s.aliasRepo.CountReferences(entityType, []int64{source.ID})
+		if err != nil {
+			return nil, err
+		}
+		logEntries[i] = &mergeLogEntry{Record: source.Record, Aliases: aliases, References: references}
+		for table, n := range references {
+			result.Repointed[table] += n
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	repointed, err := s.aliasRepo.MergeEntities(entityType, sourceIDs, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge %s: %w", entityType, err)
+	}
+	result.Repointed = repointed
+
+	// Alias and search entity types share their names, so merged-away sources drop out of search
+	if s.searchService != nil {
+		for _, sourceID := range sourceIDs {
+			s.searchService.Refresh(entityType, sourceID)
+		}
+	}
+
+	// The merge is committed; failures below are reported in the result so they can be retried
+	recomputed := s.RecomputePRs(affectedUsers)
+	result.PRsFlagged = recomputed.PRsFlagged
+	result.PRRecomputeFailedUsers = recomputed.FailedUsers
+
+	if s.dataChangeLogService != nil {
+		for i, source := range sources {
+			if logErr := s.dataChangeLogService.LogMerge(entityType, source.ID, source.Name, adminID, adminEmail, logEntries[i], result, nil, nil); logErr != nil {
+				fmt.Printf("Warning: failed to log %s merge: %v\n", entityType, logErr)
+				result.ChangeLogFailedSources = append(result.ChangeLogFailedSources, source.ID)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// RecomputePRs re-flags the PRs of each user from their full history (admin only)
+// Used after a merge, and to retry the users a merge reported in PRRecomputeFailedUsers
+func (s *AliasService) RecomputePRs(userIDs []int64) *PRRecomputeResult {
+	result := &PRRecomputeResult{}
+	if s.userWorkoutService == nil {
+		return result
+	}
+	for _, userID := range userIDs {
+		movementPRs, wodPRs, err := s.userWorkoutService.RetroactivelyFlagPRs(userID)
+		if err != nil {
+			fmt.Printf("Warning: failed to recompute PRs for user %d: %v\n", userID, err)
+			result.FailedUsers = append(result.FailedUsers, userID)
+			continue
+		}
+		result.PRsFlagged += movementPRs + wodPRs
+	}
+	return result
+}
+
+// matcher builds a matcher for the user's view of the library; nil matches every record
+func (s *AliasService) matcher(userID *int64, entityType string) (*NameMatcher, error) {
+	switch entityType {
+	case domain.AliasEntityMovement:
+		return buildMovementMatcher(s.movementRepo, s.aliasRepo, userID)
+	case domain.AliasEntityWOD:
+		return buildWODMatcher(s.wodRepo, s.aliasRepo, userID)
+	default:
+		return nil, ErrInvalidAliasEntity
+	}
+}
+
+func (s *AliasService) getEntity(entityType string, id int64) (*nameEntity, error) {
+	switch entityType {
+	case domain.AliasEntityMovement:
+		movement, err := s.movementRepo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get movement: %w", err)
+		}
+		if movement == nil {
+			return nil, ErrMovementNotFound
+		}
+		return &nameEntity{ID: movement.ID, Name: movement.Name, IsStandard: movement.IsStandard, Record: movement}, nil
+	case domain.AliasEntityWOD:
+		wod, err := s.wodRepo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wod: %w", err)
+		}
+		if wod == nil {
+			return nil, ErrWODNotFound
+		}
+		return &nameEntity{ID: wod.ID, Name: wod.Name, IsStandard: wod.IsStandard, Record: wod}, nil
+	default:
+		return nil, ErrInvalidAliasEntity
+	}
+}
+
+// nameCatalog resolves imported movement and WOD names during one import
+type nameCatalog struct {
+	movements *NameMatcher
+	wods      *NameMatcher
+}
+
+// loadNameCatalog snapshots the standard movements and WODs and the importing user's own, with their
+// aliases when aliasService is set
+func loadNameCatalog(movementRepo domain.MovementRepository, wodRepo domain.WODRepository, aliasService *AliasService, userID int64) (*nameCatalog, error) {
+	var aliasRepo domain.NameAliasRepository
+	if aliasService != nil {
+		aliasRepo = aliasService.aliasRepo
+	}
+
+	movements, err := buildMovementMatcher(movementRepo, aliasRepo, &userID)
+	if err != nil {
+		return nil, err
+	}
+	wods, err := buildWODMatcher(wodRepo, aliasRepo, &userID)
+	if err != nil {
+		return nil, err
+	}
+	return &nameCatalog{movements: movements, wods: wods}, nil
+}
+
+// buildMovementMatcher builds a matcher over the standard movements and the user's custom ones, so other
+// users' records are never matched; a nil userID covers every movement. aliasRepo may be nil to match by name only
+func buildMovementMatcher(movementRepo domain.MovementRepository, aliasRepo domain.NameAliasRepository, userID *int64) (*NameMatcher, error) {
+	var movements []*domain.Movement
+	if userID == nil {
+		all, err := movementRepo.ListAll(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list movements: %w", err)
+		}
+		movements = all
+	} else {
+		standard, err := movementRepo.ListStandard()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list standard movements: %w", err)
+		}
+		own, err := movementRepo.ListByUser(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list user movements: %w", err)
+		}
+		movements = standard
+		for _, m := range own {
+			if !m.IsStandard {
+				movements = append(movements, m)
+			}
+		}
+	}
+
+	matcher := NewNameMatcher(domain.AliasEntityMovement)
+	for _, m := range movements {
+		matcher.Add(m.ID, m.Name, m.IsStandard)
+	}
+	return matcher, addMatcherAliases(matcher, aliasRepo)
+}
+
+// buildWODMatcher builds a matcher over the standard WODs and the user's custom ones, so other users'
+// records are never matched; a nil userID covers every WOD. aliasRepo may be nil to match by name only
+func buildWODMatcher(wodRepo domain.WODRepository, aliasRepo domain.NameAliasRepository, userID *int64) (*NameMatcher, error) {
+	var wods []*domain.WOD
+	if userID == nil {
+		all, err := wodRepo.List(nil, 0, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list wods: %w", err)
+		}
+		wods = all
+	} else {
+		standard, err := wodRepo.ListStandard(0, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list standard wods: %w", err)
+		}
+		own, err := wodRepo.ListByUser(*userID, 0, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list user wods: %w", err)
+		}
+		wods = standard
+		for _, w := range own {
+			if !w.IsStandard {
+				wods = append(wods, w)
+			}
+		}
+	}
+
+	matcher := NewNameMatcher(domain.AliasEntityWOD)
+	for _, w := range wods {
+		matcher.Add(w.ID, w.Name, w.IsStandard)
+	}
+	return matcher, addMatcherAliases(matcher, aliasRepo)
+}
+
+func addMatcherAliases(matcher *NameMatcher, aliasRepo domain.NameAliasRepository) error {
+	if aliasRepo == nil {
+		return nil
+	}
+	aliases, err := aliasRepo.ListByType(matcher.entityType)
+	if err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		matcher.AddAlias(alias)
+	}
+	return nil
+}
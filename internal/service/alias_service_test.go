@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+// newAliasTestDB returns an alias service over a migrated SQLite database with a target movement and a
+// duplicate source movement that user Alice has logged twice
+func newAliasTestDB(t *testing.T) (*AliasService, *repository.UserWorkoutMovementRepository, *domain.Movement, *domain.Movement, *domain.User) {
+	t.Helper()
+	db := openTestDB(t)
+
+	movementRepo := repository.NewMovementRepository(db)
+	target := &domain.Movement{Name: "Alias Test Squat", Type: domain.MovementTypeWeightlifting, IsStandard: true}
+	source := &domain.Movement{Name: "Alias Test Sqaut", Type: domain.MovementTypeWeightlifting}
+	for _, m := range []*domain.Movement{target, source} {
+		if err := movementRepo.Create(m); err != nil {
+			t.Fatalf("failed to create movement: %v", err)
+		}
+	}
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	user := &domain.User{Email: "alice@example.com", Name: "Alice", Role: "user", CreatedAt: day, UpdatedAt: day}
+	if err := repository.NewSQLiteUserRepository(db).Create(user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	userWorkoutRepo := repository.NewUserWorkoutRepository(db)
+	userWorkoutMovementRepo := repository.NewUserWorkoutMovementRepository(db)
+	for i, weight := range []float64{100, 120} {
+		userWorkout := &domain.UserWorkout{UserID: user.ID, WorkoutDate: day.AddDate(0, 0, i)}
+		if err := userWorkoutRepo.Create(userWorkout); err != nil {
+			t.Fatalf("failed to log workout: %v", err)
+		}
+		w := weight
+		if err := userWorkoutMovementRepo.Create(&domain.UserWorkoutMovement{UserWorkoutID: userWorkout.ID, MovementID: source.ID, Weight: &w}); err != nil {
+			t.Fatalf("failed to log movement: %v", err)
+		}
+	}
+
+	wodRepo := repository.NewWODRepository(db)
+	dataChangeLogService := NewDataChangeLogService(repository.NewDataChangeLogRepository(db, "sqlite3"))
+	svc := NewAliasService(repository.NewNameAliasRepository(db), movementRepo, wodRepo, dataChangeLogService)
+	svc.SetUserWorkoutService(NewUserWorkoutService(userWorkoutRepo, repository.NewWorkoutRepository(db),
+		repository.NewWorkoutMovementRepository(db), userWorkoutMovementRepo, repository.NewUserWorkoutWODRepository(db), wodRepo))
+	return svc, userWorkoutMovementRepo, target, source, user
+}
+
+func TestAliasServiceMergeDryRun(t *testing.T) {
+	svc, userWorkoutMovementRepo, target, source, user := newAliasTestDB(t)
+
+	result, err := svc.Merge(1, "admin@example.com", domain.AliasEntityMovement, []int64{source.ID}, target.ID, true)
+	if err != nil {
+		t.Fatalf("Merge() dry run error = %v", err)
+	}
+	if result.Repointed["user_workout_movements"] != 2 {
+		t.Errorf("expected 2 logged movements to be repointed, got %v", result.Repointed)
+	}
+	if len(result.AliasesAdded) != 1 || result.AliasesAdded[0] != source.Name {
+		t.Errorf("expected %q to be kept as an alias, got %v", source.Name, result.AliasesAdded)
+	}
+	if len(result.AffectedUsers) != 1 || result.AffectedUsers[0] != user.ID {
+		t.Errorf("expected user %d to be affected, got %v", user.ID, result.AffectedUsers)
+	}
+
+	// Nothing changes
+	logged, err := userWorkoutMovementRepo.GetByUserIDAndMovementID(user.ID, source.ID, 10)
+	if err != nil || len(logged) != 2 {
+		t.Errorf("expected the dry run to leave logged movements alone, got %d on the source (err %v)", len(logged), err)
+	}
+	if aliases, _ := svc.ListAliases(domain.AliasEntityMovement, target.ID); len(aliases) != 0 {
+		t.Errorf("expected no aliases after a dry run, got %d", len(aliases))
+	}
+
+	if _, err := svc.Merge(1, "", domain.AliasEntityMovement, []int64{target.ID}, target.ID, true); !errors.Is(err, ErrInvalidMerge) {
+		t.Errorf("expected ErrInvalidMerge merging into itself, got %v", err)
+	}
+	if _, err := svc.Merge(1, "", domain.AliasEntityMovement, []int64{target.ID}, source.ID, true); !errors.Is(err, ErrInvalidMerge) {
+		t.Errorf("expected ErrInvalidMerge merging a standard movement into a custom one, got %v", err)
+	}
+}
+
+func TestAliasServiceMerge(t *testing.T) {
+	svc, userWorkoutMovementRepo, target, source, user := newAliasTestDB(t)
+
+	result, err := svc.Merge(1, "admin@example.com", domain.AliasEntityMovement, []int64{source.ID}, target.ID, false)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if result.Repointed["user_workout_movements"] != 2 {
+		t.Errorf("expected 2 logged movements repointed, got %v", result.Repointed)
+	}
+	if len(result.PRRecomputeFailedUsers) != 0 || len(result.ChangeLogFailedSources) != 0 {
+		t.Errorf("expected no failures, got users %v and sources %v", result.PRRecomputeFailedUsers, result.ChangeLogFailedSources)
+	}
+	if result.PRsFlagged == 0 {
+		t.Error("expected the repointed history to be flagged for PRs")
+	}
+
+	movements, err := userWorkoutMovementRepo.GetByUserIDAndMovementID(user.ID, target.ID, 10)
+	if err != nil {
+		t.Fatalf("failed to list logged movements: %v", err)
+	}
+	if len(movements) != 2 {
+		t.Errorf("expected both logged movements on the target, got %d", len(movements))
+	}
+	if _, err := svc.getEntity(domain.AliasEntityMovement, source.ID); !errors.Is(err, ErrMovementNotFound) {
+		t.Errorf("expected the source to be deleted, got %v", err)
+	}
+	aliases, err := svc.ListAliases(domain.AliasEntityMovement, target.ID)
+	if err != nil || len(aliases) != 1 || aliases[0].Alias != source.Name {
+		t.Errorf("expected %q kept as an alias of the target, got %v (err %v)", source.Name, aliases, err)
+	}
+}
+
+func TestAliasServiceMergeReportsFailedPRRecompute(t *testing.T) {
+	svc, _, target, source, user := newAliasTestDB(t)
+	failingRepo := newMockUserWorkoutRepo()
+	failingRepo.listError = errors.New("database is locked")
+	svc.SetUserWorkoutService(NewUserWorkoutService(failingRepo, newMockWorkoutRepo(), &mockWorkoutMovementRepo{},
+		&mockUserWorkoutMovementRepo{}, &mockUserWorkoutWODRepo{}, newMockWODRepo()))
+
+	result, err := svc.Merge(1, "admin@example.com", domain.AliasEntityMovement, []int64{source.ID}, target.ID, false)
+	if err != nil {
+		t.Fatalf("expected the committed merge to succeed, got %v", err)
+	}
+	if len(result.PRRecomputeFailedUsers) != 1 || result.PRRecomputeFailedUsers[0] != user.ID {
+		t.Errorf("expected user %d reported for a retry, got %v", user.ID, result.PRRecomputeFailedUsers)
+	}
+
+	failingRepo.listError = nil
+	if retry := svc.RecomputePRs(result.PRRecomputeFailedUsers); len(retry.FailedUsers) != 0 {
+		t.Errorf("expected the retry to succeed, got %v", retry.FailedUsers)
+	}
+}
+
+func TestAliasServiceMatchHidesOtherUsersRecords(t *testing.T) {
+	svc, _, _, _, alice := newAliasTestDB(t)
+
+	custom := &domain.WOD{Name: "Alice Secret Chipper", Source: "Self-recorded", Type: "Self-created", Regime: "Fastest Time",
+		ScoreType: domain.ScoreTypeTime, CreatedBy: &alice.ID}
+	if err := svc.wodRepo.Create(custom); err != nil {
+		t.Fatalf("failed to create wod: %v", err)
+	}
+
+	own, err := svc.Match(alice.ID, domain.AliasEntityWOD, "alice secret chipper", 5)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if own.Match == nil || own.Match.ID != custom.ID {
+		t.Errorf("expected Alice to match her own WOD, got %+v", own.Match)
+	}
+
+	otherUserID := alice.ID + 1
+	other, err := svc.Match(otherUserID, domain.AliasEntityWOD, "alice secret chipper", 5)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if other.Match != nil {
+		t.Errorf("expected another user not to match Alice's WOD, got %+v", other.Match)
+	}
+	for _, candidate := range other.Suggestions {
+		if candidate.ID == custom.ID {
+			t.Errorf("expected Alice's WOD not to be suggested to another user")
+		}
+	}
+
+	catalog, err := loadNameCatalog(svc.movementRepo, svc.wodRepo, svc, otherUserID)
+	if err != nil {
+		t.Fatalf("loadNameCatalog failed: %v", err)
+	}
+	if _, ok := catalog.wods.Resolve("Alice Secret Chipper"); ok {
+		t.Errorf("expected imports by another user not to resolve to Alice's WOD")
+	}
+}
+
+func TestAliasServiceMergeRepointsGymLibraries(t *testing.T) {
+	db := openTestDB(t)
+	movementRepo := repository.NewMovementRepository(db)
+	orgRepo := repository.NewOrganizationRepository(db)
+
+	target := &domain.Movement{Name: "Library Test Squat", Type: domain.MovementTypeWeightlifting, IsStandard: true}
+	source := &domain.Movement{Name: "Library Test Sqaut", Type: domain.MovementTypeWeightlifting}
+	for _, m := range []*domain.Movement{target, source} {
+		if err := movementRepo.Create(m); err != nil {
+			t.Fatalf("failed to create movement: %v", err)
+		}
+	}
+
+	// One gym lists both movements, the other only the duplicate
+	both := &domain.Organization{Name: "Both Gym", Slug: "both-gym"}
+	sourceOnly := &domain.Organization{Name: "Source Gym", Slug: "source-gym"}
+	for _, org := range []*domain.Organization{both, sourceOnly} {
+		if err := orgRepo.Create(org); err != nil {
+			t.Fatalf("failed to create gym: %v", err)
+		}
+		if err := orgRepo.AddLibraryItem(org.ID, domain.LibraryEntityMovement, source.ID, 0); err != nil {
+			t.Fatalf("failed to add library item: %v", err)
+		}
+	}
+	if err := orgRepo.AddLibraryItem(both.ID, domain.LibraryEntityMovement, target.ID, 0); err != nil {
+		t.Fatalf("failed to add library item: %v", err)
+	}
+
+	svc := NewAliasService(repository.NewNameAliasRepository(db), movementRepo, repository.NewWODRepository(db), nil)
+	if _, err := svc.Merge(1, "admin@example.com", domain.AliasEntityMovement, []int64{source.ID}, target.ID, false); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	for _, org := range []*domain.Organization{both, sourceOnly} {
+		movements, err := orgRepo.ListLibraryMovements(org.ID)
+		if err != nil {
+			t.Fatalf("failed to list library: %v", err)
+		}
+		if len(movements) != 1 || movements[0].ID != target.ID {
+			t.Errorf("expected %s to list only the target, got %v", org.Name, movements)
+		}
+	}
+}
@@ -0,0 +1,1257 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/pkg/prmath"
+)
+
+var (
+	ErrInvalidBucket          = errors.New("bucket must be day, week or month")
+	ErrAnalyticsRangeTooLarge = errors.New("date range has too many buckets")
+	ErrInvalidE1RMBucket      = errors.New("bucket must be week or month")
+	ErrInvalidE1RMFormula     = errors.New("unknown 1RM formula")
+	ErrInvalidBaselineDate    = errors.New("baseline date must be on or before the end date")
+	ErrInvalidRepsPerRound    = errors.New("reps per round must be positive")
+	ErrInvalidRestDays        = errors.New("rest days must be between 0 and 6")
+	ErrInvalidCalendarYear    = errors.New("year must be between 1900 and 9999")
+	ErrInvalidACWRBand        = errors.New("acwr band must satisfy 0 < low < high")
+)
+
+// consistencyWindows are the rolling windows (in weeks) used for sessions per week
+var consistencyWindows = []int{4, 12, 26, 52}
+
+// Training load windows and warning thresholds (session load = session RPE x minutes)
+const (
+	acuteLoadDays            = 7
+	chronicLoadDays          = 28
+	maxTrainingLoadDays      = 366
+	DefaultACWRLow           = 0.8
+	DefaultACWRHigh          = 1.3
+	monotonyWarningThreshold = 2.0
+)
+
+// E1RMFormulaHybrid selects prmath.Calculate1RM (actual for singles, Epley to 10 reps, Wathan above)
+const E1RMFormulaHybrid = "Hybrid"
+
+const (
+	// maxAnalyticsBuckets bounds a report (about a year of days or eight years of weeks)
+	maxAnalyticsBuckets = 400
+
+	// A period is flagged as a deload when its tonnage falls below this share of the
+	// average of the preceding deloadLookback periods that had any tonnage
+	deloadThreshold = 0.6
+	deloadLookback  = 3
+)
+
+// VolumePeriod is the training volume for one bucket
+type VolumePeriod struct {
+	Bucket                string             `json:"bucket"` // Bucket start date (YYYY-MM-DD)
+	WorkoutCount          int                `json:"workout_count"`
+	TimeUnderWork         int                `json:"time_under_work"` // seconds
+	Sets                  int                `json:"sets"`
+	Reps                  int                `json:"reps"`
+	Tonnage               float64            `json:"tonnage"`
+	RepsByMovementType    map[string]int     `json:"reps_by_movement_type"`
+	TonnageByMovementType map[string]float64 `json:"tonnage_by_movement_type"`
+	WorkoutsByType        map[string]int     `json:"workouts_by_type"`
+	TonnageChangePercent  *float64           `json:"tonnage_change_percent,omitempty"` // vs previous period
+	IsDeload              bool               `json:"is_deload"`
+}
+
+// VolumeReport is a user's training volume over a date range, one period per bucket (gaps included)
+type VolumeReport struct {
+	Bucket        string          `json:"bucket"`
+	StartDate     string          `json:"start_date"`
+	EndDate       string          `json:"end_date"`
+	Periods       []*VolumePeriod `json:"periods"`
+	TotalTonnage  float64         `json:"total_tonnage"`
+	TotalReps     int             `json:"total_reps"`
+	TotalWorkouts int             `json:"total_workouts"`
+	TotalTime     int             `json:"total_time"`
+}
+
+// E1RMPeriod is the best estimated 1RM for one bucket
+// Periods without a weighted set have no Best1RM but still carry the rolling max
+type E1RMPeriod struct {
+	Bucket        string   `json:"bucket"`
+	Best1RM       *float64 `json:"best_1rm,omitempty"`
+	Weight        *float64 `json:"weight,omitempty"` // Set that produced Best1RM
+	Reps          *int     `json:"reps,omitempty"`
+	UserWorkoutID *int64   `json:"user_workout_id,omitempty"`
+	RollingMax    *float64 `json:"rolling_max,omitempty"`    // Best estimate to date, including history before the range
+	ChangePercent *float64 `json:"change_percent,omitempty"` // Best1RM vs the baseline
+}
+
+// E1RMProgression is a movement's estimated 1RM over time
+type E1RMProgression struct {
+	MovementID    int64         `json:"movement_id"`
+	MovementName  string        `json:"movement_name"`
+	Bucket        string        `json:"bucket"`
+	Formula       string        `json:"formula"`
+	StartDate     string        `json:"start_date"`
+	EndDate       string        `json:"end_date"`
+	BaselineDate  *string       `json:"baseline_date,omitempty"`
+	Baseline1RM   *float64      `json:"baseline_1rm,omitempty"`
+	Current1RM    *float64      `json:"current_1rm,omitempty"`    // Rolling max at the end of the range
+	ChangePercent *float64      `json:"change_percent,omitempty"` // Current1RM vs the baseline
+	Periods       []*E1RMPeriod `json:"periods"`
+}
+
+// BenchmarkAttempt is one logged attempt at a WOD with its change from earlier attempts
+// Score is normalized by score type: seconds for Time, total reps for Rounds+Reps, load for Max Weight.
+// Deltas are Score differences (negative is faster for Time); Improved means better than the previous attempt.
+type BenchmarkAttempt struct {
+	UserWorkoutWODID  int64    `json:"user_workout_wod_id"`
+	UserWorkoutID     int64    `json:"user_workout_id"`
+	WorkoutDate       string   `json:"workout_date"`
+	ScoreValue        *string  `json:"score_value,omitempty"`
+	TimeSeconds       *int     `json:"time_seconds,omitempty"`
+	Rounds            *int     `json:"rounds,omitempty"`
+	Reps              *int     `json:"reps,omitempty"`
+	Weight            *float64 `json:"weight,omitempty"`
+	Division          *string  `json:"division,omitempty"`
+	Score             *float64 `json:"score,omitempty"`
+	DeltaFromPrevious *float64 `json:"delta_from_previous,omitempty"`
+	DeltaFromFirst    *float64 `json:"delta_from_first,omitempty"`
+	Improved          bool     `json:"improved"`
+	IsPR              bool     `json:"is_pr"`
+	domain.WODScoreDetails
+}
+
+// BenchmarkPR is the user's best result on a WOD
+type BenchmarkPR struct {
+	TimeSeconds *int     `json:"time_seconds,omitempty"`
+	Rounds      *int     `json:"rounds,omitempty"`
+	Reps        *int     `json:"reps,omitempty"`
+	Weight      *float64 `json:"weight,omitempty"`
+	Score       *float64 `json:"score,omitempty"`
+}
+
+// BenchmarkRetestReport compares every attempt a user has made at a WOD, oldest first
+type BenchmarkRetestReport struct {
+	WODID                int64               `json:"wod_id"`
+	WODName              string              `json:"wod_name"`
+	WODType              string              `json:"wod_type,omitempty"`
+	ScoreType            string              `json:"score_type"`
+	ScoreUnit            string              `json:"score_unit"` // seconds, reps, weight, cal, m or pts
+	LowerIsBetter        bool                `json:"lower_is_better"`
+	RepsPerRound         *int                `json:"reps_per_round,omitempty"`
+	Attempts             []*BenchmarkAttempt `json:"attempts"`
+	CurrentPR            *BenchmarkPR        `json:"current_pr,omitempty"`
+	LastAttemptDate      *string             `json:"last_attempt_date,omitempty"`
+	DaysSinceLastAttempt *int                `json:"days_since_last_attempt,omitempty"`
+}
+
+// Streak is a run of training days where no gap exceeds the allowed rest days
+type Streak struct {
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+	Days         int    `json:"days"`          // Calendar days from first to last training day
+	TrainingDays int    `json:"training_days"` // Days with at least one session
+}
+
+// WeeklyFrequency is the average sessions per week over a rolling window ending today
+type WeeklyFrequency struct {
+	Weeks           int     `json:"weeks"`
+	Sessions        int     `json:"sessions"`
+	SessionsPerWeek float64 `json:"sessions_per_week"`
+}
+
+// DayOfWeekCount is how many sessions fell on a weekday
+type DayOfWeekCount struct {
+	Day     string  `json:"day"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// ConsistencyReport summarizes a user's training consistency over their whole history
+type ConsistencyReport struct {
+	RestDays        int               `json:"rest_days"`
+	TotalSessions   int               `json:"total_sessions"`
+	TrainingDays    int               `json:"training_days"`
+	LastWorkoutDate *string           `json:"last_workout_date,omitempty"`
+	CurrentStreak   *Streak           `json:"current_streak,omitempty"` // nil once the allowed rest days have passed
+	LongestStreak   *Streak           `json:"longest_streak,omitempty"`
+	Windows         []WeeklyFrequency `json:"windows"`
+	DayOfWeek       []DayOfWeekCount  `json:"day_of_week"` // Monday first
+}
+
+// CalendarDay is one heatmap cell
+type CalendarDay struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// TrainingCalendar is a heatmap-ready year: one row per Monday-start week, seven cells per row.
+// Cells outside the year are null.
+type TrainingCalendar struct {
+	Year          int              `json:"year"`
+	Weeks         [][]*CalendarDay `json:"weeks"`
+	MaxCount      int              `json:"max_count"`
+	TotalSessions int              `json:"total_sessions"`
+	ActiveDays    int              `json:"active_days"`
+}
+
+// DailyLoad is one day of the training load series with its rolling 7/28-day metrics
+type DailyLoad struct {
+	Date        string   `json:"date"`
+	Sessions    int      `json:"sessions"`
+	Load        float64  `json:"load"`               // Sum of session RPE x minutes
+	AcuteLoad   float64  `json:"acute_load"`         // 7-day sum
+	ChronicLoad float64  `json:"chronic_load"`       // 28-day sum / 4 (average week)
+	ACWR        *float64 `json:"acwr,omitempty"`     // Nil until 28 days of history or with no chronic load
+	Monotony    *float64 `json:"monotony,omitempty"` // 7-day mean / standard deviation; nil when loads are identical
+	Strain      *float64 `json:"strain,omitempty"`   // Acute load x monotony
+	Zone        string   `json:"zone,omitempty"`     // low, safe or high relative to the ACWR band
+}
+
+// LoadWarning flags the first day of an excursion outside the safe band or above the monotony threshold
+type LoadWarning struct {
+	Date    string  `json:"date"`
+	Type    string  `json:"type"` // acwr_high, acwr_low or monotony_high
+	Value   float64 `json:"value"`
+	Message string  `json:"message"`
+}
+
+// WellnessSummary averages the readiness markers logged in the acute window
+type WellnessSummary struct {
+	Sessions        int      `json:"sessions"`
+	AvgSessionRPE   *float64 `json:"avg_session_rpe,omitempty"`
+	AvgSleepHours   *float64 `json:"avg_sleep_hours,omitempty"`
+	AvgSleepQuality *float64 `json:"avg_sleep_quality,omitempty"`
+	AvgSoreness     *float64 `json:"avg_soreness,omitempty"`
+	AvgReadiness    *float64 `json:"avg_readiness,omitempty"`
+}
+
+// TrainingLoadReport is the acute:chronic workload series for a date range
+type TrainingLoadReport struct {
+	StartDate           string          `json:"start_date"`
+	EndDate             string          `json:"end_date"`
+	ACWRLow             float64         `json:"acwr_low"`
+	ACWRHigh            float64         `json:"acwr_high"`
+	Days                []*DailyLoad    `json:"days"`
+	Current             *DailyLoad      `json:"current"`
+	SessionsWithoutLoad int             `json:"sessions_without_load"` // Sessions missing session RPE or duration
+	Warnings            []LoadWarning   `json:"warnings"`
+	Wellness            WellnessSummary `json:"wellness"` // Last 7 days of the range
+}
+
+// MovementBreakdownEntry is the volume of one movement, or of a movement family when variants are rolled up
+type MovementBreakdownEntry struct {
+	MovementID   int64    `json:"movement_id"`
+	MovementName string   `json:"movement_name"`
+	Pattern      string   `json:"pattern,omitempty"`
+	Variants     []string `json:"variants,omitempty"` // Variants logged in the range and counted under this movement
+	Sessions     int      `json:"sessions"`
+	Sets         int      `json:"sets"`
+	Reps         int      `json:"reps"`
+	Tonnage      float64  `json:"tonnage"`
+}
+
+// PatternVolume totals sets, reps and tonnage for one movement pattern
+type PatternVolume struct {
+	Pattern string  `json:"pattern"` // A domain.MovementPatterns value, or "unspecified"
+	Sets    int     `json:"sets"`
+	Reps    int     `json:"reps"`
+	Tonnage float64 `json:"tonnage"`
+}
+
+// MovementBreakdown is the per-movement and per-pattern volume for a date range
+type MovementBreakdown struct {
+	StartDate string                    `json:"start_date"`
+	EndDate   string                    `json:"end_date"`
+	Rollup    bool                      `json:"rollup"` // Variants counted under their parent movement
+	Movements []*MovementBreakdownEntry `json:"movements"`
+	Patterns  []*PatternVolume          `json:"patterns"`
+}
+
+// AnalyticsService computes training volume analytics with SQL aggregation
+type AnalyticsService struct {
+	analyticsRepo      domain.AnalyticsRepository
+	movementRepo       domain.MovementRepository
+	wodRepo            domain.WODRepository
+	userWorkoutRepo    domain.UserWorkoutRepository
+	userWorkoutWODRepo domain.UserWorkoutWODRepository
+	structureService   *WODStructureService
+	now                func() time.Time
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(
+	analyticsRepo domain.AnalyticsRepository,
+	movementRepo domain.MovementRepository,
+	wodRepo domain.WODRepository,
+	userWorkoutRepo domain.UserWorkoutRepository,
+	userWorkoutWODRepo domain.UserWorkoutWODRepository,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo:      analyticsRepo,
+		movementRepo:       movementRepo,
+		wodRepo:            wodRepo,
+		userWorkoutRepo:    userWorkoutRepo,
+		userWorkoutWODRepo: userWorkoutWODRepo,
+		now:                time.Now,
+	}
+}
+
+// SetStructureService sets the WOD structure service used to count a WOD's reps per round
+func (s *AnalyticsService) SetStructureService(structureService *WODStructureService) {
+	s.structureService = structureService
+}
+
+// DefaultAnalyticsStart returns the default range start for a bucket ending at end
+// (30 days, 12 weeks or 12 months)
+func DefaultAnalyticsStart(bucket string, end time.Time) time.Time {
+	switch bucket {
+	case domain.BucketDay:
+		return end.AddDate(0, 0, -29)
+	case domain.BucketMonth:
+		return end.AddDate(0, -11, 0)
+	default:
+		return end.AddDate(0, 0, -7*11)
+	}
+}
+
+// GetVolumeReport aggregates tonnage, reps per movement type, time under work and workout types per bucket
+// start and end are dates; end is inclusive
+func (s *AnalyticsService) GetVolumeReport(userID int64, bucket string, start, end time.Time) (*VolumeReport, error) {
+	if bucket != domain.BucketDay && bucket != domain.BucketWeek && bucket != domain.BucketMonth {
+		return nil, ErrInvalidBucket
+	}
+
+	start = bucketStart(bucket, start)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	if start.After(end) {
+		return nil, ErrInvalidScheduleRange
+	}
+
+	periods := make(map[string]*VolumePeriod)
+	var ordered []*VolumePeriod
+	for b := start; !b.After(end); b = nextBucket(bucket, b) {
+		if len(ordered) >= maxAnalyticsBuckets {
+			return nil, ErrAnalyticsRangeTooLarge
+		}
+		p := &VolumePeriod{
+			Bucket:                b.Format(domain.ScheduledDateFormat),
+			RepsByMovementType:    map[string]int{},
+			TonnageByMovementType: map[string]float64{},
+			WorkoutsByType:        map[string]int{},
+		}
+		periods[p.Bucket] = p
+		ordered = append(ordered, p)
+	}
+
+	// Include the whole end day
+	queryEnd := end.Add(24*time.Hour - time.Nanosecond)
+
+	workouts, err := s.analyticsRepo.GetWorkoutVolume(userID, bucket, start, queryEnd)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := s.analyticsRepo.GetMovementVolume(userID, bucket, start, queryEnd)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.analyticsRepo.GetWorkoutTypeCounts(userID, bucket, start, queryEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &VolumeReport{
+		Bucket:    bucket,
+		StartDate: start.Format(domain.ScheduledDateFormat),
+		EndDate:   end.Format(domain.ScheduledDateFormat),
+		Periods:   ordered,
+	}
+
+	for _, w := range workouts {
+		if p, ok := periods[w.Bucket]; ok {
+			p.WorkoutCount = w.WorkoutCount
+			p.TimeUnderWork = w.TimeUnderWork
+			report.TotalWorkouts += w.WorkoutCount
+			report.TotalTime += w.TimeUnderWork
+		}
+	}
+	for _, m := range movements {
+		if p, ok := periods[m.Bucket]; ok {
+			p.Sets += m.Sets
+			p.Reps += m.Reps
+			p.Tonnage += m.Tonnage
+			p.RepsByMovementType[m.MovementType] += m.Reps
+			p.TonnageByMovementType[m.MovementType] += m.Tonnage
+			report.TotalReps += m.Reps
+			report.TotalTonnage += m.Tonnage
+		}
+	}
+	for _, t := range types {
+		if p, ok := periods[t.Bucket]; ok {
+			p.WorkoutsByType[t.WorkoutType] = t.Count
+		}
+	}
+
+	annotateTrend(ordered)
+	return report, nil
+}
+
+// annotateTrend sets the tonnage change versus the previous period and flags deload periods
+func annotateTrend(periods []*VolumePeriod) {
+	for i, p := range periods {
+		if i > 0 && periods[i-1].Tonnage > 0 {
+			change := roundTenth((p.Tonnage - periods[i-1].Tonnage) / periods[i-1].Tonnage * 100)
+			p.TonnageChangePercent = &change
+		}
+
+		var sum float64
+		var count int
+		for j := i - 1; j >= 0 && count < deloadLookback; j-- {
+			if periods[j].Tonnage > 0 {
+				sum += periods[j].Tonnage
+				count++
+			}
+		}
+		if count > 0 && p.Tonnage < deloadThreshold*(sum/float64(count)) {
+			p.IsDeload = true
+		}
+	}
+}
+
+// E1RMFormulas lists the accepted formula names: the hybrid default plus those of prmath.CalculateAllFormulas
+func E1RMFormulas() []string {
+	var names []string
+	for name := range prmath.CalculateAllFormulas(100, 1) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{E1RMFormulaHybrid}, names...)
+}
+
+// GetE1RMProgression returns the best estimated 1RM per week or month for a movement, the rolling max,
+// and the change against the baseline. The baseline is the best estimate logged on or before
+// baselineDate, or the first period with data when baselineDate is nil.
+func (s *AnalyticsService) GetE1RMProgression(userID, movementID int64, bucket, formula string, start, end time.Time, baselineDate *time.Time) (*E1RMProgression, error) {
+	if bucket != domain.BucketWeek && bucket != domain.BucketMonth {
+		return nil, ErrInvalidE1RMBucket
+	}
+
+	formula, err := resolveE1RMFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	start = bucketStart(bucket, start)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	if start.After(end) {
+		return nil, ErrInvalidScheduleRange
+	}
+	if baselineDate != nil && baselineDate.After(end) {
+		return nil, ErrInvalidBaselineDate
+	}
+
+	movement, err := s.movementRepo.GetByID(movementID)
+	if err != nil {
+		return nil, err
+	}
+	if movement == nil {
+		return nil, ErrMovementNotFound
+	}
+
+	sets, err := s.analyticsRepo.GetLiftSets(userID, movementID, end.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	progression := &E1RMProgression{
+		MovementID:   movement.ID,
+		MovementName: movement.Name,
+		Bucket:       bucket,
+		Formula:      formula,
+		StartDate:    start.Format(domain.ScheduledDateFormat),
+		EndDate:      end.Format(domain.ScheduledDateFormat),
+	}
+
+	periods := make(map[string]*E1RMPeriod)
+	for b := start; !b.After(end); b = nextBucket(bucket, b) {
+		if len(progression.Periods) >= maxAnalyticsBuckets {
+			return nil, ErrAnalyticsRangeTooLarge
+		}
+		p := &E1RMPeriod{Bucket: b.Format(domain.ScheduledDateFormat)}
+		periods[p.Bucket] = p
+		progression.Periods = append(progression.Periods, p)
+	}
+
+	// Sets arrive oldest first: history before the range only seeds the rolling max and the baseline
+	var rollingMax, baseline float64
+	for _, set := range sets {
+		oneRM, ok := estimate1RM(formula, set.Weight, set.Reps)
+		if !ok {
+			continue
+		}
+		oneRM = roundTenth(oneRM)
+
+		if baselineDate != nil && !set.WorkoutDate.After(baselineDate.Add(24*time.Hour-time.Nanosecond)) && oneRM > baseline {
+			baseline = oneRM
+		}
+
+		if set.WorkoutDate.Before(start) {
+			rollingMax = math.Max(rollingMax, oneRM)
+			continue
+		}
+
+		p, ok := periods[bucketStart(bucket, set.WorkoutDate).Format(domain.ScheduledDateFormat)]
+		if !ok {
+			continue
+		}
+		if p.Best1RM == nil || oneRM > *p.Best1RM {
+			weight, reps, workoutID := set.Weight, set.Reps, set.UserWorkoutID
+			best := oneRM
+			p.Best1RM, p.Weight, p.Reps, p.UserWorkoutID = &best, &weight, &reps, &workoutID
+		}
+	}
+
+	for _, p := range progression.Periods {
+		if p.Best1RM != nil {
+			rollingMax = math.Max(rollingMax, *p.Best1RM)
+			if baselineDate == nil && progression.BaselineDate == nil {
+				bucketDate := p.Bucket
+				baseline = *p.Best1RM
+				progression.BaselineDate = &bucketDate
+			}
+		}
+		if rollingMax > 0 {
+			current := rollingMax
+			p.RollingMax = &current
+		}
+	}
+
+	if baselineDate != nil {
+		date := baselineDate.Format(domain.ScheduledDateFormat)
+		progression.BaselineDate = &date
+	}
+	if baseline > 0 {
+		progression.Baseline1RM = &baseline
+		for _, p := range progression.Periods {
+			if p.Best1RM != nil {
+				change := roundTenth(prmath.CompareToBaseline(*p.Best1RM, baseline))
+				p.ChangePercent = &change
+			}
+		}
+	}
+	if rollingMax > 0 {
+		progression.Current1RM = &rollingMax
+		if baseline > 0 {
+			change := roundTenth(prmath.CompareToBaseline(rollingMax, baseline))
+			progression.ChangePercent = &change
+		}
+	}
+
+	return progression, nil
+}
+
+// GetBenchmarkRetests returns every attempt a user has made at a WOD with deltas from the previous and
+// first attempts, the current PR and the time since the last attempt.
+// Rounds+Reps scores normalize to total reps using the reps per round of the WOD's structure; repsPerRound
+// overrides it. When neither is known those attempts are listed without Score or deltas.
+func (s *AnalyticsService) GetBenchmarkRetests(userID, wodID int64, repsPerRound *int) (*BenchmarkRetestReport, error) {
+	if repsPerRound != nil && *repsPerRound <= 0 {
+		return nil, ErrInvalidRepsPerRound
+	}
+
+	wod, err := s.wodRepo.GetByID(wodID)
+	if err != nil {
+		return nil, err
+	}
+	if wod == nil || (!wod.IsStandard && (wod.CreatedBy == nil || *wod.CreatedBy != userID)) {
+		return nil, ErrWODNotFound
+	}
+
+	history, err := s.userWorkoutWODRepo.GetHistoryForWOD(userID, wodID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreType := wod.ScoreType
+	if scoreType == "" && len(history) > 0 && history[0].ScoreType != nil {
+		scoreType = *history[0].ScoreType
+	}
+
+	report := &BenchmarkRetestReport{
+		WODID:        wod.ID,
+		WODName:      wod.Name,
+		WODType:      wod.Type,
+		ScoreType:    scoreType,
+		RepsPerRound: repsPerRound,
+		Attempts:     []*BenchmarkAttempt{},
+	}
+	report.ScoreUnit, report.LowerIsBetter = benchmarkScoreUnit(scoreType)
+
+	if scoreType == domain.ScoreTypeRoundsReps && repsPerRound == nil {
+		if repsPerRound, err = s.wodRepsPerRound(wodID); err != nil {
+			return nil, err
+		}
+		report.RepsPerRound = repsPerRound
+	}
+
+	// History is oldest first
+	var first, previous *float64
+	for _, h := range history {
+		attempt := &BenchmarkAttempt{
+			UserWorkoutWODID: h.ID,
+			UserWorkoutID:    h.UserWorkoutID,
+			WorkoutDate:      h.WorkoutDate.Format(domain.ScheduledDateFormat),
+			ScoreValue:       h.ScoreValue,
+			TimeSeconds:      h.TimeSeconds,
+			Rounds:           h.Rounds,
+			Reps:             h.Reps,
+			Weight:           h.Weight,
+			Division:         h.Division,
+			WODScoreDetails:  h.WODScoreDetails,
+			IsPR:             h.IsPR,
+			Score:            benchmarkScore(scoreType, h, repsPerRound),
+		}
+
+		if attempt.Score != nil {
+			if previous != nil {
+				delta := roundTenth(*attempt.Score - *previous)
+				attempt.DeltaFromPrevious = &delta
+				attempt.Improved = (report.LowerIsBetter && delta < 0) || (!report.LowerIsBetter && delta > 0)
+			}
+			if first != nil {
+				delta := roundTenth(*attempt.Score - *first)
+				attempt.DeltaFromFirst = &delta
+			} else {
+				first = attempt.Score
+			}
+			previous = attempt.Score
+		}
+
+		report.Attempts = append(report.Attempts, attempt)
+	}
+
+	if len(report.Attempts) > 0 {
+		last := report.Attempts[len(report.Attempts)-1].WorkoutDate
+		lastDate, _ := time.Parse(domain.ScheduledDateFormat, last)
+		days := daysBetween(lastDate, truncateDay(s.now()))
+		report.LastAttemptDate = &last
+		report.DaysSinceLastAttempt = &days
+	}
+
+	report.CurrentPR, err = s.benchmarkPR(userID, wodID, scoreType, report.Attempts, repsPerRound)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// wodRepsPerRound counts the reps in one round of a WOD from its structure; nil when it has none or they cannot be counted
+func (s *AnalyticsService) wodRepsPerRound(wodID int64) (*int, error) {
+	if s.structureService == nil {
+		return nil, nil
+	}
+	structure, err := s.structureService.GetStructure(wodID)
+	if errors.Is(err, ErrWODStructureNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return structure.RepsPerRound(), nil
+}
+
+// benchmarkPR looks up the best result using the repository's best-score queries
+func (s *AnalyticsService) benchmarkPR(userID, wodID int64, scoreType string, attempts []*BenchmarkAttempt, repsPerRound *int) (*BenchmarkPR, error) {
+	switch scoreType {
+	case domain.ScoreTypeRoundsReps:
+		rounds, reps, err := s.userWorkoutWODRepo.GetBestRoundsRepsForWOD(userID, wodID)
+		if err != nil || rounds == nil {
+			return nil, err
+		}
+		pr := &BenchmarkPR{Rounds: rounds, Reps: reps}
+		pr.Score = benchmarkScore(scoreType, &domain.UserWorkoutWOD{Rounds: rounds, Reps: reps}, repsPerRound)
+		return pr, nil
+	case domain.ScoreTypeMaxWeight:
+		// No best-weight query exists; attempts already hold every result
+		var pr *BenchmarkPR
+		for _, a := range attempts {
+			if a.Weight != nil && (pr == nil || *a.Weight > *pr.Weight) {
+				pr = &BenchmarkPR{Weight: a.Weight, Score: a.Score}
+			}
+		}
+		return pr, nil
+	case domain.ScoreTypeReps, domain.ScoreTypeCalories, domain.ScoreTypeDistance, domain.ScoreTypePoints:
+		// Single-field scores where more is better; attempts already hold every result
+		var pr *BenchmarkPR
+		for _, a := range attempts {
+			if a.Score != nil && (pr == nil || *a.Score > *pr.Score) {
+				pr = &BenchmarkPR{Score: a.Score}
+			}
+		}
+		return pr, nil
+	default:
+		best, err := s.userWorkoutWODRepo.GetBestTimeForWOD(userID, wodID)
+		if err != nil || best == nil {
+			return nil, err
+		}
+		score := float64(*best)
+		return &BenchmarkPR{TimeSeconds: best, Score: &score}, nil
+	}
+}
+
+// benchmarkScoreUnit returns the unit benchmarkScore reports for a score type and whether lower is better
+func benchmarkScoreUnit(scoreType string) (unit string, lowerIsBetter bool) {
+	switch scoreType {
+	case domain.ScoreTypeRoundsReps:
+		return "reps", false
+	case domain.ScoreTypeMaxWeight:
+		return "weight", false
+	case domain.ScoreTypeReps, domain.ScoreTypeCalories, domain.ScoreTypeDistance, domain.ScoreTypePoints:
+		def := domain.LookupScoreType(scoreType)
+		return def.Unit, def.LowerIsBetter
+	default:
+		return "seconds", true
+	}
+}
+
+// benchmarkScore normalizes a result to seconds, total reps, load or the score type's single field;
+// nil when it cannot be compared (including time-capped results, which have no finishing time)
+func benchmarkScore(scoreType string, result *domain.UserWorkoutWOD, repsPerRound *int) *float64 {
+	var score float64
+	switch scoreType {
+	case domain.ScoreTypeRoundsReps:
+		if result.Rounds == nil || repsPerRound == nil {
+			return nil
+		}
+		score = float64(*result.Rounds * *repsPerRound)
+		if result.Reps != nil {
+			score += float64(*result.Reps)
+		}
+	case domain.ScoreTypeMaxWeight:
+		if result.Weight == nil {
+			return nil
+		}
+		score = *result.Weight
+	case domain.ScoreTypeReps, domain.ScoreTypeCalories, domain.ScoreTypeDistance, domain.ScoreTypePoints:
+		value, ok := result.ScoreFieldValue(domain.LookupScoreType(scoreType).Fields[0])
+		if !ok {
+			return nil
+		}
+		score = value
+	default:
+		if result.TimeSeconds == nil || result.TimeCapped {
+			return nil
+		}
+		score = float64(*result.TimeSeconds)
+	}
+	return &score
+}
+
+// GetConsistency returns streaks, rolling sessions per week and the weekday distribution.
+// restDays is how many consecutive days off a streak tolerates (0 = train every day).
+func (s *AnalyticsService) GetConsistency(userID int64, restDays int) (*ConsistencyReport, error) {
+	if restDays < 0 || restDays > 6 {
+		return nil, ErrInvalidRestDays
+	}
+
+	today := truncateDay(s.now())
+	workouts, err := s.userWorkoutRepo.ListByUserAndDateRange(userID, time.Time{}, today.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ConsistencyReport{
+		RestDays:      restDays,
+		TotalSessions: len(workouts),
+	}
+
+	sessionsByDay := make(map[time.Time]int)
+	weekdayCounts := make([]int, 7) // Monday = 0
+	for _, w := range workouts {
+		day := truncateDay(w.WorkoutDate)
+		sessionsByDay[day]++
+		weekdayCounts[(int(day.Weekday())+6)%7]++
+	}
+
+	days := make([]time.Time, 0, len(sessionsByDay))
+	for day := range sessionsByDay {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	report.TrainingDays = len(days)
+
+	if len(days) > 0 {
+		last := days[len(days)-1].Format(domain.ScheduledDateFormat)
+		report.LastWorkoutDate = &last
+	}
+	report.CurrentStreak, report.LongestStreak = computeStreaks(days, restDays, today)
+
+	for _, weeks := range consistencyWindows {
+		since := today.AddDate(0, 0, -7*weeks+1)
+		sessions := 0
+		for day, count := range sessionsByDay {
+			if !day.Before(since) {
+				sessions += count
+			}
+		}
+		report.Windows = append(report.Windows, WeeklyFrequency{
+			Weeks:           weeks,
+			Sessions:        sessions,
+			SessionsPerWeek: roundTenth(float64(sessions) / float64(weeks)),
+		})
+	}
+
+	for i, count := range weekdayCounts {
+		entry := DayOfWeekCount{Day: time.Weekday((i + 1) % 7).String(), Count: count}
+		if len(workouts) > 0 {
+			entry.Percent = roundTenth(float64(count) / float64(len(workouts)) * 100)
+		}
+		report.DayOfWeek = append(report.DayOfWeek, entry)
+	}
+
+	return report, nil
+}
+
+// GetTrainingCalendar returns a year of daily session counts laid out as Monday-start weeks
+func (s *AnalyticsService) GetTrainingCalendar(userID int64, year int) (*TrainingCalendar, error) {
+	if year < 1900 || year > 9999 {
+		return nil, ErrInvalidCalendarYear
+	}
+
+	first := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)
+	workouts, err := s.userWorkoutRepo.ListByUserAndDateRange(userID, first, last.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, w := range workouts {
+		counts[w.WorkoutDate.UTC().Format(domain.ScheduledDateFormat)]++
+	}
+
+	calendar := &TrainingCalendar{Year: year, TotalSessions: len(workouts)}
+	for week := bucketStart(domain.BucketWeek, first); !week.After(last); week = week.AddDate(0, 0, 7) {
+		row := make([]*CalendarDay, 7)
+		for i := range row {
+			day := week.AddDate(0, 0, i)
+			if day.Year() != year {
+				continue
+			}
+			cell := &CalendarDay{Date: day.Format(domain.ScheduledDateFormat)}
+			cell.Count = counts[cell.Date]
+			if cell.Count > 0 {
+				calendar.ActiveDays++
+			}
+			if cell.Count > calendar.MaxCount {
+				calendar.MaxCount = cell.Count
+			}
+			row[i] = cell
+		}
+		calendar.Weeks = append(calendar.Weeks, row)
+	}
+
+	return calendar, nil
+}
+
+// GetTrainingLoad computes daily session load with ACWR, monotony and strain between start and end (inclusive).
+// Warnings are raised when ACWR leaves [acwrLow, acwrHigh] or monotony exceeds 2.0.
+func (s *AnalyticsService) GetTrainingLoad(userID int64, start, end time.Time, acwrLow, acwrHigh float64) (*TrainingLoadReport, error) {
+	if acwrLow <= 0 || acwrHigh <= acwrLow {
+		return nil, ErrInvalidACWRBand
+	}
+	start, end = truncateDay(start), truncateDay(end)
+	if start.After(end) {
+		return nil, ErrInvalidScheduleRange
+	}
+	if daysBetween(start, end)+1 > maxTrainingLoadDays {
+		return nil, ErrAnalyticsRangeTooLarge
+	}
+
+	// Load the full chronic window before the first reported day
+	historyStart := start.AddDate(0, 0, -(chronicLoadDays - 1))
+	workouts, err := s.userWorkoutRepo.ListByUserAndDateRange(userID, historyStart, end.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TrainingLoadReport{
+		StartDate: start.Format(domain.ScheduledDateFormat),
+		EndDate:   end.Format(domain.ScheduledDateFormat),
+		ACWRLow:   acwrLow,
+		ACWRHigh:  acwrHigh,
+		Warnings:  []LoadWarning{},
+	}
+
+	totalDays := daysBetween(historyStart, end) + 1
+	loads := make([]float64, totalDays)
+	sessions := make([]int, totalDays)
+	firstLoad := -1
+	acuteStart := end.AddDate(0, 0, -(acuteLoadDays - 1))
+	var wellness []domain.SessionWellness
+	for _, w := range workouts {
+		day := truncateDay(w.WorkoutDate)
+		i := daysBetween(historyStart, day)
+		if i < 0 || i >= totalDays {
+			continue
+		}
+		sessions[i]++
+		if !day.Before(acuteStart) {
+			wellness = append(wellness, w.SessionWellness)
+		}
+
+		load, ok := sessionLoad(w)
+		if !ok {
+			if !day.Before(start) {
+				report.SessionsWithoutLoad++
+			}
+			continue
+		}
+		loads[i] += load
+		if firstLoad == -1 || i < firstLoad {
+			firstLoad = i
+		}
+	}
+
+	var prev *DailyLoad
+	for i := chronicLoadDays - 1; i < totalDays; i++ {
+		day := &DailyLoad{
+			Date:     historyStart.AddDate(0, 0, i).Format(domain.ScheduledDateFormat),
+			Sessions: sessions[i],
+			Load:     roundTenth(loads[i]),
+		}
+		acute := sumLoads(loads[i-acuteLoadDays+1 : i+1])
+		chronic := sumLoads(loads[i-chronicLoadDays+1:i+1]) / (chronicLoadDays / acuteLoadDays)
+		day.AcuteLoad = roundTenth(acute)
+		day.ChronicLoad = roundTenth(chronic)
+
+		// The ratio is only meaningful once a full chronic window of history exists
+		if firstLoad != -1 && i-firstLoad >= chronicLoadDays-1 && chronic > 0 {
+			acwr := math.Round(acute/chronic*100) / 100
+			day.ACWR = &acwr
+			switch {
+			case acwr < acwrLow:
+				day.Zone = "low"
+			case acwr > acwrHigh:
+				day.Zone = "high"
+			default:
+				day.Zone = "safe"
+			}
+		}
+		if monotony, ok := loadMonotony(loads[i-acuteLoadDays+1 : i+1]); ok {
+			monotony = math.Round(monotony*100) / 100
+			strain := roundTenth(acute * monotony)
+			day.Monotony = &monotony
+			day.Strain = &strain
+		}
+
+		report.Warnings = append(report.Warnings, loadWarnings(prev, day, acwrLow, acwrHigh)...)
+		report.Days = append(report.Days, day)
+		prev = day
+	}
+	report.Current = prev
+	report.Wellness = summarizeWellness(wellness)
+
+	return report, nil
+}
+
+// GetMovementBreakdown reports the volume of each movement logged between start and end (inclusive),
+// most sessions first, with totals per movement pattern. With rollup, variants such as Front Squat
+// are counted under their parent movement; a session with several variants counts once.
+func (s *AnalyticsService) GetMovementBreakdown(userID int64, start, end time.Time, rollup bool) (*MovementBreakdown, error) {
+	start, end = truncateDay(start), truncateDay(end)
+	if start.After(end) {
+		return nil, ErrInvalidScheduleRange
+	}
+	queryEnd := end.Add(24*time.Hour - time.Nanosecond)
+
+	logged, err := s.analyticsRepo.GetMovementFrequency(userID, start, queryEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make(map[int64]*domain.Movement)
+	lookup := func(id int64) (*domain.Movement, error) {
+		if m, ok := movements[id]; ok {
+			return m, nil
+		}
+		m, err := s.movementRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		movements[id] = m
+		return m, nil
+	}
+
+	report := &MovementBreakdown{
+		StartDate: start.Format(domain.ScheduledDateFormat),
+		EndDate:   end.Format(domain.ScheduledDateFormat),
+		Rollup:    rollup,
+		Movements: []*MovementBreakdownEntry{},
+		Patterns:  []*PatternVolume{},
+	}
+
+	// Pattern totals use each logged movement's own pattern, so a variant with a different
+	// pattern than its parent is still counted where it belongs
+	patterns := make(map[string]*PatternVolume)
+	variants := make(map[int64][]string)
+	for _, f := range logged {
+		m, err := lookup(f.MovementID)
+		if err != nil {
+			return nil, err
+		}
+		pattern := "unspecified"
+		if m != nil && m.Pattern != "" {
+			pattern = m.Pattern
+		}
+		p, ok := patterns[pattern]
+		if !ok {
+			p = &PatternVolume{Pattern: pattern}
+			patterns[pattern] = p
+			report.Patterns = append(report.Patterns, p)
+		}
+		p.Sets += f.Sets
+		p.Reps += f.Reps
+		p.Tonnage += f.Tonnage
+
+		if rollup && m != nil && m.ParentID != nil {
+			variants[*m.ParentID] = append(variants[*m.ParentID], m.Name)
+		}
+	}
+	sort.SliceStable(report.Patterns, func(i, j int) bool {
+		return report.Patterns[i].Tonnage > report.Patterns[j].Tonnage
+	})
+
+	entries := logged
+	if rollup {
+		if entries, err = s.analyticsRepo.GetMovementFamilyFrequency(userID, start, queryEnd); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range entries {
+		entry := &MovementBreakdownEntry{
+			MovementID:   f.MovementID,
+			MovementName: f.MovementName,
+			Variants:     variants[f.MovementID],
+			Sessions:     f.Sessions,
+			Sets:         f.Sets,
+			Reps:         f.Reps,
+			Tonnage:      roundTenth(f.Tonnage),
+		}
+		m, err := lookup(f.MovementID)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			entry.Pattern = m.Pattern
+		}
+		report.Movements = append(report.Movements, entry)
+	}
+	for _, p := range report.Patterns {
+		p.Tonnage = roundTenth(p.Tonnage)
+	}
+
+	return report, nil
+}
+
+// sessionLoad is session RPE x duration in minutes; false when either is missing
+func sessionLoad(w *domain.UserWorkout) (float64, bool) {
+	if w.SessionRPE == nil || w.TotalTime == nil || *w.TotalTime <= 0 {
+		return 0, false
+	}
+	return float64(*w.SessionRPE) * float64(*w.TotalTime) / 60, true
+}
+
+func sumLoads(loads []float64) float64 {
+	var total float64
+	for _, l := range loads {
+		total += l
+	}
+	return total
+}
+
+// loadMonotony is mean / population standard deviation of daily loads (Foster);
+// false when there is no load or the loads do not vary
+func loadMonotony(loads []float64) (float64, bool) {
+	mean := sumLoads(loads) / float64(len(loads))
+	if mean == 0 {
+		return 0, false
+	}
+	var variance float64
+	for _, l := range loads {
+		variance += (l - mean) * (l - mean)
+	}
+	sd := math.Sqrt(variance / float64(len(loads)))
+	if sd < 1e-9 {
+		return 0, false
+	}
+	return mean / sd, true
+}
+
+// loadWarnings reports when day first moves outside the ACWR band or above the monotony threshold
+func loadWarnings(prev, day *DailyLoad, acwrLow, acwrHigh float64) []LoadWarning {
+	var warnings []LoadWarning
+	if day.Zone == "high" || day.Zone == "low" {
+		if prev == nil || prev.Zone != day.Zone {
+			w := LoadWarning{Date: day.Date, Type: "acwr_" + day.Zone, Value: *day.ACWR}
+			if day.Zone == "high" {
+				w.Message = fmt.Sprintf("Acute:chronic workload ratio %.2f is above %.2f; injury risk rises with load spikes", *day.ACWR, acwrHigh)
+			} else {
+				w.Message = fmt.Sprintf("Acute:chronic workload ratio %.2f is below %.2f; training load is dropping off", *day.ACWR, acwrLow)
+			}
+			warnings = append(warnings, w)
+		}
+	}
+	if day.Monotony != nil && *day.Monotony > monotonyWarningThreshold {
+		if prev == nil || prev.Monotony == nil || *prev.Monotony <= monotonyWarningThreshold {
+			warnings = append(warnings, LoadWarning{
+				Date:    day.Date,
+				Type:    "monotony_high",
+				Value:   *day.Monotony,
+				Message: fmt.Sprintf("Training monotony %.2f is above %.1f; vary daily load and include easier days", *day.Monotony, monotonyWarningThreshold),
+			})
+		}
+	}
+	return warnings
+}
+
+// summarizeWellness averages each readiness marker over the sessions that recorded it
+func summarizeWellness(entries []domain.SessionWellness) WellnessSummary {
+	summary := WellnessSummary{Sessions: len(entries)}
+	average := func(value func(domain.SessionWellness) *float64) *float64 {
+		var total float64
+		var n int
+		for _, e := range entries {
+			if v := value(e); v != nil {
+				total += *v
+				n++
+			}
+		}
+		if n == 0 {
+			return nil
+		}
+		avg := roundTenth(total / float64(n))
+		return &avg
+	}
+	intValue := func(v *int) *float64 {
+		if v == nil {
+			return nil
+		}
+		f := float64(*v)
+		return &f
+	}
+
+	summary.AvgSessionRPE = average(func(e domain.SessionWellness) *float64 { return intValue(e.SessionRPE) })
+	summary.AvgSleepHours = average(func(e domain.SessionWellness) *float64 { return e.SleepHours })
+	summary.AvgSleepQuality = average(func(e domain.SessionWellness) *float64 { return intValue(e.SleepQuality) })
+	summary.AvgSoreness = average(func(e domain.SessionWellness) *float64 { return intValue(e.Soreness) })
+	summary.AvgReadiness = average(func(e domain.SessionWellness) *float64 { return intValue(e.Readiness) })
+	return summary
+}
+
+// computeStreaks walks sorted, distinct training days. A gap of more than restDays days off ends a streak;
+// the last streak is current while today is within restDays+1 days of its final training day.
+func computeStreaks(days []time.Time, restDays int, today time.Time) (current, longest *Streak) {
+	if len(days) == 0 {
+		return nil, nil
+	}
+
+	maxGap := restDays + 1
+	start, trainingDays := days[0], 1
+	closeStreak := func(end time.Time) *Streak {
+		return &Streak{
+			StartDate:    start.Format(domain.ScheduledDateFormat),
+			EndDate:      end.Format(domain.ScheduledDateFormat),
+			Days:         daysBetween(start, end) + 1,
+			TrainingDays: trainingDays,
+		}
+	}
+
+	for i := 1; i < len(days); i++ {
+		if daysBetween(days[i-1], days[i]) > maxGap {
+			if streak := closeStreak(days[i-1]); longest == nil || streak.Days > longest.Days {
+				longest = streak
+			}
+			start, trainingDays = days[i], 0
+		}
+		trainingDays++
+	}
+
+	lastDay := days[len(days)-1]
+	streak := closeStreak(lastDay)
+	if longest == nil || streak.Days > longest.Days {
+		longest = streak
+	}
+	if daysBetween(lastDay, today) <= maxGap {
+		current = streak
+	}
+
+	return current, longest
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// resolveE1RMFormula matches a formula name case-insensitively; empty selects the hybrid default
+func resolveE1RMFormula(formula string) (string, error) {
+	if formula == "" {
+		return E1RMFormulaHybrid, nil
+	}
+	for _, name := range E1RMFormulas() {
+		if strings.EqualFold(name, formula) {
+			return name, nil
+		}
+	}
+	return "", ErrInvalidE1RMFormula
+}
+
+// estimate1RM applies a resolved formula; false when the formula does not cover the rep count
+// (Actual only accepts singles, Brzycki stops at 36 reps)
+func estimate1RM(formula string, weight float64, reps int) (float64, bool) {
+	if formula == E1RMFormulaHybrid {
+		oneRM, _ := prmath.Calculate1RM(weight, reps)
+		return oneRM, oneRM > 0
+	}
+	oneRM, ok := prmath.CalculateAllFormulas(weight, reps)[formula]
+	return oneRM, ok
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// bucketStart truncates a date to the start of its bucket (weeks start on Monday)
+func bucketStart(bucket string, t time.Time) time.Time {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch bucket {
+	case domain.BucketWeek:
+		offset := (int(d.Weekday()) + 6) % 7 // Monday = 0
+		return d.AddDate(0, 0, -offset)
+	case domain.BucketMonth:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return d
+	}
+}
+
+func nextBucket(bucket string, t time.Time) time.Time {
+	switch bucket {
+	case domain.BucketWeek:
+		return t.AddDate(0, 0, 7)
+	case domain.BucketMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
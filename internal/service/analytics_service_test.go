@@ -0,0 +1,265 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+func TestBucketStart(t *testing.T) {
+	sunday := time.Date(2026, 9, 13, 18, 0, 0, 0, time.UTC)
+
+	if got := bucketStart("week", sunday).Format("2006-01-02"); got != "2026-09-07" {
+		t.Errorf("expected week to start Monday 2026-09-07, got %s", got)
+	}
+	if got := bucketStart("month", sunday).Format("2006-01-02"); got != "2026-09-01" {
+		t.Errorf("expected month start 2026-09-01, got %s", got)
+	}
+	if got := bucketStart("day", sunday).Format("2006-01-02"); got != "2026-09-13" {
+		t.Errorf("expected day 2026-09-13, got %s", got)
+	}
+}
+
+func TestAnnotateTrend(t *testing.T) {
+	periods := []*VolumePeriod{
+		{Tonnage: 10000},
+		{Tonnage: 0},
+		{Tonnage: 10000},
+		{Tonnage: 5000},
+	}
+
+	annotateTrend(periods)
+
+	if periods[0].TonnageChangePercent != nil || periods[0].IsDeload {
+		t.Errorf("first period should have no trend")
+	}
+	// Empty weeks are skipped when averaging, but a zero-tonnage week is itself a deload
+	if !periods[1].IsDeload {
+		t.Errorf("expected empty period after training to be flagged")
+	}
+	if periods[2].TonnageChangePercent != nil {
+		t.Errorf("expected no change percent after an empty period")
+	}
+	if periods[2].IsDeload {
+		t.Errorf("expected full period not to be flagged")
+	}
+	if periods[3].TonnageChangePercent == nil || *periods[3].TonnageChangePercent != -50 {
+		t.Errorf("expected -50%% change, got %v", periods[3].TonnageChangePercent)
+	}
+	if !periods[3].IsDeload {
+		t.Errorf("expected half-volume period to be flagged as deload")
+	}
+}
+
+func TestResolveE1RMFormula(t *testing.T) {
+	if got, err := resolveE1RMFormula(""); err != nil || got != E1RMFormulaHybrid {
+		t.Errorf("expected hybrid default, got %q (%v)", got, err)
+	}
+	if got, err := resolveE1RMFormula("brzycki"); err != nil || got != "Brzycki" {
+		t.Errorf("expected case-insensitive match to Brzycki, got %q (%v)", got, err)
+	}
+	if _, err := resolveE1RMFormula("Bogus"); err != ErrInvalidE1RMFormula {
+		t.Errorf("expected ErrInvalidE1RMFormula, got %v", err)
+	}
+}
+
+func TestEstimate1RM(t *testing.T) {
+	if v, ok := estimate1RM(E1RMFormulaHybrid, 200, 1); !ok || v != 200 {
+		t.Errorf("expected hybrid single to be actual weight, got %v", v)
+	}
+	if _, ok := estimate1RM("Actual", 200, 5); ok {
+		t.Errorf("expected Actual to skip multi-rep sets")
+	}
+	if _, ok := estimate1RM("Brzycki", 100, 40); ok {
+		t.Errorf("expected Brzycki to skip sets of 37+ reps")
+	}
+	if v, ok := estimate1RM("Epley", 300, 3); !ok || v != 330 {
+		t.Errorf("expected Epley 330, got %v", v)
+	}
+}
+
+func TestBenchmarkScore(t *testing.T) {
+	rounds, reps, perRound := 18, 5, 30
+
+	amrap := &domain.UserWorkoutWOD{Rounds: &rounds, Reps: &reps}
+	if got := benchmarkScore("Rounds+Reps", amrap, &perRound); got == nil || *got != 545 {
+		t.Errorf("expected 545 total reps, got %v", got)
+	}
+	if got := benchmarkScore("Rounds+Reps", amrap, nil); got != nil {
+		t.Errorf("expected no score without reps per round, got %v", *got)
+	}
+
+	seconds := 245
+	if got := benchmarkScore("Time (HH:MM:SS)", &domain.UserWorkoutWOD{TimeSeconds: &seconds}, nil); got == nil || *got != 245 {
+		t.Errorf("expected 245 seconds, got %v", got)
+	}
+	if got := benchmarkScore("Max Weight", &domain.UserWorkoutWOD{}, nil); got != nil {
+		t.Errorf("expected no score without weight, got %v", *got)
+	}
+}
+
+func TestGetBenchmarkRetestsCountsRepsPerRound(t *testing.T) {
+	db := openTestDB(t)
+	wodRepo := repository.NewWODRepository(db)
+	structureRepo := repository.NewWODStructureRepository(db)
+	userRepo := repository.NewSQLiteUserRepository(db)
+	userWorkoutRepo := repository.NewUserWorkoutRepository(db)
+	userWorkoutWODRepo := repository.NewUserWorkoutWODRepository(db)
+
+	wod := &domain.WOD{Name: "Retest AMRAP", Source: "CrossFit", Type: "Girl", Regime: "AMRAP",
+		ScoreType: domain.ScoreTypeRoundsReps, IsStandard: true}
+	if err := wodRepo.Create(wod); err != nil {
+		t.Fatalf("failed to create wod: %v", err)
+	}
+	// 5 pull-ups, 10 push-ups and 15 calories on the rower: 30 reps per round
+	if err := structureRepo.Save(&domain.WODStructure{WODID: wod.ID, Source: domain.WODStructureSourceManual, Components: []*domain.WODComponent{
+		{MovementName: "Pull-ups", Reps: intPtr(5)},
+		{MovementName: "Push-ups", Reps: intPtr(10)},
+		{MovementName: "Row", Calories: intPtr(15)},
+	}}); err != nil {
+		t.Fatalf("failed to save structure: %v", err)
+	}
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	user := &domain.User{Email: "retest@example.com", Name: "Retest", Role: "user", CreatedAt: day, UpdatedAt: day}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	for i, rounds := range []int{15, 18, 17} {
+		name := wod.Name
+		userWorkout := &domain.UserWorkout{UserID: user.ID, WorkoutName: &name, WorkoutDate: day.AddDate(0, i, 0)}
+		if err := userWorkoutRepo.Create(userWorkout); err != nil {
+			t.Fatalf("failed to log workout: %v", err)
+		}
+		if err := userWorkoutWODRepo.Create(&domain.UserWorkoutWOD{UserWorkoutID: userWorkout.ID, WODID: wod.ID,
+			Rounds: intPtr(rounds), Reps: intPtr(5)}); err != nil {
+			t.Fatalf("failed to log result: %v", err)
+		}
+	}
+
+	service := NewAnalyticsService(nil, nil, wodRepo, userWorkoutRepo, userWorkoutWODRepo)
+	service.SetStructureService(NewWODStructureService(structureRepo, wodRepo, nil))
+	service.now = func() time.Time { return day.AddDate(0, 3, 0) }
+
+	report, err := service.GetBenchmarkRetests(user.ID, wod.ID, nil)
+	if err != nil {
+		t.Fatalf("GetBenchmarkRetests failed: %v", err)
+	}
+	if report.RepsPerRound == nil || *report.RepsPerRound != 30 {
+		t.Fatalf("expected 30 reps per round from the structure, got %v", report.RepsPerRound)
+	}
+	if len(report.Attempts) != 3 || report.Attempts[0].WorkoutDate != "2026-03-01" {
+		t.Fatalf("expected three attempts oldest first, got %+v", report.Attempts)
+	}
+	last := report.Attempts[2]
+	if last.Score == nil || *last.Score != 515 || last.DeltaFromFirst == nil || *last.DeltaFromFirst != 60 {
+		t.Errorf("expected 515 total reps, 60 more than the first attempt, got %+v", last)
+	}
+	if report.CurrentPR == nil || report.CurrentPR.Score == nil || *report.CurrentPR.Score != 545 {
+		t.Errorf("expected a 545 rep PR, got %+v", report.CurrentPR)
+	}
+
+	override := 20
+	report, err = service.GetBenchmarkRetests(user.ID, wod.ID, &override)
+	if err != nil {
+		t.Fatalf("GetBenchmarkRetests failed: %v", err)
+	}
+	if score := report.Attempts[0].Score; score == nil || *score != 305 {
+		t.Errorf("expected reps_per_round to override the structure, got %v", score)
+	}
+}
+
+func TestComputeStreaks(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	// Mar 1-3 trained, 2 days off, Mar 6-10 every other day
+	days := []time.Time{day(1), day(2), day(3), day(6), day(8), day(10)}
+
+	current, longest := computeStreaks(days, 1, day(11))
+	if longest == nil || longest.StartDate != "2026-03-06" || longest.Days != 5 || longest.TrainingDays != 3 {
+		t.Errorf("unexpected longest streak with one rest day: %+v", longest)
+	}
+	if current == nil || current.StartDate != "2026-03-06" {
+		t.Errorf("expected current streak to start 2026-03-06, got %+v", current)
+	}
+
+	_, longest = computeStreaks(days, 0, day(11))
+	if longest.StartDate != "2026-03-01" || longest.Days != 3 {
+		t.Errorf("unexpected longest streak without rest days: %+v", longest)
+	}
+
+	current, _ = computeStreaks(days, 1, day(13))
+	if current != nil {
+		t.Errorf("expected streak to be broken after two days off, got %+v", current)
+	}
+
+	current, longest = computeStreaks(nil, 1, day(13))
+	if current != nil || longest != nil {
+		t.Errorf("expected no streaks without training days")
+	}
+}
+
+func TestGetTrainingLoad(t *testing.T) {
+	repo := newMockUserWorkoutRepo()
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 18, 0, 0, 0, time.UTC) }
+	log := func(date time.Time, rpe *int, minutes int) {
+		_ = repo.Create(&domain.UserWorkout{
+			UserID:          1,
+			WorkoutDate:     date,
+			TotalTime:       intPtr(minutes * 60),
+			SessionWellness: domain.SessionWellness{SessionRPE: rpe, Readiness: intPtr(7)},
+		})
+	}
+	// Four steady weeks at 300 a day, then a week at 480 a day
+	for d := 1; d <= 28; d++ {
+		log(day(d), intPtr(5), 60)
+	}
+	for d := 29; d <= 35; d++ {
+		log(day(d), intPtr(8), 60)
+	}
+	log(day(35), nil, 30) // no session RPE
+
+	service := NewAnalyticsService(nil, nil, nil, repo, nil)
+	report, err := service.GetTrainingLoad(1, day(28), day(35), DefaultACWRLow, DefaultACWRHigh)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Days) != 8 || report.Days[0].Zone != "safe" || *report.Days[0].ACWR != 1 {
+		t.Fatalf("expected 8 days starting in the safe zone, got %+v", report.Days[0])
+	}
+	current := report.Current
+	// acute 7 x 480 = 3360; chronic (21 x 300 + 3360) / 4 = 2415
+	if current.AcuteLoad != 3360 || current.ChronicLoad != 2415 || current.ACWR == nil || *current.ACWR != 1.39 || current.Zone != "high" {
+		t.Errorf("unexpected current load: %+v", current)
+	}
+	if current.Monotony != nil {
+		t.Errorf("expected no monotony for identical daily loads, got %v", *current.Monotony)
+	}
+	// Near-identical daily loads also trip the monotony warning once the spike starts
+	if len(report.Warnings) != 2 || report.Warnings[0].Type != "monotony_high" ||
+		report.Warnings[1].Type != "acwr_high" || report.Warnings[1].Date != "2026-04-03" {
+		t.Errorf("expected monotony_high then acwr_high warnings, got %+v", report.Warnings)
+	}
+	if report.SessionsWithoutLoad != 1 {
+		t.Errorf("expected one session without load, got %d", report.SessionsWithoutLoad)
+	}
+	if report.Wellness.Sessions != 8 || report.Wellness.AvgReadiness == nil || *report.Wellness.AvgReadiness != 7 {
+		t.Errorf("unexpected wellness summary: %+v", report.Wellness)
+	}
+
+	if _, err := service.GetTrainingLoad(1, day(28), day(35), 1.3, 0.8); err != ErrInvalidACWRBand {
+		t.Errorf("expected ErrInvalidACWRBand, got %v", err)
+	}
+}
+
+func TestLoadMonotony(t *testing.T) {
+	monotony, ok := loadMonotony([]float64{300, 0, 300, 0, 300, 0, 300})
+	if !ok || roundTenth(monotony) != 1.2 {
+		t.Errorf("loadMonotony = %v, %v; want 1.2", monotony, ok)
+	}
+	if _, ok := loadMonotony(make([]float64, 7)); ok {
+		t.Error("expected no monotony without load")
+	}
+}
@@ -0,0 +1,544 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Register GIF decoding for thumbnails
+	"image/jpeg"
+	_ "image/png" // Register PNG decoding for thumbnails
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrAttachmentEntityNotFound = errors.New("movement, WOD or workout not found")
+	ErrInvalidAttachmentEntity  = errors.New("invalid attachment entity type")
+	ErrAttachmentUnauthorized   = errors.New("unauthorized to access these attachments")
+	ErrAttachmentTooLarge       = errors.New("attachment is too large")
+	ErrUnsupportedAttachment    = errors.New("unsupported attachment type")
+	ErrInvalidAttachmentLink    = errors.New("link must be an http or https URL")
+	ErrInvalidAttachmentTitle   = errors.New("attachment title must be at most 255 characters")
+	ErrAttachmentURLExpired     = errors.New("attachment file link has expired; list the attachments again")
+)
+
+// Attachment limits
+const (
+	MaxAttachmentImageBytes = 10 << 20  // 10MB
+	MaxAttachmentVideoBytes = 100 << 20 // 100MB
+	maxAttachmentTitle      = 255
+	maxAttachmentLink       = 2048
+
+	attachmentUploadDir      = "attachments" // Under the uploads directory
+	attachmentThumbnailSize  = 320           // Longest side in pixels
+	maxThumbnailSourcePixels = 50_000_000    // Larger images are kept without a thumbnail
+
+	workoutFileURLLifetime = time.Hour // How long a signed workout attachment file URL works
+)
+
+// WorkoutAttachmentUploadDir holds the files of logged workout attachments, under the uploads directory.
+// They are private to the workout's owner and their coaches and served by WorkoutFile through signed
+// URLs, never as static uploads.
+const WorkoutAttachmentUploadDir = attachmentUploadDir + "/" + domain.AttachmentEntityWorkout
+
+// attachmentFileType is an accepted upload format, identified from the file's content
+type attachmentFileType struct {
+	kind string
+	ext  string
+}
+
+var attachmentFileTypes = map[string]attachmentFileType{
+	"image/jpeg":      {domain.AttachmentKindImage, ".jpg"},
+	"image/png":       {domain.AttachmentKindImage, ".png"},
+	"image/gif":       {domain.AttachmentKindImage, ".gif"},
+	"image/webp":      {domain.AttachmentKindImage, ".webp"},
+	"video/mp4":       {domain.AttachmentKindVideo, ".mp4"},
+	"video/webm":      {domain.AttachmentKindVideo, ".webm"},
+	"video/quicktime": {domain.AttachmentKindVideo, ".mov"},
+}
+
+// AttachmentService handles images, videos and links on movements, WODs and logged workouts
+type AttachmentService struct {
+	attachmentRepo  domain.AttachmentRepository
+	movementRepo    domain.MovementRepository
+	wodRepo         domain.WODRepository
+	userWorkoutRepo domain.UserWorkoutRepository
+	coachService    *CoachService
+	uploadsDir      string
+	fileURLSecret   []byte
+}
+
+// NewAttachmentService creates a new attachment service storing files under uploadsDir
+func NewAttachmentService(
+	attachmentRepo domain.AttachmentRepository,
+	movementRepo domain.MovementRepository,
+	wodRepo domain.WODRepository,
+	userWorkoutRepo domain.UserWorkoutRepository,
+	uploadsDir string,
+) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo:  attachmentRepo,
+		movementRepo:    movementRepo,
+		wodRepo:         wodRepo,
+		userWorkoutRepo: userWorkoutRepo,
+		uploadsDir:      uploadsDir,
+	}
+}
+
+// SetCoachService lets coaches list the workout attachments of the athletes they manage
+func (s *AttachmentService) SetCoachService(coachService *CoachService) {
+	s.coachService = coachService
+}
+
+// SetFileURLSecret sets the key signing workout attachment file URLs; without one their files cannot be downloaded
+func (s *AttachmentService) SetFileURLSecret(secret string) {
+	s.fileURLSecret = []byte(secret)
+}
+
+// List lists an entity's attachments. Movement and WOD attachments are public; a logged
+// workout's are only visible to its owner, with file URLs signed for a limited time.
+func (s *AttachmentService) List(entityType string, entityID, userID int64) ([]*domain.Attachment, error) {
+	if err := s.authorize(entityType, entityID, userID, false, false); err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepo.ListByEntity(entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if entityType == domain.AttachmentEntityWorkout {
+		s.signFileURLs(attachments)
+	}
+	return attachments, nil
+}
+
+// ListForCoach lists the attachments of an athlete's logged workout for their coach; the read is
+// recorded in the audit log like every other coach view
+func (s *AttachmentService) ListForCoach(coachID, athleteID, userWorkoutID int64, ipAddress, userAgent string) ([]*domain.Attachment, error) {
+	if s.coachService == nil {
+		return nil, ErrAttachmentUnauthorized
+	}
+	if err := s.coachService.authorize(coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	userWorkout, err := s.userWorkoutRepo.GetByID(userWorkoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logged workout: %w", err)
+	}
+	if userWorkout == nil || userWorkout.UserID != athleteID {
+		return nil, ErrAttachmentEntityNotFound
+	}
+
+	attachments, err := s.attachmentRepo.ListByEntity(domain.AttachmentEntityWorkout, userWorkoutID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.coachService.logView(coachID, athleteID, "workout_attachments", ipAddress, userAgent, map[string]interface{}{
+		"user_workout_id": userWorkoutID,
+	}); err != nil {
+		return nil, err
+	}
+	s.signFileURLs(attachments)
+	return attachments, nil
+}
+
+// Upload stores an image or video and attaches it to an entity. The type is detected from the
+// content rather than the file name; images get a JPEG thumbnail when they can be decoded.
+func (s *AttachmentService) Upload(entityType string, entityID, userID int64, isAdmin bool, title, filename string, file io.Reader) (*domain.Attachment, error) {
+	if err := s.authorize(entityType, entityID, userID, isAdmin, true); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return nil, ErrUnsupportedAttachment
+		}
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	head = head[:n]
+
+	contentType := detectAttachmentType(head)
+	fileType, ok := attachmentFileTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAttachment, contentType)
+	}
+	limit := int64(MaxAttachmentImageBytes)
+	if fileType.kind == domain.AttachmentKindVideo {
+		limit = MaxAttachmentVideoBytes
+	}
+
+	if title = strings.TrimSpace(title); title == "" {
+		title = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	if title == "" || title == "." {
+		title = fileType.kind
+	}
+	if len(title) > maxAttachmentTitle {
+		return nil, ErrInvalidAttachmentTitle
+	}
+
+	name, err := randomAttachmentName()
+	if err != nil {
+		return nil, fmt.Errorf("failed to name upload: %w", err)
+	}
+	dir := path.Join(attachmentUploadDir, entityType, strconv.FormatInt(entityID, 10))
+	if err := os.MkdirAll(filepath.Join(s.uploadsDir, filepath.FromSlash(dir)), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+
+	relPath := path.Join(dir, name+fileType.ext)
+	size, err := s.writeUpload(relPath, io.MultiReader(bytes.NewReader(head), file), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &domain.Attachment{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Kind:        fileType.kind,
+		Title:       title,
+		URL:         attachmentFileURL(entityType, entityID, relPath),
+		ContentType: &contentType,
+		SizeBytes:   size,
+		FilePath:    &relPath,
+		CreatedBy:   &userID,
+	}
+
+	if fileType.kind == domain.AttachmentKindImage {
+		thumbPath := path.Join(dir, name+"_thumb.jpg")
+		if err := writeThumbnail(s.fullPath(relPath), s.fullPath(thumbPath)); err != nil {
+			fmt.Printf("Warning: failed to create thumbnail for %s: %v\n", relPath, err)
+		} else {
+			thumbURL := attachmentFileURL(entityType, entityID, thumbPath)
+			attachment.ThumbnailPath = &thumbPath
+			attachment.ThumbnailURL = &thumbURL
+		}
+	}
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		s.removeFiles(attachment)
+		return nil, err
+	}
+	if entityType == domain.AttachmentEntityWorkout {
+		signed := []*domain.Attachment{attachment}
+		s.signFileURLs(signed)
+		return signed[0], nil
+	}
+	return attachment, nil
+}
+
+// AddLink attaches a titled link to an entity
+func (s *AttachmentService) AddLink(entityType string, entityID, userID int64, isAdmin bool, title, link string) (*domain.Attachment, error) {
+	if err := s.authorize(entityType, entityID, userID, isAdmin, true); err != nil {
+		return nil, err
+	}
+
+	link = strings.TrimSpace(link)
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || len(link) > maxAttachmentLink {
+		return nil, ErrInvalidAttachmentLink
+	}
+	if title = strings.TrimSpace(title); title == "" {
+		title = parsed.Host
+	}
+	if len(title) > maxAttachmentTitle {
+		return nil, ErrInvalidAttachmentTitle
+	}
+
+	attachment := &domain.Attachment{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Kind:       domain.AttachmentKindLink,
+		Title:      title,
+		URL:        link,
+		CreatedBy:  &userID,
+	}
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// Delete removes an attachment and its files. Uploaders can delete their own attachments; whoever
+// can change an entity's attachments can delete any of them.
+func (s *AttachmentService) Delete(id, userID int64, isAdmin bool) error {
+	attachment, err := s.attachmentRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if attachment == nil {
+		return ErrAttachmentNotFound
+	}
+
+	if attachment.CreatedBy == nil || *attachment.CreatedBy != userID {
+		if err := s.authorize(attachment.EntityType, attachment.EntityID, userID, isAdmin, true); err != nil {
+			if errors.Is(err, ErrAttachmentEntityNotFound) {
+				return ErrAttachmentUnauthorized
+			}
+			return err
+		}
+	}
+
+	if err := s.attachmentRepo.Delete(id); err != nil {
+		return err
+	}
+	s.removeFiles(attachment)
+	return nil
+}
+
+// WorkoutFile returns the path of a stored file or thumbnail of a logged workout's attachments.
+// The request carries no credentials, so media tags can load it: the expiry and signature of a URL
+// handed out when listing the attachments are the proof of access.
+func (s *AttachmentService) WorkoutFile(userWorkoutID int64, name, expires, signature string) (string, error) {
+	if name == "" || name != path.Base(name) || strings.HasPrefix(name, ".") {
+		return "", ErrAttachmentNotFound
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || len(s.fileURLSecret) == 0 ||
+		!hmac.Equal([]byte(signature), []byte(s.fileSignature(userWorkoutID, name, expiresAt))) {
+		return "", ErrAttachmentUnauthorized
+	}
+	if time.Now().Unix() > expiresAt {
+		return "", ErrAttachmentURLExpired
+	}
+
+	fullPath := s.fullPath(path.Join(WorkoutAttachmentUploadDir, strconv.FormatInt(userWorkoutID, 10), name))
+	if info, err := os.Stat(fullPath); err != nil || info.IsDir() {
+		return "", ErrAttachmentNotFound
+	}
+	return fullPath, nil
+}
+
+// DeleteForEntity removes every attachment of a deleted movement, WOD or logged workout
+func (s *AttachmentService) DeleteForEntity(entityType string, entityID int64) error {
+	attachments, err := s.attachmentRepo.DeleteByEntity(entityType, entityID)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		s.removeFiles(attachment)
+	}
+	return nil
+}
+
+// authorize checks the entity exists and the user may view (or, with write, change) its attachments.
+// Admins manage attachments on every movement and WOD, other users only on their own custom ones;
+// a logged workout's attachments belong to its owner alone.
+func (s *AttachmentService) authorize(entityType string, entityID, userID int64, isAdmin, write bool) error {
+	switch entityType {
+	case domain.AttachmentEntityMovement:
+		movement, err := s.movementRepo.GetByID(entityID)
+		if err != nil {
+			return fmt.Errorf("failed to get movement: %w", err)
+		}
+		if movement == nil {
+			return ErrAttachmentEntityNotFound
+		}
+		if write && !isAdmin && (movement.IsStandard || movement.CreatedBy == nil || *movement.CreatedBy != userID) {
+			return ErrAttachmentUnauthorized
+		}
+	case domain.AttachmentEntityWOD:
+		wod, err := s.wodRepo.GetByID(entityID)
+		if err != nil {
+			return fmt.Errorf("failed to get wod: %w", err)
+		}
+		if wod == nil {
+			return ErrAttachmentEntityNotFound
+		}
+		if write && !isAdmin && (wod.IsStandard || wod.CreatedBy == nil || *wod.CreatedBy != userID) {
+			return ErrAttachmentUnauthorized
+		}
+	case domain.AttachmentEntityWorkout:
+		userWorkout, err := s.userWorkoutRepo.GetByID(entityID)
+		if err != nil {
+			return fmt.Errorf("failed to get logged workout: %w", err)
+		}
+		if userWorkout == nil {
+			return ErrAttachmentEntityNotFound
+		}
+		if userWorkout.UserID != userID {
+			return ErrAttachmentUnauthorized
+		}
+	default:
+		return ErrInvalidAttachmentEntity
+	}
+	return nil
+}
+
+// writeUpload copies an upload to relPath, failing with ErrAttachmentTooLarge past limit bytes
+func (s *AttachmentService) writeUpload(relPath string, src io.Reader, limit int64) (int64, error) {
+	fullPath := s.fullPath(relPath)
+	dst, err := os.Create(fullPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create attachment file: %w", err)
+	}
+
+	size, err := io.Copy(dst, io.LimitReader(src, limit+1))
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size > limit {
+		err = fmt.Errorf("%w (max %dMB)", ErrAttachmentTooLarge, limit>>20)
+	} else if err != nil {
+		err = fmt.Errorf("failed to save attachment: %w", err)
+	}
+	if err != nil {
+		os.Remove(fullPath)
+		return 0, err
+	}
+	return size, nil
+}
+
+// removeFiles deletes an attachment's stored file and thumbnail
+func (s *AttachmentService) removeFiles(attachment *domain.Attachment) {
+	for _, relPath := range []*string{attachment.FilePath, attachment.ThumbnailPath} {
+		if relPath == nil || *relPath == "" {
+			continue
+		}
+		if err := os.Remove(s.fullPath(*relPath)); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Warning: failed to delete attachment file %s: %v\n", *relPath, err)
+		}
+	}
+}
+
+// attachmentFileURL is where a stored file is downloaded: logged workout files through the API
+// (see signFileURLs), movement and WOD files as static uploads
+func attachmentFileURL(entityType string, entityID int64, relPath string) string {
+	if entityType == domain.AttachmentEntityWorkout {
+		return fmt.Sprintf("/api/workouts/%d/attachments/files/%s", entityID, path.Base(relPath))
+	}
+	return "/uploads/" + relPath
+}
+
+// signFileURLs replaces logged workout attachments with copies whose file and thumbnail URLs carry an
+// expiry and signature
+func (s *AttachmentService) signFileURLs(attachments []*domain.Attachment) {
+	if len(s.fileURLSecret) == 0 {
+		return
+	}
+	expiresAt := time.Now().Add(workoutFileURLLifetime).Unix()
+	sign := func(entityID int64, fileURL string) string {
+		return fmt.Sprintf("%s?expires=%d&signature=%s", fileURL, expiresAt, s.fileSignature(entityID, path.Base(fileURL), expiresAt))
+	}
+	for i, attachment := range attachments {
+		if attachment.FilePath == nil {
+			continue // Links point elsewhere
+		}
+		signed := *attachment
+		signed.URL = sign(attachment.EntityID, attachment.URL)
+		if attachment.ThumbnailURL != nil {
+			thumbURL := sign(attachment.EntityID, *attachment.ThumbnailURL)
+			signed.ThumbnailURL = &thumbURL
+		}
+		attachments[i] = &signed
+	}
+}
+
+// fileSignature is the hex HMAC-SHA256 of a logged workout's file name and link expiry
+func (s *AttachmentService) fileSignature(userWorkoutID int64, name string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, s.fileURLSecret)
+	fmt.Fprintf(mac, "workout-attachment:%d/%s:%d", userWorkoutID, name, expiresAt)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *AttachmentService) fullPath(relPath string) string {
+	return filepath.Join(s.uploadsDir, filepath.FromSlash(relPath))
+}
+
+// detectAttachmentType sniffs the content type of an upload from its first bytes. QuickTime
+// movies, as recorded by phones, are not recognized by http.DetectContentType.
+func detectAttachmentType(head []byte) string {
+	contentType := http.DetectContentType(head)
+	if contentType == "application/octet-stream" && len(head) >= 12 && string(head[4:8]) == "ftyp" && string(head[8:12]) == "qt  " {
+		return "video/quicktime"
+	}
+	return contentType
+}
+
+func randomAttachmentName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// writeThumbnail saves a JPEG of the image at src scaled to fit the thumbnail size
+func writeThumbnail(src, dst string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return err
+	}
+	if config.Width*config.Height > maxThumbnailSourcePixels {
+		return fmt.Errorf("image is too large to thumbnail (%dx%d)", config.Width, config.Height)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if err := jpeg.Encode(out, scaleThumbnail(img, attachmentThumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+// scaleThumbnail samples img down to fit within size x size, flattening transparency onto white
+func scaleThumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	thumbWidth, thumbHeight := width, height
+	if width > size || height > size {
+		if width >= height {
+			thumbWidth, thumbHeight = size, max(1, height*size/width)
+		} else {
+			thumbWidth, thumbHeight = max(1, width*size/height), size
+		}
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		srcY := bounds.Min.Y + (2*y+1)*height/(2*thumbHeight)
+		for x := 0; x < thumbWidth; x++ {
+			srcX := bounds.Min.X + (2*x+1)*width/(2*thumbWidth)
+			c := color.NRGBAModel.Convert(img.At(srcX, srcY)).(color.NRGBA)
+			alpha := uint32(c.A)
+			blend := func(v uint8) uint8 { return uint8((uint32(v)*alpha + 255*(255-alpha)) / 255) }
+			thumb.SetRGBA(x, y, color.RGBA{R: blend(c.R), G: blend(c.G), B: blend(c.B), A: 255})
+		}
+	}
+	return thumb
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// newTestAttachmentService returns a service with a standard WOD 10, a custom WOD 11 created by
+// user 1 and a logged workout 20 owned by user 1
+func newTestAttachmentService(t *testing.T) (*AttachmentService, *mockAttachmentRepo, string) {
+	wodRepo := newMockWODRepo()
+	wodRepo.wods[10] = &domain.WOD{ID: 10, Name: "Fran", IsStandard: true}
+	wodRepo.wods[11] = &domain.WOD{ID: 11, Name: "My WOD", CreatedBy: int64Ptr(1)}
+	userWorkoutRepo := newMockUserWorkoutRepo()
+	userWorkoutRepo.userWorkouts[20] = &domain.UserWorkout{ID: 20, UserID: 1}
+
+	attachmentRepo := newMockAttachmentRepo()
+	uploadsDir := t.TempDir()
+	svc := NewAttachmentService(attachmentRepo, nil, wodRepo, userWorkoutRepo, uploadsDir)
+	svc.SetFileURLSecret("test-secret")
+	return svc, attachmentRepo, uploadsDir
+}
+
+// fileURLParts splits a signed workout attachment file URL into its file name, expiry and signature
+func fileURLParts(t *testing.T, fileURL string) (string, string, string) {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		t.Fatalf("invalid file URL %s: %v", fileURL, err)
+	}
+	return path.Base(parsed.Path), parsed.Query().Get("expires"), parsed.Query().Get("signature")
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestAttachmentServiceUploadImage(t *testing.T) {
+	svc, _, uploadsDir := newTestAttachmentService(t)
+
+	attachment, err := svc.Upload(domain.AttachmentEntityWorkout, 20, 1, false, "", "heavy-single.png", bytes.NewReader(testPNG(t, 800, 400)))
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if attachment.Kind != domain.AttachmentKindImage || attachment.Title != "heavy-single" {
+		t.Errorf("unexpected attachment: %+v", attachment)
+	}
+	// Workout attachments are private and downloaded through the API
+	if !strings.HasPrefix(attachment.URL, "/api/workouts/20/attachments/files/") || !strings.Contains(attachment.URL, ".png?expires=") {
+		t.Errorf("unexpected URL %s", attachment.URL)
+	}
+	if _, err := os.Stat(filepath.Join(uploadsDir, *attachment.FilePath)); err != nil {
+		t.Errorf("expected the file to be stored: %v", err)
+	}
+
+	if attachment.ThumbnailPath == nil {
+		t.Fatal("expected a thumbnail")
+	}
+	thumbFile, err := os.Open(filepath.Join(uploadsDir, *attachment.ThumbnailPath))
+	if err != nil {
+		t.Fatalf("expected the thumbnail to be stored: %v", err)
+	}
+	defer thumbFile.Close()
+	config, format, err := image.DecodeConfig(thumbFile)
+	if err != nil {
+		t.Fatalf("failed to read thumbnail: %v", err)
+	}
+	if format != "jpeg" || config.Width != attachmentThumbnailSize || config.Height != attachmentThumbnailSize/2 {
+		t.Errorf("expected a %dx%d jpeg thumbnail, got %dx%d %s", attachmentThumbnailSize, attachmentThumbnailSize/2, config.Width, config.Height, format)
+	}
+}
+
+func TestAttachmentServiceUploadRejections(t *testing.T) {
+	svc, repo, uploadsDir := newTestAttachmentService(t)
+	img := testPNG(t, 4, 4)
+
+	if _, err := svc.Upload(domain.AttachmentEntityWorkout, 20, 2, false, "", "x.png", bytes.NewReader(img)); !errors.Is(err, ErrAttachmentUnauthorized) {
+		t.Errorf("expected ErrAttachmentUnauthorized for another user's workout, got %v", err)
+	}
+	if _, err := svc.Upload(domain.AttachmentEntityWOD, 10, 1, false, "", "x.png", bytes.NewReader(img)); !errors.Is(err, ErrAttachmentUnauthorized) {
+		t.Errorf("expected ErrAttachmentUnauthorized for a standard WOD, got %v", err)
+	}
+	if _, err := svc.Upload(domain.AttachmentEntityWOD, 10, 2, true, "Demo", "x.png", bytes.NewReader(img)); err != nil {
+		t.Errorf("expected admins to attach to a standard WOD, got %v", err)
+	}
+	if _, err := svc.Upload(domain.AttachmentEntityWOD, 11, 1, false, "", "x.png", bytes.NewReader(img)); err != nil {
+		t.Errorf("expected the creator to attach to a custom WOD, got %v", err)
+	}
+	if _, err := svc.Upload("program", 1, 1, true, "", "x.png", bytes.NewReader(img)); !errors.Is(err, ErrInvalidAttachmentEntity) {
+		t.Errorf("expected ErrInvalidAttachmentEntity, got %v", err)
+	}
+
+	// The extension is ignored; content decides the type
+	if _, err := svc.Upload(domain.AttachmentEntityWorkout, 20, 1, false, "", "notes.png", strings.NewReader("<svg onload=alert(1)>")); !errors.Is(err, ErrUnsupportedAttachment) {
+		t.Errorf("expected ErrUnsupportedAttachment, got %v", err)
+	}
+
+	oversized := append(append([]byte{}, img...), make([]byte, MaxAttachmentImageBytes)...)
+	if _, err := svc.Upload(domain.AttachmentEntityWorkout, 20, 1, false, "", "big.png", bytes.NewReader(oversized)); !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Errorf("expected ErrAttachmentTooLarge, got %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(uploadsDir, "attachments", "workout", "20"))
+	if len(entries) != 0 || len(repo.attachments) != 2 {
+		t.Errorf("expected rejected uploads to leave nothing behind, got %d files and %d attachments", len(entries), len(repo.attachments))
+	}
+}
+
+func TestAttachmentServiceWorkoutFile(t *testing.T) {
+	svc, repo, uploadsDir := newTestAttachmentService(t)
+
+	attachment, err := svc.Upload(domain.AttachmentEntityWorkout, 20, 1, false, "", "x.png", bytes.NewReader(testPNG(t, 4, 4)))
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	name := path.Base(*attachment.FilePath)
+	thumbName := path.Base(*attachment.ThumbnailPath)
+	if attachment.ThumbnailURL == nil || !strings.Contains(*attachment.ThumbnailURL, "/files/"+thumbName+"?") {
+		t.Errorf("unexpected thumbnail URL %v", attachment.ThumbnailURL)
+	}
+	// The stored URLs stay unsigned; every listing signs them afresh
+	if stored := repo.attachments[attachment.ID]; strings.Contains(stored.URL, "?") {
+		t.Errorf("expected the stored URL to be unsigned, got %s", stored.URL)
+	}
+
+	list, err := svc.List(domain.AttachmentEntityWorkout, 20, 1)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("expected 1 attachment, got %d (err %v)", len(list), err)
+	}
+	for _, fileURL := range []string{list[0].URL, *list[0].ThumbnailURL} {
+		n, expires, signature := fileURLParts(t, fileURL)
+		filePath, err := svc.WorkoutFile(20, n, expires, signature)
+		if err != nil {
+			t.Fatalf("WorkoutFile(%s) error = %v", fileURL, err)
+		}
+		if filePath != filepath.Join(uploadsDir, "attachments", "workout", "20", n) {
+			t.Errorf("unexpected file path %s", filePath)
+		}
+	}
+
+	_, expires, signature := fileURLParts(t, list[0].URL)
+	if _, err := svc.WorkoutFile(20, thumbName, expires, signature); !errors.Is(err, ErrAttachmentUnauthorized) {
+		t.Errorf("expected a signature to only cover its own file, got %v", err)
+	}
+	if _, err := svc.WorkoutFile(21, name, expires, signature); !errors.Is(err, ErrAttachmentUnauthorized) {
+		t.Errorf("expected a signature to only cover its own workout, got %v", err)
+	}
+	if _, err := svc.WorkoutFile(20, name, "", ""); !errors.Is(err, ErrAttachmentUnauthorized) {
+		t.Errorf("expected ErrAttachmentUnauthorized without a signature, got %v", err)
+	}
+	past := time.Now().Add(-time.Minute).Unix()
+	if _, err := svc.WorkoutFile(20, name, strconv.FormatInt(past, 10), svc.fileSignature(20, name, past)); !errors.Is(err, ErrAttachmentURLExpired) {
+		t.Errorf("expected ErrAttachmentURLExpired, got %v", err)
+	}
+	future := time.Now().Add(time.Minute).Unix()
+	for _, n := range []string{"", "missing.png", "..", "../20/" + name} {
+		if _, err := svc.WorkoutFile(20, n, strconv.FormatInt(future, 10), svc.fileSignature(20, n, future)); !errors.Is(err, ErrAttachmentNotFound) {
+			t.Errorf("WorkoutFile(%q) expected ErrAttachmentNotFound, got %v", n, err)
+		}
+	}
+}
+
+func TestAttachmentServiceListForCoach(t *testing.T) {
+	svc, _, _ := newTestAttachmentService(t)
+	if _, err := svc.Upload(domain.AttachmentEntityWorkout, 20, 1, false, "", "x.png", bytes.NewReader(testPNG(t, 4, 4))); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	auditRepo := &mockAuditLogRepo{}
+	coachAthleteRepo := newMockCoachAthleteRepo()
+	coachService := NewCoachService(coachAthleteRepo, &mockUserRepo{users: map[int64]*domain.User{}}, newMockUserWorkoutRepo(),
+		&mockUserWorkoutMovementRepo{}, &mockUserWorkoutWODRepo{}, NewAuditLogService(auditRepo))
+	svc.SetCoachService(coachService)
+
+	if _, err := svc.ListForCoach(5, 1, 20, "", ""); !errors.Is(err, ErrCoachAccessDenied) {
+		t.Errorf("expected ErrCoachAccessDenied without a relationship, got %v", err)
+	}
+
+	if err := coachAthleteRepo.Create(&domain.CoachAthlete{CoachID: 5, AthleteID: 1, Status: domain.CoachAthleteStatusActive}); err != nil {
+		t.Fatalf("failed to create relationship: %v", err)
+	}
+	list, err := svc.ListForCoach(5, 1, 20, "", "")
+	if err != nil || len(list) != 1 {
+		t.Fatalf("expected the coach to see 1 attachment, got %d (err %v)", len(list), err)
+	}
+	n, expires, signature := fileURLParts(t, list[0].URL)
+	if _, err := svc.WorkoutFile(20, n, expires, signature); err != nil {
+		t.Errorf("expected the coach's signed URL to serve the file, got %v", err)
+	}
+	if len(auditRepo.logs) != 1 {
+		t.Errorf("expected the coach view to be audited, got %d audit logs", len(auditRepo.logs))
+	}
+
+	if _, err := svc.ListForCoach(5, 1, 99, "", ""); !errors.Is(err, ErrAttachmentEntityNotFound) {
+		t.Errorf("expected ErrAttachmentEntityNotFound for another workout, got %v", err)
+	}
+}
+
+func TestAttachmentServiceAddLink(t *testing.T) {
+	svc, _, _ := newTestAttachmentService(t)
+
+	for _, link := range []string{"javascript:alert(1)", "ftp://example.com/x", "https://", "not a url"} {
+		if _, err := svc.AddLink(domain.AttachmentEntityWOD, 10, 1, true, "", link); !errors.Is(err, ErrInvalidAttachmentLink) {
+			t.Errorf("AddLink(%q) expected ErrInvalidAttachmentLink, got %v", link, err)
+		}
+	}
+
+	attachment, err := svc.AddLink(domain.AttachmentEntityWOD, 10, 1, true, "", " https://www.youtube.com/watch?v=abc ")
+	if err != nil {
+		t.Fatalf("AddLink() error = %v", err)
+	}
+	if attachment.Kind != domain.AttachmentKindLink || attachment.Title != "www.youtube.com" || attachment.URL != "https://www.youtube.com/watch?v=abc" {
+		t.Errorf("unexpected link attachment: %+v", attachment)
+	}
+
+	if _, err := svc.AddLink(domain.AttachmentEntityWOD, 10, 1, true, strings.Repeat("x", maxAttachmentTitle+1), "https://example.com"); !errors.Is(err, ErrInvalidAttachmentTitle) {
+		t.Errorf("expected ErrInvalidAttachmentTitle, got %v", err)
+	}
+}
+
+func TestAttachmentServiceListAndDelete(t *testing.T) {
+	svc, _, uploadsDir := newTestAttachmentService(t)
+
+	upload, err := svc.Upload(domain.AttachmentEntityWOD, 10, 3, true, "Demo", "demo.png", bytes.NewReader(testPNG(t, 4, 4)))
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if _, err := svc.AddLink(domain.AttachmentEntityWOD, 10, 3, true, "Video", "https://example.com/fran"); err != nil {
+		t.Fatalf("AddLink() error = %v", err)
+	}
+
+	// Standard WOD attachments are public
+	list, err := svc.List(domain.AttachmentEntityWOD, 10, 0)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("expected 2 attachments, got %d (err %v)", len(list), err)
+	}
+	if _, err := svc.List(domain.AttachmentEntityWorkout, 20, 2); !errors.Is(err, ErrAttachmentUnauthorized) {
+		t.Errorf("expected another user's workout attachments to be private, got %v", err)
+	}
+
+	if err := svc.Delete(upload.ID, 4, false); !errors.Is(err, ErrAttachmentUnauthorized) {
+		t.Errorf("expected ErrAttachmentUnauthorized, got %v", err)
+	}
+	if err := svc.Delete(upload.ID, 3, false); err != nil {
+		t.Fatalf("expected the uploader to delete their attachment, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(uploadsDir, *upload.FilePath)); !os.IsNotExist(err) {
+		t.Errorf("expected the file to be removed")
+	}
+	if err := svc.Delete(upload.ID, 3, false); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("expected ErrAttachmentNotFound, got %v", err)
+	}
+
+	if err := svc.DeleteForEntity(domain.AttachmentEntityWOD, 10); err != nil {
+		t.Fatalf("DeleteForEntity() error = %v", err)
+	}
+	if list, _ := svc.List(domain.AttachmentEntityWOD, 10, 0); len(list) != 0 {
+		t.Errorf("expected no attachments left, got %d", len(list))
+	}
+}
+
+func TestDetectAttachmentType(t *testing.T) {
+	quickTime := append([]byte{0, 0, 0, 20}, []byte("ftypqt  \x00\x00\x00\x00qt  ")...)
+	if got := detectAttachmentType(quickTime); got != "video/quicktime" {
+		t.Errorf("expected video/quicktime, got %s", got)
+	}
+	if got := detectAttachmentType([]byte("hello")); got == "video/quicktime" {
+		t.Errorf("expected plain text not to be detected as video")
+	}
+}
@@ -26,6 +26,7 @@ type BackupServiceImpl struct {
 	uploadsDir     string
 	userRepo       domain.UserRepository
 	auditLogRepo   domain.AuditLogRepository
+	searchService  *SearchService
 }
 
 // NewBackupService creates a new backup service
@@ -49,6 +50,11 @@ func NewBackupService(
 	}
 }
 
+// SetSearchService rebuilds the search index after a restore replaces the data it was built from
+func (s *BackupServiceImpl) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
 // CreateBackup creates a full database backup and returns the filename
 func (s *BackupServiceImpl) CreateBackup(createdByUserID int64) (string, error) {
 	// Get user info for metadata
@@ -391,7 +397,18 @@ func (s *BackupServiceImpl) RestoreBackup(filename string, restoredByUserID int6
 
 	// Delete all existing data (in reverse order of foreign keys)
 	tables := []string{
+		"name_aliases",
+		"wod_components",
+		"wod_structures",
+		"user_achievements",
+		"achievement_rules",
+		"goals",
+		"body_metrics",
 		"user_workout_wods",
+		"wod_variants",
+		"wod_versions",
+		"seed_library_entries",
+		"attachments",
 		"user_workout_movements",
 		"workout_wods",
 		"workout_movements",
@@ -432,6 +449,18 @@ func (s *BackupServiceImpl) RestoreBackup(filename string, restoredByUserID int6
 	if err := s.restoreTable(tx, "wods", backupData.WODs); err != nil {
 		return fmt.Errorf("failed to restore wods: %w", err)
 	}
+	if err := s.restoreTable(tx, "wod_versions", backupData.WODVersions); err != nil {
+		return fmt.Errorf("failed to restore wod_versions: %w", err)
+	}
+	if err := s.restoreTable(tx, "wod_variants", backupData.WODVariants); err != nil {
+		return fmt.Errorf("failed to restore wod_variants: %w", err)
+	}
+	if err := s.restoreTable(tx, "seed_library_entries", backupData.SeedLibraryEntries); err != nil {
+		return fmt.Errorf("failed to restore seed_library_entries: %w", err)
+	}
+	if err := s.restoreTable(tx, "attachments", backupData.Attachments); err != nil {
+		return fmt.Errorf("failed to restore attachments: %w", err)
+	}
 	if err := s.restoreTable(tx, "workouts", backupData.Workouts); err != nil {
 		return fmt.Errorf("failed to restore workouts: %w", err)
 	}
@@ -465,6 +494,43 @@ func (s *BackupServiceImpl) RestoreBackup(filename string, restoredByUserID int6
 	if err := s.restoreTable(tx, "audit_logs", backupData.AuditLogs); err != nil {
 		return fmt.Errorf("failed to restore audit_logs: %w", err)
 	}
+	if err := s.restoreTable(tx, "body_metrics", backupData.BodyMetrics); err != nil {
+		return fmt.Errorf("failed to restore body_metrics: %w", err)
+	}
+	if err := s.restoreTable(tx, "goals", backupData.Goals); err != nil {
+		return fmt.Errorf("failed to restore goals: %w", err)
+	}
+	if err := s.restoreTable(tx, "achievement_rules", backupData.AchievementRules); err != nil {
+		return fmt.Errorf("failed to restore achievement_rules: %w", err)
+	}
+	if err := s.restoreTable(tx, "user_achievements", backupData.UserAchievements); err != nil {
+		return fmt.Errorf("failed to restore user_achievements: %w", err)
+	}
+	if err := s.restoreTable(tx, "wod_structures", backupData.WODStructures); err != nil {
+		return fmt.Errorf("failed to restore wod_structures: %w", err)
+	}
+	if err := s.restoreTable(tx, "wod_components", backupData.WODComponents); err != nil {
+		return fmt.Errorf("failed to restore wod_components: %w", err)
+	}
+	if err := s.restoreTable(tx, "name_aliases", backupData.NameAliases); err != nil {
+		return fmt.Errorf("failed to restore name_aliases: %w", err)
+	}
+
+	// Backups from before enumerations were managed carry none; keep the current values for those
+	if len(backupData.Enumerations) > 0 {
+		exists, err := s.tableExists(tx, "enumerations")
+		if err != nil {
+			return fmt.Errorf("failed to check if table enumerations exists: %w", err)
+		}
+		if exists {
+			if _, err := tx.Exec("DELETE FROM enumerations"); err != nil {
+				return fmt.Errorf("failed to clear table enumerations: %w", err)
+			}
+		}
+		if err := s.restoreTable(tx, "enumerations", backupData.Enumerations); err != nil {
+			return fmt.Errorf("failed to restore enumerations: %w", err)
+		}
+	}
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
@@ -477,6 +543,10 @@ func (s *BackupServiceImpl) RestoreBackup(filename string, restoredByUserID int6
 		fmt.Printf("Warning: failed to restore uploads: %v\n", err)
 	}
 
+	if s.searchService != nil {
+		s.searchService.RefreshAll()
+	}
+
 	// Create audit log (after restore, so it's in the new database)
 	details := fmt.Sprintf("Restored backup: %s (users: %d, workouts: %d, movements: %d, WODs: %d)",
 		filename,
@@ -521,6 +591,18 @@ func (s *BackupServiceImpl) exportAllTables() (*domain.BackupData, error) {
 		{"audit_logs", &data.AuditLogs},
 		{"user_settings", &data.UserSettings},
 		{"data_change_logs", &data.DataChangeLogs},
+		{"body_metrics", &data.BodyMetrics},
+		{"goals", &data.Goals},
+		{"achievement_rules", &data.AchievementRules},
+		{"user_achievements", &data.UserAchievements},
+		{"wod_structures", &data.WODStructures},
+		{"wod_components", &data.WODComponents},
+		{"name_aliases", &data.NameAliases},
+		{"enumerations", &data.Enumerations},
+		{"wod_versions", &data.WODVersions},
+		{"wod_variants", &data.WODVariants},
+		{"seed_library_entries", &data.SeedLibraryEntries},
+		{"attachments", &data.Attachments},
 	}
 
 	for _, table := range tables {
@@ -718,7 +800,7 @@ func (s *BackupServiceImpl) addUploadsToZip(zipWriter *zip.Writer) error {
 		}
 
 		// Create file in ZIP
-		zipPath := filepath.Join("uploads", relPath)
+		zipPath := "uploads/" + filepath.ToSlash(relPath)
 		writer, err := zipWriter.Create(zipPath)
 		if err != nil {
 			return err
@@ -751,8 +833,17 @@ func (s *BackupServiceImpl) restoreUploadsFromZip(zipReader *zip.ReadCloser) err
 			continue
 		}
 
-		// Get destination path
-		destPath := filepath.Join(s.uploadsDir, filepath.Base(f.Name))
+		// Keep the file's place under uploads (avatars/, attachments/...), refusing paths that escape it
+		if strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		destPath := filepath.Join(s.uploadsDir, filepath.FromSlash(strings.TrimPrefix(f.Name, "uploads/")))
+		if rel, err := filepath.Rel(s.uploadsDir, destPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid upload path in backup: %s", f.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", destPath, err)
+		}
 
 		// Create destination file
 		destFile, err := os.Create(destPath)
@@ -831,6 +922,18 @@ func (s *BackupServiceImpl) createSQLiteDump(backupData *domain.BackupData, outp
 	if err := s.restoreTableToSQLite(tx, "wods", backupData.WODs); err != nil {
 		return fmt.Errorf("failed to restore wods: %w", err)
 	}
+	if err := s.restoreTableToSQLite(tx, "wod_versions", backupData.WODVersions); err != nil {
+		return fmt.Errorf("failed to restore wod_versions: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "wod_variants", backupData.WODVariants); err != nil {
+		return fmt.Errorf("failed to restore wod_variants: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "seed_library_entries", backupData.SeedLibraryEntries); err != nil {
+		return fmt.Errorf("failed to restore seed_library_entries: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "attachments", backupData.Attachments); err != nil {
+		return fmt.Errorf("failed to restore attachments: %w", err)
+	}
 	if err := s.restoreTableToSQLite(tx, "workouts", backupData.Workouts); err != nil {
 		return fmt.Errorf("failed to restore workouts: %w", err)
 	}
@@ -864,6 +967,30 @@ func (s *BackupServiceImpl) createSQLiteDump(backupData *domain.BackupData, outp
 	if err := s.restoreTableToSQLite(tx, "audit_logs", backupData.AuditLogs); err != nil {
 		return fmt.Errorf("failed to restore audit_logs: %w", err)
 	}
+	if err := s.restoreTableToSQLite(tx, "body_metrics", backupData.BodyMetrics); err != nil {
+		return fmt.Errorf("failed to restore body_metrics: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "goals", backupData.Goals); err != nil {
+		return fmt.Errorf("failed to restore goals: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "achievement_rules", backupData.AchievementRules); err != nil {
+		return fmt.Errorf("failed to restore achievement_rules: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "user_achievements", backupData.UserAchievements); err != nil {
+		return fmt.Errorf("failed to restore user_achievements: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "wod_structures", backupData.WODStructures); err != nil {
+		return fmt.Errorf("failed to restore wod_structures: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "wod_components", backupData.WODComponents); err != nil {
+		return fmt.Errorf("failed to restore wod_components: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "name_aliases", backupData.NameAliases); err != nil {
+		return fmt.Errorf("failed to restore name_aliases: %w", err)
+	}
+	if err := s.restoreTableToSQLite(tx, "enumerations", backupData.Enumerations); err != nil {
+		return fmt.Errorf("failed to restore enumerations: %w", err)
+	}
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
@@ -935,9 +1062,15 @@ func (s *BackupServiceImpl) createSchemaFromData(backupData *domain.BackupData)
 		type TEXT NOT NULL,
 		description TEXT,
 		is_standard INTEGER NOT NULL DEFAULT 1,
-		created_by_user_id INTEGER,
+		created_by INTEGER,
 		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-		FOREIGN KEY (created_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		equipment TEXT,
+		pattern TEXT,
+		muscle_groups TEXT,
+		parent_id INTEGER,
+		FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+		FOREIGN KEY (parent_id) REFERENCES movements(id) ON DELETE SET NULL
 	);
 
 	CREATE TABLE wods (
@@ -1037,6 +1170,12 @@ func (s *BackupServiceImpl) createSchemaFromData(backupData *domain.BackupData)
 		rounds INTEGER,
 		reps INTEGER,
 		weight REAL,
+		calories INTEGER,
+		distance REAL,
+		points REAL,
+		tie_break_seconds INTEGER,
+		time_capped INTEGER NOT NULL DEFAULT 0,
+		division TEXT,
 		notes TEXT,
 		is_pr INTEGER NOT NULL DEFAULT 0,
 		order_index INTEGER NOT NULL DEFAULT 0,
@@ -1096,6 +1235,120 @@ func (s *BackupServiceImpl) createSchemaFromData(backupData *domain.BackupData)
 		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 	);
+
+	CREATE TABLE body_metrics (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		measured_on TEXT NOT NULL,
+		body_weight REAL,
+		body_fat_percent REAL,
+		measurements TEXT,
+		notes TEXT,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (user_id, measured_on),
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE goals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		title TEXT NOT NULL,
+		goal_type TEXT NOT NULL,
+		movement_id INTEGER,
+		wod_id INTEGER,
+		metric TEXT NOT NULL,
+		target_value REAL NOT NULL,
+		reps_per_round INTEGER,
+		start_date TEXT NOT NULL,
+		target_date TEXT,
+		baseline_value REAL,
+		status TEXT NOT NULL DEFAULT 'active',
+		achieved_at TIMESTAMP,
+		achieved_workout_id INTEGER,
+		notes TEXT,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+		FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE CASCADE,
+		FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+		FOREIGN KEY (achieved_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+	);
+
+	CREATE TABLE achievement_rules (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		code TEXT NOT NULL UNIQUE,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		icon TEXT,
+		rule_type TEXT NOT NULL,
+		params TEXT NOT NULL DEFAULT '{}',
+		is_active INTEGER NOT NULL DEFAULT 1,
+		created_by INTEGER,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+	);
+
+	CREATE TABLE user_achievements (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		rule_id INTEGER NOT NULL,
+		user_workout_id INTEGER,
+		workout_date TEXT,
+		awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (user_id, rule_id),
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+		FOREIGN KEY (rule_id) REFERENCES achievement_rules(id) ON DELETE CASCADE,
+		FOREIGN KEY (user_workout_id) REFERENCES user_workouts(id) ON DELETE SET NULL
+	);
+
+	CREATE TABLE wod_structures (
+		wod_id INTEGER PRIMARY KEY,
+		rounds INTEGER,
+		rep_scheme TEXT,
+		time_cap_seconds INTEGER,
+		notes TEXT NOT NULL DEFAULT '',
+		confidence REAL NOT NULL DEFAULT 0,
+		needs_review INTEGER NOT NULL DEFAULT 0,
+		warnings TEXT NOT NULL DEFAULT '[]',
+		source TEXT NOT NULL DEFAULT 'parsed',
+		reviewed_by INTEGER,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+		FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL
+	);
+
+	CREATE TABLE wod_components (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		wod_id INTEGER NOT NULL,
+		order_index INTEGER NOT NULL DEFAULT 0,
+		movement_id INTEGER,
+		movement_name TEXT NOT NULL,
+		reps INTEGER,
+		calories INTEGER,
+		distance REAL,
+		duration_seconds INTEGER,
+		weight_male REAL,
+		weight_female REAL,
+		weight_unit TEXT NOT NULL DEFAULT '',
+		notes TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (wod_id) REFERENCES wods(id) ON DELETE CASCADE,
+		FOREIGN KEY (movement_id) REFERENCES movements(id) ON DELETE SET NULL
+	);
+
+	CREATE TABLE name_aliases (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		entity_type TEXT NOT NULL,
+		entity_id INTEGER NOT NULL,
+		alias TEXT NOT NULL,
+		normalized_alias TEXT NOT NULL,
+		created_by INTEGER,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (entity_type, normalized_alias),
+		FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+	);
 	`
 
 	return schema, nil
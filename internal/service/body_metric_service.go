@@ -0,0 +1,389 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrBodyMetricNotFound     = errors.New("body metric not found")
+	ErrBodyMetricUnauthorized = errors.New("unauthorized to access this body metric")
+	ErrBodyMetricEmpty        = errors.New("body metric must include body weight, body fat or a measurement")
+	ErrBodyMetricInvalidValue = errors.New("body weight and measurements must be positive and body fat between 0 and 100")
+	ErrBodyMetricDateExists   = errors.New("a body metric entry already exists for this date")
+	ErrInvalidBodyMetricCSV   = errors.New("invalid body metrics CSV")
+)
+
+// bodyMetricCSVHeader is the fixed prefix of the body metrics CSV; any further columns are custom measurements
+var bodyMetricCSVHeader = []string{"measured_on", "body_weight", "body_fat_percent", "notes"}
+
+// BodyMetricService handles the dated body metrics log and body weight lookups for relative strength
+type BodyMetricService struct {
+	bodyMetricRepo domain.BodyMetricRepository
+}
+
+// NewBodyMetricService creates a new body metric service
+func NewBodyMetricService(bodyMetricRepo domain.BodyMetricRepository) *BodyMetricService {
+	return &BodyMetricService{bodyMetricRepo: bodyMetricRepo}
+}
+
+// BodyMetricImportResult summarizes a body metrics CSV import
+type BodyMetricImportResult struct {
+	TotalRows    int      `json:"total_rows"`
+	CreatedCount int      `json:"created_count"`
+	UpdatedCount int      `json:"updated_count"`
+	SkippedCount int      `json:"skipped_count"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+// CreateMetric logs a body metric entry for the user (one per date)
+func (s *BodyMetricService) CreateMetric(userID int64, m *domain.BodyMetric) error {
+	if err := validateBodyMetric(m); err != nil {
+		return err
+	}
+
+	existing, err := s.bodyMetricRepo.GetByUserAndDate(userID, m.MeasuredOn)
+	if err != nil {
+		return fmt.Errorf("failed to check existing body metric: %w", err)
+	}
+	if existing != nil {
+		return ErrBodyMetricDateExists
+	}
+
+	m.UserID = userID
+	return s.bodyMetricRepo.Create(m)
+}
+
+// GetMetric retrieves one of the user's body metric entries
+func (s *BodyMetricService) GetMetric(id, userID int64) (*domain.BodyMetric, error) {
+	m, err := s.bodyMetricRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get body metric: %w", err)
+	}
+	if m == nil {
+		return nil, ErrBodyMetricNotFound
+	}
+	if m.UserID != userID {
+		return nil, ErrBodyMetricUnauthorized
+	}
+	return m, nil
+}
+
+// ListMetrics lists the user's body metric entries between two optional dates (YYYY-MM-DD), oldest first
+func (s *BodyMetricService) ListMetrics(userID int64, startDate, endDate string) ([]*domain.BodyMetric, error) {
+	for _, d := range []string{startDate, endDate} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse(domain.ScheduledDateFormat, d); err != nil {
+			return nil, ErrInvalidScheduleDate
+		}
+	}
+	if startDate != "" && endDate != "" && startDate > endDate {
+		return nil, ErrInvalidScheduleRange
+	}
+
+	return s.bodyMetricRepo.ListByUser(userID, startDate, endDate)
+}
+
+// UpdateMetric replaces one of the user's body metric entries
+func (s *BodyMetricService) UpdateMetric(id, userID int64, updates *domain.BodyMetric) (*domain.BodyMetric, error) {
+	m, err := s.GetMetric(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBodyMetric(updates); err != nil {
+		return nil, err
+	}
+
+	if updates.MeasuredOn != m.MeasuredOn {
+		existing, err := s.bodyMetricRepo.GetByUserAndDate(userID, updates.MeasuredOn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing body metric: %w", err)
+		}
+		if existing != nil {
+			return nil, ErrBodyMetricDateExists
+		}
+	}
+
+	m.MeasuredOn = updates.MeasuredOn
+	m.BodyWeight = updates.BodyWeight
+	m.BodyFatPercent = updates.BodyFatPercent
+	m.Measurements = updates.Measurements
+	m.Notes = updates.Notes
+
+	if err := s.bodyMetricRepo.Update(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DeleteMetric deletes one of the user's body metric entries
+func (s *BodyMetricService) DeleteMetric(id, userID int64) error {
+	if _, err := s.GetMetric(id, userID); err != nil {
+		return err
+	}
+	return s.bodyMetricRepo.Delete(id)
+}
+
+// BodyWeightTimeline is a user's dated body weights, oldest first, for nearest-date lookups
+type BodyWeightTimeline struct {
+	days    []time.Time
+	metrics []*domain.BodyMetric
+}
+
+// GetBodyWeightTimeline loads every entry of the user's log that records a body weight
+func (s *BodyMetricService) GetBodyWeightTimeline(userID int64) (*BodyWeightTimeline, error) {
+	metrics, err := s.bodyMetricRepo.ListByUser(userID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return newBodyWeightTimeline(metrics), nil
+}
+
+func newBodyWeightTimeline(metrics []*domain.BodyMetric) *BodyWeightTimeline {
+	t := &BodyWeightTimeline{}
+	for _, m := range metrics {
+		if m.BodyWeight == nil || *m.BodyWeight <= 0 {
+			continue
+		}
+		day, err := time.Parse(domain.ScheduledDateFormat, m.MeasuredOn)
+		if err != nil {
+			continue
+		}
+		t.days = append(t.days, day)
+		t.metrics = append(t.metrics, m)
+	}
+	return t
+}
+
+// Nearest returns the entry measured closest to date (the earlier one on a tie), or nil when none recorded a body weight
+func (t *BodyWeightTimeline) Nearest(date time.Time) *domain.BodyMetric {
+	if t == nil || len(t.days) == 0 {
+		return nil
+	}
+
+	day := truncateDay(date)
+	i := sort.Search(len(t.days), func(i int) bool { return !t.days[i].Before(day) })
+	switch {
+	case i == 0:
+		return t.metrics[0]
+	case i == len(t.days):
+		return t.metrics[i-1]
+	case t.days[i].Sub(day) < day.Sub(t.days[i-1]):
+		return t.metrics[i]
+	default:
+		return t.metrics[i-1]
+	}
+}
+
+// RelativeStrength is a load divided by body weight, rounded to two decimals
+func RelativeStrength(load, bodyWeight float64) float64 {
+	return math.Round(load/bodyWeight*100) / 100
+}
+
+// ExportMetricsToCSV exports the user's body metrics log; custom measurements become extra columns
+func (s *BodyMetricService) ExportMetricsToCSV(userID int64) ([]byte, error) {
+	metrics, err := s.bodyMetricRepo.ListByUser(userID, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch body metrics: %w", err)
+	}
+
+	names := map[string]bool{}
+	for _, m := range metrics {
+		for name := range m.Measurements {
+			names[name] = true
+		}
+	}
+	measurementNames := make([]string, 0, len(names))
+	for name := range names {
+		measurementNames = append(measurementNames, name)
+	}
+	sort.Strings(measurementNames)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := append(append([]string{}, bodyMetricCSVHeader...), measurementNames...)
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	formatFloat := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+
+	for _, m := range metrics {
+		notes := ""
+		if m.Notes != nil {
+			notes = *m.Notes
+		}
+		row := []string{m.MeasuredOn, formatFloat(m.BodyWeight), formatFloat(m.BodyFatPercent), notes}
+		for _, name := range measurementNames {
+			if v, ok := m.Measurements[name]; ok {
+				row = append(row, formatFloat(&v))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to flush CSV writer: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ImportMetricsCSV imports body metrics in the export format
+// Rows for a date already logged are skipped unless updateExisting is set; invalid rows are reported and skipped
+func (s *BodyMetricService) ImportMetricsCSV(userID int64, r io.Reader, updateExisting bool) (*BodyMetricImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidBodyMetricCSV, err)
+	}
+	if len(header) < len(bodyMetricCSVHeader) || !equalStringSlices(header[:len(bodyMetricCSVHeader)], bodyMetricCSVHeader) {
+		return nil, fmt.Errorf("%w: header must start with %s", ErrInvalidBodyMetricCSV, strings.Join(bodyMetricCSVHeader, ","))
+	}
+	measurementNames := header[len(bodyMetricCSVHeader):]
+
+	result := &BodyMetricImportResult{}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidBodyMetricCSV, line, err)
+		}
+		result.TotalRows++
+
+		m, err := parseBodyMetricRow(record, measurementNames)
+		if err == nil {
+			err = validateBodyMetric(m)
+		}
+		if err != nil {
+			result.SkippedCount++
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		existing, err := s.bodyMetricRepo.GetByUserAndDate(userID, m.MeasuredOn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing body metric: %w", err)
+		}
+		if existing == nil {
+			m.UserID = userID
+			if err := s.bodyMetricRepo.Create(m); err != nil {
+				return nil, err
+			}
+			result.CreatedCount++
+			continue
+		}
+		if !updateExisting {
+			result.SkippedCount++
+			continue
+		}
+
+		m.ID = existing.ID
+		m.UserID = userID
+		if err := s.bodyMetricRepo.Update(m); err != nil {
+			return nil, err
+		}
+		result.UpdatedCount++
+	}
+
+	return result, nil
+}
+
+func parseBodyMetricRow(record, measurementNames []string) (*domain.BodyMetric, error) {
+	field := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	parseFloat := func(name, v string) (*float64, error) {
+		if v == "" {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, v)
+		}
+		return &f, nil
+	}
+
+	m := &domain.BodyMetric{MeasuredOn: field(0)}
+
+	var err error
+	if m.BodyWeight, err = parseFloat("body_weight", field(1)); err != nil {
+		return nil, err
+	}
+	if m.BodyFatPercent, err = parseFloat("body_fat_percent", field(2)); err != nil {
+		return nil, err
+	}
+	if notes := field(3); notes != "" {
+		m.Notes = &notes
+	}
+
+	for i, name := range measurementNames {
+		v, err := parseFloat(name, field(len(bodyMetricCSVHeader)+i))
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		if m.Measurements == nil {
+			m.Measurements = map[string]float64{}
+		}
+		m.Measurements[strings.TrimSpace(name)] = *v
+	}
+
+	return m, nil
+}
+
+// validateBodyMetric checks the date and that at least one sane value is recorded
+func validateBodyMetric(m *domain.BodyMetric) error {
+	if _, err := time.Parse(domain.ScheduledDateFormat, m.MeasuredOn); err != nil {
+		return ErrInvalidScheduleDate
+	}
+	if m.BodyWeight == nil && m.BodyFatPercent == nil && len(m.Measurements) == 0 {
+		return ErrBodyMetricEmpty
+	}
+	if m.BodyWeight != nil && *m.BodyWeight <= 0 {
+		return ErrBodyMetricInvalidValue
+	}
+	if m.BodyFatPercent != nil && (*m.BodyFatPercent <= 0 || *m.BodyFatPercent >= 100) {
+		return ErrBodyMetricInvalidValue
+	}
+	for name, v := range m.Measurements {
+		if strings.TrimSpace(name) == "" || v <= 0 {
+			return ErrBodyMetricInvalidValue
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+func TestBodyWeightTimelineNearest(t *testing.T) {
+	weight := func(v float64) *float64 { return &v }
+	timeline := newBodyWeightTimeline([]*domain.BodyMetric{
+		{MeasuredOn: "2025-01-01", BodyWeight: weight(200)},
+		{MeasuredOn: "2025-01-05", BodyFatPercent: weight(18)}, // no body weight: ignored
+		{MeasuredOn: "2025-01-11", BodyWeight: weight(195)},
+	})
+
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2024-12-01", "2025-01-01"}, // before the first entry
+		{"2025-01-05", "2025-01-01"},
+		{"2025-01-06", "2025-01-01"}, // tie: earlier measurement wins
+		{"2025-01-07", "2025-01-11"},
+		{"2025-03-01", "2025-01-11"}, // after the last entry
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse(domain.ScheduledDateFormat, tt.date)
+		got := timeline.Nearest(date.Add(18 * time.Hour))
+		if got == nil || got.MeasuredOn != tt.want {
+			t.Errorf("Nearest(%s) = %v, want %s", tt.date, got, tt.want)
+		}
+	}
+
+	if got := newBodyWeightTimeline(nil).Nearest(time.Now()); got != nil {
+		t.Errorf("expected nil from an empty timeline, got %v", got)
+	}
+	if got := RelativeStrength(405, 190); got != 2.13 {
+		t.Errorf("RelativeStrength(405, 190) = %v, want 2.13", got)
+	}
+}
+
+func TestParseBodyMetricRow(t *testing.T) {
+	m, err := parseBodyMetricRow([]string{"2025-02-03", "181.5", "", "after travel", "34", ""}, []string{"waist", "chest"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.BodyWeight == nil || *m.BodyWeight != 181.5 || m.BodyFatPercent != nil {
+		t.Errorf("unexpected weights: %+v", m)
+	}
+	if len(m.Measurements) != 1 || m.Measurements["waist"] != 34 {
+		t.Errorf("expected only the waist measurement, got %v", m.Measurements)
+	}
+	if err := validateBodyMetric(m); err != nil {
+		t.Errorf("expected valid row, got %v", err)
+	}
+
+	if _, err := parseBodyMetricRow([]string{"2025-02-03", "heavy"}, nil); err == nil {
+		t.Error("expected an error for a non-numeric body weight")
+	}
+	if err := validateBodyMetric(&domain.BodyMetric{MeasuredOn: "2025-02-03"}); !errors.Is(err, ErrBodyMetricEmpty) {
+		t.Errorf("expected ErrBodyMetricEmpty, got %v", err)
+	}
+}
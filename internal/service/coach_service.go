@@ -0,0 +1,341 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrCoachInviteSelf           = errors.New("cannot invite yourself as an athlete")
+	ErrCoachAthleteNotFound      = errors.New("athlete not found")
+	ErrCoachRelationshipExists   = errors.New("coach-athlete relationship already exists")
+	ErrCoachRelationshipNotFound = errors.New("coach-athlete relationship not found")
+	ErrCoachInvalidTransition    = errors.New("invalid coach-athlete status change")
+	ErrCoachAccessDenied         = errors.New("no active coaching relationship with this athlete")
+	ErrCoachAuditUnavailable     = errors.New("coach access cannot be audited")
+)
+
+// CoachService handles coach-athlete relationships and coach read access to athlete data
+// Coaches can only read; every read is recorded in the audit log against the athlete
+type CoachService struct {
+	coachAthleteRepo        domain.CoachAthleteRepository
+	userRepo                domain.UserRepository
+	userWorkoutRepo         domain.UserWorkoutRepository
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository
+	userWorkoutWODRepo      domain.UserWorkoutWODRepository
+	auditLogService         *AuditLogService
+}
+
+// NewCoachService creates a new coach service
+func NewCoachService(
+	coachAthleteRepo domain.CoachAthleteRepository,
+	userRepo domain.UserRepository,
+	userWorkoutRepo domain.UserWorkoutRepository,
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository,
+	userWorkoutWODRepo domain.UserWorkoutWODRepository,
+	auditLogService *AuditLogService,
+) *CoachService {
+	return &CoachService{
+		coachAthleteRepo:        coachAthleteRepo,
+		userRepo:                userRepo,
+		userWorkoutRepo:         userWorkoutRepo,
+		userWorkoutMovementRepo: userWorkoutMovementRepo,
+		userWorkoutWODRepo:      userWorkoutWODRepo,
+		auditLogService:         auditLogService,
+	}
+}
+
+// CoachPRSummary is an athlete's PR-flagged movements and WODs as seen by a coach
+type CoachPRSummary struct {
+	PRMovements []*domain.UserWorkoutMovement `json:"pr_movements"`
+	PRWODs      []*domain.UserWorkoutWOD      `json:"pr_wods"`
+}
+
+// InviteAthlete creates a pending invite from a coach to the athlete with the given email
+// A previously declined or revoked relationship is re-opened as pending
+func (s *CoachService) InviteAthlete(coachID int64, athleteEmail, ipAddress, userAgent string) (*domain.CoachAthlete, error) {
+	athlete, err := s.userRepo.GetByEmail(strings.TrimSpace(athleteEmail))
+	if err != nil || athlete == nil {
+		return nil, ErrCoachAthleteNotFound
+	}
+	if athlete.ID == coachID {
+		return nil, ErrCoachInviteSelf
+	}
+
+	existing, err := s.coachAthleteRepo.GetByCoachAndAthlete(coachID, athlete.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing relationship: %w", err)
+	}
+
+	var rel *domain.CoachAthlete
+	if existing != nil {
+		if existing.Status == domain.CoachAthleteStatusPending || existing.Status == domain.CoachAthleteStatusActive {
+			return nil, ErrCoachRelationshipExists
+		}
+		existing.Status = domain.CoachAthleteStatusPending
+		existing.RespondedAt = nil
+		existing.RevokedAt = nil
+		if err := s.coachAthleteRepo.Update(existing); err != nil {
+			return nil, fmt.Errorf("failed to re-open invite: %w", err)
+		}
+		rel = existing
+	} else {
+		rel = &domain.CoachAthlete{
+			CoachID:   coachID,
+			AthleteID: athlete.ID,
+			Status:    domain.CoachAthleteStatusPending,
+		}
+		if err := s.coachAthleteRepo.Create(rel); err != nil {
+			return nil, fmt.Errorf("failed to create invite: %w", err)
+		}
+	}
+
+	s.logEvent(domain.EventCoachInviteSent, coachID, athlete.ID, ipAddress, userAgent, map[string]interface{}{
+		"relationship_id": rel.ID,
+		"athlete_email":   athlete.Email,
+	})
+
+	return rel, nil
+}
+
+// AcceptInvite lets an athlete accept a pending invite
+func (s *CoachService) AcceptInvite(athleteID, relationshipID int64, ipAddress, userAgent string) (*domain.CoachAthlete, error) {
+	return s.respond(athleteID, relationshipID, domain.CoachAthleteStatusActive, domain.EventCoachInviteAccepted, ipAddress, userAgent)
+}
+
+// DeclineInvite lets an athlete decline a pending invite
+func (s *CoachService) DeclineInvite(athleteID, relationshipID int64, ipAddress, userAgent string) (*domain.CoachAthlete, error) {
+	return s.respond(athleteID, relationshipID, domain.CoachAthleteStatusDeclined, domain.EventCoachInviteDeclined, ipAddress, userAgent)
+}
+
+// RevokeCoach lets an athlete revoke a coach's access (pending or active)
+func (s *CoachService) RevokeCoach(athleteID, relationshipID int64, ipAddress, userAgent string) error {
+	rel, err := s.getForAthlete(athleteID, relationshipID)
+	if err != nil {
+		return err
+	}
+	if rel.Status != domain.CoachAthleteStatusActive && rel.Status != domain.CoachAthleteStatusPending {
+		return ErrCoachInvalidTransition
+	}
+
+	now := time.Now()
+	rel.Status = domain.CoachAthleteStatusRevoked
+	rel.RevokedAt = &now
+	if err := s.coachAthleteRepo.Update(rel); err != nil {
+		return fmt.Errorf("failed to revoke coach access: %w", err)
+	}
+
+	s.logEvent(domain.EventCoachAccessRevoked, athleteID, rel.CoachID, ipAddress, userAgent, map[string]interface{}{
+		"relationship_id": rel.ID,
+		"coach_email":     rel.CoachEmail,
+	})
+	return nil
+}
+
+// ListAthletes retrieves a coach's relationships (empty status = all)
+func (s *CoachService) ListAthletes(coachID int64, status string) ([]*domain.CoachAthlete, error) {
+	rels, err := s.coachAthleteRepo.ListByCoach(coachID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list athletes: %w", err)
+	}
+	return rels, nil
+}
+
+// ListCoaches retrieves an athlete's relationships, including pending invites
+func (s *CoachService) ListCoaches(athleteID int64) ([]*domain.CoachAthlete, error) {
+	rels, err := s.coachAthleteRepo.ListByAthlete(athleteID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coaches: %w", err)
+	}
+	return rels, nil
+}
+
+// ListAthleteWorkouts retrieves an athlete's logged workouts for their coach
+func (s *CoachService) ListAthleteWorkouts(coachID, athleteID int64, limit, offset int, ipAddress, userAgent string) ([]*domain.UserWorkoutWithDetails, error) {
+	if err := s.authorize(coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	workouts, err := s.userWorkoutRepo.ListByUserWithDetails(athleteID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list athlete workouts: %w", err)
+	}
+
+	if err := s.logView(coachID, athleteID, "workouts", ipAddress, userAgent, map[string]interface{}{
+		"limit":  limit,
+		"offset": offset,
+	}); err != nil {
+		return nil, err
+	}
+	return workouts, nil
+}
+
+// GetAthleteWorkout retrieves a single logged workout of an athlete for their coach
+func (s *CoachService) GetAthleteWorkout(coachID, athleteID, userWorkoutID int64, ipAddress, userAgent string) (*domain.UserWorkoutWithDetails, error) {
+	if err := s.authorize(coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	basic, err := s.userWorkoutRepo.GetByID(userWorkoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logged workout: %w", err)
+	}
+	if basic == nil || basic.UserID != athleteID {
+		return nil, ErrUserWorkoutNotFound
+	}
+
+	// Ownership scoping stays in the repository; the coach reads through the athlete's ID
+	workout, err := s.userWorkoutRepo.GetByIDWithDetails(userWorkoutID, athleteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logged workout details: %w", err)
+	}
+
+	if err := s.logView(coachID, athleteID, "workout", ipAddress, userAgent, map[string]interface{}{
+		"user_workout_id": userWorkoutID,
+	}); err != nil {
+		return nil, err
+	}
+	return workout, nil
+}
+
+// GetAthletePRs retrieves an athlete's recent PR-flagged movements and WODs for their coach
+func (s *CoachService) GetAthletePRs(coachID, athleteID int64, limit int, ipAddress, userAgent string) (*CoachPRSummary, error) {
+	if err := s.authorize(coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	movements, err := s.userWorkoutMovementRepo.GetPRMovements(athleteID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR movements: %w", err)
+	}
+	wods, err := s.userWorkoutWODRepo.GetPRWODs(athleteID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR WODs: %w", err)
+	}
+
+	if err := s.logView(coachID, athleteID, "personal_records", ipAddress, userAgent, nil); err != nil {
+		return nil, err
+	}
+	return &CoachPRSummary{PRMovements: movements, PRWODs: wods}, nil
+}
+
+// GetAthleteMovementHistory retrieves an athlete's performance history for a movement for their coach
+func (s *CoachService) GetAthleteMovementHistory(coachID, athleteID, movementID int64, limit int, ipAddress, userAgent string) ([]*domain.UserWorkoutMovement, error) {
+	if err := s.authorize(coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.userWorkoutMovementRepo.GetByUserIDAndMovementID(athleteID, movementID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movement history: %w", err)
+	}
+
+	if err := s.logView(coachID, athleteID, "movement_performance", ipAddress, userAgent, map[string]interface{}{
+		"movement_id": movementID,
+	}); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// GetAthleteWODHistory retrieves an athlete's performance history for a WOD for their coach
+func (s *CoachService) GetAthleteWODHistory(coachID, athleteID, wodID int64, limit int, ipAddress, userAgent string) ([]*domain.UserWorkoutWOD, error) {
+	if err := s.authorize(coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.userWorkoutWODRepo.GetByUserIDAndWODID(athleteID, wodID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WOD history: %w", err)
+	}
+
+	if err := s.logView(coachID, athleteID, "wod_performance", ipAddress, userAgent, map[string]interface{}{
+		"wod_id": wodID,
+	}); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// AuthorizeView checks that the coach has an active relationship with the athlete and records the read
+// Other features exposing athlete data to coaches (e.g., analytics) call this before reading
+func (s *CoachService) AuthorizeView(coachID, athleteID int64, resource, ipAddress, userAgent string, details map[string]interface{}) error {
+	if err := s.authorize(coachID, athleteID); err != nil {
+		return err
+	}
+	return s.logView(coachID, athleteID, resource, ipAddress, userAgent, details)
+}
+
+// authorize checks that the coach has an active relationship with the athlete
+func (s *CoachService) authorize(coachID, athleteID int64) error {
+	rel, err := s.coachAthleteRepo.GetByCoachAndAthlete(coachID, athleteID)
+	if err != nil {
+		return fmt.Errorf("failed to check coaching relationship: %w", err)
+	}
+	if rel == nil || rel.Status != domain.CoachAthleteStatusActive {
+		return ErrCoachAccessDenied
+	}
+	return nil
+}
+
+func (s *CoachService) respond(athleteID, relationshipID int64, status, eventType, ipAddress, userAgent string) (*domain.CoachAthlete, error) {
+	rel, err := s.getForAthlete(athleteID, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+	if rel.Status != domain.CoachAthleteStatusPending {
+		return nil, ErrCoachInvalidTransition
+	}
+
+	now := time.Now()
+	rel.Status = status
+	rel.RespondedAt = &now
+	if err := s.coachAthleteRepo.Update(rel); err != nil {
+		return nil, fmt.Errorf("failed to update invite: %w", err)
+	}
+
+	s.logEvent(eventType, athleteID, rel.CoachID, ipAddress, userAgent, map[string]interface{}{
+		"relationship_id": rel.ID,
+		"coach_email":     rel.CoachEmail,
+	})
+	return rel, nil
+}
+
+func (s *CoachService) getForAthlete(athleteID, relationshipID int64) (*domain.CoachAthlete, error) {
+	rel, err := s.coachAthleteRepo.GetByID(relationshipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship: %w", err)
+	}
+	if rel == nil || rel.AthleteID != athleteID {
+		return nil, ErrCoachRelationshipNotFound
+	}
+	return rel, nil
+}
+
+// logView records a coach read of athlete data; the athlete is the target user
+// Unlike status changes, a read that cannot be audited is refused, including when no audit log is wired
+func (s *CoachService) logView(coachID, athleteID int64, resource, ipAddress, userAgent string, details map[string]interface{}) error {
+	if s.auditLogService == nil {
+		return ErrCoachAuditUnavailable
+	}
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["resource"] = resource
+	if err := s.auditLogService.LogEvent(domain.EventCoachViewedAthlete, &coachID, &athleteID, &ipAddress, &userAgent, details); err != nil {
+		return fmt.Errorf("failed to record coach access in audit log: %w", err)
+	}
+	return nil
+}
+
+func (s *CoachService) logEvent(eventType string, userID, targetUserID int64, ipAddress, userAgent string, details map[string]interface{}) {
+	if s.auditLogService == nil {
+		return
+	}
+	// Audit logging failures should not block a status change
+	_ = s.auditLogService.LogEvent(eventType, &userID, &targetUserID, &ipAddress, &userAgent, details)
+}
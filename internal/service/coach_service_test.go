@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// newTestCoachService returns a service with coach 1, athlete 2 and a logged workout 20 owned by the athlete
+func newTestCoachService(auditRepo *mockAuditLogRepo) (*CoachService, *mockCoachAthleteRepo) {
+	userRepo := &mockUserRepo{users: map[int64]*domain.User{
+		1: {ID: 1, Email: "coach@example.com"},
+		2: {ID: 2, Email: "athlete@example.com"},
+	}, nextID: 2}
+	userWorkoutRepo := newMockUserWorkoutRepo()
+	userWorkoutRepo.userWorkouts[20] = &domain.UserWorkout{ID: 20, UserID: 2}
+	coachAthleteRepo := newMockCoachAthleteRepo()
+
+	var auditLogService *AuditLogService
+	if auditRepo != nil {
+		auditLogService = NewAuditLogService(auditRepo)
+	}
+	return NewCoachService(coachAthleteRepo, userRepo, userWorkoutRepo, &mockUserWorkoutMovementRepo{}, &mockUserWorkoutWODRepo{}, auditLogService), coachAthleteRepo
+}
+
+func TestCoachServiceInviteFlow(t *testing.T) {
+	auditRepo := &mockAuditLogRepo{}
+	svc, _ := newTestCoachService(auditRepo)
+
+	if _, err := svc.InviteAthlete(1, "coach@example.com", "", ""); !errors.Is(err, ErrCoachInviteSelf) {
+		t.Errorf("expected ErrCoachInviteSelf, got %v", err)
+	}
+	if _, err := svc.InviteAthlete(1, "nobody@example.com", "", ""); !errors.Is(err, ErrCoachAthleteNotFound) {
+		t.Errorf("expected ErrCoachAthleteNotFound, got %v", err)
+	}
+
+	rel, err := svc.InviteAthlete(1, "athlete@example.com", "", "")
+	if err != nil {
+		t.Fatalf("InviteAthlete() error = %v", err)
+	}
+	if rel.Status != domain.CoachAthleteStatusPending {
+		t.Errorf("expected a pending invite, got %s", rel.Status)
+	}
+	if _, err := svc.InviteAthlete(1, "athlete@example.com", "", ""); !errors.Is(err, ErrCoachRelationshipExists) {
+		t.Errorf("expected ErrCoachRelationshipExists for a pending invite, got %v", err)
+	}
+
+	// Only the invited athlete can respond
+	if _, err := svc.AcceptInvite(1, rel.ID, "", ""); !errors.Is(err, ErrCoachRelationshipNotFound) {
+		t.Errorf("expected ErrCoachRelationshipNotFound for the coach, got %v", err)
+	}
+	if _, err := svc.AcceptInvite(2, rel.ID, "", ""); err != nil {
+		t.Fatalf("AcceptInvite() error = %v", err)
+	}
+	if rel.Status != domain.CoachAthleteStatusActive {
+		t.Errorf("expected an active relationship, got %s", rel.Status)
+	}
+	if _, err := svc.DeclineInvite(2, rel.ID, "", ""); !errors.Is(err, ErrCoachInvalidTransition) {
+		t.Errorf("expected ErrCoachInvalidTransition declining an accepted invite, got %v", err)
+	}
+
+	if err := svc.RevokeCoach(2, rel.ID, "", ""); err != nil {
+		t.Fatalf("RevokeCoach() error = %v", err)
+	}
+	if err := svc.RevokeCoach(2, rel.ID, "", ""); !errors.Is(err, ErrCoachInvalidTransition) {
+		t.Errorf("expected ErrCoachInvalidTransition revoking twice, got %v", err)
+	}
+
+	// A revoked relationship can be re-opened by a new invite
+	reopened, err := svc.InviteAthlete(1, "athlete@example.com", "", "")
+	if err != nil {
+		t.Fatalf("InviteAthlete() after revoke error = %v", err)
+	}
+	if reopened.ID != rel.ID || reopened.Status != domain.CoachAthleteStatusPending {
+		t.Errorf("expected relationship %d re-opened as pending, got %d %s", rel.ID, reopened.ID, reopened.Status)
+	}
+
+	want := []string{domain.EventCoachInviteSent, domain.EventCoachInviteAccepted, domain.EventCoachAccessRevoked, domain.EventCoachInviteSent}
+	got := auditRepo.eventTypes()
+	if len(got) != len(want) {
+		t.Fatalf("expected audit events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("audit event %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+}
+
+func TestCoachServiceReadAccess(t *testing.T) {
+	auditRepo := &mockAuditLogRepo{}
+	svc, coachAthleteRepo := newTestCoachService(auditRepo)
+
+	if _, err := svc.ListAthleteWorkouts(1, 2, 10, 0, "", ""); !errors.Is(err, ErrCoachAccessDenied) {
+		t.Errorf("expected ErrCoachAccessDenied without a relationship, got %v", err)
+	}
+
+	rel, err := svc.InviteAthlete(1, "athlete@example.com", "", "")
+	if err != nil {
+		t.Fatalf("InviteAthlete() error = %v", err)
+	}
+	if _, err := svc.GetAthleteWorkout(1, 2, 20, "", ""); !errors.Is(err, ErrCoachAccessDenied) {
+		t.Errorf("expected ErrCoachAccessDenied for a pending invite, got %v", err)
+	}
+
+	if _, err := svc.AcceptInvite(2, rel.ID, "", ""); err != nil {
+		t.Fatalf("AcceptInvite() error = %v", err)
+	}
+	auditRepo.logs = nil
+
+	workouts, err := svc.ListAthleteWorkouts(1, 2, 10, 0, "", "")
+	if err != nil {
+		t.Fatalf("ListAthleteWorkouts() error = %v", err)
+	}
+	if len(workouts) != 1 {
+		t.Errorf("expected 1 workout, got %d", len(workouts))
+	}
+	if len(auditRepo.logs) != 1 || auditRepo.logs[0].EventType != domain.EventCoachViewedAthlete {
+		t.Fatalf("expected the read to be audited, got %v", auditRepo.eventTypes())
+	}
+	if log := auditRepo.logs[0]; log.UserID == nil || *log.UserID != 1 || log.TargetUserID == nil || *log.TargetUserID != 2 {
+		t.Errorf("expected coach 1 viewing athlete 2, got %+v", log)
+	}
+
+	// Another athlete's workout is not reachable through this relationship
+	if _, err := svc.GetAthleteWorkout(1, 2, 99, "", ""); !errors.Is(err, ErrUserWorkoutNotFound) {
+		t.Errorf("expected ErrUserWorkoutNotFound, got %v", err)
+	}
+
+	// A read that cannot be audited is refused
+	auditRepo.createError = errors.New("disk full")
+	if _, err := svc.ListAthleteWorkouts(1, 2, 10, 0, "", ""); err == nil {
+		t.Error("expected the read to fail when the audit log write fails")
+	}
+
+	coachAthleteRepo.rels[rel.ID].Status = domain.CoachAthleteStatusRevoked
+	if err := svc.AuthorizeView(1, 2, "analytics", "", "", nil); !errors.Is(err, ErrCoachAccessDenied) {
+		t.Errorf("expected ErrCoachAccessDenied after revoke, got %v", err)
+	}
+}
+
+func TestCoachServiceRequiresAuditLog(t *testing.T) {
+	svc, coachAthleteRepo := newTestCoachService(nil)
+	coachAthleteRepo.Create(&domain.CoachAthlete{CoachID: 1, AthleteID: 2, Status: domain.CoachAthleteStatusActive})
+
+	if _, err := svc.ListAthleteWorkouts(1, 2, 10, 0, "", ""); !errors.Is(err, ErrCoachAuditUnavailable) {
+		t.Errorf("expected ErrCoachAuditUnavailable, got %v", err)
+	}
+	if err := svc.AuthorizeView(1, 2, "analytics", "", "", nil); !errors.Is(err, ErrCoachAuditUnavailable) {
+		t.Errorf("expected ErrCoachAuditUnavailable, got %v", err)
+	}
+}
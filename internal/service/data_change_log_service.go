@@ -20,6 +20,31 @@ func NewDataChangeLogService(repo domain.DataChangeLogRepository) *DataChangeLog
 	}
 }
 
+// LogCreate logs a create operation with after values (no before values)
+func (s *DataChangeLogService) LogCreate(entityType string, entityID int64, entityName string, userID int64, userEmail string, after interface{}, ipAddress, userAgent *string) error {
+	// Serialize after value
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return fmt.Errorf("failed to marshal after values: %w", err)
+	}
+	afterStr := string(afterJSON)
+
+	log := &domain.DataChangeLog{
+		EntityType:   entityType,
+		EntityID:     entityID,
+		EntityName:   entityName,
+		Operation:    domain.OperationCreate,
+		UserID:       userID,
+		UserEmail:    userEmail,
+		BeforeValues: nil, // No before values for create
+		AfterValues:  &afterStr,
+		IPAddress:    ipAddress,
+		UserAgent:    userAgent,
+	}
+
+	return s.repo.Create(log)
+}
+
 // LogUpdate logs an update operation with before/after values
 func (s *DataChangeLogService) LogUpdate(entityType string, entityID int64, entityName string, userID int64, userEmail string, before, after interface{}, ipAddress, userAgent *string) error {
 	// Serialize before value
@@ -77,6 +102,37 @@ func (s *DataChangeLogService) LogDelete(entityType string, entityID int64, enti
 	return s.repo.Create(log)
 }
 
+// LogMerge logs a record being merged into another, with the merged record as before values and
+// the merge outcome as after values
+func (s *DataChangeLogService) LogMerge(entityType string, entityID int64, entityName string, userID int64, userEmail string, before, after interface{}, ipAddress, userAgent *string) error {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return fmt.Errorf("failed to marshal before values: %w", err)
+	}
+	beforeStr := string(beforeJSON)
+
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return fmt.Errorf("failed to marshal after values: %w", err)
+	}
+	afterStr := string(afterJSON)
+
+	log := &domain.DataChangeLog{
+		EntityType:   entityType,
+		EntityID:     entityID,
+		EntityName:   entityName,
+		Operation:    domain.OperationMerge,
+		UserID:       userID,
+		UserEmail:    userEmail,
+		BeforeValues: &beforeStr,
+		AfterValues:  &afterStr,
+		IPAddress:    ipAddress,
+		UserAgent:    userAgent,
+	}
+
+	return s.repo.Create(log)
+}
+
 // GetByID retrieves a single data change log by ID
 func (s *DataChangeLogService) GetByID(id int64) (*domain.DataChangeLog, error) {
 	return s.repo.GetByID(id)
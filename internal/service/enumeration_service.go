@@ -0,0 +1,302 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrEnumerationNotFound        = errors.New("enumeration value not found")
+	ErrInvalidEnumerationCategory = errors.New("unknown enumeration category")
+	ErrInvalidEnumeration         = errors.New("invalid enumeration value")
+	ErrEnumerationDuplicate       = errors.New("enumeration value already exists in this category")
+	ErrEnumerationInUse           = errors.New("enumeration value is in use; deprecate it instead")
+	ErrEnumerationLastValue       = errors.New("a category must keep at least one active value")
+)
+
+// maxEnumerationValueLength matches the width of the enumerations.value column
+const maxEnumerationValueLength = 100
+
+// EnumerationUpdate holds the fields an admin may change on an enumeration value; nil fields are kept
+type EnumerationUpdate struct {
+	Value        *string `json:"value,omitempty"` // Renames the value on every WOD or movement using it
+	Description  *string `json:"description,omitempty"`
+	DisplayOrder *int    `json:"display_order,omitempty"`
+	IsDeprecated *bool   `json:"is_deprecated,omitempty"`
+}
+
+// EnumerationService manages the admin-editable values of WOD source, type and regime and of
+// movement type, and validates records against them
+type EnumerationService struct {
+	enumerationRepo      domain.EnumerationRepository
+	dataChangeLogService *DataChangeLogService
+}
+
+// NewEnumerationService creates a new enumeration service
+func NewEnumerationService(enumerationRepo domain.EnumerationRepository, dataChangeLogService *DataChangeLogService) *EnumerationService {
+	return &EnumerationService{
+		enumerationRepo:      enumerationRepo,
+		dataChangeLogService: dataChangeLogService,
+	}
+}
+
+// SeedDefaults fills every category that has no values with domain.DefaultEnumerations;
+// returns the number of values created
+func (s *EnumerationService) SeedDefaults() (int, error) {
+	created := 0
+	for _, category := range domain.EnumerationCategories {
+		existing, err := s.enumerationRepo.List(category, true)
+		if err != nil {
+			return created, err
+		}
+		if len(existing) > 0 {
+			continue
+		}
+		for i, value := range domain.DefaultEnumerations[category] {
+			if err := s.enumerationRepo.Create(&domain.Enumeration{Category: category, Value: value, DisplayOrder: i + 1}); err != nil {
+				return created, err
+			}
+			created++
+		}
+	}
+	return created, nil
+}
+
+// List returns the values of a category (every category when empty) in display order.
+// includeDeprecated also returns deprecated values along with how many records use each value (admin).
+func (s *EnumerationService) List(category string, includeDeprecated bool) ([]*domain.Enumeration, error) {
+	if category != "" && !contains(domain.EnumerationCategories, category) {
+		return nil, fmt.Errorf("%w: %s (must be one of: %s)", ErrInvalidEnumerationCategory, category, strings.Join(domain.EnumerationCategories, ", "))
+	}
+
+	enumerations, err := s.enumerationRepo.List(category, includeDeprecated)
+	if err != nil {
+		return nil, err
+	}
+	if includeDeprecated {
+		for _, enumeration := range enumerations {
+			if enumeration.InUse, err = s.enumerationRepo.CountUsage(enumeration.Category, enumeration.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return enumerations, nil
+}
+
+// Values returns the active values of a category in display order
+func (s *EnumerationService) Values(category string) ([]string, error) {
+	enumerations, err := s.enumerationRepo.List(category, false)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(enumerations))
+	for i, enumeration := range enumerations {
+		values[i] = enumeration.Value
+	}
+	return values, nil
+}
+
+// Create adds a value to a category (admin only); display order defaults to the end of the list
+func (s *EnumerationService) Create(enumeration *domain.Enumeration) error {
+	if !contains(domain.EnumerationCategories, enumeration.Category) {
+		return fmt.Errorf("%w: %s (must be one of: %s)", ErrInvalidEnumerationCategory, enumeration.Category, strings.Join(domain.EnumerationCategories, ", "))
+	}
+	existing, err := s.enumerationRepo.List(enumeration.Category, true)
+	if err != nil {
+		return err
+	}
+	if err := s.validateValue(enumeration, existing); err != nil {
+		return err
+	}
+
+	if enumeration.DisplayOrder <= 0 {
+		for _, other := range existing {
+			enumeration.DisplayOrder = max(enumeration.DisplayOrder, other.DisplayOrder)
+		}
+		enumeration.DisplayOrder++
+	}
+	return s.enumerationRepo.Create(enumeration)
+}
+
+// Update changes a value (admin only). Renaming updates every WOD or movement using the old value;
+// deprecating keeps it on existing records but stops it being chosen for new ones.
+func (s *EnumerationService) Update(id int64, update *EnumerationUpdate, adminID int64, adminEmail string) (*domain.Enumeration, error) {
+	existing, err := s.getEnumeration(id)
+	if err != nil {
+		return nil, err
+	}
+	before := *existing
+
+	enumeration := *existing
+	if update.Value != nil {
+		enumeration.Value = *update.Value
+	}
+	if update.Description != nil {
+		enumeration.Description = update.Description
+		if strings.TrimSpace(*update.Description) == "" {
+			enumeration.Description = nil
+		}
+	}
+	if update.DisplayOrder != nil {
+		enumeration.DisplayOrder = *update.DisplayOrder
+	}
+	if update.IsDeprecated != nil {
+		enumeration.IsDeprecated = *update.IsDeprecated
+	}
+
+	siblings, err := s.enumerationRepo.List(enumeration.Category, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateValue(&enumeration, siblings); err != nil {
+		return nil, err
+	}
+	if enumeration.IsDeprecated && !before.IsDeprecated && activeCount(siblings) <= 1 {
+		return nil, ErrEnumerationLastValue
+	}
+
+	if err := s.enumerationRepo.Update(&enumeration, before.Value); err != nil {
+		return nil, err
+	}
+
+	if s.dataChangeLogService != nil {
+		if logErr := s.dataChangeLogService.LogUpdate(domain.EntityTypeEnumeration, enumeration.ID, enumeration.Category+": "+enumeration.Value, adminID, adminEmail, before, enumeration, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log enumeration update: %v\n", logErr)
+		}
+	}
+
+	return &enumeration, nil
+}
+
+// Delete removes a value no WOD or movement uses (admin only); values in use can only be deprecated
+func (s *EnumerationService) Delete(id int64, adminID int64, adminEmail string) error {
+	enumeration, err := s.getEnumeration(id)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := s.enumerationRepo.CountUsage(enumeration.Category, enumeration.Value)
+	if err != nil {
+		return err
+	}
+	if inUse > 0 {
+		return fmt.Errorf("%w (%d records use %q)", ErrEnumerationInUse, inUse, enumeration.Value)
+	}
+	if !enumeration.IsDeprecated {
+		siblings, err := s.enumerationRepo.List(enumeration.Category, false)
+		if err != nil {
+			return err
+		}
+		if len(siblings) <= 1 {
+			return ErrEnumerationLastValue
+		}
+	}
+
+	if err := s.enumerationRepo.Delete(id); err != nil {
+		return err
+	}
+
+	if s.dataChangeLogService != nil {
+		if logErr := s.dataChangeLogService.LogDelete(domain.EntityTypeEnumeration, enumeration.ID, enumeration.Category+": "+enumeration.Value, adminID, adminEmail, enumeration, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log enumeration delete: %v\n", logErr)
+		}
+	}
+	return nil
+}
+
+// Import adds the values of an enumeration export (see ExportService.ExportEnumerationsToJSON) that
+// do not exist yet, ignoring case; existing values are left unchanged. Returns the number created.
+func (s *EnumerationService) Import(jsonData io.Reader) (int, error) {
+	var imports []EnumerationExport
+	if err := json.NewDecoder(jsonData).Decode(&imports); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidEnumeration, err)
+	}
+
+	created := 0
+	for _, item := range imports {
+		enumeration := &domain.Enumeration{
+			Category:     item.Category,
+			Value:        item.Value,
+			Description:  item.Description,
+			DisplayOrder: item.DisplayOrder,
+			IsDeprecated: item.IsDeprecated,
+		}
+		if err := s.Create(enumeration); err != nil {
+			if errors.Is(err, ErrEnumerationDuplicate) {
+				continue
+			}
+			return created, fmt.Errorf("%s %q: %w", item.Category, item.Value, err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+func (s *EnumerationService) getEnumeration(id int64) (*domain.Enumeration, error) {
+	enumeration, err := s.enumerationRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if enumeration == nil {
+		return nil, ErrEnumerationNotFound
+	}
+	return enumeration, nil
+}
+
+// validateValue trims the value and checks it is non-empty, fits the column and is unique
+// in its category ignoring case
+func (s *EnumerationService) validateValue(enumeration *domain.Enumeration, siblings []*domain.Enumeration) error {
+	enumeration.Value = strings.TrimSpace(enumeration.Value)
+	if enumeration.Value == "" {
+		return fmt.Errorf("%w: value is required", ErrInvalidEnumeration)
+	}
+	if len(enumeration.Value) > maxEnumerationValueLength {
+		return fmt.Errorf("%w: value must be at most %d characters", ErrInvalidEnumeration, maxEnumerationValueLength)
+	}
+	for _, other := range siblings {
+		if other.ID != enumeration.ID && strings.EqualFold(other.Value, enumeration.Value) {
+			return fmt.Errorf("%w: %s", ErrEnumerationDuplicate, other.Value)
+		}
+	}
+	return nil
+}
+
+// activeCount counts the values that are not deprecated
+func activeCount(enumerations []*domain.Enumeration) int {
+	count := 0
+	for _, enumeration := range enumerations {
+		if !enumeration.IsDeprecated {
+			count++
+		}
+	}
+	return count
+}
+
+// enumerationValues returns the active values of a category, or the defaults when no
+// enumeration service is wired
+func enumerationValues(s *EnumerationService, category string) ([]string, error) {
+	if s == nil {
+		return domain.DefaultEnumerations[category], nil
+	}
+	return s.Values(category)
+}
+
+// resolveEnumeration matches a value against the active values of a category ignoring case and
+// returns the stored spelling. The previous value of the record being edited is always kept, so
+// deprecated values survive edits of records that already use them.
+func resolveEnumeration(allowed []string, field, value, previous string) (string, error) {
+	if value == previous && value != "" {
+		return value, nil
+	}
+	for _, candidate := range allowed {
+		if strings.EqualFold(candidate, value) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%w: invalid %s %q (must be one of: %s)", ErrInvalidEnumeration, field, value, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+func TestEnumerationServiceSeedAndValues(t *testing.T) {
+	repo := newMockEnumerationRepo()
+	s := NewEnumerationService(repo, nil)
+
+	created, err := s.SeedDefaults()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := 0
+	for _, values := range domain.DefaultEnumerations {
+		want += len(values)
+	}
+	if created != want {
+		t.Errorf("expected %d seeded values, got %d", want, created)
+	}
+	if created, _ := s.SeedDefaults(); created != 0 {
+		t.Errorf("expected seeding to skip filled categories, got %d", created)
+	}
+
+	if err := s.Create(&domain.Enumeration{Category: domain.EnumerationWODType, Value: " Open "}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	values, _ := s.Values(domain.EnumerationWODType)
+	if values[len(values)-1] != "Open" {
+		t.Errorf("expected trimmed value appended to the end, got %v", values)
+	}
+
+	if err := s.Create(&domain.Enumeration{Category: domain.EnumerationWODType, Value: "open"}); !errors.Is(err, ErrEnumerationDuplicate) {
+		t.Errorf("expected ErrEnumerationDuplicate, got %v", err)
+	}
+	if err := s.Create(&domain.Enumeration{Category: "wod_color", Value: "Red"}); !errors.Is(err, ErrInvalidEnumerationCategory) {
+		t.Errorf("expected ErrInvalidEnumerationCategory, got %v", err)
+	}
+	if err := s.Create(&domain.Enumeration{Category: domain.EnumerationWODType, Value: strings.Repeat("x", maxEnumerationValueLength+1)}); !errors.Is(err, ErrInvalidEnumeration) {
+		t.Errorf("expected ErrInvalidEnumeration for a long value, got %v", err)
+	}
+}
+
+func TestEnumerationServiceDeprecateAndDelete(t *testing.T) {
+	repo := newMockEnumerationRepo()
+	repo.usage[domain.EnumerationWODSource+":CrossFit"] = 3
+	s := NewEnumerationService(repo, nil)
+	crossfit := &domain.Enumeration{Category: domain.EnumerationWODSource, Value: "CrossFit"}
+	coach := &domain.Enumeration{Category: domain.EnumerationWODSource, Value: "Other Coach"}
+	s.Create(crossfit)
+	s.Create(coach)
+
+	if err := s.Delete(crossfit.ID, 1, "admin@example.com"); !errors.Is(err, ErrEnumerationInUse) {
+		t.Errorf("expected ErrEnumerationInUse, got %v", err)
+	}
+
+	deprecated := true
+	if _, err := s.Update(crossfit.ID, &EnumerationUpdate{IsDeprecated: &deprecated}, 1, "admin@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Update(coach.ID, &EnumerationUpdate{IsDeprecated: &deprecated}, 1, "admin@example.com"); !errors.Is(err, ErrEnumerationLastValue) {
+		t.Errorf("expected ErrEnumerationLastValue when deprecating the last active value, got %v", err)
+	}
+	if err := s.Delete(coach.ID, 1, "admin@example.com"); !errors.Is(err, ErrEnumerationLastValue) {
+		t.Errorf("expected ErrEnumerationLastValue when deleting the last active value, got %v", err)
+	}
+
+	values, _ := s.Values(domain.EnumerationWODSource)
+	if len(values) != 1 || values[0] != "Other Coach" {
+		t.Errorf("expected only the active value, got %v", values)
+	}
+	all, _ := s.List(domain.EnumerationWODSource, true)
+	if len(all) != 2 || all[0].InUse != 3 {
+		t.Errorf("expected deprecated value listed with usage, got %+v", all)
+	}
+}
+
+func TestResolveEnumeration(t *testing.T) {
+	allowed := []string{"Benchmark", "Hero"}
+	tests := []struct {
+		value, previous, want string
+		wantErr               bool
+	}{
+		{"hero", "", "Hero", false},
+		{"Benchmark", "Hero", "Benchmark", false},
+		{"Girl", "Girl", "Girl", false}, // deprecated value kept on an existing record
+		{"Girl", "Hero", "", true},
+		{"", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := resolveEnumeration(allowed, "type", tt.value, tt.previous)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("resolveEnumeration(%q, %q) = %q, %v; want %q, error %v", tt.value, tt.previous, got, err, tt.want, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidEnumeration) {
+			t.Errorf("expected ErrInvalidEnumeration, got %v", err)
+		}
+	}
+}
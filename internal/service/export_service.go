@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/johnzastrow/actalog/internal/domain"
@@ -17,6 +18,8 @@ type ExportService struct {
 	movementRepo    domain.MovementRepository
 	userRepo        domain.UserRepository
 	userWorkoutRepo domain.UserWorkoutRepository
+
+	enumerationService *EnumerationService
 }
 
 // NewExportService creates a new export service
@@ -34,6 +37,11 @@ func NewExportService(
 	}
 }
 
+// SetEnumerationService enables exporting the admin-managed WOD and movement field values
+func (s *ExportService) SetEnumerationService(enumerationService *EnumerationService) {
+	s.enumerationService = enumerationService
+}
+
 // UserWorkoutExport represents the complete export structure for user workouts
 type UserWorkoutExport struct {
 	ExportMetadata ExportMetadata          `json:"export_metadata"`
@@ -57,13 +65,14 @@ type DateRange struct {
 
 // UserWorkoutExportItem represents a single workout in the export
 type UserWorkoutExportItem struct {
-	WorkoutDate string                       `json:"workout_date"`
-	WorkoutType *string                      `json:"workout_type,omitempty"`
-	WorkoutName *string                      `json:"workout_name,omitempty"`
-	TotalTime   *int                         `json:"total_time,omitempty"`
-	Notes       *string                      `json:"notes,omitempty"`
-	Movements   []MovementPerformanceExport  `json:"movements,omitempty"`
-	WODs        []WODPerformanceExport       `json:"wods,omitempty"`
+	WorkoutDate string  `json:"workout_date"`
+	WorkoutType *string `json:"workout_type,omitempty"`
+	WorkoutName *string `json:"workout_name,omitempty"`
+	TotalTime   *int    `json:"total_time,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+	domain.SessionWellness
+	Movements []MovementPerformanceExport `json:"movements,omitempty"`
+	WODs      []WODPerformanceExport      `json:"wods,omitempty"`
 }
 
 // MovementPerformanceExport represents movement performance data
@@ -90,9 +99,10 @@ type WODPerformanceExport struct {
 	Rounds       *int     `json:"rounds,omitempty"`
 	Reps         *int     `json:"reps,omitempty"`
 	Weight       *float64 `json:"weight,omitempty"`
-	Notes        string   `json:"notes,omitempty"`
-	IsPR         bool     `json:"is_pr"`
-	OrderIndex   int      `json:"order_index"`
+	domain.WODScoreDetails
+	Notes      string `json:"notes,omitempty"`
+	IsPR       bool   `json:"is_pr"`
+	OrderIndex int    `json:"order_index"`
 }
 
 // ExportWODsToCSV exports WODs to CSV format
@@ -261,7 +271,7 @@ func (s *ExportService) ExportMovementsToCSV(userID int64, isAdmin bool, include
 	// Fetch movements based on permissions and filters
 	if isAdmin && includeStandard && includeCustom {
 		// Admin wants everything
-		movements, err = s.movementRepo.ListAll()
+		movements, err = s.movementRepo.ListAll(nil)
 	} else if includeStandard && includeCustom {
 		// User wants standard + their custom
 		standardMovements, err1 := s.movementRepo.ListStandard()
@@ -293,11 +303,17 @@ func (s *ExportService) ExportMovementsToCSV(userID int64, isAdmin bool, include
 	writer := csv.NewWriter(&buf)
 
 	// Write CSV header
-	header := []string{"name", "type", "description", "is_standard", "created_by_email"}
+	header := []string{"name", "type", "description", "is_standard", "created_by_email", "equipment", "pattern", "muscle_groups", "parent"}
 	if err := writer.Write(header); err != nil {
 		return nil, fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
+	// Parent names are written instead of IDs so the file can be imported into another instance
+	movementNames := make(map[int64]string, len(movements))
+	for _, movement := range movements {
+		movementNames[movement.ID] = movement.Name
+	}
+
 	// Write movement rows
 	for _, movement := range movements {
 		var createdByEmail string
@@ -312,12 +328,32 @@ func (s *ExportService) ExportMovementsToCSV(userID int64, isAdmin bool, include
 			}
 		}
 
+		var parentName string
+		if movement.ParentID != nil {
+			name, ok := movementNames[*movement.ParentID]
+			if !ok {
+				parent, err := s.movementRepo.GetByID(*movement.ParentID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to fetch parent of movement %d: %w", movement.ID, err)
+				}
+				if parent != nil {
+					name = parent.Name
+				}
+				movementNames[*movement.ParentID] = name
+			}
+			parentName = name
+		}
+
 		row := []string{
 			movement.Name,
 			string(movement.Type),
 			movement.Description,
 			strconv.FormatBool(movement.IsStandard),
 			createdByEmail,
+			strings.Join(movement.Equipment, "|"),
+			movement.Pattern,
+			strings.Join(movement.MuscleGroups, "|"),
+			parentName,
 		}
 
 		if err := writer.Write(row); err != nil {
@@ -341,7 +377,7 @@ func (s *ExportService) ExportMovementsToJSON(userID int64, isAdmin bool, includ
 
 	// Fetch movements based on permissions and filters (same logic as CSV)
 	if isAdmin && includeStandard && includeCustom {
-		movements, err = s.movementRepo.ListAll()
+		movements, err = s.movementRepo.ListAll(nil)
 	} else if includeStandard && includeCustom {
 		standardMovements, err1 := s.movementRepo.ListStandard()
 		if err1 != nil {
@@ -394,6 +430,83 @@ func (s *ExportService) ExportMovementsToJSON(userID int64, isAdmin bool, includ
 	return jsonData, nil
 }
 
+// EnumerationExport is one allowed WOD or movement field value in an export
+type EnumerationExport struct {
+	Category     string  `json:"category"`
+	Value        string  `json:"value"`
+	Description  *string `json:"description,omitempty"`
+	DisplayOrder int     `json:"display_order"`
+	IsDeprecated bool    `json:"is_deprecated"`
+}
+
+// listEnumerationExports reads every enumeration value, deprecated ones included
+func (s *ExportService) listEnumerationExports() ([]EnumerationExport, error) {
+	if s.enumerationService == nil {
+		return nil, fmt.Errorf("enumeration export is not available")
+	}
+	enumerations, err := s.enumerationService.List("", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch enumerations: %w", err)
+	}
+
+	exports := make([]EnumerationExport, 0, len(enumerations))
+	for _, e := range enumerations {
+		exports = append(exports, EnumerationExport{
+			Category:     e.Category,
+			Value:        e.Value,
+			Description:  e.Description,
+			DisplayOrder: e.DisplayOrder,
+			IsDeprecated: e.IsDeprecated,
+		})
+	}
+	return exports, nil
+}
+
+// ExportEnumerationsToCSV exports the WOD source, type and regime and movement type values to CSV format
+func (s *ExportService) ExportEnumerationsToCSV() ([]byte, error) {
+	exports, err := s.listEnumerationExports()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write([]string{"category", "value", "description", "display_order", "is_deprecated"}); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, e := range exports {
+		description := ""
+		if e.Description != nil {
+			description = *e.Description
+		}
+		if err := writer.Write([]string{e.Category, e.Value, description, strconv.Itoa(e.DisplayOrder), strconv.FormatBool(e.IsDeprecated)}); err != nil {
+			return nil, fmt.Errorf("failed to write enumeration row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("CSV writer error: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportEnumerationsToJSON exports the WOD source, type and regime and movement type values to JSON format
+// The output can be loaded into another instance with EnumerationService.Import
+func (s *ExportService) ExportEnumerationsToJSON() ([]byte, error) {
+	exports, err := s.listEnumerationExports()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.MarshalIndent(exports, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return jsonData, nil
+}
+
 // ExportUserWorkoutsToJSON exports user workouts with full nested data to JSON format
 // Supports optional date range filtering
 func (s *ExportService) ExportUserWorkoutsToJSON(userID int64, startDate, endDate *time.Time) ([]byte, error) {
@@ -450,13 +563,14 @@ func (s *ExportService) ExportUserWorkoutsToJSON(userID int64, startDate, endDat
 
 		// Build workout export item
 		item := UserWorkoutExportItem{
-			WorkoutDate: details.WorkoutDate.Format("2006-01-02"),
-			WorkoutType: details.WorkoutType,
-			WorkoutName: &details.WorkoutName,
-			TotalTime:   details.TotalTime,
-			Notes:       details.Notes,
-			Movements:   make([]MovementPerformanceExport, 0),
-			WODs:        make([]WODPerformanceExport, 0),
+			WorkoutDate:     details.WorkoutDate.Format("2006-01-02"),
+			WorkoutType:     details.WorkoutType,
+			WorkoutName:     &details.WorkoutName,
+			TotalTime:       details.TotalTime,
+			Notes:           details.Notes,
+			SessionWellness: details.SessionWellness,
+			Movements:       make([]MovementPerformanceExport, 0),
+			WODs:            make([]WODPerformanceExport, 0),
 		}
 
 		// Add movement performance data
@@ -479,17 +593,18 @@ func (s *ExportService) ExportUserWorkoutsToJSON(userID int64, startDate, endDat
 		// Add WOD performance data
 		for _, perfWOD := range details.PerformanceWODs {
 			wodExport := WODPerformanceExport{
-				WODName:     perfWOD.WODName,
-				WODType:     perfWOD.WODType,
-				ScoreType:   perfWOD.ScoreType,
-				ScoreValue:  perfWOD.ScoreValue,
-				TimeSeconds: perfWOD.TimeSeconds,
-				Rounds:      perfWOD.Rounds,
-				Reps:        perfWOD.Reps,
-				Weight:      perfWOD.Weight,
-				Notes:       perfWOD.Notes,
-				IsPR:        perfWOD.IsPR,
-				OrderIndex:  perfWOD.OrderIndex,
+				WODName:         perfWOD.WODName,
+				WODType:         perfWOD.WODType,
+				ScoreType:       perfWOD.ScoreType,
+				ScoreValue:      perfWOD.ScoreValue,
+				TimeSeconds:     perfWOD.TimeSeconds,
+				Rounds:          perfWOD.Rounds,
+				Reps:            perfWOD.Reps,
+				Weight:          perfWOD.Weight,
+				WODScoreDetails: perfWOD.WODScoreDetails,
+				Notes:           perfWOD.Notes,
+				IsPR:            perfWOD.IsPR,
+				OrderIndex:      perfWOD.OrderIndex,
 			}
 			item.WODs = append(item.WODs, wodExport)
 		}
@@ -555,6 +670,10 @@ func (s *ExportService) ExportUserWorkoutsToCSV(userID int64, startDate, endDate
 		"is_pr",
 		"performance_notes",
 		"order_index",
+		"calories",
+		"points",
+		"tie_break_seconds",
+		"time_capped",
 	}
 	if err := writer.Write(header); err != nil {
 		return nil, fmt.Errorf("failed to write CSV header: %w", err)
@@ -620,6 +739,10 @@ func (s *ExportService) ExportUserWorkoutsToCSV(userID int64, startDate, endDate
 				strconv.FormatBool(perfMovement.IsPR), // is_pr
 				perfMovement.Notes,             // performance_notes
 				strconv.Itoa(perfMovement.OrderIndex), // order_index
+				"",                             // calories (n/a for movements)
+				"",                             // points (n/a for movements)
+				"",                             // tie_break_seconds (n/a for movements)
+				"",                             // time_capped (n/a for movements)
 			}
 			if err := writer.Write(row); err != nil {
 				return nil, fmt.Errorf("failed to write movement row: %w", err)
@@ -641,13 +764,17 @@ func (s *ExportService) ExportUserWorkoutsToCSV(userID int64, startDate, endDate
 				formatInt(perfWOD.Reps),        // reps
 				formatFloat(perfWOD.Weight),    // weight
 				formatInt(perfWOD.TimeSeconds), // time_seconds
-				"",                             // distance (n/a for WODs)
+				formatFloat(perfWOD.Distance),  // distance
 				formatInt(perfWOD.Rounds),      // rounds
 				formatString(perfWOD.ScoreType), // score_type
 				formatString(perfWOD.ScoreValue), // score_value
 				strconv.FormatBool(perfWOD.IsPR), // is_pr
 				perfWOD.Notes,                  // performance_notes
 				strconv.Itoa(perfWOD.OrderIndex), // order_index
+				formatInt(perfWOD.Calories),    // calories
+				formatFloat(perfWOD.Points),    // points
+				formatInt(perfWOD.TieBreakSeconds), // tie_break_seconds
+				strconv.FormatBool(perfWOD.TimeCapped), // time_capped
 			}
 			if err := writer.Write(row); err != nil {
 				return nil, fmt.Errorf("failed to write WOD row: %w", err)
@@ -676,6 +803,10 @@ func (s *ExportService) ExportUserWorkoutsToCSV(userID int64, startDate, endDate
 				"",  // is_pr
 				"",  // performance_notes
 				"",  // order_index
+				"",  // calories
+				"",  // points
+				"",  // tie_break_seconds
+				"",  // time_capped
 			}
 			if err := writer.Write(row); err != nil {
 				return nil, fmt.Errorf("failed to write workout row: %w", err)
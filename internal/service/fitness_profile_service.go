@@ -0,0 +1,437 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/pkg/prmath"
+)
+
+var (
+	ErrInvalidFitnessStandards = errors.New("invalid fitness standards")
+	ErrProfileGenderRequired   = errors.New("gender is required to pick reference standards; set it in your profile or pass gender")
+)
+
+// fitnessStandardsHeader is the expected header of seeds/fitness_standards.csv and admin uploads
+var fitnessStandardsHeader = []string{"domain", "name", "kind", "metric", "distance", "gender", "beginner", "intermediate", "advanced", "elite"}
+
+// fitnessDomains is the spider chart axis order
+var fitnessDomains = []string{
+	domain.FitnessDomainStrength,
+	domain.FitnessDomainGymnastics,
+	domain.FitnessDomainMonostructural,
+	domain.FitnessDomainMetcon,
+}
+
+// fitnessLevels maps each standard threshold to the percentile it represents
+var fitnessLevels = []struct {
+	name       string
+	percentile float64
+}{
+	{"beginner", 20},
+	{"intermediate", 50},
+	{"advanced", 80},
+	{"elite", 95},
+}
+
+// FitnessBand is one threshold of a standard
+type FitnessBand struct {
+	Level      string  `json:"level"`
+	Value      float64 `json:"value"`
+	Percentile float64 `json:"percentile"`
+}
+
+// FitnessStandardResult is a user's best result against one reference standard
+type FitnessStandardResult struct {
+	Name          string        `json:"name"`
+	Kind          string        `json:"kind"`
+	Metric        string        `json:"metric"`
+	Distance      *float64      `json:"distance,omitempty"`
+	LowerIsBetter bool          `json:"lower_is_better"`
+	Value         *float64      `json:"value,omitempty"`      // nil when never logged
+	Percentile    *float64      `json:"percentile,omitempty"` // 0-99
+	Level         string        `json:"level,omitempty"`      // novice, beginner, intermediate, advanced, elite
+	Bands         []FitnessBand `json:"bands"`
+}
+
+// FitnessDomainScore is one spider chart axis: the mean percentile of the measured standards
+type FitnessDomainScore struct {
+	Domain    string                   `json:"domain"`
+	Score     *float64                 `json:"score,omitempty"`
+	Measured  int                      `json:"measured"`
+	Total     int                      `json:"total"`
+	Standards []*FitnessStandardResult `json:"standards"`
+}
+
+// FitnessProfile scores a user across strength, gymnastics, monostructural and metcon
+type FitnessProfile struct {
+	UserID  int64                 `json:"user_id"`
+	Gender  string                `json:"gender,omitempty"`
+	Domains []*FitnessDomainScore `json:"domains"`
+}
+
+// FitnessProfileService scores athletes against configurable reference standards
+type FitnessProfileService struct {
+	standardRepo       domain.FitnessStandardRepository
+	userRepo           domain.UserRepository
+	movementRepo       domain.MovementRepository
+	wodRepo            domain.WODRepository
+	analyticsRepo      domain.AnalyticsRepository
+	userWorkoutWODRepo domain.UserWorkoutWODRepository
+}
+
+// NewFitnessProfileService creates a new fitness profile service
+func NewFitnessProfileService(
+	standardRepo domain.FitnessStandardRepository,
+	userRepo domain.UserRepository,
+	movementRepo domain.MovementRepository,
+	wodRepo domain.WODRepository,
+	analyticsRepo domain.AnalyticsRepository,
+	userWorkoutWODRepo domain.UserWorkoutWODRepository,
+) *FitnessProfileService {
+	return &FitnessProfileService{
+		standardRepo:       standardRepo,
+		userRepo:           userRepo,
+		movementRepo:       movementRepo,
+		wodRepo:            wodRepo,
+		analyticsRepo:      analyticsRepo,
+		userWorkoutWODRepo: userWorkoutWODRepo,
+	}
+}
+
+// SeedStandards loads standards from a CSV seed file when none are stored yet; returns the number loaded
+func (s *FitnessProfileService) SeedStandards(csvData io.Reader) (int, error) {
+	count, err := s.standardRepo.Count()
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, nil
+	}
+
+	return s.ImportStandards(csvData)
+}
+
+// ImportStandards replaces all standards with the rows of a CSV file; returns the number loaded
+func (s *FitnessProfileService) ImportStandards(csvData io.Reader) (int, error) {
+	standards, err := ParseFitnessStandards(csvData)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.standardRepo.ReplaceAll(standards); err != nil {
+		return 0, err
+	}
+	return len(standards), nil
+}
+
+// ListStandards returns all reference standards
+func (s *FitnessProfileService) ListStandards() ([]*domain.FitnessStandard, error) {
+	return s.standardRepo.List()
+}
+
+// GetProfile scores a user's best results against the standards for their gender.
+// gender overrides the profile gender; standards with no gender apply to everyone.
+func (s *FitnessProfileService) GetProfile(userID int64, gender string) (*FitnessProfile, error) {
+	gender = strings.ToLower(strings.TrimSpace(gender))
+	if gender == "" {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+		if user.Gender != nil {
+			gender = *user.Gender
+		}
+	}
+	if gender != "" && gender != domain.GenderMale && gender != domain.GenderFemale {
+		return nil, ErrInvalidGender
+	}
+
+	standards, err := s.standardRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &FitnessProfile{UserID: userID, Gender: gender}
+	byDomain := make(map[string]*FitnessDomainScore)
+	for _, d := range fitnessDomains {
+		score := &FitnessDomainScore{Domain: d, Standards: []*FitnessStandardResult{}}
+		byDomain[d] = score
+		profile.Domains = append(profile.Domains, score)
+	}
+
+	for _, standard := range standards {
+		if standard.Gender != "" && standard.Gender != gender {
+			if gender == "" {
+				return nil, ErrProfileGenderRequired
+			}
+			continue
+		}
+
+		value, err := s.bestValue(userID, standard)
+		if err != nil {
+			return nil, err
+		}
+
+		result := scoreStandard(standard, value)
+		if d, ok := byDomain[standard.Domain]; ok {
+			d.Standards = append(d.Standards, result)
+		}
+	}
+
+	for _, d := range profile.Domains {
+		var sum float64
+		for _, r := range d.Standards {
+			d.Total++
+			if r.Percentile != nil {
+				d.Measured++
+				sum += *r.Percentile
+			}
+		}
+		if d.Measured > 0 {
+			score := roundTenth(sum / float64(d.Measured))
+			d.Score = &score
+		}
+	}
+
+	return profile, nil
+}
+
+// bestValue returns the user's best result for a standard's metric, or nil when never logged
+func (s *FitnessProfileService) bestValue(userID int64, standard *domain.FitnessStandard) (*float64, error) {
+	if standard.Kind == domain.FitnessKindWOD {
+		wod, err := s.wodRepo.GetByName(standard.Name)
+		if err != nil || wod == nil {
+			return nil, err
+		}
+
+		if standard.Metric == domain.FitnessMetricRounds {
+			rounds, _, err := s.userWorkoutWODRepo.GetBestRoundsRepsForWOD(userID, wod.ID)
+			if err != nil || rounds == nil {
+				return nil, err
+			}
+			value := float64(*rounds)
+			return &value, nil
+		}
+
+		best, err := s.userWorkoutWODRepo.GetBestTimeForWOD(userID, wod.ID)
+		if err != nil || best == nil {
+			return nil, err
+		}
+		value := float64(*best)
+		return &value, nil
+	}
+
+	movement, err := s.movementRepo.GetByName(standard.Name)
+	if err != nil || movement == nil {
+		return nil, err
+	}
+
+	switch standard.Metric {
+	case domain.FitnessMetricE1RM:
+		sets, err := s.analyticsRepo.GetLiftSets(userID, movement.ID, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		var best float64
+		for _, set := range sets {
+			oneRM, _ := prmath.Calculate1RM(set.Weight, set.Reps)
+			best = math.Max(best, oneRM)
+		}
+		if best == 0 {
+			return nil, nil
+		}
+		best = roundTenth(best)
+		return &best, nil
+	case domain.FitnessMetricMaxReps:
+		reps, err := s.analyticsRepo.GetMaxReps(userID, movement.ID)
+		if err != nil || reps == nil || *reps == 0 {
+			return nil, err
+		}
+		value := float64(*reps)
+		return &value, nil
+	default:
+		best, err := s.analyticsRepo.GetBestMovementTime(userID, movement.ID, *standard.Distance)
+		if err != nil || best == nil {
+			return nil, err
+		}
+		value := float64(*best)
+		return &value, nil
+	}
+}
+
+// scoreStandard places a value on the standard's percentile bands
+func scoreStandard(standard *domain.FitnessStandard, value *float64) *FitnessStandardResult {
+	thresholds := []float64{standard.Beginner, standard.Intermediate, standard.Advanced, standard.Elite}
+
+	result := &FitnessStandardResult{
+		Name:          standard.Name,
+		Kind:          standard.Kind,
+		Metric:        standard.Metric,
+		Distance:      standard.Distance,
+		LowerIsBetter: standard.LowerIsBetter(),
+		Value:         value,
+	}
+	for i, level := range fitnessLevels {
+		result.Bands = append(result.Bands, FitnessBand{Level: level.name, Value: thresholds[i], Percentile: level.percentile})
+	}
+
+	if value == nil {
+		return result
+	}
+
+	percentile := fitnessPercentile(thresholds, *value, result.LowerIsBetter)
+	result.Percentile = &percentile
+	result.Level = "novice"
+	for i, level := range fitnessLevels {
+		if (result.LowerIsBetter && *value <= thresholds[i]) || (!result.LowerIsBetter && *value >= thresholds[i]) {
+			result.Level = level.name
+		}
+	}
+
+	return result
+}
+
+// fitnessPercentile interpolates linearly between the level anchors. The zero point is 0 for
+// higher-is-better metrics and twice the beginner value for timed ones; results past elite
+// extend at the advanced-to-elite slope and are capped at 99.
+func fitnessPercentile(thresholds []float64, value float64, lowerIsBetter bool) float64 {
+	// Work on a "goodness" scale where larger is always better
+	sign, floor := 1.0, 0.0
+	if lowerIsBetter {
+		sign, floor = -1.0, -2*thresholds[0]
+	}
+	v := sign * value
+
+	points := []float64{floor}
+	percentiles := []float64{0}
+	for i, level := range fitnessLevels {
+		points = append(points, sign*thresholds[i])
+		percentiles = append(percentiles, level.percentile)
+	}
+
+	if v <= points[0] {
+		return 0
+	}
+	for i := 1; i < len(points); i++ {
+		if v <= points[i] {
+			fraction := (v - points[i-1]) / (points[i] - points[i-1])
+			return roundTenth(percentiles[i-1] + fraction*(percentiles[i]-percentiles[i-1]))
+		}
+	}
+
+	last := len(points) - 1
+	slope := (percentiles[last] - percentiles[last-1]) / (points[last] - points[last-1])
+	return math.Min(99, roundTenth(percentiles[last]+slope*(v-points[last])))
+}
+
+// ParseFitnessStandards reads and validates a fitness standards CSV
+func ParseFitnessStandards(csvData io.Reader) ([]*domain.FitnessStandard, error) {
+	reader := csv.NewReader(csvData)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrInvalidFitnessStandards, err)
+	}
+	if !equalStringSlices(header, fitnessStandardsHeader) {
+		return nil, fmt.Errorf("%w: invalid CSV header. Expected: %v, Got: %v", ErrInvalidFitnessStandards, fitnessStandardsHeader, header)
+	}
+
+	var standards []*domain.FitnessStandard
+	rowNumber := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNumber++
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read CSV row %d: %v", ErrInvalidFitnessStandards, rowNumber, err)
+		}
+
+		standard, err := parseFitnessStandardRow(record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidFitnessStandards, rowNumber, err)
+		}
+		standards = append(standards, standard)
+	}
+
+	if len(standards) == 0 {
+		return nil, fmt.Errorf("%w: no standards found", ErrInvalidFitnessStandards)
+	}
+	return standards, nil
+}
+
+func parseFitnessStandardRow(record []string) (*domain.FitnessStandard, error) {
+	for i := range record {
+		record[i] = strings.TrimSpace(record[i])
+	}
+
+	standard := &domain.FitnessStandard{
+		Domain: strings.ToLower(record[0]),
+		Name:   record[1],
+		Kind:   strings.ToLower(record[2]),
+		Metric: strings.ToLower(record[3]),
+		Gender: strings.ToLower(record[5]),
+	}
+
+	if !contains(fitnessDomains, standard.Domain) {
+		return nil, fmt.Errorf("domain must be one of %v", fitnessDomains)
+	}
+	if standard.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	switch standard.Kind {
+	case domain.FitnessKindMovement:
+		if standard.Metric != domain.FitnessMetricE1RM && standard.Metric != domain.FitnessMetricMaxReps && standard.Metric != domain.FitnessMetricTime {
+			return nil, fmt.Errorf("movement metric must be e1rm, max_reps or time")
+		}
+	case domain.FitnessKindWOD:
+		if standard.Metric != domain.FitnessMetricTime && standard.Metric != domain.FitnessMetricRounds {
+			return nil, fmt.Errorf("wod metric must be time or rounds")
+		}
+	default:
+		return nil, fmt.Errorf("kind must be movement or wod")
+	}
+	if standard.Gender != "" && standard.Gender != domain.GenderMale && standard.Gender != domain.GenderFemale {
+		return nil, fmt.Errorf("gender must be male, female or empty")
+	}
+
+	if record[4] != "" {
+		distance, err := strconv.ParseFloat(record[4], 64)
+		if err != nil || distance <= 0 {
+			return nil, fmt.Errorf("distance must be a positive number")
+		}
+		standard.Distance = &distance
+	}
+	if standard.Kind == domain.FitnessKindMovement && standard.Metric == domain.FitnessMetricTime && standard.Distance == nil {
+		return nil, fmt.Errorf("timed movement standards need a distance")
+	}
+
+	thresholds := make([]float64, len(fitnessLevels))
+	for i, level := range fitnessLevels {
+		value, err := strconv.ParseFloat(record[6+i], 64)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("%s must be a positive number", level.name)
+		}
+		thresholds[i] = value
+	}
+	for i := 1; i < len(thresholds); i++ {
+		if (standard.LowerIsBetter() && thresholds[i] >= thresholds[i-1]) || (!standard.LowerIsBetter() && thresholds[i] <= thresholds[i-1]) {
+			return nil, fmt.Errorf("thresholds must improve from beginner to elite")
+		}
+	}
+	standard.Beginner, standard.Intermediate, standard.Advanced, standard.Elite = thresholds[0], thresholds[1], thresholds[2], thresholds[3]
+
+	return standard, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFitnessPercentile(t *testing.T) {
+	lift := []float64{185, 265, 365, 455}
+	tests := []struct {
+		value float64
+		want  float64
+	}{
+		{0, 0},
+		{92.5, 10},
+		{185, 20},
+		{225, 35},
+		{455, 95},
+		{1000, 99},
+	}
+	for _, tt := range tests {
+		if got := fitnessPercentile(lift, tt.value, false); got != tt.want {
+			t.Errorf("fitnessPercentile(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	// Timed: 2k row in seconds, lower is better; twice the beginner time scores 0
+	row := []float64{510, 450, 405, 375}
+	if got := fitnessPercentile(row, 450, true); got != 50 {
+		t.Errorf("expected 7:30 row at the 50th percentile, got %v", got)
+	}
+	if got := fitnessPercentile(row, 1020, true); got != 0 {
+		t.Errorf("expected 0 at twice the beginner time, got %v", got)
+	}
+	if got := fitnessPercentile(row, 370, true); got != 97.5 {
+		t.Errorf("expected past-elite time to extend the elite slope, got %v", got)
+	}
+}
+
+func TestParseFitnessStandards(t *testing.T) {
+	header := `"domain","name","kind","metric","distance","gender","beginner","intermediate","advanced","elite"` + "\n"
+
+	standards, err := ParseFitnessStandards(strings.NewReader(header +
+		`"strength","Back Squat","movement","e1rm","","male","185","265","365","455"` + "\n" +
+		`"monostructural","Row","movement","time","2000","","510","450","405","375"` + "\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(standards) != 2 || standards[1].Distance == nil || *standards[1].Distance != 2000 || !standards[1].LowerIsBetter() {
+		t.Errorf("unexpected standards: %+v", standards)
+	}
+
+	invalid := []string{
+		`"strength","Back Squat","movement","e1rm","","male","265","185","365","455"`, // thresholds out of order
+		`"monostructural","Row","movement","time","","","510","450","405","375"`,      // timed movement without distance
+		`"metcon","Fran","wod","max_reps","","","1","2","3","4"`,                      // metric not valid for WODs
+		`"cardio","Row","movement","time","2000","","510","450","405","375"`,          // unknown domain
+	}
+	for _, row := range invalid {
+		if _, err := ParseFitnessStandards(strings.NewReader(header + row + "\n")); !errors.Is(err, ErrInvalidFitnessStandards) {
+			t.Errorf("expected ErrInvalidFitnessStandards for %s, got %v", row, err)
+		}
+	}
+}
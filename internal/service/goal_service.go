@@ -0,0 +1,585 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/pkg/prmath"
+)
+
+var (
+	ErrGoalNotFound             = errors.New("goal not found")
+	ErrGoalUnauthorized         = errors.New("unauthorized goal access")
+	ErrGoalTitleRequired        = errors.New("goal title is required")
+	ErrInvalidGoalType          = errors.New("goal type must be movement, wod or workout_count")
+	ErrInvalidGoalMetric        = errors.New("metric is not valid for this goal type")
+	ErrInvalidGoalTarget        = errors.New("target value must be positive")
+	ErrGoalRepsPerRoundRequired = errors.New("reps per round is required for Rounds+Reps WOD goals")
+	ErrGoalAlreadyMet           = errors.New("target is already met by results before the start date")
+	ErrInvalidGoalStatus        = errors.New("status must be active or abandoned")
+	ErrGoalAlreadyAchieved      = errors.New("an achieved goal cannot be changed")
+)
+
+// goalHistoryLimit bounds the movement or WOD results loaded to evaluate a goal
+const goalHistoryLimit = 5000
+
+// goalMetrics lists the valid metrics per goal type; the first is the default
+var goalMetrics = map[string][]string{
+	domain.GoalTypeMovement:     {domain.GoalMetricMaxWeight, domain.GoalMetricE1RM, domain.GoalMetricMaxReps},
+	domain.GoalTypeWOD:          {domain.GoalMetricScore},
+	domain.GoalTypeWorkoutCount: {domain.GoalMetricWorkouts},
+}
+
+// GoalProgress is a goal with its evaluated progress and trend projection
+type GoalProgress struct {
+	*domain.Goal
+	TargetName      string   `json:"target_name,omitempty"` // Movement or WOD name
+	ScoreUnit       string   `json:"score_unit"`            // weight, reps, seconds, workouts, or a score type's unit
+	LowerIsBetter   bool     `json:"lower_is_better"`
+	CurrentValue    *float64 `json:"current_value,omitempty"` // Best result (or workout count) since the start date
+	ProgressPercent float64  `json:"progress_percent"`
+	ProjectedDate   *string  `json:"projected_date,omitempty"` // When the trend reaches the target; nil without an improving trend
+	OnTrack         *bool    `json:"on_track,omitempty"`       // Projected date is on or before the target date
+}
+
+// goalResult is one dated result counted towards a goal
+type goalResult struct {
+	date          time.Time
+	userWorkoutID int64
+	value         float64
+}
+
+// GoalService manages personal goals and evaluates them against logged workouts
+type GoalService struct {
+	goalRepo                domain.GoalRepository
+	movementRepo            domain.MovementRepository
+	wodRepo                 domain.WODRepository
+	userWorkoutRepo         domain.UserWorkoutRepository
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository
+	userWorkoutWODRepo      domain.UserWorkoutWODRepository
+	auditLogService         *AuditLogService
+	now                     func() time.Time
+}
+
+// NewGoalService creates a new goal service
+func NewGoalService(
+	goalRepo domain.GoalRepository,
+	movementRepo domain.MovementRepository,
+	wodRepo domain.WODRepository,
+	userWorkoutRepo domain.UserWorkoutRepository,
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository,
+	userWorkoutWODRepo domain.UserWorkoutWODRepository,
+	auditLogService *AuditLogService,
+) *GoalService {
+	return &GoalService{
+		goalRepo:                goalRepo,
+		movementRepo:            movementRepo,
+		wodRepo:                 wodRepo,
+		userWorkoutRepo:         userWorkoutRepo,
+		userWorkoutMovementRepo: userWorkoutMovementRepo,
+		userWorkoutWODRepo:      userWorkoutWODRepo,
+		auditLogService:         auditLogService,
+		now:                     time.Now,
+	}
+}
+
+// CreateGoal validates and saves a new goal, recording the best result before the start date as its baseline.
+// A goal already reached by results since the start date is saved as achieved.
+func (s *GoalService) CreateGoal(userID int64, goal *domain.Goal) (*GoalProgress, error) {
+	goal.UserID = userID
+	goal.Title = strings.TrimSpace(goal.Title)
+	goal.Status = domain.GoalStatusActive
+	goal.BaselineValue = nil
+	goal.AchievedAt = nil
+	goal.AchievedWorkoutID = nil
+	if goal.StartDate == "" {
+		goal.StartDate = s.now().Format(domain.ScheduledDateFormat)
+	}
+	if err := s.validateGoal(goal); err != nil {
+		return nil, err
+	}
+
+	progress, achievedBy, err := s.evaluate(goal)
+	if err != nil {
+		return nil, err
+	}
+	if goal.BaselineValue != nil && progress.meets(*goal.BaselineValue) {
+		return nil, ErrGoalAlreadyMet
+	}
+
+	if err := s.goalRepo.Create(goal); err != nil {
+		return nil, fmt.Errorf("failed to create goal: %w", err)
+	}
+	if achievedBy != nil {
+		if err := s.markAchieved(goal, achievedBy); err != nil {
+			return nil, err
+		}
+		progress.ProgressPercent = 100
+	}
+
+	return progress, nil
+}
+
+// GetGoal returns a user's goal with its current progress
+func (s *GoalService) GetGoal(id, userID int64) (*GoalProgress, error) {
+	goal, err := s.getOwnedGoal(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.progress(goal)
+}
+
+// ListGoals returns a user's goals with progress, optionally filtered by status
+func (s *GoalService) ListGoals(userID int64, status string) ([]*GoalProgress, error) {
+	goals, err := s.goalRepo.ListByUser(userID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		progress, err := s.progress(goal)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, progress)
+	}
+	return result, nil
+}
+
+// UpdateGoal changes a goal's title, target, dates, notes or status (active/abandoned).
+// The goal type, movement, WOD and metric are fixed; achieved goals only accept title and notes.
+func (s *GoalService) UpdateGoal(id, userID int64, update *domain.Goal) (*GoalProgress, error) {
+	goal, err := s.getOwnedGoal(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Title != "" {
+		goal.Title = strings.TrimSpace(update.Title)
+	}
+	if update.Notes != nil {
+		goal.Notes = update.Notes
+	}
+
+	if goal.Status == domain.GoalStatusAchieved {
+		if update.TargetValue != 0 || update.TargetDate != nil || update.RepsPerRound != nil || update.Status != "" {
+			return nil, ErrGoalAlreadyAchieved
+		}
+	} else {
+		if update.TargetValue != 0 {
+			goal.TargetValue = update.TargetValue
+		}
+		if update.TargetDate != nil {
+			goal.TargetDate = update.TargetDate
+			if *goal.TargetDate == "" {
+				goal.TargetDate = nil
+			}
+		}
+		if update.RepsPerRound != nil {
+			goal.RepsPerRound = update.RepsPerRound
+		}
+		if update.Status != "" {
+			if update.Status != domain.GoalStatusActive && update.Status != domain.GoalStatusAbandoned {
+				return nil, ErrInvalidGoalStatus
+			}
+			goal.Status = update.Status
+		}
+	}
+
+	if err := s.validateGoal(goal); err != nil {
+		return nil, err
+	}
+	if err := s.goalRepo.Update(goal); err != nil {
+		return nil, fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	return s.progress(goal)
+}
+
+// DeleteGoal deletes a user's goal
+func (s *GoalService) DeleteGoal(id, userID int64) error {
+	if _, err := s.getOwnedGoal(id, userID); err != nil {
+		return err
+	}
+	return s.goalRepo.Delete(id)
+}
+
+// CheckGoals evaluates a user's active goals and marks any that have been reached as achieved.
+// It runs after every logged workout; the newly achieved goals are returned.
+func (s *GoalService) CheckGoals(userID int64) ([]*domain.Goal, error) {
+	goals, err := s.goalRepo.ListByUser(userID, domain.GoalStatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	var achieved []*domain.Goal
+	for _, goal := range goals {
+		_, achievedBy, err := s.evaluate(goal)
+		if err != nil {
+			return achieved, err
+		}
+		if achievedBy == nil {
+			continue
+		}
+		if err := s.markAchieved(goal, achievedBy); err != nil {
+			return achieved, err
+		}
+		achieved = append(achieved, goal)
+	}
+	return achieved, nil
+}
+
+// progress evaluates a goal without changing it; CheckGoals marks reached goals achieved after a workout is logged
+func (s *GoalService) progress(goal *domain.Goal) (*GoalProgress, error) {
+	progress, _, err := s.evaluate(goal)
+	if err != nil {
+		return nil, err
+	}
+	if goal.Status == domain.GoalStatusAchieved {
+		progress.ProgressPercent = 100
+		progress.ProjectedDate = nil
+		progress.OnTrack = nil
+	}
+	return progress, nil
+}
+
+// markAchieved records the achieving workout and writes a goal_achieved audit event
+func (s *GoalService) markAchieved(goal *domain.Goal, achievedBy *goalResult) error {
+	now := s.now()
+	goal.Status = domain.GoalStatusAchieved
+	goal.AchievedAt = &now
+	goal.AchievedWorkoutID = &achievedBy.userWorkoutID
+	if err := s.goalRepo.Update(goal); err != nil {
+		return fmt.Errorf("failed to mark goal achieved: %w", err)
+	}
+
+	if s.auditLogService != nil {
+		_ = s.auditLogService.LogEvent(domain.EventGoalAchieved, &goal.UserID, &goal.UserID, nil, nil, map[string]interface{}{
+			"goal_id":         goal.ID,
+			"title":           goal.Title,
+			"goal_type":       goal.GoalType,
+			"metric":          goal.Metric,
+			"target_value":    goal.TargetValue,
+			"achieved_value":  achievedBy.value,
+			"user_workout_id": achievedBy.userWorkoutID,
+			"workout_date":    achievedBy.date.Format(domain.ScheduledDateFormat),
+		})
+	}
+	return nil
+}
+
+// evaluate loads the goal's results and computes progress, the trend projection and baseline.
+// achievedBy is the first result on or after the start date that reaches the target.
+func (s *GoalService) evaluate(goal *domain.Goal) (*GoalProgress, *goalResult, error) {
+	progress := &GoalProgress{Goal: goal}
+	results, err := s.loadResults(goal, progress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start, _ := time.Parse(domain.ScheduledDateFormat, goal.StartDate)
+	var baseline *float64
+	var current *float64
+	var achievedBy *goalResult
+	var trend []goalResult
+	for i := range results {
+		r := results[i]
+		if truncateDay(r.date).Before(start) {
+			if baseline == nil || progress.better(r.value, *baseline) {
+				v := r.value
+				baseline = &v
+			}
+			continue
+		}
+		if current == nil || progress.better(r.value, *current) {
+			v := r.value
+			current = &v
+		}
+		trend = append(trend, goalResult{date: truncateDay(r.date), userWorkoutID: r.userWorkoutID, value: *current})
+		if achievedBy == nil && progress.meets(r.value) {
+			achievedBy = &r
+		}
+	}
+
+	if goal.GoalType != domain.GoalTypeWorkoutCount {
+		goal.BaselineValue = baseline
+	}
+	progress.CurrentValue = current
+	progress.ProgressPercent = progress.percent()
+	if achievedBy == nil {
+		progress.ProjectedDate = projectGoalDate(start, baseline, trend, goal.TargetValue, progress.LowerIsBetter)
+		if progress.ProjectedDate != nil && goal.TargetDate != nil {
+			onTrack := *progress.ProjectedDate <= *goal.TargetDate
+			progress.OnTrack = &onTrack
+		}
+	}
+
+	return progress, achievedBy, nil
+}
+
+// loadResults returns the goal's results oldest first and fills in the target name and score unit
+func (s *GoalService) loadResults(goal *domain.Goal, progress *GoalProgress) ([]goalResult, error) {
+	var results []goalResult
+
+	switch goal.GoalType {
+	case domain.GoalTypeMovement:
+		movement, err := s.movementRepo.GetByID(*goal.MovementID)
+		if err != nil {
+			return nil, err
+		}
+		if movement != nil {
+			progress.TargetName = movement.Name
+		}
+		progress.ScoreUnit = "weight"
+		if goal.Metric == domain.GoalMetricMaxReps {
+			progress.ScoreUnit = "reps"
+		}
+
+		history, err := s.userWorkoutMovementRepo.GetByUserIDAndMovementID(goal.UserID, *goal.MovementID, goalHistoryLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get movement history: %w", err)
+		}
+		for _, perf := range history {
+			var value float64
+			switch goal.Metric {
+			case domain.GoalMetricMaxReps:
+				if perf.Reps == nil {
+					continue
+				}
+				value = float64(*perf.Reps)
+			case domain.GoalMetricE1RM:
+				if perf.Weight == nil || perf.Reps == nil {
+					continue
+				}
+				value, _ = prmath.Calculate1RM(*perf.Weight, *perf.Reps)
+				value = roundTenth(value)
+			default:
+				if perf.Weight == nil {
+					continue
+				}
+				value = *perf.Weight
+			}
+			results = append(results, goalResult{date: perf.WorkoutDate, userWorkoutID: perf.UserWorkoutID, value: value})
+		}
+
+	case domain.GoalTypeWOD:
+		wod, err := s.wodRepo.GetByID(*goal.WODID)
+		if err != nil {
+			return nil, err
+		}
+		scoreType := ""
+		if wod != nil {
+			progress.TargetName = wod.Name
+			scoreType = wod.ScoreType
+		}
+		progress.ScoreUnit, progress.LowerIsBetter = benchmarkScoreUnit(scoreType)
+
+		history, err := s.userWorkoutWODRepo.GetByUserIDAndWODID(goal.UserID, *goal.WODID, goalHistoryLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get WOD history: %w", err)
+		}
+		for _, result := range history {
+			if score := benchmarkScore(scoreType, result, goal.RepsPerRound); score != nil {
+				results = append(results, goalResult{date: result.WorkoutDate, userWorkoutID: result.UserWorkoutID, value: *score})
+			}
+		}
+
+	case domain.GoalTypeWorkoutCount:
+		progress.ScoreUnit = "workouts"
+		start, _ := time.Parse(domain.ScheduledDateFormat, goal.StartDate)
+		end := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+		if goal.TargetDate != nil {
+			end, _ = time.Parse(domain.ScheduledDateFormat, *goal.TargetDate)
+		}
+		workouts, err := s.userWorkoutRepo.ListByUserAndDateRange(goal.UserID, start, end.Add(24*time.Hour-time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(workouts, func(i, j int) bool {
+			if !workouts[i].WorkoutDate.Equal(workouts[j].WorkoutDate) {
+				return workouts[i].WorkoutDate.Before(workouts[j].WorkoutDate)
+			}
+			return workouts[i].ID < workouts[j].ID
+		})
+		// The value is the running count, so the Nth workout reaches a target of N
+		for i, w := range workouts {
+			results = append(results, goalResult{date: w.WorkoutDate, userWorkoutID: w.ID, value: float64(i + 1)})
+		}
+		return results, nil
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].date.Before(results[j].date) })
+	return results, nil
+}
+
+func (p *GoalProgress) better(a, b float64) bool {
+	if p.LowerIsBetter {
+		return a < b
+	}
+	return a > b
+}
+
+func (p *GoalProgress) meets(v float64) bool {
+	if p.LowerIsBetter {
+		return v <= p.TargetValue
+	}
+	return v >= p.TargetValue
+}
+
+// percent measures progress from the baseline to the target, or towards the target from zero without a usable baseline
+func (p *GoalProgress) percent() float64 {
+	best := p.CurrentValue
+	if best == nil || (p.BaselineValue != nil && p.better(*p.BaselineValue, *best)) {
+		best = p.BaselineValue
+	}
+	if best == nil || *best <= 0 {
+		return 0
+	}
+	if p.meets(*best) {
+		return 100
+	}
+
+	target := p.TargetValue
+	var pct float64
+	switch {
+	case p.BaselineValue != nil && *p.BaselineValue != target:
+		pct = (*best - *p.BaselineValue) / (target - *p.BaselineValue) * 100
+	case p.LowerIsBetter:
+		pct = target / *best * 100
+	default:
+		pct = *best / target * 100
+	}
+	return roundTenth(math.Max(0, math.Min(100, pct)))
+}
+
+// projectGoalDate fits a least-squares line through the running best since the start date (plus the baseline at
+// the start date) and extends it from the latest result to the target. Nil without an improving trend.
+func projectGoalDate(start time.Time, baseline *float64, trend []goalResult, target float64, lowerIsBetter bool) *string {
+	points := trend
+	if baseline != nil {
+		points = append([]goalResult{{date: start, value: *baseline}}, trend...)
+	}
+	if len(points) < 2 || !points[len(points)-1].date.After(points[0].date) {
+		return nil
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	n := float64(len(points))
+	for _, p := range points {
+		x := float64(daysBetween(points[0].date, p.date))
+		sumX += x
+		sumY += p.value
+		sumXY += x * p.value
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return nil
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	if (lowerIsBetter && slope >= 0) || (!lowerIsBetter && slope <= 0) {
+		return nil
+	}
+
+	last := points[len(points)-1]
+	days := int(math.Ceil((target - last.value) / slope))
+	projected := last.date.AddDate(0, 0, days).Format(domain.ScheduledDateFormat)
+	return &projected
+}
+
+func (s *GoalService) validateGoal(goal *domain.Goal) error {
+	if goal.Title == "" {
+		return ErrGoalTitleRequired
+	}
+	metrics, ok := goalMetrics[goal.GoalType]
+	if !ok {
+		return ErrInvalidGoalType
+	}
+	if goal.Metric == "" {
+		goal.Metric = metrics[0]
+	}
+	validMetric := false
+	for _, m := range metrics {
+		if goal.Metric == m {
+			validMetric = true
+		}
+	}
+	if !validMetric {
+		return ErrInvalidGoalMetric
+	}
+	if goal.TargetValue <= 0 || math.IsNaN(goal.TargetValue) || math.IsInf(goal.TargetValue, 0) {
+		return ErrInvalidGoalTarget
+	}
+
+	start, err := time.Parse(domain.ScheduledDateFormat, goal.StartDate)
+	if err != nil {
+		return ErrInvalidScheduleDate
+	}
+	if goal.TargetDate != nil {
+		target, err := time.Parse(domain.ScheduledDateFormat, *goal.TargetDate)
+		if err != nil {
+			return ErrInvalidScheduleDate
+		}
+		if target.Before(start) {
+			return ErrInvalidScheduleRange
+		}
+	}
+
+	switch goal.GoalType {
+	case domain.GoalTypeMovement:
+		goal.WODID, goal.RepsPerRound = nil, nil
+		if goal.MovementID == nil {
+			return ErrMovementNotFound
+		}
+		movement, err := s.movementRepo.GetByID(*goal.MovementID)
+		if err != nil {
+			return err
+		}
+		if movement == nil || (!movement.IsStandard && (movement.CreatedBy == nil || *movement.CreatedBy != goal.UserID)) {
+			return ErrMovementNotFound
+		}
+	case domain.GoalTypeWOD:
+		goal.MovementID = nil
+		if goal.WODID == nil {
+			return ErrWODNotFound
+		}
+		wod, err := s.wodRepo.GetByID(*goal.WODID)
+		if err != nil {
+			return err
+		}
+		if wod == nil || (!wod.IsStandard && (wod.CreatedBy == nil || *wod.CreatedBy != goal.UserID)) {
+			return ErrWODNotFound
+		}
+		if wod.ScoreType == "Rounds+Reps" {
+			if goal.RepsPerRound == nil || *goal.RepsPerRound <= 0 {
+				return ErrGoalRepsPerRoundRequired
+			}
+		} else {
+			goal.RepsPerRound = nil
+		}
+	default:
+		goal.MovementID, goal.WODID, goal.RepsPerRound = nil, nil, nil
+	}
+
+	return nil
+}
+
+func (s *GoalService) getOwnedGoal(id, userID int64) (*domain.Goal, error) {
+	goal, err := s.goalRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, ErrGoalNotFound
+	}
+	if goal.UserID != userID {
+		return nil, ErrGoalUnauthorized
+	}
+	return goal, nil
+}
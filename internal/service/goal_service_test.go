@@ -0,0 +1,181 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+type mockGoalRepo struct {
+	goals  map[int64]*domain.Goal
+	nextID int64
+}
+
+func (m *mockGoalRepo) Create(goal *domain.Goal) error {
+	m.nextID++
+	goal.ID = m.nextID
+	m.goals[goal.ID] = goal
+	return nil
+}
+
+func (m *mockGoalRepo) GetByID(id int64) (*domain.Goal, error) {
+	return m.goals[id], nil
+}
+
+func (m *mockGoalRepo) ListByUser(userID int64, status string) ([]*domain.Goal, error) {
+	var result []*domain.Goal
+	for _, g := range m.goals {
+		if g.UserID == userID && (status == "" || g.Status == status) {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockGoalRepo) Update(goal *domain.Goal) error {
+	m.goals[goal.ID] = goal
+	return nil
+}
+
+func (m *mockGoalRepo) Delete(id int64) error {
+	delete(m.goals, id)
+	return nil
+}
+
+func TestGoalService_WorkoutCountGoal(t *testing.T) {
+	userWorkoutRepo := newMockUserWorkoutRepo()
+	goalRepo := &mockGoalRepo{goals: make(map[int64]*domain.Goal)}
+	service := NewGoalService(goalRepo, nil, nil, userWorkoutRepo, nil, nil, nil)
+	service.now = func() time.Time { return time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC) }
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 18, 0, 0, 0, time.UTC) }
+	_ = userWorkoutRepo.Create(&domain.UserWorkout{UserID: 1, WorkoutDate: time.Date(2025, 12, 31, 18, 0, 0, 0, time.UTC)}) // before the start date
+	for _, d := range []int{2, 4, 6, 8} {
+		_ = userWorkoutRepo.Create(&domain.UserWorkout{UserID: 1, WorkoutDate: day(d)})
+	}
+
+	targetDate := "2026-01-31"
+	progress, err := service.CreateGoal(1, &domain.Goal{
+		Title:       "10 workouts in January",
+		GoalType:    domain.GoalTypeWorkoutCount,
+		TargetValue: 10,
+		StartDate:   "2026-01-01",
+		TargetDate:  &targetDate,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if progress.Metric != domain.GoalMetricWorkouts || progress.CurrentValue == nil || *progress.CurrentValue != 4 || progress.ProgressPercent != 40 {
+		t.Errorf("unexpected progress: metric=%s current=%v percent=%v", progress.Metric, progress.CurrentValue, progress.ProgressPercent)
+	}
+	// One workout every two days: the 10th lands on Jan 20
+	if progress.ProjectedDate == nil || *progress.ProjectedDate != "2026-01-20" || progress.OnTrack == nil || !*progress.OnTrack {
+		t.Errorf("unexpected projection: %v on_track=%v", progress.ProjectedDate, progress.OnTrack)
+	}
+
+	var lastID int64
+	for d := 9; d <= 14; d++ {
+		uw := &domain.UserWorkout{UserID: 1, WorkoutDate: day(d)}
+		_ = userWorkoutRepo.Create(uw)
+		lastID = uw.ID
+	}
+	achieved, err := service.CheckGoals(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(achieved) != 1 || achieved[0].Status != domain.GoalStatusAchieved {
+		t.Fatalf("expected the goal to be achieved, got %+v", achieved)
+	}
+	// The 10th workout in the window (Jan 14) reached the target
+	if achieved[0].AchievedWorkoutID == nil || *achieved[0].AchievedWorkoutID != lastID {
+		t.Errorf("expected achieving workout %d, got %v", lastID, achieved[0].AchievedWorkoutID)
+	}
+
+	if achieved, _ := service.CheckGoals(1); len(achieved) != 0 {
+		t.Errorf("expected an achieved goal not to be re-evaluated, got %d", len(achieved))
+	}
+}
+
+func TestGoalService_ReadsDoNotMarkAchieved(t *testing.T) {
+	userWorkoutRepo := newMockUserWorkoutRepo()
+	goalRepo := &mockGoalRepo{goals: make(map[int64]*domain.Goal)}
+	service := NewGoalService(goalRepo, nil, nil, userWorkoutRepo, nil, nil, nil)
+	service.now = func() time.Time { return time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC) }
+
+	progress, err := service.CreateGoal(1, &domain.Goal{
+		Title:       "3 workouts",
+		GoalType:    domain.GoalTypeWorkoutCount,
+		TargetValue: 3,
+		StartDate:   "2026-01-01",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for d := 2; d <= 4; d++ {
+		_ = userWorkoutRepo.Create(&domain.UserWorkout{UserID: 1, WorkoutDate: time.Date(2026, 1, d, 18, 0, 0, 0, time.UTC)})
+	}
+
+	got, err := service.GetGoal(progress.Goal.ID, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ProgressPercent != 100 {
+		t.Errorf("expected the reached goal to report 100%%, got %v", got.ProgressPercent)
+	}
+	if _, err := service.ListGoals(1, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored := goalRepo.goals[progress.Goal.ID]; stored.Status != domain.GoalStatusActive || stored.AchievedWorkoutID != nil {
+		t.Fatalf("expected reads to leave the goal active, got status %s", stored.Status)
+	}
+
+	if achieved, _ := service.CheckGoals(1); len(achieved) != 1 {
+		t.Errorf("expected CheckGoals to mark the goal achieved, got %d", len(achieved))
+	}
+}
+
+func TestGoalProgressPercent(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name     string
+		progress GoalProgress
+		want     float64
+	}{
+		{"lift from baseline", GoalProgress{Goal: &domain.Goal{TargetValue: 315, BaselineValue: value(275)}, CurrentValue: value(295)}, 50},
+		{"lift without baseline", GoalProgress{Goal: &domain.Goal{TargetValue: 200}, CurrentValue: value(150)}, 75},
+		{"time from baseline", GoalProgress{Goal: &domain.Goal{TargetValue: 240, BaselineValue: value(300)}, CurrentValue: value(270), LowerIsBetter: true}, 50},
+		{"time without baseline", GoalProgress{Goal: &domain.Goal{TargetValue: 240}, CurrentValue: value(300), LowerIsBetter: true}, 80},
+		{"worse than baseline", GoalProgress{Goal: &domain.Goal{TargetValue: 315, BaselineValue: value(275)}, CurrentValue: value(255)}, 0},
+		{"target met", GoalProgress{Goal: &domain.Goal{TargetValue: 240}, CurrentValue: value(238), LowerIsBetter: true}, 100},
+		{"no results", GoalProgress{Goal: &domain.Goal{TargetValue: 100}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.progress.percent(); got != tt.want {
+				t.Errorf("percent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectGoalDate(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	baseline := 300.0
+	// Fran improving 10 seconds a week from a 5:00 baseline
+	trend := []goalResult{
+		{date: start.AddDate(0, 0, 7), value: 290},
+		{date: start.AddDate(0, 0, 14), value: 280},
+	}
+	got := projectGoalDate(start, &baseline, trend, 240, true)
+	if got == nil || *got != "2026-02-12" {
+		t.Errorf("projectGoalDate = %v, want 2026-02-12", got)
+	}
+
+	if got := projectGoalDate(start, &baseline, trend, 240, false); got != nil {
+		t.Errorf("expected no projection when the trend moves away from the target, got %s", *got)
+	}
+	if got := projectGoalDate(start, nil, trend[:1], 240, true); got != nil {
+		t.Errorf("expected no projection from a single result, got %s", *got)
+	}
+}
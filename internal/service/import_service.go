@@ -19,6 +19,11 @@ type ImportService struct {
 	userWorkoutRepo         domain.UserWorkoutRepository
 	userWorkoutMovementRepo domain.UserWorkoutMovementRepository
 	userWorkoutWODRepo      domain.UserWorkoutWODRepository
+	aliasService            *AliasService
+	searchService           *SearchService
+	enumerationService      *EnumerationService
+	wodVersionService       *WODVersionService
+	structureService        *WODStructureService
 }
 
 // NewImportService creates a new import service
@@ -40,6 +45,52 @@ func NewImportService(
 	}
 }
 
+// SetAliasService lets workout imports resolve movement and WOD names through aliases
+func (s *ImportService) SetAliasService(aliasService *AliasService) {
+	s.aliasService = aliasService
+}
+
+// SetSearchService re-indexes search after imports that create or update records
+func (s *ImportService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
+// SetEnumerationService validates imported sources, types, regimes and movement types against the
+// admin-managed values instead of domain.DefaultEnumerations
+func (s *ImportService) SetEnumerationService(enumerationService *EnumerationService) {
+	s.enumerationService = enumerationService
+}
+
+// SetWODVersionService records a version when an import changes an existing WOD's definition
+func (s *ImportService) SetWODVersionService(wodVersionService *WODVersionService) {
+	s.wodVersionService = wodVersionService
+}
+
+// SetStructureService parses the descriptions of WODs an import creates or changes
+func (s *ImportService) SetStructureService(structureService *WODStructureService) {
+	s.structureService = structureService
+}
+
+// loadEnumerations reads the active values of the given categories once per import
+func (s *ImportService) loadEnumerations(categories ...string) (map[string][]string, error) {
+	allowed := make(map[string][]string, len(categories))
+	for _, category := range categories {
+		values, err := enumerationValues(s.enumerationService, category)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load allowed %s values: %w", category, err)
+		}
+		allowed[category] = values
+	}
+	return allowed, nil
+}
+
+// refreshSearch re-indexes everything when an import changed any records
+func (s *ImportService) refreshSearch(changed int) {
+	if s.searchService != nil && changed > 0 {
+		s.searchService.RefreshAll()
+	}
+}
+
 // WODImportResult represents the result of a WOD import operation
 type WODImportResult struct {
 	TotalRows      int                    `json:"total_rows"`
@@ -92,19 +143,17 @@ type MovementImportRow struct {
 	Description    string   `json:"description"`
 	IsStandard     bool     `json:"is_standard"`
 	CreatedByEmail string   `json:"created_by_email"`
+	Equipment      []string `json:"equipment,omitempty"`
+	Pattern        string   `json:"pattern,omitempty"`
+	MuscleGroups   []string `json:"muscle_groups,omitempty"`
+	Parent         string   `json:"parent,omitempty"` // Parent movement name, in the file or already stored
 	IsValid        bool     `json:"is_valid"`
 	IsDuplicate    bool     `json:"is_duplicate"`
 	Errors         []string `json:"errors,omitempty"`
 }
 
-// Valid enum values for WODs
-var (
-	validSources    = []string{"CrossFit", "Other Coach", "Self-recorded"}
-	validTypes      = []string{"Benchmark", "Hero", "Girl", "Notables", "Games", "Endurance", "Self-created"}
-	validRegimes    = []string{"EMOM", "AMRAP", "Fastest Time", "Slowest Round", "Get Stronger", "Skills"}
-	validScoreTypes = []string{"Time (HH:MM:SS)", "Rounds+Reps", "Max Weight"}
-	validMovementTypes = []string{"weightlifting", "bodyweight", "cardio", "gymnastics"}
-)
+// Valid score types for WODs; source, type, regime and movement type values are admin-managed enumerations
+var validScoreTypes = domain.ScoreTypeNames()
 
 // PreviewWODImport validates and previews WOD CSV data without saving
 func (s *ImportService) PreviewWODImport(csvData io.Reader, userID int64, isAdmin bool) (*WODImportResult, error) {
@@ -122,6 +171,11 @@ func (s *ImportService) PreviewWODImport(csvData io.Reader, userID int64, isAdmi
 		return nil, fmt.Errorf("invalid CSV header. Expected: %v, Got: %v", expectedHeader, header)
 	}
 
+	allowed, err := s.loadEnumerations(domain.EnumerationWODSource, domain.EnumerationWODType, domain.EnumerationWODRegime)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &WODImportResult{
 		Rows: []WODImportRow{},
 	}
@@ -139,14 +193,15 @@ func (s *ImportService) PreviewWODImport(csvData io.Reader, userID int64, isAdmi
 		rowNumber++
 		row := s.parseWODRow(record, rowNumber)
 
-		// Validate the row
-		s.validateWODRow(&row, userID, isAdmin)
-
 		// Check for duplicate by name
 		existingWOD, err := s.wodRepo.GetByName(row.Name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check for duplicate WOD: %w", err)
 		}
+
+		// Validate the row
+		s.validateWODRow(&row, userID, isAdmin, allowed, existingWOD)
+
 		if existingWOD != nil {
 			row.IsDuplicate = true
 			result.DuplicateRows++
@@ -193,6 +248,7 @@ func (s *ImportService) ConfirmWODImport(csvData io.Reader, userID int64, isAdmi
 				}
 
 				// Update fields
+				before := *existingWOD
 				existingWOD.Source = row.Source
 				existingWOD.Type = row.Type
 				existingWOD.Regime = row.Regime
@@ -205,9 +261,11 @@ func (s *ImportService) ConfirmWODImport(csvData io.Reader, userID int64, isAdmi
 					existingWOD.Notes = &row.Notes
 				}
 
-				// Use appropriate update method based on whether WOD is standard
+				// A definition change is saved with its new version, or not at all
 				var updateErr error
-				if existingWOD.IsStandard {
+				if s.wodVersionService != nil {
+					_, updateErr = s.wodVersionService.SaveEdit(&before, existingWOD, userID)
+				} else if existingWOD.IsStandard {
 					updateErr = s.wodRepo.UpdateStandard(existingWOD)
 				} else {
 					updateErr = s.wodRepo.Update(existingWOD)
@@ -215,6 +273,9 @@ func (s *ImportService) ConfirmWODImport(csvData io.Reader, userID int64, isAdmi
 				if updateErr != nil {
 					return nil, fmt.Errorf("failed to update WOD: %w", updateErr)
 				}
+				if before.Description != existingWOD.Description {
+					refreshWODStructure(s.structureService, existingWOD)
+				}
 				preview.UpdatedCount++
 			} else {
 				preview.SkippedCount++
@@ -257,8 +318,10 @@ func (s *ImportService) ConfirmWODImport(csvData io.Reader, userID int64, isAdmi
 		if err := s.wodRepo.Create(wod); err != nil {
 			return nil, fmt.Errorf("failed to create WOD: %w", err)
 		}
+		refreshWODStructure(s.structureService, wod)
 		preview.CreatedCount++
 	}
+	s.refreshSearch(preview.CreatedCount + preview.UpdatedCount)
 
 	return preview, nil
 }
@@ -273,10 +336,16 @@ func (s *ImportService) PreviewMovementImport(csvData io.Reader, userID int64, i
 		return nil, fmt.Errorf("failed to read CSV header: %w", err)
 	}
 
-	// Validate header
+	// Validate header; the taxonomy columns are optional
 	expectedHeader := []string{"name", "type", "description", "is_standard", "created_by_email"}
-	if !equalStringSlices(header, expectedHeader) {
-		return nil, fmt.Errorf("invalid CSV header. Expected: %v, Got: %v", expectedHeader, header)
+	extendedHeader := append(append([]string{}, expectedHeader...), movementTaxonomyColumns...)
+	if !equalStringSlices(header, expectedHeader) && !equalStringSlices(header, extendedHeader) {
+		return nil, fmt.Errorf("invalid CSV header. Expected: %v or %v, Got: %v", expectedHeader, extendedHeader, header)
+	}
+
+	allowed, err := s.loadEnumerations(domain.EnumerationMovementType)
+	if err != nil {
+		return nil, err
 	}
 
 	result := &MovementImportResult{
@@ -296,14 +365,15 @@ func (s *ImportService) PreviewMovementImport(csvData io.Reader, userID int64, i
 		rowNumber++
 		row := s.parseMovementRow(record, rowNumber)
 
-		// Validate the row
-		s.validateMovementRow(&row, userID, isAdmin)
-
 		// Check for duplicate by name
 		existingMovement, err := s.movementRepo.GetByName(row.Name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check for duplicate movement: %w", err)
 		}
+
+		// Validate the row
+		s.validateMovementRow(&row, userID, isAdmin, allowed, existingMovement)
+
 		if existingMovement != nil {
 			row.IsDuplicate = true
 			result.DuplicateRows++
@@ -319,9 +389,60 @@ func (s *ImportService) PreviewMovementImport(csvData io.Reader, userID int64, i
 		result.TotalRows++
 	}
 
+	if err := s.validateMovementParents(result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
+// validateMovementParents checks that each row's parent is a movement in the file or already stored,
+// and that the parent is not itself a variant
+func (s *ImportService) validateMovementParents(result *MovementImportResult) error {
+	fileParents := make(map[string]string, len(result.Rows))
+	for _, row := range result.Rows {
+		fileParents[row.Name] = row.Parent
+	}
+
+	for i := range result.Rows {
+		row := &result.Rows[i]
+		if row.Parent == "" {
+			continue
+		}
+
+		var problem string
+		if grandparent, inFile := fileParents[row.Parent]; inFile {
+			if grandparent != "" {
+				problem = fmt.Sprintf("parent %s is itself a variant", row.Parent)
+			}
+		} else {
+			parent, err := s.movementRepo.GetByName(row.Parent)
+			if err != nil {
+				return fmt.Errorf("failed to check parent movement: %w", err)
+			}
+			switch {
+			case parent == nil:
+				problem = fmt.Sprintf("parent movement not found: %s", row.Parent)
+			case parent.ParentID != nil:
+				problem = fmt.Sprintf("parent %s is itself a variant", row.Parent)
+			}
+		}
+		if row.Parent == row.Name {
+			problem = "a movement cannot be its own parent"
+		}
+
+		if problem != "" {
+			row.Errors = append(row.Errors, problem)
+			if row.IsValid {
+				row.IsValid = false
+				result.ValidRows--
+				result.InvalidRows++
+			}
+		}
+	}
+	return nil
+}
+
 // ConfirmMovementImport actually imports movement data after preview
 func (s *ImportService) ConfirmMovementImport(csvData io.Reader, userID int64, isAdmin bool, skipDuplicates, updateDuplicates bool) (*MovementImportResult, error) {
 	// First, run preview to validate
@@ -331,6 +452,7 @@ func (s *ImportService) ConfirmMovementImport(csvData io.Reader, userID int64, i
 	}
 
 	// Process each valid row
+	imported := make(map[string]MovementImportRow)
 	for _, row := range preview.Rows {
 		if !row.IsValid {
 			preview.SkippedCount++
@@ -352,11 +474,15 @@ func (s *ImportService) ConfirmMovementImport(csvData io.Reader, userID int64, i
 				// Update fields
 				existingMovement.Type = domain.MovementType(row.Type)
 				existingMovement.Description = row.Description
+				existingMovement.Equipment = row.Equipment
+				existingMovement.Pattern = row.Pattern
+				existingMovement.MuscleGroups = row.MuscleGroups
 
 				if err := s.movementRepo.Update(existingMovement); err != nil {
 					return nil, fmt.Errorf("failed to update movement: %w", err)
 				}
 				preview.UpdatedCount++
+				imported[row.Name] = row
 			} else {
 				preview.SkippedCount++
 			}
@@ -365,10 +491,13 @@ func (s *ImportService) ConfirmMovementImport(csvData io.Reader, userID int64, i
 
 		// Create new movement
 		movement := &domain.Movement{
-			Name:        row.Name,
-			Type:        domain.MovementType(row.Type),
-			Description: row.Description,
-			IsStandard:  row.IsStandard,
+			Name:         row.Name,
+			Type:         domain.MovementType(row.Type),
+			Description:  row.Description,
+			IsStandard:   row.IsStandard,
+			Equipment:    row.Equipment,
+			Pattern:      row.Pattern,
+			MuscleGroups: row.MuscleGroups,
 		}
 
 		// Handle created_by
@@ -389,8 +518,32 @@ func (s *ImportService) ConfirmMovementImport(csvData io.Reader, userID int64, i
 			return nil, fmt.Errorf("failed to create movement: %w", err)
 		}
 		preview.CreatedCount++
+		imported[row.Name] = row
 	}
 
+	// Link variants once every movement of the file exists
+	for name, row := range imported {
+		if row.Parent == "" {
+			continue
+		}
+		movement, err := s.movementRepo.GetByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch imported movement: %w", err)
+		}
+		parent, err := s.movementRepo.GetByName(row.Parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch parent movement: %w", err)
+		}
+		if movement == nil || parent == nil {
+			continue
+		}
+		movement.ParentID = &parent.ID
+		if err := s.movementRepo.UpdateTaxonomy(movement); err != nil {
+			return nil, fmt.Errorf("failed to link movement variant: %w", err)
+		}
+	}
+	s.refreshSearch(preview.CreatedCount + preview.UpdatedCount)
+
 	return preview, nil
 }
 
@@ -434,10 +587,20 @@ func (s *ImportService) parseMovementRow(record []string, rowNumber int) Movemen
 	isStandard := strings.ToLower(strings.TrimSpace(record[3]))
 	row.IsStandard = isStandard == "true" || isStandard == "1"
 
+	// Optional taxonomy columns
+	if len(record) >= 9 {
+		row.Equipment = splitTaxonomyList(record[5])
+		row.Pattern = strings.TrimSpace(record[6])
+		row.MuscleGroups = splitTaxonomyList(record[7])
+		row.Parent = strings.TrimSpace(record[8])
+	}
+
 	return row
 }
 
-func (s *ImportService) validateWODRow(row *WODImportRow, userID int64, isAdmin bool) {
+// validateWODRow checks a row's fields; source, type and regime are matched ignoring case against the
+// allowed values, and a duplicate's current values are accepted even when deprecated
+func (s *ImportService) validateWODRow(row *WODImportRow, userID int64, isAdmin bool, allowed map[string][]string, existing *domain.WOD) {
 	// Validate required fields
 	if row.Name == "" {
 		row.Errors = append(row.Errors, "name is required")
@@ -461,17 +624,30 @@ func (s *ImportService) validateWODRow(row *WODImportRow, userID int64, isAdmin
 	}
 
 	// Validate enum values
-	if !contains(validSources, row.Source) {
-		row.Errors = append(row.Errors, fmt.Sprintf("invalid source: %s (must be one of: %v)", row.Source, validSources))
-		row.IsValid = false
-	}
-	if !contains(validTypes, row.Type) {
-		row.Errors = append(row.Errors, fmt.Sprintf("invalid type: %s (must be one of: %v)", row.Type, validTypes))
-		row.IsValid = false
+	var previous domain.WOD
+	if existing != nil {
+		previous = *existing
 	}
-	if !contains(validRegimes, row.Regime) {
-		row.Errors = append(row.Errors, fmt.Sprintf("invalid regime: %s (must be one of: %v)", row.Regime, validRegimes))
-		row.IsValid = false
+	for _, field := range []struct {
+		category string
+		name     string
+		value    *string
+		previous string
+	}{
+		{domain.EnumerationWODSource, "source", &row.Source, previous.Source},
+		{domain.EnumerationWODType, "type", &row.Type, previous.Type},
+		{domain.EnumerationWODRegime, "regime", &row.Regime, previous.Regime},
+	} {
+		if *field.value == "" {
+			continue
+		}
+		value, err := resolveEnumeration(allowed[field.category], field.name, *field.value, field.previous)
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid %s: %s (must be one of: %v)", field.name, *field.value, allowed[field.category]))
+			row.IsValid = false
+			continue
+		}
+		*field.value = value
 	}
 	if !contains(validScoreTypes, row.ScoreType) {
 		row.Errors = append(row.Errors, fmt.Sprintf("invalid score_type: %s (must be one of: %v)", row.ScoreType, validScoreTypes))
@@ -485,7 +661,9 @@ func (s *ImportService) validateWODRow(row *WODImportRow, userID int64, isAdmin
 	}
 }
 
-func (s *ImportService) validateMovementRow(row *MovementImportRow, userID int64, isAdmin bool) {
+// validateMovementRow checks a row's fields; the type is matched ignoring case against the allowed
+// movement types, and a duplicate's current type is accepted even when deprecated
+func (s *ImportService) validateMovementRow(row *MovementImportRow, userID int64, isAdmin bool, allowed map[string][]string, existing *domain.Movement) {
 	// Validate required fields
 	if row.Name == "" {
 		row.Errors = append(row.Errors, "name is required")
@@ -497,9 +675,18 @@ func (s *ImportService) validateMovementRow(row *MovementImportRow, userID int64
 	}
 
 	// Validate enum values
-	if !contains(validMovementTypes, row.Type) {
-		row.Errors = append(row.Errors, fmt.Sprintf("invalid type: %s (must be one of: %v)", row.Type, validMovementTypes))
-		row.IsValid = false
+	var previous string
+	if existing != nil {
+		previous = string(existing.Type)
+	}
+	if row.Type != "" {
+		movementTypes := allowed[domain.EnumerationMovementType]
+		if movementType, err := resolveEnumeration(movementTypes, "type", row.Type, previous); err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid type: %s (must be one of: %v)", row.Type, movementTypes))
+			row.IsValid = false
+		} else {
+			row.Type = movementType
+		}
 	}
 
 	// Check permissions for standard movements
@@ -507,6 +694,22 @@ func (s *ImportService) validateMovementRow(row *MovementImportRow, userID int64
 		row.Errors = append(row.Errors, "only admins can import standard movements")
 		row.IsValid = false
 	}
+
+	// Validate taxonomy values
+	var err error
+	if row.Equipment, err = normalizeTaxonomyValues("equipment", row.Equipment, domain.MovementEquipment); err != nil {
+		row.Errors = append(row.Errors, err.Error())
+		row.IsValid = false
+	}
+	if row.MuscleGroups, err = normalizeTaxonomyValues("muscle group", row.MuscleGroups, domain.MuscleGroups); err != nil {
+		row.Errors = append(row.Errors, err.Error())
+		row.IsValid = false
+	}
+	row.Pattern = strings.ToLower(row.Pattern)
+	if row.Pattern != "" && !contains(domain.MovementPatterns, row.Pattern) {
+		row.Errors = append(row.Errors, fmt.Sprintf("invalid pattern: %s (must be one of: %v)", row.Pattern, domain.MovementPatterns))
+		row.IsValid = false
+	}
 }
 
 func equalStringSlices(a, b []string) bool {
@@ -542,6 +745,7 @@ type UserWorkoutImportResult struct {
 	MovementsCreated int      `json:"movements_created"`
 	WODsCreated      int      `json:"wods_created"`
 	Errors           []string `json:"errors,omitempty"`
+	Suggestions      []*domain.NameSuggestion `json:"suggestions,omitempty"` // Close matches for movements and WODs that will be created
 }
 
 // PreviewUserWorkoutImport validates and previews user workout JSON import
@@ -573,6 +777,12 @@ func (s *ImportService) PreviewUserWorkoutImport(jsonData []byte, userID int64)
 		Errors:        []string{},
 	}
 
+	catalog, err := loadNameCatalog(s.movementRepo, s.wodRepo, s.aliasService, userID)
+	if err != nil {
+		return nil, err
+	}
+	suggested := make(map[string]bool)
+
 	// Validate each workout
 	for _, workout := range exportData.UserWorkouts {
 		// Parse workout date
@@ -596,14 +806,14 @@ func (s *ImportService) PreviewUserWorkoutImport(jsonData []byte, userID int64)
 				continue
 			}
 
-			// Check if movement exists
-			existingMovement, err := s.movementRepo.GetByName(movementName)
-			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Error checking movement %s: %v", movementName, err))
-				continue
-			}
-			if existingMovement == nil {
+			// Check if movement exists, by normalized name or alias
+			if _, ok := catalog.movements.Resolve(movementName); !ok {
 				result.Errors = append(result.Errors, fmt.Sprintf("Movement not found: %s (will be created)", movementName))
+				key := domain.AliasEntityMovement + ":" + domain.NormalizeName(movementName)
+				if suggestion := catalog.movements.Suggestion(movementName, 3); suggestion != nil && !suggested[key] {
+					suggested[key] = true
+					result.Suggestions = append(result.Suggestions, suggestion)
+				}
 			}
 		}
 
@@ -616,14 +826,14 @@ func (s *ImportService) PreviewUserWorkoutImport(jsonData []byte, userID int64)
 				continue
 			}
 
-			// Check if WOD exists
-			existingWOD, err := s.wodRepo.GetByName(wodName)
-			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Error checking WOD %s: %v", wodName, err))
-				continue
-			}
-			if existingWOD == nil {
+			// Check if WOD exists, by normalized name or alias
+			if _, ok := catalog.wods.Resolve(wodName); !ok {
 				result.Errors = append(result.Errors, fmt.Sprintf("WOD not found: %s (will be created)", wodName))
+				key := domain.AliasEntityWOD + ":" + domain.NormalizeName(wodName)
+				if suggestion := catalog.wods.Suggestion(wodName, 3); suggestion != nil && !suggested[key] {
+					suggested[key] = true
+					result.Suggestions = append(result.Suggestions, suggestion)
+				}
 			}
 		}
 
@@ -648,7 +858,8 @@ func (s *ImportService) ConfirmUserWorkoutImport(jsonData []byte, userID int64,
 			WorkoutName *string `json:"workout_name,omitempty"`
 			TotalTime   *int    `json:"total_time,omitempty"`
 			Notes       *string `json:"notes,omitempty"`
-			Movements   []struct {
+			domain.SessionWellness
+			Movements []struct {
 				MovementName string   `json:"movement_name"`
 				MovementType string   `json:"movement_type"`
 				Sets         *int     `json:"sets,omitempty"`
@@ -669,9 +880,10 @@ func (s *ImportService) ConfirmUserWorkoutImport(jsonData []byte, userID int64,
 				Rounds      *int     `json:"rounds,omitempty"`
 				Reps        *int     `json:"reps,omitempty"`
 				Weight      *float64 `json:"weight,omitempty"`
-				Notes       string   `json:"notes,omitempty"`
-				IsPR        bool     `json:"is_pr"`
-				OrderIndex  int      `json:"order_index"`
+				domain.WODScoreDetails
+				Notes      string `json:"notes,omitempty"`
+				IsPR       bool   `json:"is_pr"`
+				OrderIndex int    `json:"order_index"`
 			} `json:"wods,omitempty"`
 		} `json:"user_workouts"`
 	}
@@ -685,6 +897,11 @@ func (s *ImportService) ConfirmUserWorkoutImport(jsonData []byte, userID int64,
 		Errors:        []string{},
 	}
 
+	catalog, err := loadNameCatalog(s.movementRepo, s.wodRepo, s.aliasService, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Import each workout
 	for _, workoutData := range exportData.UserWorkouts {
 		// Parse workout date
@@ -703,65 +920,59 @@ func (s *ImportService) ConfirmUserWorkoutImport(jsonData []byte, userID int64,
 		// Create or get movements
 		movementIDs := make(map[string]int64)
 		for _, movement := range workoutData.Movements {
-			existingMovement, err := s.movementRepo.GetByName(movement.MovementName)
-			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Error checking movement: %v", err))
+			// Names differing only in case or punctuation, and aliases, resolve to the existing movement
+			if match, ok := catalog.movements.Resolve(movement.MovementName); ok {
+				movementIDs[movement.MovementName] = match.ID
 				continue
 			}
 
-			if existingMovement == nil {
-				// Create movement
-				newMovement := &domain.Movement{
-					Name:        movement.MovementName,
-					Type:        domain.MovementType(movement.MovementType),
-					Description: "",
-					IsStandard:  false,
-					CreatedBy:   &userID,
-				}
-				if err := s.movementRepo.Create(newMovement); err != nil {
-					result.Errors = append(result.Errors, fmt.Sprintf("Failed to create movement: %v", err))
-					continue
-				}
-				movementIDs[movement.MovementName] = newMovement.ID
-				result.MovementsCreated++
-			} else {
-				movementIDs[movement.MovementName] = existingMovement.ID
+			// Create movement
+			newMovement := &domain.Movement{
+				Name:        movement.MovementName,
+				Type:        domain.MovementType(movement.MovementType),
+				Description: "",
+				IsStandard:  false,
+				CreatedBy:   &userID,
 			}
+			if err := s.movementRepo.Create(newMovement); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("Failed to create movement: %v", err))
+				continue
+			}
+			movementIDs[movement.MovementName] = newMovement.ID
+			catalog.movements.Add(newMovement.ID, newMovement.Name, false)
+			result.MovementsCreated++
 		}
 
 		// Create or get WODs
 		wodIDs := make(map[string]int64)
 		for _, wod := range workoutData.WODs {
-			existingWOD, err := s.wodRepo.GetByName(wod.WODName)
-			if err != nil {
-				result.Errors = append(result.Errors, fmt.Sprintf("Error checking WOD: %v", err))
+			// Names differing only in case or punctuation, and aliases, resolve to the existing WOD
+			if match, ok := catalog.wods.Resolve(wod.WODName); ok {
+				wodIDs[wod.WODName] = match.ID
 				continue
 			}
 
-			if existingWOD == nil {
-				// Create WOD with minimal info
-				newWOD := &domain.WOD{
-					Name:        wod.WODName,
-					Type:        wod.WODType,
-					Source:      "Self-recorded",
-					Regime:      "AMRAP",
-					ScoreType:   "Rounds+Reps",
-					Description: fmt.Sprintf("Imported from backup on %s", time.Now().Format("2006-01-02")),
-					IsStandard:  false,
-					CreatedBy:   &userID,
-				}
-				if wod.ScoreType != nil {
-					newWOD.ScoreType = *wod.ScoreType
-				}
-				if err := s.wodRepo.Create(newWOD); err != nil {
-					result.Errors = append(result.Errors, fmt.Sprintf("Failed to create WOD: %v", err))
-					continue
-				}
-				wodIDs[wod.WODName] = newWOD.ID
-				result.WODsCreated++
-			} else {
-				wodIDs[wod.WODName] = existingWOD.ID
+			// Create WOD with minimal info
+			newWOD := &domain.WOD{
+				Name:        wod.WODName,
+				Type:        wod.WODType,
+				Source:      "Self-recorded",
+				Regime:      "AMRAP",
+				ScoreType:   "Rounds+Reps",
+				Description: fmt.Sprintf("Imported from backup on %s", time.Now().Format("2006-01-02")),
+				IsStandard:  false,
+				CreatedBy:   &userID,
+			}
+			if wod.ScoreType != nil {
+				newWOD.ScoreType = *wod.ScoreType
 			}
+			if err := s.wodRepo.Create(newWOD); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("Failed to create WOD: %v", err))
+				continue
+			}
+			wodIDs[wod.WODName] = newWOD.ID
+			catalog.wods.Add(newWOD.ID, newWOD.Name, false)
+			result.WODsCreated++
 		}
 
 		// Create UserWorkout record
@@ -781,6 +992,9 @@ func (s *ImportService) ConfirmUserWorkoutImport(jsonData []byte, userID int64,
 			Notes:       workoutData.Notes,
 			TotalTime:   workoutData.TotalTime,
 		}
+		if validateSessionWellness(workoutData.SessionWellness) == nil {
+			userWorkout.SessionWellness = workoutData.SessionWellness
+		}
 
 		if err := s.userWorkoutRepo.Create(userWorkout); err != nil {
 			result.InvalidWorkouts++
@@ -821,15 +1035,16 @@ func (s *ImportService) ConfirmUserWorkoutImport(jsonData []byte, userID int64,
 			}
 
 			userWorkoutWOD := &domain.UserWorkoutWOD{
-				UserWorkoutID: userWorkout.ID,
-				WODID:         wodID,
-				TimeSeconds:   wod.TimeSeconds,
-				Rounds:        wod.Rounds,
-				Reps:          wod.Reps,
-				Weight:        wod.Weight,
-				Notes:         wod.Notes,
-				IsPR:          wod.IsPR,
-				OrderIndex:    wod.OrderIndex,
+				UserWorkoutID:   userWorkout.ID,
+				WODID:           wodID,
+				TimeSeconds:     wod.TimeSeconds,
+				Rounds:          wod.Rounds,
+				Reps:            wod.Reps,
+				Weight:          wod.Weight,
+				WODScoreDetails: wod.WODScoreDetails,
+				Notes:           wod.Notes,
+				IsPR:            wod.IsPR,
+				OrderIndex:      wod.OrderIndex,
 			}
 
 			if err := s.userWorkoutWODRepo.Create(userWorkoutWOD); err != nil {
@@ -840,6 +1055,7 @@ func (s *ImportService) ConfirmUserWorkoutImport(jsonData []byte, userID int64,
 		result.CreatedCount++
 		result.ValidWorkouts++
 	}
+	s.refreshSearch(result.CreatedCount)
 
 	return result, nil
 }
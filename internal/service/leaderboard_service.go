@@ -0,0 +1,248 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrLeaderboardNotAvailable = errors.New("leaderboards are only available for standard WODs")
+	ErrInvalidLeaderboardQuery = errors.New("invalid leaderboard filter")
+)
+
+const (
+	// leaderboardCacheTTL is how long a computed leaderboard page is served from memory
+	leaderboardCacheTTL = time.Minute
+
+	// DefaultLeaderboardLimit and MaxLeaderboardLimit bound leaderboard page sizes
+	DefaultLeaderboardLimit = 25
+	MaxLeaderboardLimit     = 100
+)
+
+// LeaderboardQuery represents the filters accepted by a WOD leaderboard
+// Empty strings and nil pointers mean "no filter"
+type LeaderboardQuery struct {
+	StartDate string // YYYY-MM-DD, inclusive
+	EndDate   string // YYYY-MM-DD, inclusive
+	Gender    string // male, female
+	AgeMin    *int   // Age bracket, inclusive, based on User.Birthday
+	AgeMax    *int
+	Division  string // rx, scaled
+	Limit     int
+	Offset    int
+}
+
+// Leaderboard is one page of a WOD leaderboard
+type Leaderboard struct {
+	WODID     int64                      `json:"wod_id"`
+	WODName   string                     `json:"wod_name"`
+	ScoreType string                     `json:"score_type"`
+	WODIDs    []int64                    `json:"wod_ids"` // The WOD and the comparable variants ranked with it
+	Entries   []*domain.LeaderboardEntry `json:"entries"`
+	Total     int                        `json:"total"`
+	Limit     int                        `json:"limit"`
+	Offset    int                        `json:"offset"`
+}
+
+type cachedLeaderboard struct {
+	leaderboard *Leaderboard
+	expiresAt   time.Time
+}
+
+// LeaderboardService builds opt-in community leaderboards for standard WODs
+// Only users with UserSettings.LeaderboardOptIn appear; pages are cached briefly in memory
+type LeaderboardService struct {
+	leaderboardRepo   domain.LeaderboardRepository
+	wodRepo           domain.WODRepository
+	wodVersionService *WODVersionService
+
+	mu    sync.Mutex
+	cache map[string]cachedLeaderboard
+	now   func() time.Time
+}
+
+// NewLeaderboardService creates a new leaderboard service
+func NewLeaderboardService(leaderboardRepo domain.LeaderboardRepository, wodRepo domain.WODRepository) *LeaderboardService {
+	return &LeaderboardService{
+		leaderboardRepo: leaderboardRepo,
+		wodRepo:         wodRepo,
+		cache:           make(map[string]cachedLeaderboard),
+		now:             time.Now,
+	}
+}
+
+// SetWODVersionService ranks comparable variants together with their parent WOD
+func (s *LeaderboardService) SetWODVersionService(wodVersionService *WODVersionService) {
+	s.wodVersionService = wodVersionService
+}
+
+// GetWODLeaderboard returns a ranked, paginated leaderboard for a standard WOD
+func (s *LeaderboardService) GetWODLeaderboard(wodID int64, query LeaderboardQuery) (*Leaderboard, error) {
+	wod, err := s.wodRepo.GetByID(wodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wod: %w", err)
+	}
+	if wod == nil {
+		return nil, ErrWODNotFound
+	}
+	if !wod.IsStandard {
+		return nil, ErrLeaderboardNotAvailable
+	}
+
+	filter, err := s.buildFilter(wod, query)
+	if err != nil {
+		return nil, err
+	}
+	if s.wodVersionService != nil {
+		if filter.WODIDs, err = s.wodVersionService.ComparableWODIDs(wod.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	key := fmt.Sprintf("%v|%s|%s|%s|%s|%s|%s|%s|%d|%d", filter.WODIDs, wod.ScoreType,
+		formatFilterDate(filter.StartDate), formatFilterDate(filter.EndDate), filter.Gender,
+		formatFilterDate(filter.BirthdayFrom), formatFilterDate(filter.BirthdayTo), filter.Division,
+		filter.Limit, filter.Offset)
+
+	if cached := s.cached(key); cached != nil {
+		return cached, nil
+	}
+
+	entries, total, err := s.leaderboardRepo.GetWODLeaderboard(filter)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []*domain.LeaderboardEntry{}
+	}
+
+	leaderboard := &Leaderboard{
+		WODID:     wod.ID,
+		WODName:   wod.Name,
+		ScoreType: wod.ScoreType,
+		WODIDs:    filter.WODIDs,
+		Entries:   entries,
+		Total:     total,
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+	}
+	s.store(key, leaderboard)
+
+	return leaderboard, nil
+}
+
+// buildFilter validates the query and converts the age bracket into a birthday range
+func (s *LeaderboardService) buildFilter(wod *domain.WOD, query LeaderboardQuery) (domain.LeaderboardFilter, error) {
+	filter := domain.LeaderboardFilter{
+		WODIDs:    []int64{wod.ID},
+		ScoreType: wod.ScoreType,
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultLeaderboardLimit
+	}
+	if filter.Limit > MaxLeaderboardLimit {
+		filter.Limit = MaxLeaderboardLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	if query.StartDate != "" {
+		start, err := time.Parse(domain.ScheduledDateFormat, query.StartDate)
+		if err != nil {
+			return filter, ErrInvalidScheduleDate
+		}
+		filter.StartDate = &start
+	}
+	if query.EndDate != "" {
+		end, err := time.Parse(domain.ScheduledDateFormat, query.EndDate)
+		if err != nil {
+			return filter, ErrInvalidScheduleDate
+		}
+		// Include the whole end day
+		end = end.Add(24*time.Hour - time.Nanosecond)
+		filter.EndDate = &end
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return filter, ErrInvalidScheduleRange
+	}
+
+	switch gender := strings.ToLower(strings.TrimSpace(query.Gender)); gender {
+	case "", domain.GenderMale, domain.GenderFemale:
+		filter.Gender = gender
+	default:
+		return filter, fmt.Errorf("%w: gender must be %s or %s", ErrInvalidLeaderboardQuery, domain.GenderMale, domain.GenderFemale)
+	}
+
+	switch division := strings.ToLower(strings.TrimSpace(query.Division)); division {
+	case "", domain.DivisionRx, domain.DivisionScaled:
+		filter.Division = division
+	default:
+		return filter, fmt.Errorf("%w: division must be %s or %s", ErrInvalidLeaderboardQuery, domain.DivisionRx, domain.DivisionScaled)
+	}
+
+	if query.AgeMin != nil || query.AgeMax != nil {
+		if (query.AgeMin != nil && *query.AgeMin < 0) || (query.AgeMax != nil && *query.AgeMax < 0) ||
+			(query.AgeMin != nil && query.AgeMax != nil && *query.AgeMin > *query.AgeMax) {
+			return filter, fmt.Errorf("%w: invalid age bracket", ErrInvalidLeaderboardQuery)
+		}
+		filter.BirthdayFrom, filter.BirthdayTo = ageBracketToBirthdays(s.now(), query.AgeMin, query.AgeMax)
+	}
+
+	return filter, nil
+}
+
+// ageBracketToBirthdays converts an inclusive age range into the matching birthday range as of today
+// Someone aged ageMax was born no earlier than (today - ageMax - 1 years + 1 day)
+func ageBracketToBirthdays(now time.Time, ageMin, ageMax *int) (from, to *time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if ageMin != nil {
+		t := today.AddDate(-*ageMin, 0, 0)
+		to = &t
+	}
+	if ageMax != nil {
+		f := today.AddDate(-(*ageMax + 1), 0, 1)
+		from = &f
+	}
+	return from, to
+}
+
+func formatFilterDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (s *LeaderboardService) cached(key string) *Leaderboard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[key]
+	if !ok || s.now().After(entry.expiresAt) {
+		return nil
+	}
+	return entry.leaderboard
+}
+
+func (s *LeaderboardService) store(key string, leaderboard *Leaderboard) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	// Drop expired pages so the cache cannot grow without bound
+	for k, entry := range s.cache {
+		if now.After(entry.expiresAt) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = cachedLeaderboard{leaderboard: leaderboard, expiresAt: now.Add(leaderboardCacheTTL)}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+func TestAgeBracketToBirthdays(t *testing.T) {
+	now := time.Date(2025, 6, 15, 14, 30, 0, 0, time.UTC)
+	minAge, maxAge := 35, 39
+
+	from, to := ageBracketToBirthdays(now, &minAge, &maxAge)
+	if from == nil || to == nil {
+		t.Fatal("expected both bounds")
+	}
+
+	// Turned 35 today: included
+	if got := to.Format("2006-01-02"); got != "1990-06-15" {
+		t.Errorf("expected latest birthday 1990-06-15, got %s", got)
+	}
+	// Turns 40 tomorrow: still 39 today, included
+	if got := from.Format("2006-01-02"); got != "1985-06-16" {
+		t.Errorf("expected earliest birthday 1985-06-16, got %s", got)
+	}
+
+	from, to = ageBracketToBirthdays(now, &minAge, nil)
+	if from != nil || to == nil {
+		t.Errorf("expected only an upper birthday bound for an open-ended bracket")
+	}
+}
+
+// openTestDB returns a migrated SQLite database for tests that need the repositories' SQL
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := repository.InitDatabase("sqlite3", filepath.Join(t.TempDir(), "test.db"), nil)
+	if err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newLeaderboardTestDB returns a migrated SQLite database with a standard time-scored WOD and five
+// users: Alice (female) and Bob (male) tie on 4:40, Carol (female) has 5:20, Eve (female) was time capped,
+// and Dan (male, fastest at 3:20) has not opted in
+func newLeaderboardTestDB(t *testing.T) (*LeaderboardService, *domain.WOD) {
+	t.Helper()
+	db := openTestDB(t)
+
+	wodRepo := repository.NewWODRepository(db)
+	wod := &domain.WOD{Name: "Leaderboard Test WOD", Source: "CrossFit", Type: "Benchmark", Regime: "Fastest Time",
+		ScoreType: domain.ScoreTypeTime, IsStandard: true}
+	if err := wodRepo.Create(wod); err != nil {
+		t.Fatalf("failed to create wod: %v", err)
+	}
+
+	userRepo := repository.NewSQLiteUserRepository(db)
+	settingsRepo := repository.NewSQLiteUserSettingsRepository(db)
+	userWorkoutRepo := repository.NewUserWorkoutRepository(db)
+	userWorkoutWODRepo := repository.NewUserWorkoutWODRepository(db)
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	athletes := []struct {
+		name   string
+		gender string
+		optIn  bool
+		scores []int // Seconds; a negative score is capped at 10:00 with that many reps left
+	}{
+		{"Alice", domain.GenderFemale, true, []int{300, 280}},
+		{"Bob", domain.GenderMale, true, []int{280}},
+		{"Carol", domain.GenderFemale, true, []int{320}},
+		{"Dan", domain.GenderMale, false, []int{200}},
+		{"Eve", domain.GenderFemale, true, []int{-12}},
+	}
+	for i, a := range athletes {
+		user := &domain.User{Email: strings.ToLower(a.name) + "@example.com", Name: a.name, Role: "user", CreatedAt: day, UpdatedAt: day}
+		if err := userRepo.Create(user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		if _, err := db.Exec(`UPDATE users SET gender = ? WHERE id = ?`, a.gender, user.ID); err != nil {
+			t.Fatalf("failed to set gender: %v", err)
+		}
+		if err := settingsRepo.Create(&domain.UserSettings{UserID: user.ID, LeaderboardOptIn: a.optIn}); err != nil {
+			t.Fatalf("failed to create settings: %v", err)
+		}
+
+		for j, score := range a.scores {
+			name := wod.Name
+			userWorkout := &domain.UserWorkout{UserID: user.ID, WorkoutName: &name, WorkoutDate: day.AddDate(0, 0, i+j)}
+			if err := userWorkoutRepo.Create(userWorkout); err != nil {
+				t.Fatalf("failed to log workout: %v", err)
+			}
+			result := &domain.UserWorkoutWOD{UserWorkoutID: userWorkout.ID, WODID: wod.ID, TimeSeconds: intPtr(score)}
+			if score < 0 {
+				result.TimeSeconds = intPtr(600)
+				result.Reps = intPtr(-score)
+				result.TimeCapped = true
+			}
+			if err := userWorkoutWODRepo.Create(result); err != nil {
+				t.Fatalf("failed to log result: %v", err)
+			}
+		}
+	}
+
+	return NewLeaderboardService(repository.NewLeaderboardRepository(db), wodRepo), wod
+}
+
+func leaderboardRanks(leaderboard *Leaderboard) map[string]int {
+	ranks := map[string]int{}
+	for _, e := range leaderboard.Entries {
+		ranks[e.Name] = e.Rank
+	}
+	return ranks
+}
+
+func TestLeaderboardServiceRanking(t *testing.T) {
+	svc, wod := newLeaderboardTestDB(t)
+
+	leaderboard, err := svc.GetWODLeaderboard(wod.ID, LeaderboardQuery{})
+	if err != nil {
+		t.Fatalf("GetWODLeaderboard() error = %v", err)
+	}
+	if leaderboard.Total != 4 || len(leaderboard.Entries) != 4 {
+		t.Fatalf("expected 4 opted-in athletes, got total %d with %d entries", leaderboard.Total, len(leaderboard.Entries))
+	}
+
+	// Dan has not opted in; Alice ranks on her best result and ties with Bob; RANK skips the shared place;
+	// the capped result ranks after every finisher
+	want := map[string]int{"Alice": 1, "Bob": 1, "Carol": 3, "Eve": 4}
+	got := leaderboardRanks(leaderboard)
+	for name, rank := range want {
+		if got[name] != rank {
+			t.Errorf("expected %s ranked %d, got %d (ranks %v)", name, rank, got[name], got)
+		}
+	}
+	if _, ok := got["Dan"]; ok {
+		t.Error("expected users who have not opted in to be excluded")
+	}
+	for _, e := range leaderboard.Entries {
+		if e.Name == "Alice" && (e.TimeSeconds == nil || *e.TimeSeconds != 280) {
+			t.Errorf("expected Alice's best time of 280s, got %v", e.TimeSeconds)
+		}
+	}
+
+	female, err := svc.GetWODLeaderboard(wod.ID, LeaderboardQuery{Gender: domain.GenderFemale})
+	if err != nil {
+		t.Fatalf("GetWODLeaderboard(female) error = %v", err)
+	}
+	want = map[string]int{"Alice": 1, "Carol": 2, "Eve": 3}
+	got = leaderboardRanks(female)
+	if female.Total != 3 {
+		t.Errorf("expected 3 female athletes, got %d", female.Total)
+	}
+	for name, rank := range want {
+		if got[name] != rank {
+			t.Errorf("expected %s ranked %d among women, got %d", name, rank, got[name])
+		}
+	}
+}
+
+func TestLeaderboardServicePagination(t *testing.T) {
+	svc, wod := newLeaderboardTestDB(t)
+
+	page, err := svc.GetWODLeaderboard(wod.ID, LeaderboardQuery{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("GetWODLeaderboard() error = %v", err)
+	}
+	if page.Total != 4 || page.Limit != 2 || page.Offset != 2 {
+		t.Errorf("expected page 2 of 4 entries, got total %d limit %d offset %d", page.Total, page.Limit, page.Offset)
+	}
+	if len(page.Entries) != 2 || page.Entries[0].Name != "Carol" || page.Entries[0].Rank != 3 || page.Entries[1].Name != "Eve" {
+		t.Errorf("expected Carol (3) and Eve (4) on the second page, got %v", leaderboardRanks(page))
+	}
+
+	clamped, err := svc.GetWODLeaderboard(wod.ID, LeaderboardQuery{Limit: MaxLeaderboardLimit + 1})
+	if err != nil {
+		t.Fatalf("GetWODLeaderboard() error = %v", err)
+	}
+	if clamped.Limit != MaxLeaderboardLimit || len(clamped.Entries) != 4 {
+		t.Errorf("expected the page size clamped to %d, got %d", MaxLeaderboardLimit, clamped.Limit)
+	}
+
+	past, err := svc.GetWODLeaderboard(wod.ID, LeaderboardQuery{Offset: 10})
+	if err != nil {
+		t.Fatalf("GetWODLeaderboard() error = %v", err)
+	}
+	if past.Total != 4 || len(past.Entries) != 0 {
+		t.Errorf("expected an empty page past the end with the total kept, got %d entries of %d", len(past.Entries), past.Total)
+	}
+}
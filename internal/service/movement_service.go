@@ -1,8 +1,10 @@
 package service
 
 import (
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -14,12 +16,16 @@ var (
 	ErrMovementUnauthorized = errors.New("unauthorized: cannot modify standard movement")
 	ErrMovementNameRequired = errors.New("movement name is required")
 	ErrMovementTypeRequired = errors.New("movement type is required")
+	ErrInvalidTaxonomy      = errors.New("invalid movement taxonomy")
 )
 
 // MovementService handles movement business logic
 type MovementService struct {
 	movementRepo         domain.MovementRepository
 	dataChangeLogService *DataChangeLogService
+	searchService        *SearchService
+	enumerationService   *EnumerationService
+	attachmentService    *AttachmentService
 }
 
 // NewMovementService creates a new movement service
@@ -30,12 +36,43 @@ func NewMovementService(movementRepo domain.MovementRepository, dataChangeLogSer
 	}
 }
 
+// SetSearchService keeps the search index in step with movement edits
+func (s *MovementService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
+// SetEnumerationService validates movement types against the admin-managed values
+// instead of domain.DefaultEnumerations
+func (s *MovementService) SetEnumerationService(enumerationService *EnumerationService) {
+	s.enumerationService = enumerationService
+}
+
+// SetAttachmentService removes a movement's attachments when the movement is deleted
+func (s *MovementService) SetAttachmentService(attachmentService *AttachmentService) {
+	s.attachmentService = attachmentService
+}
+
 // Create creates a new custom movement
 func (s *MovementService) Create(movement *domain.Movement) error {
+	return s.createCustom(movement, s.movementRepo.Create)
+}
+
+// CreateInGymLibrary creates a new custom movement and adds it to a gym library in one transaction
+func (s *MovementService) CreateInGymLibrary(movement *domain.Movement, orgID, addedBy int64) error {
+	return s.createCustom(movement, func(movement *domain.Movement) error {
+		return s.movementRepo.CreateInLibrary(movement, orgID, addedBy)
+	})
+}
+
+// createCustom validates a custom movement and saves it with create
+func (s *MovementService) createCustom(movement *domain.Movement, create func(*domain.Movement) error) error {
 	// Validate required fields
 	if err := s.validateMovement(movement); err != nil {
 		return err
 	}
+	if err := s.validateMovementType(movement, nil); err != nil {
+		return err
+	}
 
 	// Set as custom movement
 	movement.IsStandard = false
@@ -43,7 +80,39 @@ func (s *MovementService) Create(movement *domain.Movement) error {
 	movement.CreatedAt = now
 	movement.UpdatedAt = now
 
-	return s.movementRepo.Create(movement)
+	if err := create(movement); err != nil {
+		return err
+	}
+	s.refreshSearch(movement.ID)
+	return nil
+}
+
+// CreateStandard creates a standard movement (admin only, e.g. from the seed library)
+func (s *MovementService) CreateStandard(movement *domain.Movement, userID int64, userEmail string) error {
+	if err := s.validateMovement(movement); err != nil {
+		return err
+	}
+	if err := s.validateMovementType(movement, nil); err != nil {
+		return err
+	}
+
+	movement.IsStandard = true
+	movement.CreatedBy = nil
+	now := time.Now()
+	movement.CreatedAt = now
+	movement.UpdatedAt = now
+
+	if err := s.movementRepo.Create(movement); err != nil {
+		return err
+	}
+	s.refreshSearch(movement.ID)
+
+	if s.dataChangeLogService != nil {
+		if logErr := s.dataChangeLogService.LogCreate(domain.EntityTypeMovement, movement.ID, movement.Name, userID, userEmail, movement, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log movement create: %v\n", logErr)
+		}
+	}
+	return nil
 }
 
 // GetByID retrieves a movement by ID
@@ -66,9 +135,17 @@ func (s *MovementService) GetByName(name string) (*domain.Movement, error) {
 	return s.movementRepo.GetByName(name)
 }
 
-// ListAll retrieves all movements
-func (s *MovementService) ListAll() ([]*domain.Movement, error) {
-	return s.movementRepo.ListAll()
+// ListAll retrieves all movements matching the filters (see domain.MovementRepository.ListAll)
+func (s *MovementService) ListAll(filters map[string]interface{}) ([]*domain.Movement, error) {
+	return s.movementRepo.ListAll(filters)
+}
+
+// ListVariants retrieves the variants of a movement (e.g. Power Clean and Hang Clean for Clean)
+func (s *MovementService) ListVariants(id int64) ([]*domain.Movement, error) {
+	if _, err := s.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.movementRepo.ListAll(map[string]interface{}{"parent_id": id})
 }
 
 // ListStandard retrieves all standard movements
@@ -76,12 +153,89 @@ func (s *MovementService) ListStandard() ([]*domain.Movement, error) {
 	return s.movementRepo.ListStandard()
 }
 
-// Search searches movements by name
-func (s *MovementService) Search(query string, limit int) ([]*domain.Movement, error) {
+// Search searches movements by name, narrowed by the same filters as ListAll
+func (s *MovementService) Search(query string, filters map[string]interface{}, limit int) ([]*domain.Movement, error) {
 	if strings.TrimSpace(query) == "" {
 		return []*domain.Movement{}, nil
 	}
-	return s.movementRepo.Search(query, limit)
+	return s.movementRepo.Search(query, filters, limit)
+}
+
+// UpdateTaxonomy sets the equipment, pattern, muscle groups and parent of any movement, standard or custom (admin only)
+func (s *MovementService) UpdateTaxonomy(id int64, taxonomy *domain.Movement, userID int64, userEmail string) (*domain.Movement, error) {
+	existing, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *existing
+	updated.Equipment = taxonomy.Equipment
+	updated.Pattern = taxonomy.Pattern
+	updated.MuscleGroups = taxonomy.MuscleGroups
+	updated.ParentID = taxonomy.ParentID
+	if err := s.validateTaxonomy(&updated); err != nil {
+		return nil, err
+	}
+	updated.UpdatedAt = time.Now()
+
+	if err := s.movementRepo.UpdateTaxonomy(&updated); err != nil {
+		return nil, fmt.Errorf("failed to update movement taxonomy: %w", err)
+	}
+
+	if s.dataChangeLogService != nil {
+		if logErr := s.dataChangeLogService.LogMovementUpdate(id, existing.Name, userID, userEmail, existing, &updated, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log movement update: %v\n", logErr)
+		}
+	}
+
+	return &updated, nil
+}
+
+// SeedTaxonomy fills in the taxonomy of standard movements that have none from a CSV in the
+// seeds/movements.csv format, matching movements by name; returns the number of movements updated.
+// Movements that already have taxonomy, custom movements and rows without a matching movement are skipped.
+func (s *MovementService) SeedTaxonomy(csvData io.Reader) (int, error) {
+	rows, err := ParseMovementTaxonomyCSV(csvData)
+	if err != nil {
+		return 0, err
+	}
+
+	movements, err := s.movementRepo.ListAll(map[string]interface{}{"is_standard": true})
+	if err != nil {
+		return 0, err
+	}
+	byName := make(map[string]*domain.Movement, len(movements))
+	for _, m := range movements {
+		byName[domain.NormalizeName(m.Name)] = m
+	}
+
+	count := 0
+	for _, row := range rows {
+		movement, ok := byName[domain.NormalizeName(row.Name)]
+		if !ok || movement.HasTaxonomy() {
+			continue
+		}
+
+		movement.Equipment = row.Equipment
+		movement.Pattern = row.Pattern
+		movement.MuscleGroups = row.MuscleGroups
+		if parent, ok := byName[domain.NormalizeName(row.Parent)]; ok && row.Parent != "" {
+			movement.ParentID = &parent.ID
+		}
+		if err := s.validateTaxonomy(movement); err != nil {
+			fmt.Printf("Warning: skipping taxonomy for movement %s: %v\n", movement.Name, err)
+			movement.Equipment, movement.Pattern, movement.MuscleGroups, movement.ParentID = nil, "", nil, nil
+			continue
+		}
+
+		movement.UpdatedAt = time.Now()
+		if err := s.movementRepo.UpdateTaxonomy(movement); err != nil {
+			return count, fmt.Errorf("failed to seed taxonomy for movement %s: %w", movement.Name, err)
+		}
+		count++
+	}
+
+	return count, nil
 }
 
 // Update updates an existing movement with data change logging
@@ -99,6 +253,9 @@ func (s *MovementService) Update(movement *domain.Movement, userID int64, userEm
 	if existing == nil {
 		return ErrMovementNotFound
 	}
+	if err := s.validateMovementType(movement, existing); err != nil {
+		return err
+	}
 
 	// Check if it's a standard movement (only admins can modify these)
 	if existing.IsStandard {
@@ -115,6 +272,8 @@ func (s *MovementService) Update(movement *domain.Movement, userID int64, userEm
 		return fmt.Errorf("failed to update movement: %w", err)
 	}
 
+	s.refreshSearch(movement.ID)
+
 	// Log the change
 	if s.dataChangeLogService != nil {
 		if logErr := s.dataChangeLogService.LogMovementUpdate(movement.ID, movement.Name, userID, userEmail, existing, movement, nil, nil); logErr != nil {
@@ -140,17 +299,27 @@ func (s *MovementService) UpdateAsAdmin(movement *domain.Movement, userID int64,
 	if existing == nil {
 		return ErrMovementNotFound
 	}
+	if err := s.validateMovementType(movement, existing); err != nil {
+		return err
+	}
 
 	// Update timestamp
 	movement.UpdatedAt = time.Now()
 	movement.CreatedAt = existing.CreatedAt
 	movement.IsStandard = existing.IsStandard
 
-	// Perform the update
-	if err := s.movementRepo.Update(movement); err != nil {
+	// Use appropriate update method based on whether movement is standard
+	if existing.IsStandard {
+		err = s.movementRepo.UpdateStandard(movement)
+	} else {
+		err = s.movementRepo.Update(movement)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to update movement: %w", err)
 	}
 
+	s.refreshSearch(movement.ID)
+
 	// Log the change
 	if s.dataChangeLogService != nil {
 		if logErr := s.dataChangeLogService.LogMovementUpdate(movement.ID, movement.Name, userID, userEmail, existing, movement, nil, nil); logErr != nil {
@@ -182,6 +351,13 @@ func (s *MovementService) Delete(id int64, userID int64, userEmail string) error
 		return fmt.Errorf("failed to delete movement: %w", err)
 	}
 
+	s.refreshSearch(id)
+	if s.attachmentService != nil {
+		if err := s.attachmentService.DeleteForEntity(domain.AttachmentEntityMovement, id); err != nil {
+			fmt.Printf("Warning: failed to delete movement attachments: %v\n", err)
+		}
+	}
+
 	// Log the deletion
 	if s.dataChangeLogService != nil {
 		if logErr := s.dataChangeLogService.LogMovementDelete(id, existing.Name, userID, userEmail, existing, nil, nil); logErr != nil {
@@ -227,9 +403,10 @@ func (s *MovementService) ListAllUserCreatedWithUserInfo() ([]*domain.MovementWi
 }
 
 // ListAllUserCreatedWithUserInfoFiltered retrieves all user-created movements with creator info and filters (admin only)
-func (s *MovementService) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, movementType, creator string) ([]*domain.MovementWithCreator, int64, error) {
+// A positive orgID limits the list to items created by members of that gym
+func (s *MovementService) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, movementType, creator string, orgID int64) ([]*domain.MovementWithCreator, int64, error) {
 	// Get the list with user info and filters
-	movements, count, err := s.movementRepo.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, movementType, creator)
+	movements, count, err := s.movementRepo.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, movementType, creator, orgID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list all user-created movements with filters: %w", err)
 	}
@@ -259,11 +436,19 @@ func (s *MovementService) CopyToStandard(id int64, newName string) (*domain.Move
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy movement to standard: %w", err)
 	}
+	s.refreshSearch(movement.ID)
 
 	return movement, nil
 }
 
-// validateMovement validates movement required fields
+// refreshSearch re-indexes a movement when search is wired
+func (s *MovementService) refreshSearch(id int64) {
+	if s.searchService != nil {
+		s.searchService.Refresh(domain.SearchEntityMovement, id)
+	}
+}
+
+// validateMovement validates movement required fields and taxonomy
 func (s *MovementService) validateMovement(movement *domain.Movement) error {
 	if strings.TrimSpace(movement.Name) == "" {
 		return ErrMovementNameRequired
@@ -271,5 +456,147 @@ func (s *MovementService) validateMovement(movement *domain.Movement) error {
 	if movement.Type == "" {
 		return ErrMovementTypeRequired
 	}
+	return s.validateTaxonomy(movement)
+}
+
+// validateMovementType checks the type against the active movement types and stores its canonical
+// spelling; a type unchanged from the existing movement is kept even when deprecated
+func (s *MovementService) validateMovementType(movement *domain.Movement, existing *domain.Movement) error {
+	allowed, err := enumerationValues(s.enumerationService, domain.EnumerationMovementType)
+	if err != nil {
+		return fmt.Errorf("failed to load allowed movement types: %w", err)
+	}
+
+	var previous string
+	if existing != nil {
+		previous = string(existing.Type)
+	}
+	movementType, err := resolveEnumeration(allowed, "movement type", string(movement.Type), previous)
+	if err != nil {
+		return err
+	}
+	movement.Type = domain.MovementType(movementType)
 	return nil
 }
+
+// validateTaxonomy normalizes the taxonomy lists and checks values against the accepted ones.
+// A parent must exist, differ from the movement and not be a variant itself; a movement with
+// variants cannot become a variant, so families are always one level deep.
+func (s *MovementService) validateTaxonomy(movement *domain.Movement) error {
+	var err error
+	if movement.Equipment, err = normalizeTaxonomyValues("equipment", movement.Equipment, domain.MovementEquipment); err != nil {
+		return err
+	}
+	if movement.MuscleGroups, err = normalizeTaxonomyValues("muscle group", movement.MuscleGroups, domain.MuscleGroups); err != nil {
+		return err
+	}
+	movement.Pattern = strings.ToLower(strings.TrimSpace(movement.Pattern))
+	if movement.Pattern != "" && !contains(domain.MovementPatterns, movement.Pattern) {
+		return fmt.Errorf("%w: unknown pattern %q (must be one of: %s)", ErrInvalidTaxonomy, movement.Pattern, strings.Join(domain.MovementPatterns, ", "))
+	}
+
+	if movement.ParentID == nil {
+		return nil
+	}
+	if *movement.ParentID == movement.ID {
+		return fmt.Errorf("%w: a movement cannot be its own parent", ErrInvalidTaxonomy)
+	}
+	parent, err := s.movementRepo.GetByID(*movement.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get parent movement: %w", err)
+	}
+	if parent == nil {
+		return fmt.Errorf("%w: parent movement %d not found", ErrInvalidTaxonomy, *movement.ParentID)
+	}
+	if parent.ParentID != nil {
+		return fmt.Errorf("%w: %s is itself a variant; use its parent instead", ErrInvalidTaxonomy, parent.Name)
+	}
+	if movement.ID != 0 {
+		variants, err := s.movementRepo.ListAll(map[string]interface{}{"parent_id": movement.ID})
+		if err != nil {
+			return fmt.Errorf("failed to list movement variants: %w", err)
+		}
+		if len(variants) > 0 {
+			return fmt.Errorf("%w: %s has variants and cannot become a variant itself", ErrInvalidTaxonomy, movement.Name)
+		}
+	}
+	return nil
+}
+
+// normalizeTaxonomyValues lowercases, dedupes and checks equipment or muscle group values
+func normalizeTaxonomyValues(kind string, values, accepted []string) ([]string, error) {
+	normalized := []string{}
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" || contains(normalized, value) {
+			continue
+		}
+		if !contains(accepted, value) {
+			return nil, fmt.Errorf("%w: unknown %s %q (must be one of: %s)", ErrInvalidTaxonomy, kind, value, strings.Join(accepted, ", "))
+		}
+		normalized = append(normalized, value)
+	}
+	return normalized, nil
+}
+
+// MovementTaxonomyRow is the taxonomy of one row of a movements CSV
+type MovementTaxonomyRow struct {
+	Name         string
+	Equipment    []string
+	Pattern      string
+	MuscleGroups []string
+	Parent       string // Parent movement name
+}
+
+// movementTaxonomyColumns are the optional taxonomy columns of a movements CSV, after the base columns.
+// Equipment and muscle groups are "|"-separated lists; parent is the parent movement's name.
+var movementTaxonomyColumns = []string{"equipment", "pattern", "muscle_groups", "parent"}
+
+// ParseMovementTaxonomyCSV reads the name and taxonomy columns of a movements CSV
+func ParseMovementTaxonomyCSV(csvData io.Reader) ([]*MovementTaxonomyRow, error) {
+	reader := csv.NewReader(csvData)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range append([]string{"name"}, movementTaxonomyColumns...) {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: CSV is missing the %s column", ErrInvalidTaxonomy, required)
+		}
+	}
+
+	var rows []*MovementTaxonomyRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row %d: %w", len(rows)+2, err)
+		}
+		rows = append(rows, &MovementTaxonomyRow{
+			Name:         strings.TrimSpace(record[columns["name"]]),
+			Equipment:    splitTaxonomyList(record[columns["equipment"]]),
+			Pattern:      strings.TrimSpace(record[columns["pattern"]]),
+			MuscleGroups: splitTaxonomyList(record[columns["muscle_groups"]]),
+			Parent:       strings.TrimSpace(record[columns["parent"]]),
+		})
+	}
+	return rows, nil
+}
+
+// splitTaxonomyList splits a "|"-separated CSV cell
+func splitTaxonomyList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, "|") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
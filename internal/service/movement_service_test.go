@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+func TestParseMovementTaxonomyCSV(t *testing.T) {
+	csvData := `"name","type","description","is_standard","created_by_email","equipment","pattern","muscle_groups","parent"
+"Back Squat","weightlifting","","true","","barbell","squat","quads|glutes",""
+"Front Squat","weightlifting","","true","","barbell","squat"," quads | core ","Back Squat"
+`
+	rows, err := ParseMovementTaxonomyCSV(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	front := rows[1]
+	if front.Name != "Front Squat" || front.Pattern != "squat" || front.Parent != "Back Squat" {
+		t.Errorf("unexpected row: %+v", front)
+	}
+	if len(front.MuscleGroups) != 2 || front.MuscleGroups[0] != "quads" || front.MuscleGroups[1] != "core" {
+		t.Errorf("expected trimmed muscle groups, got %v", front.MuscleGroups)
+	}
+
+	if _, err := ParseMovementTaxonomyCSV(strings.NewReader("name,type\nBack Squat,weightlifting\n")); !errors.Is(err, ErrInvalidTaxonomy) {
+		t.Errorf("expected ErrInvalidTaxonomy for a legacy header, got %v", err)
+	}
+}
+
+func TestNormalizeTaxonomyValues(t *testing.T) {
+	values, err := normalizeTaxonomyValues("equipment", []string{"Barbell", " barbell ", "", "plate"}, domain.MovementEquipment)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(values) != 2 || values[0] != "barbell" || values[1] != "plate" {
+		t.Errorf("expected lowercased, deduped values, got %v", values)
+	}
+
+	if _, err := normalizeTaxonomyValues("muscle group", []string{"wings"}, domain.MuscleGroups); !errors.Is(err, ErrInvalidTaxonomy) {
+		t.Errorf("expected ErrInvalidTaxonomy, got %v", err)
+	}
+}
+
+func TestSeedMovementsTaxonomy(t *testing.T) {
+	file, err := os.Open("../../seeds/movements.csv")
+	if err != nil {
+		t.Skipf("seed file not available: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := ParseMovementTaxonomyCSV(file)
+	if err != nil {
+		t.Fatalf("failed to parse seed file: %v", err)
+	}
+
+	parents := make(map[string]string, len(rows))
+	for _, row := range rows {
+		parents[row.Name] = row.Parent
+	}
+	for _, row := range rows {
+		if row.Pattern != "" && !contains(domain.MovementPatterns, row.Pattern) {
+			t.Errorf("%s: unknown pattern %q", row.Name, row.Pattern)
+		}
+		if _, err := normalizeTaxonomyValues("equipment", row.Equipment, domain.MovementEquipment); err != nil {
+			t.Errorf("%s: %v", row.Name, err)
+		}
+		if _, err := normalizeTaxonomyValues("muscle group", row.MuscleGroups, domain.MuscleGroups); err != nil {
+			t.Errorf("%s: %v", row.Name, err)
+		}
+		if row.Parent == "" {
+			continue
+		}
+		grandparent, ok := parents[row.Parent]
+		if !ok {
+			t.Errorf("%s: parent %q is not in the seed file", row.Name, row.Parent)
+		} else if grandparent != "" {
+			t.Errorf("%s: parent %q is itself a variant", row.Name, row.Parent)
+		}
+	}
+}
+
+func TestMovementTaxonomyFilterMatchesLiterally(t *testing.T) {
+	movementRepo := repository.NewMovementRepository(openTestDB(t))
+	movement := &domain.Movement{Name: "Filter Test Thruster", Type: domain.MovementTypeWeightlifting,
+		Equipment: []string{"Barbell", "Box"}, MuscleGroups: []string{"Quads", "Shoulders"}}
+	if err := movementRepo.Create(movement); err != nil {
+		t.Fatalf("failed to create movement: %v", err)
+	}
+
+	found := func(filters map[string]interface{}) bool {
+		movements, err := movementRepo.ListAll(filters)
+		if err != nil {
+			t.Fatalf("ListAll failed: %v", err)
+		}
+		for _, m := range movements {
+			if m.ID == movement.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !found(map[string]interface{}{"equipment": "Box"}) || !found(map[string]interface{}{"muscle_group": "Quads"}) {
+		t.Error("expected exact list entries to match")
+	}
+	for _, value := range []string{"Bar%", "%", "Barbel_", `Box\`} {
+		if found(map[string]interface{}{"equipment": value}) {
+			t.Errorf("expected equipment %q to match literally, not as a pattern", value)
+		}
+	}
+	if found(map[string]interface{}{"muscle_group": "Q_ads"}) {
+		t.Error("expected muscle_group wildcards to match literally")
+	}
+}
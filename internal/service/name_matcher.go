@@ -0,0 +1,209 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// nameSuggestionThreshold is the lowest similarity offered as a fuzzy suggestion
+const nameSuggestionThreshold = 0.6
+
+// nameMatchEntry is a movement or WOD known to a NameMatcher
+type nameMatchEntry struct {
+	id         int64
+	name       string
+	key        string // domain.NormalizeName(name)
+	isStandard bool
+	aliases    []*domain.NameAlias
+}
+
+// NameMatcher resolves imported names to existing movements or WODs by normalized name or alias,
+// and suggests close matches for names that do not resolve
+// It is built once per import so that every row resolves against the same snapshot
+type NameMatcher struct {
+	entityType string
+	entries    []*nameMatchEntry
+	byID       map[int64]*nameMatchEntry
+	byName     map[string]*nameMatchEntry
+	byAlias    map[string]*nameMatchEntry
+}
+
+// NewNameMatcher creates an empty matcher for an alias entity type
+func NewNameMatcher(entityType string) *NameMatcher {
+	return &NameMatcher{
+		entityType: entityType,
+		byID:       make(map[int64]*nameMatchEntry),
+		byName:     make(map[string]*nameMatchEntry),
+		byAlias:    make(map[string]*nameMatchEntry),
+	}
+}
+
+// Add registers a movement or WOD; when names collide after normalization the standard one wins
+func (m *NameMatcher) Add(id int64, name string, isStandard bool) {
+	entry := &nameMatchEntry{id: id, name: name, key: domain.NormalizeName(name), isStandard: isStandard}
+	m.entries = append(m.entries, entry)
+	m.byID[id] = entry
+	if entry.key == "" {
+		return
+	}
+	if existing, ok := m.byName[entry.key]; !ok || (isStandard && !existing.isStandard) {
+		m.byName[entry.key] = entry
+	}
+}
+
+// AddAlias registers an alias of a movement or WOD already added to the matcher
+func (m *NameMatcher) AddAlias(alias *domain.NameAlias) {
+	entry, ok := m.byID[alias.EntityID]
+	if !ok {
+		return
+	}
+	entry.aliases = append(entry.aliases, alias)
+	m.byAlias[alias.NormalizedAlias] = entry
+}
+
+// Resolve finds the movement or WOD a name refers to, matching names before aliases
+func (m *NameMatcher) Resolve(name string) (*domain.NameCandidate, bool) {
+	key := domain.NormalizeName(name)
+	if key == "" {
+		return nil, false
+	}
+	if entry, ok := m.byName[key]; ok {
+		return entry.candidate(1, ""), true
+	}
+	if entry, ok := m.byAlias[key]; ok {
+		for _, alias := range entry.aliases {
+			if alias.NormalizedAlias == key {
+				return entry.candidate(1, alias.Alias), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Suggest lists up to limit movements or WODs whose name or an alias is similar to the given name,
+// best match first
+func (m *NameMatcher) Suggest(name string, limit int) []*domain.NameCandidate {
+	key := domain.NormalizeName(name)
+	if key == "" {
+		return nil
+	}
+
+	var candidates []*domain.NameCandidate
+	for _, entry := range m.entries {
+		best := nameSimilarity(key, entry.key)
+		matchedAlias := ""
+		for _, alias := range entry.aliases {
+			if score := nameSimilarity(key, alias.NormalizedAlias); score > best {
+				best = score
+				matchedAlias = alias.Alias
+			}
+		}
+		if best >= nameSuggestionThreshold {
+			candidates = append(candidates, entry.candidate(best, matchedAlias))
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].IsStandard != candidates[j].IsStandard {
+			return candidates[i].IsStandard
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+// Suggestion builds the import preview suggestion for a name that did not resolve, or nil when
+// nothing is similar enough
+func (m *NameMatcher) Suggestion(name string, limit int) *domain.NameSuggestion {
+	candidates := m.Suggest(name, limit)
+	if len(candidates) == 0 {
+		return nil
+	}
+	return &domain.NameSuggestion{EntityType: m.entityType, Name: name, Candidates: candidates}
+}
+
+func (e *nameMatchEntry) candidate(score float64, matchedAlias string) *domain.NameCandidate {
+	return &domain.NameCandidate{
+		ID:           e.id,
+		Name:         e.name,
+		IsStandard:   e.isStandard,
+		MatchedAlias: matchedAlias,
+		Score:        math.Round(score*100) / 100,
+	}
+}
+
+// nameSimilarity scores two normalized names from 0 to 1 as the better of their edit-distance
+// ratio (catches typos and plurals) and trigram overlap (catches reordered or extra words)
+func nameSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	editRatio := 1 - float64(levenshtein(ra, rb))/float64(longest)
+
+	if trigram := trigramSimilarity(a, b); trigram > editRatio {
+		return trigram
+	}
+	return editRatio
+}
+
+// levenshtein counts the single-rune insertions, deletions and substitutions between two strings
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// trigramSimilarity is the Dice coefficient of the padded three-rune substrings of two strings
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ta)+len(tb))
+}
+
+func trigrams(s string) map[string]bool {
+	runes := []rune("  " + s + " ")
+	set := make(map[string]bool)
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = true
+	}
+	return set
+}
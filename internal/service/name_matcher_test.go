@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+func TestNormalizeName(t *testing.T) {
+	for _, name := range []string{"Back Squat", "back squat", "Back-Squat", " BACK_SQUAT ", "BackSquat"} {
+		if got := domain.NormalizeName(name); got != "backsquat" {
+			t.Errorf("NormalizeName(%q) = %q, want backsquat", name, got)
+		}
+	}
+}
+
+func TestNameMatcher(t *testing.T) {
+	matcher := NewNameMatcher(domain.AliasEntityMovement)
+	matcher.Add(1, "Back Squat", true)
+	matcher.Add(2, "Front Squat", true)
+	matcher.Add(3, "Pull-up", true)
+	matcher.Add(4, "back squat", false)
+	matcher.AddAlias(&domain.NameAlias{EntityType: domain.AliasEntityMovement, EntityID: 1, Alias: "BS", NormalizedAlias: "bs"})
+
+	t.Run("resolves case and punctuation variants to the standard movement", func(t *testing.T) {
+		for _, name := range []string{"Back squat", "BACK-SQUAT", "backsquat"} {
+			match, ok := matcher.Resolve(name)
+			if !ok || match.ID != 1 {
+				t.Errorf("Resolve(%q) = %+v, want movement 1", name, match)
+			}
+		}
+	})
+
+	t.Run("resolves aliases", func(t *testing.T) {
+		match, ok := matcher.Resolve("bs")
+		if !ok || match.ID != 1 || match.MatchedAlias != "BS" {
+			t.Errorf("Resolve(bs) = %+v, want movement 1 via alias BS", match)
+		}
+	})
+
+	t.Run("suggests close names for typos and plurals", func(t *testing.T) {
+		if _, ok := matcher.Resolve("Pullups"); ok {
+			t.Fatal("Pullups should not resolve exactly")
+		}
+		suggestions := matcher.Suggest("Pullups", 3)
+		if len(suggestions) == 0 || suggestions[0].ID != 3 {
+			t.Fatalf("Suggest(Pullups) = %+v, want Pull-up first", suggestions)
+		}
+
+		suggestions = matcher.Suggest("Bak Squats", 3)
+		if len(suggestions) < 2 || suggestions[0].ID != 1 {
+			t.Errorf("Suggest(Bak Squats) = %+v, want Back Squat first", suggestions)
+		}
+	})
+
+	t.Run("no suggestion for unrelated names", func(t *testing.T) {
+		if suggestion := matcher.Suggestion("Rowing", 3); suggestion != nil {
+			t.Errorf("Suggestion(Rowing) = %+v, want none", suggestion.Candidates)
+		}
+	})
+}
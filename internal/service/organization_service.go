@@ -0,0 +1,550 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrGymNotFound          = errors.New("gym not found")
+	ErrGymNameRequired      = errors.New("gym name is required")
+	ErrGymSlugInvalid       = errors.New("gym slug must contain letters or numbers")
+	ErrGymSlugTaken         = errors.New("gym with this slug already exists")
+	ErrGymAccessDenied      = errors.New("not a member of this gym")
+	ErrGymAdminRequired     = errors.New("gym admin access required")
+	ErrGymMemberNotFound    = errors.New("gym member not found")
+	ErrGymMemberExists      = errors.New("user is already a member of this gym")
+	ErrGymInvalidRole       = errors.New("invalid gym role")
+	ErrGymLastAdmin         = errors.New("cannot remove or demote the last gym admin")
+	ErrGymInvalidEntityType = errors.New("entity type must be wod or movement")
+	ErrGymLibraryItemExists = errors.New("item is already in the gym library")
+)
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// OrganizationService handles gyms (organizations), their members and their curated libraries
+// Site admins (users.role = admin) can manage any gym; gym admins can manage their own gym
+type OrganizationService struct {
+	orgRepo         domain.OrganizationRepository
+	userRepo        domain.UserRepository
+	wodRepo         domain.WODRepository
+	movementRepo    domain.MovementRepository
+	wodService      *WODService
+	movementService *MovementService
+	auditLogService *AuditLogService
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(
+	orgRepo domain.OrganizationRepository,
+	userRepo domain.UserRepository,
+	wodRepo domain.WODRepository,
+	movementRepo domain.MovementRepository,
+	wodService *WODService,
+	movementService *MovementService,
+	auditLogService *AuditLogService,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:         orgRepo,
+		userRepo:        userRepo,
+		wodRepo:         wodRepo,
+		movementRepo:    movementRepo,
+		wodService:      wodService,
+		movementService: movementService,
+		auditLogService: auditLogService,
+	}
+}
+
+// GymLibrary is a gym's WOD and movement library layered on top of the global standard set
+type GymLibrary struct {
+	StandardWODs      []*domain.WOD      `json:"standard_wods"`
+	GymWODs           []*domain.WOD      `json:"gym_wods"`
+	StandardMovements []*domain.Movement `json:"standard_movements"`
+	GymMovements      []*domain.Movement `json:"gym_movements"`
+}
+
+// GymActor identifies who is calling the service and whether they are a site admin
+type GymActor struct {
+	UserID      int64
+	IsSiteAdmin bool
+	IPAddress   string
+	UserAgent   string
+}
+
+// CreateOrganization creates a gym (site admin only); adminEmail, if set, becomes the first gym admin
+func (s *OrganizationService) CreateOrganization(actor GymActor, name, slug, description, adminEmail string) (*domain.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrGymNameRequired
+	}
+	if slug == "" {
+		slug = name
+	}
+	slug = Slugify(slug)
+	if slug == "" {
+		return nil, ErrGymSlugInvalid
+	}
+
+	existing, err := s.orgRepo.GetBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check gym slug: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrGymSlugTaken
+	}
+
+	var firstAdmin *domain.User
+	if adminEmail != "" {
+		firstAdmin, err = s.userRepo.GetByEmail(strings.TrimSpace(adminEmail))
+		if err != nil || firstAdmin == nil {
+			return nil, ErrUserNotFound
+		}
+	}
+
+	org := &domain.Organization{
+		Name:        name,
+		Slug:        slug,
+		Description: strings.TrimSpace(description),
+		CreatedBy:   &actor.UserID,
+	}
+	if err := s.orgRepo.Create(org); err != nil {
+		return nil, fmt.Errorf("failed to create gym: %w", err)
+	}
+
+	if firstAdmin != nil {
+		member := &domain.OrganizationMember{OrganizationID: org.ID, UserID: firstAdmin.ID, Role: domain.OrganizationRoleAdmin}
+		if err := s.orgRepo.AddMember(member); err != nil {
+			return nil, fmt.Errorf("failed to add gym admin: %w", err)
+		}
+	}
+
+	s.logEvent(actor, domain.EventGymCreated, nil, map[string]interface{}{"organization_id": org.ID, "slug": org.Slug})
+	return org, nil
+}
+
+// ListOrganizations lists all gyms (site admin only)
+func (s *OrganizationService) ListOrganizations() ([]*domain.Organization, error) {
+	return s.orgRepo.List()
+}
+
+// ListMyOrganizations lists the gyms a user belongs to, with their role in each
+func (s *OrganizationService) ListMyOrganizations(userID int64) ([]*domain.Organization, error) {
+	return s.orgRepo.ListByUser(userID)
+}
+
+// GetOrganization retrieves a gym visible to the actor
+func (s *OrganizationService) GetOrganization(actor GymActor, orgID int64) (*domain.Organization, error) {
+	org, err := s.getOrganization(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.requireMember(actor, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if member != nil {
+		org.Role = member.Role
+	}
+	return org, nil
+}
+
+// UpdateOrganization updates a gym's name, slug and description (gym admin)
+// An empty name or slug, or a nil description, leaves that field unchanged
+func (s *OrganizationService) UpdateOrganization(actor GymActor, orgID int64, name, slug string, description *string) (*domain.Organization, error) {
+	org, err := s.getOrganization(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return nil, err
+	}
+
+	if name = strings.TrimSpace(name); name != "" {
+		org.Name = name
+	}
+	if slug != "" && Slugify(slug) == "" {
+		return nil, ErrGymSlugInvalid
+	}
+	if slug != "" && Slugify(slug) != org.Slug {
+		existing, err := s.orgRepo.GetBySlug(Slugify(slug))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check gym slug: %w", err)
+		}
+		if existing != nil {
+			return nil, ErrGymSlugTaken
+		}
+		org.Slug = Slugify(slug)
+	}
+	if description != nil {
+		org.Description = strings.TrimSpace(*description)
+	}
+
+	if err := s.orgRepo.Update(org); err != nil {
+		return nil, fmt.Errorf("failed to update gym: %w", err)
+	}
+
+	s.logEvent(actor, domain.EventGymUpdated, nil, map[string]interface{}{"organization_id": org.ID, "slug": org.Slug})
+	return org, nil
+}
+
+// DeleteOrganization deletes a gym (site admin only); WODs and movements themselves are kept
+func (s *OrganizationService) DeleteOrganization(actor GymActor, orgID int64) error {
+	org, err := s.getOrganization(orgID)
+	if err != nil {
+		return err
+	}
+	if err := s.orgRepo.Delete(orgID); err != nil {
+		return fmt.Errorf("failed to delete gym: %w", err)
+	}
+
+	s.logEvent(actor, domain.EventGymDeleted, nil, map[string]interface{}{"organization_id": org.ID, "slug": org.Slug})
+	return nil
+}
+
+// ListMembers lists a gym's members (gym admin)
+func (s *OrganizationService) ListMembers(actor GymActor, orgID int64) ([]*domain.OrganizationMember, error) {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(orgID)
+}
+
+// AddMember adds a user to a gym by email (gym admin)
+func (s *OrganizationService) AddMember(actor GymActor, orgID int64, email, role string) (*domain.OrganizationMember, error) {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return nil, err
+	}
+	if role == "" {
+		role = domain.OrganizationRoleMember
+	}
+	if !isValidGymRole(role) {
+		return nil, ErrGymInvalidRole
+	}
+
+	user, err := s.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	existing, err := s.orgRepo.GetMember(orgID, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check gym membership: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrGymMemberExists
+	}
+
+	member := &domain.OrganizationMember{OrganizationID: orgID, UserID: user.ID, Role: role}
+	if err := s.orgRepo.AddMember(member); err != nil {
+		return nil, fmt.Errorf("failed to add gym member: %w", err)
+	}
+	member.UserName = user.Name
+	member.UserEmail = user.Email
+
+	s.logEvent(actor, domain.EventGymMemberAdded, &user.ID, map[string]interface{}{"organization_id": orgID, "role": role})
+	return member, nil
+}
+
+// UpdateMemberRole promotes or demotes a gym member (gym admin)
+func (s *OrganizationService) UpdateMemberRole(actor GymActor, orgID, userID int64, role string) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return err
+	}
+	if !isValidGymRole(role) {
+		return ErrGymInvalidRole
+	}
+
+	member, err := s.orgRepo.GetMember(orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get gym member: %w", err)
+	}
+	if member == nil {
+		return ErrGymMemberNotFound
+	}
+	if member.Role == role {
+		return nil
+	}
+	if member.Role == domain.OrganizationRoleAdmin {
+		if err := s.ensureAnotherAdmin(orgID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.UpdateMemberRole(orgID, userID, role); err != nil {
+		return fmt.Errorf("failed to update gym member role: %w", err)
+	}
+
+	s.logEvent(actor, domain.EventGymMemberRoleChanged, &userID, map[string]interface{}{
+		"organization_id": orgID,
+		"old_role":        member.Role,
+		"new_role":        role,
+	})
+	return nil
+}
+
+// RemoveMember removes a user from a gym (gym admin)
+func (s *OrganizationService) RemoveMember(actor GymActor, orgID, userID int64) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return err
+	}
+
+	member, err := s.orgRepo.GetMember(orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get gym member: %w", err)
+	}
+	if member == nil {
+		return ErrGymMemberNotFound
+	}
+	if member.Role == domain.OrganizationRoleAdmin {
+		if err := s.ensureAnotherAdmin(orgID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.RemoveMember(orgID, userID); err != nil {
+		return fmt.Errorf("failed to remove gym member: %w", err)
+	}
+
+	s.logEvent(actor, domain.EventGymMemberRemoved, &userID, map[string]interface{}{"organization_id": orgID})
+	return nil
+}
+
+// GetLibrary returns the global standard WODs and movements plus the gym's curated items (gym member)
+func (s *OrganizationService) GetLibrary(actor GymActor, orgID int64) (*GymLibrary, error) {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireMember(actor, orgID); err != nil {
+		return nil, err
+	}
+
+	library := &GymLibrary{}
+	var err error
+	if library.StandardWODs, err = s.wodRepo.ListStandard(0, 0); err != nil {
+		return nil, fmt.Errorf("failed to list standard wods: %w", err)
+	}
+	if library.GymWODs, err = s.orgRepo.ListLibraryWODs(orgID); err != nil {
+		return nil, fmt.Errorf("failed to list gym wods: %w", err)
+	}
+	if library.StandardMovements, err = s.movementRepo.ListStandard(); err != nil {
+		return nil, fmt.Errorf("failed to list standard movements: %w", err)
+	}
+	if library.GymMovements, err = s.orgRepo.ListLibraryMovements(orgID); err != nil {
+		return nil, fmt.Errorf("failed to list gym movements: %w", err)
+	}
+
+	return library, nil
+}
+
+// CreateGymWOD creates a custom WOD owned by the gym admin and adds it to the gym library
+func (s *OrganizationService) CreateGymWOD(actor GymActor, orgID int64, wod *domain.WOD) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return err
+	}
+
+	return s.wodService.CreateInGymLibrary(wod, actor.UserID, orgID)
+}
+
+// CreateGymMovement creates a custom movement owned by the gym admin and adds it to the gym library
+func (s *OrganizationService) CreateGymMovement(actor GymActor, orgID int64, movement *domain.Movement) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return err
+	}
+
+	movement.CreatedBy = &actor.UserID
+	return s.movementService.CreateInGymLibrary(movement, orgID, actor.UserID)
+}
+
+// AddLibraryItem adds an existing WOD or movement to the gym library (gym admin)
+// Gym admins can only add custom items they created; site admins can add any custom item
+func (s *OrganizationService) AddLibraryItem(actor GymActor, orgID int64, entityType string, entityID int64) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return err
+	}
+
+	switch entityType {
+	case domain.LibraryEntityWOD:
+		wod, err := s.wodRepo.GetByID(entityID)
+		if err != nil || wod == nil {
+			return ErrWODNotFound
+		}
+		if !wod.IsStandard && !ownsLibraryItem(actor, wod.CreatedBy) {
+			return ErrWODNotFound // Another user's custom WOD is not visible to the actor
+		}
+		if wod.IsStandard {
+			return ErrGymLibraryItemExists // Already visible to every gym
+		}
+		existing, err := s.orgRepo.ListLibraryWODs(orgID)
+		if err != nil {
+			return fmt.Errorf("failed to list gym wods: %w", err)
+		}
+		for _, w := range existing {
+			if w.ID == entityID {
+				return ErrGymLibraryItemExists
+			}
+		}
+	case domain.LibraryEntityMovement:
+		movement, err := s.movementRepo.GetByID(entityID)
+		if err != nil || movement == nil {
+			return ErrMovementNotFound
+		}
+		if !movement.IsStandard && !ownsLibraryItem(actor, movement.CreatedBy) {
+			return ErrMovementNotFound // Another user's custom movement is not visible to the actor
+		}
+		if movement.IsStandard {
+			return ErrGymLibraryItemExists // Already visible to every gym
+		}
+		existing, err := s.orgRepo.ListLibraryMovements(orgID)
+		if err != nil {
+			return fmt.Errorf("failed to list gym movements: %w", err)
+		}
+		for _, m := range existing {
+			if m.ID == entityID {
+				return ErrGymLibraryItemExists
+			}
+		}
+	default:
+		return ErrGymInvalidEntityType
+	}
+
+	return s.orgRepo.AddLibraryItem(orgID, entityType, entityID, actor.UserID)
+}
+
+// RemoveLibraryItem removes a WOD or movement from the gym library (gym admin); the item itself is kept
+func (s *OrganizationService) RemoveLibraryItem(actor GymActor, orgID int64, entityType string, entityID int64) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	if err := s.requireAdmin(actor, orgID); err != nil {
+		return err
+	}
+	if entityType != domain.LibraryEntityWOD && entityType != domain.LibraryEntityMovement {
+		return ErrGymInvalidEntityType
+	}
+
+	return s.orgRepo.RemoveLibraryItem(orgID, entityType, entityID)
+}
+
+// AuthorizeMember checks that the gym exists and the actor belongs to it (site admins always pass)
+func (s *OrganizationService) AuthorizeMember(actor GymActor, orgID int64) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	_, err := s.requireMember(actor, orgID)
+	return err
+}
+
+// AuthorizeAdmin checks that the gym exists and the actor is one of its admins (site admins always pass)
+func (s *OrganizationService) AuthorizeAdmin(actor GymActor, orgID int64) error {
+	if _, err := s.getOrganization(orgID); err != nil {
+		return err
+	}
+	return s.requireAdmin(actor, orgID)
+}
+
+// IsMember reports whether a user belongs to a gym
+func (s *OrganizationService) IsMember(orgID, userID int64) (bool, error) {
+	member, err := s.orgRepo.GetMember(orgID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check gym membership: %w", err)
+	}
+	return member != nil, nil
+}
+
+// Slugify converts a name into a URL-friendly slug (e.g., "CrossFit Portland" -> "crossfit-portland")
+func Slugify(name string) string {
+	return strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func (s *OrganizationService) getOrganization(orgID int64) (*domain.Organization, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gym: %w", err)
+	}
+	if org == nil {
+		return nil, ErrGymNotFound
+	}
+	return org, nil
+}
+
+// requireMember returns the actor's membership; site admins pass without one (nil member)
+func (s *OrganizationService) requireMember(actor GymActor, orgID int64) (*domain.OrganizationMember, error) {
+	member, err := s.orgRepo.GetMember(orgID, actor.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check gym membership: %w", err)
+	}
+	if member == nil && !actor.IsSiteAdmin {
+		return nil, ErrGymAccessDenied
+	}
+	return member, nil
+}
+
+func (s *OrganizationService) requireAdmin(actor GymActor, orgID int64) error {
+	if actor.IsSiteAdmin {
+		return nil
+	}
+	member, err := s.orgRepo.GetMember(orgID, actor.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to check gym membership: %w", err)
+	}
+	if member == nil || member.Role != domain.OrganizationRoleAdmin {
+		return ErrGymAdminRequired
+	}
+	return nil
+}
+
+func (s *OrganizationService) ensureAnotherAdmin(orgID, userID int64) error {
+	members, err := s.orgRepo.ListMembers(orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list gym members: %w", err)
+	}
+	for _, m := range members {
+		if m.UserID != userID && m.Role == domain.OrganizationRoleAdmin {
+			return nil
+		}
+	}
+	return ErrGymLastAdmin
+}
+
+func (s *OrganizationService) logEvent(actor GymActor, eventType string, targetUserID *int64, details map[string]interface{}) {
+	if s.auditLogService == nil {
+		return
+	}
+	// Audit logging failures should not block gym administration
+	_ = s.auditLogService.LogEvent(eventType, &actor.UserID, targetUserID, &actor.IPAddress, &actor.UserAgent, details)
+}
+
+// ownsLibraryItem reports whether the actor may curate a custom WOD or movement with the given creator
+func ownsLibraryItem(actor GymActor, createdBy *int64) bool {
+	return actor.IsSiteAdmin || (createdBy != nil && *createdBy == actor.UserID)
+}
+
+func isValidGymRole(role string) bool {
+	return role == domain.OrganizationRoleMember || role == domain.OrganizationRoleAdmin
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+// newTestOrganizationService returns a service with gym 1 administered by user 1, where user 2 is a member
+// and user 3 is not. WOD 10 and movement 30 are standard; WOD 11 and movement 31 were created by user 1;
+// WOD 12 and movement 32 were created by user 3.
+func newTestOrganizationService() (*OrganizationService, *mockOrganizationRepo) {
+	userRepo := &mockUserRepo{users: map[int64]*domain.User{
+		1: {ID: 1, Email: "owner@example.com"},
+		2: {ID: 2, Email: "member@example.com"},
+		3: {ID: 3, Email: "outsider@example.com"},
+	}, nextID: 3}
+
+	wodRepo := newMockWODRepo()
+	wodRepo.wods[10] = &domain.WOD{ID: 10, Name: "Fran", IsStandard: true}
+	wodRepo.wods[11] = &domain.WOD{ID: 11, Name: "Gym Chipper", CreatedBy: int64Ptr(1)}
+	wodRepo.wods[12] = &domain.WOD{ID: 12, Name: "Private WOD", CreatedBy: int64Ptr(3)}
+
+	movementRepo := newMockMovementRepo()
+	movementRepo.movements[30] = &domain.Movement{ID: 30, Name: "Back Squat", IsStandard: true}
+	movementRepo.movements[31] = &domain.Movement{ID: 31, Name: "Sled Push", CreatedBy: int64Ptr(1)}
+	movementRepo.movements[32] = &domain.Movement{ID: 32, Name: "Private Move", CreatedBy: int64Ptr(3)}
+
+	orgRepo := newMockOrganizationRepo(wodRepo, movementRepo)
+	orgRepo.Create(&domain.Organization{Name: "CrossFit Portland", Slug: "crossfit-portland"})
+	orgRepo.AddMember(&domain.OrganizationMember{OrganizationID: 1, UserID: 1, Role: domain.OrganizationRoleAdmin})
+	orgRepo.AddMember(&domain.OrganizationMember{OrganizationID: 1, UserID: 2, Role: domain.OrganizationRoleMember})
+
+	return NewOrganizationService(orgRepo, userRepo, wodRepo, movementRepo, nil, nil, nil), orgRepo
+}
+
+func TestOrganizationServiceCreateSlug(t *testing.T) {
+	svc, _ := newTestOrganizationService()
+	siteAdmin := GymActor{UserID: 9, IsSiteAdmin: true}
+
+	org, err := svc.CreateOrganization(siteAdmin, "Iron Box Gym", "", "", "member@example.com")
+	if err != nil {
+		t.Fatalf("CreateOrganization() error = %v", err)
+	}
+	if org.Slug != "iron-box-gym" {
+		t.Errorf("expected the slug to default to the name, got %q", org.Slug)
+	}
+
+	for _, slug := range []string{"   ", "!!!"} {
+		if _, err := svc.CreateOrganization(siteAdmin, "Another Gym", slug, "", ""); !errors.Is(err, ErrGymSlugInvalid) {
+			t.Errorf("CreateOrganization(slug %q) expected ErrGymSlugInvalid, got %v", slug, err)
+		}
+	}
+	if _, err := svc.CreateOrganization(siteAdmin, "???", "", "", ""); !errors.Is(err, ErrGymSlugInvalid) {
+		t.Errorf("expected ErrGymSlugInvalid for a name without letters or numbers, got %v", err)
+	}
+	if _, err := svc.CreateOrganization(siteAdmin, "Iron Box", "Iron Box Gym", "", ""); !errors.Is(err, ErrGymSlugTaken) {
+		t.Errorf("expected ErrGymSlugTaken, got %v", err)
+	}
+	if _, err := svc.UpdateOrganization(siteAdmin, org.ID, "", " ", nil); !errors.Is(err, ErrGymSlugInvalid) {
+		t.Errorf("expected UpdateOrganization to reject a blank slug, got %v", err)
+	}
+}
+
+func TestOrganizationServiceMemberRoles(t *testing.T) {
+	svc, _ := newTestOrganizationService()
+	gymAdmin := GymActor{UserID: 1}
+	member := GymActor{UserID: 2}
+
+	if _, err := svc.AddMember(member, 1, "outsider@example.com", ""); !errors.Is(err, ErrGymAdminRequired) {
+		t.Errorf("expected ErrGymAdminRequired for a plain member, got %v", err)
+	}
+	if _, err := svc.AddMember(gymAdmin, 1, "outsider@example.com", "owner"); !errors.Is(err, ErrGymInvalidRole) {
+		t.Errorf("expected ErrGymInvalidRole, got %v", err)
+	}
+	added, err := svc.AddMember(gymAdmin, 1, "outsider@example.com", "")
+	if err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+	if added.Role != domain.OrganizationRoleMember {
+		t.Errorf("expected the default member role, got %s", added.Role)
+	}
+	if _, err := svc.AddMember(gymAdmin, 1, "outsider@example.com", ""); !errors.Is(err, ErrGymMemberExists) {
+		t.Errorf("expected ErrGymMemberExists, got %v", err)
+	}
+
+	// The only admin can neither step down nor be removed
+	if err := svc.UpdateMemberRole(gymAdmin, 1, 1, domain.OrganizationRoleMember); !errors.Is(err, ErrGymLastAdmin) {
+		t.Errorf("expected ErrGymLastAdmin demoting the last admin, got %v", err)
+	}
+	if err := svc.RemoveMember(gymAdmin, 1, 1); !errors.Is(err, ErrGymLastAdmin) {
+		t.Errorf("expected ErrGymLastAdmin removing the last admin, got %v", err)
+	}
+
+	if err := svc.UpdateMemberRole(gymAdmin, 1, 2, domain.OrganizationRoleAdmin); err != nil {
+		t.Fatalf("UpdateMemberRole() error = %v", err)
+	}
+	if err := svc.RemoveMember(member, 1, 1); err != nil {
+		t.Errorf("expected the promoted member to remove the other admin, got %v", err)
+	}
+	if err := svc.AuthorizeMember(gymAdmin, 1); !errors.Is(err, ErrGymAccessDenied) {
+		t.Errorf("expected ErrGymAccessDenied for a removed member, got %v", err)
+	}
+	if err := svc.AuthorizeAdmin(GymActor{UserID: 9, IsSiteAdmin: true}, 1); err != nil {
+		t.Errorf("expected site admins to administer any gym, got %v", err)
+	}
+}
+
+func TestOrganizationServiceLibrary(t *testing.T) {
+	svc, orgRepo := newTestOrganizationService()
+	gymAdmin := GymActor{UserID: 1}
+	member := GymActor{UserID: 2}
+	outsider := GymActor{UserID: 3}
+
+	if err := svc.AddLibraryItem(member, 1, domain.LibraryEntityWOD, 11); !errors.Is(err, ErrGymAdminRequired) {
+		t.Errorf("expected ErrGymAdminRequired for a plain member, got %v", err)
+	}
+	if err := svc.AddLibraryItem(gymAdmin, 1, "program", 11); !errors.Is(err, ErrGymInvalidEntityType) {
+		t.Errorf("expected ErrGymInvalidEntityType, got %v", err)
+	}
+
+	// Another user's custom items are reported as missing rather than forbidden
+	if err := svc.AddLibraryItem(gymAdmin, 1, domain.LibraryEntityWOD, 12); !errors.Is(err, ErrWODNotFound) {
+		t.Errorf("expected ErrWODNotFound for another user's WOD, got %v", err)
+	}
+	if err := svc.AddLibraryItem(gymAdmin, 1, domain.LibraryEntityMovement, 32); !errors.Is(err, ErrMovementNotFound) {
+		t.Errorf("expected ErrMovementNotFound for another user's movement, got %v", err)
+	}
+	if err := svc.AddLibraryItem(gymAdmin, 1, domain.LibraryEntityWOD, 99); !errors.Is(err, ErrWODNotFound) {
+		t.Errorf("expected ErrWODNotFound for a missing WOD, got %v", err)
+	}
+
+	// Standard items are already in every library
+	if err := svc.AddLibraryItem(gymAdmin, 1, domain.LibraryEntityWOD, 10); !errors.Is(err, ErrGymLibraryItemExists) {
+		t.Errorf("expected ErrGymLibraryItemExists for a standard WOD, got %v", err)
+	}
+
+	if err := svc.AddLibraryItem(gymAdmin, 1, domain.LibraryEntityWOD, 11); err != nil {
+		t.Fatalf("AddLibraryItem(wod) error = %v", err)
+	}
+	if err := svc.AddLibraryItem(gymAdmin, 1, domain.LibraryEntityWOD, 11); !errors.Is(err, ErrGymLibraryItemExists) {
+		t.Errorf("expected ErrGymLibraryItemExists adding twice, got %v", err)
+	}
+	if err := svc.AddLibraryItem(gymAdmin, 1, domain.LibraryEntityMovement, 31); err != nil {
+		t.Fatalf("AddLibraryItem(movement) error = %v", err)
+	}
+
+	// Site admins curate any custom item
+	if err := svc.AddLibraryItem(GymActor{UserID: 9, IsSiteAdmin: true}, 1, domain.LibraryEntityWOD, 12); err != nil {
+		t.Errorf("expected a site admin to add another user's WOD, got %v", err)
+	}
+
+	library, err := svc.GetLibrary(member, 1)
+	if err != nil {
+		t.Fatalf("GetLibrary() error = %v", err)
+	}
+	if len(library.StandardWODs) != 1 || len(library.GymWODs) != 2 || len(library.StandardMovements) != 1 || len(library.GymMovements) != 1 {
+		t.Errorf("unexpected library: %d standard WODs, %d gym WODs, %d standard movements, %d gym movements",
+			len(library.StandardWODs), len(library.GymWODs), len(library.StandardMovements), len(library.GymMovements))
+	}
+	if _, err := svc.GetLibrary(outsider, 1); !errors.Is(err, ErrGymAccessDenied) {
+		t.Errorf("expected ErrGymAccessDenied for a non-member, got %v", err)
+	}
+
+	if err := svc.RemoveLibraryItem(gymAdmin, 1, domain.LibraryEntityWOD, 11); err != nil {
+		t.Fatalf("RemoveLibraryItem() error = %v", err)
+	}
+	if len(orgRepo.library) != 2 {
+		t.Errorf("expected 2 library items left, got %d", len(orgRepo.library))
+	}
+}
+
+func TestOrganizationServiceUpdate(t *testing.T) {
+	svc, _ := newTestOrganizationService()
+	auditRepo := &mockAuditLogRepo{}
+	svc.auditLogService = NewAuditLogService(auditRepo)
+	gymAdmin := GymActor{UserID: 1}
+
+	description := " Barbell club "
+	org, err := svc.UpdateOrganization(gymAdmin, 1, "", "", &description)
+	if err != nil {
+		t.Fatalf("UpdateOrganization() error = %v", err)
+	}
+	if org.Description != "Barbell club" {
+		t.Errorf("expected the description to be set, got %q", org.Description)
+	}
+
+	// A missing description leaves it unchanged
+	if org, err = svc.UpdateOrganization(gymAdmin, 1, "CrossFit PDX", "", nil); err != nil {
+		t.Fatalf("UpdateOrganization() error = %v", err)
+	}
+	if org.Name != "CrossFit PDX" || org.Description != "Barbell club" {
+		t.Errorf("expected only the name to change, got %q / %q", org.Name, org.Description)
+	}
+
+	if got := auditRepo.eventTypes(); len(got) != 2 || got[0] != domain.EventGymUpdated {
+		t.Errorf("expected each update to be audited, got %v", got)
+	}
+	if _, err := svc.UpdateOrganization(GymActor{UserID: 2}, 1, "Taken Over", "", nil); !errors.Is(err, ErrGymAdminRequired) {
+		t.Errorf("expected ErrGymAdminRequired for a plain member, got %v", err)
+	}
+}
+
+func TestOrganizationServiceCreateGymWODIsAtomic(t *testing.T) {
+	db := openTestDB(t)
+	orgRepo := repository.NewOrganizationRepository(db)
+	wodRepo := repository.NewWODRepository(db)
+	movementRepo := repository.NewMovementRepository(db)
+	svc := NewOrganizationService(orgRepo, repository.NewSQLiteUserRepository(db), wodRepo, movementRepo,
+		NewWODService(wodRepo, nil), NewMovementService(movementRepo, nil), nil)
+	siteAdmin := GymActor{UserID: 1, IsSiteAdmin: true}
+
+	org, err := svc.CreateOrganization(siteAdmin, "Atomic Gym", "", "", "")
+	if err != nil {
+		t.Fatalf("CreateOrganization() error = %v", err)
+	}
+	wod := &domain.WOD{Name: "Atomic Chipper", Source: "Self-recorded", Type: "Self-created", Regime: "Fastest Time", ScoreType: domain.ScoreTypeTime}
+	if err := svc.CreateGymWOD(siteAdmin, org.ID, wod); err != nil {
+		t.Fatalf("CreateGymWOD() error = %v", err)
+	}
+	if gymWODs, _ := orgRepo.ListLibraryWODs(org.ID); len(gymWODs) != 1 || gymWODs[0].ID != wod.ID {
+		t.Errorf("expected the new WOD in the gym library, got %v", gymWODs)
+	}
+
+	// When the library link cannot be written the WOD and movement are not left behind
+	if _, err := db.Exec(`DROP TABLE organization_library`); err != nil {
+		t.Fatalf("failed to drop library table: %v", err)
+	}
+	orphan := &domain.WOD{Name: "Orphan Chipper", Source: "Self-recorded", Type: "Self-created", Regime: "Fastest Time", ScoreType: domain.ScoreTypeTime}
+	if err := svc.CreateGymWOD(siteAdmin, org.ID, orphan); err == nil {
+		t.Fatal("expected CreateGymWOD to fail without a library table")
+	}
+	if existing, _ := wodRepo.GetByName(orphan.Name); existing != nil {
+		t.Errorf("expected the WOD create to be rolled back, found WOD %d", existing.ID)
+	}
+	movement := &domain.Movement{Name: "Orphan Carry", Type: domain.MovementTypeGymnastics}
+	if err := svc.CreateGymMovement(siteAdmin, org.ID, movement); err == nil {
+		t.Fatal("expected CreateGymMovement to fail without a library table")
+	}
+	if existing, _ := movementRepo.GetByName(movement.Name); existing != nil {
+		t.Errorf("expected the movement create to be rolled back, found movement %d", existing.ID)
+	}
+}
+
+func TestOrganizationScopedAdminLists(t *testing.T) {
+	db := openTestDB(t)
+	userRepo := repository.NewSQLiteUserRepository(db)
+	orgRepo := repository.NewOrganizationRepository(db)
+	wodRepo := repository.NewWODRepository(db)
+	orgService := NewOrganizationService(orgRepo, userRepo, wodRepo, repository.NewMovementRepository(db), nil, nil, nil)
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	member := &domain.User{Email: "member@example.com", Name: "Member", Role: "user", CreatedAt: day, UpdatedAt: day}
+	outsider := &domain.User{Email: "outsider@example.com", Name: "Outsider", Role: "user", CreatedAt: day, UpdatedAt: day}
+	for _, u := range []*domain.User{member, outsider} {
+		if err := userRepo.Create(u); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+	org, err := orgService.CreateOrganization(GymActor{UserID: member.ID, IsSiteAdmin: true}, "Scoped Gym", "", "", member.Email)
+	if err != nil {
+		t.Fatalf("CreateOrganization() error = %v", err)
+	}
+	for _, u := range []*domain.User{member, outsider} {
+		wod := &domain.WOD{Name: u.Name + " Chipper", Source: "Self-recorded", Type: "Self-created", Regime: "Fastest Time",
+			ScoreType: domain.ScoreTypeTime, CreatedBy: &u.ID}
+		if err := wodRepo.Create(wod); err != nil {
+			t.Fatalf("failed to create wod: %v", err)
+		}
+	}
+
+	userService := NewUserService(userRepo, nil, nil, "test-secret-key", time.Hour, time.Hour, false, nil, "", false, 5, time.Minute)
+	users, total, err := userService.ListOrganizationUsers(org.ID, 50, 0)
+	if err != nil {
+		t.Fatalf("ListOrganizationUsers() error = %v", err)
+	}
+	if total != 1 || len(users) != 1 || users[0].ID != member.ID || users[0].PasswordHash != "" {
+		t.Errorf("expected only the gym member without a password hash, got %d of %d", len(users), total)
+	}
+	if isMember, err := orgService.IsMember(org.ID, outsider.ID); err != nil || isMember {
+		t.Errorf("expected the outsider not to be a member, got %v (err %v)", isMember, err)
+	}
+
+	wods, count, err := NewWODService(wodRepo, nil).ListAllUserCreatedWithUserInfoFiltered(50, 0, "", "", "", org.ID)
+	if err != nil {
+		t.Fatalf("ListAllUserCreatedWithUserInfoFiltered() error = %v", err)
+	}
+	if count != 1 || len(wods) != 1 || wods[0].Name != "Member Chipper" {
+		t.Errorf("expected only the member's WOD in the gym scope, got %d of %d", len(wods), count)
+	}
+	if _, count, _ := NewWODService(wodRepo, nil).ListAllUserCreatedWithUserInfoFiltered(50, 0, "", "", "", 0); count != 2 {
+		t.Errorf("expected every user's WOD without a gym scope, got %d", count)
+	}
+}
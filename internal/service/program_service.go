@@ -0,0 +1,589 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/pkg/prmath"
+)
+
+var (
+	ErrProgramNotFound         = errors.New("program not found")
+	ErrProgramUnauthorized     = errors.New("unauthorized to modify this program")
+	ErrProgramNameRequired     = errors.New("program name is required")
+	ErrProgramInvalidWeeks     = errors.New("program must have between 1 and 52 weeks")
+	ErrProgramNoSessions       = errors.New("program must have at least one session")
+	ErrProgramInvalidSession   = errors.New("session week must be within the program and day between 1 and 7")
+	ErrProgramInvalidRule      = errors.New("invalid progression rule")
+	ErrEnrollmentNotFound      = errors.New("enrollment not found")
+	ErrEnrollmentNotActive     = errors.New("enrollment is not active")
+	ErrSessionNotInProgram     = errors.New("session does not belong to the enrolled program")
+	ErrSessionAlreadyCompleted = errors.New("session already completed")
+)
+
+// maxProgramWeeks bounds program length (a year of training)
+const maxProgramWeeks = 52
+
+// oneRepMaxHistoryLimit is how many past performances are scanned for the best estimated 1RM
+const oneRepMaxHistoryLimit = 500
+
+// ProgramService handles multi-week training programs, enrollments and session prescriptions
+type ProgramService struct {
+	programRepo             domain.ProgramRepository
+	workoutRepo             domain.WorkoutRepository
+	movementRepo            domain.MovementRepository
+	userWorkoutRepo         domain.UserWorkoutRepository
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository
+}
+
+// NewProgramService creates a new program service
+func NewProgramService(
+	programRepo domain.ProgramRepository,
+	workoutRepo domain.WorkoutRepository,
+	movementRepo domain.MovementRepository,
+	userWorkoutRepo domain.UserWorkoutRepository,
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository,
+) *ProgramService {
+	return &ProgramService{
+		programRepo:             programRepo,
+		workoutRepo:             workoutRepo,
+		movementRepo:            movementRepo,
+		userWorkoutRepo:         userWorkoutRepo,
+		userWorkoutMovementRepo: userWorkoutMovementRepo,
+	}
+}
+
+// PrescribedMovement is a template movement with the load computed for the athlete's current week
+type PrescribedMovement struct {
+	MovementID   int64    `json:"movement_id"`
+	MovementName string   `json:"movement_name"`
+	Sets         *int     `json:"sets,omitempty"`
+	Reps         *int     `json:"reps,omitempty"`
+	Weight       *float64 `json:"weight,omitempty"`      // Concrete load to use
+	RuleType     string   `json:"rule_type,omitempty"`   // Progression rule applied, if any
+	Percentage   *float64 `json:"percentage,omitempty"`  // percent_1rm: fraction of 1RM used
+	OneRepMax    *float64 `json:"one_rep_max,omitempty"` // percent_1rm: best estimated 1RM the load is based on
+	Notes        string   `json:"notes,omitempty"`
+}
+
+// ProgramSessionStatus is a session with its due date and completion state for an enrollment
+type ProgramSessionStatus struct {
+	Session       *domain.ProgramSession `json:"session"`
+	DueDate       string                 `json:"due_date"` // YYYY-MM-DD
+	Completed     bool                   `json:"completed"`
+	CompletedAt   *time.Time             `json:"completed_at,omitempty"`
+	UserWorkoutID *int64                 `json:"user_workout_id,omitempty"`
+}
+
+// ProgramProgress summarizes completion of an enrollment
+type ProgramProgress struct {
+	Enrollment        *domain.ProgramEnrollment `json:"enrollment"`
+	TotalSessions     int                       `json:"total_sessions"`
+	CompletedSessions int                       `json:"completed_sessions"`
+	PercentComplete   float64                   `json:"percent_complete"`
+	Sessions          []*ProgramSessionStatus   `json:"sessions"`
+}
+
+// ProgramNextSession is the next uncompleted session with concrete weights
+type ProgramNextSession struct {
+	Enrollment        *domain.ProgramEnrollment `json:"enrollment"`
+	Session           *domain.ProgramSession    `json:"session"`
+	DueDate           string                    `json:"due_date"` // YYYY-MM-DD
+	Workout           *domain.Workout           `json:"workout"`
+	Prescriptions     []*PrescribedMovement     `json:"prescriptions"`
+	CompletedSessions int                       `json:"completed_sessions"`
+	TotalSessions     int                       `json:"total_sessions"`
+}
+
+// ListPrograms lists standard programs and the user's own programs
+func (s *ProgramService) ListPrograms(userID int64) ([]*domain.Program, error) {
+	return s.programRepo.ListForUser(userID)
+}
+
+// GetProgram retrieves a program visible to the user (standard or own)
+func (s *ProgramService) GetProgram(id, userID int64) (*domain.Program, error) {
+	program, err := s.programRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+	if program == nil || (program.CreatedBy != nil && *program.CreatedBy != userID) {
+		return nil, ErrProgramNotFound
+	}
+	return program, nil
+}
+
+// CreateProgram creates a program owned by the user
+func (s *ProgramService) CreateProgram(userID int64, program *domain.Program) error {
+	if err := s.validateProgram(userID, program); err != nil {
+		return err
+	}
+
+	program.CreatedBy = &userID
+	if err := s.programRepo.Create(program); err != nil {
+		return fmt.Errorf("failed to create program: %w", err)
+	}
+	return nil
+}
+
+// UpdateProgram replaces a program's definition (owner only)
+// Sessions keep their completions when the same week and day is still scheduled; removed sessions lose theirs
+func (s *ProgramService) UpdateProgram(id, userID int64, update *domain.Program) (*domain.Program, error) {
+	if _, err := s.getOwnedProgram(id, userID); err != nil {
+		return nil, err
+	}
+	if err := s.validateProgram(userID, update); err != nil {
+		return nil, err
+	}
+
+	update.ID = id
+	if err := s.programRepo.Update(update); err != nil {
+		return nil, fmt.Errorf("failed to update program: %w", err)
+	}
+	return s.programRepo.GetByID(id)
+}
+
+// DeleteProgram deletes a program and its enrollments (owner only); logged workouts are kept
+func (s *ProgramService) DeleteProgram(id, userID int64) error {
+	if _, err := s.getOwnedProgram(id, userID); err != nil {
+		return err
+	}
+	return s.programRepo.Delete(id)
+}
+
+// Enroll starts a program for the user on startDate (YYYY-MM-DD)
+func (s *ProgramService) Enroll(userID, programID int64, startDate string) (*domain.ProgramEnrollment, error) {
+	program, err := s.GetProgram(programID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(program.Sessions) == 0 {
+		return nil, ErrProgramNoSessions
+	}
+	if _, err := time.Parse(domain.ScheduledDateFormat, startDate); err != nil {
+		return nil, ErrInvalidScheduleDate
+	}
+
+	enrollment := &domain.ProgramEnrollment{
+		ProgramID:   programID,
+		UserID:      userID,
+		StartDate:   startDate,
+		Status:      domain.EnrollmentStatusActive,
+		ProgramName: program.Name,
+	}
+	if err := s.programRepo.CreateEnrollment(enrollment); err != nil {
+		return nil, fmt.Errorf("failed to enroll: %w", err)
+	}
+	return enrollment, nil
+}
+
+// ListEnrollments lists the user's enrollments (empty status = all)
+func (s *ProgramService) ListEnrollments(userID int64, status string) ([]*domain.ProgramEnrollment, error) {
+	return s.programRepo.ListEnrollmentsByUser(userID, status)
+}
+
+// CancelEnrollment stops an active enrollment
+func (s *ProgramService) CancelEnrollment(userID, enrollmentID int64) error {
+	enrollment, err := s.getEnrollment(userID, enrollmentID)
+	if err != nil {
+		return err
+	}
+	if enrollment.Status != domain.EnrollmentStatusActive {
+		return ErrEnrollmentNotActive
+	}
+
+	enrollment.Status = domain.EnrollmentStatusCancelled
+	return s.programRepo.UpdateEnrollment(enrollment)
+}
+
+// GetProgress returns every session of an enrollment with due dates and completion state
+func (s *ProgramService) GetProgress(userID, enrollmentID int64) (*ProgramProgress, error) {
+	enrollment, err := s.getEnrollment(userID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	_, progress, err := s.loadProgress(enrollment)
+	return progress, err
+}
+
+// GetNextSession returns the first uncompleted session (by week and day) with concrete weights
+// Returns nil when every session is completed
+func (s *ProgramService) GetNextSession(userID, enrollmentID int64) (*ProgramNextSession, error) {
+	enrollment, err := s.getEnrollment(userID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	program, progress, err := s.loadProgress(enrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *ProgramSessionStatus
+	for _, status := range progress.Sessions {
+		if !status.Completed {
+			next = status
+			break
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+
+	workout, err := s.workoutRepo.GetByIDWithDetails(next.Session.WorkoutID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workout template: %w", err)
+	}
+	if workout == nil {
+		return nil, ErrWorkoutNotFound
+	}
+
+	prescriptions, err := s.prescribe(userID, program, next.Session.Week, workout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProgramNextSession{
+		Enrollment:        enrollment,
+		Session:           next.Session,
+		DueDate:           next.DueDate,
+		Workout:           workout,
+		Prescriptions:     prescriptions,
+		CompletedSessions: progress.CompletedSessions,
+		TotalSessions:     progress.TotalSessions,
+	}, nil
+}
+
+// CompleteSession marks a session done, optionally linking the logged workout
+// The enrollment is marked completed once every session is done
+func (s *ProgramService) CompleteSession(userID, enrollmentID, sessionID int64, userWorkoutID *int64) (*ProgramProgress, error) {
+	enrollment, err := s.getEnrollment(userID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.Status != domain.EnrollmentStatusActive {
+		return nil, ErrEnrollmentNotActive
+	}
+
+	_, progress, err := s.loadProgress(enrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *ProgramSessionStatus
+	for _, status := range progress.Sessions {
+		if status.Session.ID == sessionID {
+			target = status
+			break
+		}
+	}
+	if target == nil {
+		return nil, ErrSessionNotInProgram
+	}
+	if target.Completed {
+		return nil, ErrSessionAlreadyCompleted
+	}
+
+	if userWorkoutID != nil {
+		userWorkout, err := s.userWorkoutRepo.GetByID(*userWorkoutID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logged workout: %w", err)
+		}
+		if userWorkout == nil || userWorkout.UserID != userID {
+			return nil, ErrUserWorkoutNotFound
+		}
+	}
+
+	completion := &domain.ProgramSessionCompletion{
+		EnrollmentID:  enrollmentID,
+		SessionID:     sessionID,
+		UserWorkoutID: userWorkoutID,
+	}
+	if err := s.programRepo.CreateCompletion(completion); err != nil {
+		return nil, fmt.Errorf("failed to complete session: %w", err)
+	}
+
+	if progress.CompletedSessions+1 == progress.TotalSessions {
+		now := time.Now()
+		enrollment.Status = domain.EnrollmentStatusCompleted
+		enrollment.CompletedAt = &now
+		if err := s.programRepo.UpdateEnrollment(enrollment); err != nil {
+			return nil, fmt.Errorf("failed to complete enrollment: %w", err)
+		}
+	}
+
+	_, progress, err = s.loadProgress(enrollment)
+	return progress, err
+}
+
+// loadProgress loads the enrolled program and merges its sessions with recorded completions
+func (s *ProgramService) loadProgress(enrollment *domain.ProgramEnrollment) (*domain.Program, *ProgramProgress, error) {
+	program, err := s.programRepo.GetByID(enrollment.ProgramID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get program: %w", err)
+	}
+	if program == nil {
+		return nil, nil, ErrProgramNotFound
+	}
+
+	completions, err := s.programRepo.ListCompletions(enrollment.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	completedBySession := make(map[int64]*domain.ProgramSessionCompletion, len(completions))
+	for _, c := range completions {
+		completedBySession[c.SessionID] = c
+	}
+
+	start, err := time.Parse(domain.ScheduledDateFormat, enrollment.StartDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("enrollment has invalid start date %q: %w", enrollment.StartDate, err)
+	}
+
+	progress := &ProgramProgress{
+		Enrollment:    enrollment,
+		TotalSessions: len(program.Sessions),
+		Sessions:      make([]*ProgramSessionStatus, 0, len(program.Sessions)),
+	}
+	for _, session := range program.Sessions {
+		status := &ProgramSessionStatus{
+			Session: session,
+			DueDate: sessionDueDate(start, session.Week, session.Day).Format(domain.ScheduledDateFormat),
+		}
+		if c, ok := completedBySession[session.ID]; ok {
+			status.Completed = true
+			status.CompletedAt = &c.CompletedAt
+			status.UserWorkoutID = c.UserWorkoutID
+			progress.CompletedSessions++
+		}
+		progress.Sessions = append(progress.Sessions, status)
+	}
+	if progress.TotalSessions > 0 {
+		progress.PercentComplete = math.Round(float64(progress.CompletedSessions)/float64(progress.TotalSessions)*1000) / 10
+	}
+
+	return program, progress, nil
+}
+
+// prescribe computes concrete loads for a template's movements in the given program week
+func (s *ProgramService) prescribe(userID int64, program *domain.Program, week int, workout *domain.Workout) ([]*PrescribedMovement, error) {
+	rules := make(map[int64]*domain.ProgramProgression, len(program.Progressions))
+	for _, p := range program.Progressions {
+		rules[p.MovementID] = p
+	}
+
+	prescriptions := make([]*PrescribedMovement, 0, len(workout.Movements))
+	for _, wm := range workout.Movements {
+		pm := &PrescribedMovement{
+			MovementID: wm.MovementID,
+			Sets:       wm.Sets,
+			Reps:       wm.Reps,
+			Weight:     wm.Weight,
+			Notes:      wm.Notes,
+		}
+		if wm.Movement != nil {
+			pm.MovementName = wm.Movement.Name
+		}
+
+		if rule, ok := rules[wm.MovementID]; ok {
+			pm.RuleType = rule.RuleType
+			var oneRM float64
+			if rule.RuleType == domain.ProgressionPercent1RM {
+				best, err := s.bestEstimated1RM(userID, wm.MovementID)
+				if err != nil {
+					return nil, err
+				}
+				if best > 0 {
+					oneRM = best
+					pm.OneRepMax = &best
+				}
+				if pct, ok := weekPercentage(rule.Percentages, week); ok {
+					pm.Percentage = &pct
+				}
+			}
+			if weight := prescribeWeight(rule, week, wm.Weight, oneRM); weight != nil {
+				pm.Weight = weight
+			}
+		}
+
+		prescriptions = append(prescriptions, pm)
+	}
+
+	return prescriptions, nil
+}
+
+// bestEstimated1RM returns the user's best estimated 1RM for a movement across logged sets (0 if none)
+func (s *ProgramService) bestEstimated1RM(userID, movementID int64) (float64, error) {
+	history, err := s.userWorkoutMovementRepo.GetByUserIDAndMovementID(userID, movementID, oneRepMaxHistoryLimit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get movement history: %w", err)
+	}
+
+	var best float64
+	for _, perf := range history {
+		if perf.Weight == nil || perf.Reps == nil {
+			continue
+		}
+		if oneRM, _ := prmath.Calculate1RM(*perf.Weight, *perf.Reps); oneRM > best {
+			best = oneRM
+		}
+	}
+	return best, nil
+}
+
+// prescribeWeight applies a progression rule for a 1-based week
+// Linear: base (or template) weight + increment per completed week
+// percent_1rm: the week's percentage (last one repeats) of oneRM
+// Returns nil when the rule cannot produce a load (no base weight, no 1RM on record)
+func prescribeWeight(rule *domain.ProgramProgression, week int, templateWeight *float64, oneRM float64) *float64 {
+	var weight float64
+
+	switch rule.RuleType {
+	case domain.ProgressionLinear:
+		base := rule.BaseWeight
+		if base == nil {
+			base = templateWeight
+		}
+		if base == nil {
+			return nil
+		}
+		weight = *base
+		if rule.Increment != nil && week > 1 {
+			weight += *rule.Increment * float64(week-1)
+		}
+	case domain.ProgressionPercent1RM:
+		pct, ok := weekPercentage(rule.Percentages, week)
+		if !ok || oneRM <= 0 {
+			return nil
+		}
+		weight = oneRM * pct
+	default:
+		return nil
+	}
+
+	if rule.RoundTo > 0 {
+		weight = math.Round(weight/rule.RoundTo) * rule.RoundTo
+	}
+	return &weight
+}
+
+// weekPercentage returns the percentage for a 1-based week; weeks past the list repeat the last value
+func weekPercentage(percentages []float64, week int) (float64, bool) {
+	if len(percentages) == 0 || week < 1 {
+		return 0, false
+	}
+	if week > len(percentages) {
+		return percentages[len(percentages)-1], true
+	}
+	return percentages[week-1], true
+}
+
+// sessionDueDate is the start date plus (week-1) weeks and (day-1) days
+func sessionDueDate(start time.Time, week, day int) time.Time {
+	return start.AddDate(0, 0, (week-1)*7+(day-1))
+}
+
+func (s *ProgramService) getOwnedProgram(id, userID int64) (*domain.Program, error) {
+	program, err := s.programRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+	if program == nil {
+		return nil, ErrProgramNotFound
+	}
+	if program.CreatedBy == nil || *program.CreatedBy != userID {
+		return nil, ErrProgramUnauthorized
+	}
+	return program, nil
+}
+
+func (s *ProgramService) getEnrollment(userID, enrollmentID int64) (*domain.ProgramEnrollment, error) {
+	enrollment, err := s.programRepo.GetEnrollment(enrollmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollment: %w", err)
+	}
+	if enrollment == nil || enrollment.UserID != userID {
+		return nil, ErrEnrollmentNotFound
+	}
+	return enrollment, nil
+}
+
+// validateProgram checks structure, template access and progression rules
+func (s *ProgramService) validateProgram(userID int64, program *domain.Program) error {
+	program.Name = strings.TrimSpace(program.Name)
+	if program.Name == "" {
+		return ErrProgramNameRequired
+	}
+	if program.Weeks < 1 || program.Weeks > maxProgramWeeks {
+		return ErrProgramInvalidWeeks
+	}
+	if len(program.Sessions) == 0 {
+		return ErrProgramNoSessions
+	}
+
+	checked := make(map[int64]bool)
+	for _, session := range program.Sessions {
+		if session.Week < 1 || session.Week > program.Weeks || session.Day < 1 || session.Day > 7 {
+			return ErrProgramInvalidSession
+		}
+		if checked[session.WorkoutID] {
+			continue
+		}
+		workout, err := s.workoutRepo.GetByID(session.WorkoutID)
+		if err != nil {
+			return fmt.Errorf("failed to get workout template: %w", err)
+		}
+		if workout == nil {
+			return ErrWorkoutNotFound
+		}
+		// Programs can use standard templates or the user's own
+		if workout.CreatedBy != nil && *workout.CreatedBy != userID {
+			return ErrUnauthorizedWorkoutAccess
+		}
+		checked[session.WorkoutID] = true
+	}
+
+	seen := make(map[int64]bool)
+	for _, rule := range program.Progressions {
+		if seen[rule.MovementID] {
+			return fmt.Errorf("%w: duplicate rule for movement %d", ErrProgramInvalidRule, rule.MovementID)
+		}
+		seen[rule.MovementID] = true
+
+		movement, err := s.movementRepo.GetByID(rule.MovementID)
+		if err != nil {
+			return fmt.Errorf("failed to get movement: %w", err)
+		}
+		if movement == nil {
+			return ErrMovementNotFound
+		}
+
+		switch rule.RuleType {
+		case domain.ProgressionLinear:
+			if rule.Increment == nil {
+				return fmt.Errorf("%w: linear rule needs an increment", ErrProgramInvalidRule)
+			}
+			rule.Percentages = nil
+		case domain.ProgressionPercent1RM:
+			if len(rule.Percentages) == 0 {
+				return fmt.Errorf("%w: percent_1rm rule needs weekly percentages", ErrProgramInvalidRule)
+			}
+			for _, pct := range rule.Percentages {
+				if pct <= 0 || pct > 1.5 {
+					return fmt.Errorf("%w: percentages are fractions of 1RM (e.g., 0.75)", ErrProgramInvalidRule)
+				}
+			}
+			rule.BaseWeight = nil
+			rule.Increment = nil
+		default:
+			return fmt.Errorf("%w: unknown rule type %q", ErrProgramInvalidRule, rule.RuleType)
+		}
+		if rule.RoundTo <= 0 {
+			rule.RoundTo = 5
+		}
+	}
+
+	return nil
+}
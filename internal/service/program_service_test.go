@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+func TestPrescribeWeight(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name           string
+		rule           *domain.ProgramProgression
+		week           int
+		templateWeight *float64
+		oneRM          float64
+		expected       *float64
+	}{
+		{
+			name:     "linear week 1 uses base weight",
+			rule:     &domain.ProgramProgression{RuleType: domain.ProgressionLinear, BaseWeight: f(185), Increment: f(5), RoundTo: 5},
+			week:     1,
+			expected: f(185),
+		},
+		{
+			name:     "linear adds increment per week",
+			rule:     &domain.ProgramProgression{RuleType: domain.ProgressionLinear, BaseWeight: f(185), Increment: f(5), RoundTo: 5},
+			week:     12,
+			expected: f(240),
+		},
+		{
+			name:           "linear falls back to template weight",
+			rule:           &domain.ProgramProgression{RuleType: domain.ProgressionLinear, Increment: f(10), RoundTo: 5},
+			week:           3,
+			templateWeight: f(135),
+			expected:       f(155),
+		},
+		{
+			name:     "linear without any base weight",
+			rule:     &domain.ProgramProgression{RuleType: domain.ProgressionLinear, Increment: f(5), RoundTo: 5},
+			week:     2,
+			expected: nil,
+		},
+		{
+			name:     "percent of 1RM rounded",
+			rule:     &domain.ProgramProgression{RuleType: domain.ProgressionPercent1RM, Percentages: []float64{0.65, 0.7, 0.75}, RoundTo: 5},
+			week:     2,
+			oneRM:    300,
+			expected: f(210),
+		},
+		{
+			name:     "percent repeats last week's value",
+			rule:     &domain.ProgramProgression{RuleType: domain.ProgressionPercent1RM, Percentages: []float64{0.65, 0.7, 0.75}, RoundTo: 2.5},
+			week:     8,
+			oneRM:    311,
+			expected: f(232.5),
+		},
+		{
+			name:     "percent without 1RM on record",
+			rule:     &domain.ProgramProgression{RuleType: domain.ProgressionPercent1RM, Percentages: []float64{0.7}, RoundTo: 5},
+			week:     1,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := prescribeWeight(tt.rule, tt.week, tt.templateWeight, tt.oneRM)
+			if tt.expected == nil {
+				if got != nil {
+					t.Errorf("expected no weight, got %v", *got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("expected %v, got nil", *tt.expected)
+			}
+			if *got != *tt.expected {
+				t.Errorf("expected %v, got %v", *tt.expected, *got)
+			}
+		})
+	}
+}
+
+func TestSessionDueDate(t *testing.T) {
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	got := sessionDueDate(start, 3, 5).Format(domain.ScheduledDateFormat)
+	if got != "2025-01-24" {
+		t.Errorf("expected 2025-01-24, got %s", got)
+	}
+}
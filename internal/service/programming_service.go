@@ -0,0 +1,256 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrScheduledWorkoutNotFound = errors.New("scheduled workout not found")
+	ErrScheduledWorkoutEmpty    = errors.New("a scheduled workout needs a workout template or a WOD")
+	ErrInvalidScheduleDate      = errors.New("invalid date format, use YYYY-MM-DD")
+	ErrInvalidScheduleRange     = errors.New("start date must not be after end date")
+)
+
+// ProgrammingService handles a gym's programming calendar
+// Gym admins (coaches) publish templates or WODs for dates; gym members fetch and log them
+type ProgrammingService struct {
+	scheduledRepo      domain.ScheduledWorkoutRepository
+	workoutRepo        domain.WorkoutRepository
+	wodRepo            domain.WODRepository
+	orgService         *OrganizationService
+	userWorkoutService *UserWorkoutService
+}
+
+// NewProgrammingService creates a new programming service
+func NewProgrammingService(
+	scheduledRepo domain.ScheduledWorkoutRepository,
+	workoutRepo domain.WorkoutRepository,
+	wodRepo domain.WODRepository,
+	orgService *OrganizationService,
+	userWorkoutService *UserWorkoutService,
+) *ProgrammingService {
+	return &ProgrammingService{
+		scheduledRepo:      scheduledRepo,
+		workoutRepo:        workoutRepo,
+		wodRepo:            wodRepo,
+		orgService:         orgService,
+		userWorkoutService: userWorkoutService,
+	}
+}
+
+// Schedule publishes a workout template and/or WOD on a gym's calendar (gym admin)
+func (s *ProgrammingService) Schedule(actor GymActor, orgID int64, sw *domain.ScheduledWorkout) error {
+	if err := s.orgService.AuthorizeAdmin(actor, orgID); err != nil {
+		return err
+	}
+	if err := s.validate(actor, sw); err != nil {
+		return err
+	}
+
+	sw.OrganizationID = orgID
+	sw.CreatedBy = &actor.UserID
+	if err := s.scheduledRepo.Create(sw); err != nil {
+		return fmt.Errorf("failed to schedule workout: %w", err)
+	}
+	return nil
+}
+
+// UpdateScheduled changes the date, track, content or notes of a scheduled workout (gym admin)
+func (s *ProgrammingService) UpdateScheduled(actor GymActor, orgID, id int64, update *domain.ScheduledWorkout) (*domain.ScheduledWorkout, error) {
+	if err := s.orgService.AuthorizeAdmin(actor, orgID); err != nil {
+		return nil, err
+	}
+	existing, err := s.getForGym(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validate(actor, update); err != nil {
+		return nil, err
+	}
+
+	existing.ScheduledDate = update.ScheduledDate
+	existing.Track = update.Track
+	existing.WorkoutID = update.WorkoutID
+	existing.WODID = update.WODID
+	existing.Title = update.Title
+	existing.Notes = update.Notes
+
+	if err := s.scheduledRepo.Update(existing); err != nil {
+		return nil, fmt.Errorf("failed to update scheduled workout: %w", err)
+	}
+	return s.scheduledRepo.GetByID(id)
+}
+
+// DeleteScheduled removes a workout from a gym's calendar (gym admin); logged workouts are kept
+func (s *ProgrammingService) DeleteScheduled(actor GymActor, orgID, id int64) error {
+	if err := s.orgService.AuthorizeAdmin(actor, orgID); err != nil {
+		return err
+	}
+	if _, err := s.getForGym(orgID, id); err != nil {
+		return err
+	}
+	return s.scheduledRepo.Delete(id)
+}
+
+// ListCalendar lists a gym's scheduled workouts between two dates inclusive (gym member)
+func (s *ProgrammingService) ListCalendar(actor GymActor, orgID int64, startDate, endDate, track string) ([]*domain.ScheduledWorkout, error) {
+	if err := s.orgService.AuthorizeMember(actor, orgID); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(domain.ScheduledDateFormat, startDate)
+	if err != nil {
+		return nil, ErrInvalidScheduleDate
+	}
+	end, err := time.Parse(domain.ScheduledDateFormat, endDate)
+	if err != nil {
+		return nil, ErrInvalidScheduleDate
+	}
+	if start.After(end) {
+		return nil, ErrInvalidScheduleRange
+	}
+
+	return s.scheduledRepo.ListByOrganization(orgID, startDate, endDate, strings.TrimSpace(track))
+}
+
+// GetDay returns everything scheduled for one date with template movements/WODs loaded (gym member)
+func (s *ProgrammingService) GetDay(actor GymActor, orgID int64, date, track string) ([]*domain.ScheduledWorkout, error) {
+	scheduled, err := s.ListCalendar(actor, orgID, date, date, track)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sw := range scheduled {
+		if sw.WorkoutID != nil {
+			workout, err := s.workoutRepo.GetByIDWithDetails(*sw.WorkoutID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load workout template: %w", err)
+			}
+			sw.Workout = workout
+		}
+		if sw.WODID != nil {
+			wod, err := s.wodRepo.GetByID(*sw.WODID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load wod: %w", err)
+			}
+			sw.WOD = wod
+		}
+	}
+
+	return scheduled, nil
+}
+
+// LogScheduled logs a scheduled workout for the athlete in one call (gym member)
+// The UserWorkout is linked to the scheduled template; WOD scores without a wod_id default to the scheduled WOD
+// date overrides the scheduled date when the athlete did the workout on another day (nil = scheduled date)
+func (s *ProgrammingService) LogScheduled(
+	actor GymActor,
+	orgID, id int64,
+	date *time.Time,
+	notes *string,
+	totalTime *int,
+	workoutType *string,
+	wellness *domain.SessionWellness,
+	movements []*domain.UserWorkoutMovement,
+	wods []*domain.UserWorkoutWOD,
+) (*domain.UserWorkout, error) {
+	if err := s.orgService.AuthorizeMember(actor, orgID); err != nil {
+		return nil, err
+	}
+	sw, err := s.getForGym(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workoutDate, err := time.Parse(domain.ScheduledDateFormat, sw.ScheduledDate)
+	if err != nil {
+		return nil, fmt.Errorf("scheduled workout has invalid date %q: %w", sw.ScheduledDate, err)
+	}
+	if date != nil {
+		workoutDate = *date
+	}
+
+	// Ad-hoc workouts (WOD only) need a name
+	var workoutName *string
+	if sw.WorkoutID == nil {
+		name := sw.Title
+		if name == "" {
+			name = sw.WODName
+		}
+		workoutName = &name
+	}
+
+	for _, w := range wods {
+		if w.WODID == 0 && sw.WODID != nil {
+			w.WODID = *sw.WODID
+		}
+	}
+
+	return s.userWorkoutService.LogScheduledWorkout(actor.UserID, sw.WorkoutID, workoutName, workoutDate,
+		notes, totalTime, workoutType, wellness, movements, wods)
+}
+
+func (s *ProgrammingService) getForGym(orgID, id int64) (*domain.ScheduledWorkout, error) {
+	sw, err := s.scheduledRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled workout: %w", err)
+	}
+	if sw == nil || sw.OrganizationID != orgID {
+		return nil, ErrScheduledWorkoutNotFound
+	}
+	return sw, nil
+}
+
+// validate checks the date and that the referenced template and WOD exist and are usable by the coach
+func (s *ProgrammingService) validate(actor GymActor, sw *domain.ScheduledWorkout) error {
+	if _, err := time.Parse(domain.ScheduledDateFormat, sw.ScheduledDate); err != nil {
+		return ErrInvalidScheduleDate
+	}
+	sw.Track = strings.TrimSpace(sw.Track)
+	sw.Title = strings.TrimSpace(sw.Title)
+
+	if sw.WorkoutID != nil && *sw.WorkoutID == 0 {
+		sw.WorkoutID = nil
+	}
+	if sw.WODID != nil && *sw.WODID == 0 {
+		sw.WODID = nil
+	}
+	if sw.WorkoutID == nil && sw.WODID == nil {
+		return ErrScheduledWorkoutEmpty
+	}
+
+	if sw.WorkoutID != nil {
+		workout, err := s.workoutRepo.GetByID(*sw.WorkoutID)
+		if err != nil {
+			return fmt.Errorf("failed to get workout template: %w", err)
+		}
+		if workout == nil {
+			return ErrWorkoutNotFound
+		}
+		// Coaches can publish standard templates or their own
+		if workout.CreatedBy != nil && *workout.CreatedBy != actor.UserID && !actor.IsSiteAdmin {
+			return ErrUnauthorizedWorkoutAccess
+		}
+	}
+
+	if sw.WODID != nil {
+		wod, err := s.wodRepo.GetByID(*sw.WODID)
+		if err != nil {
+			return fmt.Errorf("failed to get wod: %w", err)
+		}
+		if wod == nil {
+			return ErrWODNotFound
+		}
+		// Coaches can publish standard WODs or their own
+		if !wod.IsStandard && (wod.CreatedBy == nil || *wod.CreatedBy != actor.UserID) && !actor.IsSiteAdmin {
+			return ErrUnauthorizedWorkoutAccess
+		}
+	}
+
+	return nil
+}
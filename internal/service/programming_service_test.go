@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// newTestProgrammingService builds on the gym from newTestOrganizationService (admin 1, member 2, outsider 3)
+// with standard template 40, template 41 created by user 1 and template 42 created by user 3
+func newTestProgrammingService() (*ProgrammingService, *mockScheduledWorkoutRepo, *mockUserWorkoutRepo) {
+	orgService, orgRepo := newTestOrganizationService()
+	wodRepo := orgRepo.wodRepo
+
+	workoutRepo := newMockWorkoutRepo()
+	workoutRepo.workouts[40] = &domain.Workout{ID: 40, Name: "Strength Day"}
+	workoutRepo.workouts[41] = &domain.Workout{ID: 41, Name: "Coach Template", CreatedBy: int64Ptr(1)}
+	workoutRepo.workouts[42] = &domain.Workout{ID: 42, Name: "Private Template", CreatedBy: int64Ptr(3)}
+
+	userWorkoutRepo := newMockUserWorkoutRepo()
+	userWorkoutService := NewUserWorkoutService(userWorkoutRepo, workoutRepo, &mockWorkoutMovementRepo{},
+		&mockUserWorkoutMovementRepo{}, &mockUserWorkoutWODRepo{}, wodRepo)
+
+	scheduledRepo := newMockScheduledWorkoutRepo()
+	return NewProgrammingService(scheduledRepo, workoutRepo, wodRepo, orgService, userWorkoutService), scheduledRepo, userWorkoutRepo
+}
+
+func TestProgrammingServiceSchedule(t *testing.T) {
+	svc, scheduledRepo, _ := newTestProgrammingService()
+	coach := GymActor{UserID: 1}
+
+	tests := []struct {
+		name    string
+		actor   GymActor
+		sw      *domain.ScheduledWorkout
+		wantErr error
+	}{
+		{"member cannot publish", GymActor{UserID: 2}, &domain.ScheduledWorkout{ScheduledDate: "2026-03-02", WODID: int64Ptr(10)}, ErrGymAdminRequired},
+		{"invalid date", coach, &domain.ScheduledWorkout{ScheduledDate: "03/02/2026", WODID: int64Ptr(10)}, ErrInvalidScheduleDate},
+		{"nothing scheduled", coach, &domain.ScheduledWorkout{ScheduledDate: "2026-03-02", WODID: int64Ptr(0)}, ErrScheduledWorkoutEmpty},
+		{"another user's template", coach, &domain.ScheduledWorkout{ScheduledDate: "2026-03-02", WorkoutID: int64Ptr(42)}, ErrUnauthorizedWorkoutAccess},
+		{"another user's WOD", coach, &domain.ScheduledWorkout{ScheduledDate: "2026-03-02", WODID: int64Ptr(12)}, ErrUnauthorizedWorkoutAccess},
+		{"standard WOD", coach, &domain.ScheduledWorkout{ScheduledDate: "2026-03-02", WODID: int64Ptr(10)}, nil},
+		{"own WOD and template", coach, &domain.ScheduledWorkout{ScheduledDate: "2026-03-03", WorkoutID: int64Ptr(41), WODID: int64Ptr(11), Track: " Competitors "}, nil},
+		{"site admin publishes any WOD", GymActor{UserID: 9, IsSiteAdmin: true}, &domain.ScheduledWorkout{ScheduledDate: "2026-03-04", WODID: int64Ptr(12)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Schedule(tt.actor, 1, tt.sw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Schedule() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (tt.sw.OrganizationID != 1 || tt.sw.CreatedBy == nil || *tt.sw.CreatedBy != tt.actor.UserID) {
+				t.Errorf("expected the workout scheduled for gym 1 by user %d, got %+v", tt.actor.UserID, tt.sw)
+			}
+		})
+	}
+
+	if len(scheduledRepo.scheduled) != 3 {
+		t.Errorf("expected 3 scheduled workouts, got %d", len(scheduledRepo.scheduled))
+	}
+	if scheduledRepo.scheduled[2].Track != "Competitors" {
+		t.Errorf("expected the track to be trimmed, got %q", scheduledRepo.scheduled[2].Track)
+	}
+
+	// The calendar is visible to members only and filtered by track
+	calendar, err := svc.ListCalendar(GymActor{UserID: 2}, 1, "2026-03-01", "2026-03-31", "Competitors")
+	if err != nil {
+		t.Fatalf("ListCalendar() error = %v", err)
+	}
+	if len(calendar) != 1 || calendar[0].ID != 2 {
+		t.Errorf("expected only the Competitors workout, got %d workouts", len(calendar))
+	}
+	if _, err := svc.ListCalendar(GymActor{UserID: 3}, 1, "2026-03-01", "2026-03-31", ""); !errors.Is(err, ErrGymAccessDenied) {
+		t.Errorf("expected ErrGymAccessDenied for a non-member, got %v", err)
+	}
+	if _, err := svc.ListCalendar(GymActor{UserID: 2}, 1, "2026-03-31", "2026-03-01", ""); !errors.Is(err, ErrInvalidScheduleRange) {
+		t.Errorf("expected ErrInvalidScheduleRange, got %v", err)
+	}
+
+	// Updates are validated like new schedules
+	if _, err := svc.UpdateScheduled(coach, 1, 1, &domain.ScheduledWorkout{ScheduledDate: "2026-03-02", WODID: int64Ptr(12)}); !errors.Is(err, ErrUnauthorizedWorkoutAccess) {
+		t.Errorf("expected UpdateScheduled to reject another user's WOD, got %v", err)
+	}
+}
+
+func TestProgrammingServiceLogScheduled(t *testing.T) {
+	svc, scheduledRepo, userWorkoutRepo := newTestProgrammingService()
+	scheduledRepo.Create(&domain.ScheduledWorkout{OrganizationID: 1, ScheduledDate: "2026-03-02", WODID: int64Ptr(10), WODName: "Fran"})
+	scheduledRepo.Create(&domain.ScheduledWorkout{OrganizationID: 1, ScheduledDate: "2026-03-03", WorkoutID: int64Ptr(41)})
+	scheduledRepo.Create(&domain.ScheduledWorkout{OrganizationID: 2, ScheduledDate: "2026-03-03", WODID: int64Ptr(10)})
+	member := GymActor{UserID: 2}
+
+	wods := []*domain.UserWorkoutWOD{{TimeSeconds: intPtr(245)}}
+	logged, err := svc.LogScheduled(member, 1, 1, nil, stringPtr("Unbroken thrusters"), nil, nil, nil, nil, wods)
+	if err != nil {
+		t.Fatalf("LogScheduled() error = %v", err)
+	}
+	if logged.UserID != 2 || logged.WorkoutID != nil || logged.WorkoutName == nil || *logged.WorkoutName != "Fran" {
+		t.Errorf("expected an ad-hoc workout named after the WOD, got %+v", logged)
+	}
+	if got := logged.WorkoutDate.Format(domain.ScheduledDateFormat); got != "2026-03-02" {
+		t.Errorf("expected the scheduled date, got %s", got)
+	}
+	if wods[0].WODID != 10 || wods[0].UserWorkoutID != logged.ID {
+		t.Errorf("expected the score to default to the scheduled WOD, got WOD %d on workout %d", wods[0].WODID, wods[0].UserWorkoutID)
+	}
+
+	// Logging on another day links the template and keeps the athlete's date
+	didOn := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	logged, err = svc.LogScheduled(member, 1, 2, &didOn, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("LogScheduled() error = %v", err)
+	}
+	if logged.WorkoutID == nil || *logged.WorkoutID != 41 || logged.WorkoutName != nil || !logged.WorkoutDate.Equal(didOn) {
+		t.Errorf("expected template 41 logged on %s, got %+v", didOn.Format(domain.ScheduledDateFormat), logged)
+	}
+
+	if _, err := svc.LogScheduled(member, 1, 3, nil, nil, nil, nil, nil, nil, nil); !errors.Is(err, ErrScheduledWorkoutNotFound) {
+		t.Errorf("expected ErrScheduledWorkoutNotFound for another gym's workout, got %v", err)
+	}
+	if _, err := svc.LogScheduled(GymActor{UserID: 3}, 1, 1, nil, nil, nil, nil, nil, nil, nil); !errors.Is(err, ErrGymAccessDenied) {
+		t.Errorf("expected ErrGymAccessDenied for a non-member, got %v", err)
+	}
+	if len(userWorkoutRepo.userWorkouts) != 2 {
+		t.Errorf("expected 2 logged workouts, got %d", len(userWorkoutRepo.userWorkouts))
+	}
+}
+
+func TestProgrammingServiceLogScheduledWithWellness(t *testing.T) {
+	svc, scheduledRepo, userWorkoutRepo := newTestProgrammingService()
+	// Template 42 belongs to user 3; the schedule grants the member access to it
+	scheduledRepo.Create(&domain.ScheduledWorkout{OrganizationID: 1, ScheduledDate: "2026-03-02", WorkoutID: int64Ptr(42)})
+	member := GymActor{UserID: 2}
+
+	wellness := &domain.SessionWellness{SessionRPE: intPtr(8), Readiness: intPtr(6)}
+	logged, err := svc.LogScheduled(member, 1, 1, nil, nil, nil, nil, wellness, nil, nil)
+	if err != nil {
+		t.Fatalf("LogScheduled() error = %v", err)
+	}
+	if logged.SessionRPE == nil || *logged.SessionRPE != 8 || logged.Readiness == nil || *logged.Readiness != 6 {
+		t.Errorf("expected the wellness report saved with the log, got %+v", logged.SessionWellness)
+	}
+
+	if _, err := svc.LogScheduled(member, 1, 1, nil, nil, nil, nil, &domain.SessionWellness{SessionRPE: intPtr(11)}, nil, nil); !errors.Is(err, ErrInvalidSessionWellness) {
+		t.Errorf("expected ErrInvalidSessionWellness for an RPE of 11, got %v", err)
+	}
+	if len(userWorkoutRepo.userWorkouts) != 1 {
+		t.Errorf("expected only the valid log to be saved, got %d", len(userWorkoutRepo.userWorkouts))
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrSearchQueryRequired = errors.New("search query must contain at least one word")
+	ErrInvalidSearchType   = errors.New("unknown search type")
+)
+
+// Search result limits
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchResponse is a ranked full-text search across movements, WODs, templates and workout notes
+type SearchResponse struct {
+	Query   string                 `json:"query"`
+	Terms   []string               `json:"terms"`   // Words searched for; punctuation and operators are dropped
+	Backend string                 `json:"backend"` // fts5, like, tsvector or fulltext
+	Results []*domain.SearchResult `json:"results"`
+	Count   int                    `json:"count"`
+}
+
+// SearchService runs full-text searches and keeps the search index in step with edits
+type SearchService struct {
+	searchRepo domain.SearchRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(searchRepo domain.SearchRepository) *SearchService {
+	return &SearchService{
+		searchRepo: searchRepo,
+	}
+}
+
+// Search returns the records visible to the user that contain every word of the query, best match first.
+// Types restricts the results to some of domain.SearchEntityTypes; limit defaults to 20 and is capped at 100.
+func (s *SearchService) Search(userID int64, query string, types []string, limit int) (*SearchResponse, error) {
+	terms := domain.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, ErrSearchQueryRequired
+	}
+	for _, entityType := range types {
+		if !contains(domain.SearchEntityTypes, entityType) {
+			return nil, fmt.Errorf("%w: %s (must be one of: %v)", ErrInvalidSearchType, entityType, domain.SearchEntityTypes)
+		}
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	results, err := s.searchRepo.Search(userID, terms, types, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchResponse{
+		Query:   query,
+		Terms:   terms,
+		Backend: s.searchRepo.Backend(),
+		Results: results,
+		Count:   len(results),
+	}, nil
+}
+
+// Refresh re-indexes one record after it was created, changed or deleted.
+// Failures are logged rather than returned so a stale index never fails the edit itself.
+func (s *SearchService) Refresh(entityType string, entityID int64) {
+	if err := s.searchRepo.Refresh(entityType, entityID); err != nil {
+		fmt.Printf("Warning: failed to update search index for %s %d: %v\n", entityType, entityID, err)
+	}
+}
+
+// RefreshAll re-indexes every record after a bulk change such as an import or restore; failures are logged
+func (s *SearchService) RefreshAll() {
+	if _, err := s.searchRepo.Rebuild(); err != nil {
+		fmt.Printf("Warning: failed to rebuild search index: %v\n", err)
+	}
+}
+
+// Rebuild re-indexes every record and returns the number of documents indexed
+func (s *SearchService) Rebuild() (int, error) {
+	return s.searchRepo.Rebuild()
+}
+
+// EnsureIndex rebuilds the index when it is empty or out of step, returning the number of documents indexed
+func (s *SearchService) EnsureIndex() (int, error) {
+	needed, err := s.searchRepo.NeedsRebuild()
+	if err != nil || !needed {
+		return 0, err
+	}
+	return s.searchRepo.Rebuild()
+}
+
+// Backend names the full-text implementation in use
+func (s *SearchService) Backend() string {
+	return s.searchRepo.Backend()
+}
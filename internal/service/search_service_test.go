@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"Fran", []string{"fran"}},
+		{`  thrusters "pull-ups" fran*  `, []string{"thrusters", "pull", "ups", "fran"}},
+		{"back squat BACK", []string{"back", "squat"}},
+		{"-OR- AND ()", []string{"or", "and"}},
+		{"*** ???", nil},
+	}
+	for _, tt := range tests {
+		if got := domain.SearchTerms(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SearchTerms(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchServiceSearch(t *testing.T) {
+	repo := &mockSearchRepo{}
+	s := NewSearchService(repo)
+
+	response, err := s.Search(1, "Murph vest", nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.limit != DefaultSearchLimit || !reflect.DeepEqual(repo.terms, []string{"murph", "vest"}) {
+		t.Errorf("unexpected repository call: terms=%v limit=%d", repo.terms, repo.limit)
+	}
+	if response.Count != 1 || response.Backend != domain.SearchBackendLike {
+		t.Errorf("unexpected response: %+v", response)
+	}
+
+	if _, err := s.Search(1, "murph", []string{domain.SearchEntityWorkout}, 500); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.limit != MaxSearchLimit || !reflect.DeepEqual(repo.types, []string{domain.SearchEntityWorkout}) {
+		t.Errorf("expected limit capped and types passed through, got limit=%d types=%v", repo.limit, repo.types)
+	}
+
+	if _, err := s.Search(1, " -- ", nil, 0); !errors.Is(err, ErrSearchQueryRequired) {
+		t.Errorf("expected ErrSearchQueryRequired, got %v", err)
+	}
+	if _, err := s.Search(1, "murph", []string{"users"}, 0); !errors.Is(err, ErrInvalidSearchType) {
+		t.Errorf("expected ErrInvalidSearchType, got %v", err)
+	}
+}
@@ -0,0 +1,761 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrSeedBundleNotFound = errors.New("seed bundle not found")
+	ErrInvalidSeedBundle  = errors.New("invalid seed bundle")
+	ErrSeedBundleChanged  = errors.New("seed bundle version does not match; review the diff again")
+)
+
+// seedBundleManifest is the manifest naming the bundle's version and files, relative to the seeds directory
+const seedBundleManifest = "bundle.json"
+
+// Seed change actions
+const (
+	SeedActionCreate = "create"
+	SeedActionUpdate = "update"
+	SeedActionRemove = "remove" // Retired: the record stays in the standard library with its results but is no longer synced
+)
+
+// SeedBundle is a versioned release of the standard movement and WOD library
+type SeedBundle struct {
+	Version   string          `json:"version"`
+	Released  string          `json:"released,omitempty"`
+	Movements []*SeedMovement `json:"movements"`
+	WODs      []*SeedWOD      `json:"wods"`
+}
+
+// seedBundleManifestFile is the layout of seeds/bundle.json
+type seedBundleManifestFile struct {
+	Version   string `json:"version"`
+	Released  string `json:"released"`
+	Movements string `json:"movements"` // CSV in the seeds/movements.csv format
+	WODs      string `json:"wods"`      // CSV in the seeds/wods.csv format
+}
+
+// SeedMovement is a standard movement as the bundle defines it
+type SeedMovement struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Description  string   `json:"description"`
+	Equipment    []string `json:"equipment"`
+	Pattern      string   `json:"pattern"`
+	MuscleGroups []string `json:"muscle_groups"`
+	Parent       string   `json:"parent"` // Parent movement name
+}
+
+// SeedWOD is a standard WOD as the bundle defines it
+type SeedWOD struct {
+	Name        string `json:"name"`
+	Source      string `json:"source"`
+	Type        string `json:"type"`
+	Regime      string `json:"regime"`
+	ScoreType   string `json:"score_type"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Notes       string `json:"notes"`
+}
+
+// SeedChange is one difference between the bundle and the standard library
+type SeedChange struct {
+	Key        string      `json:"key"` // Entity type and normalized name, e.g. "wod:fran"
+	EntityType string      `json:"entity_type"`
+	Name       string      `json:"name"`
+	Action     string      `json:"action"` // create, update, remove
+	EntityID   *int64      `json:"entity_id,omitempty"`
+	Fields     []string    `json:"fields,omitempty"` // Fields an update changes
+	LocalEdits bool        `json:"local_edits"`      // Edited since the bundle last applied it (or never applied); needs confirm_overwrite
+	Current    interface{} `json:"current,omitempty"`
+	Proposed   interface{} `json:"proposed,omitempty"`
+
+	proposedHash string
+}
+
+// SeedLibraryDiff lists what applying the bundle would change
+type SeedLibraryDiff struct {
+	BundleVersion  string        `json:"bundle_version"`
+	Released       string        `json:"released,omitempty"`
+	AppliedVersion string        `json:"applied_version,omitempty"` // Bundle version of the most recent sync, if any
+	Changes        []*SeedChange `json:"changes"`
+	Unchanged      int           `json:"unchanged"`
+
+	// Records matching the bundle that are not yet tracked at its hash
+	adoptable []*domain.SeedLibraryEntry
+}
+
+// SeedApplyRequest selects the changes of a reviewed diff to apply
+type SeedApplyRequest struct {
+	BundleVersion    string   `json:"bundle_version"`    // Version of the reviewed diff; must match the bundle on disk
+	Keys             []string `json:"keys"`              // Changes to apply; empty applies every change
+	ConfirmOverwrite bool     `json:"confirm_overwrite"` // Also apply selected changes to records with local edits
+}
+
+// SeedApplyResult reports the outcome of a sync
+type SeedApplyResult struct {
+	BundleVersion string        `json:"bundle_version"`
+	Applied       []*SeedChange `json:"applied"`
+	Skipped       []*SeedSkip   `json:"skipped"`
+	Adopted       int           `json:"adopted"` // Unchanged records now tracked against the bundle
+}
+
+// SeedSkip is a selected change that was not applied
+type SeedSkip struct {
+	Key    string `json:"key"`
+	Reason string `json:"reason"`
+}
+
+// SeedLibraryService syncs the standard movements and WODs with the curated seed bundle
+type SeedLibraryService struct {
+	seedRepo             domain.SeedLibraryRepository
+	movementRepo         domain.MovementRepository
+	wodRepo              domain.WODRepository
+	movementService      *MovementService
+	wodService           *WODService
+	dataChangeLogService *DataChangeLogService
+	searchService        *SearchService
+	bundleDir            string
+}
+
+// NewSeedLibraryService creates a new seed library service reading the bundle from bundleDir
+func NewSeedLibraryService(
+	seedRepo domain.SeedLibraryRepository,
+	movementRepo domain.MovementRepository,
+	wodRepo domain.WODRepository,
+	movementService *MovementService,
+	wodService *WODService,
+	dataChangeLogService *DataChangeLogService,
+	bundleDir string,
+) *SeedLibraryService {
+	return &SeedLibraryService{
+		seedRepo:             seedRepo,
+		movementRepo:         movementRepo,
+		wodRepo:              wodRepo,
+		movementService:      movementService,
+		wodService:           wodService,
+		dataChangeLogService: dataChangeLogService,
+		bundleDir:            bundleDir,
+	}
+}
+
+// SetSearchService re-indexes records the sync retires
+func (s *SeedLibraryService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
+// Diff compares the bundle with the standard library.
+// Records the bundle never applied count as locally edited when they differ from it; records edited
+// since the bundle last applied them are only listed when the bundle itself changed them since.
+func (s *SeedLibraryService) Diff() (*SeedLibraryDiff, error) {
+	bundle, err := LoadSeedBundle(s.bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.loadState()
+	if err != nil {
+		return nil, err
+	}
+	return diffSeedLibrary(bundle, state), nil
+}
+
+// Apply applies the selected changes of the bundle and records each one in the data change log.
+// Changes to records with local edits are skipped unless ConfirmOverwrite is set; failures skip
+// the change and never stop the sync.
+func (s *SeedLibraryService) Apply(req *SeedApplyRequest, adminID int64, adminEmail string) (*SeedApplyResult, error) {
+	bundle, err := LoadSeedBundle(s.bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	if req.BundleVersion != bundle.Version {
+		return nil, fmt.Errorf("%w: bundle is %s", ErrSeedBundleChanged, bundle.Version)
+	}
+	state, err := s.loadState()
+	if err != nil {
+		return nil, err
+	}
+	diff := diffSeedLibrary(bundle, state)
+
+	result := &SeedApplyResult{BundleVersion: bundle.Version, Applied: []*SeedChange{}, Skipped: []*SeedSkip{}}
+	selected := diff.Changes
+	if len(req.Keys) > 0 {
+		byKey := make(map[string]*SeedChange, len(diff.Changes))
+		for _, change := range diff.Changes {
+			byKey[change.Key] = change
+		}
+		selected = nil
+		for _, key := range req.Keys {
+			if change, ok := byKey[key]; ok {
+				selected = append(selected, change)
+			} else {
+				result.Skipped = append(result.Skipped, &SeedSkip{Key: key, Reason: "no pending change"})
+			}
+		}
+	}
+
+	for _, change := range orderSeedChanges(selected) {
+		if change.LocalEdits && !req.ConfirmOverwrite {
+			result.Skipped = append(result.Skipped, &SeedSkip{Key: change.Key, Reason: "edited locally; confirm to overwrite"})
+			continue
+		}
+		if err := s.applyChange(change, bundle.Version, adminID, adminEmail); err != nil {
+			result.Skipped = append(result.Skipped, &SeedSkip{Key: change.Key, Reason: err.Error()})
+			continue
+		}
+		result.Applied = append(result.Applied, change)
+	}
+
+	for _, entry := range diff.adoptable {
+		entry.BundleVersion = bundle.Version
+		if err := s.seedRepo.SaveEntry(entry); err != nil {
+			fmt.Printf("Warning: failed to track seed library entry %s:%s: %v\n", entry.EntityType, entry.SeedKey, err)
+			continue
+		}
+		result.Adopted++
+	}
+
+	return result, nil
+}
+
+// applyChange applies one change through the movement and WOD services, which log creates and updates
+func (s *SeedLibraryService) applyChange(change *SeedChange, bundleVersion string, adminID int64, adminEmail string) error {
+	if change.Action == SeedActionRemove {
+		return s.retire(change, adminID, adminEmail)
+	}
+
+	var entityID int64
+	var err error
+	switch change.EntityType {
+	case domain.SeedEntityMovement:
+		entityID, err = s.applyMovement(change, adminID, adminEmail)
+	case domain.SeedEntityWOD:
+		entityID, err = s.applyWOD(change, adminID, adminEmail)
+	default:
+		err = fmt.Errorf("unknown seed entity type: %s", change.EntityType)
+	}
+	if err != nil {
+		return err
+	}
+
+	change.EntityID = &entityID
+	return s.seedRepo.SaveEntry(&domain.SeedLibraryEntry{
+		EntityType:    change.EntityType,
+		SeedKey:       domain.NormalizeName(change.Name),
+		EntityID:      entityID,
+		AppliedHash:   change.proposedHash,
+		BundleVersion: bundleVersion,
+	})
+}
+
+func (s *SeedLibraryService) applyMovement(change *SeedChange, adminID int64, adminEmail string) (int64, error) {
+	proposed := change.Proposed.(*SeedMovement)
+
+	movement := &domain.Movement{}
+	if change.Action == SeedActionUpdate {
+		existing, err := s.movementRepo.GetByID(*change.EntityID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get movement: %w", err)
+		}
+		if existing == nil {
+			return 0, ErrMovementNotFound
+		}
+		movement = existing
+	}
+	movement.Name = proposed.Name
+	movement.Type = domain.MovementType(proposed.Type)
+	movement.Description = proposed.Description
+	movement.Equipment = proposed.Equipment
+	movement.Pattern = proposed.Pattern
+	movement.MuscleGroups = proposed.MuscleGroups
+	movement.ParentID = nil
+	if proposed.Parent != "" {
+		parent, err := s.movementRepo.GetByName(proposed.Parent)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get parent movement: %w", err)
+		}
+		if parent == nil {
+			return 0, fmt.Errorf("parent movement %s not found", proposed.Parent)
+		}
+		movement.ParentID = &parent.ID
+	}
+
+	if change.Action == SeedActionCreate {
+		if err := s.movementService.CreateStandard(movement, adminID, adminEmail); err != nil {
+			return 0, err
+		}
+		return movement.ID, nil
+	}
+	if err := s.movementService.UpdateAsAdmin(movement, adminID, adminEmail); err != nil {
+		return 0, err
+	}
+	return movement.ID, nil
+}
+
+func (s *SeedLibraryService) applyWOD(change *SeedChange, adminID int64, adminEmail string) (int64, error) {
+	proposed := change.Proposed.(*SeedWOD)
+
+	wod := &domain.WOD{}
+	if change.Action == SeedActionUpdate {
+		existing, err := s.wodRepo.GetByID(*change.EntityID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get wod: %w", err)
+		}
+		if existing == nil {
+			return 0, ErrWODNotFound
+		}
+		copied := *existing
+		wod = &copied
+	}
+	wod.Name = proposed.Name
+	wod.Source = proposed.Source
+	wod.Type = proposed.Type
+	wod.Regime = proposed.Regime
+	wod.ScoreType = proposed.ScoreType
+	wod.Description = proposed.Description
+	wod.URL = optionalString(proposed.URL)
+	wod.Notes = optionalString(proposed.Notes)
+
+	if change.Action == SeedActionCreate {
+		if err := s.wodService.CreateStandard(wod, adminID, adminEmail); err != nil {
+			return 0, err
+		}
+		return wod.ID, nil
+	}
+	if err := s.wodService.UpdateAsAdmin(wod, adminID, adminEmail); err != nil {
+		return 0, err
+	}
+	return wod.ID, nil
+}
+
+// retire stops tracking a standard record the bundle dropped. The record stays standard, so every
+// user keeps seeing it and the results logged on it, and later syncs leave it alone like any record
+// the bundle never applied.
+func (s *SeedLibraryService) retire(change *SeedChange, adminID int64, adminEmail string) error {
+	if err := s.seedRepo.DeleteEntry(change.EntityType, strings.TrimPrefix(change.Key, change.EntityType+":")); err != nil {
+		return err
+	}
+	if s.searchService != nil {
+		s.searchService.Refresh(change.EntityType, *change.EntityID)
+	}
+
+	if s.dataChangeLogService != nil {
+		after := map[string]interface{}{"name": change.Name, "seed_tracked": false}
+		if logErr := s.dataChangeLogService.LogUpdate(change.EntityType, *change.EntityID, change.Name, adminID, adminEmail, change.Current, after, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log retired standard %s: %v\n", change.EntityType, logErr)
+		}
+	}
+	return nil
+}
+
+// seedLibraryState is the standard library and tracking entries a diff compares against
+type seedLibraryState struct {
+	entries   map[string]*domain.SeedLibraryEntry // By change key
+	movements map[int64]*SeedMovement             // Standard movements by ID
+	wods      map[int64]*SeedWOD                  // Standard WODs by ID
+}
+
+func (s *SeedLibraryService) loadState() (*seedLibraryState, error) {
+	state := &seedLibraryState{
+		entries:   make(map[string]*domain.SeedLibraryEntry),
+		movements: make(map[int64]*SeedMovement),
+		wods:      make(map[int64]*SeedWOD),
+	}
+
+	entries, err := s.seedRepo.ListEntries()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		state.entries[entry.EntityType+":"+entry.SeedKey] = entry
+	}
+
+	movements, err := s.movementRepo.ListAll(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list movements: %w", err)
+	}
+	names := make(map[int64]string, len(movements))
+	for _, m := range movements {
+		names[m.ID] = m.Name
+	}
+	for _, m := range movements {
+		if !m.IsStandard {
+			continue
+		}
+		movement := &SeedMovement{
+			Name:         m.Name,
+			Type:         string(m.Type),
+			Description:  m.Description,
+			Equipment:    m.Equipment,
+			Pattern:      m.Pattern,
+			MuscleGroups: m.MuscleGroups,
+		}
+		if m.ParentID != nil {
+			movement.Parent = names[*m.ParentID]
+		}
+		state.movements[m.ID] = movement
+	}
+
+	wods, err := s.wodRepo.ListStandard(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list standard wods: %w", err)
+	}
+	for _, w := range wods {
+		wod := &SeedWOD{
+			Name:        w.Name,
+			Source:      w.Source,
+			Type:        w.Type,
+			Regime:      w.Regime,
+			ScoreType:   w.ScoreType,
+			Description: w.Description,
+		}
+		if w.URL != nil {
+			wod.URL = *w.URL
+		}
+		if w.Notes != nil {
+			wod.Notes = *w.Notes
+		}
+		state.wods[w.ID] = wod
+	}
+
+	return state, nil
+}
+
+// seedItem is a bundle or library definition being compared
+type seedItem struct {
+	entityType string
+	name       string
+	value      interface{}
+}
+
+// diffSeedLibrary lists the changes the bundle makes to the library
+func diffSeedLibrary(bundle *SeedBundle, state *seedLibraryState) *SeedLibraryDiff {
+	diff := &SeedLibraryDiff{BundleVersion: bundle.Version, Released: bundle.Released, Changes: []*SeedChange{}}
+	var lastApplied time.Time
+	for _, entry := range state.entries {
+		if entry.AppliedAt.After(lastApplied) {
+			lastApplied = entry.AppliedAt
+			diff.AppliedVersion = entry.BundleVersion
+		}
+	}
+
+	var items []seedItem
+	for _, m := range bundle.Movements {
+		items = append(items, seedItem{domain.SeedEntityMovement, m.Name, m})
+	}
+	for _, w := range bundle.WODs {
+		items = append(items, seedItem{domain.SeedEntityWOD, w.Name, w})
+	}
+
+	inBundle := make(map[string]bool, len(items))
+	for _, item := range items {
+		key := item.entityType + ":" + domain.NormalizeName(item.name)
+		inBundle[key] = true
+		entry := state.entries[key]
+		proposedHash := hashSeedDefinition(item.value)
+
+		id, current := state.find(item.entityType, item.name, entry)
+		if current == nil {
+			// A tracked record that is gone was deleted or merged by an admin
+			diff.Changes = append(diff.Changes, &SeedChange{
+				Key: key, EntityType: item.entityType, Name: item.name, Action: SeedActionCreate,
+				LocalEdits: entry != nil, Proposed: item.value, proposedHash: proposedHash,
+			})
+			continue
+		}
+
+		currentHash := hashSeedDefinition(current)
+		if currentHash == proposedHash {
+			diff.Unchanged++
+			if entry == nil || entry.AppliedHash != proposedHash || entry.EntityID != id {
+				diff.adoptable = append(diff.adoptable, &domain.SeedLibraryEntry{
+					EntityType: item.entityType, SeedKey: domain.NormalizeName(item.name), EntityID: id, AppliedHash: proposedHash,
+				})
+			}
+			continue
+		}
+		if entry != nil && entry.EntityID == id && entry.AppliedHash == proposedHash {
+			// The bundle has not changed since it was applied; keep the admin's edits
+			diff.Unchanged++
+			continue
+		}
+
+		entityID := id
+		diff.Changes = append(diff.Changes, &SeedChange{
+			Key: key, EntityType: item.entityType, Name: item.name, Action: SeedActionUpdate, EntityID: &entityID,
+			Fields:     seedFieldChanges(current, item.value),
+			LocalEdits: entry == nil || entry.EntityID != id || entry.AppliedHash != currentHash,
+			Current:    current, Proposed: item.value, proposedHash: proposedHash,
+		})
+	}
+
+	// Tracked records the bundle dropped; records it never applied are left alone
+	for key, entry := range state.entries {
+		if inBundle[key] {
+			continue
+		}
+		current := state.byID(entry.EntityType, entry.EntityID)
+		if current == nil {
+			continue
+		}
+		entityID := entry.EntityID
+		diff.Changes = append(diff.Changes, &SeedChange{
+			Key: key, EntityType: entry.EntityType, Name: seedName(current), Action: SeedActionRemove, EntityID: &entityID,
+			LocalEdits: hashSeedDefinition(current) != entry.AppliedHash, Current: current,
+		})
+	}
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		if diff.Changes[i].EntityType != diff.Changes[j].EntityType {
+			return diff.Changes[i].EntityType == domain.SeedEntityMovement
+		}
+		return diff.Changes[i].Key < diff.Changes[j].Key
+	})
+	return diff
+}
+
+// find returns the standard record a bundle item maps to: the tracked record, else one with the same name
+func (st *seedLibraryState) find(entityType, name string, entry *domain.SeedLibraryEntry) (int64, interface{}) {
+	if entry != nil {
+		if current := st.byID(entityType, entry.EntityID); current != nil {
+			return entry.EntityID, current
+		}
+	}
+	normalized := domain.NormalizeName(name)
+	switch entityType {
+	case domain.SeedEntityMovement:
+		for id, m := range st.movements {
+			if domain.NormalizeName(m.Name) == normalized {
+				return id, m
+			}
+		}
+	case domain.SeedEntityWOD:
+		for id, w := range st.wods {
+			if domain.NormalizeName(w.Name) == normalized {
+				return id, w
+			}
+		}
+	}
+	return 0, nil
+}
+
+func (st *seedLibraryState) byID(entityType string, id int64) interface{} {
+	switch entityType {
+	case domain.SeedEntityMovement:
+		if m, ok := st.movements[id]; ok {
+			return m
+		}
+	case domain.SeedEntityWOD:
+		if w, ok := st.wods[id]; ok {
+			return w
+		}
+	}
+	return nil
+}
+
+// orderSeedChanges applies movements before WODs, parents before their variants and removals last
+func orderSeedChanges(changes []*SeedChange) []*SeedChange {
+	ordered := append([]*SeedChange(nil), changes...)
+	rank := func(c *SeedChange) int {
+		switch {
+		case c.Action == SeedActionRemove:
+			return 3
+		case c.EntityType == domain.SeedEntityWOD:
+			return 2
+		case c.Proposed != nil && c.Proposed.(*SeedMovement).Parent != "":
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return rank(ordered[i]) < rank(ordered[j]) })
+	return ordered
+}
+
+// hashSeedDefinition hashes a movement or WOD definition in canonical form
+func hashSeedDefinition(value interface{}) string {
+	data, _ := json.Marshal(canonicalSeed(value))
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalSeed ignores what saving a definition normalizes anyway: the case of enumerated values
+// and taxonomy, surrounding whitespace and empty versus missing lists
+func canonicalSeed(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *SeedMovement:
+		c := *v
+		c.Name, c.Description, c.Parent = strings.TrimSpace(c.Name), strings.TrimSpace(c.Description), strings.TrimSpace(c.Parent)
+		c.Type, c.Pattern = strings.ToLower(strings.TrimSpace(c.Type)), strings.ToLower(strings.TrimSpace(c.Pattern))
+		c.Equipment, c.MuscleGroups = canonicalSeedList(c.Equipment), canonicalSeedList(c.MuscleGroups)
+		return &c
+	case *SeedWOD:
+		c := *v
+		c.Name, c.ScoreType, c.Description = strings.TrimSpace(c.Name), strings.TrimSpace(c.ScoreType), strings.TrimSpace(c.Description)
+		c.URL, c.Notes = strings.TrimSpace(c.URL), strings.TrimSpace(c.Notes)
+		c.Source, c.Type, c.Regime = strings.ToLower(strings.TrimSpace(c.Source)), strings.ToLower(strings.TrimSpace(c.Type)), strings.ToLower(strings.TrimSpace(c.Regime))
+		return &c
+	}
+	return value
+}
+
+func canonicalSeedList(values []string) []string {
+	list := []string{}
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" && !contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// seedFieldChanges lists the JSON fields that differ between two definitions of the same kind
+func seedFieldChanges(current, proposed interface{}) []string {
+	var before, after map[string]interface{}
+	currentJSON, _ := json.Marshal(canonicalSeed(current))
+	proposedJSON, _ := json.Marshal(canonicalSeed(proposed))
+	json.Unmarshal(currentJSON, &before)
+	json.Unmarshal(proposedJSON, &after)
+
+	var fields []string
+	for field, value := range after {
+		if fmt.Sprint(before[field]) != fmt.Sprint(value) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func seedName(value interface{}) string {
+	switch v := value.(type) {
+	case *SeedMovement:
+		return v.Name
+	case *SeedWOD:
+		return v.Name
+	}
+	return ""
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// LoadSeedBundle reads the bundle manifest and the movement and WOD CSVs it names from dir
+func LoadSeedBundle(dir string) (*SeedBundle, error) {
+	data, err := os.ReadFile(filepath.Join(dir, seedBundleManifest))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrSeedBundleNotFound, filepath.Join(dir, seedBundleManifest))
+		}
+		return nil, fmt.Errorf("failed to read seed bundle manifest: %w", err)
+	}
+	var manifest seedBundleManifestFile
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSeedBundle, err)
+	}
+	if manifest.Version == "" || manifest.Movements == "" || manifest.WODs == "" {
+		return nil, fmt.Errorf("%w: version, movements and wods are required", ErrInvalidSeedBundle)
+	}
+
+	bundle := &SeedBundle{Version: manifest.Version, Released: manifest.Released}
+	if err := readSeedCSV(filepath.Join(dir, manifest.Movements), []string{"name", "type", "description"}, func(row map[string]string) {
+		bundle.Movements = append(bundle.Movements, &SeedMovement{
+			Name:         row["name"],
+			Type:         row["type"],
+			Description:  row["description"],
+			Equipment:    splitTaxonomyList(row["equipment"]),
+			Pattern:      row["pattern"],
+			MuscleGroups: splitTaxonomyList(row["muscle_groups"]),
+			Parent:       row["parent"],
+		})
+	}); err != nil {
+		return nil, err
+	}
+	if err := readSeedCSV(filepath.Join(dir, manifest.WODs), []string{"name", "source", "type", "regime", "score_type", "description"}, func(row map[string]string) {
+		bundle.WODs = append(bundle.WODs, &SeedWOD{
+			Name:        row["name"],
+			Source:      row["source"],
+			Type:        row["type"],
+			Regime:      row["regime"],
+			ScoreType:   row["score_type"],
+			Description: row["description"],
+			URL:         row["url"],
+			Notes:       row["notes"],
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range bundle.Movements {
+		key := domain.SeedEntityMovement + ":" + domain.NormalizeName(m.Name)
+		if m.Name == "" || seen[key] {
+			return nil, fmt.Errorf("%w: missing or duplicate movement name %q", ErrInvalidSeedBundle, m.Name)
+		}
+		seen[key] = true
+	}
+	for _, w := range bundle.WODs {
+		key := domain.SeedEntityWOD + ":" + domain.NormalizeName(w.Name)
+		if w.Name == "" || seen[key] {
+			return nil, fmt.Errorf("%w: missing or duplicate WOD name %q", ErrInvalidSeedBundle, w.Name)
+		}
+		seen[key] = true
+	}
+	return bundle, nil
+}
+
+// readSeedCSV reads a seed CSV by header name, trimming every cell
+func readSeedCSV(path string, required []string, add func(row map[string]string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSeedBundle, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("%w: failed to read %s header: %v", ErrInvalidSeedBundle, filepath.Base(path), err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	for _, column := range required {
+		if !contains(header, column) {
+			return fmt.Errorf("%w: %s is missing the %s column", ErrInvalidSeedBundle, filepath.Base(path), column)
+		}
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: failed to read %s row %d: %v", ErrInvalidSeedBundle, filepath.Base(path), line, err)
+		}
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = strings.TrimSpace(record[i])
+			}
+		}
+		add(row)
+	}
+}
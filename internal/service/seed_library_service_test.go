@@ -0,0 +1,244 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+func TestLoadSeedBundle(t *testing.T) {
+	bundle, err := LoadSeedBundle("../../seeds")
+	if err != nil {
+		t.Fatalf("LoadSeedBundle() error = %v", err)
+	}
+	if bundle.Version == "" {
+		t.Error("expected the bundle version to be set")
+	}
+	if len(bundle.Movements) == 0 || len(bundle.WODs) == 0 {
+		t.Fatalf("expected movements and WODs, got %d and %d", len(bundle.Movements), len(bundle.WODs))
+	}
+	for _, m := range bundle.Movements {
+		if m.Name == "" || m.Type == "" {
+			t.Errorf("movement missing name or type: %+v", m)
+		}
+	}
+}
+
+func TestLoadSeedBundle_Missing(t *testing.T) {
+	if _, err := LoadSeedBundle(t.TempDir()); !errors.Is(err, ErrSeedBundleNotFound) {
+		t.Errorf("expected ErrSeedBundleNotFound, got %v", err)
+	}
+}
+
+func TestHashSeedDefinition_IgnoresNormalizedDifferences(t *testing.T) {
+	a := &SeedMovement{Name: "Back Squat", Type: "Weightlifting", Equipment: nil, Pattern: "Squat"}
+	b := &SeedMovement{Name: " Back Squat ", Type: "weightlifting", Equipment: []string{}, Pattern: "squat"}
+	if hashSeedDefinition(a) != hashSeedDefinition(b) {
+		t.Error("expected equal hashes for definitions differing only in case, whitespace and empty lists")
+	}
+
+	b.Description = "Bar on the back"
+	if hashSeedDefinition(a) == hashSeedDefinition(b) {
+		t.Error("expected different hashes when the description differs")
+	}
+	if fields := seedFieldChanges(a, b); len(fields) != 1 || fields[0] != "description" {
+		t.Errorf("expected [description], got %v", fields)
+	}
+}
+
+func TestDiffSeedLibrary(t *testing.T) {
+	fran := &SeedWOD{Name: "Fran", Source: "CrossFit", Type: "Girl", Regime: "Fastest Time", ScoreType: "Time"}
+	grace := &SeedWOD{Name: "Grace", Source: "CrossFit", Type: "Girl", Regime: "Fastest Time", ScoreType: "Time"}
+	helen := &SeedWOD{Name: "Helen", Source: "CrossFit", Type: "Girl", Regime: "Fastest Time", ScoreType: "Time"}
+	isabel := &SeedWOD{Name: "Isabel", Source: "CrossFit", Type: "Girl", Regime: "Fastest Time", ScoreType: "Time"}
+	squat := &SeedMovement{Name: "Back Squat", Type: "weightlifting", Description: "Barbell squat"}
+
+	withDescription := func(w *SeedWOD, description string) *SeedWOD {
+		c := *w
+		c.Description = description
+		return &c
+	}
+
+	bundle := &SeedBundle{
+		Version:   "1.1.0",
+		Movements: []*SeedMovement{squat},
+		WODs: []*SeedWOD{
+			fran,
+			withDescription(grace, "30 clean and jerks"),
+			withDescription(helen, "3 rounds"),
+			withDescription(isabel, "30 snatches"),
+		},
+	}
+
+	applied := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &seedLibraryState{
+		entries: map[string]*domain.SeedLibraryEntry{
+			// Grace: applied by the bundle and edited since, bundle unchanged
+			"wod:grace": {EntityType: domain.SeedEntityWOD, SeedKey: "grace", EntityID: 2,
+				AppliedHash: hashSeedDefinition(withDescription(grace, "30 clean and jerks")), BundleVersion: "1.0.0", AppliedAt: applied},
+			// Helen: applied as-is, bundle changed since
+			"wod:helen": {EntityType: domain.SeedEntityWOD, SeedKey: "helen", EntityID: 3,
+				AppliedHash: hashSeedDefinition(helen), BundleVersion: "1.0.0", AppliedAt: applied},
+			// Cindy: applied and since dropped from the bundle
+			"wod:cindy": {EntityType: domain.SeedEntityWOD, SeedKey: "cindy", EntityID: 5,
+				AppliedHash: hashSeedDefinition(&SeedWOD{Name: "Cindy"}), BundleVersion: "1.0.0", AppliedAt: applied},
+		},
+		movements: map[int64]*SeedMovement{},
+		wods: map[int64]*SeedWOD{
+			1: fran,
+			2: withDescription(grace, "30 C&J for time"),
+			3: helen,
+			4: isabel, // Never applied by the bundle
+			5: {Name: "Cindy"},
+			6: {Name: "Annie"}, // Never in the bundle
+		},
+	}
+
+	diff := diffSeedLibrary(bundle, state)
+
+	if diff.AppliedVersion != "1.0.0" {
+		t.Errorf("expected applied version 1.0.0, got %q", diff.AppliedVersion)
+	}
+	// Fran matches and Grace keeps the admin's edits
+	if diff.Unchanged != 2 {
+		t.Errorf("expected 2 unchanged, got %d", diff.Unchanged)
+	}
+	if len(diff.adoptable) != 1 || diff.adoptable[0].SeedKey != "fran" || diff.adoptable[0].EntityID != 1 {
+		t.Errorf("expected fran to be adopted, got %+v", diff.adoptable)
+	}
+
+	want := []struct {
+		key        string
+		action     string
+		localEdits bool
+	}{
+		{"movement:backsquat", SeedActionCreate, false},
+		{"wod:cindy", SeedActionRemove, false},
+		{"wod:helen", SeedActionUpdate, false},
+		{"wod:isabel", SeedActionUpdate, true},
+	}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d", len(want), len(diff.Changes))
+	}
+	for i, w := range want {
+		got := diff.Changes[i]
+		if got.Key != w.key || got.Action != w.action || got.LocalEdits != w.localEdits {
+			t.Errorf("change %d = %s %s local_edits=%v, want %s %s local_edits=%v",
+				i, got.Action, got.Key, got.LocalEdits, w.action, w.key, w.localEdits)
+		}
+	}
+}
+
+func TestDiffSeedLibrary_DeletedTrackedRecord(t *testing.T) {
+	fran := &SeedWOD{Name: "Fran", Type: "Girl"}
+	bundle := &SeedBundle{Version: "1.0.0", WODs: []*SeedWOD{fran}}
+	state := &seedLibraryState{
+		entries: map[string]*domain.SeedLibraryEntry{
+			"wod:fran": {EntityType: domain.SeedEntityWOD, SeedKey: "fran", EntityID: 9, AppliedHash: hashSeedDefinition(fran)},
+		},
+		movements: map[int64]*SeedMovement{},
+		wods:      map[int64]*SeedWOD{},
+	}
+
+	diff := diffSeedLibrary(bundle, state)
+	if len(diff.Changes) != 1 || diff.Changes[0].Action != SeedActionCreate || !diff.Changes[0].LocalEdits {
+		t.Errorf("expected a create flagged as a local edit, got %+v", diff.Changes)
+	}
+}
+
+func TestOrderSeedChanges(t *testing.T) {
+	changes := []*SeedChange{
+		{Key: "wod:old", EntityType: domain.SeedEntityWOD, Action: SeedActionRemove},
+		{Key: "wod:fran", EntityType: domain.SeedEntityWOD, Action: SeedActionCreate, Proposed: &SeedWOD{Name: "Fran"}},
+		{Key: "movement:frontsquat", EntityType: domain.SeedEntityMovement, Action: SeedActionCreate,
+			Proposed: &SeedMovement{Name: "Front Squat", Parent: "Squat"}},
+		{Key: "movement:squat", EntityType: domain.SeedEntityMovement, Action: SeedActionCreate, Proposed: &SeedMovement{Name: "Squat"}},
+	}
+
+	ordered := orderSeedChanges(changes)
+	want := []string{"movement:squat", "movement:frontsquat", "wod:fran", "wod:old"}
+	for i, key := range want {
+		if ordered[i].Key != key {
+			t.Errorf("position %d = %s, want %s", i, ordered[i].Key, key)
+		}
+	}
+}
+
+func TestSeedLibraryApply_RetireKeepsRecordStandard(t *testing.T) {
+	db := openTestDB(t)
+	wodRepo := repository.NewWODRepository(db)
+	movementRepo := repository.NewMovementRepository(db)
+	seedRepo := repository.NewSeedLibraryRepository(db)
+	searchService := NewSearchService(repository.NewSearchRepository(db))
+
+	// A bundle that dropped Grace, which an earlier bundle applied
+	dir := t.TempDir()
+	files := map[string]string{
+		"bundle.json":   `{"version": "2.0.0", "movements": "movements.csv", "wods": "wods.csv"}`,
+		"movements.csv": "name,type,description\n",
+		"wods.csv":      "name,source,type,regime,score_type,description\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	grace, err := wodRepo.GetByName("Grace")
+	if err != nil || grace == nil {
+		t.Fatalf("seeded Grace not found: %v", err)
+	}
+	if err := seedRepo.SaveEntry(&domain.SeedLibraryEntry{EntityType: domain.SeedEntityWOD, SeedKey: "grace",
+		EntityID: grace.ID, AppliedHash: "applied", BundleVersion: "1.0.0"}); err != nil {
+		t.Fatalf("failed to track grace: %v", err)
+	}
+
+	s := NewSeedLibraryService(seedRepo, movementRepo, wodRepo, NewMovementService(movementRepo, nil), NewWODService(wodRepo, nil), nil, dir)
+	s.SetSearchService(searchService)
+	result, err := s.Apply(&SeedApplyRequest{BundleVersion: "2.0.0", Keys: []string{"wod:grace"}, ConfirmOverwrite: true}, 1, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(result.Applied) != 1 || result.Applied[0].Action != SeedActionRemove {
+		t.Fatalf("expected grace to be retired, got applied %+v skipped %+v", result.Applied, result.Skipped)
+	}
+
+	retired, err := wodRepo.GetByID(grace.ID)
+	if err != nil || retired == nil || !retired.IsStandard || retired.CreatedBy != nil {
+		t.Errorf("expected grace to stay a standard WOD, got %+v (%v)", retired, err)
+	}
+	entries, err := seedRepo.ListEntries()
+	if err != nil {
+		t.Fatalf("ListEntries() error = %v", err)
+	}
+	for _, entry := range entries {
+		if entry.EntityType == domain.SeedEntityWOD && entry.SeedKey == "grace" {
+			t.Error("expected grace to no longer be tracked")
+		}
+	}
+	diff, err := s.Diff()
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	for _, change := range diff.Changes {
+		if change.Key == "wod:grace" {
+			t.Errorf("expected later syncs to leave grace alone, got %+v", change)
+		}
+	}
+
+	found, err := searchService.Search(1, "Grace", []string{domain.SearchEntityWOD}, 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	indexed := false
+	for _, r := range found.Results {
+		indexed = indexed || r.EntityID == grace.ID
+	}
+	if !indexed {
+		t.Errorf("expected grace to stay searchable, got %+v", found.Results)
+	}
+}
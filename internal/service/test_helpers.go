@@ -16,6 +16,7 @@ type mockUserWorkoutRepo struct {
 	createError         error
 	updateError         error
 	deleteError         error
+	listError           error
 }
 
 func newMockUserWorkoutRepo() *mockUserWorkoutRepo {
@@ -105,6 +106,9 @@ func (m *mockUserWorkoutRepo) ListByUserWithDetails(userID int64, limit, offset
 }
 
 func (m *mockUserWorkoutRepo) ListByUserAndDateRange(userID int64, startDate, endDate time.Time) ([]*domain.UserWorkout, error) {
+	if m.listError != nil {
+		return nil, m.listError
+	}
 	var result []*domain.UserWorkout
 	for _, uw := range m.userWorkouts {
 		if uw.UserID == userID && !uw.WorkoutDate.Before(startDate) && !uw.WorkoutDate.After(endDate) {
@@ -282,7 +286,7 @@ func (m *mockWorkoutRepo) ListAllUserCreatedWithUserInfo(limit, offset int) ([]*
 	return []*domain.WorkoutWithCreator{}, nil
 }
 
-func (m *mockWorkoutRepo) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string) ([]*domain.WorkoutWithCreator, int64, error) {
+func (m *mockWorkoutRepo) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string, orgID int64) ([]*domain.WorkoutWithCreator, int64, error) {
 	return []*domain.WorkoutWithCreator{}, 0, nil
 }
 
@@ -409,6 +413,10 @@ func (m *mockUserWorkoutMovementRepo) UpdatePRFlag(id int64, isPR bool) error {
 	return nil
 }
 
+func (m *mockUserWorkoutMovementRepo) GetByUserIDAndMovementID(userID, movementID int64, limit int) ([]*domain.UserWorkoutMovement, error) {
+	return []*domain.UserWorkoutMovement{}, nil
+}
+
 // Mock UserWorkoutWODRepository
 type mockUserWorkoutWODRepo struct{}
 
@@ -448,6 +456,10 @@ func (m *mockUserWorkoutWODRepo) GetBestRoundsRepsForWOD(userID, wodID int64) (r
 	return nil, nil, nil
 }
 
+func (m *mockUserWorkoutWODRepo) GetBestForWOD(userID int64, wodIDs []int64, scoreType string) (*domain.UserWorkoutWOD, error) {
+	return nil, nil
+}
+
 func (m *mockUserWorkoutWODRepo) GetPRWODs(userID int64, limit int) ([]*domain.UserWorkoutWOD, error) {
 	return []*domain.UserWorkoutWOD{}, nil
 }
@@ -456,6 +468,14 @@ func (m *mockUserWorkoutWODRepo) UpdatePRFlag(id int64, isPR bool) error {
 	return nil
 }
 
+func (m *mockUserWorkoutWODRepo) GetByUserIDAndWODID(userID, wodID int64, limit int) ([]*domain.UserWorkoutWOD, error) {
+	return []*domain.UserWorkoutWOD{}, nil
+}
+
+func (m *mockUserWorkoutWODRepo) GetHistoryForWOD(userID, wodID int64, start, end *time.Time) ([]*domain.UserWorkoutWOD, error) {
+	return []*domain.UserWorkoutWOD{}, nil
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -496,6 +516,10 @@ func (m *mockWODRepo) Create(wod *domain.WOD) error {
 	return nil
 }
 
+func (m *mockWODRepo) CreateInLibrary(wod *domain.WOD, orgID, addedBy int64) error {
+	return m.Create(wod)
+}
+
 func (m *mockWODRepo) GetByID(id int64) (*domain.WOD, error) {
 	if m.getByIDError != nil {
 		return nil, m.getByIDError
@@ -559,7 +583,7 @@ func (m *mockWODRepo) ListAllUserCreatedWithUserInfo(limit, offset int) ([]*doma
 	return []*domain.WODWithCreator{}, nil
 }
 
-func (m *mockWODRepo) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string) ([]*domain.WODWithCreator, int64, error) {
+func (m *mockWODRepo) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string, orgID int64) ([]*domain.WODWithCreator, int64, error) {
 	return []*domain.WODWithCreator{}, 0, nil
 }
 
@@ -659,3 +683,657 @@ func toLower(s string) string {
 	}
 	return string(result)
 }
+
+// Mock CoachAthleteRepository
+type mockCoachAthleteRepo struct {
+	rels   map[int64]*domain.CoachAthlete
+	nextID int64
+}
+
+func newMockCoachAthleteRepo() *mockCoachAthleteRepo {
+	return &mockCoachAthleteRepo{rels: make(map[int64]*domain.CoachAthlete)}
+}
+
+func (m *mockCoachAthleteRepo) Create(rel *domain.CoachAthlete) error {
+	m.nextID++
+	rel.ID = m.nextID
+	m.rels[rel.ID] = rel
+	return nil
+}
+
+func (m *mockCoachAthleteRepo) GetByID(id int64) (*domain.CoachAthlete, error) {
+	return m.rels[id], nil
+}
+
+func (m *mockCoachAthleteRepo) GetByCoachAndAthlete(coachID, athleteID int64) (*domain.CoachAthlete, error) {
+	for _, rel := range m.rels {
+		if rel.CoachID == coachID && rel.AthleteID == athleteID {
+			return rel, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCoachAthleteRepo) ListByCoach(coachID int64, status string) ([]*domain.CoachAthlete, error) {
+	var result []*domain.CoachAthlete
+	for _, rel := range m.rels {
+		if rel.CoachID == coachID && (status == "" || rel.Status == status) {
+			result = append(result, rel)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockCoachAthleteRepo) ListByAthlete(athleteID int64, status string) ([]*domain.CoachAthlete, error) {
+	var result []*domain.CoachAthlete
+	for _, rel := range m.rels {
+		if rel.AthleteID == athleteID && (status == "" || rel.Status == status) {
+			result = append(result, rel)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockCoachAthleteRepo) Update(rel *domain.CoachAthlete) error {
+	m.rels[rel.ID] = rel
+	return nil
+}
+
+// Mock AuditLogRepository
+type mockAuditLogRepo struct {
+	logs        []*domain.AuditLog
+	createError error
+}
+
+func (m *mockAuditLogRepo) Create(log *domain.AuditLog) error {
+	if m.createError != nil {
+		return m.createError
+	}
+	log.ID = int64(len(m.logs) + 1)
+	m.logs = append(m.logs, log)
+	return nil
+}
+
+func (m *mockAuditLogRepo) GetByID(id int64) (*domain.AuditLog, error) {
+	for _, log := range m.logs {
+		if log.ID == id {
+			return log, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockAuditLogRepo) List(filters domain.AuditLogFilters, limit, offset int) ([]*domain.AuditLog, error) {
+	return m.logs, nil
+}
+
+func (m *mockAuditLogRepo) Count(filters domain.AuditLogFilters) (int, error) {
+	return len(m.logs), nil
+}
+
+func (m *mockAuditLogRepo) GetByUserID(userID int64, limit, offset int) ([]*domain.AuditLog, error) {
+	return nil, nil
+}
+
+func (m *mockAuditLogRepo) GetByTargetUserID(targetUserID int64, limit, offset int) ([]*domain.AuditLog, error) {
+	return nil, nil
+}
+
+func (m *mockAuditLogRepo) DeleteOlderThan(before time.Time) (int, error) {
+	return 0, nil
+}
+
+// eventTypes lists the event types recorded in the mock audit log, oldest first
+func (m *mockAuditLogRepo) eventTypes() []string {
+	var types []string
+	for _, log := range m.logs {
+		types = append(types, log.EventType)
+	}
+	return types
+}
+
+// Mock MovementRepository
+type mockMovementRepo struct {
+	movements map[int64]*domain.Movement
+	nextID    int64
+}
+
+func newMockMovementRepo() *mockMovementRepo {
+	return &mockMovementRepo{movements: make(map[int64]*domain.Movement)}
+}
+
+func (m *mockMovementRepo) Create(movement *domain.Movement) error {
+	m.nextID++
+	movement.ID = m.nextID
+	m.movements[movement.ID] = movement
+	return nil
+}
+
+func (m *mockMovementRepo) CreateInLibrary(movement *domain.Movement, orgID, addedBy int64) error {
+	return m.Create(movement)
+}
+
+func (m *mockMovementRepo) GetByID(id int64) (*domain.Movement, error) {
+	return m.movements[id], nil
+}
+
+func (m *mockMovementRepo) GetByName(name string) (*domain.Movement, error) {
+	for _, movement := range m.movements {
+		if movement.Name == name {
+			return movement, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockMovementRepo) ListAll(filters map[string]interface{}) ([]*domain.Movement, error) {
+	var result []*domain.Movement
+	for _, movement := range m.movements {
+		result = append(result, movement)
+	}
+	return result, nil
+}
+
+func (m *mockMovementRepo) ListStandard() ([]*domain.Movement, error) {
+	var result []*domain.Movement
+	for _, movement := range m.movements {
+		if movement.IsStandard {
+			result = append(result, movement)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMovementRepo) ListByUser(userID int64) ([]*domain.Movement, error) {
+	var result []*domain.Movement
+	for _, movement := range m.movements {
+		if movement.CreatedBy != nil && *movement.CreatedBy == userID {
+			result = append(result, movement)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMovementRepo) ListAllUserCreated() ([]*domain.Movement, error) {
+	var result []*domain.Movement
+	for _, movement := range m.movements {
+		if !movement.IsStandard {
+			result = append(result, movement)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockMovementRepo) ListAllUserCreatedWithUserInfo() ([]*domain.MovementWithCreator, error) {
+	return nil, nil
+}
+
+func (m *mockMovementRepo) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, movementType, creator string, orgID int64) ([]*domain.MovementWithCreator, int64, error) {
+	return nil, 0, nil
+}
+
+func (m *mockMovementRepo) CountAllUserCreated() (int64, error) {
+	movements, _ := m.ListAllUserCreated()
+	return int64(len(movements)), nil
+}
+
+func (m *mockMovementRepo) Update(movement *domain.Movement) error {
+	m.movements[movement.ID] = movement
+	return nil
+}
+
+func (m *mockMovementRepo) UpdateStandard(movement *domain.Movement) error {
+	m.movements[movement.ID] = movement
+	return nil
+}
+
+func (m *mockMovementRepo) UpdateTaxonomy(movement *domain.Movement) error {
+	m.movements[movement.ID] = movement
+	return nil
+}
+
+func (m *mockMovementRepo) Delete(id int64) error {
+	delete(m.movements, id)
+	return nil
+}
+
+func (m *mockMovementRepo) Search(query string, filters map[string]interface{}, limit int) ([]*domain.Movement, error) {
+	return nil, nil
+}
+
+func (m *mockMovementRepo) CopyToStandard(id int64, newName string) (*domain.Movement, error) {
+	return nil, nil
+}
+
+type mockLibraryItem struct {
+	orgID      int64
+	entityType string
+	entityID   int64
+}
+
+// Mock OrganizationRepository
+// Library WODs and movements are resolved through the given WOD and movement repos
+type mockOrganizationRepo struct {
+	orgs         map[int64]*domain.Organization
+	members      []*domain.OrganizationMember
+	library      []mockLibraryItem
+	nextID       int64
+	wodRepo      *mockWODRepo
+	movementRepo *mockMovementRepo
+}
+
+func newMockOrganizationRepo(wodRepo *mockWODRepo, movementRepo *mockMovementRepo) *mockOrganizationRepo {
+	return &mockOrganizationRepo{orgs: make(map[int64]*domain.Organization), wodRepo: wodRepo, movementRepo: movementRepo}
+}
+
+func (m *mockOrganizationRepo) Create(org *domain.Organization) error {
+	m.nextID++
+	org.ID = m.nextID
+	m.orgs[org.ID] = org
+	return nil
+}
+
+func (m *mockOrganizationRepo) GetByID(id int64) (*domain.Organization, error) {
+	return m.orgs[id], nil
+}
+
+func (m *mockOrganizationRepo) GetBySlug(slug string) (*domain.Organization, error) {
+	for _, org := range m.orgs {
+		if org.Slug == slug {
+			return org, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockOrganizationRepo) List() ([]*domain.Organization, error) {
+	var result []*domain.Organization
+	for _, org := range m.orgs {
+		result = append(result, org)
+	}
+	return result, nil
+}
+
+func (m *mockOrganizationRepo) ListByUser(userID int64) ([]*domain.Organization, error) {
+	var result []*domain.Organization
+	for _, member := range m.members {
+		if member.UserID == userID {
+			org := *m.orgs[member.OrganizationID]
+			org.Role = member.Role
+			result = append(result, &org)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockOrganizationRepo) Update(org *domain.Organization) error {
+	m.orgs[org.ID] = org
+	return nil
+}
+
+func (m *mockOrganizationRepo) Delete(id int64) error {
+	delete(m.orgs, id)
+	return nil
+}
+
+func (m *mockOrganizationRepo) AddMember(member *domain.OrganizationMember) error {
+	member.ID = int64(len(m.members) + 1)
+	m.members = append(m.members, member)
+	return nil
+}
+
+func (m *mockOrganizationRepo) GetMember(orgID, userID int64) (*domain.OrganizationMember, error) {
+	for _, member := range m.members {
+		if member.OrganizationID == orgID && member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockOrganizationRepo) ListMembers(orgID int64) ([]*domain.OrganizationMember, error) {
+	var result []*domain.OrganizationMember
+	for _, member := range m.members {
+		if member.OrganizationID == orgID {
+			result = append(result, member)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockOrganizationRepo) UpdateMemberRole(orgID, userID int64, role string) error {
+	member, _ := m.GetMember(orgID, userID)
+	if member != nil {
+		member.Role = role
+	}
+	return nil
+}
+
+func (m *mockOrganizationRepo) RemoveMember(orgID, userID int64) error {
+	for i, member := range m.members {
+		if member.OrganizationID == orgID && member.UserID == userID {
+			m.members = append(m.members[:i], m.members[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockOrganizationRepo) AddLibraryItem(orgID int64, entityType string, entityID int64, addedBy int64) error {
+	m.library = append(m.library, mockLibraryItem{orgID: orgID, entityType: entityType, entityID: entityID})
+	return nil
+}
+
+func (m *mockOrganizationRepo) RemoveLibraryItem(orgID int64, entityType string, entityID int64) error {
+	for i, item := range m.library {
+		if item.orgID == orgID && item.entityType == entityType && item.entityID == entityID {
+			m.library = append(m.library[:i], m.library[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockOrganizationRepo) ListLibraryWODs(orgID int64) ([]*domain.WOD, error) {
+	var result []*domain.WOD
+	for _, item := range m.library {
+		if item.orgID == orgID && item.entityType == domain.LibraryEntityWOD {
+			result = append(result, m.wodRepo.wods[item.entityID])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockOrganizationRepo) ListLibraryMovements(orgID int64) ([]*domain.Movement, error) {
+	var result []*domain.Movement
+	for _, item := range m.library {
+		if item.orgID == orgID && item.entityType == domain.LibraryEntityMovement {
+			result = append(result, m.movementRepo.movements[item.entityID])
+		}
+	}
+	return result, nil
+}
+
+// Mock ScheduledWorkoutRepository
+type mockScheduledWorkoutRepo struct {
+	scheduled map[int64]*domain.ScheduledWorkout
+	nextID    int64
+}
+
+func newMockScheduledWorkoutRepo() *mockScheduledWorkoutRepo {
+	return &mockScheduledWorkoutRepo{scheduled: make(map[int64]*domain.ScheduledWorkout)}
+}
+
+func (m *mockScheduledWorkoutRepo) Create(sw *domain.ScheduledWorkout) error {
+	m.nextID++
+	sw.ID = m.nextID
+	m.scheduled[sw.ID] = sw
+	return nil
+}
+
+func (m *mockScheduledWorkoutRepo) GetByID(id int64) (*domain.ScheduledWorkout, error) {
+	return m.scheduled[id], nil
+}
+
+func (m *mockScheduledWorkoutRepo) ListByOrganization(orgID int64, startDate, endDate string, track string) ([]*domain.ScheduledWorkout, error) {
+	var result []*domain.ScheduledWorkout
+	for id := int64(1); id <= m.nextID; id++ {
+		sw, ok := m.scheduled[id]
+		if !ok || sw.OrganizationID != orgID || sw.ScheduledDate < startDate || sw.ScheduledDate > endDate {
+			continue
+		}
+		if track == "" || sw.Track == track {
+			result = append(result, sw)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockScheduledWorkoutRepo) Update(sw *domain.ScheduledWorkout) error {
+	m.scheduled[sw.ID] = sw
+	return nil
+}
+
+func (m *mockScheduledWorkoutRepo) Delete(id int64) error {
+	delete(m.scheduled, id)
+	return nil
+}
+
+// Mock SearchRepository
+// Records the arguments of the last search and returns a single WOD result
+type mockSearchRepo struct {
+	terms     []string
+	types     []string
+	limit     int
+	refreshed []int64 // Entity IDs passed to Refresh, in order
+}
+
+func (m *mockSearchRepo) Search(userID int64, terms []string, entityTypes []string, limit int) ([]*domain.SearchResult, error) {
+	m.terms, m.types, m.limit = terms, entityTypes, limit
+	return []*domain.SearchResult{{EntityType: domain.SearchEntityWOD, EntityID: 1, Title: "Fran"}}, nil
+}
+
+func (m *mockSearchRepo) Refresh(entityType string, entityID int64) error {
+	m.refreshed = append(m.refreshed, entityID)
+	return nil
+}
+
+func (m *mockSearchRepo) Rebuild() (int, error) {
+	return 0, nil
+}
+
+func (m *mockSearchRepo) NeedsRebuild() (bool, error) {
+	return false, nil
+}
+
+func (m *mockSearchRepo) Backend() string {
+	return domain.SearchBackendLike
+}
+
+// Mock EnumerationRepository
+// Usage counts are keyed by category and value
+type mockEnumerationRepo struct {
+	enumerations []*domain.Enumeration
+	usage        map[string]int
+	nextID       int64
+}
+
+func newMockEnumerationRepo() *mockEnumerationRepo {
+	return &mockEnumerationRepo{usage: make(map[string]int)}
+}
+
+func (m *mockEnumerationRepo) Create(e *domain.Enumeration) error {
+	m.nextID++
+	e.ID = m.nextID
+	copied := *e
+	m.enumerations = append(m.enumerations, &copied)
+	return nil
+}
+
+func (m *mockEnumerationRepo) GetByID(id int64) (*domain.Enumeration, error) {
+	for _, e := range m.enumerations {
+		if e.ID == id {
+			copied := *e
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockEnumerationRepo) List(category string, includeDeprecated bool) ([]*domain.Enumeration, error) {
+	var result []*domain.Enumeration
+	for _, e := range m.enumerations {
+		if (category == "" || e.Category == category) && (includeDeprecated || !e.IsDeprecated) {
+			copied := *e
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEnumerationRepo) Update(e *domain.Enumeration, previousValue string) error {
+	for i, existing := range m.enumerations {
+		if existing.ID == e.ID {
+			copied := *e
+			m.enumerations[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (m *mockEnumerationRepo) Delete(id int64) error {
+	for i, e := range m.enumerations {
+		if e.ID == id {
+			m.enumerations = append(m.enumerations[:i], m.enumerations[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockEnumerationRepo) CountUsage(category, value string) (int, error) {
+	return m.usage[category+":"+value], nil
+}
+
+// Mock WODVersionRepository
+type mockWODVersionRepo struct {
+	versions  []*domain.WODVersion
+	variants  map[int64]*domain.WODVariant
+	assigned  map[int64]int64 // WOD ID -> version ID given to unversioned results
+	nextID    int64
+	wodRepo   *mockWODRepo // Receives saved edits when set
+	saveError error
+}
+
+func newMockWODVersionRepo(wodRepo *mockWODRepo) *mockWODVersionRepo {
+	return &mockWODVersionRepo{
+		variants: make(map[int64]*domain.WODVariant),
+		assigned: make(map[int64]int64),
+		wodRepo:  wodRepo,
+	}
+}
+
+func (m *mockWODVersionRepo) SaveEdit(wod *domain.WOD, versions []*domain.WODVersion) error {
+	if m.saveError != nil {
+		return m.saveError
+	}
+	if m.wodRepo != nil {
+		copied := *wod
+		m.wodRepo.wods[wod.ID] = &copied
+	}
+	for _, version := range versions {
+		m.nextID++
+		version.ID = m.nextID
+		copied := *version
+		m.versions = append(m.versions, &copied)
+		if version.Version == 1 {
+			m.assigned[wod.ID] = version.ID
+		}
+	}
+	return nil
+}
+
+func (m *mockWODVersionRepo) ListVersions(wodID int64) ([]*domain.WODVersion, error) {
+	var result []*domain.WODVersion
+	for _, v := range m.versions {
+		if v.WODID == wodID {
+			copied := *v
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWODVersionRepo) UpdateComparability(versions []*domain.WODVersion) error {
+	for _, updated := range versions {
+		for _, v := range m.versions {
+			if v.ID == updated.ID {
+				v.ComparableWithPrevious = updated.ComparableWithPrevious
+				v.BaselineVersion = updated.BaselineVersion
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockWODVersionRepo) DeleteForWOD(wodID int64) error {
+	return nil
+}
+
+func (m *mockWODVersionRepo) GetVariant(wodID int64) (*domain.WODVariant, error) {
+	if v, ok := m.variants[wodID]; ok {
+		copied := *v
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *mockWODVersionRepo) ListVariants(parentWODID int64) ([]*domain.WODVariant, error) {
+	result := []*domain.WODVariant{}
+	for _, v := range m.variants {
+		if v.ParentWODID == parentWODID {
+			copied := *v
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWODVersionRepo) SaveVariant(variant *domain.WODVariant) error {
+	copied := *variant
+	m.variants[variant.WODID] = &copied
+	return nil
+}
+
+func (m *mockWODVersionRepo) DeleteVariant(wodID int64) error {
+	delete(m.variants, wodID)
+	return nil
+}
+
+func (m *mockWODVersionRepo) ListUserIDsWithResults(wodIDs []int64) ([]int64, error) {
+	return nil, nil
+}
+
+// Mock AttachmentRepository
+type mockAttachmentRepo struct {
+	attachments map[int64]*domain.Attachment
+	nextID      int64
+}
+
+func newMockAttachmentRepo() *mockAttachmentRepo {
+	return &mockAttachmentRepo{attachments: make(map[int64]*domain.Attachment)}
+}
+
+func (m *mockAttachmentRepo) Create(attachment *domain.Attachment) error {
+	m.nextID++
+	attachment.ID = m.nextID
+	m.attachments[attachment.ID] = attachment
+	return nil
+}
+
+func (m *mockAttachmentRepo) GetByID(id int64) (*domain.Attachment, error) {
+	return m.attachments[id], nil
+}
+
+func (m *mockAttachmentRepo) ListByEntity(entityType string, entityID int64) ([]*domain.Attachment, error) {
+	var result []*domain.Attachment
+	for id := int64(1); id <= m.nextID; id++ {
+		if a, ok := m.attachments[id]; ok && a.EntityType == entityType && a.EntityID == entityID {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockAttachmentRepo) Delete(id int64) error {
+	delete(m.attachments, id)
+	return nil
+}
+
+func (m *mockAttachmentRepo) DeleteByEntity(entityType string, entityID int64) ([]*domain.Attachment, error) {
+	deleted, _ := m.ListByEntity(entityType, entityID)
+	for _, a := range deleted {
+		delete(m.attachments, a.ID)
+	}
+	return deleted, nil
+}
@@ -25,6 +25,7 @@ var (
 	ErrInvalidRefreshToken      = errors.New("invalid or expired refresh token")
 	ErrAccountLocked            = errors.New("account locked due to too many failed login attempts")
 	ErrAccountDisabled          = errors.New("account has been disabled by an administrator")
+	ErrInvalidGender            = errors.New("gender must be male or female")
 )
 
 // UserService handles user-related business logic
@@ -618,8 +619,13 @@ func (s *UserService) GetUserRefreshTokens(userID int64) ([]*domain.RefreshToken
 	return tokens, nil
 }
 
-// UpdateProfile updates user profile information
-func (s *UserService) UpdateProfile(userID int64, name, email string, birthday *time.Time) (*domain.User, error) {
+// UpdateProfile updates user profile information; empty or nil fields are left unchanged
+// clearBirthday and clearGender remove those fields (e.g., to leave the leaderboard brackets)
+func (s *UserService) UpdateProfile(userID int64, name, email string, birthday *time.Time, gender *string, clearBirthday, clearGender bool) (*domain.User, error) {
+	if gender != nil && *gender != domain.GenderMale && *gender != domain.GenderFemale {
+		return nil, ErrInvalidGender
+	}
+
 	// Get current user
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -661,7 +667,18 @@ func (s *UserService) UpdateProfile(userID int64, name, email string, birthday *
 	}
 
 	// Update birthday if provided
-	user.Birthday = birthday
+	if birthday != nil {
+		user.Birthday = birthday
+	} else if clearBirthday {
+		user.Birthday = nil
+	}
+
+	// Update gender if provided (used for leaderboard brackets)
+	if gender != nil {
+		user.Gender = gender
+	} else if clearGender {
+		user.Gender = nil
+	}
 
 	// Update timestamp
 	user.UpdatedAt = time.Now()
@@ -852,12 +869,38 @@ func (s *UserService) ListUsers(limit, offset int) ([]*domain.User, int64, error
 		return nil, 0, fmt.Errorf("failed to count users: %w", err)
 	}
 
+	return withoutPasswordHashes(users), count, nil
+}
+
+// ListOrganizationUsers returns a paginated list of a gym's members (gym-scoped admin operation)
+func (s *UserService) ListOrganizationUsers(orgID int64, limit, offset int) ([]*domain.User, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, err := s.userRepo.ListByOrganization(orgID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list gym users: %w", err)
+	}
+
+	count, err := s.userRepo.CountByOrganization(orgID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count gym users: %w", err)
+	}
+
+	return withoutPasswordHashes(users), count, nil
+}
+
+func withoutPasswordHashes(users []*domain.User) []*domain.User {
+
 	// Don't return password hashes
 	for _, user := range users {
 		user.PasswordHash = ""
 	}
-
-	return users, count, nil
+	return users
 }
 
 // SetEmailVerification sets email verification status (admin operation)
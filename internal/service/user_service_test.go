@@ -89,6 +89,14 @@ func (m *mockUserRepo) Count() (int64, error) {
 	return int64(len(m.users)), nil
 }
 
+func (m *mockUserRepo) ListByOrganization(orgID int64, limit, offset int) ([]*domain.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepo) CountByOrganization(orgID int64) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockUserRepo) UpdatePassword(userID int64, hashedPassword string) error {
 	if user, ok := m.users[userID]; ok {
 		user.PasswordHash = hashedPassword
@@ -702,3 +710,55 @@ func TestJWTTokenGeneration(t *testing.T) {
 		t.Errorf("Expected Role %s, got %s", user.Role, claims.Role)
 	}
 }
+
+// Test that profile fields left out of an update are kept
+func TestUpdateProfileKeepsOmittedFields(t *testing.T) {
+	service := newTestUserService(true)
+	birthday := time.Date(1988, 4, 2, 0, 0, 0, 0, time.UTC)
+	service.userRepo.Create(&domain.User{Email: "athlete@example.com", Name: "Athlete", Birthday: &birthday, Gender: stringPtr(domain.GenderFemale)})
+
+	user, err := service.UpdateProfile(1, "Renamed", "", nil, nil, false, false)
+	if err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if user.Name != "Renamed" || user.Gender == nil || *user.Gender != domain.GenderFemale || user.Birthday == nil || !user.Birthday.Equal(birthday) {
+		t.Errorf("expected gender and birthday to be kept, got %+v", user)
+	}
+
+	user, err = service.UpdateProfile(1, "", "", nil, stringPtr(domain.GenderMale), false, false)
+	if err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if user.Name != "Renamed" || user.Gender == nil || *user.Gender != domain.GenderMale {
+		t.Errorf("expected only the gender to change, got %+v", user)
+	}
+
+	if _, err := service.UpdateProfile(1, "", "", nil, stringPtr("other"), false, false); err != ErrInvalidGender {
+		t.Errorf("expected ErrInvalidGender, got %v", err)
+	}
+}
+
+// Test that gender and birthday can be cleared to leave the leaderboard brackets
+func TestUpdateProfileClearsFields(t *testing.T) {
+	service := newTestUserService(true)
+	birthday := time.Date(1988, 4, 2, 0, 0, 0, 0, time.UTC)
+	service.userRepo.Create(&domain.User{Email: "athlete@example.com", Name: "Athlete", Birthday: &birthday, Gender: stringPtr(domain.GenderFemale)})
+
+	user, err := service.UpdateProfile(1, "", "", nil, nil, false, true)
+	if err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if user.Gender != nil || user.Birthday == nil {
+		t.Errorf("expected only the gender to be cleared, got gender %v and birthday %v", user.Gender, user.Birthday)
+	}
+
+	if user, err = service.UpdateProfile(1, "", "", nil, nil, true, false); err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if user.Birthday != nil {
+		t.Errorf("expected the birthday to be cleared, got %v", user.Birthday)
+	}
+	if stored, _ := service.userRepo.GetByID(1); stored.Gender != nil || stored.Birthday != nil || stored.Name != "Athlete" {
+		t.Errorf("expected the cleared fields to be saved, got %+v", stored)
+	}
+}
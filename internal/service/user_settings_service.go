@@ -56,6 +56,7 @@ func (s *UserSettingsService) UpdateSettings(userID int64, updates *domain.UserS
 	existing.Theme = updates.Theme
 	existing.WeightUnit = updates.WeightUnit
 	existing.DistanceUnit = updates.DistanceUnit
+	existing.LeaderboardOptIn = updates.LeaderboardOptIn
 
 	if err := s.settingsRepo.Update(existing); err != nil {
 		return nil, err
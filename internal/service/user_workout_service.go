@@ -3,6 +3,9 @@ package service
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/johnzastrow/actalog/internal/domain"
@@ -11,6 +14,7 @@ import (
 var (
 	ErrUserWorkoutNotFound       = errors.New("user workout not found")
 	ErrUnauthorizedWorkoutAccess = errors.New("unauthorized workout access")
+	ErrInvalidSessionWellness    = errors.New("session RPE and readiness must be 1-10, sleep quality and soreness 1-5, sleep hours 0-24")
 )
 
 // UserWorkoutService handles logging workout instances (when users perform workouts)
@@ -21,6 +25,11 @@ type UserWorkoutService struct {
 	userWorkoutMovementRepo domain.UserWorkoutMovementRepository
 	userWorkoutWODRepo      domain.UserWorkoutWODRepository
 	wodRepo                 domain.WODRepository
+	goalService             *GoalService
+	achievementService      *AchievementService
+	searchService           *SearchService
+	wodVersionService       *WODVersionService
+	attachmentService       *AttachmentService
 }
 
 // NewUseroutService creates a new user workout service
@@ -42,8 +51,52 @@ func NewUserWorkoutService(
 	}
 }
 
+// SetGoalService enables goal evaluation after every logged workout
+func (s *UserWorkoutService) SetGoalService(goalService *GoalService) {
+	s.goalService = goalService
+}
+
+// SetAchievementService enables badge evaluation after every logged workout
+func (s *UserWorkoutService) SetAchievementService(achievementService *AchievementService) {
+	s.achievementService = achievementService
+}
+
+// SetSearchService keeps workout notes searchable as workouts are logged, edited and deleted
+func (s *UserWorkoutService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
+// SetWODVersionService ranks WOD PRs against comparable variants and versions only
+func (s *UserWorkoutService) SetWODVersionService(wodVersionService *WODVersionService) {
+	s.wodVersionService = wodVersionService
+}
+
+// SetAttachmentService removes a logged workout's attachments when the workout is deleted
+func (s *UserWorkoutService) SetAttachmentService(attachmentService *AttachmentService) {
+	s.attachmentService = attachmentService
+}
+
 // LogWorkout logs that a user performed a workout (template-based or ad-hoc) on a specific date
-func (s *UserWorkoutService) LogWorkout(userID int64, templateID *int64, workoutName *string, date time.Time, notes *string, totalTime *int, workoutType *string) (*domain.UserWorkout, error) {
+// wellness is the optional session RPE and readiness report
+func (s *UserWorkoutService) LogWorkout(userID int64, templateID *int64, workoutName *string, date time.Time, notes *string, totalTime *int, workoutType *string, wellness *domain.SessionWellness) (*domain.UserWorkout, error) {
+	userWorkout, err := s.createWorkout(userID, templateID, workoutName, date, notes, totalTime, workoutType, wellness, false)
+	if err != nil {
+		return nil, err
+	}
+
+	s.afterLog(userID, userWorkout.ID)
+	return userWorkout, nil
+}
+
+// createWorkout validates and saves the base user workout
+// templateGranted skips the template ownership check when access was granted elsewhere (e.g., by a gym schedule)
+func (s *UserWorkoutService) createWorkout(userID int64, templateID *int64, workoutName *string, date time.Time, notes *string, totalTime *int, workoutType *string, wellness *domain.SessionWellness, templateGranted bool) (*domain.UserWorkout, error) {
+	if wellness != nil {
+		if err := validateSessionWellness(*wellness); err != nil {
+			return nil, err
+		}
+	}
+
 	// If template ID is provided, verify it exists and check authorization
 	if templateID != nil && *templateID != 0 {
 		workout, err := s.workoutRepo.GetByID(*templateID)
@@ -55,7 +108,7 @@ func (s *UserWorkoutService) LogWorkout(userID int64, templateID *int64, workout
 		}
 
 		// Check authorization: user can only log workouts they created or standard workouts (created_by = null)
-		if workout.CreatedBy != nil && *workout.CreatedBy != userID {
+		if !templateGranted && workout.CreatedBy != nil && *workout.CreatedBy != userID {
 			return nil, ErrUnauthorizedWorkoutAccess
 		}
 	}
@@ -70,6 +123,9 @@ func (s *UserWorkoutService) LogWorkout(userID int64, templateID *int64, workout
 		TotalTime:   totalTime,
 		Notes:       notes,
 	}
+	if wellness != nil {
+		userWorkout.SessionWellness = *wellness
+	}
 
 	err := s.userWorkoutRepo.Create(userWorkout)
 	if err != nil {
@@ -88,30 +144,84 @@ func (s *UserWorkoutService) LogWorkoutWithPerformance(
 	notes *string,
 	totalTime *int,
 	workoutType *string,
+	wellness *domain.SessionWellness,
 	movements []*domain.UserWorkoutMovement,
 	wods []*domain.UserWorkoutWOD,
 ) (*domain.UserWorkout, error) {
 	// First create the base user workout
-	userWorkout, err := s.LogWorkout(userID, templateID, workoutName, date, notes, totalTime, workoutType)
+	userWorkout, err := s.createWorkout(userID, templateID, workoutName, date, notes, totalTime, workoutType, wellness, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.savePerformance(userID, userWorkout, movements, wods); err != nil {
+		return nil, err
+	}
+
+	s.afterLog(userID, userWorkout.ID)
+	return userWorkout, nil
+}
+
+// LogScheduledWorkout logs a workout published on a gym's programming calendar, with optional wellness and
+// performance data. Access to the template is granted by the schedule, so LogWorkout's template ownership
+// check does not apply
+func (s *UserWorkoutService) LogScheduledWorkout(
+	userID int64,
+	templateID *int64,
+	workoutName *string,
+	date time.Time,
+	notes *string,
+	totalTime *int,
+	workoutType *string,
+	wellness *domain.SessionWellness,
+	movements []*domain.UserWorkoutMovement,
+	wods []*domain.UserWorkoutWOD,
+) (*domain.UserWorkout, error) {
+	userWorkout, err := s.createWorkout(userID, templateID, workoutName, date, notes, totalTime, workoutType, wellness, true)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.savePerformance(userID, userWorkout, movements, wods); err != nil {
+		return nil, err
+	}
+
+	s.afterLog(userID, userWorkout.ID)
+	return userWorkout, nil
+}
+
+// afterLog indexes the newly logged workout for search, once its performance is saved, and evaluates
+// the user's active goals and achievement rules against it
+// Evaluation is best-effort and never fails the log
+func (s *UserWorkoutService) afterLog(userID, userWorkoutID int64) {
+	s.refreshSearch(userWorkoutID)
+	if s.goalService != nil {
+		_, _ = s.goalService.CheckGoals(userID)
+	}
+	if s.achievementService != nil {
+		_, _ = s.achievementService.EvaluateUser(userID)
+	}
+}
+
+// savePerformance flags PRs and saves movement and WOD performance for a newly logged workout
+// The logged workout is deleted again if any step fails
+func (s *UserWorkoutService) savePerformance(userID int64, userWorkout *domain.UserWorkout, movements []*domain.UserWorkoutMovement, wods []*domain.UserWorkoutWOD) error {
 	// Set the user_workout_id for all movements
 	for _, m := range movements {
 		m.UserWorkoutID = userWorkout.ID
 	}
 
-	// Set the user_workout_id for all WODs
+	// Set the user_workout_id for all WODs; new results are performed on each WOD's latest version
 	for _, w := range wods {
 		w.UserWorkoutID = userWorkout.ID
+		w.WODVersionID = nil
 	}
 
 	// Detect and flag PRs for movements before saving
 	if len(movements) > 0 {
 		if err := s.DetectAndFlagMovementPRs(userID, movements); err != nil {
 			_ = s.userWorkoutRepo.Delete(userWorkout.ID, userID)
-			return nil, fmt.Errorf("failed to detect movement PRs: %w", err)
+			return fmt.Errorf("failed to detect movement PRs: %w", err)
 		}
 	}
 
@@ -119,7 +229,7 @@ func (s *UserWorkoutService) LogWorkoutWithPerformance(
 	if len(wods) > 0 {
 		if err := s.ValidateWODScoreTypes(wods); err != nil {
 			_ = s.userWorkoutRepo.Delete(userWorkout.ID, userID)
-			return nil, fmt.Errorf("WOD validation failed: %w", err)
+			return fmt.Errorf("WOD validation failed: %w", err)
 		}
 	}
 
@@ -127,7 +237,7 @@ func (s *UserWorkoutService) LogWorkoutWithPerformance(
 	if len(wods) > 0 {
 		if err := s.DetectAndFlagWODPRs(userID, wods); err != nil {
 			_ = s.userWorkoutRepo.Delete(userWorkout.ID, userID)
-			return nil, fmt.Errorf("failed to detect WOD PRs: %w", err)
+			return fmt.Errorf("failed to detect WOD PRs: %w", err)
 		}
 	}
 
@@ -136,7 +246,7 @@ func (s *UserWorkoutService) LogWorkoutWithPerformance(
 		if err := s.userWorkoutMovementRepo.CreateBatch(movements); err != nil {
 			// Rollback: delete the user workout if performance data fails
 			_ = s.userWorkoutRepo.Delete(userWorkout.ID, userID)
-			return nil, fmt.Errorf("failed to save movement performance data: %w", err)
+			return fmt.Errorf("failed to save movement performance data: %w", err)
 		}
 	}
 
@@ -146,11 +256,11 @@ func (s *UserWorkoutService) LogWorkoutWithPerformance(
 			// Rollback: delete movement data and user workout
 			_ = s.userWorkoutMovementRepo.DeleteByUserWorkoutID(userWorkout.ID)
 			_ = s.userWorkoutRepo.Delete(userWorkout.ID, userID)
-			return nil, fmt.Errorf("failed to save WOD performance data: %w", err)
+			return fmt.Errorf("failed to save WOD performance data: %w", err)
 		}
 	}
 
-	return userWorkout, nil
+	return nil
 }
 
 // GetLoggedWorkout retrieves a logged workout by ID with full details including performance data
@@ -213,7 +323,8 @@ func (s *UserWorkoutService) ListLoggedWorkoutsByDateRange(userID int64, startDa
 }
 
 // UpdateLoggedWorkout updates a logged workout with authorization check
-func (s *UserWorkoutService) UpdateLoggedWorkout(userWorkoutID, userID int64, workoutName *string, notes *string, totalTime *int, workoutType *string) error {
+// Only the wellness fields that are set replace the stored report
+func (s *UserWorkoutService) UpdateLoggedWorkout(userWorkoutID, userID int64, workoutName *string, notes *string, totalTime *int, workoutType *string, wellness *domain.SessionWellness) error {
 	// Get existing logged workout
 	existing, err := s.userWorkoutRepo.GetByID(userWorkoutID)
 	if err != nil {
@@ -244,11 +355,34 @@ func (s *UserWorkoutService) UpdateLoggedWorkout(userWorkoutID, userID int64, wo
 	if workoutType != nil {
 		existing.WorkoutType = workoutType
 	}
+	if wellness != nil {
+		merged := existing.SessionWellness
+		if wellness.SessionRPE != nil {
+			merged.SessionRPE = wellness.SessionRPE
+		}
+		if wellness.SleepHours != nil {
+			merged.SleepHours = wellness.SleepHours
+		}
+		if wellness.SleepQuality != nil {
+			merged.SleepQuality = wellness.SleepQuality
+		}
+		if wellness.Soreness != nil {
+			merged.Soreness = wellness.Soreness
+		}
+		if wellness.Readiness != nil {
+			merged.Readiness = wellness.Readiness
+		}
+		if err := validateSessionWellness(merged); err != nil {
+			return err
+		}
+		existing.SessionWellness = merged
+	}
 
 	err = s.userWorkoutRepo.Update(existing)
 	if err != nil {
 		return fmt.Errorf("failed to update logged workout: %w", err)
 	}
+	s.refreshSearch(userWorkoutID)
 	return nil
 }
 
@@ -273,9 +407,22 @@ func (s *UserWorkoutService) DeleteLoggedWorkout(userWorkoutID, userID int64) er
 	if err != nil {
 		return fmt.Errorf("failed to delete logged workout: %w", err)
 	}
+	s.refreshSearch(userWorkoutID)
+	if s.attachmentService != nil {
+		if err := s.attachmentService.DeleteForEntity(domain.AttachmentEntityWorkout, userWorkoutID); err != nil {
+			fmt.Printf("Warning: failed to delete workout attachments: %v\n", err)
+		}
+	}
 	return nil
 }
 
+// refreshSearch re-indexes a logged workout when search is wired
+func (s *UserWorkoutService) refreshSearch(id int64) {
+	if s.searchService != nil {
+		s.searchService.Refresh(domain.SearchEntityWorkout, id)
+	}
+}
+
 // UpdateWorkoutMovements updates the movements for a logged workout
 func (s *UserWorkoutService) UpdateWorkoutMovements(userWorkoutID, userID int64, movements []domain.UserWorkoutMovement) error {
 	// Authorization check
@@ -329,6 +476,16 @@ func (s *UserWorkoutService) UpdateWorkoutWODs(userWorkoutID, userID int64, wods
 		return fmt.Errorf("WOD validation failed: %w", err)
 	}
 
+	// Edited results keep the version originally performed; WODs new to the workout use the latest
+	previous, err := s.userWorkoutWODRepo.GetByUserWorkoutID(userWorkoutID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing WODs: %w", err)
+	}
+	performedVersions := make(map[int64]*int64)
+	for _, p := range previous {
+		performedVersions[p.WODID] = p.WODVersionID
+	}
+
 	// Delete existing WODs
 	if err := s.userWorkoutWODRepo.DeleteByUserWorkoutID(userWorkoutID); err != nil {
 		return fmt.Errorf("failed to delete existing WODs: %w", err)
@@ -337,6 +494,7 @@ func (s *UserWorkoutService) UpdateWorkoutWODs(userWorkoutID, userID int64, wods
 	// Insert new WODs
 	for _, wod := range wods {
 		wod.UserWorkoutID = userWorkoutID
+		wod.WODVersionID = performedVersions[wod.WODID]
 		if err := s.userWorkoutWODRepo.Create(&wod); err != nil {
 			return fmt.Errorf("failed to create WOD: %w", err)
 		}
@@ -381,49 +539,53 @@ func (s *UserWorkoutService) DetectAndFlagMovementPRs(userID int64, movements []
 	return nil
 }
 
-// DetectAndFlagWODPRs automatically detects personal records for WODs (time-based or rounds+reps)
+// DetectAndFlagWODPRs automatically detects personal records for WODs, ranked by each WOD's score type
 func (s *UserWorkoutService) DetectAndFlagWODPRs(userID int64, wods []*domain.UserWorkoutWOD) error {
 	for _, w := range wods {
-		// Check for time-based PRs (fastest time)
-		if w.TimeSeconds != nil {
-			bestTime, err := s.userWorkoutWODRepo.GetBestTimeForWOD(userID, w.WODID)
-			if err != nil {
-				return fmt.Errorf("failed to get best time for WOD %d: %w", w.WODID, err)
-			}
-
-			// If this is the first time doing this WOD, or if time is faster than previous best, it's a PR
-			if bestTime == nil || *w.TimeSeconds < *bestTime {
-				w.IsPR = true
-			}
+		def := wodScoreTypeDefinition(w)
+		if def == nil || !def.IsScored(w) {
 			continue
 		}
 
-		// Check for rounds+reps PRs (most rounds, then most reps)
-		if w.Rounds != nil {
-			bestRounds, bestReps, err := s.userWorkoutWODRepo.GetBestRoundsRepsForWOD(userID, w.WODID)
-			if err != nil {
-				return fmt.Errorf("failed to get best rounds+reps for WOD %d: %w", w.WODID, err)
+		wodIDs := []int64{w.WODID}
+		if s.wodVersionService != nil {
+			var err error
+			if wodIDs, err = s.wodVersionService.ComparableWODIDs(w.WODID); err != nil {
+				return fmt.Errorf("failed to get comparable WODs for WOD %d: %w", w.WODID, err)
 			}
+		}
 
-			// If this is the first time doing this WOD, it's a PR
-			if bestRounds == nil {
-				w.IsPR = true
-				continue
-			}
+		best, err := s.userWorkoutWODRepo.GetBestForWOD(userID, wodIDs, def.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get best result for WOD %d: %w", w.WODID, err)
+		}
 
-			// Check if current rounds > best rounds
-			if *w.Rounds > *bestRounds {
-				w.IsPR = true
-				continue
-			}
+		// If this is the first time doing this WOD, or the score beats the previous best, it's a PR
+		if best == nil || def.Compare(w, best) > 0 {
+			w.IsPR = true
+		}
+	}
+	return nil
+}
 
-			// If rounds are equal, check reps
-			if *w.Rounds == *bestRounds && w.Reps != nil && bestReps != nil && *w.Reps > *bestReps {
-				w.IsPR = true
-				continue
-			}
+// wodScoreTypeDefinition resolves how a WOD result is ranked: the WOD's score type, then the
+// result's own, then time or rounds+reps inferred from the fields (how results were ranked
+// before score types were registered). Returns nil when the result cannot be ranked.
+func wodScoreTypeDefinition(w *domain.UserWorkoutWOD) *domain.ScoreTypeDefinition {
+	if def := domain.LookupScoreType(w.WODScoreType); def != nil {
+		return def
+	}
+	if w.ScoreType != nil {
+		if def := domain.LookupScoreType(*w.ScoreType); def != nil {
+			return def
 		}
 	}
+	switch {
+	case w.TimeSeconds != nil:
+		return domain.LookupScoreType(domain.ScoreTypeTime)
+	case w.Rounds != nil:
+		return domain.LookupScoreType(domain.ScoreTypeRoundsReps)
+	}
 	return nil
 }
 
@@ -455,18 +617,20 @@ func (s *UserWorkoutService) RetroactivelyFlagPRs(userID int64) (int, int, error
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get user workouts: %w", err)
 	}
+	// The repository lists newest first; PRs must be evaluated oldest first
+	sort.SliceStable(workouts, func(i, j int) bool {
+		if !workouts[i].WorkoutDate.Equal(workouts[j].WorkoutDate) {
+			return workouts[i].WorkoutDate.Before(workouts[j].WorkoutDate)
+		}
+		return workouts[i].ID < workouts[j].ID
+	})
 
 	// Track max weights per movement_id
 	maxWeights := make(map[int64]float64)
 
-	// Track best times per wod_id
-	bestTimes := make(map[int64]int)
-
-	// Track best rounds+reps per wod_id
-	bestRoundsReps := make(map[int64]struct {
-		rounds int
-		reps   int
-	})
+	// Track the best result per group of comparable results (see comparisonKey)
+	bestWODs := make(map[string]*domain.UserWorkoutWOD)
+	comparisonKeys := make(map[string]string)
 
 	// Process each workout chronologically
 	for _, workout := range workouts {
@@ -519,50 +683,21 @@ func (s *UserWorkoutService) RetroactivelyFlagPRs(userID int64) (int, int, error
 
 		// Process each WOD
 		for _, wod := range wods {
-			wodID := wod.WODID
+			key, err := s.comparisonKey(wod, comparisonKeys)
+			if err != nil {
+				return movementPRCount, wodPRCount, err
+			}
 			isPR := false
 
-			// Check time-based PRs
-			if wod.TimeSeconds != nil {
-				currentTime := *wod.TimeSeconds
-
-				if bestTime, exists := bestTimes[wodID]; exists {
-					// Compare with previous best (lower time is better)
-					if currentTime < bestTime {
-						isPR = true
-						bestTimes[wodID] = currentTime
-					}
-				} else {
-					// First time doing this WOD - it's a PR
-					isPR = true
-					bestTimes[wodID] = currentTime
-				}
+			// Rank by the WOD's score type (lower time, more rounds+reps, more calories, ...)
+			if wod.WOD != nil {
+				wod.WODScoreType = wod.WOD.ScoreType
 			}
-
-			// Check rounds+reps PRs
-			if wod.Rounds != nil {
-				currentRounds := *wod.Rounds
-				currentReps := 0
-				if wod.Reps != nil {
-					currentReps = *wod.Reps
-				}
-
-				if best, exists := bestRoundsReps[wodID]; exists {
-					// Compare with previous best
-					if currentRounds > best.rounds || (currentRounds == best.rounds && currentReps > best.reps) {
-						isPR = true
-						bestRoundsReps[wodID] = struct {
-							rounds int
-							reps   int
-						}{currentRounds, currentReps}
-					}
-				} else {
-					// First time doing this WOD - it's a PR
+			if def := wodScoreTypeDefinition(wod); def != nil && def.IsScored(wod) {
+				// First time doing this WOD, or better than the previous best, is a PR
+				if best, exists := bestWODs[key]; !exists || def.Compare(wod, best) > 0 {
 					isPR = true
-					bestRoundsReps[wodID] = struct {
-						rounds int
-						reps   int
-					}{currentRounds, currentReps}
+					bestWODs[key] = wod
 				}
 			}
 
@@ -581,6 +716,28 @@ func (s *UserWorkoutService) RetroactivelyFlagPRs(userID int64) (int, int, error
 	return movementPRCount, wodPRCount, nil
 }
 
+// comparisonKey groups a result with the results it is ranked against: its WOD, or with versions wired,
+// the current comparable run of its WOD and comparable variants. Keys are cached per WOD and version.
+func (s *UserWorkoutService) comparisonKey(w *domain.UserWorkoutWOD, cache map[string]string) (string, error) {
+	if s.wodVersionService == nil {
+		return strconv.FormatInt(w.WODID, 10), nil
+	}
+
+	cacheKey := strconv.FormatInt(w.WODID, 10)
+	if w.WODVersionID != nil {
+		cacheKey += "/" + strconv.FormatInt(*w.WODVersionID, 10)
+	}
+	if key, ok := cache[cacheKey]; ok {
+		return key, nil
+	}
+	key, err := s.wodVersionService.ComparisonKey(w.WODID, w.WODVersionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to group results of WOD %d: %w", w.WODID, err)
+	}
+	cache[cacheKey] = key
+	return key, nil
+}
+
 // ValidateWODScoreTypes validates that WOD performance data matches each WOD's defined score_type
 func (s *UserWorkoutService) ValidateWODScoreTypes(wods []*domain.UserWorkoutWOD) error {
 	for _, w := range wods {
@@ -593,42 +750,51 @@ func (s *UserWorkoutService) ValidateWODScoreTypes(wods []*domain.UserWorkoutWOD
 			return fmt.Errorf("WOD with ID %d not found", w.WODID)
 		}
 
-		// Validate based on score_type
-		scoreType := wod.ScoreType
-
-		// Time-based WODs
-		if scoreType == "Time (HH:MM:SS)" {
-			// Must have time_seconds, must NOT have rounds/reps/weight
-			if w.TimeSeconds == nil {
-				return fmt.Errorf("WOD '%s' has score_type '%s' but time_seconds is missing", wod.Name, scoreType)
-			}
-			if w.Rounds != nil || w.Reps != nil || w.Weight != nil {
-				return fmt.Errorf("WOD '%s' has score_type '%s' but contains invalid fields (rounds/reps/weight)", wod.Name, scoreType)
+		// Division is optional; normalize it for leaderboards
+		if w.Division != nil {
+			division := strings.ToLower(strings.TrimSpace(*w.Division))
+			switch division {
+			case "":
+				w.Division = nil
+			case domain.DivisionRx, domain.DivisionScaled:
+				w.Division = &division
+			default:
+				return fmt.Errorf("WOD '%s' has invalid division '%s' (must be %s or %s)", wod.Name, *w.Division, domain.DivisionRx, domain.DivisionScaled)
 			}
 		}
 
-		// Rounds+Reps WODs
-		if scoreType == "Rounds+Reps" {
-			// Must have rounds, must NOT have time_seconds/weight
-			if w.Rounds == nil {
-				return fmt.Errorf("WOD '%s' has score_type '%s' but rounds is missing", wod.Name, scoreType)
-			}
-			if w.TimeSeconds != nil || w.Weight != nil {
-				return fmt.Errorf("WOD '%s' has score_type '%s' but contains invalid fields (time_seconds/weight)", wod.Name, scoreType)
-			}
+		// Validate against the WOD's registered score type (WODs without one accept any result)
+		w.WODScoreType = wod.ScoreType
+		def := domain.LookupScoreType(wod.ScoreType)
+		if def == nil {
+			continue
 		}
-
-		// Max Weight WODs
-		if scoreType == "Max Weight" {
-			// Must have weight, must NOT have time_seconds/rounds/reps
-			if w.Weight == nil {
-				return fmt.Errorf("WOD '%s' has score_type '%s' but weight is missing", wod.Name, scoreType)
-			}
-			if w.TimeSeconds != nil || w.Rounds != nil || w.Reps != nil {
-				return fmt.Errorf("WOD '%s' has score_type '%s' but contains invalid fields (time_seconds/rounds/reps)", wod.Name, scoreType)
-			}
+		if err := def.Validate(w); err != nil {
+			return fmt.Errorf("WOD '%s' has score_type '%s' but %v", wod.Name, wod.ScoreType, err)
+		}
+		if w.ScoreType == nil {
+			w.ScoreType = &def.Name
+		}
+		if w.ScoreValue == nil || *w.ScoreValue == "" {
+			scoreValue := def.Format(w)
+			w.ScoreValue = &scoreValue
 		}
 	}
 
 	return nil
 }
+
+// validateSessionWellness checks each reported value is on its scale
+func validateSessionWellness(w domain.SessionWellness) error {
+	inRange := func(v *int, lo, hi int) bool {
+		return v == nil || (*v >= lo && *v <= hi)
+	}
+	if !inRange(w.SessionRPE, 1, 10) || !inRange(w.Readiness, 1, 10) ||
+		!inRange(w.SleepQuality, 1, 5) || !inRange(w.Soreness, 1, 5) {
+		return ErrInvalidSessionWellness
+	}
+	if w.SleepHours != nil && (*w.SleepHours < 0 || *w.SleepHours > 24) {
+		return ErrInvalidSessionWellness
+	}
+	return nil
+}
@@ -113,6 +113,7 @@ func TestUserWorkoutService_LogWorkout(t *testing.T) {
 				tt.notes,
 				tt.totalTime,
 				tt.workoutType,
+				nil,
 			)
 
 			if tt.expectedError != nil {
@@ -143,6 +144,32 @@ func TestUserWorkoutService_LogWorkout(t *testing.T) {
 	}
 }
 
+func TestUserWorkoutService_LogIndexesSavedWorkoutsOnly(t *testing.T) {
+	userWorkoutRepo := newMockUserWorkoutRepo()
+	searchRepo := &mockSearchRepo{}
+	service := NewUserWorkoutService(userWorkoutRepo, newMockWorkoutRepo(), &mockWorkoutMovementRepo{},
+		&mockUserWorkoutMovementRepo{}, &mockUserWorkoutWODRepo{}, newMockWODRepo())
+	service.SetSearchService(NewSearchService(searchRepo))
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	// A result for an unknown WOD fails after the workout row was written; the row is removed and never indexed
+	wods := []*domain.UserWorkoutWOD{{WODID: 999, TimeSeconds: intPtr(300)}}
+	if _, err := service.LogWorkoutWithPerformance(1, nil, stringPtr("Broken"), day, nil, nil, nil, nil, nil, wods); err == nil {
+		t.Fatal("expected the log to fail for an unknown WOD")
+	}
+	if len(userWorkoutRepo.userWorkouts) != 0 || len(searchRepo.refreshed) != 0 {
+		t.Errorf("expected no workout and no search document, got %d workouts and refreshes %v", len(userWorkoutRepo.userWorkouts), searchRepo.refreshed)
+	}
+
+	logged, err := service.LogScheduledWorkout(1, nil, stringPtr("Gym WOD"), day, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("LogScheduledWorkout() error = %v", err)
+	}
+	if len(searchRepo.refreshed) != 1 || searchRepo.refreshed[0] != logged.ID {
+		t.Errorf("expected the scheduled log to be indexed, got refreshes %v", searchRepo.refreshed)
+	}
+}
+
 func TestUserWorkoutService_GetLoggedWorkout(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -310,6 +337,7 @@ func TestUserWorkoutService_UpdateLoggedWorkout(t *testing.T) {
 				tt.notes,
 				tt.totalTime,
 				tt.workoutType,
+				nil,
 			)
 
 			if tt.expectedError != nil {
@@ -495,3 +523,105 @@ func TestUserWorkoutService_GetWorkoutStatsForMonth(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSessionWellness(t *testing.T) {
+	sleep := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name     string
+		wellness domain.SessionWellness
+		valid    bool
+	}{
+		{"empty", domain.SessionWellness{}, true},
+		{"all in range", domain.SessionWellness{SessionRPE: intPtr(7), SleepHours: sleep(7.5), SleepQuality: intPtr(4), Soreness: intPtr(2), Readiness: intPtr(8)}, true},
+		{"rpe above 10", domain.SessionWellness{SessionRPE: intPtr(11)}, false},
+		{"soreness zero", domain.SessionWellness{Soreness: intPtr(0)}, false},
+		{"sleep over a day", domain.SessionWellness{SleepHours: sleep(25)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSessionWellness(tt.wellness)
+			if tt.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tt.valid && err != ErrInvalidSessionWellness {
+				t.Errorf("expected ErrInvalidSessionWellness, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWODScoreTypes(t *testing.T) {
+	timed := domain.LookupScoreType(domain.ScoreTypeTime)
+	finished := &domain.UserWorkoutWOD{TimeSeconds: intPtr(1150)}
+	capped := &domain.UserWorkoutWOD{Reps: intPtr(180), WODScoreDetails: domain.WODScoreDetails{TimeCapped: true}}
+	moreReps := &domain.UserWorkoutWOD{Reps: intPtr(195), WODScoreDetails: domain.WODScoreDetails{TimeCapped: true}}
+
+	if timed.Compare(finished, capped) <= 0 || timed.Compare(capped, finished) >= 0 {
+		t.Error("expected a finished time to beat any capped result")
+	}
+	if timed.Compare(moreReps, capped) <= 0 {
+		t.Error("expected more reps at the cap to rank higher")
+	}
+	if timed.Compare(&domain.UserWorkoutWOD{TimeSeconds: intPtr(1100)}, finished) <= 0 {
+		t.Error("expected the faster time to rank higher")
+	}
+	if got := timed.Format(capped); got != "CAP+180" {
+		t.Errorf("Format(capped) = %s, want CAP+180", got)
+	}
+	if err := timed.Validate(capped); err != nil {
+		t.Errorf("expected a capped result with reps to be valid, got %v", err)
+	}
+	if err := timed.Validate(&domain.UserWorkoutWOD{TimeSeconds: intPtr(300), Reps: intPtr(10)}); err == nil {
+		t.Error("expected reps without a time cap to be invalid for a time WOD")
+	}
+
+	rr := domain.LookupScoreType(domain.ScoreTypeRoundsReps)
+	if err := rr.Validate(&domain.UserWorkoutWOD{Rounds: intPtr(5), WODScoreDetails: domain.WODScoreDetails{TimeCapped: true}}); err == nil {
+		t.Error("expected rounds+reps to reject a time cap")
+	}
+
+	calories := domain.LookupScoreType(domain.ScoreTypeCalories)
+	if err := calories.Validate(&domain.UserWorkoutWOD{Reps: intPtr(10)}); err == nil {
+		t.Error("expected a calories WOD without calories to be invalid")
+	}
+	if err := calories.Validate(&domain.UserWorkoutWOD{WODScoreDetails: domain.WODScoreDetails{Calories: intPtr(85), TieBreakSeconds: intPtr(95)}}); err != nil {
+		t.Errorf("expected calories with a tie-break to be valid, got %v", err)
+	}
+	if err := domain.LookupScoreType(domain.ScoreTypeMaxWeight).Validate(&domain.UserWorkoutWOD{Weight: new(float64), WODScoreDetails: domain.WODScoreDetails{TieBreakSeconds: intPtr(30)}}); err == nil {
+		t.Error("expected max weight to reject a tie-break")
+	}
+
+	parses := []struct {
+		scoreType string
+		input     string
+		want      string
+	}{
+		{domain.ScoreTypeTime, "1:02:03", "1:02:03"},
+		{domain.ScoreTypeTime, "cap 42", "CAP+42"},
+		{domain.ScoreTypeRoundsReps, "12 + 7", "12+7"},
+		{domain.ScoreTypeMaxWeight, "225 lbs", "225 lb"},
+		{domain.ScoreTypeReps, "150 reps", "150 reps"},
+		{domain.ScoreTypeCalories, "85 Cal", "85 cal"},
+		{domain.ScoreTypeDistance, "5 km", "5000 m"},
+		{domain.ScoreTypePoints, "42.5", "42.5 pts"},
+	}
+	for _, tt := range parses {
+		def := domain.LookupScoreType(tt.scoreType)
+		result := &domain.UserWorkoutWOD{}
+		if err := def.Parse(tt.input, result); err != nil {
+			t.Errorf("Parse(%s, %q) unexpected error: %v", tt.scoreType, tt.input, err)
+			continue
+		}
+		if got := def.Format(result); got != tt.want {
+			t.Errorf("Format(Parse(%s, %q)) = %s, want %s", tt.scoreType, tt.input, got, tt.want)
+		}
+	}
+	if err := domain.LookupScoreType(domain.ScoreTypeTime).Parse("12:75", &domain.UserWorkoutWOD{}); err == nil {
+		t.Error("expected 12:75 to be rejected")
+	}
+
+	// Results on WODs without a registered score type are still ranked by their fields
+	if def := wodScoreTypeDefinition(&domain.UserWorkoutWOD{Rounds: intPtr(3)}); def == nil || def.Name != domain.ScoreTypeRoundsReps {
+		t.Errorf("expected rounds to infer Rounds+Reps, got %+v", def)
+	}
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+// wodStructureReviewThreshold is the parse confidence below which a structure is flagged for admin review
+const wodStructureReviewThreshold = 0.9
+
+var (
+	wodRepSchemePattern = regexp.MustCompile(`(?i)\b(\d+(?:-\d+)+)\s+reps\b`)
+	wodRoundsPattern    = regexp.MustCompile(`(?i)\b(\d+)\s+(?:rounds?|sets)\b`)
+	wodTimeDomain       = regexp.MustCompile(`(?i)\b(\d+)[- ]?min(?:ute)?s?\s+(?:amrap|emom)\b|\b(?:amrap|emom)\s+(?:in\s+)?(\d+)\s*min`)
+	wodTimeCapPattern   = regexp.MustCompile(`(?i)\b(\d+)[- ]?min(?:ute)?s?\s+(?:time\s+)?cap\b|\b(?:time\s+)?cap(?:ped)?(?:\s+at|\s+of|:)?\s+(\d+)\s*min`)
+	wodFormatPattern    = regexp.MustCompile(`(?i)\b(for time|amrap|emom|for max reps|for reps|for load|rounds?|sets)\b`)
+	wodFormatPhrase     = regexp.MustCompile(`(?i)\s*\bfor\s+(?:time|load|max reps|reps)\b`)
+	wodNestedRounds     = regexp.MustCompile(`(?i)^(\d+)\s+rounds?(\s+of)?:?$`)
+	wodRestPattern      = regexp.MustCompile(`(?i)^(\d+\s*(?:min|sec)\w*\s+)?rest\b|\brest\s+\d+|\brest$`)
+	wodParenthetical    = regexp.MustCompile(`\(([^)]*)\)`)
+	wodLoadPattern      = regexp.MustCompile(`(?i)^(?:approximately\s+)?(\d+(?:\.\d+)?)\s*(?:/\s*(\d+(?:\.\d+)?))?\s*(lbs?|kg)\b\s*(.*)$`)
+	wodIntervalPrefix   = regexp.MustCompile(`(?i)^(\d+)\s*(min|sec)\w*\s+`)
+	wodMaxPrefix        = regexp.MustCompile(`(?i)^max(?:\s+reps?)?\s+`)
+	wodCaloriePrefix    = regexp.MustCompile(`(?i)^cal(?:s|ories)?\s+`)
+	wodQuantityPrefix   = regexp.MustCompile(`(?i)^(\d+(?:\.\d+)?)\s*(m|km|mi|miles?|ft|cals?|calories)?\s+(.+)$`)
+)
+
+// wodDistanceUnits converts description distance units to meters
+var wodDistanceUnits = map[string]float64{
+	"m":     1,
+	"km":    1000,
+	"mi":    1609.344,
+	"mile":  1609.344,
+	"miles": 1609.344,
+	"ft":    0.3048,
+}
+
+// WODDescriptionParser turns free-form WOD descriptions into structured definitions
+// Movement names are matched against the known movements, ignoring case, hyphens and plurals
+type WODDescriptionParser struct {
+	movements map[string]*domain.Movement
+}
+
+// NewWODDescriptionParser creates a parser that matches against the given movements
+// Standard movements win when a custom movement has the same normalized name
+func NewWODDescriptionParser(movements []*domain.Movement) *WODDescriptionParser {
+	p := &WODDescriptionParser{movements: make(map[string]*domain.Movement)}
+	for _, m := range movements {
+		key := normalizeMovementName(m.Name)
+		if existing, ok := p.movements[key]; ok && existing.IsStandard {
+			continue
+		}
+		p.movements[key] = m
+	}
+	return p
+}
+
+// Parse builds a structure from a WOD description
+// The first non-bullet line is read as the format (rounds, rep scheme, time domain); bullets and later lines as movements
+// Confidence is the share of movement lines matched to a known movement, reduced for an unrecognized format or nested rounds
+func (p *WODDescriptionParser) Parse(description string) *domain.WODStructure {
+	structure := &domain.WODStructure{
+		Source:     domain.WODStructureSourceParsed,
+		Components: []*domain.WODComponent{},
+	}
+
+	var notes []string
+	var lineScores []float64
+	headerSeen, recognized, nested := false, false, false
+
+	addComponent := func(text string) {
+		if m := wodNestedRounds.FindStringSubmatch(text); m != nil {
+			nested = true
+			structure.Warnings = append(structure.Warnings, fmt.Sprintf("nested rounds (%q) are not modeled", text))
+			return
+		}
+		if wodRestPattern.MatchString(text) {
+			notes = append(notes, text)
+			return
+		}
+		component, score, warning := p.parseComponent(text)
+		if warning != "" && !containsString(structure.Warnings, warning) {
+			structure.Warnings = append(structure.Warnings, warning)
+		}
+		component.OrderIndex = len(structure.Components)
+		structure.Components = append(structure.Components, component)
+		lineScores = append(lineScores, score)
+	}
+
+	for _, raw := range strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		if item, ok := bulletText(line); ok {
+			if raw != strings.TrimLeft(raw, " \t") {
+				nested = true
+			}
+			addComponent(item)
+			continue
+		}
+
+		// Italic lines are instructions (e.g., "*Rest 3 min between rounds*")
+		if (strings.HasPrefix(line, "*") && !strings.HasPrefix(line, "**")) || strings.HasPrefix(line, "_") {
+			notes = append(notes, strings.Trim(line, "*_ "))
+			continue
+		}
+
+		text := strings.TrimSpace(strings.Trim(line, "*_ "))
+		if !headerSeen && len(structure.Components) == 0 {
+			headerSeen = true
+			header, inline := text, ""
+			if i := strings.Index(text, ":"); i >= 0 {
+				header, inline = text[:i], strings.TrimSpace(text[i+1:])
+			}
+			// Single-movement WODs name the movement in the format line: "30 Clean and Jerks for time (135/95 lb)"
+			if movement := strings.TrimSpace(wodFormatPhrase.ReplaceAllString(header, "")); inline == "" && isSingleMovementHeader(header, movement) {
+				recognized = true
+				addComponent(movement)
+				continue
+			}
+			recognized = p.parseHeader(structure, header, &notes)
+			if !recognized {
+				structure.Warnings = append(structure.Warnings, fmt.Sprintf("unrecognized format %q", header))
+			}
+			// Plain-text descriptions list movements inline: "21-15-9 reps for time: Thrusters (95/65 lb), Pull-ups"
+			for _, item := range splitOutsideParens(inline, ',') {
+				if item = strings.TrimSpace(item); item != "" {
+					addComponent(item)
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "**") {
+			nested = true
+			structure.Warnings = append(structure.Warnings, fmt.Sprintf("additional section %q is not modeled", text))
+			continue
+		}
+		addComponent(text)
+	}
+
+	if !headerSeen {
+		structure.Warnings = append(structure.Warnings, "no format line found")
+	}
+	if len(structure.Components) == 0 {
+		structure.Warnings = append(structure.Warnings, "no movements found")
+	}
+
+	confidence := 0.0
+	for _, score := range lineScores {
+		confidence += score
+	}
+	if len(lineScores) > 0 {
+		confidence /= float64(len(lineScores))
+	}
+	if !recognized {
+		confidence *= 0.7
+	}
+	if nested {
+		confidence *= 0.8
+	}
+	structure.Confidence = math.Round(confidence*100) / 100
+	structure.NeedsReview = structure.Confidence < wodStructureReviewThreshold
+	structure.Notes = strings.Join(notes, "; ")
+
+	return structure
+}
+
+// parseHeader reads rounds, rep scheme and time domain from the format line; reports whether a format was recognized
+func (p *WODDescriptionParser) parseHeader(structure *domain.WODStructure, header string, notes *[]string) bool {
+	for _, m := range wodParenthetical.FindAllStringSubmatch(header, -1) {
+		*notes = append(*notes, strings.TrimSpace(m[1]))
+	}
+	header = wodParenthetical.ReplaceAllString(header, "")
+
+	if m := wodRepSchemePattern.FindStringSubmatch(header); m != nil {
+		structure.RepScheme = domain.ParseRepScheme(m[1])
+	}
+	if m := wodRoundsPattern.FindStringSubmatch(header); m != nil {
+		if rounds, err := strconv.Atoi(m[1]); err == nil && rounds > 0 {
+			structure.Rounds = &rounds
+		}
+	}
+	if seconds := matchMinutes(wodTimeDomain, header); seconds != nil {
+		structure.TimeCapSeconds = seconds
+	}
+	if seconds := matchMinutes(wodTimeCapPattern, header); seconds != nil {
+		structure.TimeCapSeconds = seconds
+	}
+
+	return structure.RepScheme != nil || structure.Rounds != nil || structure.TimeCapSeconds != nil ||
+		wodFormatPattern.MatchString(header)
+}
+
+// isSingleMovementHeader reports whether a format line, without its format phrase, is itself a movement line
+func isSingleMovementHeader(header, movement string) bool {
+	return movement != header && wodQuantityPrefix.MatchString(movement) &&
+		!wodRoundsPattern.MatchString(header) && !wodRepSchemePattern.MatchString(header) && !wodTimeDomain.MatchString(header)
+}
+
+// parseComponent reads one movement line such as "21 Kettlebell Swings (53/35 lb)" or "400m Run"
+// Returns the component, its match score (1 exact, 0.5 partial, 0 unmatched) and a warning for the reviewer
+func (p *WODDescriptionParser) parseComponent(text string) (*domain.WODComponent, float64, string) {
+	component := &domain.WODComponent{}
+	var notes []string
+
+	for _, m := range wodParenthetical.FindAllStringSubmatch(text, -1) {
+		inner := strings.TrimSpace(m[1])
+		load := wodLoadPattern.FindStringSubmatch(inner)
+		if load == nil || component.WeightMale != nil {
+			notes = append(notes, inner)
+			continue
+		}
+		male, _ := strconv.ParseFloat(load[1], 64)
+		component.WeightMale = &male
+		component.WeightFemale = &male
+		if load[2] != "" {
+			female, _ := strconv.ParseFloat(load[2], 64)
+			component.WeightFemale = &female
+		}
+		component.WeightUnit = strings.TrimSuffix(strings.ToLower(load[3]), "s")
+		if rest := strings.TrimSpace(strings.TrimLeft(load[4], ",; ")); rest != "" {
+			notes = append(notes, rest)
+		}
+	}
+	rest := strings.TrimSpace(wodParenthetical.ReplaceAllString(text, ""))
+
+	if m := wodIntervalPrefix.FindStringSubmatch(rest); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if strings.EqualFold(m[2], "min") {
+			n *= 60
+		}
+		component.DurationSeconds = &n
+		rest = rest[len(m[0]):]
+	}
+	if m := wodMaxPrefix.FindString(rest); m != "" {
+		notes = append(notes, "max effort")
+		rest = rest[len(m):]
+	}
+	if m := wodCaloriePrefix.FindString(rest); m != "" {
+		notes = append(notes, "calories")
+		rest = rest[len(m):]
+	}
+	if m := wodQuantityPrefix.FindStringSubmatch(rest); m != nil {
+		unit := strings.ToLower(m[2])
+		switch {
+		case wodDistanceUnits[unit] > 0:
+			value, _ := strconv.ParseFloat(m[1], 64)
+			meters := math.Round(value*wodDistanceUnits[unit]*100) / 100
+			component.Distance = &meters
+			rest = m[3]
+		case strings.HasPrefix(unit, "cal"):
+			if calories, err := strconv.Atoi(m[1]); err == nil {
+				component.Calories = &calories
+				rest = m[3]
+			}
+		default:
+			if reps, err := strconv.Atoi(m[1]); err == nil {
+				component.Reps = &reps
+				rest = m[3]
+			}
+		}
+	}
+
+	component.MovementName = strings.TrimSpace(rest)
+	component.Notes = strings.Join(notes, ", ")
+
+	movement, exact := p.matchMovement(component.MovementName)
+	switch {
+	case movement == nil:
+		return component, 0, fmt.Sprintf("movement %q not recognized", component.MovementName)
+	case !exact:
+		component.MovementID = &movement.ID
+		return component, 0.5, fmt.Sprintf("%q matched to %s by its last words", component.MovementName, movement.Name)
+	default:
+		component.MovementID = &movement.ID
+		return component, 1, ""
+	}
+}
+
+// matchMovement finds a movement by normalized name, then by the longest trailing run of words
+// (e.g., "Bodyweight Bench Press" -> Bench Press); the second result reports an exact match
+func (p *WODDescriptionParser) matchMovement(name string) (*domain.Movement, bool) {
+	key := normalizeMovementName(name)
+	if key == "" {
+		return nil, false
+	}
+	if m, ok := p.movements[key]; ok {
+		return m, true
+	}
+
+	words := strings.Fields(key)
+	for i := 1; i < len(words); i++ {
+		if m, ok := p.movements[strings.Join(words[i:], " ")]; ok {
+			return m, false
+		}
+	}
+	return nil, false
+}
+
+// normalizeMovementName lowercases a name, treats hyphens as spaces and "&" as "and", and singularizes the last word
+func normalizeMovementName(name string) string {
+	name = strings.ToLower(strings.NewReplacer("-", " ", "_", " ", "&", " and ").Replace(name))
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ""
+	}
+	words[len(words)-1] = singularize(words[len(words)-1])
+	return strings.Join(words, " ")
+}
+
+func singularize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// bulletText returns the text of a Markdown list item ("- ", "* ", "+ " or "1. ")
+func bulletText(line string) (string, bool) {
+	for _, prefix := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(line[len(prefix):]), true
+		}
+	}
+	if i := strings.Index(line, ". "); i > 0 {
+		if _, err := strconv.Atoi(line[:i]); err == nil {
+			return strings.TrimSpace(line[i+2:]), true
+		}
+	}
+	return "", false
+}
+
+// splitOutsideParens splits s on sep, ignoring separators inside parentheses
+func splitOutsideParens(s string, sep rune) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// matchMinutes returns the minutes captured by the first non-empty group, in seconds
+func matchMinutes(pattern *regexp.Regexp, s string) *int {
+	m := pattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil
+	}
+	for _, group := range m[1:] {
+		if minutes, err := strconv.Atoi(group); err == nil && minutes > 0 {
+			seconds := minutes * 60
+			return &seconds
+		}
+	}
+	return nil
+}
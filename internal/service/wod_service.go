@@ -24,6 +24,11 @@ var (
 type WODService struct {
 	wodRepo              domain.WODRepository
 	dataChangeLogService *DataChangeLogService
+	structureService     *WODStructureService
+	searchService        *SearchService
+	enumerationService   *EnumerationService
+	versionService       *WODVersionService
+	attachmentService    *AttachmentService
 }
 
 // NewWODService creates a new WOD service
@@ -34,12 +39,53 @@ func NewWODService(wodRepo domain.WODRepository, dataChangeLogService *DataChang
 	}
 }
 
+// SetStructureService wires structure parsing so WOD descriptions are parsed when they change
+func (s *WODService) SetStructureService(structureService *WODStructureService) {
+	s.structureService = structureService
+}
+
+// SetSearchService keeps the search index in step with WOD edits
+func (s *WODService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
+// SetEnumerationService validates source, type and regime against the admin-managed values
+// instead of domain.DefaultEnumerations
+func (s *WODService) SetEnumerationService(enumerationService *EnumerationService) {
+	s.enumerationService = enumerationService
+}
+
+// SetVersionService records a version whenever an edit changes how a WOD is performed or scored
+func (s *WODService) SetVersionService(versionService *WODVersionService) {
+	s.versionService = versionService
+}
+
+// SetAttachmentService removes a WOD's attachments when the WOD is deleted
+func (s *WODService) SetAttachmentService(attachmentService *AttachmentService) {
+	s.attachmentService = attachmentService
+}
+
 // Create creates a new custom WOD with validation
 func (s *WODService) Create(wod *domain.WOD, userID int64) error {
+	return s.createCustom(wod, userID, s.wodRepo.Create)
+}
+
+// CreateInGymLibrary creates a new custom WOD and adds it to a gym library in one transaction
+func (s *WODService) CreateInGymLibrary(wod *domain.WOD, userID, orgID int64) error {
+	return s.createCustom(wod, userID, func(wod *domain.WOD) error {
+		return s.wodRepo.CreateInLibrary(wod, orgID, userID)
+	})
+}
+
+// createCustom validates a custom WOD owned by userID and saves it with create
+func (s *WODService) createCustom(wod *domain.WOD, userID int64, create func(*domain.WOD) error) error {
 	// Validate required fields
 	if err := s.validateWOD(wod); err != nil {
 		return err
 	}
+	if err := s.validateEnumerations(wod, nil); err != nil {
+		return err
+	}
 
 	// Check for duplicate name
 	existing, err := s.wodRepo.GetByName(wod.Name)
@@ -58,11 +104,53 @@ func (s *WODService) Create(wod *domain.WOD, userID int64) error {
 	wod.UpdatedAt = now
 
 	// Create WOD
-	err = s.wodRepo.Create(wod)
+	err = create(wod)
+	if err != nil {
+		return fmt.Errorf("failed to create wod: %w", err)
+	}
+
+	s.refreshStructure(wod)
+	s.refreshSearch(wod.ID)
+
+	return nil
+}
+
+// CreateStandard creates a standard WOD (admin only, e.g. from the seed library)
+func (s *WODService) CreateStandard(wod *domain.WOD, userID int64, userEmail string) error {
+	if err := s.validateWOD(wod); err != nil {
+		return err
+	}
+	if err := s.validateEnumerations(wod, nil); err != nil {
+		return err
+	}
+
+	existing, err := s.wodRepo.GetByName(wod.Name)
 	if err != nil {
+		return fmt.Errorf("failed to check for duplicate WOD name: %w", err)
+	}
+	if existing != nil {
+		return ErrWODDuplicateName
+	}
+
+	wod.IsStandard = true
+	wod.CreatedBy = nil
+	now := time.Now()
+	wod.CreatedAt = now
+	wod.UpdatedAt = now
+
+	if err := s.wodRepo.Create(wod); err != nil {
 		return fmt.Errorf("failed to create wod: %w", err)
 	}
 
+	s.refreshStructure(wod)
+	s.refreshSearch(wod.ID)
+
+	if s.dataChangeLogService != nil {
+		if logErr := s.dataChangeLogService.LogCreate(domain.EntityTypeWOD, wod.ID, wod.Name, userID, userEmail, wod, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log WOD create: %v\n", logErr)
+		}
+	}
+
 	return nil
 }
 
@@ -168,6 +256,9 @@ func (s *WODService) Update(wod *domain.WOD, userID int64, userEmail string) err
 	if existing == nil {
 		return ErrWODNotFound
 	}
+	if err := s.validateEnumerations(wod, existing); err != nil {
+		return err
+	}
 
 	// Check if it's a standard WOD
 	if existing.IsStandard {
@@ -199,8 +290,7 @@ func (s *WODService) Update(wod *domain.WOD, userID int64, userEmail string) err
 	wod.CreatedAt = existing.CreatedAt
 
 	// Update WOD
-	err = s.wodRepo.Update(wod)
-	if err != nil {
+	if err := s.saveEdit(existing, wod, userID); err != nil {
 		return fmt.Errorf("failed to update wod: %w", err)
 	}
 
@@ -212,6 +302,11 @@ func (s *WODService) Update(wod *domain.WOD, userID int64, userEmail string) err
 		}
 	}
 
+	if existing.Description != wod.Description {
+		s.refreshStructure(wod)
+	}
+	s.refreshSearch(wod.ID)
+
 	return nil
 }
 
@@ -230,6 +325,9 @@ func (s *WODService) UpdateAsAdmin(wod *domain.WOD, userID int64, userEmail stri
 	if existing == nil {
 		return ErrWODNotFound
 	}
+	if err := s.validateEnumerations(wod, existing); err != nil {
+		return err
+	}
 
 	// Check for duplicate name (if name changed)
 	if existing.Name != wod.Name {
@@ -250,13 +348,7 @@ func (s *WODService) UpdateAsAdmin(wod *domain.WOD, userID int64, userEmail stri
 	wod.CreatedBy = existing.CreatedBy
 	wod.CreatedAt = existing.CreatedAt
 
-	// Use appropriate update method based on whether WOD is standard
-	if existing.IsStandard {
-		err = s.wodRepo.UpdateStandard(wod)
-	} else {
-		err = s.wodRepo.Update(wod)
-	}
-	if err != nil {
+	if err := s.saveEdit(existing, wod, userID); err != nil {
 		return fmt.Errorf("failed to update wod: %w", err)
 	}
 
@@ -268,6 +360,11 @@ func (s *WODService) UpdateAsAdmin(wod *domain.WOD, userID int64, userEmail stri
 		}
 	}
 
+	if existing.Description != wod.Description {
+		s.refreshStructure(wod)
+	}
+	s.refreshSearch(wod.ID)
+
 	return nil
 }
 
@@ -298,6 +395,23 @@ func (s *WODService) Delete(id int64, userID int64, userEmail string) error {
 		return fmt.Errorf("failed to delete wod: %w", err)
 	}
 
+	if s.structureService != nil {
+		if err := s.structureService.Delete(id); err != nil {
+			fmt.Printf("Warning: failed to delete WOD structure: %v\n", err)
+		}
+	}
+	if s.versionService != nil {
+		if err := s.versionService.DeleteForWOD(id); err != nil {
+			fmt.Printf("Warning: failed to delete WOD versions: %v\n", err)
+		}
+	}
+	if s.attachmentService != nil {
+		if err := s.attachmentService.DeleteForEntity(domain.AttachmentEntityWOD, id); err != nil {
+			fmt.Printf("Warning: failed to delete WOD attachments: %v\n", err)
+		}
+	}
+	s.refreshSearch(id)
+
 	// Log the deletion (after successful delete)
 	if s.dataChangeLogService != nil {
 		if logErr := s.dataChangeLogService.LogWODDelete(id, wod.Name, userID, userEmail, wod, nil, nil); logErr != nil {
@@ -343,6 +457,7 @@ func (s *WODService) Count(isStandard *bool) (int64, error) {
 }
 
 // validateWOD validates WOD required fields and business rules
+// Source, type and regime values are checked against the managed values by validateEnumerations
 func (s *WODService) validateWOD(wod *domain.WOD) error {
 	// Validate name
 	if strings.TrimSpace(wod.Name) == "" {
@@ -359,54 +474,9 @@ func (s *WODService) validateWOD(wod *domain.WOD) error {
 		return ErrWODTypeRequired
 	}
 
-	// Validate allowed values for enums
-	validSources := map[string]bool{
-		"CrossFit":      true,
-		"Other Coach":   true,
-		"Self-recorded": true,
-	}
-	if !validSources[wod.Source] {
-		return fmt.Errorf("invalid source: must be one of [CrossFit, Other Coach, Self-recorded]")
-	}
-
-	validTypes := map[string]bool{
-		"Benchmark":    true,
-		"Hero":         true,
-		"Girl":         true,
-		"Notables":     true,
-		"Games":        true,
-		"Endurance":    true,
-		"Self-created": true,
-	}
-	if !validTypes[wod.Type] {
-		return fmt.Errorf("invalid type: must be one of [Benchmark, Hero, Girl, Notables, Games, Endurance, Self-created]")
-	}
-
-	// Validate regime (optional but if provided must be valid)
-	if wod.Regime != "" {
-		validRegimes := map[string]bool{
-			"EMOM":          true,
-			"AMRAP":         true,
-			"Fastest Time":  true,
-			"Slowest Round": true,
-			"Get Stronger":  true,
-			"Skills":        true,
-		}
-		if !validRegimes[wod.Regime] {
-			return fmt.Errorf("invalid regime: must be one of [EMOM, AMRAP, Fastest Time, Slowest Round, Get Stronger, Skills]")
-		}
-	}
-
 	// Validate score type (optional but if provided must be valid)
-	if wod.ScoreType != "" {
-		validScoreTypes := map[string]bool{
-			"Time (HH:MM:SS)": true,
-			"Rounds+Reps":     true,
-			"Max Weight":      true,
-		}
-		if !validScoreTypes[wod.ScoreType] {
-			return fmt.Errorf("invalid score type: must be one of [Time (HH:MM:SS), Rounds+Reps, Max Weight]")
-		}
+	if wod.ScoreType != "" && domain.LookupScoreType(wod.ScoreType) == nil {
+		return fmt.Errorf("invalid score type: must be one of [%s]", strings.Join(domain.ScoreTypeNames(), ", "))
 	}
 
 	return nil
@@ -447,9 +517,10 @@ func (s *WODService) ListAllUserCreatedWithUserInfo(limit, offset int) ([]*domai
 }
 
 // ListAllUserCreatedWithUserInfoFiltered retrieves all user-created WODs with creator info and filters (admin only)
-func (s *WODService) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string) ([]*domain.WODWithCreator, int64, error) {
+// A positive orgID limits the list to items created by members of that gym
+func (s *WODService) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, scoreType, creator string, orgID int64) ([]*domain.WODWithCreator, int64, error) {
 	// Get the list with user info and filters
-	wods, count, err := s.wodRepo.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, scoreType, creator)
+	wods, count, err := s.wodRepo.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, scoreType, creator, orgID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list all user-created wods with filters: %w", err)
 	}
@@ -480,9 +551,69 @@ func (s *WODService) CopyToStandard(id int64, newName string) (*domain.WOD, erro
 		return nil, fmt.Errorf("failed to copy wod to standard: %w", err)
 	}
 
+	s.refreshStructure(wod)
+	s.refreshSearch(wod.ID)
+
 	return wod, nil
 }
 
+// refreshStructure re-parses a WOD's description; failures are logged and never fail the WOD change
+func (s *WODService) refreshStructure(wod *domain.WOD) {
+	refreshWODStructure(s.structureService, wod)
+}
+
+// saveEdit writes an edited WOD; when versioning is wired, a definition change is saved with its new
+// version in one transaction and either both are saved or the edit fails
+func (s *WODService) saveEdit(existing, wod *domain.WOD, userID int64) error {
+	if s.versionService != nil {
+		_, err := s.versionService.SaveEdit(existing, wod, userID)
+		return err
+	}
+	if wod.IsStandard {
+		return s.wodRepo.UpdateStandard(wod)
+	}
+	return s.wodRepo.Update(wod)
+}
+
+// refreshSearch re-indexes a WOD when search is wired
+func (s *WODService) refreshSearch(id int64) {
+	if s.searchService != nil {
+		s.searchService.Refresh(domain.SearchEntityWOD, id)
+	}
+}
+
+// validateEnumerations checks source, type and optional regime against the active values and
+// stores their canonical spelling; values unchanged from the existing WOD are kept even when deprecated
+func (s *WODService) validateEnumerations(wod *domain.WOD, existing *domain.WOD) error {
+	var previous domain.WOD
+	if existing != nil {
+		previous = *existing
+	}
+
+	for _, field := range []struct {
+		category string
+		name     string
+		value    *string
+		previous string
+	}{
+		{domain.EnumerationWODSource, "source", &wod.Source, previous.Source},
+		{domain.EnumerationWODType, "type", &wod.Type, previous.Type},
+		{domain.EnumerationWODRegime, "regime", &wod.Regime, previous.Regime},
+	} {
+		if *field.value == "" {
+			continue
+		}
+		allowed, err := enumerationValues(s.enumerationService, field.category)
+		if err != nil {
+			return fmt.Errorf("failed to load allowed WOD %s values: %w", field.name, err)
+		}
+		if *field.value, err = resolveEnumeration(allowed, field.name, *field.value, field.previous); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // paginateWODs applies limit and offset to a slice of WODs
 func (s *WODService) paginateWODs(wods []*domain.WOD, limit, offset int) []*domain.WOD {
 	// Set default limit if not provided
@@ -30,6 +30,20 @@ func TestWODService_CreateWOD(t *testing.T) {
 			setupMock:     func(m *mockWODRepo) {},
 			expectedError: nil,
 		},
+		{
+			name:   "custom WOD scored by calories",
+			userID: 1,
+			wod: &domain.WOD{
+				Name:        "Row Sprint",
+				Source:      "Self-recorded",
+				Type:        "Self-created",
+				Regime:      "AMRAP",
+				ScoreType:   "Calories",
+				Description: "Max calories in 2 minutes",
+			},
+			setupMock:     func(m *mockWODRepo) {},
+			expectedError: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrWODStructureNotFound = errors.New("wod structure not found")
+	ErrInvalidWODStructure  = errors.New("invalid wod structure")
+)
+
+// WODStructureBackfillResult reports the outcome of parsing stored WOD descriptions
+type WODStructureBackfillResult struct {
+	Parsed      int `json:"parsed"`
+	NeedsReview int `json:"needs_review"`
+	Skipped     int `json:"skipped"` // WODs without a description
+}
+
+// WODStructureService derives structured WOD definitions from descriptions and manages their review
+type WODStructureService struct {
+	structureRepo domain.WODStructureRepository
+	wodRepo       domain.WODRepository
+	movementRepo  domain.MovementRepository
+}
+
+// NewWODStructureService creates a new WOD structure service
+func NewWODStructureService(structureRepo domain.WODStructureRepository, wodRepo domain.WODRepository, movementRepo domain.MovementRepository) *WODStructureService {
+	return &WODStructureService{
+		structureRepo: structureRepo,
+		wodRepo:       wodRepo,
+		movementRepo:  movementRepo,
+	}
+}
+
+// GetStructure retrieves a WOD's structure
+func (s *WODStructureService) GetStructure(wodID int64) (*domain.WODStructure, error) {
+	wod, err := s.wodRepo.GetByID(wodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wod: %w", err)
+	}
+	if wod == nil {
+		return nil, ErrWODNotFound
+	}
+
+	structure, err := s.structureRepo.GetByWODID(wodID)
+	if err != nil {
+		return nil, err
+	}
+	if structure == nil {
+		return nil, ErrWODStructureNotFound
+	}
+	return structure, nil
+}
+
+// Parse builds a structure from a WOD's description without saving it
+func (s *WODStructureService) Parse(wod *domain.WOD) (*domain.WODStructure, error) {
+	parser, err := s.parser()
+	if err != nil {
+		return nil, err
+	}
+	structure := parser.Parse(wod.Description)
+	structure.WODID = wod.ID
+	structure.WODName = wod.Name
+	return structure, nil
+}
+
+// Refresh re-parses a WOD after its description was created or changed
+// Structures an admin has reviewed are kept, but flagged for review again since the description no longer matches
+func (s *WODStructureService) Refresh(wod *domain.WOD) error {
+	existing, err := s.structureRepo.GetByWODID(wod.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Source == domain.WODStructureSourceManual {
+		const changed = "description changed after review"
+		existing.NeedsReview = true
+		if !containsString(existing.Warnings, changed) {
+			existing.Warnings = append(existing.Warnings, changed)
+		}
+		return s.structureRepo.Save(existing)
+	}
+	if strings.TrimSpace(wod.Description) == "" {
+		if existing != nil {
+			return s.structureRepo.DeleteByWODID(wod.ID)
+		}
+		return nil
+	}
+
+	structure, err := s.Parse(wod)
+	if err != nil {
+		return err
+	}
+	return s.structureRepo.Save(structure)
+}
+
+// refreshWODStructure re-parses a created or changed WOD when structure parsing is wired
+// Failures are logged and never fail the change that triggered them
+func refreshWODStructure(structureService *WODStructureService, wod *domain.WOD) {
+	if structureService == nil {
+		return
+	}
+	if err := structureService.Refresh(wod); err != nil {
+		fmt.Printf("Warning: failed to parse WOD structure: %v\n", err)
+	}
+}
+
+// Reparse replaces a WOD's structure with a fresh parse of its description, discarding any manual review
+func (s *WODStructureService) Reparse(wodID int64) (*domain.WODStructure, error) {
+	wod, err := s.wodRepo.GetByID(wodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wod: %w", err)
+	}
+	if wod == nil {
+		return nil, ErrWODNotFound
+	}
+
+	structure, err := s.Parse(wod)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.structureRepo.Save(structure); err != nil {
+		return nil, err
+	}
+	return structure, nil
+}
+
+// Backfill parses WODs that have no structure yet; with all set, parsed structures are refreshed too
+// Manually reviewed structures are never replaced by a backfill
+func (s *WODStructureService) Backfill(all bool) (*WODStructureBackfillResult, error) {
+	ids, err := s.structureRepo.ListUnstructuredWODIDs()
+	if err != nil {
+		return nil, err
+	}
+	if all {
+		parsed, err := s.structureRepo.ListParsedWODIDs()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, parsed...)
+	}
+
+	result := &WODStructureBackfillResult{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	parser, err := s.parser()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		wod, err := s.wodRepo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get wod: %w", err)
+		}
+		if wod == nil || strings.TrimSpace(wod.Description) == "" {
+			result.Skipped++
+			continue
+		}
+
+		structure := parser.Parse(wod.Description)
+		structure.WODID = wod.ID
+		structure.WODName = wod.Name
+		if err := s.structureRepo.Save(structure); err != nil {
+			return nil, err
+		}
+		result.Parsed++
+		if structure.NeedsReview {
+			result.NeedsReview++
+		}
+	}
+	return result, nil
+}
+
+// ListForReview lists low-confidence structures awaiting an admin, with the total count
+func (s *WODStructureService) ListForReview(limit, offset int) ([]*domain.WODStructure, int, error) {
+	return s.structureRepo.ListForReview(limit, offset)
+}
+
+// SaveReviewed stores an admin's corrected structure for a WOD and clears its review flag
+func (s *WODStructureService) SaveReviewed(adminID, wodID int64, structure *domain.WODStructure) (*domain.WODStructure, error) {
+	wod, err := s.wodRepo.GetByID(wodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wod: %w", err)
+	}
+	if wod == nil {
+		return nil, ErrWODNotFound
+	}
+	if err := s.validateStructure(structure); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.structureRepo.GetByWODID(wodID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		structure.CreatedAt = existing.CreatedAt
+	}
+
+	structure.WODID = wodID
+	structure.WODName = wod.Name
+	structure.Source = domain.WODStructureSourceManual
+	structure.Confidence = 1
+	structure.NeedsReview = false
+	structure.Warnings = nil
+	structure.ReviewedBy = &adminID
+	if err := s.structureRepo.Save(structure); err != nil {
+		return nil, err
+	}
+	return structure, nil
+}
+
+// Delete removes a WOD's structure (used when the WOD itself is deleted)
+func (s *WODStructureService) Delete(wodID int64) error {
+	return s.structureRepo.DeleteByWODID(wodID)
+}
+
+// ListWODsByMovement lists the WODs containing a movement: standard WODs plus, for a signed-in user, their own
+func (s *WODStructureService) ListWODsByMovement(movementID int64, userID *int64, limit, offset int) ([]*domain.WOD, error) {
+	movement, err := s.movementRepo.GetByID(movementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movement: %w", err)
+	}
+	if movement == nil {
+		return nil, ErrMovementNotFound
+	}
+
+	wods, err := s.wodRepo.List(map[string]interface{}{"movement_id": movementID}, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wods: %w", err)
+	}
+
+	visible := []*domain.WOD{}
+	for _, wod := range wods {
+		if wod.IsStandard || (userID != nil && wod.CreatedBy != nil && *wod.CreatedBy == *userID) {
+			visible = append(visible, wod)
+		}
+	}
+	if offset >= len(visible) {
+		return []*domain.WOD{}, nil
+	}
+	visible = visible[offset:]
+	if limit > 0 && limit < len(visible) {
+		visible = visible[:limit]
+	}
+	return visible, nil
+}
+
+func (s *WODStructureService) parser() (*WODDescriptionParser, error) {
+	movements, err := s.movementRepo.ListAll(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list movements: %w", err)
+	}
+	return NewWODDescriptionParser(movements), nil
+}
+
+func (s *WODStructureService) validateStructure(structure *domain.WODStructure) error {
+	if structure.Rounds != nil && *structure.Rounds <= 0 {
+		return fmt.Errorf("%w: rounds must be positive", ErrInvalidWODStructure)
+	}
+	if structure.TimeCapSeconds != nil && *structure.TimeCapSeconds <= 0 {
+		return fmt.Errorf("%w: time cap must be positive", ErrInvalidWODStructure)
+	}
+	for _, reps := range structure.RepScheme {
+		if reps <= 0 {
+			return fmt.Errorf("%w: rep scheme values must be positive", ErrInvalidWODStructure)
+		}
+	}
+	if len(structure.Components) == 0 {
+		return fmt.Errorf("%w: at least one component is required", ErrInvalidWODStructure)
+	}
+
+	for i, c := range structure.Components {
+		c.MovementName = strings.TrimSpace(c.MovementName)
+		if c.MovementID != nil {
+			movement, err := s.movementRepo.GetByID(*c.MovementID)
+			if err != nil {
+				return fmt.Errorf("failed to get movement: %w", err)
+			}
+			if movement == nil {
+				return fmt.Errorf("%w: component %d references unknown movement %d", ErrInvalidWODStructure, i+1, *c.MovementID)
+			}
+			if c.MovementName == "" {
+				c.MovementName = movement.Name
+			}
+		}
+		if c.MovementName == "" {
+			return fmt.Errorf("%w: component %d needs a movement", ErrInvalidWODStructure, i+1)
+		}
+		if (c.Reps != nil && *c.Reps < 0) || (c.Calories != nil && *c.Calories < 0) || (c.Distance != nil && *c.Distance < 0) ||
+			(c.DurationSeconds != nil && *c.DurationSeconds < 0) || (c.WeightMale != nil && *c.WeightMale < 0) ||
+			(c.WeightFemale != nil && *c.WeightFemale < 0) {
+			return fmt.Errorf("%w: component %d has a negative value", ErrInvalidWODStructure, i+1)
+		}
+		if (c.WeightMale != nil || c.WeightFemale != nil) && c.WeightUnit != "lb" && c.WeightUnit != "kg" {
+			return fmt.Errorf("%w: component %d weight unit must be lb or kg", ErrInvalidWODStructure, i+1)
+		}
+	}
+	return nil
+}
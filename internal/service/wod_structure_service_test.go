@@ -0,0 +1,158 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+func testStructureMovements() []*domain.Movement {
+	names := []string{"Thruster", "Pull-up", "Run", "Kettlebell Swing", "Push-up", "Air Squat", "Bench Press", "Burpee", "Row", "Knees-to-Elbow"}
+	movements := make([]*domain.Movement, len(names))
+	for i, name := range names {
+		movements[i] = &domain.Movement{ID: int64(i + 1), Name: name, IsStandard: true}
+	}
+	return movements
+}
+
+func TestWODDescriptionParser_Parse(t *testing.T) {
+	parser := NewWODDescriptionParser(testStructureMovements())
+
+	t.Run("rep scheme with Rx loads", func(t *testing.T) {
+		s := parser.Parse("**21-15-9 reps for time:**\n- Thrusters (95/65 lb)\n- Pull-ups")
+		if domain.FormatRepScheme(s.RepScheme) != "21-15-9" {
+			t.Fatalf("rep scheme = %v, want 21-15-9", s.RepScheme)
+		}
+		if len(s.Components) != 2 || s.NeedsReview || s.Confidence != 1 {
+			t.Fatalf("got %d components, confidence %v, needs review %v", len(s.Components), s.Confidence, s.NeedsReview)
+		}
+		thrusters := s.Components[0]
+		if thrusters.MovementID == nil || *thrusters.MovementID != 1 {
+			t.Errorf("thrusters matched to %v, want movement 1", thrusters.MovementID)
+		}
+		if thrusters.WeightMale == nil || *thrusters.WeightMale != 95 || thrusters.WeightFemale == nil || *thrusters.WeightFemale != 65 || thrusters.WeightUnit != "lb" {
+			t.Errorf("unexpected thruster load: %+v", thrusters)
+		}
+
+		volume := s.MovementVolume()
+		if len(volume) != 2 || volume[0].Reps != 45 || volume[1].Reps != 45 {
+			t.Errorf("unexpected volume: %+v", volume)
+		}
+	})
+
+	t.Run("rounds, distances and rest", func(t *testing.T) {
+		s := parser.Parse("**3 rounds for time:**\n- 400m Run\n- 21 Kettlebell Swings (53/35 lb)\n- 12 Pull-ups\n\n*Rest 1 min between rounds*")
+		if s.Rounds == nil || *s.Rounds != 3 {
+			t.Fatalf("rounds = %v, want 3", s.Rounds)
+		}
+		if len(s.Components) != 3 || s.Components[0].Distance == nil || *s.Components[0].Distance != 400 {
+			t.Fatalf("unexpected components: %+v", s.Components)
+		}
+		if s.Notes != "Rest 1 min between rounds" {
+			t.Errorf("notes = %q", s.Notes)
+		}
+
+		volume := s.MovementVolume()
+		if volume[0].Distance != 1200 || volume[1].Reps != 63 || volume[2].Reps != 36 {
+			t.Errorf("unexpected volume: %+v %+v %+v", volume[0], volume[1], volume[2])
+		}
+	})
+
+	t.Run("inline AMRAP with time domain", func(t *testing.T) {
+		s := parser.Parse("10 min AMRAP: 10 burpees, 30 cal Row, 5 Knees-to-Elbows")
+		if s.TimeCapSeconds == nil || *s.TimeCapSeconds != 600 {
+			t.Fatalf("time cap = %v, want 600", s.TimeCapSeconds)
+		}
+		if len(s.Components) != 3 || s.NeedsReview {
+			t.Fatalf("got %d components, needs review %v, warnings %v", len(s.Components), s.NeedsReview, s.Warnings)
+		}
+		if s.Components[1].Calories == nil || *s.Components[1].Calories != 30 {
+			t.Errorf("row calories = %v, want 30", s.Components[1].Calories)
+		}
+	})
+
+	t.Run("unknown movements are flagged for review", func(t *testing.T) {
+		s := parser.Parse("**For time:**\n- 50 SDHP (75/55 lb)\n- 50 Bodyweight Bench Press\n- 50 Push-ups")
+		if !s.NeedsReview || s.Confidence != 0.5 {
+			t.Fatalf("confidence %v, needs review %v", s.Confidence, s.NeedsReview)
+		}
+		if s.Components[0].MovementID != nil {
+			t.Errorf("SDHP should not match a movement")
+		}
+		if s.Components[1].MovementID == nil || *s.Components[1].MovementID != 7 {
+			t.Errorf("bench press matched to %v, want movement 7", s.Components[1].MovementID)
+		}
+		if len(s.Warnings) != 2 {
+			t.Errorf("warnings = %v", s.Warnings)
+		}
+	})
+
+	t.Run("unrecognized format", func(t *testing.T) {
+		s := parser.Parse("Work up to something heavy")
+		if !s.NeedsReview || s.Confidence != 0 || len(s.Components) != 0 {
+			t.Fatalf("unexpected structure: %+v", s)
+		}
+	})
+}
+
+func TestWODStructureRepsPerRound(t *testing.T) {
+	cindy := &domain.WODStructure{Components: []*domain.WODComponent{
+		{MovementName: "Pull-ups", Reps: intPtr(5)},
+		{MovementName: "Push-ups", Reps: intPtr(10)},
+		{MovementName: "Air Squats", Reps: intPtr(15)},
+	}}
+	if got := cindy.RepsPerRound(); got == nil || *got != 30 {
+		t.Errorf("expected 30 reps per round, got %v", got)
+	}
+
+	scheme := &domain.WODStructure{RepScheme: []int{21, 15, 9}, Components: []*domain.WODComponent{
+		{MovementName: "Thrusters"},
+		{MovementName: "Pull-ups"},
+	}}
+	if got := scheme.RepsPerRound(); got == nil || *got != 90 {
+		t.Errorf("expected the rep scheme to supply 90 reps, got %v", got)
+	}
+
+	run := 400.0
+	withRun := &domain.WODStructure{Components: []*domain.WODComponent{
+		{MovementName: "Run", Distance: &run},
+		{MovementName: "Burpees", Reps: intPtr(10)},
+	}}
+	if got := withRun.RepsPerRound(); got != nil {
+		t.Errorf("expected no count with a distance component, got %d", *got)
+	}
+}
+
+func TestImportedWODsAreParsed(t *testing.T) {
+	db := openTestDB(t)
+	wodRepo := repository.NewWODRepository(db)
+	movementRepo := repository.NewMovementRepository(db)
+	structureRepo := repository.NewWODStructureRepository(db)
+
+	importService := NewImportService(wodRepo, movementRepo, nil, nil, nil, nil)
+	importService.SetStructureService(NewWODStructureService(structureRepo, wodRepo, movementRepo))
+
+	csvData := "name,source,type,regime,score_type,description,url,notes,is_standard,created_by_email\n" +
+		"Imported Triplet,CrossFit,Girl,AMRAP,Rounds+Reps,\"AMRAP 20 min: 5 Pull-ups, 10 Push-ups, 15 Air Squats\",,,true,\n"
+	result, err := importService.ConfirmWODImport(strings.NewReader(csvData), 1, true, true, false)
+	if err != nil {
+		t.Fatalf("ConfirmWODImport failed: %v", err)
+	}
+	if result.CreatedCount != 1 {
+		t.Fatalf("expected one WOD created, got %+v", result)
+	}
+
+	wod, err := wodRepo.GetByName("Imported Triplet")
+	if err != nil || wod == nil {
+		t.Fatalf("imported WOD not found: %v", err)
+	}
+	structure, err := structureRepo.GetByWODID(wod.ID)
+	if err != nil {
+		t.Fatalf("failed to get structure: %v", err)
+	}
+	if structure == nil || len(structure.Components) != 3 {
+		t.Fatalf("expected the imported description to be parsed into three components, got %+v", structure)
+	}
+}
@@ -0,0 +1,435 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+var (
+	ErrWODVersionNotFound = errors.New("wod version not found")
+	ErrInvalidWODVersion  = errors.New("invalid wod version comparability")
+	ErrInvalidWODVariant  = errors.New("invalid wod variant")
+	ErrWODVariantNotFound = errors.New("wod is not a variant")
+)
+
+// WODVariantRequest links a WOD to a parent as one of its variants
+type WODVariantRequest struct {
+	VariantWODID int64  `json:"variant_wod_id"`
+	Kind         string `json:"kind"`       // scaled, masters, team
+	Comparable   bool   `json:"comparable"` // Rank the variant's results with the parent's
+}
+
+// WODVariantFamily is a WOD's place in its variant family
+type WODVariantFamily struct {
+	ParentWODID int64                `json:"parent_wod_id"`
+	Variant     *domain.WODVariant   `json:"variant,omitempty"` // Set when the requested WOD is itself a variant
+	Variants    []*domain.WODVariant `json:"variants"`
+}
+
+// WODVersionService records WOD versions on edits, manages variant links and decides which results
+// leaderboards and PRs may compare
+type WODVersionService struct {
+	versionRepo          domain.WODVersionRepository
+	wodRepo              domain.WODRepository
+	dataChangeLogService *DataChangeLogService
+	userWorkoutService   *UserWorkoutService
+}
+
+// NewWODVersionService creates a new WOD version service
+func NewWODVersionService(versionRepo domain.WODVersionRepository, wodRepo domain.WODRepository, dataChangeLogService *DataChangeLogService) *WODVersionService {
+	return &WODVersionService{
+		versionRepo:          versionRepo,
+		wodRepo:              wodRepo,
+		dataChangeLogService: dataChangeLogService,
+	}
+}
+
+// SetUserWorkoutService recomputes the PRs of affected users when which results are comparable changes
+func (s *WODVersionService) SetUserWorkoutService(userWorkoutService *UserWorkoutService) {
+	s.userWorkoutService = userWorkoutService
+}
+
+// SaveEdit saves an edited WOD; an edit that changed its name, regime, score type or description is
+// saved with a new version in the same transaction and that version is returned (nil for metadata-only
+// edits). The first recorded edit of a WOD without versions also snapshots the definition before it as
+// version 1 and assigns it every earlier result. Renames stay comparable with the previous version,
+// other definition changes start a new comparable run (admins can change this with SetComparable).
+func (s *WODVersionService) SaveEdit(before, after *domain.WOD, userID int64) (*domain.WODVersion, error) {
+	if !definitionChanged(before, after) {
+		return nil, s.versionRepo.SaveEdit(after, nil)
+	}
+
+	versions, err := s.versionRepo.ListVersions(after.ID)
+	if err != nil {
+		return nil, err
+	}
+	var created []*domain.WODVersion
+	if len(versions) == 0 {
+		first := snapshotWOD(before, 1)
+		first.BaselineVersion = 1
+		first.CreatedBy = before.CreatedBy
+		first.CreatedAt = before.UpdatedAt
+		versions = append(versions, first)
+		created = append(created, first)
+	}
+
+	previous := versions[len(versions)-1]
+	version := snapshotWOD(after, previous.Version+1)
+	version.ComparableWithPrevious = comparableEdit(before, after)
+	version.BaselineVersion = version.Version
+	if version.ComparableWithPrevious {
+		version.BaselineVersion = previous.BaselineVersion
+	}
+	version.CreatedBy = &userID
+	if err := s.versionRepo.SaveEdit(after, append(created, version)); err != nil {
+		return nil, err
+	}
+
+	if !version.ComparableWithPrevious {
+		s.recomputePRs(after.ID)
+	}
+	return version, nil
+}
+
+// ListVersions lists a WOD's recorded versions, oldest first, and its current version number
+// A WOD that was never edited has no recorded versions and is on version 1.
+func (s *WODVersionService) ListVersions(wodID int64) ([]*domain.WODVersion, int, error) {
+	if _, err := s.getWOD(wodID); err != nil {
+		return nil, 0, err
+	}
+	versions, err := s.versionRepo.ListVersions(wodID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(versions) == 0 {
+		return versions, 1, nil
+	}
+	return versions, versions[len(versions)-1].Version, nil
+}
+
+// GetVersion returns one version of a WOD; version 1 of a WOD that was never edited is its current definition
+func (s *WODVersionService) GetVersion(wodID int64, number int) (*domain.WODVersion, error) {
+	wod, err := s.getWOD(wodID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.versionRepo.ListVersions(wodID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 && number == 1 {
+		version := snapshotWOD(wod, 1)
+		version.BaselineVersion = 1
+		version.CreatedBy = wod.CreatedBy
+		version.CreatedAt = wod.CreatedAt
+		return version, nil
+	}
+	for _, version := range versions {
+		if version.Version == number {
+			return version, nil
+		}
+	}
+	return nil, ErrWODVersionNotFound
+}
+
+// SetComparable marks whether scores on a version rank with the previous version's (admin only).
+// Baselines of later versions are recomputed and the PRs of affected users re-flagged.
+func (s *WODVersionService) SetComparable(wodID int64, number int, comparable bool, adminID int64, adminEmail string) (*domain.WODVersion, error) {
+	wod, err := s.getWOD(wodID)
+	if err != nil {
+		return nil, err
+	}
+	if number == 1 {
+		return nil, fmt.Errorf("%w: version 1 has no previous version", ErrInvalidWODVersion)
+	}
+	versions, err := s.versionRepo.ListVersions(wodID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i, version := range versions {
+		if version.Version == number {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, ErrWODVersionNotFound
+	}
+	if comparable && versions[index].ScoreType != versions[index-1].ScoreType {
+		return nil, fmt.Errorf("%w: scores of different score types cannot be compared", ErrInvalidWODVersion)
+	}
+	before := *versions[index]
+	if before.ComparableWithPrevious == comparable {
+		return versions[index], nil
+	}
+
+	versions[index].ComparableWithPrevious = comparable
+	rebaseVersions(versions)
+	if err := s.versionRepo.UpdateComparability(versions[index:]); err != nil {
+		return nil, err
+	}
+
+	if s.dataChangeLogService != nil {
+		name := fmt.Sprintf("%s v%d", wod.Name, number)
+		if logErr := s.dataChangeLogService.LogUpdate(domain.EntityTypeWODVersion, versions[index].ID, name, adminID, adminEmail, before, versions[index], nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log WOD version update: %v\n", logErr)
+		}
+	}
+
+	s.recomputePRs(wodID)
+	return versions[index], nil
+}
+
+// GetFamily returns a WOD's parent and the parent's variants
+func (s *WODVersionService) GetFamily(wodID int64) (*WODVariantFamily, error) {
+	if _, err := s.getWOD(wodID); err != nil {
+		return nil, err
+	}
+	family := &WODVariantFamily{ParentWODID: wodID}
+
+	variant, err := s.versionRepo.GetVariant(wodID)
+	if err != nil {
+		return nil, err
+	}
+	if variant != nil {
+		family.Variant = variant
+		family.ParentWODID = variant.ParentWODID
+	}
+
+	if family.Variants, err = s.versionRepo.ListVariants(family.ParentWODID); err != nil {
+		return nil, err
+	}
+	return family, nil
+}
+
+// SaveVariant links a WOD to a parent as a variant, or changes an existing link (admin only).
+// Families are one level deep: the parent cannot be a variant and the variant cannot have variants.
+func (s *WODVersionService) SaveVariant(parentID int64, req *WODVariantRequest, adminID int64, adminEmail string) (*domain.WODVariant, error) {
+	kind := strings.ToLower(strings.TrimSpace(req.Kind))
+	if !contains(domain.WODVariantKinds, kind) {
+		return nil, fmt.Errorf("%w: kind must be one of: %s", ErrInvalidWODVariant, strings.Join(domain.WODVariantKinds, ", "))
+	}
+	if req.VariantWODID == parentID {
+		return nil, fmt.Errorf("%w: a WOD cannot be a variant of itself", ErrInvalidWODVariant)
+	}
+	parent, err := s.getWOD(parentID)
+	if err != nil {
+		return nil, err
+	}
+	variantWOD, err := s.getWOD(req.VariantWODID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentLink, err := s.versionRepo.GetVariant(parentID); err != nil {
+		return nil, err
+	} else if parentLink != nil {
+		return nil, fmt.Errorf("%w: %s is itself a variant", ErrInvalidWODVariant, parent.Name)
+	}
+	if req.Comparable && variantWOD.ScoreType != parent.ScoreType {
+		return nil, fmt.Errorf("%w: scores of different score types cannot be compared", ErrInvalidWODVariant)
+	}
+	if own, err := s.versionRepo.ListVariants(variantWOD.ID); err != nil {
+		return nil, err
+	} else if len(own) > 0 {
+		return nil, fmt.Errorf("%w: %s has variants of its own", ErrInvalidWODVariant, variantWOD.Name)
+	}
+
+	previous, err := s.versionRepo.GetVariant(variantWOD.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := &domain.WODVariant{
+		WODID:       variantWOD.ID,
+		ParentWODID: parent.ID,
+		Kind:        kind,
+		Comparable:  req.Comparable,
+		WODName:     variantWOD.Name,
+	}
+	if previous != nil {
+		variant.CreatedAt = previous.CreatedAt
+	}
+	if err := s.versionRepo.SaveVariant(variant); err != nil {
+		return nil, err
+	}
+
+	if s.dataChangeLogService != nil {
+		if logErr := s.dataChangeLogService.LogUpdate(domain.EntityTypeWODVariant, variantWOD.ID, variantWOD.Name, adminID, adminEmail, previous, variant, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log WOD variant change: %v\n", logErr)
+		}
+	}
+
+	if variant.Comparable || (previous != nil && previous.Comparable) {
+		if previous != nil && previous.ParentWODID != parent.ID {
+			s.recomputePRs(previous.ParentWODID)
+		}
+		s.recomputePRs(parent.ID)
+		s.recomputePRs(variantWOD.ID)
+	}
+	return variant, nil
+}
+
+// RemoveVariant unlinks a variant from its parent (admin only); the WOD itself is kept
+func (s *WODVersionService) RemoveVariant(wodID int64, adminID int64, adminEmail string) error {
+	variant, err := s.versionRepo.GetVariant(wodID)
+	if err != nil {
+		return err
+	}
+	if variant == nil {
+		return ErrWODVariantNotFound
+	}
+	if err := s.versionRepo.DeleteVariant(wodID); err != nil {
+		return err
+	}
+
+	if s.dataChangeLogService != nil {
+		if logErr := s.dataChangeLogService.LogDelete(domain.EntityTypeWODVariant, wodID, variant.WODName, adminID, adminEmail, variant, nil, nil); logErr != nil {
+			fmt.Printf("Warning: failed to log WOD variant removal: %v\n", logErr)
+		}
+	}
+
+	if variant.Comparable {
+		s.recomputePRs(variant.ParentWODID)
+		s.recomputePRs(wodID)
+	}
+	return nil
+}
+
+// ComparableWODIDs returns the WODs whose results rank together with a WOD's: the WOD alone, or a
+// parent and its comparable variants. The parent comes first.
+func (s *WODVersionService) ComparableWODIDs(wodID int64) ([]int64, error) {
+	variant, err := s.versionRepo.GetVariant(wodID)
+	if err != nil {
+		return nil, err
+	}
+	root := wodID
+	if variant != nil {
+		if !variant.Comparable {
+			return []int64{wodID}, nil
+		}
+		root = variant.ParentWODID
+	}
+
+	variants, err := s.versionRepo.ListVariants(root)
+	if err != nil {
+		return nil, err
+	}
+	ids := []int64{root}
+	for _, v := range variants {
+		if v.Comparable {
+			ids = append(ids, v.WODID)
+		}
+	}
+	return ids, nil
+}
+
+// ComparisonKey groups results that may be ranked against each other: results on the current
+// comparable run of a WOD or its comparable variants share a key, results on superseded runs are
+// keyed by their own WOD and run
+func (s *WODVersionService) ComparisonKey(wodID int64, versionID *int64) (string, error) {
+	ids, err := s.ComparableWODIDs(wodID)
+	if err != nil {
+		return "", err
+	}
+	versions, err := s.versionRepo.ListVersions(wodID)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return fmt.Sprintf("%d", ids[0]), nil
+	}
+
+	// Results without a version were logged before the first edit, on version 1
+	baseline := versions[0].BaselineVersion
+	if versionID != nil {
+		for _, version := range versions {
+			if version.ID == *versionID {
+				baseline = version.BaselineVersion
+			}
+		}
+	}
+	if baseline == versions[len(versions)-1].BaselineVersion {
+		return fmt.Sprintf("%d", ids[0]), nil
+	}
+	return fmt.Sprintf("%d/%d@%d", ids[0], wodID, baseline), nil
+}
+
+// DeleteForWOD removes a deleted WOD's versions and variant links
+func (s *WODVersionService) DeleteForWOD(wodID int64) error {
+	return s.versionRepo.DeleteForWOD(wodID)
+}
+
+// recomputePRs re-flags the PRs of every user with results on a WOD's comparable group;
+// failures are logged and never fail the change
+func (s *WODVersionService) recomputePRs(wodID int64) {
+	if s.userWorkoutService == nil {
+		return
+	}
+	ids, err := s.ComparableWODIDs(wodID)
+	if err != nil {
+		fmt.Printf("Warning: failed to load comparable WODs for %d: %v\n", wodID, err)
+		return
+	}
+	userIDs, err := s.versionRepo.ListUserIDsWithResults(ids)
+	if err != nil {
+		fmt.Printf("Warning: failed to list users to recompute PRs for WOD %d: %v\n", wodID, err)
+		return
+	}
+	for _, userID := range userIDs {
+		if _, _, err := s.userWorkoutService.RetroactivelyFlagPRs(userID); err != nil {
+			fmt.Printf("Warning: failed to recompute PRs for user %d after WOD %d changed: %v\n", userID, wodID, err)
+		}
+	}
+}
+
+func (s *WODVersionService) getWOD(id int64) (*domain.WOD, error) {
+	wod, err := s.wodRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wod: %w", err)
+	}
+	if wod == nil {
+		return nil, ErrWODNotFound
+	}
+	return wod, nil
+}
+
+// definitionChanged reports whether an edit changed how a WOD is performed or scored
+func definitionChanged(before, after *domain.WOD) bool {
+	return before.Name != after.Name || before.Regime != after.Regime ||
+		before.ScoreType != after.ScoreType || before.Description != after.Description
+}
+
+// comparableEdit reports whether scores from before and after an edit rank together by default
+func comparableEdit(before, after *domain.WOD) bool {
+	return before.Regime == after.Regime && before.ScoreType == after.ScoreType && before.Description == after.Description
+}
+
+// rebaseVersions recomputes baselines from the comparable flags; versions are oldest first
+func rebaseVersions(versions []*domain.WODVersion) {
+	for i, version := range versions {
+		version.BaselineVersion = version.Version
+		if i > 0 && version.ComparableWithPrevious {
+			version.BaselineVersion = versions[i-1].BaselineVersion
+		}
+	}
+}
+
+func snapshotWOD(wod *domain.WOD, number int) *domain.WODVersion {
+	return &domain.WODVersion{
+		WODID:       wod.ID,
+		Version:     number,
+		Name:        wod.Name,
+		Source:      wod.Source,
+		Type:        wod.Type,
+		Regime:      wod.Regime,
+		ScoreType:   wod.ScoreType,
+		Description: wod.Description,
+		URL:         wod.URL,
+		Notes:       wod.Notes,
+	}
+}
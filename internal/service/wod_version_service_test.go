@@ -0,0 +1,246 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+func newWODVersionTestService() (*WODVersionService, *mockWODVersionRepo, *mockWODRepo) {
+	wodRepo := newMockWODRepo()
+	wodRepo.wods[1] = &domain.WOD{ID: 1, Name: "Fran", Regime: "Fastest Time", ScoreType: "Time (HH:MM:SS)", Description: "21-15-9 thrusters and pull-ups"}
+	wodRepo.wods[2] = &domain.WOD{ID: 2, Name: "Fran (Scaled)", Regime: "Fastest Time", ScoreType: "Time (HH:MM:SS)", Description: "21-15-9 light thrusters and ring rows"}
+	wodRepo.wods[3] = &domain.WOD{ID: 3, Name: "Fran (Team)", Regime: "Fastest Time", ScoreType: "Time (HH:MM:SS)", Description: "42-30-18 shared"}
+	wodRepo.wods[4] = &domain.WOD{ID: 4, Name: "Cindy", Regime: "AMRAP", ScoreType: "Rounds+Reps", Description: "20 min AMRAP"}
+	repo := newMockWODVersionRepo(wodRepo)
+	return NewWODVersionService(repo, wodRepo, nil), repo, wodRepo
+}
+
+func TestWODVersionServiceSaveEdit(t *testing.T) {
+	s, repo, wodRepo := newWODVersionTestService()
+	original := *wodRepo.wods[1]
+
+	notes := "Coach notes"
+	edited := original
+	edited.Notes = &notes
+	if version, err := s.SaveEdit(&original, &edited, 7); err != nil || version != nil {
+		t.Fatalf("expected no version for a metadata-only edit, got %+v, %v", version, err)
+	}
+
+	renamed := original
+	renamed.Name = "Fran Benchmark"
+	v2, err := s.SaveEdit(&original, &renamed, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v2.Version != 2 || !v2.ComparableWithPrevious || v2.BaselineVersion != 1 {
+		t.Errorf("expected a rename to be comparable version 2 on baseline 1, got %+v", v2)
+	}
+	versions, current, _ := s.ListVersions(1)
+	if len(versions) != 2 || current != 2 || versions[0].Name != "Fran" {
+		t.Fatalf("expected version 1 snapshotted before the first edit, got %d versions, current %d", len(versions), current)
+	}
+	if repo.assigned[1] != versions[0].ID {
+		t.Errorf("expected earlier results assigned to version 1, got %d", repo.assigned[1])
+	}
+
+	changed := renamed
+	changed.Description = "30-20-10 thrusters and pull-ups"
+	v3, err := s.SaveEdit(&renamed, &changed, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v3.ComparableWithPrevious || v3.BaselineVersion != 3 {
+		t.Errorf("expected a description change to start a new run, got %+v", v3)
+	}
+}
+
+func TestWODServiceUpdateFailsWithItsVersion(t *testing.T) {
+	s, repo, wodRepo := newWODVersionTestService()
+	wodService := NewWODService(wodRepo, nil)
+	wodService.SetVersionService(s)
+	repo.saveError = errors.New("database is locked")
+
+	wodRepo.wods[4].Source, wodRepo.wods[4].Type = "CrossFit", "Benchmark"
+	edited := *wodRepo.wods[4]
+	edited.Description = "15 min AMRAP"
+	if err := wodService.UpdateAsAdmin(&edited, 7, "admin@example.com"); !errors.Is(err, repo.saveError) {
+		t.Fatalf("expected the edit to fail when its version cannot be saved, got %v", err)
+	}
+	if wodRepo.wods[4].Description != "20 min AMRAP" || len(repo.versions) != 0 {
+		t.Errorf("expected neither the edit nor a version to be saved, got %q and %d versions", wodRepo.wods[4].Description, len(repo.versions))
+	}
+
+	repo.saveError = nil
+	if err := wodService.UpdateAsAdmin(&edited, 7, "admin@example.com"); err != nil {
+		t.Fatalf("UpdateAsAdmin() error = %v", err)
+	}
+	if wodRepo.wods[4].Description != "15 min AMRAP" || len(repo.versions) != 2 {
+		t.Errorf("expected the edit saved with versions 1 and 2, got %q and %d versions", wodRepo.wods[4].Description, len(repo.versions))
+	}
+}
+
+func TestWODVersionServiceGetVersionOfUneditedWOD(t *testing.T) {
+	s, _, _ := newWODVersionTestService()
+
+	version, err := s.GetVersion(4, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.Name != "Cindy" || version.BaselineVersion != 1 {
+		t.Errorf("expected version 1 synthesized from the current definition, got %+v", version)
+	}
+	if _, err := s.GetVersion(4, 2); !errors.Is(err, ErrWODVersionNotFound) {
+		t.Errorf("expected ErrWODVersionNotFound, got %v", err)
+	}
+}
+
+func TestWODVersionServiceSetComparable(t *testing.T) {
+	s, _, wodRepo := newWODVersionTestService()
+	v1 := *wodRepo.wods[1]
+	v2 := v1
+	v2.Description = "21-15-9 thrusters and chest-to-bar pull-ups"
+	v3 := v2
+	v3.Name = "Fran C2B"
+	s.SaveEdit(&v1, &v2, 7)
+	s.SaveEdit(&v2, &v3, 7)
+
+	if _, err := s.SetComparable(1, 1, true, 1, "admin@example.com"); !errors.Is(err, ErrInvalidWODVersion) {
+		t.Errorf("expected ErrInvalidWODVersion for version 1, got %v", err)
+	}
+
+	version, err := s.SetComparable(1, 2, true, 1, "admin@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.BaselineVersion != 1 {
+		t.Errorf("expected version 2 rebased onto version 1, got %d", version.BaselineVersion)
+	}
+	versions, _, _ := s.ListVersions(1)
+	if versions[2].BaselineVersion != 1 {
+		t.Errorf("expected the comparable version 3 to follow the new baseline, got %d", versions[2].BaselineVersion)
+	}
+
+	v4 := v3
+	v4.ScoreType = "Reps"
+	s.SaveEdit(&v3, &v4, 7)
+	if _, err := s.SetComparable(1, 4, true, 1, "admin@example.com"); !errors.Is(err, ErrInvalidWODVersion) {
+		t.Errorf("expected ErrInvalidWODVersion across score types, got %v", err)
+	}
+}
+
+func TestWODVersionServiceVariants(t *testing.T) {
+	s, _, _ := newWODVersionTestService()
+	admin := "admin@example.com"
+
+	if _, err := s.SaveVariant(1, &WODVariantRequest{VariantWODID: 2, Kind: "rx"}, 1, admin); !errors.Is(err, ErrInvalidWODVariant) {
+		t.Errorf("expected ErrInvalidWODVariant for an unknown kind, got %v", err)
+	}
+	if _, err := s.SaveVariant(1, &WODVariantRequest{VariantWODID: 1, Kind: "scaled"}, 1, admin); !errors.Is(err, ErrInvalidWODVariant) {
+		t.Errorf("expected ErrInvalidWODVariant for a self link, got %v", err)
+	}
+	if _, err := s.SaveVariant(1, &WODVariantRequest{VariantWODID: 4, Kind: "scaled", Comparable: true}, 1, admin); !errors.Is(err, ErrInvalidWODVariant) {
+		t.Errorf("expected ErrInvalidWODVariant across score types, got %v", err)
+	}
+
+	if _, err := s.SaveVariant(1, &WODVariantRequest{VariantWODID: 2, Kind: " Scaled ", Comparable: true}, 1, admin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.SaveVariant(1, &WODVariantRequest{VariantWODID: 3, Kind: "team"}, 1, admin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.SaveVariant(2, &WODVariantRequest{VariantWODID: 4, Kind: "masters"}, 1, admin); !errors.Is(err, ErrInvalidWODVariant) {
+		t.Errorf("expected ErrInvalidWODVariant for a variant parent, got %v", err)
+	}
+	if _, err := s.SaveVariant(4, &WODVariantRequest{VariantWODID: 1, Kind: "masters"}, 1, admin); !errors.Is(err, ErrInvalidWODVariant) {
+		t.Errorf("expected ErrInvalidWODVariant for a variant with variants, got %v", err)
+	}
+
+	ids, _ := s.ComparableWODIDs(2)
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("expected the parent and its comparable variant, got %v", ids)
+	}
+	if ids, _ := s.ComparableWODIDs(3); len(ids) != 1 || ids[0] != 3 {
+		t.Errorf("expected a non-comparable variant to rank alone, got %v", ids)
+	}
+
+	family, _ := s.GetFamily(2)
+	if family.ParentWODID != 1 || family.Variant == nil || len(family.Variants) != 2 {
+		t.Errorf("expected the family of the scaled variant, got %+v", family)
+	}
+
+	if err := s.RemoveVariant(2, 1, admin); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.RemoveVariant(2, 1, admin); !errors.Is(err, ErrWODVariantNotFound) {
+		t.Errorf("expected ErrWODVariantNotFound, got %v", err)
+	}
+}
+
+func TestWODVersionServiceComparisonKey(t *testing.T) {
+	s, repo, wodRepo := newWODVersionTestService()
+	s.SaveVariant(1, &WODVariantRequest{VariantWODID: 2, Kind: "scaled", Comparable: true}, 1, "admin@example.com")
+
+	if key, _ := s.ComparisonKey(2, nil); key != "1" {
+		t.Errorf("expected a comparable variant keyed by its parent, got %q", key)
+	}
+
+	before := *wodRepo.wods[2]
+	after := before
+	after.Description = "21-15-9 light thrusters and jumping pull-ups"
+	s.SaveEdit(&before, &after, 7)
+	v1 := repo.versions[0].ID
+	v2 := repo.versions[1].ID
+
+	if key, _ := s.ComparisonKey(2, &v2); key != "1" {
+		t.Errorf("expected the current run keyed by the parent, got %q", key)
+	}
+	if key, _ := s.ComparisonKey(2, &v1); key != "1/2@1" {
+		t.Errorf("expected a superseded run keyed by WOD and baseline, got %q", key)
+	}
+	if key, _ := s.ComparisonKey(2, nil); key != "1/2@1" {
+		t.Errorf("expected unversioned results on version 1, got %q", key)
+	}
+}
+
+func TestNewWODsStartOnVersionOne(t *testing.T) {
+	db := openTestDB(t)
+	wodRepo := repository.NewWODRepository(db)
+	versionRepo := repository.NewWODVersionRepository(db)
+	orgRepo := repository.NewOrganizationRepository(db)
+
+	custom := &domain.WOD{Name: "Version Test Custom", Source: "Self-recorded", Type: "Self-created", Regime: "Fastest Time",
+		ScoreType: domain.ScoreTypeTime, Description: "21-15-9 burpees"}
+	if err := wodRepo.Create(custom); err != nil {
+		t.Fatalf("failed to create wod: %v", err)
+	}
+	gym := &domain.Organization{Name: "Version Gym", Slug: "version-gym"}
+	if err := orgRepo.Create(gym); err != nil {
+		t.Fatalf("failed to create gym: %v", err)
+	}
+	gymWOD := &domain.WOD{Name: "Version Test Gym", Source: "Self-recorded", Type: "Self-created", Regime: "AMRAP",
+		ScoreType: domain.ScoreTypeRoundsReps, Description: "AMRAP 12 min"}
+	if err := wodRepo.CreateInLibrary(gymWOD, gym.ID, 0); err != nil {
+		t.Fatalf("failed to create gym wod: %v", err)
+	}
+	copied, err := wodRepo.CopyToStandard(custom.ID, "Version Test Standard")
+	if err != nil {
+		t.Fatalf("failed to copy wod to standard: %v", err)
+	}
+	fran, err := wodRepo.GetByName("Fran")
+	if err != nil || fran == nil {
+		t.Fatalf("seeded Fran not found: %v", err)
+	}
+
+	for _, wod := range []*domain.WOD{custom, gymWOD, copied, fran} {
+		versions, err := versionRepo.ListVersions(wod.ID)
+		if err != nil {
+			t.Fatalf("failed to list versions: %v", err)
+		}
+		if len(versions) != 1 || versions[0].Version != 1 || versions[0].Name != wod.Name || versions[0].BaselineVersion != 1 {
+			t.Errorf("expected %s to start on version 1, got %+v", wod.Name, versions)
+		}
+	}
+}
@@ -19,9 +19,13 @@ type WodifyImportService struct {
 	userWorkoutRepo         domain.UserWorkoutRepository
 	userWorkoutMovementRepo domain.UserWorkoutMovementRepository
 	userWorkoutWODRepo      domain.UserWorkoutWODRepository
+	aliasService            *AliasService
+	searchService           *SearchService
+	structureService        *WODStructureService
 	parser                  *WodifyResultParser
 }
 
+
 // NewWodifyImportService creates a new Wodify import service
 func NewWodifyImportService(
 	userRepo domain.UserRepository,
@@ -42,6 +46,21 @@ func NewWodifyImportService(
 	}
 }
 
+// SetAliasService lets imports resolve component names through movement and WOD aliases
+func (s *WodifyImportService) SetAliasService(aliasService *AliasService) {
+	s.aliasService = aliasService
+}
+
+// SetSearchService re-indexes search after imports that create workouts, movements or WODs
+func (s *WodifyImportService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
+// SetStructureService parses the descriptions of WODs an import creates
+func (s *WodifyImportService) SetStructureService(structureService *WODStructureService) {
+	s.structureService = structureService
+}
+
 // PreviewImport parses the CSV and returns a preview of what will be imported
 func (s *WodifyImportService) PreviewImport(csvData io.Reader, userID int64) (*domain.WodifyImportPreview, error) {
 	// Parse CSV
@@ -51,7 +70,11 @@ func (s *WodifyImportService) PreviewImport(csvData io.Reader, userID int64) (*d
 	grouped := s.groupByDate(rows)
 
 	// Analyze what needs to be created
-	newMovements, newWODs := s.analyzeNewEntities(rows, userID)
+	catalog, err := loadNameCatalog(s.movementRepo, s.wodRepo, s.aliasService, userID)
+	if err != nil {
+		return nil, err
+	}
+	newMovements, newWODs, suggestions := s.analyzeNewEntities(rows, catalog)
 
 	// Create workout summary with duplicate detection
 	workoutSummary := s.createWorkoutSummary(grouped, userID)
@@ -82,6 +105,7 @@ func (s *WodifyImportService) PreviewImport(csvData io.Reader, userID int64) (*d
 		WorkoutSummary:       workoutSummary,
 		NewMovements:         newMovements,
 		NewWODs:              newWODs,
+		Suggestions:          suggestions,
 	}
 
 	return preview, nil
@@ -95,14 +119,22 @@ func (s *WodifyImportService) ConfirmImport(csvData io.Reader, userID int64) (*d
 	// Group by date
 	grouped := s.groupByDate(rows)
 
+	catalog, err := loadNameCatalog(s.movementRepo, s.wodRepo, s.aliasService, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &domain.WodifyImportResult{}
 
 	// Process each workout date
 	for _, workout := range grouped {
-		if err := s.importWorkout(workout, userID, result); err != nil {
+		if err := s.importWorkout(workout, userID, catalog, result); err != nil {
 			return nil, fmt.Errorf("failed to import workout for %s: %w", workout.Date.Format("2006-01-02"), err)
 		}
 	}
+	if s.searchService != nil && len(grouped) > 0 {
+		s.searchService.RefreshAll()
+	}
 
 	return result, nil
 }
@@ -232,55 +264,52 @@ func (s *WodifyImportService) groupByDate(rows []domain.WodifyPerformanceRow) []
 	return grouped
 }
 
-// analyzeNewEntities determines which movements and WODs need to be created
-func (s *WodifyImportService) analyzeNewEntities(rows []domain.WodifyPerformanceRow, userID int64) ([]string, []string) {
-	movementNames := make(map[string]bool)
-	wodNames := make(map[string]bool)
+// analyzeNewEntities determines which movements and WODs need to be created, with close matches
+// for each of them so the user can spot near-duplicates before confirming
+func (s *WodifyImportService) analyzeNewEntities(rows []domain.WodifyPerformanceRow, catalog *nameCatalog) ([]string, []string, []*domain.NameSuggestion) {
+	movementNames := make(map[string]string)
+	wodNames := make(map[string]string)
 
+	// Names differing only in case or punctuation are one entity
 	for _, row := range rows {
+		key := domain.NormalizeName(row.ComponentName)
 		if row.ComponentType == "Metcon" {
-			wodNames[row.ComponentName] = true
-		} else {
-			movementNames[row.ComponentName] = true
-		}
-	}
-
-	// Check which movements already exist
-	var newMovements []string
-	for name := range movementNames {
-		movements, _ := s.movementRepo.Search(name, 10)
-		exists := false
-		for _, m := range movements {
-			if strings.EqualFold(m.Name, name) {
-				exists = true
-				break
+			if _, ok := wodNames[key]; !ok {
+				wodNames[key] = row.ComponentName
 			}
-		}
-		if !exists {
-			newMovements = append(newMovements, name)
+		} else if _, ok := movementNames[key]; !ok {
+			movementNames[key] = row.ComponentName
 		}
 	}
 
-	// Check which WODs already exist
-	var newWODs []string
-	for name := range wodNames {
-		wods, _ := s.wodRepo.Search(name, 10)
-		exists := false
-		for _, w := range wods {
-			if strings.EqualFold(w.Name, name) {
-				exists = true
-				break
-			}
+	newMovements := unresolvedNames(movementNames, catalog.movements)
+	newWODs := unresolvedNames(wodNames, catalog.wods)
+
+	var suggestions []*domain.NameSuggestion
+	for _, name := range newMovements {
+		if suggestion := catalog.movements.Suggestion(name, 3); suggestion != nil {
+			suggestions = append(suggestions, suggestion)
 		}
-		if !exists {
-			newWODs = append(newWODs, name)
+	}
+	for _, name := range newWODs {
+		if suggestion := catalog.wods.Suggestion(name, 3); suggestion != nil {
+			suggestions = append(suggestions, suggestion)
 		}
 	}
 
-	sort.Strings(newMovements)
-	sort.Strings(newWODs)
+	return newMovements, newWODs, suggestions
+}
 
-	return newMovements, newWODs
+// unresolvedNames lists, sorted, the names that do not resolve to an existing record
+func unresolvedNames(names map[string]string, matcher *NameMatcher) []string {
+	var unresolved []string
+	for _, name := range names {
+		if _, ok := matcher.Resolve(name); !ok {
+			unresolved = append(unresolved, name)
+		}
+	}
+	sort.Strings(unresolved)
+	return unresolved
 }
 
 // createWorkoutSummary creates a summary of workouts to be imported
@@ -334,7 +363,7 @@ func (s *WodifyImportService) createWorkoutSummary(grouped []domain.WodifyGroupe
 }
 
 // importWorkout imports a single grouped workout
-func (s *WodifyImportService) importWorkout(workout domain.WodifyGroupedWorkout, userID int64, result *domain.WodifyImportResult) error {
+func (s *WodifyImportService) importWorkout(workout domain.WodifyGroupedWorkout, userID int64, catalog *nameCatalog, result *domain.WodifyImportResult) error {
 	// Check if workout already exists for this date
 	existingWorkouts, err := s.userWorkoutRepo.ListByUserAndDateRange(userID, workout.Date, workout.Date.Add(24*time.Hour))
 
@@ -381,11 +410,11 @@ func (s *WodifyImportService) importWorkout(workout domain.WodifyGroupedWorkout,
 	// Process each performance
 	for orderIndex, perf := range workout.Performances {
 		if perf.ComponentType == "Metcon" {
-			if err := s.importWODPerformance(userWorkoutID, userID, perf, orderIndex, result, isUpdate); err != nil {
+			if err := s.importWODPerformance(userWorkoutID, userID, perf, orderIndex, catalog, result, isUpdate); err != nil {
 				return fmt.Errorf("failed to import WOD performance: %w", err)
 			}
 		} else {
-			if err := s.importMovementPerformance(userWorkoutID, userID, perf, orderIndex, result, isUpdate); err != nil {
+			if err := s.importMovementPerformance(userWorkoutID, userID, perf, orderIndex, catalog, result, isUpdate); err != nil {
 				return fmt.Errorf("failed to import movement performance: %w", err)
 			}
 		}
@@ -399,9 +428,9 @@ func (s *WodifyImportService) importWorkout(workout domain.WodifyGroupedWorkout,
 }
 
 // importMovementPerformance imports a movement performance
-func (s *WodifyImportService) importMovementPerformance(userWorkoutID, userID int64, perf domain.WodifyPerformanceRow, orderIndex int, result *domain.WodifyImportResult, isUpdate bool) error {
+func (s *WodifyImportService) importMovementPerformance(userWorkoutID, userID int64, perf domain.WodifyPerformanceRow, orderIndex int, catalog *nameCatalog, result *domain.WodifyImportResult, isUpdate bool) error {
 	// Get or create movement
-	movement, created, err := s.getOrCreateMovement(perf, userID)
+	movement, created, err := s.getOrCreateMovement(perf, userID, catalog.movements)
 	if err != nil {
 		return err
 	}
@@ -447,9 +476,9 @@ func (s *WodifyImportService) importMovementPerformance(userWorkoutID, userID in
 }
 
 // importWODPerformance imports a WOD performance
-func (s *WodifyImportService) importWODPerformance(userWorkoutID, userID int64, perf domain.WodifyPerformanceRow, orderIndex int, result *domain.WodifyImportResult, isUpdate bool) error {
+func (s *WodifyImportService) importWODPerformance(userWorkoutID, userID int64, perf domain.WodifyPerformanceRow, orderIndex int, catalog *nameCatalog, result *domain.WodifyImportResult, isUpdate bool) error {
 	// Get or create WOD
-	wod, created, err := s.getOrCreateWOD(perf, userID)
+	wod, created, err := s.getOrCreateWOD(perf, userID, catalog.wods)
 	if err != nil {
 		return err
 	}
@@ -466,25 +495,30 @@ func (s *WodifyImportService) importWODPerformance(userWorkoutID, userID int64,
 	}
 	parsed.IsPR = perf.IsPersonalRecord
 
-	// Determine score type and value
+	// Determine score type
 	scoreType := s.parser.DetermineWODScoreType(perf.PerformanceResultType)
-	scoreValue := s.formatScoreValue(parsed, scoreType)
 
 	// Create UserWorkoutWOD
 	uww := &domain.UserWorkoutWOD{
 		UserWorkoutID: userWorkoutID,
 		WODID:         wod.ID,
 		ScoreType:     &scoreType,
-		ScoreValue:    scoreValue,
 		TimeSeconds:   parsed.TimeSeconds,
 		Rounds:        parsed.Rounds,
 		Reps:          parsed.Reps,
 		Weight:        parsed.Weight,
-		Notes:         parsed.Notes,
-		IsPR:          parsed.IsPR,
-		OrderIndex:    orderIndex,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		WODScoreDetails: domain.WODScoreDetails{
+			Calories: parsed.Calories,
+			Distance: parsed.Distance,
+		},
+		Notes:      parsed.Notes,
+		IsPR:       parsed.IsPR,
+		OrderIndex: orderIndex,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if scoreValue := domain.LookupScoreType(scoreType).Format(uww); scoreValue != "" {
+		uww.ScoreValue = &scoreValue
 	}
 
 	if err := s.userWorkoutWODRepo.Create(uww); err != nil {
@@ -500,14 +534,15 @@ func (s *WodifyImportService) importWODPerformance(userWorkoutID, userID int64,
 }
 
 // getOrCreateMovement gets an existing movement or creates a new one
-func (s *WodifyImportService) getOrCreateMovement(perf domain.WodifyPerformanceRow, userID int64) (*domain.Movement, bool, error) {
-	// Search for existing movement
-	movements, err := s.movementRepo.Search(perf.ComponentName, 10)
-	if err == nil {
-		for _, m := range movements {
-			if strings.EqualFold(m.Name, perf.ComponentName) {
-				return m, false, nil
-			}
+func (s *WodifyImportService) getOrCreateMovement(perf domain.WodifyPerformanceRow, userID int64, matcher *NameMatcher) (*domain.Movement, bool, error) {
+	// Look up an existing movement by normalized name or alias
+	if match, ok := matcher.Resolve(perf.ComponentName); ok {
+		movement, err := s.movementRepo.GetByID(match.ID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get movement: %w", err)
+		}
+		if movement != nil {
+			return movement, false, nil
 		}
 	}
 
@@ -528,19 +563,21 @@ func (s *WodifyImportService) getOrCreateMovement(perf domain.WodifyPerformanceR
 	if err := s.movementRepo.Create(movement); err != nil {
 		return nil, false, fmt.Errorf("failed to create movement: %w", err)
 	}
+	matcher.Add(movement.ID, movement.Name, movement.IsStandard)
 
 	return movement, true, nil
 }
 
 // getOrCreateWOD gets an existing WOD or creates a new one
-func (s *WodifyImportService) getOrCreateWOD(perf domain.WodifyPerformanceRow, userID int64) (*domain.WOD, bool, error) {
-	// Search for existing WOD
-	wods, err := s.wodRepo.Search(perf.ComponentName, 10)
-	if err == nil {
-		for _, w := range wods {
-			if strings.EqualFold(w.Name, perf.ComponentName) {
-				return w, false, nil
-			}
+func (s *WodifyImportService) getOrCreateWOD(perf domain.WodifyPerformanceRow, userID int64, matcher *NameMatcher) (*domain.WOD, bool, error) {
+	// Look up an existing WOD by normalized name or alias
+	if match, ok := matcher.Resolve(perf.ComponentName); ok {
+		wod, err := s.wodRepo.GetByID(match.ID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get WOD: %w", err)
+		}
+		if wod != nil {
+			return wod, false, nil
 		}
 	}
 
@@ -564,6 +601,8 @@ func (s *WodifyImportService) getOrCreateWOD(perf domain.WodifyPerformanceRow, u
 	if err := s.wodRepo.Create(wod); err != nil {
 		return nil, false, fmt.Errorf("failed to create WOD: %w", err)
 	}
+	refreshWODStructure(s.structureService, wod)
+	matcher.Add(wod.ID, wod.Name, wod.IsStandard)
 
 	return wod, true, nil
 }
@@ -595,43 +634,6 @@ func (s *WodifyImportService) determineWorkoutType(performances []domain.WodifyP
 	return maxType
 }
 
-// formatScoreValue formats the score value string based on parsed result
-func (s *WodifyImportService) formatScoreValue(parsed *domain.ParsedPerformanceResult, scoreType string) *string {
-	var value string
-
-	switch scoreType {
-	case "Time (HH:MM:SS)":
-		if parsed.TimeSeconds != nil {
-			seconds := *parsed.TimeSeconds
-			hours := seconds / 3600
-			minutes := (seconds % 3600) / 60
-			secs := seconds % 60
-			if hours > 0 {
-				value = fmt.Sprintf("%02d:%02d:%02d", hours, minutes, secs)
-			} else {
-				value = fmt.Sprintf("%02d:%02d", minutes, secs)
-			}
-		}
-	case "Rounds+Reps":
-		if parsed.Rounds != nil && parsed.Reps != nil {
-			value = fmt.Sprintf("%d rounds + %d reps", *parsed.Rounds, *parsed.Reps)
-		} else if parsed.Rounds != nil {
-			value = fmt.Sprintf("%d rounds", *parsed.Rounds)
-		} else if parsed.Reps != nil {
-			value = fmt.Sprintf("%d reps", *parsed.Reps)
-		}
-	case "Max Weight":
-		if parsed.Weight != nil {
-			value = fmt.Sprintf("%.0f lbs", *parsed.Weight)
-		}
-	}
-
-	if value == "" {
-		return nil
-	}
-	return &value
-}
-
 // Helper function to parse boolean strings
 func parseBool(s string) bool {
 	return strings.ToUpper(strings.TrimSpace(s)) == "TRUE"
@@ -276,12 +276,18 @@ func (p *WodifyResultParser) DetermineMovementType(componentType string) string
 func (p *WodifyResultParser) DetermineWODScoreType(resultType string) string {
 	switch resultType {
 	case "Time":
-		return "Time (HH:MM:SS)"
-	case "AMRAP - Rounds and Reps", "AMRAP - Rounds", "AMRAP - Reps", "Each Round", "Max reps":
-		return "Rounds+Reps"
+		return domain.ScoreTypeTime
+	case "AMRAP - Rounds and Reps", "AMRAP - Rounds":
+		return domain.ScoreTypeRoundsReps
+	case "AMRAP - Reps", "Each Round", "Max reps":
+		return domain.ScoreTypeReps
+	case "Calories":
+		return domain.ScoreTypeCalories
+	case "Distance":
+		return domain.ScoreTypeDistance
 	case "Weight":
-		return "Max Weight"
+		return domain.ScoreTypeMaxWeight
 	default:
-		return "Time (HH:MM:SS)" // Default
+		return domain.ScoreTypeTime // Default
 	}
 }
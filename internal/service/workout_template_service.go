@@ -12,6 +12,7 @@ type WorkoutTemplateService struct {
 	workoutRepo         domain.WorkoutRepository
 	workoutMovementRepo domain.WorkoutMovementRepository
 	workoutWODRepo      domain.WorkoutWODRepository
+	searchService       *SearchService
 }
 
 func NewWorkoutTemplateService(workoutRepo domain.WorkoutRepository, workoutMovementRepo domain.WorkoutMovementRepository, workoutWODRepo domain.WorkoutWODRepository) *WorkoutTemplateService {
@@ -22,6 +23,11 @@ func NewWorkoutTemplateService(workoutRepo domain.WorkoutRepository, workoutMove
 	}
 }
 
+// SetSearchService keeps the search index in step with template edits
+func (s *WorkoutTemplateService) SetSearchService(searchService *SearchService) {
+	s.searchService = searchService
+}
+
 // Create creates a new workout template
 func (s *WorkoutTemplateService) Create(userID int64, name string, notes *string, movements []domain.WorkoutMovement, wods []domain.WorkoutWOD) (*domain.Workout, error) {
 	// Create the workout template
@@ -73,6 +79,8 @@ func (s *WorkoutTemplateService) Create(userID int64, name string, notes *string
 		}
 	}
 
+	s.refreshSearch(workout.ID)
+
 	// Reload with details
 	return s.GetByIDWithDetails(workout.ID)
 }
@@ -143,6 +151,7 @@ func (s *WorkoutTemplateService) Update(id, userID int64, name string, notes *st
 	if err := s.workoutRepo.Update(existing); err != nil {
 		return nil, fmt.Errorf("failed to update workout template: %w", err)
 	}
+	s.refreshSearch(id)
 
 	// Delete existing movements
 	if err := s.workoutMovementRepo.DeleteByWorkoutID(id); err != nil {
@@ -229,9 +238,10 @@ func (s *WorkoutTemplateService) ListAllUserCreatedWithUserInfo(limit, offset in
 }
 
 // ListAllUserCreatedWithUserInfoFiltered retrieves all user-created workout templates with creator info and filters (admin only)
-func (s *WorkoutTemplateService) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string) ([]*domain.WorkoutWithCreator, int64, error) {
+// A positive orgID limits the list to items created by members of that gym
+func (s *WorkoutTemplateService) ListAllUserCreatedWithUserInfoFiltered(limit, offset int, search, creator string, orgID int64) ([]*domain.WorkoutWithCreator, int64, error) {
 	// Get the list with user info and filters
-	workouts, count, err := s.workoutRepo.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, creator)
+	workouts, count, err := s.workoutRepo.ListAllUserCreatedWithUserInfoFiltered(limit, offset, search, creator, orgID)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list all user-created workouts with filters: %w", err)
 	}
@@ -251,6 +261,7 @@ func (s *WorkoutTemplateService) CopyToStandard(id int64, newName string) (*doma
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy workout to standard: %w", err)
 	}
+	s.refreshSearch(workout.ID)
 
 	return workout, nil
 }
@@ -280,6 +291,14 @@ func (s *WorkoutTemplateService) Delete(id, userID int64) error {
 	if err := s.workoutRepo.Delete(id); err != nil {
 		return fmt.Errorf("failed to delete workout template: %w", err)
 	}
+	s.refreshSearch(id)
 
 	return nil
 }
+
+// refreshSearch re-indexes a template when search is wired
+func (s *WorkoutTemplateService) refreshSearch(id int64) {
+	if s.searchService != nil {
+		s.searchService.Refresh(domain.SearchEntityTemplate, id)
+	}
+}
@@ -0,0 +1,508 @@
+package service
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+)
+
+const (
+	// yearInReviewTopCount is how many movements and WODs are listed as most performed
+	yearInReviewTopCount = 5
+
+	// yearInReviewPRLimit bounds the PR history scanned for the year (PR queries are newest first)
+	yearInReviewPRLimit = 5000
+)
+
+// ReviewMonth is one month of a year in review
+type ReviewMonth struct {
+	Month    string  `json:"month"` // YYYY-MM
+	Name     string  `json:"name"`
+	Sessions int     `json:"sessions"`
+	Tonnage  float64 `json:"tonnage"`
+}
+
+// ReviewPR is a personal record set during the year
+type ReviewPR struct {
+	Date          string `json:"date"`
+	Kind          string `json:"kind"` // movement or wod
+	Name          string `json:"name"`
+	Result        string `json:"result"` // Human-readable result (e.g. "315 lb x 1", "4:02")
+	UserWorkoutID int64  `json:"user_workout_id"`
+}
+
+// BenchmarkComparison compares the first and last attempts at a WOD within the year
+// Delta is last minus first (seconds for Time, load for Max Weight); Rounds+Reps only reports Improved
+type BenchmarkComparison struct {
+	WODID     int64    `json:"wod_id"`
+	WODName   string   `json:"wod_name"`
+	WODType   string   `json:"wod_type"`
+	ScoreType string   `json:"score_type"`
+	Attempts  int      `json:"attempts"`
+	FirstDate string   `json:"first_date"`
+	First     string   `json:"first"`
+	LastDate  string   `json:"last_date"`
+	Last      string   `json:"last"`
+	Delta     *float64 `json:"delta,omitempty"`
+	Improved  bool     `json:"improved"`
+}
+
+// YearInReview is a user's annual training summary
+type YearInReview struct {
+	Year          int                         `json:"year"`
+	UserName      string                      `json:"user_name"`
+	GeneratedAt   time.Time                   `json:"generated_at"`
+	TotalSessions int                         `json:"total_sessions"`
+	TrainingDays  int                         `json:"training_days"`
+	TotalTime     int                         `json:"total_time"` // seconds
+	TotalReps     int                         `json:"total_reps"`
+	TotalTonnage  float64                     `json:"total_tonnage"`
+	RestDays      int                         `json:"rest_days"`
+	LongestStreak *Streak                     `json:"longest_streak,omitempty"`
+	BusiestMonth  *ReviewMonth                `json:"busiest_month,omitempty"`
+	Months        []*ReviewMonth              `json:"months"`
+	TopMovements  []*domain.MovementFrequency `json:"top_movements"`
+	TopWODs       []*domain.WODFrequency      `json:"top_wods"`
+	PRCount       int                         `json:"pr_count"`
+	PRs           []*ReviewPR                 `json:"prs"` // Oldest first
+	Benchmarks    []*BenchmarkComparison      `json:"benchmarks"`
+}
+
+// YearInReviewService builds annual training summaries
+type YearInReviewService struct {
+	analyticsRepo           domain.AnalyticsRepository
+	userRepo                domain.UserRepository
+	userWorkoutRepo         domain.UserWorkoutRepository
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository
+	userWorkoutWODRepo      domain.UserWorkoutWODRepository
+	now                     func() time.Time
+}
+
+// NewYearInReviewService creates a new year in review service
+func NewYearInReviewService(
+	analyticsRepo domain.AnalyticsRepository,
+	userRepo domain.UserRepository,
+	userWorkoutRepo domain.UserWorkoutRepository,
+	userWorkoutMovementRepo domain.UserWorkoutMovementRepository,
+	userWorkoutWODRepo domain.UserWorkoutWODRepository,
+) *YearInReviewService {
+	return &YearInReviewService{
+		analyticsRepo:           analyticsRepo,
+		userRepo:                userRepo,
+		userWorkoutRepo:         userWorkoutRepo,
+		userWorkoutMovementRepo: userWorkoutMovementRepo,
+		userWorkoutWODRepo:      userWorkoutWODRepo,
+		now:                     time.Now,
+	}
+}
+
+// GetYearInReview summarizes a calendar year of training.
+// restDays is how many consecutive days off the longest streak tolerates (0-6).
+func (s *YearInReviewService) GetYearInReview(userID int64, year, restDays int) (*YearInReview, error) {
+	if year < 1900 || year > 9999 {
+		return nil, ErrInvalidCalendarYear
+	}
+	if restDays < 0 || restDays > 6 {
+		return nil, ErrInvalidRestDays
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).Add(24*time.Hour - time.Nanosecond)
+
+	review := &YearInReview{
+		Year:        year,
+		UserName:    user.Name,
+		GeneratedAt: s.now().UTC(),
+		RestDays:    restDays,
+		PRs:         []*ReviewPR{},
+		Benchmarks:  []*BenchmarkComparison{},
+	}
+	for m := time.January; m <= time.December; m++ {
+		review.Months = append(review.Months, &ReviewMonth{
+			Month: fmt.Sprintf("%04d-%02d", year, int(m)),
+			Name:  m.String(),
+		})
+	}
+
+	// Sessions, training days and the longest streak
+	workouts, err := s.userWorkoutRepo.ListByUserAndDateRange(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	review.TotalSessions = len(workouts)
+	seen := make(map[time.Time]bool)
+	var days []time.Time
+	for _, w := range workouts {
+		day := truncateDay(w.WorkoutDate)
+		review.Months[day.Month()-1].Sessions++
+		if w.TotalTime != nil {
+			review.TotalTime += *w.TotalTime
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	review.TrainingDays = len(days)
+	_, review.LongestStreak = computeStreaks(days, restDays, truncateDay(end))
+
+	// Tonnage and reps per month
+	volumes, err := s.analyticsRepo.GetMovementVolume(userID, domain.BucketMonth, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes {
+		review.TotalReps += v.Reps
+		review.TotalTonnage += v.Tonnage
+		for _, month := range review.Months {
+			if strings.HasPrefix(v.Bucket, month.Month) {
+				month.Tonnage = roundTenth(month.Tonnage + v.Tonnage)
+			}
+		}
+	}
+	review.TotalTonnage = roundTenth(review.TotalTonnage)
+
+	for _, month := range review.Months {
+		if month.Sessions == 0 {
+			continue
+		}
+		if review.BusiestMonth == nil || month.Sessions > review.BusiestMonth.Sessions ||
+			(month.Sessions == review.BusiestMonth.Sessions && month.Tonnage > review.BusiestMonth.Tonnage) {
+			review.BusiestMonth = month
+		}
+	}
+
+	// Most performed movements and WODs
+	movements, err := s.analyticsRepo.GetMovementFrequency(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	review.TopMovements = topN(movements, yearInReviewTopCount)
+	if review.TopMovements == nil {
+		review.TopMovements = []*domain.MovementFrequency{}
+	}
+
+	wods, err := s.analyticsRepo.GetWODFrequency(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	review.TopWODs = topN(wods, yearInReviewTopCount)
+	if review.TopWODs == nil {
+		review.TopWODs = []*domain.WODFrequency{}
+	}
+
+	if err := s.addPRs(review, userID, year); err != nil {
+		return nil, err
+	}
+
+	// First vs last attempt at every benchmark WOD done more than once
+	for _, wod := range wods {
+		if wod.Count < 2 || !isBenchmarkWODType(wod.WODType) {
+			continue
+		}
+		comparison, err := s.compareBenchmark(userID, start, end, wod)
+		if err != nil {
+			return nil, err
+		}
+		if comparison != nil {
+			review.Benchmarks = append(review.Benchmarks, comparison)
+		}
+	}
+
+	return review, nil
+}
+
+// addPRs collects the movement and WOD PRs flagged during the year, oldest first
+func (s *YearInReviewService) addPRs(review *YearInReview, userID int64, year int) error {
+	movementPRs, err := s.userWorkoutMovementRepo.GetPRMovements(userID, yearInReviewPRLimit)
+	if err != nil {
+		return err
+	}
+	for _, pr := range movementPRs {
+		if pr.WorkoutDate.UTC().Year() != year {
+			continue
+		}
+		review.PRs = append(review.PRs, &ReviewPR{
+			Date:          pr.WorkoutDate.UTC().Format(domain.ScheduledDateFormat),
+			Kind:          "movement",
+			Name:          pr.MovementName,
+			Result:        movementResultLabel(pr),
+			UserWorkoutID: pr.UserWorkoutID,
+		})
+	}
+
+	wodPRs, err := s.userWorkoutWODRepo.GetPRWODs(userID, yearInReviewPRLimit)
+	if err != nil {
+		return err
+	}
+	for _, pr := range wodPRs {
+		if pr.WorkoutDate.UTC().Year() != year {
+			continue
+		}
+		scoreType := ""
+		if pr.ScoreType != nil {
+			scoreType = *pr.ScoreType
+		}
+		review.PRs = append(review.PRs, &ReviewPR{
+			Date:          pr.WorkoutDate.UTC().Format(domain.ScheduledDateFormat),
+			Kind:          "wod",
+			Name:          pr.WODName,
+			Result:        wodResultLabel(scoreType, pr),
+			UserWorkoutID: pr.UserWorkoutID,
+		})
+	}
+
+	sort.SliceStable(review.PRs, func(i, j int) bool { return review.PRs[i].Date < review.PRs[j].Date })
+	review.PRCount = len(review.PRs)
+	return nil
+}
+
+// compareBenchmark compares the first and last attempts at a WOD within the year
+func (s *YearInReviewService) compareBenchmark(userID int64, start, end time.Time, wod *domain.WODFrequency) (*BenchmarkComparison, error) {
+	history, err := s.userWorkoutWODRepo.GetHistoryForWOD(userID, wod.WODID, &start, &end)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) < 2 {
+		return nil, nil
+	}
+
+	// History is oldest first
+	attempts := len(history)
+	first, last := history[0], history[attempts-1]
+
+	scoreType := wod.ScoreType
+	if scoreType == "" && first.ScoreType != nil {
+		scoreType = *first.ScoreType
+	}
+
+	comparison := &BenchmarkComparison{
+		WODID:     wod.WODID,
+		WODName:   wod.WODName,
+		WODType:   wod.WODType,
+		ScoreType: scoreType,
+		Attempts:  attempts,
+		FirstDate: first.WorkoutDate.UTC().Format(domain.ScheduledDateFormat),
+		First:     wodResultLabel(scoreType, first),
+		LastDate:  last.WorkoutDate.UTC().Format(domain.ScheduledDateFormat),
+		Last:      wodResultLabel(scoreType, last),
+	}
+
+	if scoreType == "Rounds+Reps" {
+		// Without reps per round only the order of results is known
+		fr, fp, lr, lp := intOrZero(first.Rounds), intOrZero(first.Reps), intOrZero(last.Rounds), intOrZero(last.Reps)
+		comparison.Improved = lr > fr || (lr == fr && lp > fp)
+		return comparison, nil
+	}
+
+	firstScore, lastScore := benchmarkScore(scoreType, first, nil), benchmarkScore(scoreType, last, nil)
+	if firstScore != nil && lastScore != nil {
+		delta := roundTenth(*lastScore - *firstScore)
+		comparison.Delta = &delta
+		if _, lowerIsBetter := benchmarkScoreUnit(scoreType); lowerIsBetter {
+			comparison.Improved = delta < 0
+		} else {
+			comparison.Improved = delta > 0
+		}
+	}
+	return comparison, nil
+}
+
+// isBenchmarkWODType reports whether a WOD type is a benchmark worth comparing across the year
+func isBenchmarkWODType(wodType string) bool {
+	for _, benchmark := range []string{"Benchmark", "Girl", "Hero"} {
+		if strings.EqualFold(wodType, benchmark) {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderYearInReviewHTML writes a review as a self-contained HTML page (inline styles, no external assets)
+func RenderYearInReviewHTML(w io.Writer, review *YearInReview) error {
+	return yearInReviewTemplate.Execute(w, review)
+}
+
+func topN[T any](items []T, n int) []T {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
+func intOrZero(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// formatDuration formats seconds as H:MM:SS or M:SS
+func formatDuration(seconds int) string {
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// movementResultLabel describes a movement result (e.g. "315 lb x 1", "50 reps", "7:30")
+func movementResultLabel(m *domain.UserWorkoutMovement) string {
+	switch {
+	case m.Weight != nil && *m.Weight > 0 && m.Reps != nil:
+		return fmt.Sprintf("%g lb x %d", *m.Weight, *m.Reps)
+	case m.Weight != nil && *m.Weight > 0:
+		return fmt.Sprintf("%g lb", *m.Weight)
+	case m.Time != nil:
+		return formatDuration(*m.Time)
+	case m.Reps != nil:
+		return fmt.Sprintf("%d reps", *m.Reps)
+	}
+	return ""
+}
+
+// wodResultLabel describes a WOD result, preferring the stored formatted score
+func wodResultLabel(scoreType string, r *domain.UserWorkoutWOD) string {
+	if r.ScoreValue != nil && *r.ScoreValue != "" {
+		return *r.ScoreValue
+	}
+	def := domain.LookupScoreType(scoreType)
+	if def == nil {
+		def = domain.LookupScoreType(domain.ScoreTypeTime)
+	}
+	return def.Format(r)
+}
+
+// formatThousands formats a number with thousands separators and no decimals
+func formatThousands(v float64) string {
+	s := fmt.Sprintf("%.0f", v)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	if neg {
+		return "-" + s
+	}
+	return s
+}
+
+var yearInReviewTemplate = template.Must(template.New("year_in_review").Funcs(template.FuncMap{
+	"thousands": formatThousands,
+	"int":       func(v int) float64 { return float64(v) },
+	"hours":     func(seconds int) string { return fmt.Sprintf("%.1f", float64(seconds)/3600) },
+	"bar": func(month *ReviewMonth, review *YearInReview) int {
+		if review.BusiestMonth == nil || review.BusiestMonth.Sessions == 0 {
+			return 0
+		}
+		return month.Sessions * 100 / review.BusiestMonth.Sessions
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.UserName}} - {{.Year}} Year in Review - ActaLog</title>
+<style>
+    body { font-family: Arial, sans-serif; line-height: 1.5; color: #333; background: #f5f7fa; margin: 0; }
+    .container { max-width: 800px; margin: 0 auto; padding: 20px; }
+    .header { background-color: #00bcd4; color: white; padding: 24px; text-align: center; border-radius: 8px; }
+    .header h1 { margin: 0; }
+    .stats { display: flex; flex-wrap: wrap; gap: 12px; margin: 20px 0; }
+    .stat { flex: 1 1 150px; background: white; border-radius: 8px; padding: 16px; text-align: center; }
+    .stat .value { font-size: 28px; font-weight: bold; color: #00838f; }
+    .stat .label { font-size: 13px; color: #666; }
+    section { background: white; border-radius: 8px; padding: 16px 20px; margin-bottom: 16px; page-break-inside: avoid; }
+    h2 { font-size: 18px; margin-top: 0; color: #00838f; }
+    table { width: 100%; border-collapse: collapse; font-size: 14px; }
+    th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #eee; }
+    .bar { background: #ffc107; height: 12px; border-radius: 6px; }
+    .better { color: #2e7d32; font-weight: bold; }
+    .footer { text-align: center; font-size: 12px; color: #666; padding: 12px; }
+    @media print { body { background: white; } .stat, section { border: 1px solid #ddd; } }
+</style>
+</head>
+<body>
+<div class="container">
+    <div class="header">
+        <h1>{{.Year}} Year in Review</h1>
+        <p>{{.UserName}}</p>
+    </div>
+
+    <div class="stats">
+        <div class="stat"><div class="value">{{.TotalSessions}}</div><div class="label">Sessions</div></div>
+        <div class="stat"><div class="value">{{.TrainingDays}}</div><div class="label">Training days</div></div>
+        <div class="stat"><div class="value">{{thousands .TotalTonnage}}</div><div class="label">lb lifted</div></div>
+        <div class="stat"><div class="value">{{thousands (int .TotalReps)}}</div><div class="label">Reps</div></div>
+        <div class="stat"><div class="value">{{hours .TotalTime}}</div><div class="label">Hours</div></div>
+        <div class="stat"><div class="value">{{.PRCount}}</div><div class="label">PRs</div></div>
+    </div>
+
+    <section>
+        <h2>Highlights</h2>
+        <p>{{with .LongestStreak}}Longest streak: <strong>{{.Days}} days</strong> ({{.TrainingDays}} training days, {{.StartDate}} to {{.EndDate}}){{else}}No training logged this year{{end}}</p>
+        {{with .BusiestMonth}}<p>Busiest month: <strong>{{.Name}}</strong> with {{.Sessions}} sessions</p>{{end}}
+    </section>
+
+    <section>
+        <h2>Sessions by month</h2>
+        <table>
+        {{range .Months}}<tr><td style="width: 100px">{{.Name}}</td><td><div class="bar" style="width: {{bar . $}}%"></div></td><td style="width: 40px">{{.Sessions}}</td></tr>
+        {{end}}</table>
+    </section>
+
+    {{if .TopMovements}}<section>
+        <h2>Most performed movements</h2>
+        <table>
+            <tr><th>Movement</th><th>Sessions</th><th>Reps</th><th>Tonnage (lb)</th></tr>
+            {{range .TopMovements}}<tr><td>{{.MovementName}}</td><td>{{.Sessions}}</td><td>{{.Reps}}</td><td>{{thousands .Tonnage}}</td></tr>
+            {{end}}
+        </table>
+    </section>{{end}}
+
+    {{if .TopWODs}}<section>
+        <h2>Most performed WODs</h2>
+        <table>
+            <tr><th>WOD</th><th>Type</th><th>Times</th></tr>
+            {{range .TopWODs}}<tr><td>{{.WODName}}</td><td>{{.WODType}}</td><td>{{.Count}}</td></tr>
+            {{end}}
+        </table>
+    </section>{{end}}
+
+    {{if .Benchmarks}}<section>
+        <h2>Benchmarks: first vs last</h2>
+        <table>
+            <tr><th>WOD</th><th>First</th><th>Last</th><th>Attempts</th></tr>
+            {{range .Benchmarks}}<tr><td>{{.WODName}}</td><td>{{.First}} <small>({{.FirstDate}})</small></td><td{{if .Improved}} class="better"{{end}}>{{.Last}} <small>({{.LastDate}})</small></td><td>{{.Attempts}}</td></tr>
+            {{end}}
+        </table>
+    </section>{{end}}
+
+    {{if .PRs}}<section>
+        <h2>Personal records</h2>
+        <table>
+            <tr><th>Date</th><th>Movement / WOD</th><th>Result</th></tr>
+            {{range .PRs}}<tr><td>{{.Date}}</td><td>{{.Name}}</td><td>{{.Result}}</td></tr>
+            {{end}}
+        </table>
+    </section>{{end}}
+
+    <div class="footer">Generated by ActaLog on {{.GeneratedAt.Format "2006-01-02"}}</div>
+</div>
+</body>
+</html>
+`))
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnzastrow/actalog/internal/domain"
+	"github.com/johnzastrow/actalog/internal/repository"
+)
+
+func TestYearInReviewFormatting(t *testing.T) {
+	if got := formatThousands(1234567.4); got != "1,234,567" {
+		t.Errorf("formatThousands = %s, want 1,234,567", got)
+	}
+	if got := formatThousands(999); got != "999" {
+		t.Errorf("formatThousands = %s, want 999", got)
+	}
+	if got := formatDuration(242); got != "4:02" {
+		t.Errorf("formatDuration = %s, want 4:02", got)
+	}
+	if got := formatDuration(3725); got != "1:02:05" {
+		t.Errorf("formatDuration = %s, want 1:02:05", got)
+	}
+
+	rounds, reps := 12, 7
+	if got := wodResultLabel("Rounds+Reps", &domain.UserWorkoutWOD{Rounds: &rounds, Reps: &reps}); got != "12+7" {
+		t.Errorf("wodResultLabel = %s, want 12+7", got)
+	}
+	weight := 315.0
+	if got := movementResultLabel(&domain.UserWorkoutMovement{Weight: &weight, Reps: intPtr(1)}); got != "315 lb x 1" {
+		t.Errorf("movementResultLabel = %s, want 315 lb x 1", got)
+	}
+}
+
+func TestRenderYearInReviewHTML(t *testing.T) {
+	review := &YearInReview{
+		Year:        2025,
+		UserName:    "<script>alert(1)</script>",
+		GeneratedAt: time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC),
+		Months:      []*ReviewMonth{{Month: "2025-01", Name: "January", Sessions: 4}},
+		TopWODs:     []*domain.WODFrequency{{WODName: "Fran", WODType: "Girl", Count: 3}},
+	}
+	review.BusiestMonth = review.Months[0]
+
+	var page bytes.Buffer
+	if err := RenderYearInReviewHTML(&page, review); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	html := page.String()
+	if strings.Contains(html, "<script>") {
+		t.Error("expected the user name to be escaped")
+	}
+	for _, want := range []string{"2025 Year in Review", "Busiest month: <strong>January</strong>", "<td>Fran</td>", "width: 100%"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected page to contain %q", want)
+		}
+	}
+}
+
+func TestYearInReviewComparesBenchmarksOnly(t *testing.T) {
+	db := openTestDB(t)
+	wodRepo := repository.NewWODRepository(db)
+	userRepo := repository.NewSQLiteUserRepository(db)
+	userWorkoutRepo := repository.NewUserWorkoutRepository(db)
+	userWorkoutWODRepo := repository.NewUserWorkoutWODRepository(db)
+
+	day := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	user := &domain.User{Email: "review@example.com", Name: "Review", Role: "user", CreatedAt: day, UpdatedAt: day}
+	if err := userRepo.Create(user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	girl := &domain.WOD{Name: "Review Girl", Source: "CrossFit", Type: "Girl", Regime: "Fastest Time",
+		ScoreType: domain.ScoreTypeTime, IsStandard: true}
+	custom := &domain.WOD{Name: "Review Custom", Source: "Self-recorded", Type: "Self-created", Regime: "Fastest Time",
+		ScoreType: domain.ScoreTypeTime, CreatedBy: &user.ID}
+	for _, wod := range []*domain.WOD{girl, custom} {
+		if err := wodRepo.Create(wod); err != nil {
+			t.Fatalf("failed to create wod: %v", err)
+		}
+	}
+
+	// The December 2025 attempt falls outside the year and must not become the first attempt
+	logs := []struct {
+		wod     *domain.WOD
+		date    time.Time
+		seconds int
+	}{
+		{girl, time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC), 200},
+		{girl, day, 300},
+		{girl, day.AddDate(0, 3, 0), 280},
+		{girl, day.AddDate(0, 6, 0), 260},
+		{custom, day, 600},
+		{custom, day.AddDate(0, 1, 0), 550},
+	}
+	for _, l := range logs {
+		name := l.wod.Name
+		userWorkout := &domain.UserWorkout{UserID: user.ID, WorkoutName: &name, WorkoutDate: l.date}
+		if err := userWorkoutRepo.Create(userWorkout); err != nil {
+			t.Fatalf("failed to log workout: %v", err)
+		}
+		if err := userWorkoutWODRepo.Create(&domain.UserWorkoutWOD{UserWorkoutID: userWorkout.ID, WODID: l.wod.ID,
+			TimeSeconds: intPtr(l.seconds)}); err != nil {
+			t.Fatalf("failed to log result: %v", err)
+		}
+	}
+
+	service := NewYearInReviewService(repository.NewAnalyticsRepository(db), userRepo, userWorkoutRepo,
+		repository.NewUserWorkoutMovementRepository(db), userWorkoutWODRepo)
+	review, err := service.GetYearInReview(user.ID, 2026, 1)
+	if err != nil {
+		t.Fatalf("GetYearInReview failed: %v", err)
+	}
+
+	if len(review.Benchmarks) != 1 {
+		t.Fatalf("expected only the Girl WOD to be compared, got %d comparisons", len(review.Benchmarks))
+	}
+	got := review.Benchmarks[0]
+	if got.WODID != girl.ID || got.Attempts != 3 || got.FirstDate != "2026-01-10" || got.LastDate != "2026-07-10" {
+		t.Errorf("expected three 2026 attempts from 2026-01-10 to 2026-07-10, got %+v", got)
+	}
+	if got.Delta == nil || *got.Delta != -40 || !got.Improved {
+		t.Errorf("expected a 40 second improvement, got %+v", got)
+	}
+}
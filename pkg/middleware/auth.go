@@ -18,6 +18,8 @@ const (
 	UserEmailKey ContextKey = "userEmail"
 	// UserRoleKey is the context key for user role
 	UserRoleKey ContextKey = "userRole"
+	// GymScopeKey is the context key for the gym an admin request is scoped to
+	GymScopeKey ContextKey = "gymScope"
 )
 
 // Auth is a middleware that validates JWT tokens
@@ -76,6 +78,17 @@ func GetUserRole(ctx context.Context) (string, bool) {
 	return role, ok
 }
 
+// WithGymScope scopes an admin request to one gym
+func WithGymScope(ctx context.Context, gymID int64) context.Context {
+	return context.WithValue(ctx, GymScopeKey, gymID)
+}
+
+// GetGymScope extracts the gym an admin request is scoped to; ok is false for unscoped (site-wide) requests
+func GetGymScope(ctx context.Context) (int64, bool) {
+	gymID, ok := ctx.Value(GymScopeKey).(int64)
+	return gymID, ok
+}
+
 // AdminOnly is a middleware that restricts access to admin users only
 func AdminOnly(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
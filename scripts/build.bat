@@ -29,7 +29,7 @@ goto help
 
 :build
 echo Building ActaLog...
-go build -o bin\actalog.exe cmd\actalog\main.go
+go build -tags sqlite_fts5 -o bin\actalog.exe cmd\actalog\main.go
 if %errorlevel% equ 0 (
     echo Build complete: bin\actalog.exe
 ) else (
@@ -40,12 +40,12 @@ goto end
 
 :run
 echo Running ActaLog...
-go run cmd\actalog\main.go
+go run -tags sqlite_fts5 cmd\actalog\main.go
 goto end
 
 :test
 echo Running tests...
-go test -v -race -coverprofile=coverage.out ./...
+go test -tags sqlite_fts5 -v -race -coverprofile=coverage.out ./...
 if %errorlevel% equ 0 (
     go tool cover -html=coverage.out -o coverage.html
     echo Coverage report generated: coverage.html